@@ -90,6 +90,32 @@ func (c *Client) Me() (*User, error) {
 	return user, nil
 }
 
+// Sessions returns the web sessions of the authenticated user.
+func (c *Client) Sessions() (Sessions, error) {
+	body, err := c.request.Get("/v1/me/sessions")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var sessions Sessions
+	if err := json.NewDecoder(body).Decode(&sessions); err != nil {
+		return nil, fmt.Errorf("miniflux: json error (%v)", err)
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession logs out a web session of the authenticated user.
+func (c *Client) DeleteSession(sessionID int64) error {
+	return c.request.Delete(fmt.Sprintf("/v1/me/sessions/%d", sessionID))
+}
+
+// DeleteSessions logs out all web sessions of the authenticated user.
+func (c *Client) DeleteSessions() error {
+	return c.request.Delete("/v1/me/sessions")
+}
+
 // Users returns all users.
 func (c *Client) Users() (Users, error) {
 	body, err := c.request.Get("/v1/users")
@@ -556,6 +582,16 @@ func (c *Client) SaveEntry(entryID int64) error {
 	return err
 }
 
+// SendEntriesToKindle sends a list of entries to the Kindle device configured in the integrations.
+func (c *Client) SendEntriesToKindle(entryIDs []int64) error {
+	type payload struct {
+		EntryIDs []int64 `json:"entry_ids"`
+	}
+
+	_, err := c.request.Post("/v1/entries/kindle", &payload{EntryIDs: entryIDs})
+	return err
+}
+
 // FetchEntryOriginalContent fetches the original content of an entry using the scraper.
 func (c *Client) FetchEntryOriginalContent(entryID int64) (string, error) {
 	body, err := c.request.Get(fmt.Sprintf("/v1/entries/%d/fetch-content", entryID))
@@ -575,6 +611,26 @@ func (c *Client) FetchEntryOriginalContent(entryID int64) (string, error) {
 	return response.Content, nil
 }
 
+// TranslateEntry translates the title and the content of an entry to the language of the user.
+func (c *Client) TranslateEntry(entryID int64) (string, string, error) {
+	body, err := c.request.Post(fmt.Sprintf("/v1/entries/%d/translate", entryID), nil)
+	if err != nil {
+		return "", "", err
+	}
+	defer body.Close()
+
+	var response struct {
+		Title   string `json:"title"`
+		Content string `json:"content"`
+	}
+
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return "", "", fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return response.Title, response.Content, nil
+}
+
 // FetchCounters fetches feed counters.
 func (c *Client) FetchCounters() (*FeedCounters, error) {
 	body, err := c.request.Get("/v1/feeds/counters")
@@ -597,6 +653,42 @@ func (c *Client) FlushHistory() error {
 	return err
 }
 
+// JobQueueStatus returns the state of the background refresh queue (admin only).
+// With several instances, the status is the one of the instance serving the request.
+func (c *Client) JobQueueStatus() (*JobQueueStatus, error) {
+	body, err := c.request.Get("/v1/jobs")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var status JobQueueStatus
+	if err := json.NewDecoder(body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("miniflux: json error (%v)", err)
+	}
+
+	return &status, nil
+}
+
+// FlushJobQueue drops the jobs waiting in the refresh queue (admin only).
+func (c *Client) FlushJobQueue() error {
+	_, err := c.request.Put("/v1/jobs/flush", nil)
+	return err
+}
+
+// PauseScheduler stops the scheduler from refreshing feeds in the background (admin only).
+// With several instances, only the instance serving the request is paused, until it restarts.
+func (c *Client) PauseScheduler() error {
+	_, err := c.request.Put("/v1/scheduler/pause", nil)
+	return err
+}
+
+// ResumeScheduler restarts the background refresh of feeds (admin only).
+func (c *Client) ResumeScheduler() error {
+	_, err := c.request.Put("/v1/scheduler/resume", nil)
+	return err
+}
+
 // Icon fetches a feed icon.
 func (c *Client) Icon(iconID int64) (*FeedIcon, error) {
 	body, err := c.request.Get(fmt.Sprintf("/v1/icons/%d", iconID))
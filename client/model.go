@@ -44,6 +44,10 @@ type User struct {
 	MediaPlaybackRate      float64    `json:"media_playback_rate"`
 	BlockFilterEntryRules  string     `json:"block_filter_entry_rules"`
 	KeepFilterEntryRules   string     `json:"keep_filter_entry_rules"`
+	FullName               string     `json:"full_name"`
+	Email                  string     `json:"email"`
+	IframeAllowedDomains   []string   `json:"iframe_allowed_domains"`
+	RenderMath             bool       `json:"render_math"`
 }
 
 func (u User) String() string {
@@ -86,16 +90,63 @@ type UserModificationRequest struct {
 	MediaPlaybackRate      *float64 `json:"media_playback_rate"`
 	BlockFilterEntryRules  *string  `json:"block_filter_entry_rules"`
 	KeepFilterEntryRules   *string  `json:"keep_filter_entry_rules"`
+	IframeAllowedDomains   []string `json:"iframe_allowed_domains"`
+	RenderMath             *bool    `json:"render_math"`
 }
 
 // Users represents a list of users.
 type Users []User
 
+// Session represents a web session of the user.
+type Session struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+}
+
+// Sessions represents a list of sessions.
+type Sessions []*Session
+
+// JobQueueStatus represents the state of the background refresh queue.
+type JobQueueStatus struct {
+	QueueSize               int64         `json:"queue_size"`
+	NbWorkers               int           `json:"nb_workers"`
+	AliveWorkers            int64         `json:"alive_workers"`
+	SchedulerPaused         bool          `json:"scheduler_paused"`
+	StartedAt               time.Time     `json:"started_at"`
+	LastRefreshAt           *time.Time    `json:"last_refresh_at"`
+	LastSuccessfulRefreshAt *time.Time    `json:"last_successful_refresh_at"`
+	RunningJobs             []*RunningJob `json:"running_jobs"`
+	RecentFailedJobs        []*FailedJob  `json:"recent_failed_jobs"`
+}
+
+// RunningJob represents a feed being refreshed by a worker.
+type RunningJob struct {
+	WorkerID  int       `json:"worker_id"`
+	UserID    int64     `json:"user_id"`
+	FeedID    int64     `json:"feed_id"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// FailedJob represents a feed refresh that returned an error.
+type FailedJob struct {
+	UserID   int64         `json:"user_id"`
+	FeedID   int64         `json:"feed_id"`
+	Error    string        `json:"error"`
+	FailedAt time.Time     `json:"failed_at"`
+	Duration time.Duration `json:"duration"`
+}
+
 // Category represents a feed category.
 type Category struct {
-	ID     int64  `json:"id,omitempty"`
-	Title  string `json:"title,omitempty"`
-	UserID int64  `json:"user_id,omitempty"`
+	ID                   int64  `json:"id,omitempty"`
+	Title                string `json:"title,omitempty"`
+	UserID               int64  `json:"user_id,omitempty"`
+	AutoArchiveAfterDays int    `json:"auto_archive_after_days,omitempty"`
+	AutoArchiveStatus    string `json:"auto_archive_status,omitempty"`
 }
 
 func (c Category) String() string {
@@ -121,32 +172,50 @@ type Subscriptions []*Subscription
 
 // Feed represents a Miniflux feed.
 type Feed struct {
-	ID                          int64     `json:"id"`
-	UserID                      int64     `json:"user_id"`
-	FeedURL                     string    `json:"feed_url"`
-	SiteURL                     string    `json:"site_url"`
-	Title                       string    `json:"title"`
-	CheckedAt                   time.Time `json:"checked_at,omitempty"`
-	EtagHeader                  string    `json:"etag_header,omitempty"`
-	LastModifiedHeader          string    `json:"last_modified_header,omitempty"`
-	ParsingErrorMsg             string    `json:"parsing_error_message,omitempty"`
-	ParsingErrorCount           int       `json:"parsing_error_count,omitempty"`
-	Disabled                    bool      `json:"disabled"`
-	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool      `json:"fetch_via_proxy"`
-	ScraperRules                string    `json:"scraper_rules"`
-	RewriteRules                string    `json:"rewrite_rules"`
-	BlocklistRules              string    `json:"blocklist_rules"`
-	KeeplistRules               string    `json:"keeplist_rules"`
-	Crawler                     bool      `json:"crawler"`
-	UserAgent                   string    `json:"user_agent"`
-	Cookie                      string    `json:"cookie"`
-	Username                    string    `json:"username"`
-	Password                    string    `json:"password"`
-	Category                    *Category `json:"category,omitempty"`
-	HideGlobally                bool      `json:"hide_globally"`
-	DisableHTTP2                bool      `json:"disable_http2"`
+	ID                          int64      `json:"id"`
+	UserID                      int64      `json:"user_id"`
+	FeedURL                     string     `json:"feed_url"`
+	SiteURL                     string     `json:"site_url"`
+	Title                       string     `json:"title"`
+	CheckedAt                   time.Time  `json:"checked_at,omitempty"`
+	EtagHeader                  string     `json:"etag_header,omitempty"`
+	LastModifiedHeader          string     `json:"last_modified_header,omitempty"`
+	ParsingErrorMsg             string     `json:"parsing_error_message,omitempty"`
+	ParsingErrorCount           int        `json:"parsing_error_count,omitempty"`
+	Disabled                    bool       `json:"disabled"`
+	IgnoreHTTPCache             bool       `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool       `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool       `json:"fetch_via_proxy"`
+	ScraperRules                string     `json:"scraper_rules"`
+	RewriteRules                string     `json:"rewrite_rules"`
+	BlocklistRules              string     `json:"blocklist_rules"`
+	KeeplistRules               string     `json:"keeplist_rules"`
+	Crawler                     bool       `json:"crawler"`
+	UserAgent                   string     `json:"user_agent"`
+	Cookie                      string     `json:"cookie"`
+	Username                    string     `json:"username"`
+	Password                    string     `json:"password"`
+	Category                    *Category  `json:"category,omitempty"`
+	HideGlobally                bool       `json:"hide_globally"`
+	DisableHTTP2                bool       `json:"disable_http2"`
+	AppriseServiceURLs          string     `json:"apprise_service_urls"`
+	MatrixBotEnabled            bool       `json:"matrix_bot_enabled"`
+	PollingMinInterval          int        `json:"polling_min_interval"`
+	PollingMaxInterval          int        `json:"polling_max_interval"`
+	RefreshInterval             int        `json:"refresh_interval"`
+	PollingWeekdays             []int64    `json:"polling_weekdays"`
+	PollingWindowStart          string     `json:"polling_window_start"`
+	PollingWindowEnd            string     `json:"polling_window_end"`
+	AutoArchiveAfterDays        int        `json:"auto_archive_after_days"`
+	AutoArchiveStatus           string     `json:"auto_archive_status"`
+	CustomIcon                  bool       `json:"custom_icon"`
+	WebSubLeaseExpiresAt        *time.Time `json:"websub_lease_expires_at,omitempty"`
+	PageMonitorSelector         string     `json:"page_monitor_selector"`
+	RelaxedParsing              bool       `json:"relaxed_parsing"`
+	FetchViaHeadlessBrowser     bool       `json:"fetch_via_headless_browser"`
+	ContentExtractor            string     `json:"content_extractor"`
+	RewriteScript               string     `json:"rewrite_script"`
+	AutoTranslate               bool       `json:"auto_translate"`
 }
 
 // FeedCreationRequest represents the request to create a feed.
@@ -168,6 +237,12 @@ type FeedCreationRequest struct {
 	KeeplistRules               string `json:"keeplist_rules"`
 	HideGlobally                bool   `json:"hide_globally"`
 	DisableHTTP2                bool   `json:"disable_http2"`
+	PageMonitorSelector         string `json:"page_monitor_selector"`
+	RelaxedParsing              bool   `json:"relaxed_parsing"`
+	UseFeedCategory             bool   `json:"use_feed_category"`
+	FetchViaHeadlessBrowser     bool   `json:"fetch_via_headless_browser"`
+	ContentExtractor            string `json:"content_extractor"`
+	RewriteScript               string `json:"rewrite_script"`
 }
 
 // FeedModificationRequest represents the request to update a feed.
@@ -191,6 +266,23 @@ type FeedModificationRequest struct {
 	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
 	HideGlobally                *bool   `json:"hide_globally"`
 	DisableHTTP2                *bool   `json:"disable_http2"`
+	AppriseServiceURLs          *string `json:"apprise_service_urls"`
+	MatrixBotEnabled            *bool   `json:"matrix_bot_enabled"`
+	PollingMinInterval          *int    `json:"polling_min_interval"`
+	PollingMaxInterval          *int    `json:"polling_max_interval"`
+	RefreshInterval             *int    `json:"refresh_interval"`
+	PollingWeekdays             []int64 `json:"polling_weekdays"`
+	PollingWindowStart          *string `json:"polling_window_start"`
+	PollingWindowEnd            *string `json:"polling_window_end"`
+	AutoArchiveAfterDays        *int    `json:"auto_archive_after_days"`
+	AutoArchiveStatus           *string `json:"auto_archive_status"`
+	PageMonitorSelector         *string `json:"page_monitor_selector"`
+	RelaxedParsing              *bool   `json:"relaxed_parsing"`
+	FetchViaHeadlessBrowser     *bool   `json:"fetch_via_headless_browser"`
+	ContentExtractor            *string `json:"content_extractor"`
+	RewriteScript               *string `json:"rewrite_script"`
+	AutoTranslate               *bool   `json:"auto_translate"`
+	IconURL                     *string `json:"icon_url"`
 }
 
 // FeedIcon represents the feed icon.
@@ -210,25 +302,39 @@ type Feeds []*Feed
 
 // Entry represents a subscription item in the system.
 type Entry struct {
-	ID          int64      `json:"id"`
-	Date        time.Time  `json:"published_at"`
-	ChangedAt   time.Time  `json:"changed_at"`
-	CreatedAt   time.Time  `json:"created_at"`
-	Feed        *Feed      `json:"feed,omitempty"`
-	Hash        string     `json:"hash"`
-	URL         string     `json:"url"`
-	CommentsURL string     `json:"comments_url"`
-	Title       string     `json:"title"`
-	Status      string     `json:"status"`
-	Content     string     `json:"content"`
-	Author      string     `json:"author"`
-	ShareCode   string     `json:"share_code"`
-	Enclosures  Enclosures `json:"enclosures,omitempty"`
-	Tags        []string   `json:"tags"`
-	ReadingTime int        `json:"reading_time"`
-	UserID      int64      `json:"user_id"`
-	FeedID      int64      `json:"feed_id"`
-	Starred     bool       `json:"starred"`
+	ID              int64      `json:"id"`
+	Date            time.Time  `json:"published_at"`
+	ChangedAt       time.Time  `json:"changed_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	Feed            *Feed      `json:"feed,omitempty"`
+	Hash            string     `json:"hash"`
+	URL             string     `json:"url"`
+	CommentsURL     string     `json:"comments_url"`
+	CommentsFeedURL string     `json:"comments_feed_url"`
+	ThumbnailURL    string     `json:"thumbnail_url"`
+	Latitude        *float64   `json:"latitude,omitempty"`
+	Longitude       *float64   `json:"longitude,omitempty"`
+	Title           string     `json:"title"`
+	Status          string     `json:"status"`
+	Content         string     `json:"content"`
+	Author          string     `json:"author"`
+	Language        string     `json:"language"`
+	ShareCode       string     `json:"share_code"`
+	Enclosures      Enclosures `json:"enclosures,omitempty"`
+	Tags            []string   `json:"tags"`
+	ReadingTime     int        `json:"reading_time"`
+	UserID          int64      `json:"user_id"`
+	FeedID          int64      `json:"feed_id"`
+	Starred         bool       `json:"starred"`
+	FeedContent     string     `json:"feed_content"`
+
+	TranslatedTitle     string `json:"translated_title"`
+	TranslatedContent   string `json:"translated_content"`
+	TranslationLanguage string `json:"translation_language"`
+
+	PodcastEpisode     int    `json:"podcast_episode"`
+	PodcastSeason      int    `json:"podcast_season"`
+	PodcastEpisodeType string `json:"podcast_episode_type"`
 }
 
 // EntryModificationRequest represents a request to modify an entry.
@@ -248,6 +354,7 @@ type Enclosure struct {
 	URL      string `json:"url"`
 	MimeType string `json:"mime_type"`
 	Size     int    `json:"size"`
+	Duration int64  `json:"duration"`
 }
 
 // Enclosures represents a list of attachments.
@@ -39,6 +39,9 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/users/{userID:[0-9]+}/mark-all-as-read", handler.markUserAsRead).Methods(http.MethodPut)
 	sr.HandleFunc("/users/{username}", handler.userByUsername).Methods(http.MethodGet)
 	sr.HandleFunc("/me", handler.currentUser).Methods(http.MethodGet)
+	sr.HandleFunc("/me/sessions", handler.getSessions).Methods(http.MethodGet)
+	sr.HandleFunc("/me/sessions", handler.removeSessions).Methods(http.MethodDelete)
+	sr.HandleFunc("/me/sessions/{sessionID}", handler.removeSession).Methods(http.MethodDelete)
 	sr.HandleFunc("/categories", handler.createCategory).Methods(http.MethodPost)
 	sr.HandleFunc("/categories", handler.getCategories).Methods(http.MethodGet)
 	sr.HandleFunc("/categories/{categoryID}", handler.updateCategory).Methods(http.MethodPut)
@@ -65,14 +68,20 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/feeds/{feedID}/entries/{entryID}", handler.getFeedEntry).Methods(http.MethodGet)
 	sr.HandleFunc("/entries", handler.getEntries).Methods(http.MethodGet)
 	sr.HandleFunc("/entries", handler.setEntryStatus).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/kindle", handler.sendEntriesToKindle).Methods(http.MethodPost)
 	sr.HandleFunc("/entries/{entryID}", handler.getEntry).Methods(http.MethodGet)
 	sr.HandleFunc("/entries/{entryID}", handler.updateEntry).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}/bookmark", handler.toggleBookmark).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}/save", handler.saveEntry).Methods(http.MethodPost)
 	sr.HandleFunc("/entries/{entryID}/fetch-content", handler.fetchContent).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/{entryID}/translate", handler.translateEntry).Methods(http.MethodPost)
 	sr.HandleFunc("/flush-history", handler.flushHistory).Methods(http.MethodPut, http.MethodDelete)
 	sr.HandleFunc("/icons/{iconID}", handler.getIconByIconID).Methods(http.MethodGet)
 	sr.HandleFunc("/version", handler.versionHandler).Methods(http.MethodGet)
+	sr.HandleFunc("/jobs", handler.jobQueueStatus).Methods(http.MethodGet)
+	sr.HandleFunc("/jobs/flush", handler.flushJobQueue).Methods(http.MethodPut)
+	sr.HandleFunc("/scheduler/pause", handler.pauseScheduler).Methods(http.MethodPut)
+	sr.HandleFunc("/scheduler/resume", handler.resumeScheduler).Methods(http.MethodPut)
 }
 
 func (h *handler) versionHandler(w http.ResponseWriter, r *http.Request) {
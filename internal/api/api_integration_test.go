@@ -160,6 +160,96 @@ func TestGetMeEndpoint(t *testing.T) {
 	}
 }
 
+func TestSessionsEndpoints(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	client := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+	if _, err := client.Sessions(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.DeleteSession(0); err != miniflux.ErrNotFound {
+		t.Fatalf(`Removing an unknown session should return ErrNotFound, got %v`, err)
+	}
+
+	if err := client.DeleteSessions(); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := client.Sessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sessions) != 0 {
+		t.Fatalf(`All sessions should have been removed, got %d`, len(sessions))
+	}
+}
+
+func TestJobQueueEndpoints(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	client := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+	if err := client.PauseScheduler(); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := client.JobQueueStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !status.SchedulerPaused {
+		t.Error(`The scheduler should be paused`)
+	}
+
+	if err := client.ResumeScheduler(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.FlushJobQueue(); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err = client.JobQueueStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.SchedulerPaused {
+		t.Error(`The scheduler should be resumed`)
+	}
+}
+
+func TestJobQueueEndpointsAsRegularUser(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+	if _, err := regularUserClient.JobQueueStatus(); err != miniflux.ErrForbidden {
+		t.Fatalf(`Regular users should not have access to the job queue, got %v`, err)
+	}
+
+	if err := regularUserClient.PauseScheduler(); err != miniflux.ErrForbidden {
+		t.Fatalf(`Regular users should not be able to pause the scheduler, got %v`, err)
+	}
+}
+
 func TestGetUsersEndpointAsAdmin(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
@@ -1255,6 +1345,260 @@ func TestUpdateFeedEndpoint(t *testing.T) {
 	}
 }
 
+func TestUpdateFeedPollingIntervals(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feedUpdateRequest := &miniflux.FeedModificationRequest{
+		PollingMinInterval: miniflux.SetOptionalField(30),
+		PollingMaxInterval: miniflux.SetOptionalField(120),
+	}
+
+	updatedFeed, err := regularUserClient.UpdateFeed(feedID, feedUpdateRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.PollingMinInterval != 30 || updatedFeed.PollingMaxInterval != 120 {
+		t.Fatalf(`Invalid polling intervals, got %d and %d`, updatedFeed.PollingMinInterval, updatedFeed.PollingMaxInterval)
+	}
+
+	feedUpdateRequest = &miniflux.FeedModificationRequest{
+		PollingMinInterval: miniflux.SetOptionalField(240),
+		PollingMaxInterval: miniflux.SetOptionalField(120),
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, feedUpdateRequest); err == nil {
+		t.Fatalf(`A minimum polling interval greater than the maximum should not be allowed`)
+	}
+}
+
+func TestUpdateFeedRefreshInterval(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updatedFeed, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		RefreshInterval: miniflux.SetOptionalField(1440),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.RefreshInterval != 1440 {
+		t.Fatalf(`Invalid refresh interval, got %d`, updatedFeed.RefreshInterval)
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		RefreshInterval: miniflux.SetOptionalField(-1),
+	}); err == nil {
+		t.Fatalf(`A negative refresh interval should not be allowed`)
+	}
+}
+
+func TestUpdateFeedAutoArchiveRule(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feedUpdateRequest := &miniflux.FeedModificationRequest{
+		AutoArchiveAfterDays: miniflux.SetOptionalField(7),
+		AutoArchiveStatus:    miniflux.SetOptionalField("removed"),
+	}
+
+	updatedFeed, err := regularUserClient.UpdateFeed(feedID, feedUpdateRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.AutoArchiveAfterDays != 7 || updatedFeed.AutoArchiveStatus != "removed" {
+		t.Fatalf(`Invalid archive rule, got %d days and status %q`, updatedFeed.AutoArchiveAfterDays, updatedFeed.AutoArchiveStatus)
+	}
+
+	feedUpdateRequest = &miniflux.FeedModificationRequest{
+		AutoArchiveStatus: miniflux.SetOptionalField("unread"),
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, feedUpdateRequest); err == nil {
+		t.Fatalf(`Marking old entries as unread should not be allowed`)
+	}
+
+	feedUpdateRequest = &miniflux.FeedModificationRequest{
+		AutoArchiveAfterDays: miniflux.SetOptionalField(-1),
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, feedUpdateRequest); err == nil {
+		t.Fatalf(`A negative number of days should not be allowed`)
+	}
+}
+
+func TestUpdateFeedCustomIcon(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A 1x1 transparent PNG image.
+	iconURL := "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII="
+	updatedFeed, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		IconURL: miniflux.SetOptionalField(iconURL),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !updatedFeed.CustomIcon {
+		t.Fatalf(`The feed should have a custom icon`)
+	}
+
+	icon, err := regularUserClient.FeedIcon(feedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if icon.MimeType != "image/png" {
+		t.Fatalf(`Invalid icon mime type, got %q`, icon.MimeType)
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		IconURL: miniflux.SetOptionalField("data:text/html,<p>"),
+	}); err == nil {
+		t.Fatalf(`Data URLs that are not images should not be allowed`)
+	}
+}
+
+func TestUpdateFeedPollingWindow(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updatedFeed, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		PollingWeekdays:    []int64{1, 2, 3, 4, 5},
+		PollingWindowStart: miniflux.SetOptionalField("08:00"),
+		PollingWindowEnd:   miniflux.SetOptionalField("18:00"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updatedFeed.PollingWeekdays) != 5 || updatedFeed.PollingWindowStart != "08:00" || updatedFeed.PollingWindowEnd != "18:00" {
+		t.Fatalf(`Invalid polling window, got %v from %q to %q`, updatedFeed.PollingWeekdays, updatedFeed.PollingWindowStart, updatedFeed.PollingWindowEnd)
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		PollingWeekdays: []int64{8},
+	}); err == nil {
+		t.Fatalf(`An invalid day of the week should not be allowed`)
+	}
+
+	updatedFeed, err = regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		PollingWindowStart: miniflux.SetOptionalField("09:00"),
+	})
+	if err != nil {
+		t.Fatalf(`Changing only the start of the polling window should keep the stored end: %v`, err)
+	}
+
+	if updatedFeed.PollingWindowStart != "09:00" || updatedFeed.PollingWindowEnd != "18:00" {
+		t.Fatalf(`Invalid polling window, got from %q to %q`, updatedFeed.PollingWindowStart, updatedFeed.PollingWindowEnd)
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		PollingWindowEnd: miniflux.SetOptionalField(""),
+	}); err == nil {
+		t.Fatalf(`A polling window without end should not be allowed`)
+	}
+}
+
 func TestCannotHaveDuplicateFeedWhenUpdatingFeed(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
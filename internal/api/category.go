@@ -157,7 +157,7 @@ func (h *handler) refreshCategory(w http.ResponseWriter, r *http.Request) {
 		slog.Int("nb_jobs", len(jobs)),
 	)
 
-	go h.pool.Push(jobs)
+	go h.pool.PushPriority(jobs)
 
 	json.NoContent(w, r)
 }
@@ -7,6 +7,7 @@ import (
 	json_parser "encoding/json"
 	"errors"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"miniflux.app/v2/internal/reader/processor"
 	"miniflux.app/v2/internal/reader/readingtime"
 	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/translation"
 	"miniflux.app/v2/internal/urllib"
 	"miniflux.app/v2/internal/validator"
 )
@@ -37,6 +39,8 @@ func (h *handler) getEntryFromBuilder(w http.ResponseWriter, r *http.Request, b
 	}
 
 	entry.Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router, entry.Content)
+	entry.TranslatedContent = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router, entry.TranslatedContent)
+	entry.ThumbnailURL = h.proxifyThumbnailURL(entry.ThumbnailURL)
 	proxyOption := config.Opts.MediaProxyMode()
 
 	for i := range entry.Enclosures {
@@ -53,6 +57,20 @@ func (h *handler) getEntryFromBuilder(w http.ResponseWriter, r *http.Request, b
 	json.OK(w, r, entry)
 }
 
+// proxifyThumbnailURL rewrites the thumbnail URL like the images of the content.
+func (h *handler) proxifyThumbnailURL(thumbnailURL string) string {
+	if thumbnailURL == "" || !slices.Contains(config.Opts.MediaProxyResourceTypes(), "image") {
+		return thumbnailURL
+	}
+
+	proxyOption := config.Opts.MediaProxyMode()
+	if proxyOption == "all" || proxyOption != "none" && !urllib.IsHTTPS(thumbnailURL) {
+		return mediaproxy.ProxifyAbsoluteURL(h.router, thumbnailURL)
+	}
+
+	return thumbnailURL
+}
+
 func (h *handler) getFeedEntry(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
 	entryID := request.RouteInt64Param(r, "entryID")
@@ -165,6 +183,8 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 
 	for i := range entries {
 		entries[i].Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router, entries[i].Content)
+		entries[i].TranslatedContent = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router, entries[i].TranslatedContent)
+		entries[i].ThumbnailURL = h.proxifyThumbnailURL(entries[i].ThumbnailURL)
 	}
 
 	json.OK(w, r, &entriesResponse{Total: count, Entries: entries})
@@ -228,7 +248,51 @@ func (h *handler) saveEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	go integration.SendEntry(entry, settings)
+	go integration.SendEntry(h.store, entry, settings)
+
+	json.Accepted(w, r)
+}
+
+func (h *handler) sendEntriesToKindle(w http.ResponseWriter, r *http.Request) {
+	var entriesKindleRequest model.EntriesKindleRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&entriesKindleRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if err := validator.ValidateEntriesKindleRequest(&entriesKindleRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	settings, err := h.store.Integration(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if !settings.KindleEnabled {
+		json.BadRequest(w, r, errors.New("the Kindle integration is not enabled"))
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(request.UserID(r))
+	builder.WithEntryIDs(entriesKindleRequest.EntryIDs)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSorting("e.published_at", "ASC")
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if len(entries) == 0 {
+		json.NotFound(w, r)
+		return
+	}
+
+	go integration.SendEntriesToKindle(h.store, entries, settings)
 
 	json.Accepted(w, r)
 }
@@ -338,6 +402,61 @@ func (h *handler) fetchContent(w http.ResponseWriter, r *http.Request) {
 	json.OK(w, r, map[string]string{"content": entry.Content})
 }
 
+func (h *handler) translateEntry(w http.ResponseWriter, r *http.Request) {
+	loggedUserID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	translator := translation.NewTranslator()
+	if translator == nil {
+		json.BadRequest(w, r, errors.New("the translation is not configured on this instance"))
+		return
+	}
+
+	entryBuilder := h.store.NewEntryQueryBuilder(loggedUserID)
+	entryBuilder.WithEntryID(entryID)
+	entryBuilder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := entryBuilder.GetEntry()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	user, err := h.store.UserByID(loggedUserID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if entry.TranslationLanguage != user.Language || entry.TranslatedContent == "" {
+		if err := translation.TranslateEntry(translator, entry, user.Language); err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+
+		if err := h.store.UpdateEntryTranslation(entry); err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+	}
+
+	json.OK(w, r, map[string]string{
+		"title":    entry.TranslatedTitle,
+		"content":  mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router, entry.TranslatedContent),
+		"language": entry.TranslationLanguage,
+	})
+}
+
 func (h *handler) flushHistory(w http.ResponseWriter, r *http.Request) {
 	loggedUserID := request.UserID(r)
 	go h.store.FlushHistory(loggedUserID)
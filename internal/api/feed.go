@@ -59,7 +59,7 @@ func (h *handler) refreshFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	localizedError := feedHandler.RefreshFeed(h.store, userID, feedID, false)
+	localizedError := h.pool.RefreshFeed(r.Context(), userID, feedID, false)
 	if localizedError != nil {
 		json.ServerError(w, r, localizedError.Error())
 		return
@@ -89,7 +89,7 @@ func (h *handler) refreshAllFeeds(w http.ResponseWriter, r *http.Request) {
 		slog.Int("nb_jobs", len(jobs)),
 	)
 
-	go h.pool.Push(jobs)
+	go h.pool.PushPriority(jobs)
 
 	json.NoContent(w, r)
 }
@@ -120,6 +120,13 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if feedModificationRequest.IconURL != nil {
+		if err := feedHandler.SetCustomFeedIcon(h.store, userID, feedID, *feedModificationRequest.IconURL); err != nil {
+			json.BadRequest(w, r, err)
+			return
+		}
+	}
+
 	feedModificationRequest.Patch(originalFeed)
 	if err := h.store.UpdateFeed(originalFeed); err != nil {
 		json.ServerError(w, r, err)
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+)
+
+func (h *handler) jobQueueStatus(w http.ResponseWriter, r *http.Request) {
+	if !request.IsAdminUser(r) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	json.OK(w, r, h.pool.Status())
+}
+
+func (h *handler) flushJobQueue(w http.ResponseWriter, r *http.Request) {
+	if !request.IsAdminUser(r) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	h.pool.Flush()
+	slog.Info("Job queue flushed from the API", slog.Int64("user_id", request.UserID(r)))
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) pauseScheduler(w http.ResponseWriter, r *http.Request) {
+	if !request.IsAdminUser(r) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	h.pool.PauseScheduler()
+	slog.Info("Scheduler paused from the API", slog.Int64("user_id", request.UserID(r)))
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) resumeScheduler(w http.ResponseWriter, r *http.Request) {
+	if !request.IsAdminUser(r) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	h.pool.ResumeScheduler()
+	slog.Info("Scheduler resumed from the API", slog.Int64("user_id", request.UserID(r)))
+
+	json.NoContent(w, r)
+}
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
 	"miniflux.app/v2/internal/storage"
@@ -77,7 +78,7 @@ func (m *middleware) apiKeyAuth(next http.Handler) http.Handler {
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, request.UserIDContextKey, user.ID)
 		ctx = context.WithValue(ctx, request.UserTimezoneContextKey, user.Timezone)
-		ctx = context.WithValue(ctx, request.IsAdminUserContextKey, user.IsAdmin)
+		ctx = context.WithValue(ctx, request.IsAdminUserContextKey, user.IsAdmin && isAdminNetwork(clientIP))
 		ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -115,7 +116,37 @@ func (m *middleware) basicAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		locked, err := m.store.IsLoginLocked(
+			username,
+			clientIP,
+			config.Opts.LoginMaxFailedAttempts(),
+			config.Opts.LoginMaxFailedAttemptsPerIP(),
+			config.Opts.LoginLockoutDuration(),
+		)
+		if err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+
+		if locked {
+			slog.Warn("[API] Basic HTTP Authentication refused because of too many failed attempts",
+				slog.Bool("authentication_failed", true),
+				slog.Bool("login_locked", true),
+				slog.String("client_ip", clientIP),
+				slog.String("user_agent", r.UserAgent()),
+				slog.String("username", username),
+			)
+			json.Unauthorized(w, r)
+			return
+		}
+
 		if err := m.store.CheckPassword(username, password); err != nil {
+			if config.Opts.LoginMaxFailedAttempts() > 0 || config.Opts.LoginMaxFailedAttemptsPerIP() > 0 {
+				if err := m.store.CreateLoginFailure(username, clientIP); err != nil {
+					slog.Error("[API] Unable to record failed login", slog.Any("error", err))
+				}
+			}
+
 			slog.Warn("[API] Invalid username or password provided during Basic HTTP Authentication",
 				slog.Bool("authentication_failed", true),
 				slog.String("client_ip", clientIP),
@@ -143,6 +174,17 @@ func (m *middleware) basicAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		if m.store.HasTOTP(user.ID) {
+			slog.Warn("[API] Basic HTTP Authentication is not allowed when two-factor authentication is enabled, an API key must be used",
+				slog.Bool("authentication_failed", true),
+				slog.String("client_ip", clientIP),
+				slog.String("user_agent", r.UserAgent()),
+				slog.String("username", username),
+			)
+			json.Unauthorized(w, r)
+			return
+		}
+
 		slog.Info("[API] User authenticated successfully with the Basic HTTP Authentication",
 			slog.Bool("authentication_successful", true),
 			slog.String("client_ip", clientIP),
@@ -155,9 +197,15 @@ func (m *middleware) basicAuth(next http.Handler) http.Handler {
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, request.UserIDContextKey, user.ID)
 		ctx = context.WithValue(ctx, request.UserTimezoneContextKey, user.Timezone)
-		ctx = context.WithValue(ctx, request.IsAdminUserContextKey, user.IsAdmin)
+		ctx = context.WithValue(ctx, request.IsAdminUserContextKey, user.IsAdmin && isAdminNetwork(clientIP))
 		ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// isAdminNetwork returns true when administrator privileges can be used from the client IP address.
+func isAdminNetwork(clientIP string) bool {
+	networks := config.Opts.AdminAllowedNetworks()
+	return len(networks) == 0 || request.IsIPInNetworks(clientIP, networks)
+}
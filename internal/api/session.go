@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+)
+
+func (h *handler) getSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.store.UserSessions(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if sessions == nil {
+		sessions = model.UserSessions{}
+	}
+
+	json.OK(w, r, sessions)
+}
+
+func (h *handler) removeSession(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	sessionID := request.RouteInt64Param(r, "sessionID")
+
+	if !h.store.UserSessionExists(userID, sessionID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveUserSessionByID(userID, sessionID); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) removeSessions(w http.ResponseWriter, r *http.Request) {
+	// API requests are not tied to a web session, all of them are removed.
+	if _, err := h.store.RemoveOtherUserSessions(request.UserID(r), ""); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
@@ -11,6 +11,7 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/activitypub"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/subscription"
 	"miniflux.app/v2/internal/validator"
@@ -23,6 +24,7 @@ func (h *handler) discoverSubscriptions(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	subscriptionDiscoveryRequest.URL = activitypub.ProfileURL(subscriptionDiscoveryRequest.URL)
 	if validationErr := validator.ValidateSubscriptionDiscovery(&subscriptionDiscoveryRequest); validationErr != nil {
 		json.BadRequest(w, r, validationErr.Error())
 		return
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package backup // import "miniflux.app/v2/internal/backup"
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// archiveVersion is incremented when the archive format changes in a backward incompatible way.
+const archiveVersion = 1
+
+// Archive contains everything needed to recreate a user on another instance.
+type Archive struct {
+	Version      int                `json:"version"`
+	CreatedAt    time.Time          `json:"created_at"`
+	User         *model.User        `json:"user"`
+	PasswordHash string             `json:"password_hash,omitempty"`
+	Integration  *model.Integration `json:"integration"`
+	Categories   model.Categories   `json:"categories"`
+	Feeds        model.Feeds        `json:"feeds"`
+	Icons        []*FeedIcon        `json:"icons"`
+	Entries      model.Entries      `json:"entries"`
+	APIKeys      model.APIKeys      `json:"api_keys,omitempty"`
+}
+
+// FeedIcon is the icon of a feed, the content is included because model.Icon does not serialize it.
+type FeedIcon struct {
+	FeedID   int64  `json:"feed_id"`
+	Hash     string `json:"hash"`
+	MimeType string `json:"mime_type"`
+	Content  []byte `json:"content"`
+}
+
+// Write serializes the archive as gzip-compressed JSON.
+func (a *Archive) Write(w io.Writer) error {
+	gzipWriter := gzip.NewWriter(w)
+	if err := json.NewEncoder(gzipWriter).Encode(a); err != nil {
+		return fmt.Errorf("backup: unable to encode archive: %w", err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("backup: unable to compress archive: %w", err)
+	}
+
+	return nil
+}
+
+// ReadArchive parses an archive created by Write.
+func ReadArchive(r io.Reader) (*Archive, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("backup: unable to decompress archive: %w", err)
+	}
+	defer gzipReader.Close()
+
+	var archive Archive
+	if err := json.NewDecoder(gzipReader).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("backup: unable to decode archive: %w", err)
+	}
+
+	if archive.Version != archiveVersion {
+		return nil, fmt.Errorf("backup: unsupported archive version %d", archive.Version)
+	}
+
+	if archive.User == nil || archive.User.Username == "" {
+		return nil, fmt.Errorf("backup: the archive does not contain any user")
+	}
+
+	return &archive, nil
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package backup // import "miniflux.app/v2/internal/backup"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	archive := &Archive{
+		Version:      archiveVersion,
+		User:         &model.User{ID: 1, Username: "alice", Theme: "dark_serif"},
+		PasswordHash: "$2a$10$hash",
+		Categories:   model.Categories{{ID: 2, Title: "All"}},
+		Feeds:        model.Feeds{{ID: 3, FeedURL: "https://example.org/feed.xml", Category: &model.Category{ID: 2}}},
+		Icons:        []*FeedIcon{{FeedID: 3, Hash: "abc", MimeType: "image/png", Content: []byte{0x89, 0x50}}},
+		Entries:      model.Entries{{ID: 4, FeedID: 3, Status: model.EntryStatusRead, Starred: true, Tags: []string{"go"}}},
+	}
+
+	var buffer bytes.Buffer
+	if err := archive.Write(&buffer); err != nil {
+		t.Fatalf(`Unable to write archive: %v`, err)
+	}
+
+	result, err := ReadArchive(&buffer)
+	if err != nil {
+		t.Fatalf(`Unable to read archive: %v`, err)
+	}
+
+	if result.User.Username != "alice" || result.User.Theme != "dark_serif" || result.PasswordHash != "$2a$10$hash" {
+		t.Errorf(`Unexpected user, got %+v`, result.User)
+	}
+
+	if len(result.Feeds) != 1 || result.Feeds[0].Category.ID != 2 {
+		t.Errorf(`Unexpected feeds, got %+v`, result.Feeds)
+	}
+
+	if len(result.Icons) != 1 || !bytes.Equal(result.Icons[0].Content, []byte{0x89, 0x50}) {
+		t.Errorf(`The icon content should be preserved, got %+v`, result.Icons)
+	}
+
+	if len(result.Entries) != 1 || result.Entries[0].Status != model.EntryStatusRead || !result.Entries[0].Starred {
+		t.Errorf(`The entry status should be preserved, got %+v`, result.Entries)
+	}
+}
+
+func TestReadArchiveWithUnsupportedVersion(t *testing.T) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	gzipWriter.Write([]byte(`{"version": 999, "user": {"username": "alice"}}`))
+	gzipWriter.Close()
+
+	if _, err := ReadArchive(&buffer); err == nil {
+		t.Error(`An archive with an unsupported version should be rejected`)
+	}
+}
+
+func TestReadArchiveWithoutUser(t *testing.T) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	gzipWriter.Write([]byte(`{"version": 1}`))
+	gzipWriter.Close()
+
+	if _, err := ReadArchive(&buffer); err == nil {
+		t.Error(`An archive without user should be rejected`)
+	}
+}
+
+func TestReadArchiveWithInvalidData(t *testing.T) {
+	if _, err := ReadArchive(bytes.NewBufferString("not a gzip stream")); err == nil {
+		t.Error(`Invalid data should be rejected`)
+	}
+}
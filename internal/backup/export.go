@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package backup // import "miniflux.app/v2/internal/backup"
+
+import (
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// Export creates an archive of all the data that belongs to the user.
+func Export(store *storage.Storage, user *model.User, includeAPIKeys bool) (*Archive, error) {
+	archive := &Archive{
+		Version:   archiveVersion,
+		CreatedAt: time.Now(),
+		User:      user,
+	}
+
+	var err error
+	if archive.PasswordHash, err = store.UserPasswordHash(user.ID); err != nil {
+		return nil, err
+	}
+
+	if archive.Integration, err = store.Integration(user.ID); err != nil {
+		return nil, err
+	}
+
+	if archive.Categories, err = store.Categories(user.ID); err != nil {
+		return nil, err
+	}
+
+	if archive.Feeds, err = store.Feeds(user.ID); err != nil {
+		return nil, err
+	}
+
+	for _, feed := range archive.Feeds {
+		if store.HasIcon(feed.ID) {
+			icon, err := store.IconByFeedID(user.ID, feed.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			archive.Icons = append(archive.Icons, &FeedIcon{
+				FeedID:   feed.ID,
+				Hash:     icon.Hash,
+				MimeType: icon.MimeType,
+				Content:  icon.Content,
+			})
+		}
+
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithFeedID(feed.ID)
+		builder.WithEnclosures()
+		builder.WithSorting("e.id", "ASC")
+		entries, err := builder.GetEntries()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			// The feed is already part of the archive.
+			entry.Feed = nil
+		}
+
+		archive.Entries = append(archive.Entries, entries...)
+	}
+
+	if includeAPIKeys {
+		if archive.APIKeys, err = store.APIKeys(user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return archive, nil
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package backup // import "miniflux.app/v2/internal/backup"
+
+import (
+	"fmt"
+	"log/slog"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// Restore recreates the user stored in the archive, the user must not exist on this instance.
+func Restore(store *storage.Storage, archive *Archive) (*model.User, error) {
+	if store.UserExists(archive.User.Username) {
+		return nil, fmt.Errorf("backup: the user %q already exists", archive.User.Username)
+	}
+
+	user, err := store.CreateUser(&model.UserCreationRequest{
+		Username:        archive.User.Username,
+		IsAdmin:         archive.User.IsAdmin,
+		GoogleID:        archive.User.GoogleID,
+		OpenIDConnectID: archive.User.OpenIDConnectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := restoreUser(store, user, archive); err != nil {
+		// Do not leave a partially restored user behind.
+		if removeErr := store.RemoveUser(user.ID); removeErr != nil {
+			slog.Error("Unable to remove partially restored user",
+				slog.Int64("user_id", user.ID),
+				slog.Any("error", removeErr),
+			)
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func restoreUser(store *storage.Storage, user *model.User, archive *Archive) error {
+	settings := *archive.User
+	settings.ID = user.ID
+	settings.Password = ""
+	if err := store.UpdateUser(&settings); err != nil {
+		return err
+	}
+
+	if err := store.SetUserProfile(user.ID, archive.User.FullName, archive.User.Email); err != nil {
+		return err
+	}
+
+	if archive.PasswordHash != "" {
+		if err := store.SetUserPasswordHash(user.ID, archive.PasswordHash); err != nil {
+			return err
+		}
+	}
+
+	if archive.Integration != nil {
+		archive.Integration.UserID = user.ID
+		if err := store.UpdateIntegration(archive.Integration); err != nil {
+			return err
+		}
+	}
+
+	categoryIDs, err := restoreCategories(store, user.ID, archive.Categories)
+	if err != nil {
+		return err
+	}
+
+	feedIDs, err := restoreFeeds(store, user.ID, archive.Feeds, categoryIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, icon := range archive.Icons {
+		feedID, found := feedIDs[icon.FeedID]
+		if !found {
+			continue
+		}
+
+		if err := store.CreateFeedIcon(feedID, &model.Icon{Hash: icon.Hash, MimeType: icon.MimeType, Content: icon.Content}); err != nil {
+			return err
+		}
+	}
+
+	entriesByFeed := make(map[int64]model.Entries)
+	for _, entry := range archive.Entries {
+		feedID, found := feedIDs[entry.FeedID]
+		if !found {
+			continue
+		}
+
+		entry.UserID = user.ID
+		entry.FeedID = feedID
+		for _, enclosure := range entry.Enclosures {
+			enclosure.ID = 0
+		}
+		entriesByFeed[feedID] = append(entriesByFeed[feedID], entry)
+	}
+
+	for _, entries := range entriesByFeed {
+		if err := store.RestoreEntries(entries); err != nil {
+			return err
+		}
+	}
+
+	for _, apiKey := range archive.APIKeys {
+		apiKey.UserID = user.ID
+		if err := store.CreateAPIKey(apiKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreCategories returns the new category IDs indexed by the IDs stored in the archive.
+func restoreCategories(store *storage.Storage, userID int64, categories model.Categories) (map[int64]int64, error) {
+	categoryIDs := make(map[int64]int64, len(categories))
+	for _, archivedCategory := range categories {
+		// The default category is created with the user.
+		category, err := store.CategoryByTitle(userID, archivedCategory.Title)
+		if err != nil {
+			return nil, err
+		}
+
+		if category == nil {
+			category, err = store.CreateCategory(userID, &model.CategoryRequest{Title: archivedCategory.Title})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if archivedCategory.HideGlobally {
+			category.HideGlobally = true
+			if err := store.UpdateCategory(category); err != nil {
+				return nil, err
+			}
+		}
+
+		categoryIDs[archivedCategory.ID] = category.ID
+	}
+
+	return categoryIDs, nil
+}
+
+// restoreFeeds returns the new feed IDs indexed by the IDs stored in the archive.
+func restoreFeeds(store *storage.Storage, userID int64, feeds model.Feeds, categoryIDs map[int64]int64) (map[int64]int64, error) {
+	feedIDs := make(map[int64]int64, len(feeds))
+	for _, feed := range feeds {
+		if feed.Category == nil {
+			return nil, fmt.Errorf("backup: the feed %q does not have any category", feed.FeedURL)
+		}
+
+		categoryID, found := categoryIDs[feed.Category.ID]
+		if !found {
+			return nil, fmt.Errorf("backup: the category of the feed %q is missing", feed.FeedURL)
+		}
+
+		archivedFeedID := feed.ID
+		feed.UserID = userID
+		feed.Category = &model.Category{ID: categoryID}
+		feed.Entries = nil
+		if err := store.CreateFeed(feed); err != nil {
+			return nil, err
+		}
+
+		feedIDs[archivedFeedID] = feed.ID
+	}
+
+	return feedIDs, nil
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache stores frequently read values in Redis to reduce the load on the database.
+package cache // import "miniflux.app/v2/internal/cache"
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	keyPrefix      = "miniflux:"
+	maxIdleConns   = 16
+	networkTimeout = 500 * time.Millisecond
+
+	// retryDelay is the time during which the cache is bypassed after a Redis failure.
+	retryDelay = 30 * time.Second
+
+	// tombstone is stored instead of the removed values, it is never returned to the caller.
+	tombstone = "\x00removed"
+)
+
+// Cache is a Redis client with a small connection pool.
+// A nil cache is valid and always misses. Redis errors are never returned to the caller:
+// the cache is bypassed for a while and the application falls back to the database.
+type Cache struct {
+	addr     string
+	username string
+	password string
+	database int
+	ttl      time.Duration
+	conns    chan *conn
+
+	unavailableUntil atomic.Int64
+}
+
+// New returns a cache for the given Redis URL, for example "redis://:password@localhost:6379/0".
+func New(redisURL string, ttl time.Duration) (*Cache, error) {
+	u, err := url.Parse(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("cache: invalid Redis URL: %v", err)
+	}
+
+	if u.Scheme != "redis" {
+		return nil, fmt.Errorf("cache: unsupported Redis URL scheme %q", u.Scheme)
+	}
+
+	c := &Cache{
+		addr:  u.Host,
+		ttl:   ttl,
+		conns: make(chan *conn, maxIdleConns),
+	}
+
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+	}
+
+	if path := strings.TrimPrefix(u.Path, "/"); path != "" {
+		if c.database, err = strconv.Atoi(path); err != nil {
+			return nil, fmt.Errorf("cache: invalid Redis database %q", path)
+		}
+	}
+
+	return c, nil
+}
+
+// Ping checks if Redis is reachable.
+func (c *Cache) Ping() error {
+	_, err := c.do("PING")
+	return err
+}
+
+// Get returns the value stored under the key.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	if !c.isAvailable() {
+		return nil, false
+	}
+
+	reply, err := c.do("GET", keyPrefix+key)
+	if err != nil {
+		c.markUnavailable(err)
+		return nil, false
+	}
+
+	value, ok := reply.([]byte)
+	if !ok || string(value) == tombstone {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set stores the value under the key, the value expires after the configured TTL.
+func (c *Cache) Set(key string, value []byte) {
+	if !c.isAvailable() {
+		return
+	}
+
+	if _, err := c.do("SET", keyPrefix+key, string(value), "PX", strconv.FormatInt(c.ttl.Milliseconds(), 10)); err != nil {
+		c.markUnavailable(err)
+	}
+}
+
+// Delete removes the keys from the cache.
+// Invalidations are attempted even if Redis was recently unavailable, to avoid keeping stale values.
+func (c *Cache) Delete(keys ...string) {
+	if c == nil || len(keys) == 0 {
+		return
+	}
+
+	args := make([]string, 0, len(keys)+1)
+	args = append(args, "DEL")
+	for _, key := range keys {
+		args = append(args, keyPrefix+key)
+	}
+
+	if _, err := c.do(args...); err != nil {
+		c.markUnavailable(err)
+	}
+}
+
+// Tombstone replaces the values of the keys with a marker that SetJSONIfAbsent cannot overwrite before the TTL expires.
+// It prevents a concurrent read-through from caching again a value that was just removed from the database.
+func (c *Cache) Tombstone(keys ...string) {
+	if c == nil {
+		return
+	}
+
+	ttl := strconv.FormatInt(c.ttl.Milliseconds(), 10)
+	for _, key := range keys {
+		if _, err := c.do("SET", keyPrefix+key, tombstone, "PX", ttl); err != nil {
+			c.markUnavailable(err)
+			return
+		}
+	}
+}
+
+// GetJSON decodes the value stored under the key into v.
+func (c *Cache) GetJSON(key string, v any) bool {
+	data, found := c.Get(key)
+	if !found {
+		return false
+	}
+
+	return json.Unmarshal(data, v) == nil
+}
+
+// SetJSON stores the JSON representation of v under the key.
+func (c *Cache) SetJSON(key string, v any) {
+	if !c.isAvailable() {
+		return
+	}
+
+	if data, err := json.Marshal(v); err == nil {
+		c.Set(key, data)
+	}
+}
+
+// SetJSONIfAbsent stores the JSON representation of v under the key, unless the key already exists or was removed with Tombstone.
+func (c *Cache) SetJSONIfAbsent(key string, v any) {
+	if !c.isAvailable() {
+		return
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	if _, err := c.do("SET", keyPrefix+key, string(data), "NX", "PX", strconv.FormatInt(c.ttl.Milliseconds(), 10)); err != nil {
+		c.markUnavailable(err)
+	}
+}
+
+func (c *Cache) isAvailable() bool {
+	return c != nil && time.Now().UnixNano() >= c.unavailableUntil.Load()
+}
+
+func (c *Cache) markUnavailable(err error) {
+	until := time.Now().Add(retryDelay).UnixNano()
+	if previous := c.unavailableUntil.Swap(until); previous < time.Now().UnixNano() {
+		slog.Warn("Redis is unavailable, falling back to the database",
+			slog.String("redis_addr", c.addr),
+			slog.Duration("retry_delay", retryDelay),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (c *Cache) do(args ...string) (any, error) {
+	cn, err := c.getConn()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := cn.do(args...)
+	if err != nil {
+		if _, isRedisError := err.(redisError); !isRedisError {
+			cn.Close()
+			return nil, err
+		}
+	}
+
+	c.putConn(cn)
+	return reply, err
+}
+
+func (c *Cache) getConn() (*conn, error) {
+	select {
+	case cn := <-c.conns:
+		return cn, nil
+	default:
+	}
+
+	cn, err := dial(c.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.password != "" {
+		args := []string{"AUTH", c.password}
+		if c.username != "" {
+			args = []string{"AUTH", c.username, c.password}
+		}
+
+		if _, err := cn.do(args...); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+
+	if c.database != 0 {
+		if _, err := cn.do("SELECT", strconv.Itoa(c.database)); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+
+	return cn, nil
+}
+
+func (c *Cache) putConn(cn *conn) {
+	select {
+	case c.conns <- cn:
+	default:
+		cn.Close()
+	}
+}
@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache // import "miniflux.app/v2/internal/cache"
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis implements the GET, SET (with NX), DEL, AUTH and PING commands.
+type fakeRedis struct {
+	listener net.Listener
+	password string
+
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &fakeRedis{listener: listener, password: password, values: make(map[string]string)}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeRedis) serve() {
+	for {
+		netConn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(netConn)
+	}
+}
+
+func (s *fakeRedis) handle(netConn net.Conn) {
+	defer netConn.Close()
+	reader := bufio.NewReader(netConn)
+	authenticated := s.password == ""
+
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return
+		}
+
+		var args []string
+		for _, item := range reply.([]any) {
+			args = append(args, string(item.([]byte)))
+		}
+
+		var response string
+		switch command := strings.ToUpper(args[0]); {
+		case command == "AUTH":
+			authenticated = args[len(args)-1] == s.password
+			if authenticated {
+				response = "+OK\r\n"
+			} else {
+				response = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			response = "-NOAUTH Authentication required.\r\n"
+		case command == "PING":
+			response = "+PONG\r\n"
+		case command == "GET":
+			s.mu.Lock()
+			value, found := s.values[args[1]]
+			s.mu.Unlock()
+			if found {
+				response = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			} else {
+				response = "$-1\r\n"
+			}
+		case command == "SET":
+			s.mu.Lock()
+			if _, found := s.values[args[1]]; found && slices.Contains(args[3:], "NX") {
+				response = "$-1\r\n"
+			} else {
+				s.values[args[1]] = args[2]
+				response = "+OK\r\n"
+			}
+			s.mu.Unlock()
+		case command == "DEL":
+			s.mu.Lock()
+			for _, key := range args[1:] {
+				delete(s.values, key)
+			}
+			s.mu.Unlock()
+			response = fmt.Sprintf(":%d\r\n", len(args)-1)
+		default:
+			response = "-ERR unknown command\r\n"
+		}
+
+		netConn.Write([]byte(response))
+	}
+}
+
+func TestNilCacheAlwaysMisses(t *testing.T) {
+	var c *Cache
+	c.Set("key", []byte("value"))
+	c.Delete("key")
+
+	if _, found := c.Get("key"); found {
+		t.Error(`A nil cache should always miss`)
+	}
+}
+
+func TestGetSetDelete(t *testing.T) {
+	server := newFakeRedis(t, "secret")
+
+	c, err := New("redis://:secret@"+server.listener.Addr().String(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Ping(); err != nil {
+		t.Fatalf(`Ping failed: %v`, err)
+	}
+
+	if _, found := c.Get("counter"); found {
+		t.Error(`The key should not exist`)
+	}
+
+	c.SetJSON("counter", map[string]int{"unread": 42})
+
+	var result map[string]int
+	if !c.GetJSON("counter", &result) || result["unread"] != 42 {
+		t.Errorf(`Unexpected value: %v`, result)
+	}
+
+	server.mu.Lock()
+	_, found := server.values[keyPrefix+"counter"]
+	server.mu.Unlock()
+	if !found {
+		t.Error(`The keys should be prefixed`)
+	}
+
+	c.Delete("counter")
+	if _, found := c.Get("counter"); found {
+		t.Error(`The key should be removed`)
+	}
+}
+
+func TestTombstone(t *testing.T) {
+	server := newFakeRedis(t, "")
+
+	c, err := New("redis://"+server.listener.Addr().String(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetJSONIfAbsent("session", map[string]int{"id": 1})
+	c.SetJSONIfAbsent("session", map[string]int{"id": 2})
+
+	var result map[string]int
+	if !c.GetJSON("session", &result) || result["id"] != 1 {
+		t.Errorf(`An existing value should not be replaced, got %v`, result)
+	}
+
+	c.Tombstone("session")
+	if _, found := c.Get("session"); found {
+		t.Error(`A removed key should miss`)
+	}
+
+	c.SetJSONIfAbsent("session", map[string]int{"id": 1})
+	if _, found := c.Get("session"); found {
+		t.Error(`A removed key should not be cached again`)
+	}
+}
+
+func TestWrongPassword(t *testing.T) {
+	server := newFakeRedis(t, "secret")
+
+	c, err := New("redis://:invalid@"+server.listener.Addr().String(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Ping(); err == nil {
+		t.Error(`Ping should fail with an invalid password`)
+	}
+}
+
+func TestFallbackWhenRedisIsUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	c, err := New("redis://"+addr, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("key", []byte("value"))
+	if _, found := c.Get("key"); found {
+		t.Error(`The cache should miss when Redis is unavailable`)
+	}
+
+	if c.isAvailable() {
+		t.Error(`The cache should be bypassed after a failure`)
+	}
+}
+
+func TestNewWithInvalidURL(t *testing.T) {
+	for _, redisURL := range []string{"http://localhost", "redis://localhost/abc"} {
+		if _, err := New(redisURL, time.Minute); err == nil {
+			t.Errorf(`An error should be returned for %q`, redisURL)
+		}
+	}
+}
+
+func TestNewWithDefaultPort(t *testing.T) {
+	c, err := New("redis://localhost/2", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.addr != "localhost:6379" || c.database != 2 {
+		t.Errorf(`Unexpected address %q and database %d`, c.addr, c.database)
+	}
+}
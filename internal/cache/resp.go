@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache // import "miniflux.app/v2/internal/cache"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redisError is an error reply sent by the server, the connection is still usable.
+type redisError string
+
+func (e redisError) Error() string { return "cache: redis error: " + string(e) }
+
+// conn implements the subset of the RESP protocol needed by the cache.
+// See https://redis.io/docs/latest/develop/reference/protocol-spec/.
+type conn struct {
+	netConn net.Conn
+	reader  *bufio.Reader
+	writer  *bufio.Writer
+}
+
+func dial(addr string) (*conn, error) {
+	netConn, err := net.DialTimeout("tcp", addr, networkTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("cache: unable to connect to Redis: %v", err)
+	}
+
+	return &conn{
+		netConn: netConn,
+		reader:  bufio.NewReader(netConn),
+		writer:  bufio.NewWriter(netConn),
+	}, nil
+}
+
+func (c *conn) Close() error {
+	return c.netConn.Close()
+}
+
+func (c *conn) do(args ...string) (any, error) {
+	c.netConn.SetDeadline(time.Now().Add(networkTimeout))
+
+	if err := writeCommand(c.writer, args); err != nil {
+		return nil, fmt.Errorf("cache: unable to send command: %v", err)
+	}
+
+	return readReply(c.reader)
+}
+
+func writeCommand(w *bufio.Writer, args []string) error {
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return w.Flush()
+}
+
+// readReply returns a string for simple strings, an int64 for integers, a []byte for bulk strings,
+// a []any for arrays and nil for null replies.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("cache: unable to read reply: %v", err)
+	}
+
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("cache: empty reply")
+	}
+
+	switch prefix, payload := line[0], line[1:]; prefix {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, redisError(payload)
+	case ':':
+		n, err := strconv.ParseInt(payload, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cache: invalid integer reply: %v", err)
+		}
+		return n, nil
+	case '$':
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("cache: invalid bulk string size: %v", err)
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("cache: unable to read bulk string: %v", err)
+		}
+		return data[:size], nil
+	case '*':
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("cache: invalid array size: %v", err)
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		items := make([]any, 0, size)
+		for range size {
+			item, err := readReply(r)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("cache: unexpected reply type %q", prefix)
+	}
+}
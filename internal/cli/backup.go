@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"fmt"
+	"os"
+
+	"miniflux.app/v2/internal/backup"
+	"miniflux.app/v2/internal/storage"
+)
+
+func backupUser(store *storage.Storage, username string, includeAPIKeys bool) {
+	user, err := store.UserByUsername(username)
+	if err != nil {
+		printErrorAndExit(fmt.Errorf("unable to find user: %w", err))
+	}
+
+	if user == nil {
+		printErrorAndExit(fmt.Errorf("user %q not found", username))
+	}
+
+	archive, err := backup.Export(store, user, includeAPIKeys)
+	if err != nil {
+		printErrorAndExit(fmt.Errorf("unable to create backup: %w", err))
+	}
+
+	if err := archive.Write(os.Stdout); err != nil {
+		printErrorAndExit(err)
+	}
+}
+
+func restoreUser(store *storage.Storage, filename string) {
+	fp, err := os.Open(filename)
+	if err != nil {
+		printErrorAndExit(fmt.Errorf("unable to open backup archive: %w", err))
+	}
+	defer fp.Close()
+
+	archive, err := backup.ReadArchive(fp)
+	if err != nil {
+		printErrorAndExit(err)
+	}
+
+	user, err := backup.Restore(store, archive)
+	if err != nil {
+		printErrorAndExit(fmt.Errorf("unable to restore backup: %w", err))
+	}
+
+	fmt.Printf("User %q restored with %d feeds and %d entries\n", user.Username, len(archive.Feeds), len(archive.Entries))
+}
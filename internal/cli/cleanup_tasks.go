@@ -10,15 +10,20 @@ import (
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/saml"
 	"miniflux.app/v2/internal/storage"
 )
 
 func runCleanupTasks(store *storage.Storage) {
 	nbSessions := store.CleanOldSessions(config.Opts.CleanupRemoveSessionsDays())
 	nbUserSessions := store.CleanOldUserSessions(config.Opts.CleanupRemoveSessionsDays())
+	nbLoginFailures := store.CleanOldLoginFailures(time.Now().Add(-config.Opts.LoginLockoutDuration()))
+	nbSAMLLinkRequests := store.CleanOldSAMLLinkRequests(saml.RequestLifetime)
 	slog.Info("Sessions cleanup completed",
 		slog.Int64("application_sessions_removed", nbSessions),
 		slog.Int64("user_sessions_removed", nbUserSessions),
+		slog.Int64("login_failures_removed", nbLoginFailures),
+		slog.Int64("saml_link_requests_removed", nbSAMLLinkRequests),
 	)
 
 	startTime := time.Now()
@@ -46,4 +51,37 @@ func runCleanupTasks(store *storage.Storage) {
 			metric.ArchiveEntriesDuration.WithLabelValues(model.EntryStatusUnread).Observe(time.Since(startTime).Seconds())
 		}
 	}
+
+	startTime = time.Now()
+	if rowsAffected, err := store.ApplyAutoArchiveRules(config.Opts.CleanupArchiveBatchSize()); err != nil {
+		slog.Error("Unable to apply archive rules", slog.Any("error", err))
+	} else {
+		slog.Info("Applying archive rules completed",
+			slog.Int64("entries_archived", rowsAffected),
+		)
+
+		if config.Opts.HasMetricsCollector() {
+			metric.ArchiveEntriesDuration.WithLabelValues("rules").Observe(time.Since(startTime).Seconds())
+		}
+	}
+
+	if nbScrapedContents, err := store.CleanOldScrapedContents(config.Opts.ScraperCacheTTL()); err != nil {
+		slog.Error("Unable to remove old scraped contents", slog.Any("error", err))
+	} else {
+		slog.Info("Scraped contents cleanup completed", slog.Int64("scraped_contents_removed", nbScrapedContents))
+	}
+
+	if nbIcons, err := store.RemoveOrphanIcons(); err != nil {
+		slog.Error("Unable to remove orphan icons", slog.Any("error", err))
+	} else {
+		slog.Info("Icons cleanup completed", slog.Int64("icons_removed", nbIcons))
+	}
+
+	if config.Opts.HasIconStorage() {
+		if nbIcons, err := store.MoveIconsToIconStore(config.Opts.BatchSize()); err != nil {
+			slog.Error("Unable to move icons to the icon storage", slog.Any("error", err))
+		} else if nbIcons > 0 {
+			slog.Info("Icons moved to the icon storage", slog.Int("icons_moved", nbIcons))
+		}
+	}
 }
@@ -10,9 +10,12 @@ import (
 	"log/slog"
 	"os"
 
+	"miniflux.app/v2/internal/cache"
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/database"
+	"miniflux.app/v2/internal/iconstore"
 	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/reader/siterules"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/ui/static"
 	"miniflux.app/v2/internal/version"
@@ -24,15 +27,23 @@ const (
 	flagMigrateHelp         = "Run SQL migrations"
 	flagFlushSessionsHelp   = "Flush all sessions (disconnect users)"
 	flagCreateAdminHelp     = "Create an admin user from an interactive terminal"
+	flagCreateUsersHelp     = "Create users from a CSV file with the columns username, password, admin and OPML file"
 	flagResetPasswordHelp   = "Reset user password"
 	flagResetFeedErrorsHelp = "Clear all feed errors for all users"
 	flagDebugModeHelp       = "Show debug logs"
 	flagConfigFileHelp      = "Load configuration file"
 	flagConfigDumpHelp      = "Print parsed configuration values"
 	flagHealthCheckHelp     = `Perform a health check on the given endpoint (the value "auto" try to guess the health check endpoint).`
+	flagHealthCheckDeepHelp = "Check the database, migrations, scheduler, workers and feed refreshes during the health check and print the report"
 	flagRefreshFeedsHelp    = "Refresh a batch of feeds and exit"
 	flagRunCleanupTasksHelp = "Run cleanup tasks (delete old sessions and archives old entries)"
+	flagRefreshFeedHelp     = "Refresh a feed (provide the feed ID as argument)"
+	flagRefreshUserHelp     = "Refresh the feeds of a user (provide the username as argument)"
+	flagRefreshWaitHelp     = "Wait for the end of the refresh started by -refresh-feed or -refresh-user and print a summary"
 	flagExportUserFeedsHelp = "Export user feeds (provide the username as argument)"
+	flagBackupHelp          = "Write a backup archive of the user to stdout (provide the username as argument)"
+	flagBackupNoAPIKeysHelp = "Exclude API keys from the backup archive"
+	flagRestoreHelp         = "Restore a user from a backup archive (provide the file as argument)"
 )
 
 // Parse parses command line arguments.
@@ -44,15 +55,23 @@ func Parse() {
 		flagMigrate         bool
 		flagFlushSessions   bool
 		flagCreateAdmin     bool
+		flagCreateUsers     string
 		flagResetPassword   bool
 		flagResetFeedErrors bool
 		flagDebugMode       bool
 		flagConfigFile      string
 		flagConfigDump      bool
 		flagHealthCheck     string
+		flagHealthCheckDeep bool
 		flagRefreshFeeds    bool
 		flagRunCleanupTasks bool
+		flagRefreshFeed     int64
+		flagRefreshUser     string
+		flagRefreshWait     bool
 		flagExportUserFeeds string
+		flagBackup          string
+		flagBackupNoAPIKeys bool
+		flagRestore         string
 	)
 
 	flag.BoolVar(&flagInfo, "info", false, flagInfoHelp)
@@ -62,6 +81,7 @@ func Parse() {
 	flag.BoolVar(&flagMigrate, "migrate", false, flagMigrateHelp)
 	flag.BoolVar(&flagFlushSessions, "flush-sessions", false, flagFlushSessionsHelp)
 	flag.BoolVar(&flagCreateAdmin, "create-admin", false, flagCreateAdminHelp)
+	flag.StringVar(&flagCreateUsers, "create-users", "", flagCreateUsersHelp)
 	flag.BoolVar(&flagResetPassword, "reset-password", false, flagResetPasswordHelp)
 	flag.BoolVar(&flagResetFeedErrors, "reset-feed-errors", false, flagResetFeedErrorsHelp)
 	flag.BoolVar(&flagDebugMode, "debug", false, flagDebugModeHelp)
@@ -69,21 +89,19 @@ func Parse() {
 	flag.StringVar(&flagConfigFile, "c", "", flagConfigFileHelp)
 	flag.BoolVar(&flagConfigDump, "config-dump", false, flagConfigDumpHelp)
 	flag.StringVar(&flagHealthCheck, "healthcheck", "", flagHealthCheckHelp)
+	flag.BoolVar(&flagHealthCheckDeep, "healthcheck-deep", false, flagHealthCheckDeepHelp)
 	flag.BoolVar(&flagRefreshFeeds, "refresh-feeds", false, flagRefreshFeedsHelp)
 	flag.BoolVar(&flagRunCleanupTasks, "run-cleanup-tasks", false, flagRunCleanupTasksHelp)
+	flag.Int64Var(&flagRefreshFeed, "refresh-feed", 0, flagRefreshFeedHelp)
+	flag.StringVar(&flagRefreshUser, "refresh-user", "", flagRefreshUserHelp)
+	flag.BoolVar(&flagRefreshWait, "refresh-wait", false, flagRefreshWaitHelp)
 	flag.StringVar(&flagExportUserFeeds, "export-user-feeds", "", flagExportUserFeedsHelp)
+	flag.StringVar(&flagBackup, "backup", "", flagBackupHelp)
+	flag.BoolVar(&flagBackupNoAPIKeys, "backup-exclude-api-keys", false, flagBackupNoAPIKeysHelp)
+	flag.StringVar(&flagRestore, "restore", "", flagRestoreHelp)
 	flag.Parse()
 
-	cfg := config.NewParser()
-
-	if flagConfigFile != "" {
-		config.Opts, err = cfg.ParseFile(flagConfigFile)
-		if err != nil {
-			printErrorAndExit(err)
-		}
-	}
-
-	config.Opts, err = cfg.ParseEnvironmentVariables()
+	config.Opts, err = parseConfig(flagConfigFile)
 	if err != nil {
 		printErrorAndExit(err)
 	}
@@ -117,7 +135,7 @@ func Parse() {
 	}
 
 	if flagHealthCheck != "" {
-		doHealthCheck(flagHealthCheck)
+		doHealthCheck(flagHealthCheck, flagHealthCheckDeep)
 		return
 	}
 
@@ -168,6 +186,28 @@ func Parse() {
 		printErrorAndExit(err)
 	}
 
+	if config.Opts.HasRedisCache() {
+		redisCache, err := cache.New(config.Opts.RedisURL(), config.Opts.RedisCacheTTL())
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		if err := redisCache.Ping(); err != nil {
+			slog.Warn("Redis is not reachable, the database will be used until it becomes available", slog.Any("error", err))
+		}
+
+		store.UseCache(redisCache)
+	}
+
+	if config.Opts.HasIconStorage() {
+		iconStore, err := iconstore.New(config.Opts.IconStorageURL())
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		store.UseIconStore(iconStore)
+	}
+
 	if flagMigrate {
 		if err := database.Migrate(db); err != nil {
 			printErrorAndExit(err)
@@ -211,10 +251,32 @@ func Parse() {
 		printErrorAndExit(err)
 	}
 
+	if flagCreateUsers != "" {
+		createUsersFromCSV(store, flagCreateUsers)
+		return
+	}
+
+	if flagBackup != "" {
+		backupUser(store, flagBackup, !flagBackupNoAPIKeys)
+		return
+	}
+
+	if flagRestore != "" {
+		restoreUser(store, flagRestore)
+		return
+	}
+
 	if config.Opts.CreateAdmin() {
 		createAdminUserFromEnvironmentVariables(store)
 	}
 
+	// The site rules are not mandatory, the built-in rules are used when the file cannot be loaded.
+	if config.Opts.SiteRulesFile() != "" {
+		if err := siterules.LoadFromSource(config.Opts.SiteRulesFile()); err != nil {
+			slog.Error("Unable to load the site rules", slog.Any("error", err))
+		}
+	}
+
 	if flagRefreshFeeds {
 		refreshFeeds(store)
 		return
@@ -225,7 +287,12 @@ func Parse() {
 		return
 	}
 
-	startDaemon(store)
+	if flagRefreshFeed > 0 || flagRefreshUser != "" {
+		refreshSelectedFeeds(store, flagRefreshFeed, flagRefreshUser, flagRefreshWait)
+		return
+	}
+
+	startDaemon(store, flagConfigFile, flagDebugMode)
 }
 
 func printErrorAndExit(err error) {
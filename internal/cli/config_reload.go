@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+)
+
+// parseConfig loads the configuration file, if any, and then the environment variables.
+func parseConfig(configFile string) (*config.Options, error) {
+	parser := config.NewParser()
+
+	if configFile != "" {
+		if _, err := parser.ParseFile(configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return parser.ParseEnvironmentVariables()
+}
+
+// reloadConfig parses the configuration again and applies the options that are safe to change at runtime.
+// The current configuration is kept when the new one is invalid.
+func reloadConfig(configFile string, debugMode bool) {
+	slog.Info("Reloading configuration", slog.String("config_file", configFile))
+
+	newOpts, err := parseConfig(configFile)
+	if err != nil {
+		slog.Error("Unable to reload configuration", slog.Any("error", err))
+		return
+	}
+
+	if debugMode {
+		newOpts.SetLogLevel("debug")
+	}
+
+	changes := config.Opts.Reload(newOpts)
+	setLogLevel(config.Opts.LogLevel())
+
+	slog.Info("Configuration reloaded", slog.Any("changed_options", changes))
+}
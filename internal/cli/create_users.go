@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/opml"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/validator"
+)
+
+// userProvisioningRow is a line of the CSV file given to -create-users.
+type userProvisioningRow struct {
+	line              int
+	username          string
+	password          string
+	generatedPassword bool
+	isAdmin           bool
+	opmlFile          string
+}
+
+// createUsersFromCSV provisions the accounts listed in the CSV file.
+// Each line contains the username, the password (empty or "generate" for a random one),
+// the admin flag and an optional OPML file to import. Generated passwords are printed to stdout.
+func createUsersFromCSV(store *storage.Storage, filename string) {
+	fp, err := os.Open(filename)
+	if err != nil {
+		printErrorAndExit(fmt.Errorf("unable to open CSV file: %w", err))
+	}
+	defer fp.Close()
+
+	rows, err := parseUserProvisioningCSV(fp)
+	if err != nil {
+		printErrorAndExit(err)
+	}
+
+	// Validate everything before creating the first user to avoid partial rollouts.
+	var validationErrors []error
+	for _, row := range rows {
+		if store.UserExists(row.username) {
+			continue
+		}
+
+		request := &model.UserCreationRequest{Username: row.username, Password: row.password, IsAdmin: row.isAdmin}
+		if validationErr := validator.ValidateUserCreationWithPassword(store, request); validationErr != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("line %d: %w", row.line, validationErr.Error()))
+		}
+
+		if row.opmlFile != "" {
+			if _, err := os.Stat(row.opmlFile); err != nil {
+				validationErrors = append(validationErrors, fmt.Errorf("line %d: %w", row.line, err))
+			}
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		printErrorAndExit(errors.Join(validationErrors...))
+	}
+
+	created := 0
+	for _, row := range rows {
+		if store.UserExists(row.username) {
+			slog.Info("Skipping user creation because it already exists",
+				slog.String("username", row.username),
+			)
+			continue
+		}
+
+		user, err := store.CreateUser(&model.UserCreationRequest{Username: row.username, Password: row.password, IsAdmin: row.isAdmin})
+		if err != nil {
+			printErrorAndExit(fmt.Errorf("line %d: %w", row.line, err))
+		}
+
+		slog.Info("Created new user",
+			slog.String("username", user.Username),
+			slog.Int64("user_id", user.ID),
+			slog.Bool("is_admin", user.IsAdmin),
+		)
+		created++
+
+		if row.opmlFile != "" {
+			if err := importUserOPMLFile(store, user.ID, row.opmlFile); err != nil {
+				slog.Error("Unable to import OPML file",
+					slog.String("username", user.Username),
+					slog.String("opml_file", row.opmlFile),
+					slog.Any("error", err),
+				)
+			}
+		}
+
+		if row.generatedPassword {
+			fmt.Printf("%s,%s\n", user.Username, row.password)
+		}
+	}
+
+	slog.Info("Users provisioned",
+		slog.Int("nb_rows", len(rows)),
+		slog.Int("nb_created", created),
+	)
+}
+
+func parseUserProvisioningCSV(r io.Reader) ([]*userProvisioningRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rows []*userProvisioningRow
+	seen := make(map[string]bool)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse CSV file: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(rows) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "username") {
+			continue
+		}
+
+		if len(record) > 4 {
+			return nil, fmt.Errorf("line %d: too many fields", line)
+		}
+
+		record = append(record, make([]string, 4-len(record))...)
+		row := &userProvisioningRow{
+			line:     line,
+			username: strings.ToLower(strings.TrimSpace(record[0])),
+			password: record[1],
+			isAdmin:  isTrueValue(record[2]),
+			opmlFile: strings.TrimSpace(record[3]),
+		}
+
+		if row.username == "" {
+			return nil, fmt.Errorf("line %d: the username is empty", line)
+		}
+
+		if seen[row.username] {
+			return nil, fmt.Errorf("line %d: the username %q is duplicated", line, row.username)
+		}
+		seen[row.username] = true
+
+		if row.password == "" || row.password == "generate" {
+			row.password = crypto.GenerateRandomStringHex(10)
+			row.generatedPassword = true
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func importUserOPMLFile(store *storage.Storage, userID int64, filename string) error {
+	fp, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+
+	return opml.NewHandler(store).Import(userID, fp)
+}
+
+func isTrueValue(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "yes", "true", "on", "admin":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseUserProvisioningCSV(t *testing.T) {
+	input := `username,password,admin,opml
+# Comments are ignored
+alice,"secret, with ""quotes""",yes,/tmp/alice.opml
+ Bob ,generate,no
+"carol"
+dave,,1,
+`
+
+	rows, err := parseUserProvisioningCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 4 {
+		t.Fatalf(`Four users should be parsed, got %d`, len(rows))
+	}
+
+	alice := rows[0]
+	if alice.username != "alice" || alice.password != `secret, with "quotes"` || !alice.isAdmin || alice.opmlFile != "/tmp/alice.opml" || alice.generatedPassword {
+		t.Errorf(`Unexpected quoted row, got %+v`, alice)
+	}
+
+	if alice.line != 3 {
+		t.Errorf(`The line number should be reported, got %d`, alice.line)
+	}
+
+	for _, row := range rows[1:] {
+		if !row.generatedPassword || len(row.password) != 20 {
+			t.Errorf(`A password should be generated for %q, got %+v`, row.username, row)
+		}
+	}
+
+	if rows[1].username != "bob" || rows[1].isAdmin {
+		t.Errorf(`The username should be trimmed and lowercased, got %+v`, rows[1])
+	}
+
+	if rows[2].username != "carol" || rows[2].isAdmin || rows[2].opmlFile != "" {
+		t.Errorf(`The missing columns should use the default values, got %+v`, rows[2])
+	}
+
+	if !rows[3].isAdmin {
+		t.Errorf(`The user should be an administrator, got %+v`, rows[3])
+	}
+
+	if rows[1].password == rows[2].password {
+		t.Error(`The generated passwords should be different`)
+	}
+}
+
+func TestParseUserProvisioningCSVErrors(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"duplicated username", "alice,secret\nbob,secret\nAlice,other\n", `line 3: the username "alice" is duplicated`},
+		{"empty username", "alice,secret\n ,secret\n", "line 2: the username is empty"},
+		{"too many fields", "alice,secret,no,/tmp/alice.opml,extra\n", "line 1: too many fields"},
+		{"unterminated quote", "alice,\"secret\n", "unable to parse CSV file"},
+	}
+
+	for _, scenario := range scenarios {
+		_, err := parseUserProvisioningCSV(strings.NewReader(scenario.input))
+		if err == nil {
+			t.Errorf(`%s: an error should be returned`, scenario.name)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), scenario.expected) {
+			t.Errorf(`%s: the error should contain %q, got %q`, scenario.name, scenario.expected, err)
+		}
+	}
+}
+
+func TestParseUserProvisioningCSVWithoutHeader(t *testing.T) {
+	rows, err := parseUserProvisioningCSV(strings.NewReader("alice,secret\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 1 || rows[0].username != "alice" || rows[0].password != "secret" {
+		t.Errorf(`The first line should be a user when there is no header, got %+v`, rows)
+	}
+}
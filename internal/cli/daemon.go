@@ -13,21 +13,36 @@ import (
 	"time"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/health"
 	httpd "miniflux.app/v2/internal/http/server"
 	"miniflux.app/v2/internal/metric"
+	"miniflux.app/v2/internal/newsletter"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/systemd"
+	"miniflux.app/v2/internal/tracing"
 	"miniflux.app/v2/internal/worker"
 )
 
-func startDaemon(store *storage.Storage) {
+func startDaemon(store *storage.Storage, configFile string, debugMode bool) {
 	slog.Debug("Starting daemon...")
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 	signal.Notify(stop, syscall.SIGTERM)
 
-	pool := worker.NewPool(store, config.Opts.WorkerPoolSize())
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			reloadConfig(configFile, debugMode)
+		}
+	}()
+
+	if config.Opts.HasTracing() {
+		tracing.Initialize(config.Opts.OTelExporterOTLPEndpoint(), config.Opts.OTelServiceName(), config.Opts.OTelExporterOTLPHeaders())
+	}
+
+	pool := worker.NewPool(store, config.Opts.WorkerPoolSize(), config.Opts.WorkerPoolHostConcurrency())
 
 	if config.Opts.HasSchedulerService() && !config.Opts.HasMaintenanceMode() {
 		runScheduler(store, pool)
@@ -38,6 +53,15 @@ func startDaemon(store *storage.Storage) {
 		httpServer = httpd.StartWebServer(store, pool)
 	}
 
+	var newsletterServer *newsletter.Server
+	if config.Opts.HasNewsletterService() {
+		var err error
+		newsletterServer, err = newsletter.StartServer(store, config.Opts.NewsletterListenAddr(), config.Opts.NewsletterDomain())
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	}
+
 	if config.Opts.HasMetricsCollector() {
 		collector := metric.NewCollector(store, config.Opts.MetricsRefreshInterval())
 		go collector.GatherStorageMetrics()
@@ -60,9 +84,10 @@ func startDaemon(store *storage.Storage) {
 					return
 				}
 
+				// The watchdog is not notified when the process is not ready, so systemd restarts a wedged instance.
 				for {
-					if err := store.Ping(); err != nil {
-						slog.Error("Unable to ping database", slog.Any("error", err))
+					if err := health.CheckReadiness(store); err != nil {
+						slog.Error("Readiness check failed, skipping watchdog notification", slog.Any("error", err))
 					} else {
 						systemd.SdNotify(systemd.SdNotifyWatchdog)
 					}
@@ -75,12 +100,27 @@ func startDaemon(store *storage.Storage) {
 
 	<-stop
 	slog.Debug("Shutting down the process")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if systemd.HasNotifySocket() {
+		systemd.SdNotify(systemd.SdNotifyStopping)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(config.Opts.ShutdownTimeout())*time.Second)
 	defer cancel()
 
+	// The HTTP server is stopped first because handlers can push jobs to the workers.
 	if httpServer != nil {
-		httpServer.Shutdown(ctx)
+		if err := httpServer.Shutdown(ctx); err != nil {
+			slog.Warn("Unable to drain HTTP connections before the shutdown deadline", slog.Any("error", err))
+		}
 	}
 
+	if newsletterServer != nil {
+		newsletterServer.Close()
+	}
+
+	pool.Shutdown(ctx)
+
+	tracing.Shutdown(ctx)
+
 	slog.Debug("Process gracefully stopped")
 }
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"log/slog"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/integration/emaildigest"
+	"miniflux.app/v2/internal/mail"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/timezone"
+)
+
+func runEmailDigestTask(store *storage.Storage) {
+	if !config.Opts.HasSMTP() {
+		return
+	}
+
+	userIDs, err := store.EmailDigestUserIDs()
+	if err != nil {
+		slog.Error("Unable to fetch email digest users", slog.Any("error", err))
+		return
+	}
+
+	sender := mail.NewDefaultSender()
+
+	for _, userID := range userIDs {
+		user, err := store.UserByID(userID)
+		if err != nil || user == nil {
+			slog.Error("Unable to fetch user for email digest", slog.Int64("user_id", userID), slog.Any("error", err))
+			continue
+		}
+
+		userIntegrations, err := store.Integration(userID)
+		if err != nil {
+			slog.Error("Unable to fetch integrations for email digest", slog.Int64("user_id", userID), slog.Any("error", err))
+			continue
+		}
+
+		sentAt, err := store.EmailDigestSentAt(userID)
+		if err != nil {
+			slog.Error("Unable to fetch last email digest date", slog.Int64("user_id", userID), slog.Any("error", err))
+			continue
+		}
+
+		now := time.Now()
+		localNow := timezone.Now(user.Timezone)
+		if !emaildigest.IsDue(localNow, sentAt, userIntegrations.EmailDigestFrequency, userIntegrations.EmailDigestHour, userIntegrations.EmailDigestWeekday) {
+			continue
+		}
+
+		since := now.Add(-emaildigest.Period(userIntegrations.EmailDigestFrequency))
+		if sentAt.After(since) {
+			since = sentAt
+		}
+
+		builder := store.NewEntryQueryBuilder(userID)
+		if userIntegrations.EmailDigestContent == model.EmailDigestContentStarred {
+			builder.WithStarred(true)
+			builder.WithoutStatus(model.EntryStatusRemoved)
+			builder.AfterChangedDate(since)
+		} else {
+			builder.WithStatus(model.EntryStatusUnread)
+			builder.AfterCreatedDate(since)
+		}
+		builder.WithSorting("c.title", "ASC")
+		builder.WithSorting("e.published_at", "DESC")
+		builder.WithLimit(emaildigest.MaxEntries)
+
+		entries, err := builder.GetEntries()
+		if err != nil {
+			slog.Error("Unable to fetch entries for email digest", slog.Int64("user_id", userID), slog.Any("error", err))
+			continue
+		}
+
+		// The date is only moved forward once the digest is sent, the entries are sent again on the next run otherwise.
+		if len(entries) > 0 {
+			message, err := emaildigest.NewMessage(userIntegrations.EmailDigestAddress, user.Language, userIntegrations.EmailDigestFrequency, localNow, entries)
+			if err != nil {
+				slog.Error("Unable to build email digest", slog.Int64("user_id", userID), slog.Any("error", err))
+				continue
+			}
+
+			slog.Debug("Sending email digest",
+				slog.Int64("user_id", userID),
+				slog.Int("nb_entries", len(entries)),
+			)
+
+			if err := sender.Send(message); err != nil {
+				slog.Error("Unable to send email digest", slog.Int64("user_id", userID), slog.Any("error", err))
+				continue
+			}
+		}
+
+		if err := store.SetEmailDigestSentAt(userID, now); err != nil {
+			slog.Error("Unable to update email digest date", slog.Int64("user_id", userID), slog.Any("error", err))
+		}
+	}
+}
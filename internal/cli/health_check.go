@@ -5,18 +5,29 @@ package cli // import "miniflux.app/v2/internal/cli"
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"miniflux.app/v2/internal/config"
 )
 
-func doHealthCheck(healthCheckEndpoint string) {
+func doHealthCheck(healthCheckEndpoint string, deep bool) {
 	if healthCheckEndpoint == "auto" {
 		healthCheckEndpoint = "http://" + config.Opts.ListenAddr() + config.Opts.BasePath() + "/healthcheck"
 	}
 
+	if deep {
+		if strings.Contains(healthCheckEndpoint, "?") {
+			healthCheckEndpoint += "&deep=1"
+		} else {
+			healthCheckEndpoint += "?deep=1"
+		}
+	}
+
 	slog.Debug("Executing health check request", slog.String("endpoint", healthCheckEndpoint))
 
 	client := &http.Client{Timeout: 3 * time.Second}
@@ -26,6 +37,11 @@ func doHealthCheck(healthCheckEndpoint string) {
 	}
 	defer resp.Body.Close()
 
+	if deep {
+		io.Copy(os.Stdout, resp.Body)
+		fmt.Println()
+	}
+
 	if resp.StatusCode != 200 {
 		printErrorAndExit(fmt.Errorf(`health check failed with status code %d`, resp.StatusCode))
 	}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/storage"
+)
+
+func runIconRefreshTask(store *storage.Storage) {
+	jobs, err := store.FeedsWithStaleIcon(config.Opts.IconRefreshDays(), config.Opts.BatchSize())
+	if err != nil {
+		slog.Error("Unable to fetch feeds with stale icon", slog.Any("error", err))
+		return
+	}
+
+	for _, job := range jobs {
+		if err := feedHandler.RefreshFeedIcon(store, job.UserID, job.FeedID); err != nil {
+			slog.Debug("Unable to refresh feed icon",
+				slog.Int64("user_id", job.UserID),
+				slog.Int64("feed_id", job.FeedID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	if len(jobs) > 0 {
+		slog.Info("Feed icons refreshed", slog.Int("nb_feeds", len(jobs)))
+	}
+}
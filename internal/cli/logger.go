@@ -4,22 +4,18 @@
 package cli // import "miniflux.app/v2/internal/cli"
 
 import (
+	"context"
 	"io"
 	"log/slog"
+
+	"miniflux.app/v2/internal/http/request"
 )
 
+// programLogLevel is shared by all log handlers to allow changing the log level at runtime.
+var programLogLevel = new(slog.LevelVar)
+
 func InitializeDefaultLogger(logLevel string, logFile io.Writer, logFormat string, logTime bool) error {
-	var programLogLevel = new(slog.LevelVar)
-	switch logLevel {
-	case "debug":
-		programLogLevel.Set(slog.LevelDebug)
-	case "info":
-		programLogLevel.Set(slog.LevelInfo)
-	case "warning":
-		programLogLevel.Set(slog.LevelWarn)
-	case "error":
-		programLogLevel.Set(slog.LevelError)
-	}
+	setLogLevel(logLevel)
 
 	logHandlerOptions := &slog.HandlerOptions{Level: programLogLevel}
 	if !logTime {
@@ -32,15 +28,56 @@ func InitializeDefaultLogger(logLevel string, logFile io.Writer, logFormat strin
 		}
 	}
 
-	var logger *slog.Logger
+	var logHandler slog.Handler
 	switch logFormat {
 	case "json":
-		logger = slog.New(slog.NewJSONHandler(logFile, logHandlerOptions))
+		logHandler = slog.NewJSONHandler(logFile, logHandlerOptions)
 	default:
-		logger = slog.New(slog.NewTextHandler(logFile, logHandlerOptions))
+		logHandler = slog.NewTextHandler(logFile, logHandlerOptions)
 	}
 
+	logger := slog.New(&contextHandler{logHandler})
+
 	slog.SetDefault(logger)
 
 	return nil
 }
+
+func setLogLevel(logLevel string) {
+	switch logLevel {
+	case "debug":
+		programLogLevel.Set(slog.LevelDebug)
+	case "info":
+		programLogLevel.Set(slog.LevelInfo)
+	case "warning":
+		programLogLevel.Set(slog.LevelWarn)
+	case "error":
+		programLogLevel.Set(slog.LevelError)
+	}
+}
+
+// contextHandler adds the request ID and the user ID stored in the context
+// to the records logged with the slog.*Context functions.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID, ok := ctx.Value(request.RequestIDContextKey).(string); ok && requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+
+	if userID, ok := ctx.Value(request.UserIDContextKey).(int64); ok && userID > 0 {
+		record.AddAttrs(slog.Int64("user_id", userID))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{h.Handler.WithGroup(name)}
+}
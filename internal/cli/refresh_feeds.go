@@ -4,6 +4,8 @@
 package cli // import "miniflux.app/v2/internal/cli"
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"sync"
 	"time"
@@ -14,9 +16,13 @@ import (
 	"miniflux.app/v2/internal/storage"
 )
 
-func refreshFeeds(store *storage.Storage) {
-	var wg sync.WaitGroup
+// refreshFailure describes a feed that could not be refreshed.
+type refreshFailure struct {
+	job model.Job
+	err error
+}
 
+func refreshFeeds(store *storage.Storage) {
 	startTime := time.Now()
 
 	// Generate a batch of feeds for any user that has feeds to refresh.
@@ -39,7 +45,78 @@ func refreshFeeds(store *storage.Storage) {
 		slog.Int("batch_size", config.Opts.BatchSize()),
 	)
 
-	var jobQueue = make(chan model.Job, nbJobs)
+	processRefreshJobs(store, jobs, false)
+
+	slog.Info("Refreshed a batch of feeds",
+		slog.Int("nb_feeds", nbJobs),
+		slog.String("duration", time.Since(startTime).String()),
+	)
+}
+
+// refreshSelectedFeeds refreshes a single feed when feedID is set, or all the feeds of the user otherwise.
+// Without wait, the feeds are only scheduled for the next run of the background scheduler.
+func refreshSelectedFeeds(store *storage.Storage, feedID int64, username string, wait bool) {
+	batchBuilder := store.NewBatchBuilder()
+	if feedID > 0 {
+		batchBuilder.WithFeedID(feedID)
+	} else {
+		user, err := store.UserByUsername(username)
+		if err != nil {
+			printErrorAndExit(fmt.Errorf("unable to find user: %w", err))
+		}
+
+		if user == nil {
+			printErrorAndExit(fmt.Errorf("user %q not found", username))
+		}
+
+		batchBuilder.WithUserID(user.ID)
+		batchBuilder.WithoutDisabledFeeds()
+	}
+
+	jobs, err := batchBuilder.FetchJobs()
+	if err != nil {
+		printErrorAndExit(err)
+	}
+
+	if len(jobs) == 0 {
+		printErrorAndExit(fmt.Errorf("no feed to refresh"))
+	}
+
+	if !wait {
+		feedIDs := make([]int64, 0, len(jobs))
+		for _, job := range jobs {
+			feedIDs = append(feedIDs, job.FeedID)
+		}
+
+		if err := store.ScheduleFeedsRefresh(feedIDs); err != nil {
+			printErrorAndExit(err)
+		}
+
+		fmt.Printf("%d feed(s) scheduled for refresh\n", len(jobs))
+		return
+	}
+
+	startTime := time.Now()
+	failures := processRefreshJobs(store, jobs, true)
+
+	for _, failure := range failures {
+		fmt.Printf("Feed #%d (user #%d): %v\n", failure.job.FeedID, failure.job.UserID, failure.err)
+	}
+
+	fmt.Printf("%d feed(s) refreshed, %d failed in %s\n", len(jobs)-len(failures), len(failures), time.Since(startTime).Round(time.Millisecond))
+
+	if len(failures) > 0 {
+		printErrorAndExit(fmt.Errorf("some feeds could not be refreshed"))
+	}
+}
+
+// processRefreshJobs refreshes the feeds with a pool of workers and returns the failed jobs.
+func processRefreshJobs(store *storage.Storage, jobs model.JobList, forceRefresh bool) []refreshFailure {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failures []refreshFailure
+
+	var jobQueue = make(chan model.Job, len(jobs))
 
 	slog.Info("Starting a pool of workers",
 		slog.Int("nb_workers", config.Opts.WorkerPoolSize()),
@@ -56,12 +133,16 @@ func refreshFeeds(store *storage.Storage) {
 					slog.Int("worker_id", workerID),
 				)
 
-				if localizedError := feedHandler.RefreshFeed(store, job.UserID, job.FeedID, false); localizedError != nil {
+				if localizedError := feedHandler.RefreshFeed(context.Background(), store, job.UserID, job.FeedID, forceRefresh); localizedError != nil {
 					slog.Warn("Unable to refresh feed",
 						slog.Int64("feed_id", job.FeedID),
 						slog.Int64("user_id", job.UserID),
 						slog.Any("error", localizedError.Error()),
 					)
+
+					mu.Lock()
+					failures = append(failures, refreshFailure{job: job, err: localizedError.Error()})
+					mu.Unlock()
 				}
 			}
 		}(i)
@@ -74,8 +155,5 @@ func refreshFeeds(store *storage.Storage) {
 
 	wg.Wait()
 
-	slog.Info("Refreshed a batch of feeds",
-		slog.Int("nb_feeds", nbJobs),
-		slog.String("duration", time.Since(startTime).String()),
-	)
+	return failures
 }
@@ -5,9 +5,12 @@ package cli // import "miniflux.app/v2/internal/cli"
 
 import (
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/health"
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/worker"
 )
@@ -15,33 +18,115 @@ import (
 func runScheduler(store *storage.Storage, pool *worker.Pool) {
 	slog.Debug(`Starting background scheduler...`)
 
+	election := &leaderElection{store: store}
+	election.run()
+	go func() {
+		for range time.Tick(time.Minute) {
+			election.run()
+		}
+	}()
+
 	go feedScheduler(
 		store,
 		pool,
-		config.Opts.PollingFrequency(),
 		config.Opts.BatchSize(),
 		config.Opts.PollingParsingErrorLimit(),
 	)
 
 	go cleanupScheduler(
 		store,
+		election,
 		config.Opts.CleanupFrequencyHours(),
 	)
+
+	go telegramDigestScheduler(store, election)
+
+	go emailDigestScheduler(store, election)
+
+	go integrationDeliveryScheduler(store, election)
+
+	if config.Opts.IconRefreshDays() > 0 {
+		go iconRefreshScheduler(store, election)
+	}
+}
+
+// leaderElection designates the instance running the tasks that must not be executed concurrently
+// when several instances share the same database. Feed refreshes are not concerned, they are claimed by each instance.
+type leaderElection struct {
+	store  *storage.Storage
+	lock   *storage.AdvisoryLock
+	leader atomic.Bool
 }
 
-func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency, batchSize, errorLimit int) {
-	for range time.Tick(time.Duration(frequency) * time.Minute) {
+func (e *leaderElection) run() {
+	if e.lock != nil {
+		err := e.lock.Ping()
+		if err == nil {
+			return
+		}
+
+		slog.Warn("Scheduler leadership lost", slog.Any("error", err))
+		e.lock.Release()
+		e.lock = nil
+		e.leader.Store(false)
+	}
+
+	lock, err := e.store.TryAdvisoryLock(storage.SchedulerLeaderLockKey)
+	if err != nil {
+		slog.Error("Unable to acquire the scheduler leader lock", slog.Any("error", err))
+		return
+	}
+
+	if lock != nil {
+		slog.Info("This instance is now the scheduler leader")
+		e.lock = lock
+		e.leader.Store(true)
+	}
+}
+
+func (e *leaderElection) isLeader() bool {
+	return e.leader.Load()
+}
+
+func feedScheduler(store *storage.Storage, pool *worker.Pool, batchSize, errorLimit int) {
+	logger := slog.With(slog.String("component", "scheduler"))
+
+	// The heartbeat has its own ticker: pool.Push blocks while the workers are busy, that is not a stuck scheduler.
+	go func() {
+		for {
+			health.SchedulerHeartbeat()
+			time.Sleep(time.Duration(config.Opts.PollingFrequency()) * time.Minute)
+		}
+	}()
+
+	// The polling frequency is read at each iteration because it can be reloaded at runtime.
+	for {
+		time.Sleep(time.Duration(config.Opts.PollingFrequency()) * time.Minute)
+
+		if pool.IsSchedulerPaused() {
+			logger.Info("Scheduler is paused, skipping this batch")
+			continue
+		}
+
+		if config.Opts.IsPollingQuietTime(time.Now()) {
+			logger.Debug("Quiet hours, skipping this batch")
+			continue
+		}
+
 		// Generate a batch of feeds for any user that has feeds to refresh.
 		batchBuilder := store.NewBatchBuilder()
 		batchBuilder.WithBatchSize(batchSize)
 		batchBuilder.WithErrorLimit(errorLimit)
 		batchBuilder.WithoutDisabledFeeds()
 		batchBuilder.WithNextCheckExpired()
+		batchBuilder.WithinPollingWindow()
 
-		if jobs, err := batchBuilder.FetchJobs(); err != nil {
-			slog.Error("Unable to fetch jobs from database", slog.Any("error", err))
+		// The feeds are claimed for two polling periods, other instances skip them even if they are still waiting in the queue.
+		lease := 2 * time.Duration(config.Opts.PollingFrequency()) * time.Minute
+		if jobs, err := batchBuilder.ClaimJobs(lease); err != nil {
+			logger.Error("Unable to fetch jobs from database", slog.Any("error", err))
 		} else if len(jobs) > 0 {
-			slog.Info("Created a batch of feeds",
+			logger.Info("Created a batch of feeds",
 				slog.Int("nb_jobs", len(jobs)),
 			)
 			pool.Push(jobs)
@@ -49,8 +134,42 @@ func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency, batchSi
 	}
 }
 
-func cleanupScheduler(store *storage.Storage, frequency int) {
+func cleanupScheduler(store *storage.Storage, election *leaderElection, frequency int) {
 	for range time.Tick(time.Duration(frequency) * time.Hour) {
-		runCleanupTasks(store)
+		if election.isLeader() {
+			runCleanupTasks(store)
+		}
+	}
+}
+
+func telegramDigestScheduler(store *storage.Storage, election *leaderElection) {
+	for range time.Tick(time.Hour) {
+		if election.isLeader() {
+			runTelegramDigestTask(store)
+		}
+	}
+}
+
+func emailDigestScheduler(store *storage.Storage, election *leaderElection) {
+	for range time.Tick(time.Hour) {
+		if election.isLeader() {
+			runEmailDigestTask(store)
+		}
+	}
+}
+
+func integrationDeliveryScheduler(store *storage.Storage, election *leaderElection) {
+	for range time.Tick(time.Minute) {
+		if election.isLeader() {
+			integration.ProcessDeliveryQueue(store, config.Opts.BatchSize())
+		}
+	}
+}
+
+func iconRefreshScheduler(store *storage.Storage, election *leaderElection) {
+	for range time.Tick(time.Hour) {
+		if election.isLeader() {
+			runIconRefreshTask(store)
+		}
 	}
 }
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"log/slog"
+	"time"
+
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+func runTelegramDigestTask(store *storage.Storage) {
+	userIDs, err := store.TelegramBotHourlyDigestUserIDs()
+	if err != nil {
+		slog.Error("Unable to fetch Telegram digest users", slog.Any("error", err))
+		return
+	}
+
+	for _, userID := range userIDs {
+		user, err := store.UserByID(userID)
+		if err != nil || user == nil {
+			slog.Error("Unable to fetch user for Telegram digest", slog.Int64("user_id", userID), slog.Any("error", err))
+			continue
+		}
+
+		userIntegrations, err := store.Integration(userID)
+		if err != nil {
+			slog.Error("Unable to fetch integrations for Telegram digest", slog.Int64("user_id", userID), slog.Any("error", err))
+			continue
+		}
+
+		now := time.Now()
+		sentAt, err := store.TelegramBotDigestSentAt(userID)
+		if err != nil {
+			slog.Error("Unable to fetch last Telegram digest date", slog.Int64("user_id", userID), slog.Any("error", err))
+			continue
+		}
+
+		if sentAt.IsZero() {
+			sentAt = now.Add(-time.Hour)
+		}
+
+		builder := store.NewEntryQueryBuilder(userID)
+		builder.WithoutStatus(model.EntryStatusRemoved)
+		builder.AfterCreatedDate(sentAt)
+		builder.BeforeCreatedDate(now)
+		builder.WithSorting("e.feed_id", "ASC")
+		builder.WithSorting("e.created_at", "ASC")
+
+		entries, err := builder.GetEntries()
+		if err != nil {
+			slog.Error("Unable to fetch entries for Telegram digest", slog.Int64("user_id", userID), slog.Any("error", err))
+			continue
+		}
+
+		// The date is only moved forward once the digest is sent, the entries are sent again on the next run otherwise.
+		if len(entries) > 0 {
+			if err := integration.PushTelegramDigest(store, entries, userIntegrations, user); err != nil {
+				slog.Error("Unable to send Telegram digest", slog.Int64("user_id", userID), slog.Any("error", err))
+				continue
+			}
+		}
+
+		if err := store.SetTelegramBotDigestSentAt(userID, now); err != nil {
+			slog.Error("Unable to update Telegram digest date", slog.Int64("user_id", userID), slog.Any("error", err))
+		}
+	}
+}
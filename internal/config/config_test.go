@@ -6,7 +6,9 @@ package config // import "miniflux.app/v2/internal/config"
 import (
 	"bytes"
 	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLogFileDefaultValue(t *testing.T) {
@@ -312,6 +314,48 @@ func TestInvalidBaseURL(t *testing.T) {
 	}
 }
 
+func TestDefaultAdminAllowedNetworks(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if len(opts.AdminAllowedNetworks()) != 0 {
+		t.Fatalf(`Unexpected ADMIN_ALLOWED_NETWORKS value, got %v instead of an empty list`, opts.AdminAllowedNetworks())
+	}
+}
+
+func TestAdminAllowedNetworks(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("ADMIN_ALLOWED_NETWORKS", "10.0.0.0/8, fd00::/8")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := []string{"10.0.0.0/8", "fd00::/8"}
+	result := opts.AdminAllowedNetworks()
+
+	if len(result) != len(expected) || result[0] != expected[0] || result[1] != expected[1] {
+		t.Fatalf(`Unexpected ADMIN_ALLOWED_NETWORKS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestInvalidAdminAllowedNetworks(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("ADMIN_ALLOWED_NETWORKS", "10.0.0.1")
+
+	_, err := NewParser().ParseEnvironmentVariables()
+	if err == nil {
+		t.Fatalf(`Parsing must fail`)
+	}
+}
+
 func TestDefaultBaseURL(t *testing.T) {
 	os.Clearenv()
 
@@ -713,6 +757,111 @@ func TestCleanupRemoveSessionsDays(t *testing.T) {
 	}
 }
 
+func TestDefaultLoginMaxFailedAttemptsValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 10
+	result := opts.LoginMaxFailedAttempts()
+
+	if result != expected {
+		t.Fatalf(`Unexpected LOGIN_MAX_FAILED_ATTEMPTS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestLoginMaxFailedAttempts(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("LOGIN_MAX_FAILED_ATTEMPTS", "3")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 3
+	result := opts.LoginMaxFailedAttempts()
+
+	if result != expected {
+		t.Fatalf(`Unexpected LOGIN_MAX_FAILED_ATTEMPTS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultLoginMaxFailedAttemptsPerIPValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 50
+	result := opts.LoginMaxFailedAttemptsPerIP()
+
+	if result != expected {
+		t.Fatalf(`Unexpected LOGIN_MAX_FAILED_ATTEMPTS_PER_IP value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestLoginMaxFailedAttemptsPerIP(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP", "0")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 0
+	result := opts.LoginMaxFailedAttemptsPerIP()
+
+	if result != expected {
+		t.Fatalf(`Unexpected LOGIN_MAX_FAILED_ATTEMPTS_PER_IP value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultLoginLockoutDurationValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 15 * time.Minute
+	result := opts.LoginLockoutDuration()
+
+	if result != expected {
+		t.Fatalf(`Unexpected LOGIN_LOCKOUT_DURATION value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestLoginLockoutDuration(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("LOGIN_LOCKOUT_DURATION", "60")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := time.Hour
+	result := opts.LoginLockoutDuration()
+
+	if result != expected {
+		t.Fatalf(`Unexpected LOGIN_LOCKOUT_DURATION value, got %v instead of %v`, result, expected)
+	}
+}
+
 func TestDefaultWorkerPoolSizeValue(t *testing.T) {
 	os.Clearenv()
 
@@ -958,6 +1107,41 @@ func TestSchedulerEntryFrequencyMinInterval(t *testing.T) {
 	}
 }
 
+func TestDefaultSchedulerFeedRefreshMinInterval(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultSchedulerFeedRefreshMinInterval
+	result := opts.SchedulerFeedRefreshMinInterval()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SCHEDULER_FEED_REFRESH_MIN_INTERVAL value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestSchedulerFeedRefreshMinInterval(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SCHEDULER_FEED_REFRESH_MIN_INTERVAL", "15")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 15
+	result := opts.SchedulerFeedRefreshMinInterval()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SCHEDULER_FEED_REFRESH_MIN_INTERVAL value, got %v instead of %v`, result, expected)
+	}
+}
+
 func TestDefautSchedulerEntryFrequencyFactorValue(t *testing.T) {
 	os.Clearenv()
 
@@ -1046,7 +1230,7 @@ func TestPollingParsingErrorLimit(t *testing.T) {
 	}
 }
 
-func TestOAuth2UserCreationWhenUnset(t *testing.T) {
+func TestDefaultPollingQuietHours(t *testing.T) {
 	os.Clearenv()
 
 	parser := NewParser()
@@ -1055,17 +1239,18 @@ func TestOAuth2UserCreationWhenUnset(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := false
-	result := opts.IsOAuth2UserCreationAllowed()
+	if opts.HasPollingQuietHours() {
+		t.Fatalf(`Quiet hours should be disabled by default`)
+	}
 
-	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_USER_CREATION value, got %v instead of %v`, result, expected)
+	if opts.IsPollingQuietTime(time.Date(2024, 1, 1, 3, 0, 0, 0, time.Local)) {
+		t.Fatalf(`No time should be quiet by default`)
 	}
 }
 
-func TestOAuth2UserCreationAdmin(t *testing.T) {
+func TestPollingQuietHours(t *testing.T) {
 	os.Clearenv()
-	os.Setenv("OAUTH2_USER_CREATION", "1")
+	os.Setenv("POLLING_QUIET_HOURS", "01:00-06:00")
 
 	parser := NewParser()
 	opts, err := parser.ParseEnvironmentVariables()
@@ -1073,17 +1258,17 @@ func TestOAuth2UserCreationAdmin(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := true
-	result := opts.IsOAuth2UserCreationAllowed()
-
-	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_USER_CREATION value, got %v instead of %v`, result, expected)
+	scenarios := map[int]bool{0: false, 1: true, 3: true, 6: false, 12: false}
+	for hour, expected := range scenarios {
+		if result := opts.IsPollingQuietTime(time.Date(2024, 1, 1, hour, 0, 0, 0, time.Local)); result != expected {
+			t.Errorf(`Unexpected quiet time at %02d:00, got %v instead of %v`, hour, result, expected)
+		}
 	}
 }
 
-func TestOAuth2ClientID(t *testing.T) {
+func TestPollingQuietHoursOverMidnight(t *testing.T) {
 	os.Clearenv()
-	os.Setenv("OAUTH2_CLIENT_ID", "foobar")
+	os.Setenv("POLLING_QUIET_HOURS", "22:30-06:00")
 
 	parser := NewParser()
 	opts, err := parser.ParseEnvironmentVariables()
@@ -1091,15 +1276,26 @@ func TestOAuth2ClientID(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := "foobar"
-	result := opts.OAuth2ClientID()
+	scenarios := map[int]bool{22: false, 23: true, 0: true, 5: true, 6: false, 12: false}
+	for hour, expected := range scenarios {
+		if result := opts.IsPollingQuietTime(time.Date(2024, 1, 1, hour, 0, 0, 0, time.Local)); result != expected {
+			t.Errorf(`Unexpected quiet time at %02d:00, got %v instead of %v`, hour, result, expected)
+		}
+	}
+}
 
-	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_CLIENT_ID value, got %q instead of %q`, result, expected)
+func TestInvalidPollingQuietHours(t *testing.T) {
+	for _, value := range []string{"01:00", "1:00-6:00", "25:00-06:00", "01:00-06:60"} {
+		os.Clearenv()
+		os.Setenv("POLLING_QUIET_HOURS", value)
+
+		if _, err := NewParser().ParseEnvironmentVariables(); err == nil {
+			t.Errorf(`Parsing must fail for %q`, value)
+		}
 	}
 }
 
-func TestDefaultOAuth2ClientIDValue(t *testing.T) {
+func TestDefaultIconRefreshDays(t *testing.T) {
 	os.Clearenv()
 
 	parser := NewParser()
@@ -1108,17 +1304,17 @@ func TestDefaultOAuth2ClientIDValue(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := defaultOAuth2ClientID
-	result := opts.OAuth2ClientID()
+	expected := defaultIconRefreshDays
+	result := opts.IconRefreshDays()
 
 	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_CLIENT_ID value, got %q instead of %q`, result, expected)
+		t.Fatalf(`Unexpected ICON_REFRESH_DAYS value, got %v instead of %v`, result, expected)
 	}
 }
 
-func TestOAuth2ClientSecret(t *testing.T) {
+func TestIconRefreshDays(t *testing.T) {
 	os.Clearenv()
-	os.Setenv("OAUTH2_CLIENT_SECRET", "secret")
+	os.Setenv("ICON_REFRESH_DAYS", "7")
 
 	parser := NewParser()
 	opts, err := parser.ParseEnvironmentVariables()
@@ -1126,15 +1322,15 @@ func TestOAuth2ClientSecret(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := "secret"
-	result := opts.OAuth2ClientSecret()
+	expected := 7
+	result := opts.IconRefreshDays()
 
 	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_CLIENT_SECRET value, got %q instead of %q`, result, expected)
+		t.Fatalf(`Unexpected ICON_REFRESH_DAYS value, got %v instead of %v`, result, expected)
 	}
 }
 
-func TestDefaultOAuth2ClientSecretValue(t *testing.T) {
+func TestDefaultIconMaxSizeAndDimension(t *testing.T) {
 	os.Clearenv()
 
 	parser := NewParser()
@@ -1143,17 +1339,19 @@ func TestDefaultOAuth2ClientSecretValue(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := defaultOAuth2ClientSecret
-	result := opts.OAuth2ClientSecret()
+	if result := opts.IconMaxSize(); result != defaultIconMaxSize*1024 {
+		t.Fatalf(`Unexpected ICON_MAX_SIZE value, got %v instead of %v`, result, defaultIconMaxSize*1024)
+	}
 
-	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_CLIENT_SECRET value, got %q instead of %q`, result, expected)
+	if result := opts.IconMaxDimension(); result != defaultIconMaxDimension {
+		t.Fatalf(`Unexpected ICON_MAX_DIMENSION value, got %v instead of %v`, result, defaultIconMaxDimension)
 	}
 }
 
-func TestOAuth2RedirectURL(t *testing.T) {
+func TestIconMaxSizeAndDimension(t *testing.T) {
 	os.Clearenv()
-	os.Setenv("OAUTH2_REDIRECT_URL", "http://example.org")
+	os.Setenv("ICON_MAX_SIZE", "256")
+	os.Setenv("ICON_MAX_DIMENSION", "1024")
 
 	parser := NewParser()
 	opts, err := parser.ParseEnvironmentVariables()
@@ -1161,15 +1359,27 @@ func TestOAuth2RedirectURL(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := "http://example.org"
-	result := opts.OAuth2RedirectURL()
+	if result := opts.IconMaxSize(); result != 256*1024 {
+		t.Fatalf(`Unexpected ICON_MAX_SIZE value, got %v`, result)
+	}
 
-	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_REDIRECT_URL value, got %q instead of %q`, result, expected)
+	if result := opts.IconMaxDimension(); result != 1024 {
+		t.Fatalf(`Unexpected ICON_MAX_DIMENSION value, got %v`, result)
 	}
 }
 
-func TestDefaultOAuth2RedirectURLValue(t *testing.T) {
+func TestInvalidIconMaxSizeAndDimension(t *testing.T) {
+	for _, name := range []string{"ICON_MAX_SIZE", "ICON_MAX_DIMENSION"} {
+		os.Clearenv()
+		os.Setenv(name, "-1")
+
+		if _, err := NewParser().ParseEnvironmentVariables(); err == nil {
+			t.Errorf(`Parsing must fail for a negative %s`, name)
+		}
+	}
+}
+
+func TestDefaultIconRetryCooldownHours(t *testing.T) {
 	os.Clearenv()
 
 	parser := NewParser()
@@ -1178,17 +1388,17 @@ func TestDefaultOAuth2RedirectURLValue(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := defaultOAuth2RedirectURL
-	result := opts.OAuth2RedirectURL()
+	expected := defaultIconRetryCooldownHours
+	result := opts.IconRetryCooldownHours()
 
 	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_REDIRECT_URL value, got %q instead of %q`, result, expected)
+		t.Fatalf(`Unexpected ICON_RETRY_COOLDOWN_HOURS value, got %v instead of %v`, result, expected)
 	}
 }
 
-func TestOAuth2OIDCDiscoveryEndpoint(t *testing.T) {
+func TestIconRetryCooldownHours(t *testing.T) {
 	os.Clearenv()
-	os.Setenv("OAUTH2_OIDC_DISCOVERY_ENDPOINT", "http://example.org")
+	os.Setenv("ICON_RETRY_COOLDOWN_HOURS", "6")
 
 	parser := NewParser()
 	opts, err := parser.ParseEnvironmentVariables()
@@ -1196,15 +1406,15 @@ func TestOAuth2OIDCDiscoveryEndpoint(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := "http://example.org"
-	result := opts.OIDCDiscoveryEndpoint()
+	expected := 6
+	result := opts.IconRetryCooldownHours()
 
 	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_OIDC_DISCOVERY_ENDPOINT value, got %q instead of %q`, result, expected)
+		t.Fatalf(`Unexpected ICON_RETRY_COOLDOWN_HOURS value, got %v instead of %v`, result, expected)
 	}
 }
 
-func TestDefaultOIDCDiscoveryEndpointValue(t *testing.T) {
+func TestDefaultIconServiceURL(t *testing.T) {
 	os.Clearenv()
 
 	parser := NewParser()
@@ -1213,17 +1423,14 @@ func TestDefaultOIDCDiscoveryEndpointValue(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := defaultOAuth2OidcDiscoveryEndpoint
-	result := opts.OIDCDiscoveryEndpoint()
-
-	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_OIDC_DISCOVERY_ENDPOINT value, got %q instead of %q`, result, expected)
+	if opts.HasIconService() || opts.IconServiceURL() != defaultIconServiceURL {
+		t.Fatalf(`The icon service should be disabled by default`)
 	}
 }
 
-func TestOAuth2Provider(t *testing.T) {
+func TestIconServiceURL(t *testing.T) {
 	os.Clearenv()
-	os.Setenv("OAUTH2_PROVIDER", "google")
+	os.Setenv("ICON_SERVICE_URL", "https://icons.duckduckgo.com/ip3/{hostname}.ico")
 
 	parser := NewParser()
 	opts, err := parser.ParseEnvironmentVariables()
@@ -1231,15 +1438,13 @@ func TestOAuth2Provider(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := "google"
-	result := opts.OAuth2Provider()
-
-	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_PROVIDER value, got %q instead of %q`, result, expected)
+	expected := "https://icons.duckduckgo.com/ip3/{hostname}.ico"
+	if !opts.HasIconService() || opts.IconServiceURL() != expected {
+		t.Fatalf(`Unexpected ICON_SERVICE_URL value, got %q instead of %q`, opts.IconServiceURL(), expected)
 	}
 }
 
-func TestDefaultOAuth2ProviderValue(t *testing.T) {
+func TestDefaultIconStorageURL(t *testing.T) {
 	os.Clearenv()
 
 	parser := NewParser()
@@ -1248,16 +1453,14 @@ func TestDefaultOAuth2ProviderValue(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := defaultOAuth2Provider
-	result := opts.OAuth2Provider()
-
-	if result != expected {
-		t.Fatalf(`Unexpected OAUTH2_PROVIDER value, got %q instead of %q`, result, expected)
+	if opts.HasIconStorage() || opts.IconStorageURL() != defaultIconStorageURL {
+		t.Fatalf(`The icons should be stored in the database by default`)
 	}
 }
 
-func TestHSTSWhenUnset(t *testing.T) {
+func TestIconStorageURL(t *testing.T) {
 	os.Clearenv()
+	os.Setenv("ICON_STORAGE_URL", "file:///var/lib/miniflux/icons")
 
 	parser := NewParser()
 	opts, err := parser.ParseEnvironmentVariables()
@@ -1265,17 +1468,25 @@ func TestHSTSWhenUnset(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := true
-	result := opts.HasHSTS()
+	expected := "file:///var/lib/miniflux/icons"
+	if !opts.HasIconStorage() || opts.IconStorageURL() != expected {
+		t.Fatalf(`Unexpected ICON_STORAGE_URL value, got %q instead of %q`, opts.IconStorageURL(), expected)
+	}
+}
 
-	if result != expected {
-		t.Fatalf(`Unexpected DISABLE_HSTS value, got %v instead of %v`, result, expected)
+func TestInvalidIconServiceURL(t *testing.T) {
+	for _, value := range []string{"https://icons.example.org/favicon.ico", "ftp://example.org/{hostname}", "/icons/{url}"} {
+		os.Clearenv()
+		os.Setenv("ICON_SERVICE_URL", value)
+
+		if _, err := NewParser().ParseEnvironmentVariables(); err == nil {
+			t.Errorf(`Parsing must fail for %q`, value)
+		}
 	}
 }
 
-func TestHSTS(t *testing.T) {
+func TestOAuth2UserCreationWhenUnset(t *testing.T) {
 	os.Clearenv()
-	os.Setenv("DISABLE_HSTS", "1")
 
 	parser := NewParser()
 	opts, err := parser.ParseEnvironmentVariables()
@@ -1284,15 +1495,16 @@ func TestHSTS(t *testing.T) {
 	}
 
 	expected := false
-	result := opts.HasHSTS()
+	result := opts.IsOAuth2UserCreationAllowed()
 
 	if result != expected {
-		t.Fatalf(`Unexpected DISABLE_HSTS value, got %v instead of %v`, result, expected)
+		t.Fatalf(`Unexpected OAUTH2_USER_CREATION value, got %v instead of %v`, result, expected)
 	}
 }
 
-func TestDisableHTTPServiceWhenUnset(t *testing.T) {
+func TestOAuth2UserCreationAdmin(t *testing.T) {
 	os.Clearenv()
+	os.Setenv("OAUTH2_USER_CREATION", "1")
 
 	parser := NewParser()
 	opts, err := parser.ParseEnvironmentVariables()
@@ -1301,16 +1513,16 @@ func TestDisableHTTPServiceWhenUnset(t *testing.T) {
 	}
 
 	expected := true
-	result := opts.HasHTTPService()
+	result := opts.IsOAuth2UserCreationAllowed()
 
 	if result != expected {
-		t.Fatalf(`Unexpected DISABLE_HTTP_SERVICE value, got %v instead of %v`, result, expected)
+		t.Fatalf(`Unexpected OAUTH2_USER_CREATION value, got %v instead of %v`, result, expected)
 	}
 }
 
-func TestDisableHTTPService(t *testing.T) {
+func TestOAuth2ClientID(t *testing.T) {
 	os.Clearenv()
-	os.Setenv("DISABLE_HTTP_SERVICE", "1")
+	os.Setenv("OAUTH2_CLIENT_ID", "foobar")
 
 	parser := NewParser()
 	opts, err := parser.ParseEnvironmentVariables()
@@ -1318,15 +1530,15 @@ func TestDisableHTTPService(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := false
-	result := opts.HasHTTPService()
+	expected := "foobar"
+	result := opts.OAuth2ClientID()
 
 	if result != expected {
-		t.Fatalf(`Unexpected DISABLE_HTTP_SERVICE value, got %v instead of %v`, result, expected)
+		t.Fatalf(`Unexpected OAUTH2_CLIENT_ID value, got %q instead of %q`, result, expected)
 	}
 }
 
-func TestDisableSchedulerServiceWhenUnset(t *testing.T) {
+func TestDefaultOAuth2ClientIDValue(t *testing.T) {
 	os.Clearenv()
 
 	parser := NewParser()
@@ -1335,8 +1547,279 @@ func TestDisableSchedulerServiceWhenUnset(t *testing.T) {
 		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	expected := true
-	result := opts.HasSchedulerService()
+	expected := defaultOAuth2ClientID
+	result := opts.OAuth2ClientID()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_CLIENT_ID value, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestOAuth2ClientSecret(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OAUTH2_CLIENT_SECRET", "secret")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "secret"
+	result := opts.OAuth2ClientSecret()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_CLIENT_SECRET value, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestDefaultOAuth2ClientSecretValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultOAuth2ClientSecret
+	result := opts.OAuth2ClientSecret()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_CLIENT_SECRET value, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestOAuth2RedirectURL(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OAUTH2_REDIRECT_URL", "http://example.org")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "http://example.org"
+	result := opts.OAuth2RedirectURL()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_REDIRECT_URL value, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestDefaultOAuth2RedirectURLValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultOAuth2RedirectURL
+	result := opts.OAuth2RedirectURL()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_REDIRECT_URL value, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestOAuth2OIDCDiscoveryEndpoint(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OAUTH2_OIDC_DISCOVERY_ENDPOINT", "http://example.org")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "http://example.org"
+	result := opts.OIDCDiscoveryEndpoint()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_OIDC_DISCOVERY_ENDPOINT value, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestDefaultOIDCDiscoveryEndpointValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultOAuth2OidcDiscoveryEndpoint
+	result := opts.OIDCDiscoveryEndpoint()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_OIDC_DISCOVERY_ENDPOINT value, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestOIDCGroupMapping(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OAUTH2_OIDC_GROUPS_CLAIM", "realm_access.roles")
+	os.Setenv("OAUTH2_OIDC_ADMIN_GROUPS", "admins, ops")
+	os.Setenv("OAUTH2_OIDC_GROUP_CATEGORIES", "engineering=Engineering Blogs, news = News,invalid")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.OIDCGroupsClaim() != "realm_access.roles" {
+		t.Fatalf(`Unexpected OAUTH2_OIDC_GROUPS_CLAIM value, got %q`, opts.OIDCGroupsClaim())
+	}
+
+	if groups := opts.OIDCAdminGroups(); len(groups) != 2 || groups[0] != "admins" || groups[1] != "ops" {
+		t.Fatalf(`Unexpected OAUTH2_OIDC_ADMIN_GROUPS value, got %v`, groups)
+	}
+
+	categories := opts.OIDCGroupCategories()
+	if len(categories) != 2 || categories["engineering"] != "Engineering Blogs" || categories["news"] != "News" {
+		t.Fatalf(`Unexpected OAUTH2_OIDC_GROUP_CATEGORIES value, got %v`, categories)
+	}
+}
+
+func TestDefaultOIDCGroupMapping(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.OIDCGroupsClaim() != defaultOAuth2OidcGroupsClaim {
+		t.Fatalf(`Unexpected OAUTH2_OIDC_GROUPS_CLAIM value, got %q instead of %q`, opts.OIDCGroupsClaim(), defaultOAuth2OidcGroupsClaim)
+	}
+
+	if len(opts.OIDCAdminGroups()) != 0 || len(opts.OIDCGroupCategories()) != 0 {
+		t.Fatalf(`The group mapping should be empty by default`)
+	}
+}
+
+func TestOAuth2Provider(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OAUTH2_PROVIDER", "google")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "google"
+	result := opts.OAuth2Provider()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_PROVIDER value, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestDefaultOAuth2ProviderValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultOAuth2Provider
+	result := opts.OAuth2Provider()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_PROVIDER value, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestHSTSWhenUnset(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.HasHSTS()
+
+	if result != expected {
+		t.Fatalf(`Unexpected DISABLE_HSTS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestHSTS(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("DISABLE_HSTS", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := false
+	result := opts.HasHSTS()
+
+	if result != expected {
+		t.Fatalf(`Unexpected DISABLE_HSTS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDisableHTTPServiceWhenUnset(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.HasHTTPService()
+
+	if result != expected {
+		t.Fatalf(`Unexpected DISABLE_HTTP_SERVICE value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDisableHTTPService(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("DISABLE_HTTP_SERVICE", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := false
+	result := opts.HasHTTPService()
+
+	if result != expected {
+		t.Fatalf(`Unexpected DISABLE_HTTP_SERVICE value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDisableSchedulerServiceWhenUnset(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.HasSchedulerService()
 
 	if result != expected {
 		t.Fatalf(`Unexpected DISABLE_SCHEDULER_SERVICE value, got %v instead of %v`, result, expected)
@@ -1927,7 +2410,84 @@ func TestDefaultHTTPServerTimeoutValue(t *testing.T) {
 	}
 }
 
-func TestParseConfigFile(t *testing.T) {
+func TestShutdownTimeout(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SHUTDOWN_TIMEOUT", "12")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 12
+	result := opts.ShutdownTimeout()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SHUTDOWN_TIMEOUT value, got %d instead of %d`, result, expected)
+	}
+}
+
+func TestDefaultShutdownTimeoutValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultShutdownTimeout
+	result := opts.ShutdownTimeout()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SHUTDOWN_TIMEOUT value, got %d instead of %d`, result, expected)
+	}
+}
+
+func TestRedisOptions(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("REDIS_URL", "redis://:secret@localhost:6379/1")
+	os.Setenv("REDIS_CACHE_TTL", "120")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasRedisCache() {
+		t.Fatal(`The Redis cache should be enabled`)
+	}
+
+	if opts.RedisURL() != "redis://:secret@localhost:6379/1" {
+		t.Fatalf(`Unexpected REDIS_URL value, got %q`, opts.RedisURL())
+	}
+
+	if opts.RedisCacheTTL() != 2*time.Minute {
+		t.Fatalf(`Unexpected REDIS_CACHE_TTL value, got %v`, opts.RedisCacheTTL())
+	}
+}
+
+func TestDefaultRedisOptions(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasRedisCache() {
+		t.Fatal(`The Redis cache should be disabled by default`)
+	}
+
+	if opts.RedisCacheTTL() != defaultRedisCacheTTL*time.Second {
+		t.Fatalf(`Unexpected REDIS_CACHE_TTL value, got %v`, opts.RedisCacheTTL())
+	}
+}
+
+func TestParseConfigFile(t *testing.T) {
 	content := []byte(`
  # This is a comment
 
@@ -1974,6 +2534,114 @@ Invalid text
 	}
 }
 
+func TestParseTOMLConfigFile(t *testing.T) {
+	content := []byte(`
+log_level = "debug"
+polling_frequency = 30
+
+[pocket]
+consumer_key = ">#1234"
+`)
+
+	tmpfile, err := os.CreateTemp(".", "miniflux.*.unit_test.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tmpfile.Write(content); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseFile(tmpfile.Name())
+	if err != nil {
+		t.Errorf(`Parsing failure: %v`, err)
+	}
+
+	if opts.LogLevel() != "debug" {
+		t.Errorf(`Unexpected log level value, got %q`, opts.LogLevel())
+	}
+
+	if opts.PollingFrequency() != 30 {
+		t.Errorf(`Unexpected POLLING_FREQUENCY value, got %d`, opts.PollingFrequency())
+	}
+
+	expected := ">#1234"
+	result := opts.PocketConsumerKey("default")
+	if result != expected {
+		t.Errorf(`Unexpected POCKET_CONSUMER_KEY value, got %q instead of %q`, result, expected)
+	}
+
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(tmpfile.Name()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseInvalidTOMLConfigFile(t *testing.T) {
+	tmpfile, err := os.CreateTemp(".", "miniflux.*.unit_test.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tmpfile.Write([]byte("polling_frequency = \"30")); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Clearenv()
+
+	parser := NewParser()
+	if _, err := parser.ParseFile(tmpfile.Name()); err == nil {
+		t.Error(`An invalid TOML file should return an error`)
+	}
+
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(tmpfile.Name()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloadOptions(t *testing.T) {
+	os.Clearenv()
+
+	opts, err := NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	os.Setenv("LOG_LEVEL", "debug")
+	os.Setenv("POLLING_FREQUENCY", "42")
+	os.Setenv("HTTP_CLIENT_PROXY", "http://proxy.example.org:3128")
+	os.Setenv("BATCH_SIZE", "1")
+
+	newOpts, err := NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	changes := opts.Reload(newOpts)
+	expectedChanges := []string{"LOG_LEVEL", "POLLING_FREQUENCY", "HTTP_CLIENT_PROXY"}
+	if strings.Join(changes, ",") != strings.Join(expectedChanges, ",") {
+		t.Errorf(`Unexpected changes, got %v instead of %v`, changes, expectedChanges)
+	}
+
+	if opts.LogLevel() != "debug" || opts.PollingFrequency() != 42 || opts.HTTPClientProxy() != "http://proxy.example.org:3128" {
+		t.Errorf(`Reloadable options should be updated`)
+	}
+
+	if opts.BatchSize() != defaultBatchSize {
+		t.Errorf(`BATCH_SIZE should not be reloaded, got %d`, opts.BatchSize())
+	}
+}
+
 func TestAuthProxyHeader(t *testing.T) {
 	os.Clearenv()
 	os.Setenv("AUTH_PROXY_HEADER", "X-Forwarded-User")
@@ -2044,6 +2712,110 @@ func TestAuthProxyUserCreationAdmin(t *testing.T) {
 	}
 }
 
+func TestAuthProxyAttributeHeaders(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("AUTH_PROXY_NAME_HEADER", "Remote-Name")
+	os.Setenv("AUTH_PROXY_EMAIL_HEADER", "Remote-Email")
+	os.Setenv("AUTH_PROXY_GROUPS_HEADER", "Remote-Groups")
+	os.Setenv("AUTH_PROXY_ADMIN_GROUPS", "admins,miniflux-admins")
+	os.Setenv("AUTH_PROXY_USER_OPML_FILE", "/etc/miniflux/default.opml")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.AuthProxyNameHeader() != "Remote-Name" {
+		t.Fatalf(`Unexpected AUTH_PROXY_NAME_HEADER value, got %q`, opts.AuthProxyNameHeader())
+	}
+
+	if opts.AuthProxyEmailHeader() != "Remote-Email" {
+		t.Fatalf(`Unexpected AUTH_PROXY_EMAIL_HEADER value, got %q`, opts.AuthProxyEmailHeader())
+	}
+
+	if opts.AuthProxyGroupsHeader() != "Remote-Groups" {
+		t.Fatalf(`Unexpected AUTH_PROXY_GROUPS_HEADER value, got %q`, opts.AuthProxyGroupsHeader())
+	}
+
+	if groups := opts.AuthProxyAdminGroups(); len(groups) != 2 || groups[0] != "admins" || groups[1] != "miniflux-admins" {
+		t.Fatalf(`Unexpected AUTH_PROXY_ADMIN_GROUPS value, got %v`, groups)
+	}
+
+	if opts.AuthProxyUserOPMLFile() != "/etc/miniflux/default.opml" {
+		t.Fatalf(`Unexpected AUTH_PROXY_USER_OPML_FILE value, got %q`, opts.AuthProxyUserOPMLFile())
+	}
+}
+
+func TestDefaultAuthProxyAttributeHeaders(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.AuthProxyNameHeader() != "" || opts.AuthProxyEmailHeader() != "" || opts.AuthProxyGroupsHeader() != "" {
+		t.Fatalf(`The attribute headers should be empty by default`)
+	}
+
+	if len(opts.AuthProxyAdminGroups()) != 0 {
+		t.Fatalf(`Unexpected AUTH_PROXY_ADMIN_GROUPS value, got %v`, opts.AuthProxyAdminGroups())
+	}
+
+	if opts.AuthProxyUserOPMLFile() != "" {
+		t.Fatalf(`Unexpected AUTH_PROXY_USER_OPML_FILE value, got %q`, opts.AuthProxyUserOPMLFile())
+	}
+}
+
+func TestOpenTelemetryOptions(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+	os.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer token,X-Scope-OrgID=miniflux")
+	os.Setenv("OTEL_SERVICE_NAME", "reader")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasTracing() {
+		t.Fatalf(`Tracing should be enabled`)
+	}
+
+	if opts.OTelExporterOTLPEndpoint() != "http://localhost:4318" {
+		t.Fatalf(`Unexpected OTEL_EXPORTER_OTLP_ENDPOINT value, got %q`, opts.OTelExporterOTLPEndpoint())
+	}
+
+	if headers := opts.OTelExporterOTLPHeaders(); len(headers) != 2 || headers[0] != "Authorization=Bearer token" {
+		t.Fatalf(`Unexpected OTEL_EXPORTER_OTLP_HEADERS value, got %v`, headers)
+	}
+
+	if opts.OTelServiceName() != "reader" {
+		t.Fatalf(`Unexpected OTEL_SERVICE_NAME value, got %q`, opts.OTelServiceName())
+	}
+}
+
+func TestDefaultOpenTelemetryOptions(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasTracing() {
+		t.Fatalf(`Tracing should be disabled by default`)
+	}
+
+	if opts.OTelServiceName() != defaultOTelServiceName {
+		t.Fatalf(`Unexpected OTEL_SERVICE_NAME value, got %q instead of %q`, opts.OTelServiceName(), defaultOTelServiceName)
+	}
+}
+
 func TestFetchBilibiliWatchTime(t *testing.T) {
 	os.Clearenv()
 	os.Setenv("FETCH_BILIBILI_WATCH_TIME", "1")
@@ -2134,37 +2906,773 @@ func TestYouTubeEmbedUrlOverride(t *testing.T) {
 	}
 }
 
-func TestParseConfigDumpOutput(t *testing.T) {
+func TestSMTPOptions(t *testing.T) {
 	os.Clearenv()
+	os.Setenv("SMTP_HOST", "smtp.example.org")
+	os.Setenv("SMTP_PORT", "465")
+	os.Setenv("SMTP_USERNAME", "miniflux")
+	os.Setenv("SMTP_PASSWORD", "secret")
+	os.Setenv("SMTP_FROM", "Miniflux <miniflux@example.org>")
+	os.Setenv("SMTP_IMPLICIT_TLS", "1")
 
-	wantOpts := NewOptions()
-	wantOpts.adminUsername = "my-username"
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
 
-	serialized := wantOpts.String()
-	tmpfile, err := os.CreateTemp(".", "miniflux.*.unit_test.conf")
+	if opts.SMTPHost() != "smtp.example.org" {
+		t.Fatalf(`Unexpected SMTP_HOST value, got %q`, opts.SMTPHost())
+	}
+
+	if opts.SMTPPort() != 465 {
+		t.Fatalf(`Unexpected SMTP_PORT value, got %d`, opts.SMTPPort())
+	}
+
+	if opts.SMTPUsername() != "miniflux" {
+		t.Fatalf(`Unexpected SMTP_USERNAME value, got %q`, opts.SMTPUsername())
+	}
+
+	if opts.SMTPPassword() != "secret" {
+		t.Fatalf(`Unexpected SMTP_PASSWORD value, got %q`, opts.SMTPPassword())
+	}
+
+	if opts.SMTPFrom() != "Miniflux <miniflux@example.org>" {
+		t.Fatalf(`Unexpected SMTP_FROM value, got %q`, opts.SMTPFrom())
+	}
+
+	if !opts.SMTPImplicitTLS() {
+		t.Fatalf(`Unexpected SMTP_IMPLICIT_TLS value, got %v`, opts.SMTPImplicitTLS())
+	}
+
+	if !opts.HasSMTP() {
+		t.Fatalf(`SMTP should be configured`)
+	}
+}
+
+func TestDefaultSMTPOptions(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
 	if err != nil {
-		t.Fatal(err)
+		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	if _, err := tmpfile.WriteString(serialized); err != nil {
-		t.Fatal(err)
+	if opts.SMTPPort() != defaultSMTPPort {
+		t.Fatalf(`Unexpected SMTP_PORT value, got %d instead of %d`, opts.SMTPPort(), defaultSMTPPort)
 	}
 
+	if opts.SMTPImplicitTLS() {
+		t.Fatalf(`Unexpected SMTP_IMPLICIT_TLS value, got %v`, opts.SMTPImplicitTLS())
+	}
+
+	if opts.HasSMTP() {
+		t.Fatalf(`SMTP should not be configured by default`)
+	}
+}
+
+func TestNewsletterOptions(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("NEWSLETTER_LISTEN_ADDR", ":2525")
+	os.Setenv("NEWSLETTER_DOMAIN", "Newsletters.Example.org")
+
 	parser := NewParser()
-	parsedOpts, err := parser.ParseFile(tmpfile.Name())
+	opts, err := parser.ParseEnvironmentVariables()
 	if err != nil {
-		t.Errorf(`Parsing failure: %v`, err)
+		t.Fatalf(`Parsing failure: %v`, err)
 	}
 
-	if parsedOpts.AdminUsername() != wantOpts.AdminUsername() {
-		t.Fatalf(`Unexpected ADMIN_USERNAME value, got %q instead of %q`, parsedOpts.AdminUsername(), wantOpts.AdminUsername())
+	if opts.NewsletterListenAddr() != ":2525" {
+		t.Fatalf(`Unexpected NEWSLETTER_LISTEN_ADDR value, got %q`, opts.NewsletterListenAddr())
 	}
 
-	if err := tmpfile.Close(); err != nil {
-		t.Fatal(err)
+	if opts.NewsletterDomain() != "newsletters.example.org" {
+		t.Fatalf(`Unexpected NEWSLETTER_DOMAIN value, got %q`, opts.NewsletterDomain())
 	}
 
-	if err := os.Remove(tmpfile.Name()); err != nil {
-		t.Fatal(err)
+	if !opts.HasNewsletterService() {
+		t.Fatalf(`The newsletter service should be enabled`)
+	}
+}
+
+func TestNewsletterServiceWithoutDomain(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("NEWSLETTER_LISTEN_ADDR", ":2525")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasNewsletterService() {
+		t.Fatalf(`The newsletter service should not be enabled without domain`)
+	}
+}
+
+func TestParseConfigDumpOutput(t *testing.T) {
+	os.Clearenv()
+
+	wantOpts := NewOptions()
+	wantOpts.adminUsername = "my-username"
+
+	serialized := wantOpts.String()
+	tmpfile, err := os.CreateTemp(".", "miniflux.*.unit_test.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tmpfile.WriteString(serialized); err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser()
+	parsedOpts, err := parser.ParseFile(tmpfile.Name())
+	if err != nil {
+		t.Errorf(`Parsing failure: %v`, err)
+	}
+
+	if parsedOpts.AdminUsername() != wantOpts.AdminUsername() {
+		t.Fatalf(`Unexpected ADMIN_USERNAME value, got %q instead of %q`, parsedOpts.AdminUsername(), wantOpts.AdminUsername())
+	}
+
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(tmpfile.Name()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTOTPRequired(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("TOTP_REQUIRED", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.TOTPRequired() {
+		t.Fatalf(`Unexpected TOTP_REQUIRED value, got %v`, opts.TOTPRequired())
+	}
+}
+
+func TestDefaultTOTPRequired(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.TOTPRequired() != defaultTOTPRequired {
+		t.Fatalf(`Unexpected TOTP_REQUIRED value, got %v instead of %v`, opts.TOTPRequired(), defaultTOTPRequired)
+	}
+}
+
+func TestWebSub(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WEBSUB", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.WebSub() {
+		t.Fatalf(`Unexpected WEBSUB value, got %v`, opts.WebSub())
+	}
+}
+
+func TestDefaultWebSub(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.WebSub() != defaultWebSub {
+		t.Fatalf(`Unexpected WEBSUB value, got %v instead of %v`, opts.WebSub(), defaultWebSub)
+	}
+}
+
+func TestLDAPOptions(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("LDAP_URL", "ldaps://ldap.example.org")
+	os.Setenv("LDAP_BIND_DN", "cn=miniflux,dc=example,dc=org")
+	os.Setenv("LDAP_BIND_PASSWORD", "secret")
+	os.Setenv("LDAP_BASE_DN", "ou=people,dc=example,dc=org")
+	os.Setenv("LDAP_USER_FILTER", "(sAMAccountName=%s)")
+	os.Setenv("LDAP_GROUP_FILTER", "(memberOf=cn=miniflux,dc=example,dc=org)")
+	os.Setenv("LDAP_START_TLS", "1")
+	os.Setenv("LDAP_TLS_SKIP_VERIFY", "1")
+	os.Setenv("LDAP_USER_CREATION", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasLDAP() {
+		t.Fatalf(`LDAP should be configured`)
+	}
+
+	if opts.LDAPURL() != "ldaps://ldap.example.org" {
+		t.Fatalf(`Unexpected LDAP_URL value, got %q`, opts.LDAPURL())
+	}
+
+	if opts.LDAPBindDN() != "cn=miniflux,dc=example,dc=org" {
+		t.Fatalf(`Unexpected LDAP_BIND_DN value, got %q`, opts.LDAPBindDN())
+	}
+
+	if opts.LDAPBindPassword() != "secret" {
+		t.Fatalf(`Unexpected LDAP_BIND_PASSWORD value, got %q`, opts.LDAPBindPassword())
+	}
+
+	if opts.LDAPBaseDN() != "ou=people,dc=example,dc=org" {
+		t.Fatalf(`Unexpected LDAP_BASE_DN value, got %q`, opts.LDAPBaseDN())
+	}
+
+	if opts.LDAPUserFilter() != "(sAMAccountName=%s)" {
+		t.Fatalf(`Unexpected LDAP_USER_FILTER value, got %q`, opts.LDAPUserFilter())
+	}
+
+	if opts.LDAPGroupFilter() != "(memberOf=cn=miniflux,dc=example,dc=org)" {
+		t.Fatalf(`Unexpected LDAP_GROUP_FILTER value, got %q`, opts.LDAPGroupFilter())
+	}
+
+	if !opts.LDAPStartTLS() {
+		t.Fatalf(`Unexpected LDAP_START_TLS value, got %v`, opts.LDAPStartTLS())
+	}
+
+	if !opts.LDAPTLSSkipVerify() {
+		t.Fatalf(`Unexpected LDAP_TLS_SKIP_VERIFY value, got %v`, opts.LDAPTLSSkipVerify())
+	}
+
+	if !opts.IsLDAPUserCreationAllowed() {
+		t.Fatalf(`Unexpected LDAP_USER_CREATION value, got %v`, opts.IsLDAPUserCreationAllowed())
+	}
+}
+
+func TestDefaultLDAPOptions(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasLDAP() {
+		t.Fatalf(`LDAP should not be configured by default`)
+	}
+
+	if opts.LDAPUserFilter() != defaultLDAPUserFilter {
+		t.Fatalf(`Unexpected LDAP_USER_FILTER value, got %q instead of %q`, opts.LDAPUserFilter(), defaultLDAPUserFilter)
+	}
+
+	if opts.IsLDAPUserCreationAllowed() {
+		t.Fatalf(`Unexpected LDAP_USER_CREATION value, got %v`, opts.IsLDAPUserCreationAllowed())
+	}
+}
+
+func TestSAMLOptions(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SAML_IDP_METADATA_URL", "https://idp.example.org/metadata")
+	os.Setenv("SAML_SP_ENTITY_ID", "miniflux")
+	os.Setenv("SAML_USERNAME_ATTRIBUTE", "uid")
+	os.Setenv("SAML_EMAIL_ATTRIBUTE", "mail")
+	os.Setenv("SAML_ADMIN_ATTRIBUTE", "groups")
+	os.Setenv("SAML_ADMIN_VALUE", "admins")
+	os.Setenv("SAML_USER_CREATION", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasSAML() {
+		t.Fatalf(`SAML should be configured`)
+	}
+
+	if opts.SAMLIdPMetadataURL() != "https://idp.example.org/metadata" {
+		t.Fatalf(`Unexpected SAML_IDP_METADATA_URL value, got %q`, opts.SAMLIdPMetadataURL())
+	}
+
+	if opts.SAMLSPEntityID() != "miniflux" {
+		t.Fatalf(`Unexpected SAML_SP_ENTITY_ID value, got %q`, opts.SAMLSPEntityID())
+	}
+
+	if opts.SAMLUsernameAttribute() != "uid" {
+		t.Fatalf(`Unexpected SAML_USERNAME_ATTRIBUTE value, got %q`, opts.SAMLUsernameAttribute())
+	}
+
+	if opts.SAMLEmailAttribute() != "mail" {
+		t.Fatalf(`Unexpected SAML_EMAIL_ATTRIBUTE value, got %q`, opts.SAMLEmailAttribute())
+	}
+
+	if opts.SAMLAdminAttribute() != "groups" {
+		t.Fatalf(`Unexpected SAML_ADMIN_ATTRIBUTE value, got %q`, opts.SAMLAdminAttribute())
+	}
+
+	if opts.SAMLAdminValue() != "admins" {
+		t.Fatalf(`Unexpected SAML_ADMIN_VALUE value, got %q`, opts.SAMLAdminValue())
+	}
+
+	if !opts.IsSAMLUserCreationAllowed() {
+		t.Fatalf(`Unexpected SAML_USER_CREATION value, got %v`, opts.IsSAMLUserCreationAllowed())
+	}
+}
+
+func TestSCIMToken(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SCIM_TOKEN", "secret")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasSCIM() {
+		t.Fatalf(`SCIM should be enabled`)
+	}
+
+	if opts.SCIMToken() != "secret" {
+		t.Fatalf(`Unexpected SCIM_TOKEN value, got %q`, opts.SCIMToken())
+	}
+}
+
+func TestDefaultSCIMToken(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasSCIM() {
+		t.Fatalf(`SCIM should not be enabled by default`)
+	}
+}
+
+func TestDefaultSAMLOptions(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "https://example.org/folder/")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasSAML() {
+		t.Fatalf(`SAML should not be configured by default`)
+	}
+
+	expected := "https://example.org/folder/saml/metadata"
+	if opts.SAMLSPEntityID() != expected {
+		t.Fatalf(`Unexpected SAML_SP_ENTITY_ID value, got %q instead of %q`, opts.SAMLSPEntityID(), expected)
+	}
+
+	if opts.IsSAMLUserCreationAllowed() {
+		t.Fatalf(`Unexpected SAML_USER_CREATION value, got %v`, opts.IsSAMLUserCreationAllowed())
+	}
+}
+
+func TestOIDCProviders(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "https://example.org/folder")
+	os.Setenv("OAUTH2_OIDC_PROVIDER_PARTNER_CLIENT_ID", "partner-client")
+	os.Setenv("OAUTH2_OIDC_PROVIDER_PARTNER_CLIENT_SECRET", "secret")
+	os.Setenv("OAUTH2_OIDC_PROVIDER_PARTNER_DISCOVERY_ENDPOINT", "https://partner.example.org")
+	os.Setenv("OAUTH2_OIDC_PROVIDER_CORP_CLIENT_ID", "corp-client")
+	os.Setenv("OAUTH2_OIDC_PROVIDER_CORP_DISCOVERY_ENDPOINT", "https://corp.example.org")
+	os.Setenv("OAUTH2_OIDC_PROVIDER_CORP_NAME", "Corporate Account")
+	os.Setenv("OAUTH2_OIDC_PROVIDER_INCOMPLETE_CLIENT_ID", "client")
+	os.Setenv("OAUTH2_OIDC_PROVIDER_GOOGLE_CLIENT_ID", "client")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	providers := opts.OIDCProviders()
+	if len(providers) != 2 {
+		t.Fatalf(`Unexpected number of providers, got %d`, len(providers))
+	}
+
+	expected := OIDCProvider{
+		ID:                "corp",
+		Name:              "Corporate Account",
+		ClientID:          "corp-client",
+		DiscoveryEndpoint: "https://corp.example.org",
+		RedirectURL:       "https://example.org/folder/oauth2/corp/callback",
+	}
+	if *providers[0] != expected {
+		t.Fatalf(`Unexpected provider, got %+v`, providers[0])
+	}
+
+	if providers[1].ID != "partner" || providers[1].Name != "partner" || providers[1].ClientSecret != "secret" {
+		t.Fatalf(`Unexpected provider, got %+v`, providers[1])
+	}
+}
+
+func TestDefaultOIDCProviders(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if providers := opts.OIDCProviders(); len(providers) != 0 {
+		t.Fatalf(`No additional provider should be configured by default, got %d`, len(providers))
+	}
+}
+
+func TestDefaultWorkerPoolHostConcurrency(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultWorkerPoolHostConcurrency
+	result := opts.WorkerPoolHostConcurrency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WORKER_POOL_HOST_CONCURRENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestWorkerPoolHostConcurrency(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WORKER_POOL_HOST_CONCURRENCY", "4")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 4
+	result := opts.WorkerPoolHostConcurrency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WORKER_POOL_HOST_CONCURRENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestSubscriptionAlternativeFrontends(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SUBSCRIPTION_ALTERNATIVE_FRONTENDS", "Twitter.com=https://nitter.example.org/, reddit.com = https://teddit.example.org,invalid,=https://example.org")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	frontends := opts.SubscriptionAlternativeFrontends()
+	if len(frontends) != 2 || frontends["twitter.com"] != "https://nitter.example.org" || frontends["reddit.com"] != "https://teddit.example.org" {
+		t.Fatalf(`Unexpected SUBSCRIPTION_ALTERNATIVE_FRONTENDS value, got %v`, frontends)
+	}
+}
+
+func TestDefaultSubscriptionAlternativeFrontends(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if len(opts.SubscriptionAlternativeFrontends()) != 0 {
+		t.Fatalf(`The alternative frontends should be empty by default`)
+	}
+}
+
+func TestHeadlessBrowser(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("HEADLESS_BROWSER_URL", "http://browserless:3000/content")
+	os.Setenv("HEADLESS_BROWSER_ALLOWED_DOMAINS", "Example.org, ,example.com")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasHeadlessBrowser() {
+		t.Fatalf(`The headless browser should be configured`)
+	}
+
+	if result := opts.HeadlessBrowserURL(); result != "http://browserless:3000/content" {
+		t.Fatalf(`Unexpected HEADLESS_BROWSER_URL value, got %q`, result)
+	}
+
+	domains := opts.HeadlessBrowserAllowedDomains()
+	if len(domains) != 2 || domains[0] != "example.org" || domains[1] != "example.com" {
+		t.Fatalf(`Unexpected HEADLESS_BROWSER_ALLOWED_DOMAINS value, got %v`, domains)
+	}
+}
+
+func TestDefaultHeadlessBrowser(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasHeadlessBrowser() {
+		t.Fatalf(`The headless browser should not be configured by default`)
+	}
+
+	if len(opts.HeadlessBrowserAllowedDomains()) != 0 {
+		t.Fatalf(`The allowed domains should be empty by default`)
+	}
+}
+
+func TestSiteRulesFile(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SITE_RULES_FILE", "/etc/miniflux/site-rules.txt")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.SiteRulesFile(); result != "/etc/miniflux/site-rules.txt" {
+		t.Fatalf(`Unexpected SITE_RULES_FILE value, got %q`, result)
+	}
+}
+
+func TestRewriteScripts(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("REWRITE_SCRIPTS", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasRewriteScripts() {
+		t.Fatalf(`The rewrite scripts should be enabled`)
+	}
+}
+
+func TestDefaultRewriteScripts(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasRewriteScripts() {
+		t.Fatalf(`The rewrite scripts should be disabled by default`)
+	}
+}
+
+func TestTrackingParameters(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("TRACKING_PARAMETERS", "Ref, ,example.org:ID")
+	os.Setenv("TRACKING_PARAMETERS_EXCEPTIONS", "example.com:utm_source")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	params := opts.TrackingParameters()
+	if len(params) != 2 || params[0] != "ref" || params[1] != "example.org:id" {
+		t.Fatalf(`Unexpected TRACKING_PARAMETERS value, got %v`, params)
+	}
+
+	exceptions := opts.TrackingParametersExceptions()
+	if len(exceptions) != 1 || exceptions[0] != "example.com:utm_source" {
+		t.Fatalf(`Unexpected TRACKING_PARAMETERS_EXCEPTIONS value, got %v`, exceptions)
+	}
+}
+
+func TestDefaultTrackingParameters(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if params := opts.TrackingParameters(); len(params) != 0 {
+		t.Fatalf(`Unexpected TRACKING_PARAMETERS value, got %v`, params)
+	}
+
+	if exceptions := opts.TrackingParametersExceptions(); len(exceptions) != 0 {
+		t.Fatalf(`Unexpected TRACKING_PARAMETERS_EXCEPTIONS value, got %v`, exceptions)
+	}
+}
+
+func TestIframeAllowedDomains(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("IFRAME_ALLOWED_DOMAINS", "PeerTube.example.org, ,bandcamp.com")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	domains := opts.IframeAllowedDomains()
+	if len(domains) != 2 || domains[0] != "peertube.example.org" || domains[1] != "bandcamp.com" {
+		t.Fatalf(`Unexpected IFRAME_ALLOWED_DOMAINS value, got %v`, domains)
+	}
+}
+
+func TestDefaultIframeAllowedDomains(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if len(opts.IframeAllowedDomains()) != 0 {
+		t.Fatalf(`The iframe allowed domains should be empty by default`)
+	}
+}
+
+func TestSyntaxHighlightingWhenUnset(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasSyntaxHighlighting() {
+		t.Fatalf(`The syntax highlighting should be enabled by default`)
+	}
+}
+
+func TestDisableSyntaxHighlighting(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("DISABLE_SYNTAX_HIGHLIGHTING", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasSyntaxHighlighting() {
+		t.Fatalf(`Unexpected DISABLE_SYNTAX_HIGHLIGHTING value, got %v instead of false`, opts.HasSyntaxHighlighting())
+	}
+}
+
+func TestTranslationWhenUnset(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasTranslation() {
+		t.Fatalf(`The translation should be disabled by default`)
+	}
+}
+
+func TestTranslationProvider(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("TRANSLATION_PROVIDER", "DeepL")
+	os.Setenv("TRANSLATION_API_URL", "https://api.deepl.com")
+	os.Setenv("TRANSLATION_API_KEY", "secret")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasTranslation() || opts.TranslationProvider() != "deepl" {
+		t.Fatalf(`Unexpected TRANSLATION_PROVIDER value, got %q instead of "deepl"`, opts.TranslationProvider())
+	}
+
+	if opts.TranslationAPIURL() != "https://api.deepl.com" {
+		t.Fatalf(`Unexpected TRANSLATION_API_URL value, got %q`, opts.TranslationAPIURL())
+	}
+
+	if opts.TranslationAPIKey() != "secret" {
+		t.Fatalf(`Unexpected TRANSLATION_API_KEY value, got %q`, opts.TranslationAPIKey())
+	}
+}
+
+func TestInvalidTranslationProvider(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("TRANSLATION_PROVIDER", "unknown")
+
+	parser := NewParser()
+	if _, err := parser.ParseEnvironmentVariables(); err == nil {
+		t.Fatalf(`An unknown translation provider should be rejected`)
+	}
+}
+
+func TestDefaultScraperCacheTTLValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasScraperCache() {
+		t.Fatalf(`The scraper cache should be enabled by default`)
+	}
+
+	if result := opts.ScraperCacheTTL(); result != time.Hour {
+		t.Fatalf(`Unexpected SCRAPER_CACHE_TTL value, got %v instead of %v`, result, time.Hour)
+	}
+}
+
+func TestDisabledScraperCache(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SCRAPER_CACHE_TTL", "0")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasScraperCache() {
+		t.Fatalf(`The scraper cache should be disabled`)
 	}
 }
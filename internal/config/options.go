@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"miniflux.app/v2/internal/crypto"
@@ -22,19 +23,24 @@ const (
 	defaultHSTS                               = true
 	defaultHTTPService                        = true
 	defaultSchedulerService                   = true
+	defaultSyntaxHighlighting                 = true
 	defaultDebug                              = false
 	defaultTiming                             = false
 	defaultBaseURL                            = "http://localhost"
 	defaultRootURL                            = "http://localhost"
 	defaultBasePath                           = ""
 	defaultWorkerPoolSize                     = 16
+	defaultWorkerPoolHostConcurrency          = 2
 	defaultPollingFrequency                   = 60
 	defaultForceRefreshInterval               = 30
 	defaultBatchSize                          = 100
 	defaultPollingScheduler                   = "round_robin"
+	defaultRedisURL                           = ""
+	defaultRedisCacheTTL                      = 60
 	defaultSchedulerEntryFrequencyMinInterval = 5
 	defaultSchedulerEntryFrequencyMaxInterval = 24 * 60
 	defaultSchedulerEntryFrequencyFactor      = 1
+	defaultSchedulerFeedRefreshMinInterval    = 5
 	defaultSchedulerRoundRobinMinInterval     = 60
 	defaultPollingParsingErrorLimit           = 3
 	defaultRunMigrations                      = false
@@ -51,6 +57,9 @@ const (
 	defaultCleanupArchiveUnreadDays           = 180
 	defaultCleanupArchiveBatchSize            = 10000
 	defaultCleanupRemoveSessionsDays          = 30
+	defaultLoginMaxFailedAttempts             = 10
+	defaultLoginMaxFailedAttemptsPerIP        = 50
+	defaultLoginLockoutDuration               = 15
 	defaultMediaProxyHTTPClientTimeout        = 120
 	defaultMediaProxyMode                     = "http-only"
 	defaultMediaResourceTypes                 = "image"
@@ -69,14 +78,24 @@ const (
 	defaultOAuth2ClientSecret                 = ""
 	defaultOAuth2RedirectURL                  = ""
 	defaultOAuth2OidcDiscoveryEndpoint        = ""
+	defaultOAuth2OidcGroupsClaim              = "groups"
 	defaultOAuth2Provider                     = ""
 	defaultPocketConsumerKey                  = ""
 	defaultHTTPClientTimeout                  = 20
 	defaultHTTPClientMaxBodySize              = 15
 	defaultHTTPClientProxy                    = ""
+	defaultHeadlessBrowserURL                 = ""
+	defaultScraperCacheTTL                    = 60
 	defaultHTTPServerTimeout                  = 300
+	defaultShutdownTimeout                    = 30
 	defaultAuthProxyHeader                    = ""
 	defaultAuthProxyUserCreation              = false
+	defaultAuthProxyNameHeader                = ""
+	defaultAuthProxyEmailHeader               = ""
+	defaultAuthProxyGroupsHeader              = ""
+	defaultAuthProxyUserOPMLFile              = ""
+	defaultOTelExporterOTLPEndpoint           = ""
+	defaultOTelServiceName                    = "miniflux"
 	defaultMaintenanceMode                    = false
 	defaultMaintenanceMessage                 = "Miniflux is currently under maintenance"
 	defaultMetricsCollector                   = false
@@ -86,7 +105,46 @@ const (
 	defaultMetricsPassword                    = ""
 	defaultWatchdog                           = true
 	defaultInvidiousInstance                  = "yewtu.be"
+	defaultIconServiceURL                     = ""
+	defaultIconStorageURL                     = ""
+	defaultSiteRulesFile                      = ""
+	defaultRewriteScripts                     = false
+	defaultTranslationProvider                = ""
+	defaultTranslationAPIURL                  = ""
+	defaultTranslationAPIKey                  = ""
+	defaultIconRefreshDays                    = 30
+	defaultIconMaxSize                        = 1024
+	defaultIconMaxDimension                   = 4096
+	defaultIconRetryCooldownHours             = 24
 	defaultWebAuthn                           = false
+	defaultWebSub                             = false
+	defaultTOTPRequired                       = false
+	defaultLDAPURL                            = ""
+	defaultLDAPBindDN                         = ""
+	defaultLDAPBindPassword                   = ""
+	defaultLDAPBaseDN                         = ""
+	defaultLDAPUserFilter                     = "(uid=%s)"
+	defaultLDAPGroupFilter                    = ""
+	defaultLDAPStartTLS                       = false
+	defaultLDAPTLSSkipVerify                  = false
+	defaultLDAPUserCreation                   = false
+	defaultSAMLIdPMetadataURL                 = ""
+	defaultSAMLIdPMetadataFile                = ""
+	defaultSAMLSPEntityID                     = ""
+	defaultSAMLUsernameAttribute              = ""
+	defaultSAMLEmailAttribute                 = ""
+	defaultSAMLAdminAttribute                 = ""
+	defaultSAMLAdminValue                     = ""
+	defaultSAMLUserCreation                   = false
+	defaultSCIMToken                          = ""
+	defaultSMTPHost                           = ""
+	defaultSMTPPort                           = 587
+	defaultSMTPUsername                       = ""
+	defaultSMTPPassword                       = ""
+	defaultSMTPFrom                           = ""
+	defaultSMTPImplicitTLS                    = false
+	defaultNewsletterListenAddr               = ""
+	defaultNewsletterDomain                   = ""
 )
 
 var defaultHTTPClientUserAgent = "Mozilla/5.0 (compatible; Miniflux/" + version.Version + "; +https://miniflux.app)"
@@ -97,8 +155,21 @@ type Option struct {
 	Value interface{}
 }
 
+// OIDCProvider holds the settings of an additional OpenID Connect provider.
+type OIDCProvider struct {
+	ID                string
+	Name              string
+	ClientID          string
+	ClientSecret      string
+	DiscoveryEndpoint string
+	RedirectURL       string
+}
+
 // Options contains configuration options.
 type Options struct {
+	// mu guards the options that can be reloaded while the process is running.
+	mu sync.RWMutex
+
 	HTTPS                              bool
 	logFile                            string
 	logDateTime                        bool
@@ -107,6 +178,7 @@ type Options struct {
 	hsts                               bool
 	httpService                        bool
 	schedulerService                   bool
+	syntaxHighlighting                 bool
 	serverTimingHeader                 bool
 	baseURL                            string
 	rootURL                            string
@@ -125,19 +197,29 @@ type Options struct {
 	cleanupArchiveUnreadDays           int
 	cleanupArchiveBatchSize            int
 	cleanupRemoveSessionsDays          int
+	loginMaxFailedAttempts             int
+	loginMaxFailedAttemptsPerIP        int
+	loginLockoutDuration               int
 	pollingFrequency                   int
 	forceRefreshInterval               int
 	batchSize                          int
 	pollingScheduler                   string
+	redisURL                           string
+	redisCacheTTL                      int
 	schedulerEntryFrequencyMinInterval int
 	schedulerEntryFrequencyMaxInterval int
 	schedulerEntryFrequencyFactor      int
+	schedulerFeedRefreshMinInterval    int
 	schedulerRoundRobinMinInterval     int
 	pollingParsingErrorLimit           int
+	pollingQuietHoursStart             string
+	pollingQuietHoursEnd               string
 	workerPoolSize                     int
+	workerPoolHostConcurrency          int
 	createAdmin                        bool
 	adminUsername                      string
 	adminPassword                      string
+	adminAllowedNetworks               []string
 	mediaProxyHTTPClientTimeout        int
 	mediaProxyMode                     string
 	mediaProxyResourceTypes            []string
@@ -148,20 +230,38 @@ type Options struct {
 	fetchYouTubeWatchTime              bool
 	filterEntryMaxAgeDays              int
 	youTubeEmbedUrlOverride            string
+	alternativeFrontends               []string
 	oauth2UserCreationAllowed          bool
 	oauth2ClientID                     string
 	oauth2ClientSecret                 string
 	oauth2RedirectURL                  string
 	oidcDiscoveryEndpoint              string
+	oidcGroupsClaim                    string
+	oidcAdminGroups                    []string
+	oidcGroupCategories                []string
+	oidcProviders                      map[string]*OIDCProvider
 	oauth2Provider                     string
 	pocketConsumerKey                  string
 	httpClientTimeout                  int
 	httpClientMaxBodySize              int64
 	httpClientProxy                    string
+	headlessBrowserURL                 string
+	headlessBrowserAllowedDomains      []string
+	scraperCacheTTL                    int
 	httpClientUserAgent                string
 	httpServerTimeout                  int
+	iframeAllowedDomains               []string
+	shutdownTimeout                    int
 	authProxyHeader                    string
 	authProxyUserCreation              bool
+	authProxyNameHeader                string
+	authProxyEmailHeader               string
+	authProxyGroupsHeader              string
+	authProxyAdminGroups               []string
+	authProxyUserOPMLFile              string
+	otelExporterOTLPEndpoint           string
+	otelExporterOTLPHeaders            []string
+	otelServiceName                    string
 	maintenanceMode                    bool
 	maintenanceMessage                 string
 	metricsCollector                   bool
@@ -171,8 +271,49 @@ type Options struct {
 	metricsPassword                    string
 	watchdog                           bool
 	invidiousInstance                  string
+	iconServiceURL                     string
+	iconStorageURL                     string
+	siteRulesFile                      string
+	rewriteScripts                     bool
+	translationProvider                string
+	translationAPIURL                  string
+	translationAPIKey                  string
+	trackingParameters                 []string
+	trackingParametersExceptions       []string
+	iconRefreshDays                    int
+	iconMaxSize                        int64
+	iconMaxDimension                   int
+	iconRetryCooldownHours             int
 	mediaProxyPrivateKey               []byte
 	webAuthn                           bool
+	webSub                             bool
+	totpRequired                       bool
+	ldapURL                            string
+	ldapBindDN                         string
+	ldapBindPassword                   string
+	ldapBaseDN                         string
+	ldapUserFilter                     string
+	ldapGroupFilter                    string
+	ldapStartTLS                       bool
+	ldapTLSSkipVerify                  bool
+	ldapUserCreation                   bool
+	samlIdPMetadataURL                 string
+	samlIdPMetadataFile                string
+	samlSPEntityID                     string
+	samlUsernameAttribute              string
+	samlEmailAttribute                 string
+	samlAdminAttribute                 string
+	samlAdminValue                     string
+	samlUserCreation                   bool
+	scimToken                          string
+	smtpHost                           string
+	smtpPort                           int
+	smtpUsername                       string
+	smtpPassword                       string
+	smtpFrom                           string
+	smtpImplicitTLS                    bool
+	newsletterListenAddr               string
+	newsletterDomain                   string
 }
 
 // NewOptions returns Options with default values.
@@ -186,6 +327,7 @@ func NewOptions() *Options {
 		hsts:                               defaultHSTS,
 		httpService:                        defaultHTTPService,
 		schedulerService:                   defaultSchedulerService,
+		syntaxHighlighting:                 defaultSyntaxHighlighting,
 		serverTimingHeader:                 defaultTiming,
 		baseURL:                            defaultBaseURL,
 		rootURL:                            defaultRootURL,
@@ -204,16 +346,23 @@ func NewOptions() *Options {
 		cleanupArchiveUnreadDays:           defaultCleanupArchiveUnreadDays,
 		cleanupArchiveBatchSize:            defaultCleanupArchiveBatchSize,
 		cleanupRemoveSessionsDays:          defaultCleanupRemoveSessionsDays,
+		loginMaxFailedAttempts:             defaultLoginMaxFailedAttempts,
+		loginMaxFailedAttemptsPerIP:        defaultLoginMaxFailedAttemptsPerIP,
+		loginLockoutDuration:               defaultLoginLockoutDuration,
 		pollingFrequency:                   defaultPollingFrequency,
 		forceRefreshInterval:               defaultForceRefreshInterval,
 		batchSize:                          defaultBatchSize,
 		pollingScheduler:                   defaultPollingScheduler,
+		redisURL:                           defaultRedisURL,
+		redisCacheTTL:                      defaultRedisCacheTTL,
 		schedulerEntryFrequencyMinInterval: defaultSchedulerEntryFrequencyMinInterval,
 		schedulerEntryFrequencyMaxInterval: defaultSchedulerEntryFrequencyMaxInterval,
 		schedulerEntryFrequencyFactor:      defaultSchedulerEntryFrequencyFactor,
+		schedulerFeedRefreshMinInterval:    defaultSchedulerFeedRefreshMinInterval,
 		schedulerRoundRobinMinInterval:     defaultSchedulerRoundRobinMinInterval,
 		pollingParsingErrorLimit:           defaultPollingParsingErrorLimit,
 		workerPoolSize:                     defaultWorkerPoolSize,
+		workerPoolHostConcurrency:          defaultWorkerPoolHostConcurrency,
 		createAdmin:                        defaultCreateAdmin,
 		mediaProxyHTTPClientTimeout:        defaultMediaProxyHTTPClientTimeout,
 		mediaProxyMode:                     defaultMediaProxyMode,
@@ -225,20 +374,36 @@ func NewOptions() *Options {
 		fetchOdyseeWatchTime:               defaultFetchOdyseeWatchTime,
 		fetchYouTubeWatchTime:              defaultFetchYouTubeWatchTime,
 		youTubeEmbedUrlOverride:            defaultYouTubeEmbedUrlOverride,
+		alternativeFrontends:               []string{},
 		oauth2UserCreationAllowed:          defaultOAuth2UserCreation,
 		oauth2ClientID:                     defaultOAuth2ClientID,
 		oauth2ClientSecret:                 defaultOAuth2ClientSecret,
 		oauth2RedirectURL:                  defaultOAuth2RedirectURL,
 		oidcDiscoveryEndpoint:              defaultOAuth2OidcDiscoveryEndpoint,
+		oidcGroupsClaim:                    defaultOAuth2OidcGroupsClaim,
+		oidcAdminGroups:                    []string{},
+		oidcGroupCategories:                []string{},
+		oidcProviders:                      make(map[string]*OIDCProvider),
 		oauth2Provider:                     defaultOAuth2Provider,
 		pocketConsumerKey:                  defaultPocketConsumerKey,
 		httpClientTimeout:                  defaultHTTPClientTimeout,
 		httpClientMaxBodySize:              defaultHTTPClientMaxBodySize * 1024 * 1024,
 		httpClientProxy:                    defaultHTTPClientProxy,
+		headlessBrowserURL:                 defaultHeadlessBrowserURL,
+		headlessBrowserAllowedDomains:      []string{},
+		scraperCacheTTL:                    defaultScraperCacheTTL,
 		httpClientUserAgent:                defaultHTTPClientUserAgent,
 		httpServerTimeout:                  defaultHTTPServerTimeout,
+		iframeAllowedDomains:               []string{},
+		shutdownTimeout:                    defaultShutdownTimeout,
 		authProxyHeader:                    defaultAuthProxyHeader,
 		authProxyUserCreation:              defaultAuthProxyUserCreation,
+		authProxyNameHeader:                defaultAuthProxyNameHeader,
+		authProxyEmailHeader:               defaultAuthProxyEmailHeader,
+		authProxyGroupsHeader:              defaultAuthProxyGroupsHeader,
+		authProxyUserOPMLFile:              defaultAuthProxyUserOPMLFile,
+		otelExporterOTLPEndpoint:           defaultOTelExporterOTLPEndpoint,
+		otelServiceName:                    defaultOTelServiceName,
 		maintenanceMode:                    defaultMaintenanceMode,
 		maintenanceMessage:                 defaultMaintenanceMessage,
 		metricsCollector:                   defaultMetricsCollector,
@@ -248,8 +413,49 @@ func NewOptions() *Options {
 		metricsPassword:                    defaultMetricsPassword,
 		watchdog:                           defaultWatchdog,
 		invidiousInstance:                  defaultInvidiousInstance,
+		iconServiceURL:                     defaultIconServiceURL,
+		iconStorageURL:                     defaultIconStorageURL,
+		siteRulesFile:                      defaultSiteRulesFile,
+		rewriteScripts:                     defaultRewriteScripts,
+		translationProvider:                defaultTranslationProvider,
+		translationAPIURL:                  defaultTranslationAPIURL,
+		translationAPIKey:                  defaultTranslationAPIKey,
+		trackingParameters:                 []string{},
+		trackingParametersExceptions:       []string{},
+		iconRefreshDays:                    defaultIconRefreshDays,
+		iconMaxSize:                        defaultIconMaxSize * 1024,
+		iconMaxDimension:                   defaultIconMaxDimension,
+		iconRetryCooldownHours:             defaultIconRetryCooldownHours,
 		mediaProxyPrivateKey:               crypto.GenerateRandomBytes(16),
 		webAuthn:                           defaultWebAuthn,
+		webSub:                             defaultWebSub,
+		totpRequired:                       defaultTOTPRequired,
+		ldapURL:                            defaultLDAPURL,
+		ldapBindDN:                         defaultLDAPBindDN,
+		ldapBindPassword:                   defaultLDAPBindPassword,
+		ldapBaseDN:                         defaultLDAPBaseDN,
+		ldapUserFilter:                     defaultLDAPUserFilter,
+		ldapGroupFilter:                    defaultLDAPGroupFilter,
+		ldapStartTLS:                       defaultLDAPStartTLS,
+		ldapTLSSkipVerify:                  defaultLDAPTLSSkipVerify,
+		ldapUserCreation:                   defaultLDAPUserCreation,
+		samlIdPMetadataURL:                 defaultSAMLIdPMetadataURL,
+		samlIdPMetadataFile:                defaultSAMLIdPMetadataFile,
+		samlSPEntityID:                     defaultSAMLSPEntityID,
+		samlUsernameAttribute:              defaultSAMLUsernameAttribute,
+		samlEmailAttribute:                 defaultSAMLEmailAttribute,
+		samlAdminAttribute:                 defaultSAMLAdminAttribute,
+		samlAdminValue:                     defaultSAMLAdminValue,
+		samlUserCreation:                   defaultSAMLUserCreation,
+		scimToken:                          defaultSCIMToken,
+		smtpHost:                           defaultSMTPHost,
+		smtpPort:                           defaultSMTPPort,
+		smtpUsername:                       defaultSMTPUsername,
+		smtpPassword:                       defaultSMTPPassword,
+		smtpFrom:                           defaultSMTPFrom,
+		smtpImplicitTLS:                    defaultSMTPImplicitTLS,
+		newsletterListenAddr:               defaultNewsletterListenAddr,
+		newsletterDomain:                   defaultNewsletterDomain,
 	}
 }
 
@@ -269,11 +475,15 @@ func (o *Options) LogFormat() string {
 
 // LogLevel returns the log level.
 func (o *Options) LogLevel() string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
 	return o.logLevel
 }
 
 // SetLogLevel sets the log level.
 func (o *Options) SetLogLevel(level string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	o.logLevel = level
 }
 
@@ -372,6 +582,21 @@ func (o *Options) CleanupArchiveBatchSize() int {
 	return o.cleanupArchiveBatchSize
 }
 
+// LoginMaxFailedAttempts returns the number of failed logins allowed for an account before it is temporarily locked.
+func (o *Options) LoginMaxFailedAttempts() int {
+	return o.loginMaxFailedAttempts
+}
+
+// LoginMaxFailedAttemptsPerIP returns the number of failed logins allowed from a single IP address before it is temporarily blocked.
+func (o *Options) LoginMaxFailedAttemptsPerIP() int {
+	return o.loginMaxFailedAttemptsPerIP
+}
+
+// LoginLockoutDuration returns the time window used to count failed logins and the duration of the lockout.
+func (o *Options) LoginLockoutDuration() time.Duration {
+	return time.Duration(o.loginLockoutDuration) * time.Minute
+}
+
 // CleanupRemoveSessionsDays returns the number of days after which to remove sessions.
 func (o *Options) CleanupRemoveSessionsDays() int {
 	return o.cleanupRemoveSessionsDays
@@ -382,8 +607,15 @@ func (o *Options) WorkerPoolSize() int {
 	return o.workerPoolSize
 }
 
+// WorkerPoolHostConcurrency returns the maximum number of feeds of the same host refreshed at the same time.
+func (o *Options) WorkerPoolHostConcurrency() int {
+	return o.workerPoolHostConcurrency
+}
+
 // PollingFrequency returns the interval to refresh feeds in the background.
 func (o *Options) PollingFrequency() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
 	return o.pollingFrequency
 }
 
@@ -402,6 +634,21 @@ func (o *Options) PollingScheduler() string {
 	return o.pollingScheduler
 }
 
+// HasRedisCache returns true if sessions and counters are cached in Redis.
+func (o *Options) HasRedisCache() bool {
+	return o.redisURL != ""
+}
+
+// RedisURL returns the Redis connection URL.
+func (o *Options) RedisURL() string {
+	return o.redisURL
+}
+
+// RedisCacheTTL returns the time to live of the cached values.
+func (o *Options) RedisCacheTTL() time.Duration {
+	return time.Duration(o.redisCacheTTL) * time.Second
+}
+
 // SchedulerEntryFrequencyMaxInterval returns the maximum interval in minutes for the entry frequency scheduler.
 func (o *Options) SchedulerEntryFrequencyMaxInterval() int {
 	return o.schedulerEntryFrequencyMaxInterval
@@ -417,6 +664,11 @@ func (o *Options) SchedulerEntryFrequencyFactor() int {
 	return o.schedulerEntryFrequencyFactor
 }
 
+// SchedulerFeedRefreshMinInterval returns the minimum refresh interval in minutes that users can set on a feed.
+func (o *Options) SchedulerFeedRefreshMinInterval() int {
+	return o.schedulerFeedRefreshMinInterval
+}
+
 func (o *Options) SchedulerRoundRobinMinInterval() int {
 	return o.schedulerRoundRobinMinInterval
 }
@@ -426,6 +678,32 @@ func (o *Options) PollingParsingErrorLimit() int {
 	return o.pollingParsingErrorLimit
 }
 
+// HasPollingQuietHours returns true if the scheduler must not refresh feeds during a period of the day.
+func (o *Options) HasPollingQuietHours() bool {
+	return o.pollingQuietHoursStart != o.pollingQuietHoursEnd
+}
+
+// IsPollingQuietTime returns true if the given time, in the local time of the server, is within the quiet hours.
+// The period can span midnight, for example "22:00-06:00".
+func (o *Options) IsPollingQuietTime(t time.Time) bool {
+	if !o.HasPollingQuietHours() {
+		return false
+	}
+
+	clock := t.Format("15:04")
+	if o.pollingQuietHoursStart < o.pollingQuietHoursEnd {
+		return clock >= o.pollingQuietHoursStart && clock < o.pollingQuietHoursEnd
+	}
+	return clock >= o.pollingQuietHoursStart || clock < o.pollingQuietHoursEnd
+}
+
+func (o *Options) pollingQuietHours() string {
+	if !o.HasPollingQuietHours() {
+		return ""
+	}
+	return o.pollingQuietHoursStart + "-" + o.pollingQuietHoursEnd
+}
+
 // IsOAuth2UserCreationAllowed returns true if user creation is allowed for OAuth2 users.
 func (o *Options) IsOAuth2UserCreationAllowed() bool {
 	return o.oauth2UserCreationAllowed
@@ -451,6 +729,50 @@ func (o *Options) OIDCDiscoveryEndpoint() string {
 	return o.oidcDiscoveryEndpoint
 }
 
+// OIDCProviders returns the additional OpenID Connect providers sorted by ID, incomplete providers are ignored.
+func (o *Options) OIDCProviders() []*OIDCProvider {
+	var providers []*OIDCProvider
+	for _, provider := range o.oidcProviders {
+		if provider.ClientID == "" || provider.DiscoveryEndpoint == "" {
+			continue
+		}
+
+		result := *provider
+		if result.Name == "" {
+			result.Name = result.ID
+		}
+		result.RedirectURL = o.baseURL + "/oauth2/" + result.ID + "/callback"
+		providers = append(providers, &result)
+	}
+
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].ID < providers[j].ID
+	})
+
+	return providers
+}
+
+// OIDCGroupsClaim returns the name of the claim listing the groups of the user, nested claims are separated by dots.
+func (o *Options) OIDCGroupsClaim() string {
+	return o.oidcGroupsClaim
+}
+
+// OIDCAdminGroups returns the groups whose members are administrators, the role is not synchronized when empty.
+func (o *Options) OIDCAdminGroups() []string {
+	return o.oidcAdminGroups
+}
+
+// OIDCGroupCategories returns the categories created for the members of each group when the user is provisioned.
+func (o *Options) OIDCGroupCategories() map[string]string {
+	categories := make(map[string]string, len(o.oidcGroupCategories))
+	for _, mapping := range o.oidcGroupCategories {
+		if group, category, found := strings.Cut(mapping, "="); found && group != "" && category != "" {
+			categories[strings.TrimSpace(group)] = strings.TrimSpace(category)
+		}
+	}
+	return categories
+}
+
 // OAuth2Provider returns the name of the OAuth2 provider configured.
 func (o *Options) OAuth2Provider() string {
 	return o.oauth2Provider
@@ -461,6 +783,11 @@ func (o *Options) HasHSTS() bool {
 	return o.hsts
 }
 
+// HasSyntaxHighlighting returns true if the code blocks of the entries are highlighted.
+func (o *Options) HasSyntaxHighlighting() bool {
+	return o.syntaxHighlighting
+}
+
 // RunMigrations returns true if the environment variable RUN_MIGRATIONS is not empty.
 func (o *Options) RunMigrations() bool {
 	return o.runMigrations
@@ -476,6 +803,12 @@ func (o *Options) AdminUsername() string {
 	return o.adminUsername
 }
 
+// AdminAllowedNetworks returns the list of networks allowed to use the administration features.
+// An empty list means no restriction.
+func (o *Options) AdminAllowedNetworks() []string {
+	return o.adminAllowedNetworks
+}
+
 // AdminPassword returns the admin password if defined.
 func (o *Options) AdminPassword() string {
 	return o.adminPassword
@@ -492,6 +825,22 @@ func (o *Options) YouTubeEmbedUrlOverride() string {
 	return o.youTubeEmbedUrlOverride
 }
 
+// SubscriptionAlternativeFrontends returns the base URL of the privacy frontend replacing each website during the subscription discovery.
+// The keys are lowercase hostnames, the subdomains of a hostname use the same frontend.
+func (o *Options) SubscriptionAlternativeFrontends() map[string]string {
+	frontends := make(map[string]string, len(o.alternativeFrontends))
+	for _, mapping := range o.alternativeFrontends {
+		if hostname, frontendURL, found := strings.Cut(mapping, "="); found {
+			hostname = strings.ToLower(strings.TrimSpace(hostname))
+			frontendURL = strings.TrimSuffix(strings.TrimSpace(frontendURL), "/")
+			if hostname != "" && frontendURL != "" {
+				frontends[hostname] = frontendURL
+			}
+		}
+	}
+	return frontends
+}
+
 // FetchNebulaWatchTime returns true if the Nebula video duration
 // should be fetched and used as a reading time.
 func (o *Options) FetchNebulaWatchTime() bool {
@@ -565,17 +914,55 @@ func (o *Options) HTTPClientMaxBodySize() int64 {
 
 // HTTPClientProxy returns the proxy URL for HTTP client.
 func (o *Options) HTTPClientProxy() string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
 	return o.httpClientProxy
 }
 
+// HeadlessBrowserURL returns the endpoint of the service rendering the web pages with a headless browser.
+func (o *Options) HeadlessBrowserURL() string {
+	return o.headlessBrowserURL
+}
+
+// HasHeadlessBrowser returns true if a headless browser rendering service is configured.
+func (o *Options) HasHeadlessBrowser() bool {
+	return o.headlessBrowserURL != ""
+}
+
+// HeadlessBrowserAllowedDomains returns the lowercase domains that can be rendered with the headless browser.
+// An empty list denies all the domains.
+func (o *Options) HeadlessBrowserAllowedDomains() []string {
+	return lowercaseList(o.headlessBrowserAllowedDomains)
+}
+
+// ScraperCacheTTL returns the duration during which the content scraped from a web page is reused for all the feeds.
+func (o *Options) ScraperCacheTTL() time.Duration {
+	return time.Duration(o.scraperCacheTTL) * time.Minute
+}
+
+// HasScraperCache returns true if the scraped contents are shared between the feeds.
+func (o *Options) HasScraperCache() bool {
+	return o.scraperCacheTTL > 0
+}
+
+// IframeAllowedDomains returns the lowercase domains whose iframes are kept by the sanitizer for all the users.
+func (o *Options) IframeAllowedDomains() []string {
+	return lowercaseList(o.iframeAllowedDomains)
+}
+
 // HTTPServerTimeout returns the time limit in seconds before the HTTP server cancel the request.
 func (o *Options) HTTPServerTimeout() int {
 	return o.httpServerTimeout
 }
 
+// ShutdownTimeout returns the time limit in seconds given to the HTTP connections and the background workers to finish during shutdown.
+func (o *Options) ShutdownTimeout() int {
+	return o.shutdownTimeout
+}
+
 // HasHTTPClientProxyConfigured returns true if the HTTP proxy is configured.
 func (o *Options) HasHTTPClientProxyConfigured() bool {
-	return o.httpClientProxy != ""
+	return o.HTTPClientProxy() != ""
 }
 
 // AuthProxyHeader returns an HTTP header name that contains username for
@@ -590,6 +977,51 @@ func (o *Options) IsAuthProxyUserCreationAllowed() bool {
 	return o.authProxyUserCreation
 }
 
+// AuthProxyNameHeader returns the HTTP header that contains the full name of the user authenticated by the proxy.
+func (o *Options) AuthProxyNameHeader() string {
+	return o.authProxyNameHeader
+}
+
+// AuthProxyEmailHeader returns the HTTP header that contains the email address of the user authenticated by the proxy.
+func (o *Options) AuthProxyEmailHeader() string {
+	return o.authProxyEmailHeader
+}
+
+// AuthProxyGroupsHeader returns the HTTP header that contains the comma-separated groups of the user authenticated by the proxy.
+func (o *Options) AuthProxyGroupsHeader() string {
+	return o.authProxyGroupsHeader
+}
+
+// AuthProxyAdminGroups returns the groups granting administrator privileges to users authenticated by the proxy.
+func (o *Options) AuthProxyAdminGroups() []string {
+	return o.authProxyAdminGroups
+}
+
+// AuthProxyUserOPMLFile returns the path of the OPML file imported for users created by the proxy authentication.
+func (o *Options) AuthProxyUserOPMLFile() string {
+	return o.authProxyUserOPMLFile
+}
+
+// HasTracing returns true if the spans must be exported to an OpenTelemetry collector.
+func (o *Options) HasTracing() bool {
+	return o.otelExporterOTLPEndpoint != ""
+}
+
+// OTelExporterOTLPEndpoint returns the base URL of the OTLP/HTTP collector.
+func (o *Options) OTelExporterOTLPEndpoint() string {
+	return o.otelExporterOTLPEndpoint
+}
+
+// OTelExporterOTLPHeaders returns the "key=value" headers sent to the OTLP/HTTP collector.
+func (o *Options) OTelExporterOTLPHeaders() []string {
+	return o.otelExporterOTLPHeaders
+}
+
+// OTelServiceName returns the service name attached to the exported spans.
+func (o *Options) OTelServiceName() string {
+	return o.otelServiceName
+}
+
 // HasMetricsCollector returns true if metrics collection is enabled.
 func (o *Options) HasMetricsCollector() bool {
 	return o.metricsCollector
@@ -623,6 +1055,89 @@ func (o *Options) HasWatchdog() bool {
 	return o.watchdog
 }
 
+// IconServiceURL returns the URL template of the favicon service used when no icon is found on the website.
+func (o *Options) IconServiceURL() string {
+	return o.iconServiceURL
+}
+
+// HasIconService returns true if a favicon service is configured.
+func (o *Options) HasIconService() bool {
+	return o.iconServiceURL != ""
+}
+
+// HasIconStorage returns true if the icons are stored outside the database.
+func (o *Options) HasIconStorage() bool {
+	return o.iconStorageURL != ""
+}
+
+// IconStorageURL returns the URL of the directory or the S3 bucket where the icons are stored.
+func (o *Options) IconStorageURL() string {
+	return o.iconStorageURL
+}
+
+// SiteRulesFile returns the path or the URL of the file defining the scraper and rewrite rules of the websites.
+func (o *Options) SiteRulesFile() string {
+	return o.siteRulesFile
+}
+
+// TrackingParameters returns the lowercase query parameters removed from the URLs in addition to the built-in list.
+// The parameters written as "domain:parameter" are only removed from the URLs of the domain and its subdomains.
+func (o *Options) TrackingParameters() []string {
+	return lowercaseList(o.trackingParameters)
+}
+
+// TrackingParametersExceptions returns the lowercase "domain:parameter" pairs kept in the URLs of the domain and its subdomains.
+func (o *Options) TrackingParametersExceptions() []string {
+	return lowercaseList(o.trackingParametersExceptions)
+}
+
+// HasRewriteScripts returns true if the users can transform the entries of their feeds with Starlark scripts.
+func (o *Options) HasRewriteScripts() bool {
+	return o.rewriteScripts
+}
+
+// TranslationProvider returns the service translating the entries, "libretranslate" or "deepl".
+// An empty string means the translation is disabled.
+func (o *Options) TranslationProvider() string {
+	return o.translationProvider
+}
+
+// TranslationAPIURL returns the base URL of the translation service, the default one of the provider is used when empty.
+func (o *Options) TranslationAPIURL() string {
+	return o.translationAPIURL
+}
+
+// TranslationAPIKey returns the API key of the translation service.
+func (o *Options) TranslationAPIKey() string {
+	return o.translationAPIKey
+}
+
+// HasTranslation returns true if a translation service is configured.
+func (o *Options) HasTranslation() bool {
+	return o.translationProvider != ""
+}
+
+// IconMaxSize returns the maximum number of bytes of an icon.
+func (o *Options) IconMaxSize() int64 {
+	return o.iconMaxSize
+}
+
+// IconMaxDimension returns the maximum width and height in pixels of the icons decoded before being resized.
+func (o *Options) IconMaxDimension() int {
+	return o.iconMaxDimension
+}
+
+// IconRefreshDays returns the number of days after which feed icons are downloaded again, 0 disables the refresh.
+func (o *Options) IconRefreshDays() int {
+	return o.iconRefreshDays
+}
+
+// IconRetryCooldownHours returns the number of hours to wait before looking for the icon of a feed again after a failure.
+// The delay is doubled after each consecutive failure, 0 disables the cooldown.
+func (o *Options) IconRetryCooldownHours() int {
+	return o.iconRetryCooldownHours
+}
+
 // InvidiousInstance returns the invidious instance used by miniflux
 func (o *Options) InvidiousInstance() string {
 	return o.invidiousInstance
@@ -633,18 +1148,220 @@ func (o *Options) WebAuthn() bool {
 	return o.webAuthn
 }
 
+// WebSub returns true if the feeds are subscribed to the WebSub hubs they advertise.
+func (o *Options) WebSub() bool {
+	return o.webSub
+}
+
+// TOTPRequired returns true if local accounts must enroll two-factor authentication.
+func (o *Options) TOTPRequired() bool {
+	return o.totpRequired
+}
+
+// HasLDAP returns true if users can authenticate with an LDAP directory.
+func (o *Options) HasLDAP() bool {
+	return o.ldapURL != ""
+}
+
+// LDAPURL returns the URL of the LDAP server.
+func (o *Options) LDAPURL() string {
+	return o.ldapURL
+}
+
+// LDAPBindDN returns the DN of the service account used to search users.
+func (o *Options) LDAPBindDN() string {
+	return o.ldapBindDN
+}
+
+// LDAPBindPassword returns the password of the service account used to search users.
+func (o *Options) LDAPBindPassword() string {
+	return o.ldapBindPassword
+}
+
+// LDAPBaseDN returns the DN below which users are searched.
+func (o *Options) LDAPBaseDN() string {
+	return o.ldapBaseDN
+}
+
+// LDAPUserFilter returns the search filter of users, %s is replaced by the username.
+func (o *Options) LDAPUserFilter() string {
+	return o.ldapUserFilter
+}
+
+// LDAPGroupFilter returns the additional filter users must match, e.g. a group membership.
+func (o *Options) LDAPGroupFilter() string {
+	return o.ldapGroupFilter
+}
+
+// LDAPStartTLS returns true if the connection must be upgraded with StartTLS.
+func (o *Options) LDAPStartTLS() bool {
+	return o.ldapStartTLS
+}
+
+// LDAPTLSSkipVerify returns true if the certificate of the LDAP server must not be verified.
+func (o *Options) LDAPTLSSkipVerify() bool {
+	return o.ldapTLSSkipVerify
+}
+
+// IsLDAPUserCreationAllowed returns true if users authenticated by LDAP are created on their first login.
+func (o *Options) IsLDAPUserCreationAllowed() bool {
+	return o.ldapUserCreation
+}
+
+// HasSAML returns true if users can authenticate with a SAML identity provider.
+func (o *Options) HasSAML() bool {
+	return o.samlIdPMetadataURL != "" || o.samlIdPMetadataFile != ""
+}
+
+// SAMLIdPMetadataURL returns the URL of the identity provider metadata.
+func (o *Options) SAMLIdPMetadataURL() string {
+	return o.samlIdPMetadataURL
+}
+
+// SAMLIdPMetadataFile returns the path of the identity provider metadata file.
+func (o *Options) SAMLIdPMetadataFile() string {
+	return o.samlIdPMetadataFile
+}
+
+// SAMLSPEntityID returns the entity ID of Miniflux, the URL of the service provider metadata by default.
+func (o *Options) SAMLSPEntityID() string {
+	if o.samlSPEntityID == "" {
+		return o.baseURL + "/saml/metadata"
+	}
+	return o.samlSPEntityID
+}
+
+// SAMLUsernameAttribute returns the assertion attribute used as username, the NameID is used when empty.
+func (o *Options) SAMLUsernameAttribute() string {
+	return o.samlUsernameAttribute
+}
+
+// SAMLEmailAttribute returns the assertion attribute used as username when the username attribute is missing.
+func (o *Options) SAMLEmailAttribute() string {
+	return o.samlEmailAttribute
+}
+
+// SAMLAdminAttribute returns the assertion attribute that grants administrator privileges.
+func (o *Options) SAMLAdminAttribute() string {
+	return o.samlAdminAttribute
+}
+
+// SAMLAdminValue returns the value of the admin attribute that grants administrator privileges.
+func (o *Options) SAMLAdminValue() string {
+	return o.samlAdminValue
+}
+
+// IsSAMLUserCreationAllowed returns true if users authenticated by SAML are created on their first login.
+func (o *Options) IsSAMLUserCreationAllowed() bool {
+	return o.samlUserCreation
+}
+
+// HasSCIM returns true if the SCIM provisioning endpoint is enabled.
+func (o *Options) HasSCIM() bool {
+	return o.scimToken != ""
+}
+
+// SCIMToken returns the bearer token used by identity providers to call the SCIM endpoint.
+func (o *Options) SCIMToken() string {
+	return o.scimToken
+}
+
 // FilterEntryMaxAgeDays returns the number of days after which entries should be retained.
 func (o *Options) FilterEntryMaxAgeDays() int {
 	return o.filterEntryMaxAgeDays
 }
 
+// SMTPHost returns the hostname of the SMTP server used to send emails.
+func (o *Options) SMTPHost() string {
+	return o.smtpHost
+}
+
+// SMTPPort returns the port of the SMTP server.
+func (o *Options) SMTPPort() int {
+	return o.smtpPort
+}
+
+// SMTPUsername returns the username used to authenticate with the SMTP server.
+func (o *Options) SMTPUsername() string {
+	return o.smtpUsername
+}
+
+// SMTPPassword returns the password used to authenticate with the SMTP server.
+func (o *Options) SMTPPassword() string {
+	return o.smtpPassword
+}
+
+// SMTPFrom returns the sender address of outgoing emails.
+func (o *Options) SMTPFrom() string {
+	return o.smtpFrom
+}
+
+// SMTPImplicitTLS returns true if the SMTP connection uses TLS from the start instead of STARTTLS.
+func (o *Options) SMTPImplicitTLS() bool {
+	return o.smtpImplicitTLS
+}
+
+// HasSMTP returns true if an SMTP server is configured.
+func (o *Options) HasSMTP() bool {
+	return o.smtpHost != "" && o.smtpFrom != ""
+}
+
+// NewsletterListenAddr returns the address of the SMTP listener receiving the newsletters.
+func (o *Options) NewsletterListenAddr() string {
+	return o.newsletterListenAddr
+}
+
+// NewsletterDomain returns the domain of the email addresses generated for the newsletter feeds.
+func (o *Options) NewsletterDomain() string {
+	return o.newsletterDomain
+}
+
+// HasNewsletterService returns true if the newsletters are received by email.
+func (o *Options) HasNewsletterService() bool {
+	return o.newsletterListenAddr != "" && o.newsletterDomain != ""
+}
+
+// Reload applies the options that can be changed without restarting the process
+// and returns the name of the options that have been modified.
+func (o *Options) Reload(newOpts *Options) []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var changes []string
+	if o.logLevel != newOpts.logLevel {
+		o.logLevel = newOpts.logLevel
+		changes = append(changes, "LOG_LEVEL")
+	}
+
+	if o.pollingFrequency != newOpts.pollingFrequency {
+		o.pollingFrequency = newOpts.pollingFrequency
+		changes = append(changes, "POLLING_FREQUENCY")
+	}
+
+	if o.httpClientProxy != newOpts.httpClientProxy {
+		o.httpClientProxy = newOpts.httpClientProxy
+		changes = append(changes, "HTTP_CLIENT_PROXY")
+	}
+
+	return changes
+}
+
 // SortedOptions returns options as a list of key value pairs, sorted by keys.
 func (o *Options) SortedOptions(redactSecret bool) []*Option {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
 	var keyValues = map[string]interface{}{
+		"ADMIN_ALLOWED_NETWORKS":                 strings.Join(o.adminAllowedNetworks, ","),
 		"ADMIN_PASSWORD":                         redactSecretValue(o.adminPassword, redactSecret),
 		"ADMIN_USERNAME":                         o.adminUsername,
+		"AUTH_PROXY_ADMIN_GROUPS":                strings.Join(o.authProxyAdminGroups, ","),
+		"AUTH_PROXY_EMAIL_HEADER":                o.authProxyEmailHeader,
+		"AUTH_PROXY_GROUPS_HEADER":               o.authProxyGroupsHeader,
 		"AUTH_PROXY_HEADER":                      o.authProxyHeader,
+		"AUTH_PROXY_NAME_HEADER":                 o.authProxyNameHeader,
 		"AUTH_PROXY_USER_CREATION":               o.authProxyUserCreation,
+		"AUTH_PROXY_USER_OPML_FILE":              o.authProxyUserOPMLFile,
 		"BASE_PATH":                              o.basePath,
 		"BASE_URL":                               o.baseURL,
 		"BATCH_SIZE":                             o.batchSize,
@@ -663,21 +1380,43 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"DISABLE_HSTS":                           !o.hsts,
 		"DISABLE_HTTP_SERVICE":                   !o.httpService,
 		"DISABLE_SCHEDULER_SERVICE":              !o.schedulerService,
+		"DISABLE_SYNTAX_HIGHLIGHTING":            !o.syntaxHighlighting,
 		"FILTER_ENTRY_MAX_AGE_DAYS":              o.filterEntryMaxAgeDays,
 		"FETCH_YOUTUBE_WATCH_TIME":               o.fetchYouTubeWatchTime,
 		"FETCH_NEBULA_WATCH_TIME":                o.fetchNebulaWatchTime,
 		"FETCH_ODYSEE_WATCH_TIME":                o.fetchOdyseeWatchTime,
 		"FETCH_BILIBILI_WATCH_TIME":              o.fetchBilibiliWatchTime,
 		"HTTPS":                                  o.HTTPS,
+		"HEADLESS_BROWSER_ALLOWED_DOMAINS":       strings.Join(o.headlessBrowserAllowedDomains, ","),
+		"HEADLESS_BROWSER_URL":                   redactSecretValue(o.headlessBrowserURL, redactSecret),
 		"HTTP_CLIENT_MAX_BODY_SIZE":              o.httpClientMaxBodySize,
 		"HTTP_CLIENT_PROXY":                      o.httpClientProxy,
 		"HTTP_CLIENT_TIMEOUT":                    o.httpClientTimeout,
 		"HTTP_CLIENT_USER_AGENT":                 o.httpClientUserAgent,
 		"HTTP_SERVER_TIMEOUT":                    o.httpServerTimeout,
 		"HTTP_SERVICE":                           o.httpService,
+		"ICON_MAX_DIMENSION":                     o.iconMaxDimension,
+		"ICON_MAX_SIZE":                          o.iconMaxSize,
+		"ICON_REFRESH_DAYS":                      o.iconRefreshDays,
+		"ICON_RETRY_COOLDOWN_HOURS":              o.iconRetryCooldownHours,
+		"ICON_SERVICE_URL":                       o.iconServiceURL,
+		"ICON_STORAGE_URL":                       redactSecretValue(o.iconStorageURL, redactSecret),
+		"IFRAME_ALLOWED_DOMAINS":                 strings.Join(o.iframeAllowedDomains, ","),
 		"INVIDIOUS_INSTANCE":                     o.invidiousInstance,
 		"KEY_FILE":                               o.certKeyFile,
+		"LDAP_BASE_DN":                           o.ldapBaseDN,
+		"LDAP_BIND_DN":                           o.ldapBindDN,
+		"LDAP_BIND_PASSWORD":                     redactSecretValue(o.ldapBindPassword, redactSecret),
+		"LDAP_GROUP_FILTER":                      o.ldapGroupFilter,
+		"LDAP_START_TLS":                         o.ldapStartTLS,
+		"LDAP_TLS_SKIP_VERIFY":                   o.ldapTLSSkipVerify,
+		"LDAP_URL":                               o.ldapURL,
+		"LDAP_USER_CREATION":                     o.ldapUserCreation,
+		"LDAP_USER_FILTER":                       o.ldapUserFilter,
 		"LISTEN_ADDR":                            o.listenAddr,
+		"LOGIN_LOCKOUT_DURATION":                 o.loginLockoutDuration,
+		"LOGIN_MAX_FAILED_ATTEMPTS":              o.loginMaxFailedAttempts,
+		"LOGIN_MAX_FAILED_ATTEMPTS_PER_IP":       o.loginMaxFailedAttemptsPerIP,
 		"LOG_FILE":                               o.logFile,
 		"LOG_DATE_TIME":                          o.logDateTime,
 		"LOG_FORMAT":                             o.logFormat,
@@ -689,34 +1428,82 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"METRICS_PASSWORD":                       redactSecretValue(o.metricsPassword, redactSecret),
 		"METRICS_REFRESH_INTERVAL":               o.metricsRefreshInterval,
 		"METRICS_USERNAME":                       o.metricsUsername,
+		"NEWSLETTER_DOMAIN":                      o.newsletterDomain,
+		"NEWSLETTER_LISTEN_ADDR":                 o.newsletterListenAddr,
 		"OAUTH2_CLIENT_ID":                       o.oauth2ClientID,
 		"OAUTH2_CLIENT_SECRET":                   redactSecretValue(o.oauth2ClientSecret, redactSecret),
+		"OAUTH2_OIDC_ADMIN_GROUPS":               strings.Join(o.oidcAdminGroups, ","),
 		"OAUTH2_OIDC_DISCOVERY_ENDPOINT":         o.oidcDiscoveryEndpoint,
+		"OAUTH2_OIDC_GROUPS_CLAIM":               o.oidcGroupsClaim,
+		"OAUTH2_OIDC_GROUP_CATEGORIES":           strings.Join(o.oidcGroupCategories, ","),
 		"OAUTH2_PROVIDER":                        o.oauth2Provider,
 		"OAUTH2_REDIRECT_URL":                    o.oauth2RedirectURL,
 		"OAUTH2_USER_CREATION":                   o.oauth2UserCreationAllowed,
+		"OTEL_EXPORTER_OTLP_ENDPOINT":            o.otelExporterOTLPEndpoint,
+		"OTEL_EXPORTER_OTLP_HEADERS":             redactSecretValue(strings.Join(o.otelExporterOTLPHeaders, ","), redactSecret),
+		"OTEL_SERVICE_NAME":                      o.otelServiceName,
 		"POCKET_CONSUMER_KEY":                    redactSecretValue(o.pocketConsumerKey, redactSecret),
 		"POLLING_FREQUENCY":                      o.pollingFrequency,
 		"FORCE_REFRESH_INTERVAL":                 o.forceRefreshInterval,
 		"POLLING_PARSING_ERROR_LIMIT":            o.pollingParsingErrorLimit,
+		"POLLING_QUIET_HOURS":                    o.pollingQuietHours(),
 		"POLLING_SCHEDULER":                      o.pollingScheduler,
 		"MEDIA_PROXY_HTTP_CLIENT_TIMEOUT":        o.mediaProxyHTTPClientTimeout,
 		"MEDIA_PROXY_RESOURCE_TYPES":             o.mediaProxyResourceTypes,
 		"MEDIA_PROXY_MODE":                       o.mediaProxyMode,
 		"MEDIA_PROXY_PRIVATE_KEY":                redactSecretValue(string(o.mediaProxyPrivateKey), redactSecret),
 		"MEDIA_PROXY_CUSTOM_URL":                 o.mediaProxyCustomURL,
+		"REDIS_CACHE_TTL":                        o.redisCacheTTL,
+		"REDIS_URL":                              redactSecretValue(o.redisURL, redactSecret),
 		"ROOT_URL":                               o.rootURL,
+		"REWRITE_SCRIPTS":                        o.rewriteScripts,
 		"RUN_MIGRATIONS":                         o.runMigrations,
+		"SAML_ADMIN_ATTRIBUTE":                   o.samlAdminAttribute,
+		"SAML_ADMIN_VALUE":                       o.samlAdminValue,
+		"SAML_EMAIL_ATTRIBUTE":                   o.samlEmailAttribute,
+		"SAML_IDP_METADATA_FILE":                 o.samlIdPMetadataFile,
+		"SAML_IDP_METADATA_URL":                  o.samlIdPMetadataURL,
+		"SAML_SP_ENTITY_ID":                      o.samlSPEntityID,
+		"SAML_USERNAME_ATTRIBUTE":                o.samlUsernameAttribute,
+		"SAML_USER_CREATION":                     o.samlUserCreation,
 		"SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL": o.schedulerEntryFrequencyMaxInterval,
 		"SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL": o.schedulerEntryFrequencyMinInterval,
 		"SCHEDULER_ENTRY_FREQUENCY_FACTOR":       o.schedulerEntryFrequencyFactor,
+		"SCHEDULER_FEED_REFRESH_MIN_INTERVAL":    o.schedulerFeedRefreshMinInterval,
 		"SCHEDULER_ROUND_ROBIN_MIN_INTERVAL":     o.schedulerRoundRobinMinInterval,
 		"SCHEDULER_SERVICE":                      o.schedulerService,
+		"SCRAPER_CACHE_TTL":                      o.scraperCacheTTL,
+		"SCIM_TOKEN":                             redactSecretValue(o.scimToken, redactSecret),
 		"SERVER_TIMING_HEADER":                   o.serverTimingHeader,
+		"SITE_RULES_FILE":                        o.siteRulesFile,
+		"SHUTDOWN_TIMEOUT":                       o.shutdownTimeout,
+		"SMTP_FROM":                              o.smtpFrom,
+		"SMTP_HOST":                              o.smtpHost,
+		"SMTP_IMPLICIT_TLS":                      o.smtpImplicitTLS,
+		"SMTP_PASSWORD":                          redactSecretValue(o.smtpPassword, redactSecret),
+		"SMTP_PORT":                              o.smtpPort,
+		"SMTP_USERNAME":                          o.smtpUsername,
+		"SUBSCRIPTION_ALTERNATIVE_FRONTENDS":     strings.Join(o.alternativeFrontends, ","),
+		"TRACKING_PARAMETERS":                    strings.Join(o.trackingParameters, ","),
+		"TRANSLATION_API_KEY":                    redactSecretValue(o.translationAPIKey, redactSecret),
+		"TRANSLATION_API_URL":                    o.translationAPIURL,
+		"TRANSLATION_PROVIDER":                   o.translationProvider,
+		"TRACKING_PARAMETERS_EXCEPTIONS":         strings.Join(o.trackingParametersExceptions, ","),
+		"TOTP_REQUIRED":                          o.totpRequired,
 		"WATCHDOG":                               o.watchdog,
+		"WORKER_POOL_HOST_CONCURRENCY":           o.workerPoolHostConcurrency,
 		"WORKER_POOL_SIZE":                       o.workerPoolSize,
 		"YOUTUBE_EMBED_URL_OVERRIDE":             o.youTubeEmbedUrlOverride,
 		"WEBAUTHN":                               o.webAuthn,
+		"WEBSUB":                                 o.webSub,
+	}
+
+	for _, provider := range o.oidcProviders {
+		prefix := "OAUTH2_OIDC_PROVIDER_" + strings.ToUpper(provider.ID)
+		keyValues[prefix+"_CLIENT_ID"] = provider.ClientID
+		keyValues[prefix+"_CLIENT_SECRET"] = redactSecretValue(provider.ClientSecret, redactSecret)
+		keyValues[prefix+"_DISCOVERY_ENDPOINT"] = provider.DiscoveryEndpoint
+		keyValues[prefix+"_NAME"] = provider.Name
 	}
 
 	keys := make([]string, 0, len(keyValues))
@@ -748,3 +1535,13 @@ func redactSecretValue(value string, redactSecret bool) string {
 	}
 	return value
 }
+
+func lowercaseList(values []string) []string {
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			items = append(items, value)
+		}
+	}
+	return items
+}
@@ -11,17 +11,24 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Parser handles configuration parsing.
 type Parser struct {
-	opts *Options
+	opts        *Options
+	unknownKeys []string
 }
 
+var oidcProviderIDRegex = regexp.MustCompile(`^[a-z0-9]+$`)
+
 // NewParser returns a new Parser.
 func NewParser() *Parser {
 	return &Parser{
@@ -39,6 +46,7 @@ func (p *Parser) ParseEnvironmentVariables() (*Options, error) {
 }
 
 // ParseFile loads configuration values from a local file.
+// Files with the ".toml" extension are parsed as TOML, other files contain one KEY=VALUE pair per line.
 func (p *Parser) ParseFile(filename string) (*Options, error) {
 	fp, err := os.Open(filename)
 	if err != nil {
@@ -46,10 +54,28 @@ func (p *Parser) ParseFile(filename string) (*Options, error) {
 	}
 	defer fp.Close()
 
-	err = p.parseLines(p.parseFileContent(fp))
+	isTOML := strings.EqualFold(filepath.Ext(filename), ".toml")
+
+	var lines []string
+	if isTOML {
+		lines, err = parseTOMLContent(fp)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		lines = p.parseFileContent(fp)
+	}
+
+	p.unknownKeys = nil
+	err = p.parseLines(lines)
 	if err != nil {
 		return nil, err
 	}
+
+	// Unlike the environment, a TOML file only contains Miniflux options: an unknown key is a typo.
+	if isTOML && len(p.unknownKeys) > 0 {
+		return nil, fmt.Errorf("config: unknown options in %s: %s", filename, strings.Join(p.unknownKeys, ", "))
+	}
 	return p.opts, nil
 }
 
@@ -126,6 +152,8 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.schedulerService = !parseBool(value, defaultSchedulerService)
 		case "DISABLE_HTTP_SERVICE":
 			p.opts.httpService = !parseBool(value, defaultHTTPService)
+		case "DISABLE_SYNTAX_HIGHLIGHTING":
+			p.opts.syntaxHighlighting = !parseBool(value, defaultSyntaxHighlighting)
 		case "CERT_FILE":
 			p.opts.certFile = parseString(value, defaultCertFile)
 		case "KEY_FILE":
@@ -142,14 +170,26 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.cleanupArchiveBatchSize = parseInt(value, defaultCleanupArchiveBatchSize)
 		case "CLEANUP_REMOVE_SESSIONS_DAYS":
 			p.opts.cleanupRemoveSessionsDays = parseInt(value, defaultCleanupRemoveSessionsDays)
+		case "LOGIN_MAX_FAILED_ATTEMPTS":
+			p.opts.loginMaxFailedAttempts = parseInt(value, defaultLoginMaxFailedAttempts)
+		case "LOGIN_MAX_FAILED_ATTEMPTS_PER_IP":
+			p.opts.loginMaxFailedAttemptsPerIP = parseInt(value, defaultLoginMaxFailedAttemptsPerIP)
+		case "LOGIN_LOCKOUT_DURATION":
+			p.opts.loginLockoutDuration = parseInt(value, defaultLoginLockoutDuration)
 		case "WORKER_POOL_SIZE":
 			p.opts.workerPoolSize = parseInt(value, defaultWorkerPoolSize)
+		case "WORKER_POOL_HOST_CONCURRENCY":
+			p.opts.workerPoolHostConcurrency = parseInt(value, defaultWorkerPoolHostConcurrency)
 		case "POLLING_FREQUENCY":
 			p.opts.pollingFrequency = parseInt(value, defaultPollingFrequency)
 		case "FORCE_REFRESH_INTERVAL":
 			p.opts.forceRefreshInterval = parseInt(value, defaultForceRefreshInterval)
 		case "BATCH_SIZE":
 			p.opts.batchSize = parseInt(value, defaultBatchSize)
+		case "REDIS_URL":
+			p.opts.redisURL = parseString(value, defaultRedisURL)
+		case "REDIS_CACHE_TTL":
+			p.opts.redisCacheTTL = parseInt(value, defaultRedisCacheTTL)
 		case "POLLING_SCHEDULER":
 			p.opts.pollingScheduler = strings.ToLower(parseString(value, defaultPollingScheduler))
 		case "SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL":
@@ -158,10 +198,17 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.schedulerEntryFrequencyMinInterval = parseInt(value, defaultSchedulerEntryFrequencyMinInterval)
 		case "SCHEDULER_ENTRY_FREQUENCY_FACTOR":
 			p.opts.schedulerEntryFrequencyFactor = parseInt(value, defaultSchedulerEntryFrequencyFactor)
+		case "SCHEDULER_FEED_REFRESH_MIN_INTERVAL":
+			p.opts.schedulerFeedRefreshMinInterval = parseInt(value, defaultSchedulerFeedRefreshMinInterval)
 		case "SCHEDULER_ROUND_ROBIN_MIN_INTERVAL":
 			p.opts.schedulerRoundRobinMinInterval = parseInt(value, defaultSchedulerRoundRobinMinInterval)
 		case "POLLING_PARSING_ERROR_LIMIT":
 			p.opts.pollingParsingErrorLimit = parseInt(value, defaultPollingParsingErrorLimit)
+		case "POLLING_QUIET_HOURS":
+			p.opts.pollingQuietHoursStart, p.opts.pollingQuietHoursEnd, err = parseClockRange(value)
+			if err != nil {
+				return fmt.Errorf("config: invalid POLLING_QUIET_HOURS: %w", err)
+			}
 		case "PROXY_IMAGES":
 			slog.Warn("The PROXY_IMAGES environment variable is deprecated, use MEDIA_PROXY_MODE instead")
 			p.opts.mediaProxyMode = parseString(value, defaultMediaProxyMode)
@@ -225,6 +272,12 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.oauth2RedirectURL = parseString(value, defaultOAuth2RedirectURL)
 		case "OAUTH2_OIDC_DISCOVERY_ENDPOINT":
 			p.opts.oidcDiscoveryEndpoint = parseString(value, defaultOAuth2OidcDiscoveryEndpoint)
+		case "OAUTH2_OIDC_GROUPS_CLAIM":
+			p.opts.oidcGroupsClaim = parseString(value, defaultOAuth2OidcGroupsClaim)
+		case "OAUTH2_OIDC_ADMIN_GROUPS":
+			p.opts.oidcAdminGroups = parseStringList(value, []string{})
+		case "OAUTH2_OIDC_GROUP_CATEGORIES":
+			p.opts.oidcGroupCategories = parseStringList(value, []string{})
 		case "OAUTH2_PROVIDER":
 			p.opts.oauth2Provider = parseString(value, defaultOAuth2Provider)
 		case "HTTP_CLIENT_TIMEOUT":
@@ -233,14 +286,40 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.httpClientMaxBodySize = int64(parseInt(value, defaultHTTPClientMaxBodySize) * 1024 * 1024)
 		case "HTTP_CLIENT_PROXY":
 			p.opts.httpClientProxy = parseString(value, defaultHTTPClientProxy)
+		case "HEADLESS_BROWSER_URL":
+			p.opts.headlessBrowserURL = parseString(value, defaultHeadlessBrowserURL)
+		case "HEADLESS_BROWSER_ALLOWED_DOMAINS":
+			p.opts.headlessBrowserAllowedDomains = parseStringList(value, []string{})
+		case "SCRAPER_CACHE_TTL":
+			p.opts.scraperCacheTTL = parseInt(value, defaultScraperCacheTTL)
 		case "HTTP_CLIENT_USER_AGENT":
 			p.opts.httpClientUserAgent = parseString(value, defaultHTTPClientUserAgent)
 		case "HTTP_SERVER_TIMEOUT":
 			p.opts.httpServerTimeout = parseInt(value, defaultHTTPServerTimeout)
+		case "IFRAME_ALLOWED_DOMAINS":
+			p.opts.iframeAllowedDomains = parseStringList(value, []string{})
+		case "SHUTDOWN_TIMEOUT":
+			p.opts.shutdownTimeout = parseInt(value, defaultShutdownTimeout)
 		case "AUTH_PROXY_HEADER":
 			p.opts.authProxyHeader = parseString(value, defaultAuthProxyHeader)
 		case "AUTH_PROXY_USER_CREATION":
 			p.opts.authProxyUserCreation = parseBool(value, defaultAuthProxyUserCreation)
+		case "AUTH_PROXY_NAME_HEADER":
+			p.opts.authProxyNameHeader = parseString(value, defaultAuthProxyNameHeader)
+		case "AUTH_PROXY_EMAIL_HEADER":
+			p.opts.authProxyEmailHeader = parseString(value, defaultAuthProxyEmailHeader)
+		case "AUTH_PROXY_GROUPS_HEADER":
+			p.opts.authProxyGroupsHeader = parseString(value, defaultAuthProxyGroupsHeader)
+		case "AUTH_PROXY_ADMIN_GROUPS":
+			p.opts.authProxyAdminGroups = parseStringList(value, nil)
+		case "AUTH_PROXY_USER_OPML_FILE":
+			p.opts.authProxyUserOPMLFile = parseString(value, defaultAuthProxyUserOPMLFile)
+		case "OTEL_EXPORTER_OTLP_ENDPOINT":
+			p.opts.otelExporterOTLPEndpoint = parseString(value, defaultOTelExporterOTLPEndpoint)
+		case "OTEL_EXPORTER_OTLP_HEADERS":
+			p.opts.otelExporterOTLPHeaders = parseStringList(value, nil)
+		case "OTEL_SERVICE_NAME":
+			p.opts.otelServiceName = parseString(value, defaultOTelServiceName)
 		case "MAINTENANCE_MODE":
 			p.opts.maintenanceMode = parseBool(value, defaultMaintenanceMode)
 		case "MAINTENANCE_MESSAGE":
@@ -249,6 +328,11 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.metricsCollector = parseBool(value, defaultMetricsCollector)
 		case "METRICS_REFRESH_INTERVAL":
 			p.opts.metricsRefreshInterval = parseInt(value, defaultMetricsRefreshInterval)
+		case "ADMIN_ALLOWED_NETWORKS":
+			p.opts.adminAllowedNetworks, err = parseNetworkList(value)
+			if err != nil {
+				return fmt.Errorf("config: invalid ADMIN_ALLOWED_NETWORKS: %w", err)
+			}
 		case "METRICS_ALLOWED_NETWORKS":
 			p.opts.metricsAllowedNetworks = parseStringList(value, []string{defaultMetricsAllowedNetworks})
 		case "METRICS_USERNAME":
@@ -269,12 +353,126 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.fetchYouTubeWatchTime = parseBool(value, defaultFetchYouTubeWatchTime)
 		case "YOUTUBE_EMBED_URL_OVERRIDE":
 			p.opts.youTubeEmbedUrlOverride = parseString(value, defaultYouTubeEmbedUrlOverride)
+		case "SUBSCRIPTION_ALTERNATIVE_FRONTENDS":
+			p.opts.alternativeFrontends = parseStringList(value, []string{})
 		case "WATCHDOG":
 			p.opts.watchdog = parseBool(value, defaultWatchdog)
 		case "INVIDIOUS_INSTANCE":
 			p.opts.invidiousInstance = parseString(value, defaultInvidiousInstance)
+		case "ICON_MAX_SIZE":
+			iconMaxSize := parseInt(value, defaultIconMaxSize)
+			if iconMaxSize <= 0 {
+				return fmt.Errorf("config: invalid ICON_MAX_SIZE: the size must be positive")
+			}
+			p.opts.iconMaxSize = int64(iconMaxSize * 1024)
+		case "ICON_MAX_DIMENSION":
+			iconMaxDimension := parseInt(value, defaultIconMaxDimension)
+			if iconMaxDimension <= 0 {
+				return fmt.Errorf("config: invalid ICON_MAX_DIMENSION: the dimension must be positive")
+			}
+			p.opts.iconMaxDimension = iconMaxDimension
+		case "ICON_REFRESH_DAYS":
+			p.opts.iconRefreshDays = parseInt(value, defaultIconRefreshDays)
+		case "ICON_RETRY_COOLDOWN_HOURS":
+			p.opts.iconRetryCooldownHours = parseInt(value, defaultIconRetryCooldownHours)
+		case "ICON_SERVICE_URL":
+			iconServiceURL := parseString(value, defaultIconServiceURL)
+			if err := validateIconServiceURL(iconServiceURL); err != nil {
+				return fmt.Errorf("config: invalid ICON_SERVICE_URL: %w", err)
+			}
+			p.opts.iconServiceURL = iconServiceURL
+		case "ICON_STORAGE_URL":
+			p.opts.iconStorageURL = parseString(value, defaultIconStorageURL)
+		case "SITE_RULES_FILE":
+			p.opts.siteRulesFile = parseString(value, defaultSiteRulesFile)
+		case "TRACKING_PARAMETERS":
+			p.opts.trackingParameters = parseStringList(value, []string{})
+		case "TRACKING_PARAMETERS_EXCEPTIONS":
+			p.opts.trackingParametersExceptions = parseStringList(value, []string{})
+		case "REWRITE_SCRIPTS":
+			p.opts.rewriteScripts = parseBool(value, defaultRewriteScripts)
+		case "TRANSLATION_PROVIDER":
+			translationProvider := strings.ToLower(parseString(value, defaultTranslationProvider))
+			if translationProvider != "" && translationProvider != "libretranslate" && translationProvider != "deepl" {
+				return fmt.Errorf(`config: invalid TRANSLATION_PROVIDER: the provider must be "libretranslate" or "deepl"`)
+			}
+			p.opts.translationProvider = translationProvider
+		case "TRANSLATION_API_URL":
+			p.opts.translationAPIURL = parseString(value, defaultTranslationAPIURL)
+		case "TRANSLATION_API_KEY":
+			p.opts.translationAPIKey = parseString(value, defaultTranslationAPIKey)
+		case "TRANSLATION_API_KEY_FILE":
+			p.opts.translationAPIKey = readSecretFile(value, defaultTranslationAPIKey)
 		case "WEBAUTHN":
 			p.opts.webAuthn = parseBool(value, defaultWebAuthn)
+		case "WEBSUB":
+			p.opts.webSub = parseBool(value, defaultWebSub)
+		case "LDAP_URL":
+			p.opts.ldapURL = parseString(value, defaultLDAPURL)
+		case "LDAP_BIND_DN":
+			p.opts.ldapBindDN = parseString(value, defaultLDAPBindDN)
+		case "LDAP_BIND_PASSWORD":
+			p.opts.ldapBindPassword = parseString(value, defaultLDAPBindPassword)
+		case "LDAP_BIND_PASSWORD_FILE":
+			p.opts.ldapBindPassword = readSecretFile(value, defaultLDAPBindPassword)
+		case "LDAP_BASE_DN":
+			p.opts.ldapBaseDN = parseString(value, defaultLDAPBaseDN)
+		case "LDAP_USER_FILTER":
+			p.opts.ldapUserFilter = parseString(value, defaultLDAPUserFilter)
+		case "LDAP_GROUP_FILTER":
+			p.opts.ldapGroupFilter = parseString(value, defaultLDAPGroupFilter)
+		case "LDAP_START_TLS":
+			p.opts.ldapStartTLS = parseBool(value, defaultLDAPStartTLS)
+		case "LDAP_TLS_SKIP_VERIFY":
+			p.opts.ldapTLSSkipVerify = parseBool(value, defaultLDAPTLSSkipVerify)
+		case "LDAP_USER_CREATION":
+			p.opts.ldapUserCreation = parseBool(value, defaultLDAPUserCreation)
+		case "SAML_IDP_METADATA_URL":
+			p.opts.samlIdPMetadataURL = parseString(value, defaultSAMLIdPMetadataURL)
+		case "SAML_IDP_METADATA_FILE":
+			p.opts.samlIdPMetadataFile = parseString(value, defaultSAMLIdPMetadataFile)
+		case "SAML_SP_ENTITY_ID":
+			p.opts.samlSPEntityID = parseString(value, defaultSAMLSPEntityID)
+		case "SAML_USERNAME_ATTRIBUTE":
+			p.opts.samlUsernameAttribute = parseString(value, defaultSAMLUsernameAttribute)
+		case "SAML_EMAIL_ATTRIBUTE":
+			p.opts.samlEmailAttribute = parseString(value, defaultSAMLEmailAttribute)
+		case "SAML_ADMIN_ATTRIBUTE":
+			p.opts.samlAdminAttribute = parseString(value, defaultSAMLAdminAttribute)
+		case "SAML_ADMIN_VALUE":
+			p.opts.samlAdminValue = parseString(value, defaultSAMLAdminValue)
+		case "SAML_USER_CREATION":
+			p.opts.samlUserCreation = parseBool(value, defaultSAMLUserCreation)
+		case "SCIM_TOKEN":
+			p.opts.scimToken = parseString(value, defaultSCIMToken)
+		case "SCIM_TOKEN_FILE":
+			p.opts.scimToken = readSecretFile(value, defaultSCIMToken)
+		case "TOTP_REQUIRED":
+			p.opts.totpRequired = parseBool(value, defaultTOTPRequired)
+		case "SMTP_HOST":
+			p.opts.smtpHost = parseString(value, defaultSMTPHost)
+		case "SMTP_PORT":
+			p.opts.smtpPort = parseInt(value, defaultSMTPPort)
+		case "SMTP_USERNAME":
+			p.opts.smtpUsername = parseString(value, defaultSMTPUsername)
+		case "SMTP_PASSWORD":
+			p.opts.smtpPassword = parseString(value, defaultSMTPPassword)
+		case "SMTP_PASSWORD_FILE":
+			p.opts.smtpPassword = readSecretFile(value, defaultSMTPPassword)
+		case "SMTP_FROM":
+			p.opts.smtpFrom = parseString(value, defaultSMTPFrom)
+		case "SMTP_IMPLICIT_TLS":
+			p.opts.smtpImplicitTLS = parseBool(value, defaultSMTPImplicitTLS)
+		case "NEWSLETTER_LISTEN_ADDR":
+			p.opts.newsletterListenAddr = parseString(value, defaultNewsletterListenAddr)
+		case "NEWSLETTER_DOMAIN":
+			p.opts.newsletterDomain = strings.ToLower(parseString(value, defaultNewsletterDomain))
+		default:
+			if strings.HasPrefix(key, "OAUTH2_OIDC_PROVIDER_") {
+				p.parseOIDCProvider(strings.TrimPrefix(key, "OAUTH2_OIDC_PROVIDER_"), value)
+			} else {
+				p.unknownKeys = append(p.unknownKeys, key)
+			}
 		}
 	}
 
@@ -284,6 +482,41 @@ func (p *Parser) parseLines(lines []string) (err error) {
 	return nil
 }
 
+// parseOIDCProvider handles the OAUTH2_OIDC_PROVIDER_<ID>_<SETTING> options of additional OpenID Connect providers.
+func (p *Parser) parseOIDCProvider(key, value string) {
+	for _, setting := range []string{"_CLIENT_ID", "_CLIENT_SECRET_FILE", "_CLIENT_SECRET", "_DISCOVERY_ENDPOINT", "_NAME"} {
+		if !strings.HasSuffix(key, setting) {
+			continue
+		}
+
+		id := strings.ToLower(strings.TrimSuffix(key, setting))
+		if !oidcProviderIDRegex.MatchString(id) || id == "google" || id == "oidc" {
+			slog.Warn("Ignoring OpenID Connect provider with an invalid ID", slog.String("id", id))
+			return
+		}
+
+		provider, found := p.opts.oidcProviders[id]
+		if !found {
+			provider = &OIDCProvider{ID: id}
+			p.opts.oidcProviders[id] = provider
+		}
+
+		switch setting {
+		case "_CLIENT_ID":
+			provider.ClientID = parseString(value, "")
+		case "_CLIENT_SECRET_FILE":
+			provider.ClientSecret = readSecretFile(value, "")
+		case "_CLIENT_SECRET":
+			provider.ClientSecret = parseString(value, "")
+		case "_DISCOVERY_ENDPOINT":
+			provider.DiscoveryEndpoint = parseString(value, "")
+		case "_NAME":
+			provider.Name = parseString(value, "")
+		}
+		return
+	}
+}
+
 func parseBaseURL(value string) (string, string, string, error) {
 	if value == "" {
 		return defaultBaseURL, defaultRootURL, "", nil
@@ -341,6 +574,59 @@ func parseString(value string, fallback string) string {
 	return value
 }
 
+func parseNetworkList(value string) ([]string, error) {
+	networks := parseStringList(value, nil)
+	for _, network := range networks {
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			return nil, err
+		}
+	}
+	return networks, nil
+}
+
+// parseClockRange parses a period of the day like "01:00-06:00".
+func parseClockRange(value string) (string, string, error) {
+	if value == "" {
+		return "", "", nil
+	}
+
+	start, end, found := strings.Cut(value, "-")
+	if !found {
+		return "", "", errors.New(`the period must be formatted as "HH:MM-HH:MM"`)
+	}
+
+	start, end = strings.TrimSpace(start), strings.TrimSpace(end)
+	for _, clock := range []string{start, end} {
+		if _, err := time.Parse("15:04", clock); err != nil || len(clock) != 5 {
+			return "", "", fmt.Errorf("invalid time %q", clock)
+		}
+	}
+
+	return start, end, nil
+}
+
+// validateIconServiceURL checks that the favicon service is an HTTP URL with a placeholder for the website.
+func validateIconServiceURL(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if !strings.Contains(value, "{hostname}") && !strings.Contains(value, "{url}") {
+		return errors.New("the URL must contain the {hostname} or {url} placeholder")
+	}
+
+	parsedURL, err := url.Parse(strings.NewReplacer("{hostname}", "", "{url}", "").Replace(value))
+	if err != nil {
+		return err
+	}
+
+	if scheme := strings.ToLower(parsedURL.Scheme); (scheme != "http" && scheme != "https") || parsedURL.Host == "" {
+		return errors.New("the URL must be an absolute HTTP URL")
+	}
+
+	return nil
+}
+
 func parseStringList(value string, fallback []string) []string {
 	if value == "" {
 		return fallback
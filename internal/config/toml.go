@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config // import "miniflux.app/v2/internal/config"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseTOMLContent converts a TOML document into environment-like lines.
+//
+// Only the subset of TOML needed to express Miniflux options is supported:
+// comments, tables, bare or dotted keys, strings, integers, floats, booleans and single-line arrays.
+// Keys are mapped to the environment variable names, for example "polling_frequency" becomes
+// POLLING_FREQUENCY and the key "header" inside the "[auth_proxy]" table becomes AUTH_PROXY_HEADER.
+func parseTOMLContent(r io.Reader) ([]string, error) {
+	var lines []string
+	var table string
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			closing := strings.Index(line, "]")
+			if closing == -1 || strings.HasPrefix(line, "[[") || !isTOMLComment(line[closing+1:]) {
+				return nil, fmt.Errorf("config: invalid table header on line %d", lineNumber)
+			}
+
+			table = tomlKeyToOptionName(line[1:closing])
+			if table == "" {
+				return nil, fmt.Errorf("config: invalid table header on line %d", lineNumber)
+			}
+			continue
+		}
+
+		rawKey, rawValue, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("config: missing value on line %d", lineNumber)
+		}
+
+		key := tomlKeyToOptionName(rawKey)
+		if key == "" {
+			return nil, fmt.Errorf("config: invalid key on line %d", lineNumber)
+		}
+
+		if table != "" {
+			key = table + "_" + key
+		}
+
+		if seen[key] {
+			return nil, fmt.Errorf("config: duplicate key %s on line %d", key, lineNumber)
+		}
+		seen[key] = true
+
+		value, err := parseTOMLValue(strings.TrimSpace(rawValue))
+		if err != nil {
+			return nil, fmt.Errorf("config: invalid value for %s on line %d: %v", key, lineNumber, err)
+		}
+
+		lines = append(lines, key+"="+value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+func tomlKeyToOptionName(key string) string {
+	var parts []string
+	for _, part := range strings.Split(key, ".") {
+		part = strings.Trim(strings.TrimSpace(part), `"`)
+		if part == "" {
+			return ""
+		}
+
+		for _, r := range part {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+				return ""
+			}
+		}
+
+		parts = append(parts, strings.ToUpper(strings.ReplaceAll(part, "-", "_")))
+	}
+
+	return strings.Join(parts, "_")
+}
+
+// parseTOMLValue returns the value as expected by the options parser, arrays are joined with commas.
+func parseTOMLValue(value string) (string, error) {
+	if strings.HasPrefix(value, "[") {
+		closing := strings.LastIndex(value, "]")
+		if closing == -1 || !isTOMLComment(value[closing+1:]) {
+			return "", fmt.Errorf("unterminated array")
+		}
+
+		var items []string
+		remaining := strings.TrimSpace(value[1:closing])
+		for remaining != "" {
+			item, rest, err := parseTOMLScalar(remaining)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+
+			rest = strings.TrimSpace(rest)
+			if rest != "" && !strings.HasPrefix(rest, ",") {
+				return "", fmt.Errorf("missing comma between array items")
+			}
+			remaining = strings.TrimSpace(strings.TrimPrefix(rest, ","))
+		}
+
+		return strings.Join(items, ","), nil
+	}
+
+	result, rest, err := parseTOMLScalar(value)
+	if err != nil {
+		return "", err
+	}
+
+	if !isTOMLComment(rest) {
+		return "", fmt.Errorf("unexpected characters after value")
+	}
+
+	return result, nil
+}
+
+// parseTOMLScalar parses the value at the beginning of the input and returns the remaining characters.
+func parseTOMLScalar(input string) (value, rest string, err error) {
+	switch {
+	case strings.HasPrefix(input, `"`):
+		return parseTOMLBasicString(input[1:])
+	case strings.HasPrefix(input, "'"):
+		closing := strings.Index(input[1:], "'")
+		if closing == -1 {
+			return "", "", fmt.Errorf("unterminated string")
+		}
+
+		value = input[1 : closing+1]
+		if strings.ContainsFunc(value, isTOMLControlCharacter) {
+			return "", "", fmt.Errorf("control characters must be escaped")
+		}
+		return value, input[closing+2:], nil
+	}
+
+	end := strings.IndexAny(input, ",#] \t")
+	if end == -1 {
+		end = len(input)
+	}
+
+	value = input[:end]
+	switch value {
+	case "true", "false":
+		return value, input[end:], nil
+	}
+
+	if _, err := strconv.ParseFloat(strings.ReplaceAll(value, "_", ""), 64); err != nil {
+		return "", "", fmt.Errorf("unsupported value %q", value)
+	}
+
+	return strings.ReplaceAll(value, "_", ""), input[end:], nil
+}
+
+// parseTOMLBasicString decodes a double-quoted string, the input starts after the opening quote.
+// The escape sequences are the ones of the TOML specification, which differ from the Go ones.
+func parseTOMLBasicString(input string) (value, rest string, err error) {
+	var builder strings.Builder
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case c == '"':
+			return builder.String(), input[i+1:], nil
+		case c == '\\':
+			if i+1 >= len(input) {
+				return "", "", fmt.Errorf("unterminated string")
+			}
+
+			i++
+			switch input[i] {
+			case 'b':
+				builder.WriteByte('\b')
+			case 't':
+				builder.WriteByte('\t')
+			case 'n':
+				builder.WriteByte('\n')
+			case 'f':
+				builder.WriteByte('\f')
+			case 'r':
+				builder.WriteByte('\r')
+			case '"':
+				builder.WriteByte('"')
+			case '\\':
+				builder.WriteByte('\\')
+			case 'u', 'U':
+				size := 4
+				if input[i] == 'U' {
+					size = 8
+				}
+
+				if i+size >= len(input) {
+					return "", "", fmt.Errorf("invalid unicode escape")
+				}
+
+				codePoint, err := strconv.ParseUint(input[i+1:i+1+size], 16, 32)
+				if err != nil || !utf8.ValidRune(rune(codePoint)) {
+					return "", "", fmt.Errorf("invalid unicode escape")
+				}
+
+				builder.WriteRune(rune(codePoint))
+				i += size
+			default:
+				return "", "", fmt.Errorf("invalid escape sequence \\%c", input[i])
+			}
+		case isTOMLControlCharacter(rune(c)):
+			return "", "", fmt.Errorf("control characters must be escaped")
+		default:
+			builder.WriteByte(c)
+		}
+	}
+
+	return "", "", fmt.Errorf("unterminated string")
+}
+
+// isTOMLControlCharacter returns true for the characters that can't appear unescaped in a string, the tab is allowed.
+func isTOMLControlCharacter(r rune) bool {
+	return (r < 0x20 && r != '\t') || r == 0x7f
+}
+
+func isTOMLComment(input string) bool {
+	input = strings.TrimSpace(input)
+	return input == "" || strings.HasPrefix(input, "#")
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config // import "miniflux.app/v2/internal/config"
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTOMLContent(t *testing.T) {
+	content := `
+# Comment
+log_level = "debug" # trailing comment
+polling-frequency = 30
+batch_size = 1_000
+https = true
+oauth2.provider = 'oidc'
+admin_allowed_networks = ["10.0.0.0/8", "192.168.0.0/16"]
+
+[auth_proxy]
+header = "X-Forwarded-User"
+name_header = "X-Name \"quoted\""
+email_header = "tab\there, \u00e9t\u00E9 \U0001F600 \\"
+`
+
+	lines, err := parseTOMLContent(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := []string{
+		"LOG_LEVEL=debug",
+		"POLLING_FREQUENCY=30",
+		"BATCH_SIZE=1000",
+		"HTTPS=true",
+		"OAUTH2_PROVIDER=oidc",
+		"ADMIN_ALLOWED_NETWORKS=10.0.0.0/8,192.168.0.0/16",
+		"AUTH_PROXY_HEADER=X-Forwarded-User",
+		`AUTH_PROXY_NAME_HEADER=X-Name "quoted"`,
+		"AUTH_PROXY_EMAIL_HEADER=tab\there, \u00e9t\u00e9 \U0001F600 \\",
+	}
+
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf(`Unexpected lines, got %q instead of %q`, lines, expected)
+	}
+}
+
+func TestParseTOMLContentWithInvalidDocuments(t *testing.T) {
+	scenarios := []string{
+		`log_level`,
+		`log_level = "debug`,
+		`log_level = debug`,
+		`log_level = "debug" "info"`,
+		`[auth_proxy`,
+		`[[providers]]`,
+		`= 10`,
+		`batch_size = [1, 2`,
+		"batch_size = 10\nbatch_size = 20",
+		`log_level = "\x41"`,
+		`log_level = "\a"`,
+		`log_level = "\u12"`,
+		`log_level = "\uD800"`,
+		"log_level = \"a\x01b\"",
+		"log_level = 'a\x01b'",
+	}
+
+	for _, content := range scenarios {
+		if _, err := parseTOMLContent(strings.NewReader(content)); err == nil {
+			t.Errorf(`An error should be returned for %q`, content)
+		}
+	}
+}
+
+func TestParseTOMLFileWithUnknownKey(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "miniflux.toml")
+	if err := os.WriteFile(filename, []byte("log_level = \"debug\"\npolling_frequncy = 30\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewParser().ParseFile(filename)
+	if err == nil || !strings.Contains(err.Error(), "POLLING_FREQUNCY") {
+		t.Errorf(`An unknown key should return an error, got %v`, err)
+	}
+}
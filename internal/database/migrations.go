@@ -942,4 +942,470 @@ var migrations = []func(tx *sql.Tx) error{
 		_, err = tx.Exec(sql)
 		return err
 	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN telegram_bot_category_chat_ids text default '';
+			ALTER TABLE integrations ADD COLUMN telegram_bot_digest_mode text default '';
+			ALTER TABLE integrations ADD COLUMN telegram_bot_digest_size int default 10;
+			ALTER TABLE integrations ADD COLUMN telegram_bot_digest_sent_at timestamp with time zone;
+			ALTER TABLE integrations ADD COLUMN telegram_bot_quiet_hours_start int;
+			ALTER TABLE integrations ADD COLUMN telegram_bot_quiet_hours_end int;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN matrix_bot_access_token text default '';
+			ALTER TABLE feeds ADD COLUMN matrix_bot_enabled bool default 't';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN email_digest_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN email_digest_address text default '';
+			ALTER TABLE integrations ADD COLUMN email_digest_frequency text default 'daily';
+			ALTER TABLE integrations ADD COLUMN email_digest_hour int default 8;
+			ALTER TABLE integrations ADD COLUMN email_digest_weekday int default 1;
+			ALTER TABLE integrations ADD COLUMN email_digest_content text default 'unread';
+			ALTER TABLE integrations ADD COLUMN email_digest_sent_at timestamp with time zone;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN kindle_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN kindle_email text default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN mastodon_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN mastodon_url text default '';
+			ALTER TABLE integrations ADD COLUMN mastodon_access_token text default '';
+			ALTER TABLE integrations ADD COLUMN mastodon_visibility text default 'public';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN bluesky_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN bluesky_pds_url text default '';
+			ALTER TABLE integrations ADD COLUMN bluesky_handle text default '';
+			ALTER TABLE integrations ADD COLUMN bluesky_app_password text default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN pinboard_entry_tags bool default 'f';
+			ALTER TABLE integrations ADD COLUMN pinboard_category_tag bool default 'f';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN zotero_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN zotero_user_id text default '';
+			ALTER TABLE integrations ADD COLUMN zotero_api_key text default '';
+			ALTER TABLE integrations ADD COLUMN zotero_collection_key text default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN hypothesis_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN hypothesis_api_token text default '';
+			ALTER TABLE integrations ADD COLUMN hypothesis_group text default '__world__';
+			ALTER TABLE integrations ADD COLUMN hypothesis_show_annotations bool default 'f';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN homeassistant_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN homeassistant_webhook_url text default '';
+			ALTER TABLE integrations ADD COLUMN homeassistant_rules text default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN webhook_payload_template text default '';
+			ALTER TABLE integrations ADD COLUMN homeassistant_payload_template text default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE integration_deliveries (
+				id bigserial not null,
+				user_id int not null,
+				destination text not null,
+				event_type text not null,
+				feed_id bigint,
+				entry_ids bigint[] not null,
+				status text not null default 'pending',
+				attempts int not null default 0,
+				last_error text not null default '',
+				next_attempt_at timestamp with time zone not null default now(),
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+
+			CREATE INDEX integration_deliveries_next_attempt_idx ON integration_deliveries(next_attempt_at) WHERE status = 'pending';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE integration_routing_rules (
+				id bigserial not null,
+				user_id int not null,
+				destination text not null,
+				category_id int,
+				feed_id bigint,
+				primary key (id),
+				check ((category_id is null) <> (feed_id is null)),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN totp_secret text not null default '';
+			ALTER TABLE users ADD COLUMN totp_enabled bool not null default 'f';
+			ALTER TABLE users ADD COLUMN totp_recovery_codes text[] not null default '{}';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN saml_id text not null default '';
+			CREATE UNIQUE INDEX users_saml_id_idx ON users(saml_id) WHERE saml_id <> '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE api_keys ADD COLUMN expires_at timestamp with time zone`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE user_sessions ADD COLUMN last_seen_at timestamp with time zone not null default now();
+			UPDATE user_sessions SET last_seen_at=created_at;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE login_failures (
+				id bigserial not null,
+				username text not null,
+				client_ip text not null,
+				created_at timestamp with time zone not null default now(),
+				primary key(id)
+			);
+			CREATE INDEX login_failures_username_idx ON login_failures(username, created_at);
+			CREATE INDEX login_failures_client_ip_idx ON login_failures(client_ip, created_at);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN is_disabled bool not null default 'f';
+			ALTER TABLE users ADD COLUMN scim_provisioned bool not null default 'f';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN full_name text not null default '';
+			ALTER TABLE users ADD COLUMN email text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE app_passwords (
+				id bigserial not null,
+				user_id int not null references users(id) on delete cascade,
+				description text not null,
+				scope text not null,
+				credential text not null,
+				last_used_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				primary key(id),
+				unique (user_id, description)
+			);
+			CREATE INDEX app_passwords_scope_credential_idx ON app_passwords(scope, credential);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN polling_min_interval int not null default 0;
+			ALTER TABLE feeds ADD COLUMN polling_max_interval int not null default 0;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN refresh_interval int not null default 0`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN polling_weekdays int[] not null default '{}';
+			ALTER TABLE feeds ADD COLUMN polling_window_start text not null default '';
+			ALTER TABLE feeds ADD COLUMN polling_window_end text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN auto_archive_after_days int not null default 0;
+			ALTER TABLE feeds ADD COLUMN auto_archive_status entry_status not null default 'read';
+			ALTER TABLE categories ADD COLUMN auto_archive_after_days int not null default 0;
+			ALTER TABLE categories ADD COLUMN auto_archive_status entry_status not null default 'read';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN icon_checked_at timestamp with time zone;
+			UPDATE feeds SET icon_checked_at=now() WHERE id IN (SELECT feed_id FROM feed_icons);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// Keep only the most recent icon of each feed, then merge the icons having the same content
+		// and remove the icons that are not used anymore.
+		sql := `
+			DELETE FROM feed_icons a USING feed_icons b WHERE a.feed_id=b.feed_id AND a.icon_id < b.icon_id;
+
+			WITH duplicates AS (
+				SELECT id, min(id) OVER (PARTITION BY content) AS original_id FROM icons
+			)
+			UPDATE feed_icons SET icon_id=duplicates.original_id
+			FROM duplicates
+			WHERE feed_icons.icon_id=duplicates.id AND duplicates.id <> duplicates.original_id;
+
+			DELETE FROM icons WHERE NOT EXISTS (SELECT 1 FROM feed_icons WHERE feed_icons.icon_id=icons.id);
+
+			CREATE UNIQUE INDEX feed_icons_feed_id_idx ON feed_icons(feed_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feed_icons ADD COLUMN dark_icon_id bigint REFERENCES icons(id) ON DELETE SET NULL`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN custom_icon bool not null default false`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN icon_failure_count int not null default 0;
+			ALTER TABLE feeds ADD COLUMN icon_retry_at timestamp with time zone;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feed_icons ADD COLUMN placeholder bool not null default false`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN websub_hub_url text not null default '';
+			ALTER TABLE feeds ADD COLUMN websub_topic_url text not null default '';
+			ALTER TABLE feeds ADD COLUMN websub_secret text not null default '';
+			ALTER TABLE feeds ADD COLUMN websub_requested_at timestamp with time zone;
+			ALTER TABLE feeds ADD COLUMN websub_lease_expires_at timestamp with time zone;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE entries ADD COLUMN thumbnail_url text not null default ''`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN latitude double precision;
+			ALTER TABLE entries ADD COLUMN longitude double precision;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE entries ADD COLUMN language text not null default ''`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE entries ADD COLUMN comments_feed_url text not null default ''`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN page_monitor_selector text not null default ''`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN newsletter_token text not null default '';
+			CREATE UNIQUE INDEX feeds_newsletter_token_idx ON feeds(newsletter_token) WHERE newsletter_token <> '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN relaxed_parsing bool not null default 'f'`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN podcast_episode int not null default 0;
+			ALTER TABLE entries ADD COLUMN podcast_season int not null default 0;
+			ALTER TABLE entries ADD COLUMN podcast_episode_type text not null default '';
+			ALTER TABLE enclosures ADD COLUMN duration int not null default 0;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN fetch_via_headless_browser bool not null default 'f'`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN content_extractor text not null default ''`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE entries ADD COLUMN feed_content text not null default ''`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN rewrite_script text not null default ''`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE users ADD COLUMN iframe_allowed_domains text[] not null default '{}'`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE users ADD COLUMN render_math bool not null default 'f'`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN translated_title text not null default '';
+			ALTER TABLE entries ADD COLUMN translated_content text not null default '';
+			ALTER TABLE entries ADD COLUMN translation_language text not null default '';
+			ALTER TABLE feeds ADD COLUMN auto_translate bool not null default 'f';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE scraped_contents (
+				url_hash text not null,
+				url text not null,
+				page_base_url text not null default '',
+				content text not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (url_hash)
+			);
+			CREATE INDEX scraped_contents_created_at_idx ON scraped_contents(created_at);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE users ADD COLUMN totp_last_counter bigint not null default 0`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE users ADD COLUMN auth_source text not null default ''`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE saml_link_requests (
+				request_id text not null,
+				user_id bigint not null,
+				user_session_hash text not null,
+				name_id text not null default '',
+				created_at timestamp with time zone not null default now(),
+				primary key (request_id),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// The subjects of the default OpenID Connect provider are prefixed like the ones of the additional providers,
+		// the subjects containing a colon are prefixed on the next login of the user.
+		sql := `UPDATE users SET openid_connect_id = 'oidc:' || openid_connect_id WHERE openid_connect_id <> '' AND position(':' in openid_connect_id) = 0`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN scim_external_id text not null default '';
+			CREATE UNIQUE INDEX users_scim_external_id_idx ON users(scim_external_id) WHERE scim_external_id <> '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
 }
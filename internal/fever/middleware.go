@@ -7,9 +7,12 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"strings"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/storage"
 )
 
@@ -35,6 +38,34 @@ func (m *middleware) serve(next http.Handler) http.Handler {
 			return
 		}
 
+		// The API key doesn't contain the username, only the failures of the client IP are limited.
+		locked, err := m.store.IsLoginLocked(
+			"",
+			clientIP,
+			0,
+			config.Opts.LoginMaxFailedAttemptsPerIP(),
+			config.Opts.LoginLockoutDuration(),
+		)
+		if err != nil {
+			slog.Error("[Fever] Unable to check failed logins",
+				slog.String("client_ip", clientIP),
+				slog.Any("error", err),
+			)
+			json.OK(w, r, newAuthFailureResponse())
+			return
+		}
+
+		if locked {
+			slog.Warn("[Fever] Authentication refused because of too many failed attempts",
+				slog.Bool("authentication_failed", true),
+				slog.Bool("login_locked", true),
+				slog.String("client_ip", clientIP),
+				slog.String("user_agent", r.UserAgent()),
+			)
+			json.OK(w, r, newAuthFailureResponse())
+			return
+		}
+
 		user, err := m.store.UserByFeverToken(apiKey)
 		if err != nil {
 			slog.Error("[Fever] Unable to fetch user by API key",
@@ -48,6 +79,12 @@ func (m *middleware) serve(next http.Handler) http.Handler {
 		}
 
 		if user == nil {
+			if config.Opts.LoginMaxFailedAttemptsPerIP() > 0 {
+				if err := m.store.CreateLoginFailure("", clientIP); err != nil {
+					slog.Error("[Fever] Unable to record failed login", slog.Any("error", err))
+				}
+			}
+
 			slog.Warn("[Fever] No user found with the API key provided",
 				slog.Bool("authentication_failed", true),
 				slog.String("client_ip", clientIP),
@@ -66,6 +103,7 @@ func (m *middleware) serve(next http.Handler) http.Handler {
 		)
 
 		m.store.SetLastLogin(user.ID)
+		m.store.SetAppPasswordUsedTimestamp(model.AppPasswordScopeFever, strings.ToLower(apiKey))
 
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, request.UserIDContextKey, user.ID)
@@ -401,6 +401,16 @@ func checkOutputFormat(r *http.Request) error {
 	return nil
 }
 
+func (h *handler) recordLoginFailure(username, clientIP string) {
+	if config.Opts.LoginMaxFailedAttempts() <= 0 && config.Opts.LoginMaxFailedAttemptsPerIP() <= 0 {
+		return
+	}
+
+	if err := h.store.CreateLoginFailure(username, clientIP); err != nil {
+		slog.Error("[GoogleReader] Unable to record failed login", slog.Any("error", err))
+	}
+}
+
 func (h *handler) clientLoginHandler(w http.ResponseWriter, r *http.Request) {
 	clientIP := request.ClientIP(r)
 
@@ -435,7 +445,33 @@ func (h *handler) clientLoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.store.GoogleReaderUserCheckPassword(username, password); err != nil {
+	locked, err := h.store.IsLoginLocked(
+		username,
+		clientIP,
+		config.Opts.LoginMaxFailedAttempts(),
+		config.Opts.LoginMaxFailedAttemptsPerIP(),
+		config.Opts.LoginLockoutDuration(),
+	)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if locked {
+		slog.Warn("[GoogleReader] Login refused because of too many failed attempts",
+			slog.Bool("authentication_failed", true),
+			slog.Bool("login_locked", true),
+			slog.String("client_ip", clientIP),
+			slog.String("user_agent", r.UserAgent()),
+			slog.String("username", username),
+		)
+		json.Unauthorized(w, r)
+		return
+	}
+
+	integration, err := h.store.GoogleReaderUserGetIntegration(username)
+	if err != nil {
+		h.recordLoginFailure(username, clientIP)
 		slog.Warn("[GoogleReader] Invalid username or password",
 			slog.Bool("authentication_failed", true),
 			slog.String("client_ip", clientIP),
@@ -447,6 +483,26 @@ func (h *handler) clientLoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The token is derived from the password hash, either the main one or the one of the app password used.
+	credential := integration.GoogleReaderPassword
+	if err := h.store.GoogleReaderUserCheckPassword(username, password); err != nil {
+		credential, err = h.store.CheckAppPassword(integration.UserID, model.AppPasswordScopeGoogleReader, password)
+		if err != nil {
+			h.recordLoginFailure(username, clientIP)
+			slog.Warn("[GoogleReader] Invalid username or password",
+				slog.Bool("authentication_failed", true),
+				slog.String("client_ip", clientIP),
+				slog.String("user_agent", r.UserAgent()),
+				slog.String("username", username),
+				slog.Any("error", err),
+			)
+			json.Unauthorized(w, r)
+			return
+		}
+
+		h.store.SetAppPasswordUsedTimestamp(model.AppPasswordScopeGoogleReader, credential)
+	}
+
 	slog.Info("[GoogleReader] User authenticated successfully",
 		slog.Bool("authentication_successful", true),
 		slog.String("client_ip", clientIP),
@@ -454,15 +510,9 @@ func (h *handler) clientLoginHandler(w http.ResponseWriter, r *http.Request) {
 		slog.String("username", username),
 	)
 
-	integration, err := h.store.GoogleReaderUserGetIntegration(username)
-	if err != nil {
-		json.ServerError(w, r, err)
-		return
-	}
-
 	h.store.SetLastLogin(integration.UserID)
 
-	token := getAuthToken(integration.GoogleReaderUsername, integration.GoogleReaderPassword)
+	token := getAuthToken(integration.GoogleReaderUsername, credential)
 	slog.Debug("[GoogleReader] Created token",
 		slog.String("client_ip", clientIP),
 		slog.String("user_agent", r.UserAgent()),
@@ -651,7 +701,7 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 		for _, entry := range entries {
 			e := entry
 			go func() {
-				integration.SendEntry(e, settings)
+				integration.SendEntry(h.store, e, settings)
 			}()
 		}
 	}
@@ -143,7 +143,7 @@ func (m *middleware) apiKeyAuth(next http.Handler) http.Handler {
 			return
 		}
 		expectedToken := getAuthToken(integration.GoogleReaderUsername, integration.GoogleReaderPassword)
-		if expectedToken != token {
+		if expectedToken != token && !m.isAppPasswordToken(integration, token) {
 			slog.Warn("[GoogleReader] Token does not match",
 				slog.Bool("authentication_failed", true),
 				slog.String("client_ip", clientIP),
@@ -194,6 +194,24 @@ func (m *middleware) apiKeyAuth(next http.Handler) http.Handler {
 	})
 }
 
+// isAppPasswordToken returns true if the token has been issued for one of the user app passwords.
+func (m *middleware) isAppPasswordToken(integration *model.Integration, token string) bool {
+	credentials, err := m.store.AppPasswordCredentials(integration.UserID, model.AppPasswordScopeGoogleReader)
+	if err != nil {
+		slog.Error("[GoogleReader] Unable to fetch app passwords", slog.Any("error", err))
+		return false
+	}
+
+	for _, credential := range credentials {
+		if getAuthToken(integration.GoogleReaderUsername, credential) == token {
+			m.store.SetAppPasswordUsedTimestamp(model.AppPasswordScopeGoogleReader, credential)
+			return true
+		}
+	}
+
+	return false
+}
+
 func getAuthToken(username, password string) string {
 	token := hex.EncodeToString(hmac.New(sha1.New, []byte(username+password)).Sum(nil))
 	token = username + "/" + token
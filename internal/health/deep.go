@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package health // import "miniflux.app/v2/internal/health"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/worker"
+)
+
+// Component statuses.
+const (
+	StatusOK       = "ok"
+	StatusFailed   = "failed"
+	StatusDisabled = "disabled"
+)
+
+// stuckJobThreshold is the duration after which a running job is considered stuck.
+const stuckJobThreshold = 15 * time.Minute
+
+// Report is the result of a deep health check.
+type Report struct {
+	Status     string                      `json:"status"`
+	Components map[string]*ComponentReport `json:"components,omitempty"`
+}
+
+// ComponentReport describes the state of one component.
+type ComponentReport struct {
+	Status         string     `json:"status"`
+	Message        string     `json:"message,omitempty"`
+	LatencyMS      float64    `json:"latency_ms,omitempty"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty"`
+}
+
+// IsHealthy returns true if all components are working.
+func (r *Report) IsHealthy() bool {
+	return r.Status == StatusOK
+}
+
+// DeepCheck checks the database, the migrations, the scheduler, the workers and the feed refreshes.
+// The pool can be nil when the scheduler and the workers are not running in this process.
+func DeepCheck(store *storage.Storage, pool *worker.Pool) *Report {
+	now := time.Now()
+	report := &Report{Status: StatusOK, Components: make(map[string]*ComponentReport)}
+
+	startTime := time.Now()
+	report.Components["database"] = newComponentReport(store.Ping())
+	report.Components["database"].LatencyMS = float64(time.Since(startTime).Microseconds()) / 1000
+
+	report.Components["migrations"] = newComponentReport(store.IsSchemaUpToDate())
+
+	pollingFrequency := time.Duration(config.Opts.PollingFrequency()) * time.Minute
+	hasScheduler := config.Opts.HasSchedulerService() && !config.Opts.HasMaintenanceMode()
+	if hasScheduler {
+		lastHeartbeat := schedulerHeartbeat.Load()
+		report.Components["scheduler"] = newComponentReport(checkScheduler(now, lastHeartbeat, pollingFrequency))
+		if lastHeartbeat > 0 {
+			lastTick := time.Unix(lastHeartbeat, 0)
+			report.Components["scheduler"].LastActivityAt = &lastTick
+		}
+	} else {
+		report.Components["scheduler"] = &ComponentReport{Status: StatusDisabled}
+	}
+
+	if pool != nil {
+		status := pool.Status()
+		report.Components["workers"] = newComponentReport(checkWorkers(now, status))
+		report.Components["workers"].LastActivityAt = status.LastRefreshAt
+
+		if hasScheduler {
+			report.Components["feed_refresh"] = newComponentReport(checkFeedRefresh(now, status, pollingFrequency))
+		} else {
+			report.Components["feed_refresh"] = &ComponentReport{Status: StatusDisabled}
+		}
+		report.Components["feed_refresh"].LastActivityAt = status.LastSuccessfulRefreshAt
+	}
+
+	for _, component := range report.Components {
+		if component.Status == StatusFailed {
+			report.Status = StatusFailed
+		}
+	}
+
+	return report
+}
+
+func newComponentReport(err error) *ComponentReport {
+	if err != nil {
+		return &ComponentReport{Status: StatusFailed, Message: err.Error()}
+	}
+	return &ComponentReport{Status: StatusOK}
+}
+
+func checkWorkers(now time.Time, status *worker.Status) error {
+	if status.AliveWorkers < int64(status.NbWorkers) {
+		return fmt.Errorf("health: only %d workers out of %d are running", status.AliveWorkers, status.NbWorkers)
+	}
+
+	for _, job := range status.RunningJobs {
+		if now.Sub(job.StartedAt) > stuckJobThreshold {
+			return fmt.Errorf("health: worker #%d is refreshing feed #%d since %s", job.WorkerID, job.FeedID, now.Sub(job.StartedAt).Truncate(time.Second))
+		}
+	}
+
+	return nil
+}
+
+// checkFeedRefresh fails when feeds were refreshed during the last two polling periods but none successfully.
+// An instance without any refresh is not considered stale since there is maybe nothing to refresh.
+func checkFeedRefresh(now time.Time, status *worker.Status, pollingFrequency time.Duration) error {
+	if status.LastRefreshAt == nil {
+		return nil
+	}
+
+	lastSuccess := status.StartedAt
+	if status.LastSuccessfulRefreshAt != nil {
+		lastSuccess = *status.LastSuccessfulRefreshAt
+	}
+
+	if elapsed := now.Sub(lastSuccess); elapsed > 2*pollingFrequency {
+		return fmt.Errorf("health: no feed has been refreshed successfully for %s", elapsed.Truncate(time.Second))
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package health reports whether the process is able to serve requests and refresh feeds.
+package health // import "miniflux.app/v2/internal/health"
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/storage"
+)
+
+// schedulerHeartbeat is the Unix timestamp of the last feed scheduler heartbeat.
+var schedulerHeartbeat atomic.Int64
+
+// SchedulerHeartbeat records that the feed scheduler is still running.
+func SchedulerHeartbeat() {
+	schedulerHeartbeat.Store(time.Now().Unix())
+}
+
+// CheckReadiness returns an error when the database is unreachable or when the feed scheduler seems stuck.
+func CheckReadiness(store *storage.Storage) error {
+	if err := store.Ping(); err != nil {
+		return fmt.Errorf("health: database connection error: %v", err)
+	}
+
+	if config.Opts.HasSchedulerService() && !config.Opts.HasMaintenanceMode() {
+		pollingFrequency := time.Duration(config.Opts.PollingFrequency()) * time.Minute
+		return checkScheduler(time.Now(), schedulerHeartbeat.Load(), pollingFrequency)
+	}
+
+	return nil
+}
+
+// checkScheduler considers the scheduler stuck when it missed two heartbeats in a row.
+func checkScheduler(now time.Time, lastHeartbeat int64, pollingFrequency time.Duration) error {
+	if lastHeartbeat == 0 {
+		return errors.New("health: the feed scheduler is not started")
+	}
+
+	if elapsed := now.Sub(time.Unix(lastHeartbeat, 0)); elapsed > 2*pollingFrequency {
+		return fmt.Errorf("health: the feed scheduler did not run for %s", elapsed.Truncate(time.Second))
+	}
+
+	return nil
+}
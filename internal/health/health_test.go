@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package health // import "miniflux.app/v2/internal/health"
+
+import (
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/worker"
+)
+
+func TestCheckSchedulerNotStarted(t *testing.T) {
+	if err := checkScheduler(time.Now(), 0, time.Hour); err == nil {
+		t.Error(`The scheduler should not be ready before the first heartbeat`)
+	}
+}
+
+func TestCheckSchedulerRecentHeartbeat(t *testing.T) {
+	now := time.Now()
+	if err := checkScheduler(now, now.Add(-90*time.Minute).Unix(), time.Hour); err != nil {
+		t.Errorf(`The scheduler should be ready, got %v`, err)
+	}
+}
+
+func TestCheckSchedulerStaleHeartbeat(t *testing.T) {
+	now := time.Now()
+	if err := checkScheduler(now, now.Add(-3*time.Hour).Unix(), time.Hour); err == nil {
+		t.Error(`The scheduler should be considered stuck after missing two iterations`)
+	}
+}
+
+func TestCheckWorkersWithStoppedWorker(t *testing.T) {
+	status := &worker.Status{NbWorkers: 4, AliveWorkers: 3}
+	if err := checkWorkers(time.Now(), status); err == nil {
+		t.Error(`The workers should be unhealthy when one of them is not running`)
+	}
+}
+
+func TestCheckWorkersWithStuckJob(t *testing.T) {
+	now := time.Now()
+	status := &worker.Status{
+		NbWorkers:    2,
+		AliveWorkers: 2,
+		RunningJobs: []worker.RunningJob{
+			{WorkerID: 0, FeedID: 1, StartedAt: now.Add(-time.Minute)},
+			{WorkerID: 1, FeedID: 2, StartedAt: now.Add(-time.Hour)},
+		},
+	}
+
+	if err := checkWorkers(now, status); err == nil {
+		t.Error(`The workers should be unhealthy when a job is stuck`)
+	}
+
+	status.RunningJobs = status.RunningJobs[:1]
+	if err := checkWorkers(now, status); err != nil {
+		t.Errorf(`The workers should be healthy, got %v`, err)
+	}
+}
+
+func TestCheckFeedRefreshWithoutAnyRefresh(t *testing.T) {
+	now := time.Now()
+	status := &worker.Status{StartedAt: now.Add(-24 * time.Hour)}
+	if err := checkFeedRefresh(now, status, time.Hour); err != nil {
+		t.Errorf(`An instance without any refresh should be healthy, got %v`, err)
+	}
+}
+
+func TestCheckFeedRefreshWithOnlyFailures(t *testing.T) {
+	now := time.Now()
+	lastRefresh := now.Add(-time.Minute)
+	status := &worker.Status{StartedAt: now.Add(-3 * time.Hour), LastRefreshAt: &lastRefresh}
+	if err := checkFeedRefresh(now, status, time.Hour); err == nil {
+		t.Error(`The feed refresh should be unhealthy when all refreshes failed for two polling periods`)
+	}
+
+	lastSuccess := now.Add(-30 * time.Minute)
+	status.LastSuccessfulRefreshAt = &lastSuccess
+	if err := checkFeedRefresh(now, status, time.Hour); err != nil {
+		t.Errorf(`The feed refresh should be healthy, got %v`, err)
+	}
+}
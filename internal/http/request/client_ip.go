@@ -46,3 +46,24 @@ func dropIPv6zone(address string) string {
 	}
 	return address
 }
+
+// IsIPInNetworks returns true when the IP address belongs to one of the CIDR networks.
+// Requests received on a Unix socket ("@") are always considered part of the networks.
+func IsIPInNetworks(address string, networks []string) bool {
+	if address == "@" {
+		return true
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, cidr := range networks {
+		if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -123,3 +123,23 @@ func TestClientIPWithUnixSocketRemoteAddrAndBothHeaders(t *testing.T) {
 		t.Fatalf(`Unexpected result, got: %q`, ip)
 	}
 }
+
+func TestIsIPInNetworks(t *testing.T) {
+	networks := []string{"10.0.0.0/8", "fd00::/8"}
+
+	scenarios := map[string]bool{
+		"10.1.2.3":    true,
+		"192.168.0.1": false,
+		"fd12::1":     true,
+		"2001:db8::1": false,
+		"@":           true,
+		"invalid":     false,
+		"":            false,
+	}
+
+	for address, expected := range scenarios {
+		if result := IsIPInNetworks(address, networks); result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, address, result, expected)
+		}
+	}
+}
@@ -33,6 +33,8 @@ const (
 	ClientIPContextKey
 	GoogleReaderToken
 	WebAuthnDataContextKey
+	TOTPPendingUserIDContextKey
+	RequestIDContextKey
 )
 
 func WebAuthnSessionData(r *http.Request) *model.WebAuthnSession {
@@ -44,6 +46,16 @@ func WebAuthnSessionData(r *http.Request) *model.WebAuthnSession {
 	return nil
 }
 
+// TOTPPendingUserID returns the user waiting for two-factor authentication after a successful password check.
+func TOTPPendingUserID(r *http.Request) int64 {
+	return getContextInt64Value(r, TOTPPendingUserIDContextKey)
+}
+
+// RequestID returns the identifier assigned to the request, it is also sent in the X-Request-Id response header.
+func RequestID(r *http.Request) string {
+	return getContextStringValue(r, RequestIDContextKey)
+}
+
 // GoolgeReaderToken returns the google reader token if it exists.
 func GoolgeReaderToken(r *http.Request) string {
 	return getContextStringValue(r, GoogleReaderToken)
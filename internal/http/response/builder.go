@@ -92,7 +92,7 @@ func (b *Builder) Write() {
 		b.writeHeaders()
 		_, err := io.Copy(b.w, v)
 		if err != nil {
-			slog.Error("Unable to write response body", slog.Any("error", err))
+			slog.ErrorContext(b.r.Context(), "Unable to write response body", slog.Any("error", err))
 		}
 	}
 }
@@ -22,7 +22,7 @@ func OK(w http.ResponseWriter, r *http.Request, body interface{}) {
 
 // ServerError sends an internal error to the client.
 func ServerError(w http.ResponseWriter, r *http.Request, err error) {
-	slog.Error(http.StatusText(http.StatusInternalServerError),
+	slog.ErrorContext(r.Context(), http.StatusText(http.StatusInternalServerError),
 		slog.Any("error", err),
 		slog.String("client_ip", request.ClientIP(r)),
 		slog.Group("request",
@@ -46,7 +46,7 @@ func ServerError(w http.ResponseWriter, r *http.Request, err error) {
 
 // BadRequest sends a bad request error to the client.
 func BadRequest(w http.ResponseWriter, r *http.Request, err error) {
-	slog.Warn(http.StatusText(http.StatusBadRequest),
+	slog.WarnContext(r.Context(), http.StatusText(http.StatusBadRequest),
 		slog.Any("error", err),
 		slog.String("client_ip", request.ClientIP(r)),
 		slog.Group("request",
@@ -70,7 +70,7 @@ func BadRequest(w http.ResponseWriter, r *http.Request, err error) {
 
 // Forbidden sends a forbidden error to the client.
 func Forbidden(w http.ResponseWriter, r *http.Request) {
-	slog.Warn(http.StatusText(http.StatusForbidden),
+	slog.WarnContext(r.Context(), http.StatusText(http.StatusForbidden),
 		slog.String("client_ip", request.ClientIP(r)),
 		slog.Group("request",
 			slog.String("method", r.Method),
@@ -92,7 +92,7 @@ func Forbidden(w http.ResponseWriter, r *http.Request) {
 
 // NotFound sends a page not found error to the client.
 func NotFound(w http.ResponseWriter, r *http.Request) {
-	slog.Warn(http.StatusText(http.StatusNotFound),
+	slog.WarnContext(r.Context(), http.StatusText(http.StatusNotFound),
 		slog.String("client_ip", request.ClientIP(r)),
 		slog.Group("request",
 			slog.String("method", r.Method),
@@ -119,7 +119,7 @@ func Redirect(w http.ResponseWriter, r *http.Request, uri string) {
 
 // RequestedRangeNotSatisfiable sends a range not satisfiable error to the client.
 func RequestedRangeNotSatisfiable(w http.ResponseWriter, r *http.Request, contentRange string) {
-	slog.Warn(http.StatusText(http.StatusRequestedRangeNotSatisfiable),
+	slog.WarnContext(r.Context(), http.StatusText(http.StatusRequestedRangeNotSatisfiable),
 		slog.String("client_ip", request.ClientIP(r)),
 		slog.Group("request",
 			slog.String("method", r.Method),
@@ -32,6 +32,15 @@ func Created(w http.ResponseWriter, r *http.Request, body interface{}) {
 	builder.Write()
 }
 
+// ServiceUnavailable sends a service unavailable response with a body to the client.
+func ServiceUnavailable(w http.ResponseWriter, r *http.Request, body interface{}) {
+	builder := response.New(w, r)
+	builder.WithStatus(http.StatusServiceUnavailable)
+	builder.WithHeader("Content-Type", contentTypeHeader)
+	builder.WithBody(toJSON(body))
+	builder.Write()
+}
+
 // NoContent sends a no content response to the client.
 func NoContent(w http.ResponseWriter, r *http.Request) {
 	builder := response.New(w, r)
@@ -49,7 +58,7 @@ func Accepted(w http.ResponseWriter, r *http.Request) {
 
 // ServerError sends an internal error to the client.
 func ServerError(w http.ResponseWriter, r *http.Request, err error) {
-	slog.Error(http.StatusText(http.StatusInternalServerError),
+	slog.ErrorContext(r.Context(), http.StatusText(http.StatusInternalServerError),
 		slog.Any("error", err),
 		slog.String("client_ip", request.ClientIP(r)),
 		slog.Group("request",
@@ -71,7 +80,7 @@ func ServerError(w http.ResponseWriter, r *http.Request, err error) {
 
 // BadRequest sends a bad request error to the client.
 func BadRequest(w http.ResponseWriter, r *http.Request, err error) {
-	slog.Warn(http.StatusText(http.StatusBadRequest),
+	slog.WarnContext(r.Context(), http.StatusText(http.StatusBadRequest),
 		slog.Any("error", err),
 		slog.String("client_ip", request.ClientIP(r)),
 		slog.Group("request",
@@ -93,7 +102,7 @@ func BadRequest(w http.ResponseWriter, r *http.Request, err error) {
 
 // Unauthorized sends a not authorized error to the client.
 func Unauthorized(w http.ResponseWriter, r *http.Request) {
-	slog.Warn(http.StatusText(http.StatusUnauthorized),
+	slog.WarnContext(r.Context(), http.StatusText(http.StatusUnauthorized),
 		slog.String("client_ip", request.ClientIP(r)),
 		slog.Group("request",
 			slog.String("method", r.Method),
@@ -114,7 +123,7 @@ func Unauthorized(w http.ResponseWriter, r *http.Request) {
 
 // Forbidden sends a forbidden error to the client.
 func Forbidden(w http.ResponseWriter, r *http.Request) {
-	slog.Warn(http.StatusText(http.StatusForbidden),
+	slog.WarnContext(r.Context(), http.StatusText(http.StatusForbidden),
 		slog.String("client_ip", request.ClientIP(r)),
 		slog.Group("request",
 			slog.String("method", r.Method),
@@ -135,7 +144,7 @@ func Forbidden(w http.ResponseWriter, r *http.Request) {
 
 // NotFound sends a page not found error to the client.
 func NotFound(w http.ResponseWriter, r *http.Request) {
-	slog.Warn(http.StatusText(http.StatusNotFound),
+	slog.WarnContext(r.Context(), http.StatusText(http.StatusNotFound),
 		slog.String("client_ip", request.ClientIP(r)),
 		slog.Group("request",
 			slog.String("method", r.Method),
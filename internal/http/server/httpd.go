@@ -18,10 +18,14 @@ import (
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/fever"
 	"miniflux.app/v2/internal/googlereader"
+	"miniflux.app/v2/internal/health"
 	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/scim"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/ui"
 	"miniflux.app/v2/internal/version"
+	"miniflux.app/v2/internal/websub"
 	"miniflux.app/v2/internal/worker"
 
 	"github.com/gorilla/mux"
@@ -192,9 +196,35 @@ func setupHandler(store *storage.Storage, pool *worker.Pool) *mux.Router {
 	fever.Serve(router, store)
 	googlereader.Serve(router, store)
 	api.Serve(router, store, pool)
+
+	if config.Opts.HasSCIM() {
+		scim.Serve(router, store)
+	}
+
+	if config.Opts.WebSub() {
+		websub.Serve(router, store)
+	}
+
 	ui.Serve(router, store, pool)
 
 	router.HandleFunc("/healthcheck", func(w http.ResponseWriter, r *http.Request) {
+		if request.QueryBoolParam(r, "deep", false) {
+			report := health.DeepCheck(store, pool)
+
+			// The details of the components are only sent to the networks allowed to access the metrics.
+			if !isMetricsAllowedNetwork(r) {
+				report = &health.Report{Status: report.Status}
+			}
+
+			if !report.IsHealthy() {
+				json.ServiceUnavailable(w, r, report)
+				return
+			}
+
+			json.OK(w, r, report)
+			return
+		}
+
 		if err := store.Ping(); err != nil {
 			http.Error(w, "Database Connection Error", http.StatusInternalServerError)
 			return
@@ -203,6 +233,16 @@ func setupHandler(store *storage.Storage, pool *worker.Pool) *mux.Router {
 		w.Write([]byte("OK"))
 	}).Name("healthcheck")
 
+	router.HandleFunc("/readiness", func(w http.ResponseWriter, r *http.Request) {
+		if err := health.CheckReadiness(store); err != nil {
+			slog.Warn("Readiness check failed", slog.Any("error", err))
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte("OK"))
+	}).Name("readiness")
+
 	router.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(version.Version))
 	}).Name("version")
@@ -268,6 +308,12 @@ func isAllowedToAccessMetricsEndpoint(r *http.Request) bool {
 		}
 	}
 
+	return isMetricsAllowedNetwork(r)
+}
+
+// isMetricsAllowedNetwork returns true if the request comes from a Unix socket or from the networks allowed to access the metrics.
+func isMetricsAllowedNetwork(r *http.Request) bool {
+	clientIP := request.ClientIP(r)
 	remoteIP := request.FindRemoteIP(r)
 	if remoteIP == "@" {
 		// This indicates a request sent via a Unix socket, always consider these trusted.
@@ -4,21 +4,37 @@
 package httpd // import "miniflux.app/v2/internal/http/server"
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
+	"regexp"
 	"time"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/tracing"
 )
 
+// requestIDRegex restricts the request IDs forwarded by reverse-proxies to safe values.
+var requestIDRegex = regexp.MustCompile(`^[a-zA-Z0-9._:-]{1,128}$`)
+
 func middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientIP := request.FindClientIP(r)
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, request.ClientIPContextKey, clientIP)
 
+		requestID := r.Header.Get("X-Request-Id")
+		if !requestIDRegex.MatchString(requestID) {
+			requestID = crypto.GenerateRandomStringHex(16)
+		}
+		ctx = context.WithValue(ctx, request.RequestIDContextKey, requestID)
+		w.Header().Set("X-Request-Id", requestID)
+
 		if r.Header.Get("X-Forwarded-Proto") == "https" {
 			config.Opts.HTTPS = true
 		}
@@ -26,6 +42,8 @@ func middleware(next http.Handler) http.Handler {
 		t1 := time.Now()
 		defer func() {
 			slog.Debug("Incoming request",
+				slog.String("component", "http"),
+				slog.String("request_id", requestID),
 				slog.String("client_ip", clientIP),
 				slog.Group("request",
 					slog.String("method", r.Method),
@@ -40,6 +58,60 @@ func middleware(next http.Handler) http.Handler {
 			w.Header().Set("Strict-Transport-Security", "max-age=31536000")
 		}
 
+		if config.Opts.HasTracing() {
+			ctx = tracing.ContextWithTraceParent(ctx, r.Header.Get("traceparent"))
+			var span *tracing.Span
+			ctx, span = tracing.StartWithKind(ctx, r.Method, tracing.KindServer,
+				tracing.String("http.request.method", r.Method),
+				tracing.String("url.path", r.URL.Path),
+				tracing.String("client.address", clientIP),
+				tracing.String("user_agent.original", r.UserAgent()),
+				tracing.String("request_id", requestID),
+			)
+
+			recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			defer func() {
+				span.SetAttributes(tracing.Int("http.response.status_code", recorder.statusCode))
+				if recorder.statusCode >= http.StatusInternalServerError {
+					span.RecordError(errors.New(http.StatusText(recorder.statusCode)))
+				}
+				span.End()
+			}()
+			w = recorder
+		}
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// statusRecorder keeps the status code of the response for the request span.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (s *statusRecorder) WriteHeader(statusCode int) {
+	s.statusCode = statusCode
+	s.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Unwrap allows http.ResponseController to access the original response writer.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// Flush keeps the streamed responses working for the handlers checking for http.Flusher.
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack keeps the connection upgrades working for the handlers checking for http.Hijacker.
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	return hijacker.Hijack()
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package iconstore // import "miniflux.app/v2/internal/iconstore"
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// fileStore saves the icons in a local directory, the files are spread in subdirectories named after the first characters of the hash.
+type fileStore struct {
+	directory string
+}
+
+func newFileStore(directory string) *fileStore {
+	return &fileStore{directory: directory}
+}
+
+func (f *fileStore) path(hash string) (string, error) {
+	if !isValidHash(hash) {
+		return "", fmt.Errorf("iconstore: invalid icon hash %q", hash)
+	}
+	return filepath.Join(f.directory, hash[:2], hash), nil
+}
+
+// Put writes the file atomically, a partially written icon is never served.
+func (f *fileStore) Put(hash string, content []byte) error {
+	path, err := f.path(hash)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("iconstore: unable to create directory: %v", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+hash+".*")
+	if err != nil {
+		return fmt.Errorf("iconstore: unable to create file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return fmt.Errorf("iconstore: unable to write file: %v", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("iconstore: unable to write file: %v", err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("iconstore: unable to rename file: %v", err)
+	}
+
+	return nil
+}
+
+func (f *fileStore) Get(hash string) ([]byte, error) {
+	path, err := f.path(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("iconstore: unable to read file: %v", err)
+	}
+
+	return content, nil
+}
+
+func (f *fileStore) Delete(hash string) error {
+	path, err := f.path(hash)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("iconstore: unable to remove file: %v", err)
+	}
+
+	return nil
+}
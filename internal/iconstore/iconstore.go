@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package iconstore saves the content of the icons outside the database, in a local directory or in an S3-compatible bucket.
+package iconstore // import "miniflux.app/v2/internal/iconstore"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrNotFound is returned when the content of an icon is not in the store.
+var ErrNotFound = errors.New("iconstore: icon not found")
+
+// Store saves the content of the icons, identified by their hash.
+type Store interface {
+	Put(hash string, content []byte) error
+	Get(hash string) ([]byte, error)
+	Delete(hash string) error
+}
+
+// New returns the store for the given URL, for example "file:///var/lib/miniflux/icons"
+// or "s3://access_key:secret_key@s3.us-east-1.amazonaws.com/bucket/prefix?region=us-east-1".
+// The scheme "s3+http" connects to the S3 endpoint without TLS.
+func New(storeURL string) (Store, error) {
+	u, err := url.Parse(storeURL)
+	if err != nil {
+		return nil, fmt.Errorf("iconstore: invalid URL: %v", err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, errors.New("iconstore: the directory is missing")
+		}
+		return newFileStore(u.Path), nil
+	case "s3", "s3+http":
+		return newS3Store(u)
+	default:
+		return nil, fmt.Errorf("iconstore: unsupported URL scheme %q", u.Scheme)
+	}
+}
+
+// isValidHash prevents path traversals: the icons are identified by the hexadecimal SHA-256 of their content.
+func isValidHash(hash string) bool {
+	return len(hash) == 64 && strings.Trim(hash, "0123456789abcdef") == ""
+}
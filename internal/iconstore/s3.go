@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package iconstore // import "miniflux.app/v2/internal/iconstore"
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	s3Timeout       = 30 * time.Second
+	maxS3ObjectSize = 10 * 1024 * 1024
+)
+
+// s3Store saves the icons in a bucket of an S3-compatible service.
+// The requests use path-style URLs and are signed with AWS Signature Version 4.
+type s3Store struct {
+	endpoint        *url.URL
+	bucket          string
+	prefix          string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+
+	now func() time.Time
+}
+
+func newS3Store(u *url.URL) (*s3Store, error) {
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if bucket == "" {
+		return nil, errors.New("iconstore: the S3 bucket is missing")
+	}
+
+	if u.User == nil {
+		return nil, errors.New("iconstore: the S3 credentials are missing")
+	}
+
+	scheme := "https"
+	if u.Scheme == "s3+http" {
+		scheme = "http"
+	}
+
+	store := &s3Store{
+		endpoint:    &url.URL{Scheme: scheme, Host: u.Host},
+		bucket:      bucket,
+		prefix:      strings.Trim(prefix, "/"),
+		region:      u.Query().Get("region"),
+		accessKeyID: u.User.Username(),
+		client:      &http.Client{Timeout: s3Timeout},
+		now:         time.Now,
+	}
+	store.secretAccessKey, _ = u.User.Password()
+
+	if store.region == "" {
+		store.region = defaultS3Region
+	}
+
+	return store, nil
+}
+
+func (s *s3Store) objectURL(hash string) (string, error) {
+	if !isValidHash(hash) {
+		return "", fmt.Errorf("iconstore: invalid icon hash %q", hash)
+	}
+
+	key := hash
+	if s.prefix != "" {
+		key = s.prefix + "/" + hash
+	}
+
+	objectURL := *s.endpoint
+	objectURL.Path = "/" + s.bucket + "/" + key
+	return objectURL.String(), nil
+}
+
+func (s *s3Store) Put(hash string, content []byte) error {
+	objectURL, err := s.objectURL(hash)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPut, objectURL, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("iconstore: unable to create request: %v", err)
+	}
+	request.Header.Set("Content-Type", "application/octet-stream")
+
+	response, err := s.do(request, content)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+
+	return nil
+}
+
+func (s *s3Store) Get(hash string) ([]byte, error) {
+	objectURL, err := s.objectURL(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodGet, objectURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("iconstore: unable to create request: %v", err)
+	}
+
+	response, err := s.do(request, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(response.Body, maxS3ObjectSize))
+	if err != nil {
+		return nil, fmt.Errorf("iconstore: unable to read S3 object: %v", err)
+	}
+
+	return content, nil
+}
+
+func (s *s3Store) Delete(hash string) error {
+	objectURL, err := s.objectURL(hash)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodDelete, objectURL, nil)
+	if err != nil {
+		return fmt.Errorf("iconstore: unable to create request: %v", err)
+	}
+
+	response, err := s.do(request, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	response.Body.Close()
+
+	return nil
+}
+
+// do signs and sends the request, the response body must be closed by the caller when no error is returned.
+func (s *s3Store) do(request *http.Request, payload []byte) (*http.Response, error) {
+	s.sign(request, payload, s.now())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("iconstore: S3 request failed: %v", err)
+	}
+
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		response.Body.Close()
+		return nil, ErrNotFound
+	case response.StatusCode >= 300:
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		response.Body.Close()
+		return nil, fmt.Errorf("iconstore: S3 request failed with status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return response, nil
+}
+
+// sign adds the AWS Signature Version 4 to the request, all the headers of the request are signed.
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html.
+func (s *s3Store) sign(request *http.Request, payload []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), date)
+	for _, value := range []string{s.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, value)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func sha256Hex(value []byte) string {
+	hash := sha256.Sum256(value)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
@@ -6,6 +6,7 @@ package apprise
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -26,20 +27,37 @@ func NewClient(serviceURL, baseURL string) *Client {
 	return &Client{serviceURL, baseURL}
 }
 
-func (c *Client) SendNotification(entry *model.Entry) error {
+func (c *Client) SendNotification(feed *model.Feed, entries model.Entries) error {
 	if c.baseURL == "" || c.servicesURL == "" {
 		return fmt.Errorf("apprise: missing base URL or services URL")
 	}
 
-	message := "[" + entry.Title + "]" + "(" + entry.URL + ")" + "\n\n"
 	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.baseURL, "/notify")
 	if err != nil {
 		return fmt.Errorf(`apprise: invalid API endpoint: %v`, err)
 	}
 
-	requestBody, err := json.Marshal(map[string]any{
-		"urls": c.servicesURL,
-		"body": message,
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+
+	// A failed notification doesn't prevent the notifications of the other entries to be sent.
+	var errs []error
+	for _, entry := range entries {
+		if err := c.sendEntryNotification(httpClient, apiEndpoint, feed, entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Client) sendEntryNotification(httpClient *http.Client, apiEndpoint string, feed *model.Feed, entry *model.Entry) error {
+	message := "[" + entry.Title + "]" + "(" + entry.URL + ")" + "\n\n"
+
+	requestBody, err := json.Marshal(&notificationRequest{
+		URLs:   c.servicesURL,
+		Title:  feed.Title,
+		Body:   message,
+		Format: "markdown",
 	})
 	if err != nil {
 		return fmt.Errorf("apprise: unable to encode request body: %v", err)
@@ -53,16 +71,22 @@ func (c *Client) SendNotification(entry *model.Entry) error {
 	request.Header.Set("Content-Type", "application/json")
 	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
 
-	httpClient := &http.Client{Timeout: defaultClientTimeout}
 	response, err := httpClient.Do(request)
 	if err != nil {
 		return fmt.Errorf("apprise: unable to send request: %v", err)
 	}
-	defer response.Body.Close()
+	response.Body.Close()
 
 	if response.StatusCode >= 400 {
-		return fmt.Errorf("apprise: unable to send a notification: url=%s status=%d", apiEndpoint, response.StatusCode)
+		return fmt.Errorf("apprise: unable to send a notification: url=%s status=%d entry_url=%s", apiEndpoint, response.StatusCode, entry.URL)
 	}
 
 	return nil
 }
+
+type notificationRequest struct {
+	URLs   string `json:"urls"`
+	Title  string `json:"title,omitempty"`
+	Body   string `json:"body"`
+	Format string `json:"format,omitempty"`
+}
@@ -0,0 +1,301 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bluesky // import "miniflux.app/v2/internal/integration/bluesky"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/urllib"
+	"miniflux.app/v2/internal/version"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	defaultClientTimeout = 10 * time.Second
+	defaultPDSURL        = "https://bsky.social"
+
+	// Bluesky limits posts to 300 graphemes, runes are used as an approximation.
+	MaxPostLength = 300
+
+	maxDescriptionLength = 300
+	maxThumbnailSize     = 1000000
+)
+
+var urlRegex = regexp.MustCompile(`https?://[^\s]+`)
+
+type Client struct {
+	pdsURL      string
+	handle      string
+	appPassword string
+}
+
+func NewClient(pdsURL, handle, appPassword string) *Client {
+	if pdsURL == "" {
+		pdsURL = defaultPDSURL
+	}
+	return &Client{pdsURL: pdsURL, handle: handle, appPassword: appPassword}
+}
+
+// PostEntry publishes a post with a link card pointing to the entry.
+func (c *Client) PostEntry(entry *model.Entry, text string) error {
+	if c.handle == "" || c.appPassword == "" {
+		return fmt.Errorf("bluesky: missing handle or app password")
+	}
+
+	if utf8.RuneCountInString(text) > MaxPostLength {
+		return fmt.Errorf("bluesky: the post is longer than %d characters", MaxPostLength)
+	}
+
+	session, err := c.createSession()
+	if err != nil {
+		return err
+	}
+
+	external := &externalEmbed{
+		URI:         entry.URL,
+		Title:       entry.Title,
+		Description: description(entry.Content),
+	}
+
+	if thumbnailURL := thumbnail(entry); thumbnailURL != "" {
+		// The link card is still useful without a thumbnail, errors are ignored on purpose.
+		if blob, err := c.uploadThumbnail(session, thumbnailURL); err == nil {
+			external.Thumb = blob
+		}
+	}
+
+	record := &postRecord{
+		Type:      "app.bsky.feed.post",
+		Text:      text,
+		Facets:    linkFacets(text),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Embed: &postEmbed{
+			Type:     "app.bsky.embed.external",
+			External: external,
+		},
+	}
+
+	request := &createRecordRequest{
+		Repo:       session.DID,
+		Collection: "app.bsky.feed.post",
+		Record:     record,
+	}
+
+	if err := c.call(session, "com.atproto.repo.createRecord", "application/json", request, nil); err != nil {
+		return fmt.Errorf("bluesky: unable to publish post: %v", err)
+	}
+
+	return nil
+}
+
+func (c *Client) createSession() (*sessionResponse, error) {
+	var session sessionResponse
+	request := &sessionRequest{Identifier: c.handle, Password: c.appPassword}
+	if err := c.call(nil, "com.atproto.server.createSession", "application/json", request, &session); err != nil {
+		return nil, fmt.Errorf("bluesky: unable to authenticate: %v", err)
+	}
+	return &session, nil
+}
+
+func (c *Client) uploadThumbnail(session *sessionResponse, imageURL string) (*blob, error) {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
+	requestBuilder.UseProxy(config.Opts.HasHTTPClientProxyConfigured())
+	requestBuilder.WithUserAgent(config.Opts.HTTPClientUserAgent(), config.Opts.HTTPClientUserAgent())
+
+	response, err := requestBuilder.ExecuteRequest(imageURL)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bluesky: unable to fetch thumbnail: status=%d", response.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxThumbnailSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxThumbnailSize {
+		return nil, fmt.Errorf("bluesky: thumbnail is too large")
+	}
+
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("bluesky: unsupported thumbnail type %q", contentType)
+	}
+
+	var uploadResponse uploadBlobResponse
+	if err := c.call(session, "com.atproto.repo.uploadBlob", contentType, data, &uploadResponse); err != nil {
+		return nil, err
+	}
+
+	return uploadResponse.Blob, nil
+}
+
+func (c *Client) call(session *sessionResponse, method, contentType string, payload, result any) error {
+	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.pdsURL, "/xrpc/"+method)
+	if err != nil {
+		return fmt.Errorf("invalid API endpoint: %v", err)
+	}
+
+	var requestBody []byte
+	if data, isRaw := payload.([]byte); isRaw {
+		requestBody = data
+	} else if requestBody, err = json.Marshal(payload); err != nil {
+		return fmt.Errorf("unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, apiEndpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	if session != nil {
+		request.Header.Set("Authorization", "Bearer "+session.AccessJWT)
+	}
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("url=%s status=%d", apiEndpoint, response.StatusCode)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+			return fmt.Errorf("unable to decode response: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// linkFacets returns the rich text annotations required to make links clickable.
+func linkFacets(text string) []*facet {
+	var facets []*facet
+	for _, match := range urlRegex.FindAllStringIndex(text, -1) {
+		uri := strings.TrimRight(text[match[0]:match[1]], ".,;:!?)")
+		facets = append(facets, &facet{
+			Index: facetIndex{ByteStart: match[0], ByteEnd: match[0] + len(uri)},
+			Features: []facetFeature{
+				{Type: "app.bsky.richtext.facet#link", URI: uri},
+			},
+		})
+	}
+	return facets
+}
+
+func description(content string) string {
+	text := strings.Join(strings.Fields(sanitizer.StripTags(content)), " ")
+	if utf8.RuneCountInString(text) <= maxDescriptionLength {
+		return text
+	}
+
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:maxDescriptionLength-1])) + "…"
+}
+
+func thumbnail(entry *model.Entry) string {
+	for _, enclosure := range entry.Enclosures {
+		if strings.HasPrefix(enclosure.MimeType, "image/") {
+			return enclosure.URL
+		}
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(entry.Content))
+	if err != nil {
+		return ""
+	}
+
+	src, _ := doc.Find("img[src]").First().Attr("src")
+	if !strings.HasPrefix(src, "https://") && !strings.HasPrefix(src, "http://") {
+		return ""
+	}
+	return src
+}
+
+type sessionRequest struct {
+	Identifier string `json:"identifier"`
+	Password   string `json:"password"`
+}
+
+type sessionResponse struct {
+	AccessJWT string `json:"accessJwt"`
+	DID       string `json:"did"`
+}
+
+type uploadBlobResponse struct {
+	Blob *blob `json:"blob"`
+}
+
+type blob struct {
+	Type     string          `json:"$type"`
+	Ref      json.RawMessage `json:"ref"`
+	MimeType string          `json:"mimeType"`
+	Size     int             `json:"size"`
+}
+
+type createRecordRequest struct {
+	Repo       string      `json:"repo"`
+	Collection string      `json:"collection"`
+	Record     *postRecord `json:"record"`
+}
+
+type postRecord struct {
+	Type      string     `json:"$type"`
+	Text      string     `json:"text"`
+	Facets    []*facet   `json:"facets,omitempty"`
+	CreatedAt string     `json:"createdAt"`
+	Embed     *postEmbed `json:"embed,omitempty"`
+}
+
+type postEmbed struct {
+	Type     string         `json:"$type"`
+	External *externalEmbed `json:"external"`
+}
+
+type externalEmbed struct {
+	URI         string `json:"uri"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Thumb       *blob  `json:"thumb,omitempty"`
+}
+
+type facet struct {
+	Index    facetIndex     `json:"index"`
+	Features []facetFeature `json:"features"`
+}
+
+type facetIndex struct {
+	ByteStart int `json:"byteStart"`
+	ByteEnd   int `json:"byteEnd"`
+}
+
+type facetFeature struct {
+	Type string `json:"$type"`
+	URI  string `json:"uri"`
+}
@@ -0,0 +1,322 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+const (
+	maxDeliveryAttempts = 10
+	maxRetryDelay       = 12 * time.Hour
+
+	// The circuit opens after breakerThreshold consecutive failures and stays open for breakerCooldown.
+	breakerThreshold = 5
+	breakerCooldown  = 15 * time.Minute
+)
+
+var errCircuitOpen = errors.New("integration: too many consecutive failures, delivery postponed")
+
+var breakers = &circuitBreakers{states: make(map[string]*breakerState)}
+
+// dispatch tracks the destinations reached while sending entries and the deliveries that failed.
+type dispatch struct {
+	userID       int64
+	routingRules model.IntegrationRoutingRules
+
+	// destination restricts the dispatch to a single destination when retrying a delivery.
+	destination string
+
+	attempted []string
+	failures  []*deliveryFailure
+}
+
+type deliveryFailure struct {
+	destination string
+	entries     model.Entries
+	err         error
+
+	// postponedUntil is set when the destination was not called because its circuit is open.
+	postponedUntil time.Time
+}
+
+func newDispatch(store *storage.Storage, userID int64, destination string) *dispatch {
+	routingRules, err := store.IntegrationRoutingRules(userID)
+	if err != nil {
+		// Without rules, entries are sent to all destinations rather than being lost.
+		slog.Error("Unable to fetch integration routing rules",
+			slog.Int64("user_id", userID),
+			slog.Any("error", err),
+		)
+	}
+
+	return &dispatch{userID: userID, routingRules: routingRules, destination: destination}
+}
+
+// accepts returns true if the entries of the feed must be sent to the destination now.
+//
+// The feed is nil when the entries were picked explicitly and routing rules do not apply.
+func (d *dispatch) accepts(destination string, feed *model.Feed, entries model.Entries) bool {
+	if d.destination != "" && d.destination != destination {
+		return false
+	}
+
+	if !d.routingRules.Allows(destination, feed) {
+		return false
+	}
+
+	if openUntil, isOpen := breakers.openUntil(d.userID, destination); isOpen {
+		slog.Debug("Postponing delivery because the circuit is open",
+			slog.Int64("user_id", d.userID),
+			slog.String("destination", destination),
+			slog.Time("open_until", openUntil),
+		)
+		d.failures = append(d.failures, &deliveryFailure{destination: destination, entries: entries, err: errCircuitOpen, postponedUntil: openUntil})
+		return false
+	}
+
+	d.attempted = append(d.attempted, destination)
+	return true
+}
+
+func (d *dispatch) fail(destination string, entries model.Entries, err error) {
+	breakers.recordFailure(d.userID, destination)
+	d.failures = append(d.failures, &deliveryFailure{destination: destination, entries: entries, err: err})
+}
+
+// finish closes the circuit of the destinations reached without errors.
+func (d *dispatch) finish() {
+	for _, destination := range d.attempted {
+		hasFailed := slices.ContainsFunc(d.failures, func(failure *deliveryFailure) bool {
+			return failure.destination == destination
+		})
+		if !hasFailed {
+			breakers.recordSuccess(d.userID, destination)
+		}
+	}
+}
+
+// queueFailures stores the failed deliveries, one per destination, to retry them later.
+func (d *dispatch) queueFailures(store *storage.Storage, eventType string, feedID int64) {
+	d.finish()
+
+	var deliveries []*model.IntegrationDelivery
+	deliveryByDestination := make(map[string]*model.IntegrationDelivery)
+	for _, failure := range d.failures {
+		delivery, found := deliveryByDestination[failure.destination]
+		if !found {
+			delivery = &model.IntegrationDelivery{
+				UserID:        d.userID,
+				Destination:   failure.destination,
+				EventType:     eventType,
+				FeedID:        feedID,
+				Status:        model.IntegrationDeliveryStatusPending,
+				LastError:     failure.err.Error(),
+				NextAttemptAt: time.Now().Add(retryDelay(1)),
+				Attempts:      1,
+			}
+
+			if !failure.postponedUntil.IsZero() {
+				delivery.Attempts = 0
+				delivery.NextAttemptAt = failure.postponedUntil
+			}
+
+			deliveryByDestination[failure.destination] = delivery
+			deliveries = append(deliveries, delivery)
+		}
+
+		for _, entry := range failure.entries {
+			if !slices.Contains(delivery.EntryIDs, entry.ID) {
+				delivery.EntryIDs = append(delivery.EntryIDs, entry.ID)
+			}
+		}
+	}
+
+	for _, delivery := range deliveries {
+		if err := store.CreateIntegrationDelivery(delivery); err != nil {
+			slog.Error("Unable to queue integration delivery",
+				slog.Int64("user_id", delivery.UserID),
+				slog.String("destination", delivery.Destination),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+// ProcessDeliveryQueue retries the pending deliveries that are due.
+func ProcessDeliveryQueue(store *storage.Storage, batchSize int) {
+	deliveries, err := store.DueIntegrationDeliveries(batchSize)
+	if err != nil {
+		slog.Error("Unable to fetch integration deliveries", slog.Any("error", err))
+		return
+	}
+
+	for _, delivery := range deliveries {
+		if err := retryDelivery(store, delivery); err != nil {
+			slog.Error("Unable to retry integration delivery",
+				slog.Int64("user_id", delivery.UserID),
+				slog.Int64("delivery_id", delivery.ID),
+				slog.String("destination", delivery.Destination),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+func retryDelivery(store *storage.Storage, delivery *model.IntegrationDelivery) error {
+	user, err := store.UserByID(delivery.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return store.RemoveIntegrationDelivery(delivery.UserID, delivery.ID)
+	}
+
+	userIntegrations, err := store.Integration(delivery.UserID)
+	if err != nil {
+		return err
+	}
+
+	builder := store.NewEntryQueryBuilder(delivery.UserID)
+	builder.WithEntryIDs(delivery.EntryIDs)
+	builder.WithEnclosures()
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return store.RemoveIntegrationDelivery(delivery.UserID, delivery.ID)
+	}
+
+	d := newDispatch(store, delivery.UserID, delivery.Destination)
+
+	switch delivery.EventType {
+	case model.IntegrationDeliveryEventSaveEntry:
+		if delivery.Destination == "kindle" {
+			if userIntegrations.KindleEnabled && d.accepts("kindle", nil, entries) {
+				d.sendEntriesToKindle(entries, userIntegrations)
+			}
+		} else {
+			for _, entry := range entries {
+				d.sendEntry(entry, userIntegrations)
+			}
+		}
+	case model.IntegrationDeliveryEventNewEntries:
+		feed, err := store.FeedByID(delivery.UserID, delivery.FeedID)
+		if err != nil {
+			return err
+		}
+
+		if feed == nil {
+			return store.RemoveIntegrationDelivery(delivery.UserID, delivery.ID)
+		}
+
+		d.pushEntries(feed, entries, userIntegrations, user)
+	default:
+		return fmt.Errorf("integration: unknown delivery event type %q", delivery.EventType)
+	}
+
+	d.finish()
+
+	// The delivery is also removed when the destination has been disabled in the meantime.
+	if len(d.failures) == 0 {
+		return store.RemoveIntegrationDelivery(delivery.UserID, delivery.ID)
+	}
+
+	failure := d.failures[0]
+	delivery.LastError = failure.err.Error()
+
+	switch {
+	case !failure.postponedUntil.IsZero():
+		delivery.NextAttemptAt = failure.postponedUntil
+	case delivery.Attempts+1 >= maxDeliveryAttempts:
+		delivery.Attempts++
+		delivery.Status = model.IntegrationDeliveryStatusFailed
+		slog.Warn("Giving up integration delivery",
+			slog.Int64("user_id", delivery.UserID),
+			slog.Int64("delivery_id", delivery.ID),
+			slog.String("destination", delivery.Destination),
+			slog.Int("attempts", delivery.Attempts),
+		)
+	default:
+		delivery.Attempts++
+		delivery.NextAttemptAt = time.Now().Add(retryDelay(delivery.Attempts))
+	}
+
+	return store.UpdateIntegrationDelivery(delivery)
+}
+
+// retryDelay returns the exponential backoff delay after the given number of attempts.
+func retryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		return time.Minute
+	}
+
+	if attempts > 10 {
+		return maxRetryDelay
+	}
+
+	return min(time.Minute<<(attempts-1), maxRetryDelay)
+}
+
+type breakerState struct {
+	failures  int
+	openUntil time.Time
+}
+
+// circuitBreakers keeps the state of each user destination in memory.
+type circuitBreakers struct {
+	mu     sync.Mutex
+	states map[string]*breakerState
+}
+
+func breakerKey(userID int64, destination string) string {
+	return fmt.Sprintf("%d:%s", userID, destination)
+}
+
+func (c *circuitBreakers) openUntil(userID int64, destination string) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, found := c.states[breakerKey(userID, destination)]
+	if !found || !time.Now().Before(state.openUntil) {
+		return time.Time{}, false
+	}
+	return state.openUntil, true
+}
+
+func (c *circuitBreakers) recordFailure(userID int64, destination string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := breakerKey(userID, destination)
+	state, found := c.states[key]
+	if !found {
+		state = &breakerState{}
+		c.states[key] = state
+	}
+
+	state.failures++
+	if state.failures >= breakerThreshold {
+		state.openUntil = time.Now().Add(breakerCooldown)
+		state.failures = 0
+	}
+}
+
+func (c *circuitBreakers) recordSuccess(userID int64, destination string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.states, breakerKey(userID, destination))
+}
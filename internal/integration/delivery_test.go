@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestRetryDelay(t *testing.T) {
+	scenarios := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{-1, time.Minute},
+		{0, time.Minute},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{6, 32 * time.Minute},
+		{10, 512 * time.Minute},
+		{11, maxRetryDelay},
+		{100, maxRetryDelay},
+	}
+
+	for _, scenario := range scenarios {
+		if delay := retryDelay(scenario.attempts); delay != scenario.expected {
+			t.Errorf(`The delay after %d attempts should be %v, got %v`, scenario.attempts, scenario.expected, delay)
+		}
+	}
+}
+
+func TestCircuitBreakers(t *testing.T) {
+	scenarios := []struct {
+		name       string
+		failures   int
+		success    bool
+		expectOpen bool
+	}{
+		{"no failure", 0, false, false},
+		{"below the threshold", breakerThreshold - 1, false, false},
+		{"at the threshold", breakerThreshold, false, true},
+		{"above the threshold", breakerThreshold + 1, false, true},
+		{"success before the threshold", breakerThreshold - 1, true, false},
+		{"success after the threshold", breakerThreshold, true, false},
+	}
+
+	for _, scenario := range scenarios {
+		c := &circuitBreakers{states: make(map[string]*breakerState)}
+		for range scenario.failures {
+			c.recordFailure(1, "webhook")
+		}
+
+		if scenario.success {
+			c.recordSuccess(1, "webhook")
+		}
+
+		openUntil, isOpen := c.openUntil(1, "webhook")
+		if isOpen != scenario.expectOpen {
+			t.Errorf(`%s: the circuit open state should be %v, got %v`, scenario.name, scenario.expectOpen, isOpen)
+		}
+
+		if isOpen && time.Until(openUntil) > breakerCooldown {
+			t.Errorf(`%s: the circuit should not stay open longer than %v, got %v`, scenario.name, breakerCooldown, openUntil)
+		}
+
+		if _, isOpen := c.openUntil(2, "webhook"); isOpen {
+			t.Errorf(`%s: the circuit of another user should stay closed`, scenario.name)
+		}
+
+		if _, isOpen := c.openUntil(1, "ntfy"); isOpen {
+			t.Errorf(`%s: the circuit of another destination should stay closed`, scenario.name)
+		}
+	}
+}
+
+func TestCircuitBreakerClosesAfterCooldown(t *testing.T) {
+	c := &circuitBreakers{states: make(map[string]*breakerState)}
+	for range breakerThreshold {
+		c.recordFailure(1, "webhook")
+	}
+
+	c.states[breakerKey(1, "webhook")].openUntil = time.Now().Add(-time.Second)
+
+	if _, isOpen := c.openUntil(1, "webhook"); isOpen {
+		t.Error(`The circuit should be closed once the cooldown is over`)
+	}
+
+	// The failures are counted again from zero once the circuit has been opened.
+	c.recordFailure(1, "webhook")
+	if _, isOpen := c.openUntil(1, "webhook"); isOpen {
+		t.Error(`A single failure after the cooldown should not open the circuit again`)
+	}
+}
+
+func TestDispatchPostponesDestinationWithOpenCircuit(t *testing.T) {
+	const userID = -1
+	t.Cleanup(func() {
+		breakers.recordSuccess(userID, "webhook")
+		breakers.recordSuccess(userID, "ntfy")
+	})
+
+	entries := model.Entries{{ID: 42}}
+	d := &dispatch{userID: userID}
+	for range breakerThreshold {
+		d.fail("webhook", entries, errors.New("unavailable"))
+	}
+
+	if d.accepts("webhook", nil, entries) {
+		t.Fatal(`The destination with an open circuit should not be called`)
+	}
+
+	failure := d.failures[len(d.failures)-1]
+	if !errors.Is(failure.err, errCircuitOpen) || failure.postponedUntil.IsZero() {
+		t.Errorf(`The delivery should be postponed until the circuit closes, got %+v`, failure)
+	}
+
+	if !d.accepts("ntfy", nil, entries) {
+		t.Error(`The other destinations should still be called`)
+	}
+
+	retry := &dispatch{userID: userID, destination: "ntfy"}
+	if retry.accepts("telegram", nil, entries) {
+		t.Error(`A retry dispatch should only call its own destination`)
+	}
+}
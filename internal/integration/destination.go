@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+// Destination is a third-party service receiving entries.
+type Destination struct {
+	Name  string
+	Title string
+}
+
+// Destinations lists the services used by the delivery queue and the routing rules.
+var Destinations = []Destination{
+	{"apprise", "Apprise"},
+	{"betula", "Betula"},
+	{"espial", "Espial"},
+	{"homeassistant", "Home Assistant"},
+	{"instapaper", "Instapaper"},
+	{"kindle", "Kindle"},
+	{"linkace", "LinkAce"},
+	{"linkding", "Linkding"},
+	{"linkwarden", "Linkwarden"},
+	{"matrix", "Matrix Bot"},
+	{"notion", "Notion"},
+	{"ntfy", "Ntfy"},
+	{"nunux_keeper", "Nunux Keeper"},
+	{"omnivore", "Omnivore"},
+	{"pinboard", "Pinboard"},
+	{"pocket", "Pocket"},
+	{"raindrop", "Raindrop"},
+	{"readeck", "Readeck"},
+	{"readwise", "Readwise"},
+	{"shaarli", "Shaarli"},
+	{"shiori", "Shiori"},
+	{"telegram", "Telegram Bot"},
+	{"wallabag", "Wallabag"},
+	{"webhook", "Webhook"},
+	{"zotero", "Zotero"},
+}
+
+// IsValidDestination returns true if the name matches a known destination.
+func IsValidDestination(name string) bool {
+	for _, destination := range Destinations {
+		if destination.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package emaildigest // import "miniflux.app/v2/internal/integration/emaildigest"
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/mail"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/sanitizer"
+)
+
+const (
+	// MaxEntries is the maximum number of entries included in a single digest.
+	MaxEntries = 100
+
+	excerptLength = 280
+)
+
+var htmlTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{ .Subject }}</title></head>
+<body style="font-family: sans-serif; max-width: 640px; margin: 0 auto;">
+<h1 style="font-size: 1.4em;">{{ .Subject }}</h1>
+{{ range .Groups }}
+<h2 style="font-size: 1.2em; border-bottom: 1px solid #ddd;">{{ .Title }}</h2>
+{{ range .Items }}
+<p>
+<a href="{{ .URL }}" style="font-weight: bold;">{{ .Title }}</a><br>
+<small style="color: #666;">{{ .FeedTitle }}</small><br>
+{{ .Excerpt }}
+</p>
+{{ end }}
+{{ end }}
+</body>
+</html>
+`))
+
+type digestItem struct {
+	Title     string
+	URL       string
+	FeedTitle string
+	Excerpt   string
+}
+
+type digestGroup struct {
+	Title string
+	Items []digestItem
+}
+
+// IsDue returns true when a digest should be sent at the given time, in the user timezone.
+func IsDue(now, sentAt time.Time, frequency string, hour, weekday int) bool {
+	if now.Hour() != hour {
+		return false
+	}
+
+	if frequency == model.EmailDigestFrequencyWeekly && int(now.Weekday()) != weekday {
+		return false
+	}
+
+	// The scheduler runs every hour, make sure the same digest is not sent twice.
+	return sentAt.IsZero() || now.Sub(sentAt) > 23*time.Hour
+}
+
+// Period returns the time span covered by a digest.
+func Period(frequency string) time.Duration {
+	if frequency == model.EmailDigestFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// NewMessage builds the digest email for the given entries, grouped by category.
+func NewMessage(to, language, frequency string, now time.Time, entries model.Entries) (*mail.Message, error) {
+	printer := locale.NewPrinter(language)
+
+	subjectKey := "email_digest.subject.daily"
+	if frequency == model.EmailDigestFrequencyWeekly {
+		subjectKey = "email_digest.subject.weekly"
+	}
+	subject := printer.Printf(subjectKey, now.Format("2006-01-02"))
+
+	groups := groupEntries(entries)
+
+	var htmlBody bytes.Buffer
+	if err := htmlTemplate.Execute(&htmlBody, map[string]any{"Subject": subject, "Groups": groups}); err != nil {
+		return nil, fmt.Errorf("emaildigest: unable to render message: %v", err)
+	}
+
+	var textBody strings.Builder
+	textBody.WriteString(subject + "\n")
+	for _, group := range groups {
+		fmt.Fprintf(&textBody, "\n== %s ==\n", group.Title)
+		for _, item := range group.Items {
+			fmt.Fprintf(&textBody, "\n%s (%s)\n%s\n", item.Title, item.FeedTitle, item.URL)
+			if item.Excerpt != "" {
+				textBody.WriteString(item.Excerpt + "\n")
+			}
+		}
+	}
+
+	return &mail.Message{
+		To:       to,
+		Subject:  subject,
+		TextBody: textBody.String(),
+		HTMLBody: htmlBody.String(),
+	}, nil
+}
+
+func groupEntries(entries model.Entries) []*digestGroup {
+	var groups []*digestGroup
+	groupsByTitle := make(map[string]*digestGroup)
+
+	for _, entry := range entries {
+		categoryTitle := ""
+		feedTitle := ""
+		if entry.Feed != nil {
+			feedTitle = entry.Feed.Title
+			if entry.Feed.Category != nil {
+				categoryTitle = entry.Feed.Category.Title
+			}
+		}
+
+		group, found := groupsByTitle[categoryTitle]
+		if !found {
+			group = &digestGroup{Title: categoryTitle}
+			groupsByTitle[categoryTitle] = group
+			groups = append(groups, group)
+		}
+
+		group.Items = append(group.Items, digestItem{
+			Title:     entry.Title,
+			URL:       entry.URL,
+			FeedTitle: feedTitle,
+			Excerpt:   excerpt(entry.Content),
+		})
+	}
+
+	return groups
+}
+
+func excerpt(content string) string {
+	text := strings.Join(strings.Fields(sanitizer.StripTags(content)), " ")
+	if utf8.RuneCountInString(text) <= excerptLength {
+		return text
+	}
+
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:excerptLength])) + "…"
+}
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package homeassistant // import "miniflux.app/v2/internal/integration/homeassistant"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/integration/payloadtemplate"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/version"
+)
+
+const (
+	defaultClientTimeout = 10 * time.Second
+
+	// EventType is sent with each event to make filtering easier in automations.
+	EventType = "miniflux_new_entry"
+)
+
+var ruleFields = []string{"EntryTitle", "EntryURL", "EntryContent", "EntryAuthor", "EntryTag", "FeedTitle", "CategoryTitle"}
+
+type Client struct {
+	webhookURL      string
+	payloadTemplate string
+}
+
+// NewClient returns a Home Assistant client, the default JSON payload is replaced by the payload template when defined.
+func NewClient(webhookURL, payloadTemplate string) *Client {
+	return &Client{webhookURL: webhookURL, payloadTemplate: payloadTemplate}
+}
+
+// Rule is a compiled rule, the pattern is matched against the value of the field.
+type Rule struct {
+	definition string
+	field      string
+	pattern    *regexp.Regexp
+}
+
+// Rules is the list of compiled rules of a user, in their definition order.
+type Rules []*Rule
+
+// ParseRules compiles the rules once so they can be matched against many entries.
+//
+// Rules are defined one per line with the format "Field=Regex".
+func ParseRules(rules string) (Rules, error) {
+	var parsedRules Rules
+	for _, line := range strings.Split(rules, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		field, pattern, found := strings.Cut(line, "=")
+		if !found || !slices.Contains(ruleFields, field) {
+			return nil, fmt.Errorf("homeassistant: invalid rule %q", line)
+		}
+
+		compiledPattern, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("homeassistant: invalid pattern in rule %q: %v", line, err)
+		}
+
+		parsedRules = append(parsedRules, &Rule{definition: line, field: field, pattern: compiledPattern})
+	}
+
+	return parsedRules, nil
+}
+
+// ValidateRules returns an error if one of the rules is malformed.
+func ValidateRules(rules string) error {
+	_, err := ParseRules(rules)
+	return err
+}
+
+// Match returns the definition of the first rule matching the entry, or an empty string.
+func (r Rules) Match(feed *model.Feed, entry *model.Entry) string {
+	for _, rule := range r {
+		if rule.matches(feed, entry) {
+			return rule.definition
+		}
+	}
+
+	return ""
+}
+
+func (r *Rule) matches(feed *model.Feed, entry *model.Entry) bool {
+	switch r.field {
+	case "EntryTitle":
+		return r.pattern.MatchString(entry.Title)
+	case "EntryURL":
+		return r.pattern.MatchString(entry.URL)
+	case "EntryContent":
+		return r.pattern.MatchString(entry.Content)
+	case "EntryAuthor":
+		return r.pattern.MatchString(entry.Author)
+	case "EntryTag":
+		return slices.ContainsFunc(entry.Tags, r.pattern.MatchString)
+	case "FeedTitle":
+		return r.pattern.MatchString(feed.Title)
+	case "CategoryTitle":
+		return feed.Category != nil && r.pattern.MatchString(feed.Category.Title)
+	}
+
+	return false
+}
+
+// SendEvent triggers the Home Assistant webhook for a new entry.
+func (c *Client) SendEvent(feed *model.Feed, entry *model.Entry, rule string) error {
+	if c.webhookURL == "" {
+		return fmt.Errorf("homeassistant: missing webhook URL")
+	}
+
+	event := &entryEvent{
+		EventType: EventType,
+		Rule:      rule,
+		Entry: &eventEntry{
+			ID:          entry.ID,
+			Title:       entry.Title,
+			URL:         entry.URL,
+			Author:      entry.Author,
+			Content:     entry.Content,
+			Tags:        entry.Tags,
+			PublishedAt: entry.Date,
+		},
+		Feed: &eventFeed{
+			ID:      feed.ID,
+			Title:   feed.Title,
+			SiteURL: feed.SiteURL,
+		},
+	}
+
+	if feed.Category != nil {
+		event.Feed.Category = feed.Category.Title
+	}
+
+	var requestBody []byte
+	var err error
+	if c.payloadTemplate != "" {
+		if requestBody, err = payloadtemplate.Render(c.payloadTemplate, event); err != nil {
+			return fmt.Errorf("homeassistant: %v", err)
+		}
+	} else if requestBody, err = json.Marshal(event); err != nil {
+		return fmt.Errorf("homeassistant: unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, c.webhookURL, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("homeassistant: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("homeassistant: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("homeassistant: incorrect response status code %d for url %s", response.StatusCode, c.webhookURL)
+	}
+
+	return nil
+}
+
+type entryEvent struct {
+	EventType string      `json:"event_type"`
+	Rule      string      `json:"rule"`
+	Entry     *eventEntry `json:"entry"`
+	Feed      *eventFeed  `json:"feed"`
+}
+
+type eventEntry struct {
+	ID          int64     `json:"id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Author      string    `json:"author"`
+	Content     string    `json:"content"`
+	Tags        []string  `json:"tags"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+type eventFeed struct {
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	SiteURL  string `json:"site_url"`
+	Category string `json:"category"`
+}
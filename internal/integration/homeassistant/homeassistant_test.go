@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package homeassistant // import "miniflux.app/v2/internal/integration/homeassistant"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidateRules(t *testing.T) {
+	scenarios := []struct {
+		rules string
+		valid bool
+	}{
+		{"", true},
+		{"EntryTitle=(?i)miniflux", true},
+		{"EntryTitle=foo\n\n  FeedTitle=bar  \nEntryTag=baz", true},
+		{"EntryTitle", false},
+		{"UnknownField=foo", false},
+		{"EntryTitle=foo\nEntryURL=[", false},
+	}
+
+	for _, scenario := range scenarios {
+		err := ValidateRules(scenario.rules)
+		if scenario.valid && err != nil {
+			t.Errorf(`The rules %q should be valid, got %v`, scenario.rules, err)
+		}
+		if !scenario.valid && err == nil {
+			t.Errorf(`The rules %q should be invalid`, scenario.rules)
+		}
+	}
+}
+
+func TestParseRulesCompilesEachRuleOnce(t *testing.T) {
+	rules, err := ParseRules("EntryTitle=foo\n\nFeedTitle=bar\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rules) != 2 {
+		t.Fatalf(`Two rules should be parsed, got %d`, len(rules))
+	}
+
+	if rules[0].field != "EntryTitle" || rules[0].pattern.String() != "foo" || rules[1].definition != "FeedTitle=bar" {
+		t.Errorf(`Unexpected rules, got %+v, %+v`, rules[0], rules[1])
+	}
+}
+
+func TestRulesMatch(t *testing.T) {
+	feed := &model.Feed{Title: "Some Feed", Category: &model.Category{Title: "Home"}}
+	entry := &model.Entry{
+		Title:   "Door opened",
+		URL:     "https://example.org/door",
+		Content: "The front door has been opened",
+		Author:  "Sensor",
+		Tags:    []string{"security", "alert"},
+	}
+
+	scenarios := []struct {
+		rules    string
+		expected string
+	}{
+		{"", ""},
+		{"EntryTitle=(?i)door", "EntryTitle=(?i)door"},
+		{"EntryURL=example\\.org", "EntryURL=example\\.org"},
+		{"EntryContent=front", "EntryContent=front"},
+		{"EntryAuthor=^Sensor$", "EntryAuthor=^Sensor$"},
+		{"EntryTag=^alert$", "EntryTag=^alert$"},
+		{"FeedTitle=Feed", "FeedTitle=Feed"},
+		{"CategoryTitle=^Home$", "CategoryTitle=^Home$"},
+		{"EntryTitle=window\nEntryTag=security\nFeedTitle=Feed", "EntryTag=security"},
+		{"EntryTitle=window\nEntryTag=^secure$", ""},
+	}
+
+	for _, scenario := range scenarios {
+		rules, err := ParseRules(scenario.rules)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if match := rules.Match(feed, entry); match != scenario.expected {
+			t.Errorf(`The rules %q should match %q, got %q`, scenario.rules, scenario.expected, match)
+		}
+	}
+}
+
+func TestRulesMatchWithoutCategory(t *testing.T) {
+	rules, err := ParseRules("CategoryTitle=.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if match := rules.Match(&model.Feed{}, &model.Entry{}); match != "" {
+		t.Errorf(`The category rule should not match a feed without category, got %q`, match)
+	}
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package hypothesis // import "miniflux.app/v2/internal/integration/hypothesis"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/version"
+)
+
+const (
+	defaultClientTimeout = 10 * time.Second
+	defaultAPIEndpoint   = "https://api.hypothes.is/api"
+
+	// PublicGroup is the group of annotations visible by everyone.
+	PublicGroup = "__world__"
+
+	maxSearchResults = 50
+)
+
+// Annotation represents a Hypothes.is annotation.
+type Annotation struct {
+	ID      string
+	User    string
+	Text    string
+	Quote   string
+	Created time.Time
+	Link    string
+}
+
+type Client struct {
+	apiToken string
+	group    string
+}
+
+func NewClient(apiToken, group string) *Client {
+	if group == "" {
+		group = PublicGroup
+	}
+	return &Client{apiToken: apiToken, group: group}
+}
+
+// CreateAnnotation publishes a note on the page, the quote is the highlighted text, if any.
+func (c *Client) CreateAnnotation(pageURL, quote, text string) error {
+	if c.apiToken == "" {
+		return fmt.Errorf("hypothesis: missing API token")
+	}
+
+	annotation := &annotationRequest{
+		URI:   pageURL,
+		Text:  text,
+		Group: c.group,
+		Tags:  []string{},
+	}
+
+	target := annotationTarget{Source: pageURL}
+	if quote = strings.TrimSpace(quote); quote != "" {
+		target.Selector = []annotationSelector{{Type: "TextQuoteSelector", Exact: quote}}
+	}
+	annotation.Target = []annotationTarget{target}
+
+	requestBody, err := json.Marshal(annotation)
+	if err != nil {
+		return fmt.Errorf("hypothesis: unable to encode request body: %v", err)
+	}
+
+	apiEndpoint := defaultAPIEndpoint + "/annotations"
+	request, err := http.NewRequest(http.MethodPost, apiEndpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("hypothesis: unable to create request: %v", err)
+	}
+
+	response, err := c.do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("hypothesis: unable to create annotation: url=%s status=%d", apiEndpoint, response.StatusCode)
+	}
+
+	return nil
+}
+
+// Annotations returns the annotations of the page visible by the user.
+func (c *Client) Annotations(pageURL string) ([]*Annotation, error) {
+	values := url.Values{}
+	values.Set("uri", pageURL)
+	values.Set("limit", fmt.Sprintf("%d", maxSearchResults))
+	values.Set("sort", "created")
+	values.Set("order", "asc")
+
+	apiEndpoint := defaultAPIEndpoint + "/search?" + values.Encode()
+	request, err := http.NewRequest(http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("hypothesis: unable to create request: %v", err)
+	}
+
+	response, err := c.do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("hypothesis: unable to fetch annotations: url=%s status=%d", apiEndpoint, response.StatusCode)
+	}
+
+	var results searchResponse
+	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("hypothesis: unable to decode response: %v", err)
+	}
+
+	annotations := make([]*Annotation, 0, len(results.Rows))
+	for _, row := range results.Rows {
+		annotation := &Annotation{
+			ID:      row.ID,
+			User:    strings.TrimPrefix(strings.TrimSuffix(row.User, "@hypothes.is"), "acct:"),
+			Text:    row.Text,
+			Created: row.Created,
+			Link:    row.Links.InContext,
+		}
+
+		for _, target := range row.Target {
+			for _, selector := range target.Selector {
+				if selector.Type == "TextQuoteSelector" {
+					annotation.Quote = selector.Exact
+				}
+			}
+		}
+
+		annotations = append(annotations, annotation)
+	}
+
+	return annotations, nil
+}
+
+func (c *Client) do(request *http.Request) (*http.Response, error) {
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/vnd.hypothesis.v1+json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+
+	// Public annotations can be retrieved anonymously.
+	if c.apiToken != "" {
+		request.Header.Set("Authorization", "Bearer "+c.apiToken)
+	}
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("hypothesis: unable to send request: %v", err)
+	}
+
+	return response, nil
+}
+
+// See https://h.readthedocs.io/en/latest/api-reference/v1/#tag/annotations/paths/~1annotations/post
+type annotationRequest struct {
+	URI    string             `json:"uri"`
+	Text   string             `json:"text"`
+	Group  string             `json:"group"`
+	Tags   []string           `json:"tags"`
+	Target []annotationTarget `json:"target"`
+}
+
+type annotationTarget struct {
+	Source   string               `json:"source"`
+	Selector []annotationSelector `json:"selector,omitempty"`
+}
+
+type annotationSelector struct {
+	Type  string `json:"type"`
+	Exact string `json:"exact,omitempty"`
+}
+
+type searchResponse struct {
+	Rows []struct {
+		ID      string             `json:"id"`
+		User    string             `json:"user"`
+		Text    string             `json:"text"`
+		Created time.Time          `json:"created"`
+		Target  []annotationTarget `json:"target"`
+		Links   struct {
+			InContext string `json:"incontext"`
+		} `json:"links"`
+	} `json:"rows"`
+}
@@ -4,13 +4,18 @@
 package integration // import "miniflux.app/v2/internal/integration"
 
 import (
+	"errors"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/integration/apprise"
 	"miniflux.app/v2/internal/integration/betula"
 	"miniflux.app/v2/internal/integration/espial"
+	"miniflux.app/v2/internal/integration/homeassistant"
 	"miniflux.app/v2/internal/integration/instapaper"
+	"miniflux.app/v2/internal/integration/kindle"
 	"miniflux.app/v2/internal/integration/linkace"
 	"miniflux.app/v2/internal/integration/linkding"
 	"miniflux.app/v2/internal/integration/linkwarden"
@@ -29,12 +34,45 @@ import (
 	"miniflux.app/v2/internal/integration/telegrambot"
 	"miniflux.app/v2/internal/integration/wallabag"
 	"miniflux.app/v2/internal/integration/webhook"
+	"miniflux.app/v2/internal/integration/zotero"
+	"miniflux.app/v2/internal/mail"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/timezone"
 )
 
+// SendEntriesToKindle converts the entries to a single EPUB document and emails it to the Kindle address.
+func SendEntriesToKindle(store *storage.Storage, entries model.Entries, userIntegrations *model.Integration) {
+	d := newDispatch(store, userIntegrations.UserID, "")
+	if d.accepts("kindle", nil, entries) {
+		d.sendEntriesToKindle(entries, userIntegrations)
+	}
+	d.queueFailures(store, model.IntegrationDeliveryEventSaveEntry, 0)
+}
+
+func (d *dispatch) sendEntriesToKindle(entries model.Entries, userIntegrations *model.Integration) {
+	client := kindle.NewClient(mail.NewDefaultSender(), userIntegrations.KindleEmail)
+	if err := client.SendEntries(entries); err != nil {
+		slog.Error("Unable to send entries to Kindle",
+			slog.Int64("user_id", userIntegrations.UserID),
+			slog.Int("nb_entries", len(entries)),
+			slog.Any("error", err),
+		)
+		d.fail("kindle", entries, err)
+	}
+}
+
 // SendEntry sends the entry to third-party providers when the user click on "Save".
-func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
-	if userIntegrations.BetulaEnabled {
+//
+// Failed deliveries are queued to be retried later.
+func SendEntry(store *storage.Storage, entry *model.Entry, userIntegrations *model.Integration) {
+	d := newDispatch(store, userIntegrations.UserID, "")
+	d.sendEntry(entry, userIntegrations)
+	d.queueFailures(store, model.IntegrationDeliveryEventSaveEntry, 0)
+}
+
+func (d *dispatch) sendEntry(entry *model.Entry, userIntegrations *model.Integration) {
+	if userIntegrations.BetulaEnabled && d.accepts("betula", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Betula",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -55,21 +93,66 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("betula", model.Entries{entry}, err)
+		}
+	}
+
+	if userIntegrations.KindleEnabled && d.accepts("kindle", entry.Feed, model.Entries{entry}) {
+		slog.Debug("Sending entry to Kindle",
+			slog.Int64("user_id", userIntegrations.UserID),
+			slog.Int64("entry_id", entry.ID),
+			slog.String("entry_url", entry.URL),
+		)
+
+		d.sendEntriesToKindle(model.Entries{entry}, userIntegrations)
+	}
+
+	if userIntegrations.ZoteroEnabled && d.accepts("zotero", entry.Feed, model.Entries{entry}) {
+		slog.Debug("Sending entry to Zotero",
+			slog.Int64("user_id", userIntegrations.UserID),
+			slog.Int64("entry_id", entry.ID),
+			slog.String("entry_url", entry.URL),
+		)
+
+		client := zotero.NewClient(userIntegrations.ZoteroUserID, userIntegrations.ZoteroAPIKey, userIntegrations.ZoteroCollectionKey)
+		if err := client.CreateItem(entry); err != nil {
+			slog.Error("Unable to send entry to Zotero",
+				slog.Int64("user_id", userIntegrations.UserID),
+				slog.Int64("entry_id", entry.ID),
+				slog.String("entry_url", entry.URL),
+				slog.Any("error", err),
+			)
+			d.fail("zotero", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.PinboardEnabled {
+	if userIntegrations.PinboardEnabled && d.accepts("pinboard", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Pinboard",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
 			slog.String("entry_url", entry.URL),
 		)
 
+		tags := strings.Fields(userIntegrations.PinboardTags)
+		if userIntegrations.PinboardEntryTags {
+			for _, tag := range entry.Tags {
+				if tag = pinboard.NormalizeTag(tag); tag != "" {
+					tags = append(tags, tag)
+				}
+			}
+		}
+
+		if userIntegrations.PinboardCategoryTag && entry.Feed != nil && entry.Feed.Category != nil {
+			if tag := pinboard.NormalizeTag(entry.Feed.Category.Title); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+
 		client := pinboard.NewClient(userIntegrations.PinboardToken)
 		err := client.CreateBookmark(
 			entry.URL,
 			entry.Title,
-			userIntegrations.PinboardTags,
+			tags,
 			userIntegrations.PinboardMarkAsUnread,
 		)
 
@@ -80,10 +163,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("pinboard", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.InstapaperEnabled {
+	if userIntegrations.InstapaperEnabled && d.accepts("instapaper", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Instapaper",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -98,10 +182,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("instapaper", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.WallabagEnabled {
+	if userIntegrations.WallabagEnabled && d.accepts("wallabag", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Wallabag",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -124,10 +209,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("wallabag", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.NotionEnabled {
+	if userIntegrations.NotionEnabled && d.accepts("notion", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Notion",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -145,10 +231,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("notion", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.NunuxKeeperEnabled {
+	if userIntegrations.NunuxKeeperEnabled && d.accepts("nunux_keeper", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to NunuxKeeper",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -167,10 +254,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("nunux_keeper", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.EspialEnabled {
+	if userIntegrations.EspialEnabled && d.accepts("espial", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Espial",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -189,10 +277,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("espial", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.PocketEnabled {
+	if userIntegrations.PocketEnabled && d.accepts("pocket", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Pocket",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -207,10 +296,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("pocket", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.LinkAceEnabled {
+	if userIntegrations.LinkAceEnabled && d.accepts("linkace", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to LinkAce",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -231,10 +321,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("linkace", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.LinkdingEnabled {
+	if userIntegrations.LinkdingEnabled && d.accepts("linkding", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Linkding",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -254,10 +345,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("linkding", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.LinkwardenEnabled {
+	if userIntegrations.LinkwardenEnabled && d.accepts("linkwarden", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to linkwarden",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -275,10 +367,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("linkwarden", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.ReadeckEnabled {
+	if userIntegrations.ReadeckEnabled && d.accepts("readeck", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Readeck",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -298,10 +391,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("readeck", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.ReadwiseEnabled {
+	if userIntegrations.ReadwiseEnabled && d.accepts("readwise", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Readwise",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -319,10 +413,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("readwise", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.ShioriEnabled {
+	if userIntegrations.ShioriEnabled && d.accepts("shiori", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Shiori",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -342,10 +437,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("shiori", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.ShaarliEnabled {
+	if userIntegrations.ShaarliEnabled && d.accepts("shaarli", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Shaarli",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -364,10 +460,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("shaarli", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.WebhookEnabled {
+	if userIntegrations.WebhookEnabled && d.accepts("webhook", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Webhook",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -375,7 +472,7 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 			slog.String("webhook_url", userIntegrations.WebhookURL),
 		)
 
-		webhookClient := webhook.NewClient(userIntegrations.WebhookURL, userIntegrations.WebhookSecret)
+		webhookClient := webhook.NewClient(userIntegrations.WebhookURL, userIntegrations.WebhookSecret, userIntegrations.WebhookPayloadTemplate)
 		if err := webhookClient.SendSaveEntryWebhookEvent(entry); err != nil {
 			slog.Error("Unable to send entry to Webhook",
 				slog.Int64("user_id", userIntegrations.UserID),
@@ -384,10 +481,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("webhook_url", userIntegrations.WebhookURL),
 				slog.Any("error", err),
 			)
+			d.fail("webhook", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.OmnivoreEnabled {
+	if userIntegrations.OmnivoreEnabled && d.accepts("omnivore", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Omnivore",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -402,10 +500,11 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("omnivore", model.Entries{entry}, err)
 		}
 	}
 
-	if userIntegrations.RaindropEnabled {
+	if userIntegrations.RaindropEnabled && d.accepts("raindrop", entry.Feed, model.Entries{entry}) {
 		slog.Debug("Sending entry to Raindrop",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
@@ -420,13 +519,22 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
+			d.fail("raindrop", model.Entries{entry}, err)
 		}
 	}
 }
 
 // PushEntries pushes a list of entries to activated third-party providers during feed refreshes.
-func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *model.Integration) {
-	if userIntegrations.MatrixBotEnabled {
+//
+// Failed deliveries are queued to be retried later.
+func PushEntries(store *storage.Storage, feed *model.Feed, entries model.Entries, userIntegrations *model.Integration, user *model.User) {
+	d := newDispatch(store, userIntegrations.UserID, "")
+	d.pushEntries(feed, entries, userIntegrations, user)
+	d.queueFailures(store, model.IntegrationDeliveryEventNewEntries, feed.ID)
+}
+
+func (d *dispatch) pushEntries(feed *model.Feed, entries model.Entries, userIntegrations *model.Integration, user *model.User) {
+	if userIntegrations.MatrixBotEnabled && feed.MatrixBotEnabled && d.accepts("matrix", feed, entries) {
 		slog.Debug("Sending new entries to Matrix",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int("nb_entries", len(entries)),
@@ -439,6 +547,7 @@ func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *mode
 			userIntegrations.MatrixBotURL,
 			userIntegrations.MatrixBotUser,
 			userIntegrations.MatrixBotPassword,
+			userIntegrations.MatrixBotAccessToken,
 			userIntegrations.MatrixBotChatID,
 		)
 		if err != nil {
@@ -448,10 +557,11 @@ func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *mode
 				slog.Int64("feed_id", feed.ID),
 				slog.Any("error", err),
 			)
+			d.fail("matrix", entries, err)
 		}
 	}
 
-	if userIntegrations.WebhookEnabled {
+	if userIntegrations.WebhookEnabled && d.accepts("webhook", feed, entries) {
 		slog.Debug("Sending new entries to Webhook",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int("nb_entries", len(entries)),
@@ -459,7 +569,7 @@ func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *mode
 			slog.String("webhook_url", userIntegrations.WebhookURL),
 		)
 
-		webhookClient := webhook.NewClient(userIntegrations.WebhookURL, userIntegrations.WebhookSecret)
+		webhookClient := webhook.NewClient(userIntegrations.WebhookURL, userIntegrations.WebhookSecret, userIntegrations.WebhookPayloadTemplate)
 		if err := webhookClient.SendNewEntriesWebhookEvent(feed, entries); err != nil {
 			slog.Debug("Unable to send new entries to Webhook",
 				slog.Int64("user_id", userIntegrations.UserID),
@@ -468,10 +578,46 @@ func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *mode
 				slog.String("webhook_url", userIntegrations.WebhookURL),
 				slog.Any("error", err),
 			)
+			d.fail("webhook", entries, err)
+		}
+	}
+
+	if userIntegrations.HomeAssistantEnabled && d.accepts("homeassistant", feed, entries) {
+		rules, err := homeassistant.ParseRules(userIntegrations.HomeAssistantRules)
+		if err != nil {
+			slog.Warn("Unable to parse the Home Assistant rules",
+				slog.Int64("user_id", userIntegrations.UserID),
+				slog.Any("error", err),
+			)
+		}
+
+		client := homeassistant.NewClient(userIntegrations.HomeAssistantWebhookURL, userIntegrations.HomeAssistantPayloadTemplate)
+		for _, entry := range entries {
+			rule := rules.Match(feed, entry)
+			if rule == "" {
+				continue
+			}
+
+			slog.Debug("Sending event to Home Assistant",
+				slog.Int64("user_id", userIntegrations.UserID),
+				slog.Int64("entry_id", entry.ID),
+				slog.String("entry_url", entry.URL),
+				slog.String("rule", rule),
+			)
+
+			if err := client.SendEvent(feed, entry, rule); err != nil {
+				slog.Error("Unable to send event to Home Assistant",
+					slog.Int64("user_id", userIntegrations.UserID),
+					slog.Int64("entry_id", entry.ID),
+					slog.String("entry_url", entry.URL),
+					slog.Any("error", err),
+				)
+				d.fail("homeassistant", model.Entries{entry}, err)
+			}
 		}
 	}
 
-	if userIntegrations.NtfyEnabled && feed.NtfyEnabled {
+	if userIntegrations.NtfyEnabled && feed.NtfyEnabled && d.accepts("ntfy", feed, entries) {
 		slog.Debug("Sending new entries to Ntfy",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int("nb_entries", len(entries)),
@@ -490,13 +636,85 @@ func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *mode
 
 		if err := client.SendMessages(feed, entries); err != nil {
 			slog.Warn("Unable to send new entries to Ntfy", slog.Any("error", err))
+			d.fail("ntfy", entries, err)
 		}
 	}
 
-	// Integrations that only support sending individual entries
-	if userIntegrations.TelegramBotEnabled || userIntegrations.AppriseEnabled {
-		for _, entry := range entries {
-			if userIntegrations.TelegramBotEnabled {
+	if userIntegrations.AppriseEnabled && d.accepts("apprise", feed, entries) {
+		slog.Debug("Sending new entries to Apprise",
+			slog.Int64("user_id", userIntegrations.UserID),
+			slog.Int("nb_entries", len(entries)),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("apprise_url", userIntegrations.AppriseURL),
+		)
+
+		appriseServiceURLs := userIntegrations.AppriseServicesURL
+		if feed.AppriseServiceURLs != "" {
+			appriseServiceURLs = feed.AppriseServiceURLs
+		}
+
+		client := apprise.NewClient(
+			appriseServiceURLs,
+			userIntegrations.AppriseURL,
+		)
+
+		if err := client.SendNotification(feed, entries); err != nil {
+			slog.Error("Unable to send new entries to Apprise",
+				slog.Int64("user_id", userIntegrations.UserID),
+				slog.Int("nb_entries", len(entries)),
+				slog.Int64("feed_id", feed.ID),
+				slog.String("apprise_url", userIntegrations.AppriseURL),
+				slog.Any("error", err),
+			)
+			d.fail("apprise", entries, err)
+		}
+	}
+
+	if userIntegrations.TelegramBotEnabled && userIntegrations.TelegramBotDigestMode != model.TelegramBotDigestModeHourly && d.accepts("telegram", feed, entries) {
+		chatID := telegrambot.ChatIDForCategory(
+			userIntegrations.TelegramBotChatID,
+			userIntegrations.TelegramBotCategoryChatIDs,
+			feed.Category.Title,
+		)
+
+		disableNotification := userIntegrations.TelegramBotDisableNotification || telegrambot.IsQuietHour(
+			timezone.Now(user.Timezone),
+			userIntegrations.TelegramBotQuietHoursStart,
+			userIntegrations.TelegramBotQuietHoursEnd,
+		)
+
+		if userIntegrations.TelegramBotDigestMode == model.TelegramBotDigestModeBatch {
+			slog.Debug("Sending a digest of new entries to Telegram",
+				slog.Int64("user_id", userIntegrations.UserID),
+				slog.Int("nb_entries", len(entries)),
+				slog.Int64("feed_id", feed.ID),
+			)
+
+			for _, entry := range entries {
+				if entry.Feed == nil {
+					entry.Feed = feed
+				}
+			}
+
+			if err := telegrambot.PushDigest(
+				entries,
+				userIntegrations.TelegramBotToken,
+				chatID,
+				userIntegrations.TelegramBotTopicID,
+				userIntegrations.TelegramBotDigestSize,
+				userIntegrations.TelegramBotDisableWebPagePreview,
+				disableNotification,
+			); err != nil {
+				slog.Error("Unable to send digest to Telegram",
+					slog.Int64("user_id", userIntegrations.UserID),
+					slog.Int("nb_entries", len(entries)),
+					slog.Int64("feed_id", feed.ID),
+					slog.Any("error", err),
+				)
+				d.fail("telegram", entries, err)
+			}
+		} else {
+			for _, entry := range entries {
 				slog.Debug("Sending a new entry to Telegram",
 					slog.Int64("user_id", userIntegrations.UserID),
 					slog.Int64("entry_id", entry.ID),
@@ -507,10 +725,10 @@ func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *mode
 					feed,
 					entry,
 					userIntegrations.TelegramBotToken,
-					userIntegrations.TelegramBotChatID,
+					chatID,
 					userIntegrations.TelegramBotTopicID,
 					userIntegrations.TelegramBotDisableWebPagePreview,
-					userIntegrations.TelegramBotDisableNotification,
+					disableNotification,
 					userIntegrations.TelegramBotDisableButtons,
 				); err != nil {
 					slog.Error("Unable to send entry to Telegram",
@@ -519,37 +737,70 @@ func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *mode
 						slog.String("entry_url", entry.URL),
 						slog.Any("error", err),
 					)
+					d.fail("telegram", model.Entries{entry}, err)
 				}
 			}
+		}
+	}
+}
 
-			if userIntegrations.AppriseEnabled {
-				slog.Debug("Sending a new entry to Apprise",
-					slog.Int64("user_id", userIntegrations.UserID),
-					slog.Int64("entry_id", entry.ID),
-					slog.String("entry_url", entry.URL),
-					slog.String("apprise_url", userIntegrations.AppriseURL),
-				)
+// PushTelegramDigest sends the entries accumulated since the last hourly digest to Telegram.
+// An error is returned when the digest could not be sent to one of the chats.
+func PushTelegramDigest(store *storage.Storage, entries model.Entries, userIntegrations *model.Integration, user *model.User) error {
+	d := newDispatch(store, userIntegrations.UserID, "")
+	entries = slices.DeleteFunc(entries, func(entry *model.Entry) bool {
+		return !d.routingRules.Allows("telegram", entry.Feed)
+	})
 
-				appriseServiceURLs := userIntegrations.AppriseServicesURL
-				if feed.AppriseServiceURLs != "" {
-					appriseServiceURLs = feed.AppriseServiceURLs
-				}
+	if len(entries) == 0 {
+		return nil
+	}
 
-				client := apprise.NewClient(
-					appriseServiceURLs,
-					userIntegrations.AppriseURL,
-				)
+	slog.Debug("Sending hourly digest to Telegram",
+		slog.Int64("user_id", userIntegrations.UserID),
+		slog.Int("nb_entries", len(entries)),
+	)
+
+	disableNotification := userIntegrations.TelegramBotDisableNotification || telegrambot.IsQuietHour(
+		timezone.Now(user.Timezone),
+		userIntegrations.TelegramBotQuietHoursStart,
+		userIntegrations.TelegramBotQuietHoursEnd,
+	)
+
+	// Entries are routed to the chat of their category while preserving their order.
+	var chatIDs []string
+	entriesByChatID := make(map[string]model.Entries)
+	for _, entry := range entries {
+		chatID := userIntegrations.TelegramBotChatID
+		if entry.Feed != nil && entry.Feed.Category != nil {
+			chatID = telegrambot.ChatIDForCategory(chatID, userIntegrations.TelegramBotCategoryChatIDs, entry.Feed.Category.Title)
+		}
 
-				if err := client.SendNotification(entry); err != nil {
-					slog.Error("Unable to send entry to Apprise",
-						slog.Int64("user_id", userIntegrations.UserID),
-						slog.Int64("entry_id", entry.ID),
-						slog.String("entry_url", entry.URL),
-						slog.String("apprise_url", userIntegrations.AppriseURL),
-						slog.Any("error", err),
-					)
-				}
-			}
+		if _, found := entriesByChatID[chatID]; !found {
+			chatIDs = append(chatIDs, chatID)
 		}
+		entriesByChatID[chatID] = append(entriesByChatID[chatID], entry)
 	}
+
+	var errs []error
+	for _, chatID := range chatIDs {
+		if err := telegrambot.PushDigest(
+			entriesByChatID[chatID],
+			userIntegrations.TelegramBotToken,
+			chatID,
+			userIntegrations.TelegramBotTopicID,
+			userIntegrations.TelegramBotDigestSize,
+			userIntegrations.TelegramBotDisableWebPagePreview,
+			disableNotification,
+		); err != nil {
+			slog.Error("Unable to send hourly digest to Telegram",
+				slog.Int64("user_id", userIntegrations.UserID),
+				slog.Int("nb_entries", len(entriesByChatID[chatID])),
+				slog.Any("error", err),
+			)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
 }
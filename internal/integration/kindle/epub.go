@@ -0,0 +1,349 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package kindle // import "miniflux.app/v2/internal/integration/kindle"
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ImageFetcher downloads an image and returns its content and media type.
+type ImageFetcher func(imageURL string) ([]byte, string, error)
+
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+var removedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Iframe:   true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Form:     true,
+	atom.Input:    true,
+	atom.Button:   true,
+	atom.Video:    true,
+	atom.Audio:    true,
+	atom.Source:   true,
+	atom.Noscript: true,
+}
+
+type epubImage struct {
+	id        string
+	path      string
+	mediaType string
+	data      []byte
+}
+
+type epubChapter struct {
+	id      string
+	path    string
+	title   string
+	content string
+}
+
+type epubFile struct {
+	name string
+	data []byte
+}
+
+type epubBuilder struct {
+	fetchImage  ImageFetcher
+	imageBudget int
+	images      []*epubImage
+	imageByURL  map[string]*epubImage
+	chapters    []*epubChapter
+}
+
+// BuildEPUB converts the entries into an EPUB document.
+//
+// Images are embedded as long as their total size stays below imageBudget bytes,
+// the remaining ones are replaced by their alternative text.
+func BuildEPUB(title string, entries model.Entries, fetchImage ImageFetcher, imageBudget int) ([]byte, error) {
+	builder := &epubBuilder{
+		fetchImage:  fetchImage,
+		imageBudget: imageBudget,
+		imageByURL:  make(map[string]*epubImage),
+	}
+
+	for index, entry := range entries {
+		content, err := builder.convertContent(entry.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		builder.chapters = append(builder.chapters, &epubChapter{
+			id:      fmt.Sprintf("chapter-%d", index+1),
+			path:    fmt.Sprintf("chapter-%d.xhtml", index+1),
+			title:   entry.Title,
+			content: chapterDocument(entry, content),
+		})
+	}
+
+	return builder.write(title)
+}
+
+func (b *epubBuilder) convertContent(content string) (string, error) {
+	context := &nethtml.Node{Type: nethtml.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := nethtml.ParseFragment(strings.NewReader(content), context)
+	if err != nil {
+		return "", fmt.Errorf("kindle: unable to parse entry content: %v", err)
+	}
+
+	container := &nethtml.Node{Type: nethtml.ElementNode, Data: "div", DataAtom: atom.Div}
+	for _, node := range nodes {
+		container.AppendChild(node)
+	}
+	b.cleanNode(container)
+
+	var buffer bytes.Buffer
+	for node := container.FirstChild; node != nil; node = node.NextSibling {
+		if err := nethtml.Render(&buffer, node); err != nil {
+			return "", fmt.Errorf("kindle: unable to render entry content: %v", err)
+		}
+	}
+
+	return buffer.String(), nil
+}
+
+func (b *epubBuilder) cleanNode(node *nethtml.Node) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+
+		if child.Type == nethtml.ElementNode && removedElements[child.DataAtom] {
+			node.RemoveChild(child)
+		} else if child.Type == nethtml.ElementNode && child.DataAtom == atom.Img {
+			b.replaceImage(node, child)
+		} else {
+			b.cleanNode(child)
+		}
+
+		child = next
+	}
+
+	var attrs []nethtml.Attribute
+	for _, attr := range node.Attr {
+		switch attr.Key {
+		case "srcset", "sizes", "loading", "decoding", "style", "class", "id":
+		default:
+			if attr.Namespace == "" && !strings.HasPrefix(attr.Key, "on") {
+				attrs = append(attrs, attr)
+			}
+		}
+	}
+	node.Attr = attrs
+}
+
+func (b *epubBuilder) replaceImage(parent, node *nethtml.Node) {
+	var src, alt string
+	for _, attr := range node.Attr {
+		switch attr.Key {
+		case "src":
+			src = attr.Val
+		case "alt":
+			alt = attr.Val
+		}
+	}
+
+	image := b.embedImage(src)
+	if image == nil {
+		if alt != "" {
+			parent.InsertBefore(&nethtml.Node{Type: nethtml.TextNode, Data: alt}, node)
+		}
+		parent.RemoveChild(node)
+		return
+	}
+
+	node.Attr = []nethtml.Attribute{
+		{Key: "src", Val: image.path},
+		{Key: "alt", Val: alt},
+	}
+}
+
+func (b *epubBuilder) embedImage(src string) *epubImage {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		return nil
+	}
+
+	if image, found := b.imageByURL[src]; found {
+		return image
+	}
+
+	data, mediaType, err := b.fetchImage(src)
+	if err != nil {
+		return nil
+	}
+
+	extension, supported := imageExtensions[mediaType]
+	if !supported || len(data) > b.imageBudget {
+		return nil
+	}
+	b.imageBudget -= len(data)
+
+	index := len(b.images) + 1
+	image := &epubImage{
+		id:        fmt.Sprintf("image-%d", index),
+		path:      fmt.Sprintf("images/image-%d.%s", index, extension),
+		mediaType: mediaType,
+		data:      data,
+	}
+	b.images = append(b.images, image)
+	b.imageByURL[src] = image
+	return image
+}
+
+func (b *epubBuilder) write(title string) ([]byte, error) {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	// The mimetype file must be the first entry of the archive and must not be compressed.
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, fmt.Errorf("kindle: unable to create EPUB: %v", err)
+	}
+	if _, err := mimetype.Write([]byte("application/epub+zip")); err != nil {
+		return nil, fmt.Errorf("kindle: unable to create EPUB: %v", err)
+	}
+
+	files := []*epubFile{
+		{"META-INF/container.xml", []byte(containerXML)},
+		{"OEBPS/content.opf", []byte(b.packageDocument(title))},
+		{"OEBPS/nav.xhtml", []byte(b.navigationDocument(title))},
+	}
+
+	for _, chapter := range b.chapters {
+		files = append(files, &epubFile{"OEBPS/" + chapter.path, []byte(chapter.content)})
+	}
+
+	for _, image := range b.images {
+		files = append(files, &epubFile{"OEBPS/" + image.path, image.data})
+	}
+
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return nil, fmt.Errorf("kindle: unable to create EPUB: %v", err)
+		}
+		if _, err := writer.Write(file.data); err != nil {
+			return nil, fmt.Errorf("kindle: unable to create EPUB: %v", err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("kindle: unable to create EPUB: %v", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+func (b *epubBuilder) packageDocument(title string) string {
+	var manifest, spine strings.Builder
+
+	manifest.WriteString(`    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>` + "\n")
+	for _, chapter := range b.chapters {
+		fmt.Fprintf(&manifest, `    <item id="%s" href="%s" media-type="application/xhtml+xml"/>`+"\n", chapter.id, chapter.path)
+		fmt.Fprintf(&spine, `    <itemref idref="%s"/>`+"\n", chapter.id)
+	}
+	for _, image := range b.images {
+		fmt.Fprintf(&manifest, `    <item id="%s" href="%s" media-type="%s"/>`+"\n", image.id, image.path, image.mediaType)
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">urn:miniflux:%s</dc:identifier>
+    <dc:title>%s</dc:title>
+    <dc:language>und</dc:language>
+    <dc:creator>Miniflux</dc:creator>
+    <meta property="dcterms:modified">%s</meta>
+  </metadata>
+  <manifest>
+%s  </manifest>
+  <spine>
+%s  </spine>
+</package>
+`,
+		crypto.GenerateRandomStringHex(16),
+		html.EscapeString(title),
+		time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		manifest.String(),
+		spine.String(),
+	)
+}
+
+func (b *epubBuilder) navigationDocument(title string) string {
+	var items strings.Builder
+	for _, chapter := range b.chapters {
+		fmt.Fprintf(&items, `      <li><a href="%s">%s</a></li>`+"\n", chapter.path, html.EscapeString(chapter.title))
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>%s</title></head>
+<body>
+  <nav epub:type="toc">
+    <h1>%s</h1>
+    <ol>
+%s    </ol>
+  </nav>
+</body>
+</html>
+`, html.EscapeString(title), html.EscapeString(title), items.String())
+}
+
+func chapterDocument(entry *model.Entry, content string) string {
+	var byline []string
+	if entry.Author != "" {
+		byline = append(byline, html.EscapeString(entry.Author))
+	}
+	if entry.Feed != nil && entry.Feed.Title != "" {
+		byline = append(byline, html.EscapeString(entry.Feed.Title))
+	}
+	if !entry.Date.IsZero() {
+		byline = append(byline, entry.Date.Format("2006-01-02"))
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head><title>%s</title></head>
+<body>
+<h1>%s</h1>
+<p><small>%s</small></p>
+%s
+<p><a href="%s">%s</a></p>
+</body>
+</html>
+`,
+		html.EscapeString(entry.Title),
+		html.EscapeString(entry.Title),
+		strings.Join(byline, " · "),
+		content,
+		html.EscapeString(entry.URL),
+		html.EscapeString(entry.URL),
+	)
+}
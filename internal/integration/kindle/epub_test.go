@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package kindle // import "miniflux.app/v2/internal/integration/kindle"
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func readEPUB(t *testing.T, data []byte) (*zip.Reader, map[string]string) {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf(`The EPUB should be a valid ZIP archive: %v`, err)
+	}
+
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[file.Name] = string(content)
+	}
+
+	return archive, files
+}
+
+func TestBuildEPUBStartsWithUncompressedMimetype(t *testing.T) {
+	entries := model.Entries{{Title: "First", URL: "https://example.org/first", Content: "<p>Hello</p>"}}
+
+	data, err := BuildEPUB("Miniflux", entries, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archive, files := readEPUB(t, data)
+	if len(archive.File) == 0 || archive.File[0].Name != "mimetype" {
+		t.Fatal(`The mimetype file should be the first entry of the archive`)
+	}
+
+	if archive.File[0].Method != zip.Store {
+		t.Errorf(`The mimetype file should not be compressed, got method %d`, archive.File[0].Method)
+	}
+
+	if files["mimetype"] != "application/epub+zip" {
+		t.Errorf(`Unexpected mimetype, got %q`, files["mimetype"])
+	}
+
+	for _, name := range []string{"META-INF/container.xml", "OEBPS/content.opf", "OEBPS/nav.xhtml", "OEBPS/chapter-1.xhtml"} {
+		if _, found := files[name]; !found {
+			t.Errorf(`The file %s should be in the archive`, name)
+		}
+	}
+}
+
+func TestBuildEPUBEscapesTitlesAndContent(t *testing.T) {
+	entries := model.Entries{{
+		Title:   `Tom & Jerry <script>alert("title")</script>`,
+		URL:     `https://example.org/?a=1&b="2"`,
+		Author:  "<Author>",
+		Content: `<p onclick="alert(1)">Text &amp; more<br><script>alert("content")</script></p><img src="relative.png" alt="An image">`,
+	}}
+
+	data, err := BuildEPUB("Books & <News>", entries, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, files := readEPUB(t, data)
+
+	for _, name := range []string{"OEBPS/content.opf", "OEBPS/nav.xhtml", "OEBPS/chapter-1.xhtml"} {
+		decoder := xml.NewDecoder(strings.NewReader(files[name]))
+		decoder.Strict = true
+		for {
+			_, err := decoder.Token()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf(`The file %s should be well-formed XML: %v`, name, err)
+			}
+		}
+	}
+
+	if !strings.Contains(files["OEBPS/content.opf"], "<dc:title>Books &amp; &lt;News&gt;</dc:title>") {
+		t.Errorf(`The book title should be escaped, got %s`, files["OEBPS/content.opf"])
+	}
+
+	chapter := files["OEBPS/chapter-1.xhtml"]
+	for _, expected := range []string{"<h1>Tom &amp; Jerry &lt;script&gt;", "&lt;Author&gt;", "Text &amp; more", "An image"} {
+		if !strings.Contains(chapter, expected) {
+			t.Errorf(`The chapter should contain %q, got %s`, expected, chapter)
+		}
+	}
+
+	for _, unexpected := range []string{`alert("content")`, "onclick", "<img"} {
+		if strings.Contains(chapter, unexpected) {
+			t.Errorf(`The chapter should not contain %q, got %s`, unexpected, chapter)
+		}
+	}
+}
+
+func TestBuildEPUBEmbedsImagesWithinBudget(t *testing.T) {
+	fetchImage := func(imageURL string) ([]byte, string, error) {
+		switch imageURL {
+		case "https://example.org/small.png":
+			return []byte("small"), "image/png", nil
+		case "https://example.org/large.jpg":
+			return bytes.Repeat([]byte("x"), 100), "image/jpeg", nil
+		}
+		return nil, "", errors.New("not found")
+	}
+
+	entries := model.Entries{{
+		Title:   "Images",
+		Content: `<img src="https://example.org/small.png" alt="Small"><img src="https://example.org/large.jpg" alt="Large"><img src="https://example.org/small.png" alt="Again">`,
+	}}
+
+	data, err := BuildEPUB("Images", entries, fetchImage, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, files := readEPUB(t, data)
+
+	if files["OEBPS/images/image-1.png"] != "small" {
+		t.Error(`The image within the budget should be embedded once`)
+	}
+
+	if _, found := files["OEBPS/images/image-2.jpg"]; found {
+		t.Error(`The image over the budget should not be embedded`)
+	}
+
+	chapter := files["OEBPS/chapter-1.xhtml"]
+	if strings.Count(chapter, `src="images/image-1.png"`) != 2 || !strings.Contains(chapter, "Large") {
+		t.Errorf(`Unexpected images in the chapter, got %s`, chapter)
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package kindle // import "miniflux.app/v2/internal/integration/kindle"
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/mail"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+)
+
+const (
+	// Amazon rejects emails larger than 50 MB, attachments grow by a third once encoded in base64.
+	maxDocumentSize = 35 * 1024 * 1024
+
+	// Space reserved in the document for the text content and the EPUB structure.
+	reservedDocumentSize = 2 * 1024 * 1024
+
+	maxImageSize = 5 * 1024 * 1024
+)
+
+var filenameCleaner = regexp.MustCompile(`[^\pL\pN]+`)
+
+type Client struct {
+	sender      *mail.Sender
+	kindleEmail string
+}
+
+func NewClient(sender *mail.Sender, kindleEmail string) *Client {
+	return &Client{sender: sender, kindleEmail: kindleEmail}
+}
+
+// SendEntries converts the entries to an EPUB document and emails it to the Kindle address.
+func (c *Client) SendEntries(entries model.Entries) error {
+	if c.kindleEmail == "" {
+		return fmt.Errorf("kindle: missing Kindle email address")
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	title := entries[0].Title
+	if len(entries) > 1 {
+		title = "Miniflux " + time.Now().Format("2006-01-02")
+	}
+
+	document, err := BuildEPUB(title, entries, fetchImage, maxDocumentSize-reservedDocumentSize)
+	if err != nil {
+		return err
+	}
+
+	if len(document) > maxDocumentSize {
+		return fmt.Errorf("kindle: document is too large (%d bytes)", len(document))
+	}
+
+	message := &mail.Message{
+		To:       c.kindleEmail,
+		Subject:  title,
+		TextBody: title,
+		Attachments: []*mail.Attachment{
+			{
+				Filename:    documentFilename(title),
+				ContentType: "application/epub+zip",
+				Data:        document,
+			},
+		},
+	}
+
+	if err := c.sender.Send(message); err != nil {
+		return fmt.Errorf("kindle: unable to send document: %v", err)
+	}
+
+	return nil
+}
+
+func fetchImage(imageURL string) ([]byte, string, error) {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
+	requestBuilder.UseProxy(config.Opts.HasHTTPClientProxyConfigured())
+	requestBuilder.WithUserAgent(config.Opts.HTTPClientUserAgent(), config.Opts.HTTPClientUserAgent())
+
+	response, err := requestBuilder.ExecuteRequest(imageURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("kindle: unable to fetch image: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("kindle: unable to fetch image: status code %d", response.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxImageSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("kindle: unable to read image: %v", err)
+	}
+
+	if len(data) > maxImageSize {
+		return nil, "", fmt.Errorf("kindle: image is too large")
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	return data, mediaType, nil
+}
+
+func documentFilename(title string) string {
+	filename := strings.Trim(filenameCleaner.ReplaceAllString(title, "-"), "-")
+	if runes := []rune(filename); len(runes) > 80 {
+		filename = strings.TrimRight(string(runes[:80]), "-")
+	}
+	if filename == "" {
+		filename = "miniflux"
+	}
+	return filename + ".epub"
+}
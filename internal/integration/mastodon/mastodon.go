@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mastodon // import "miniflux.app/v2/internal/integration/mastodon"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/urllib"
+	"miniflux.app/v2/internal/version"
+)
+
+const defaultClientTimeout = 10 * time.Second
+
+// Post visibilities supported by Mastodon.
+const (
+	VisibilityPublic   = "public"
+	VisibilityUnlisted = "unlisted"
+	VisibilityPrivate  = "private"
+	VisibilityDirect   = "direct"
+)
+
+// IsValidVisibility returns true if the visibility is supported by Mastodon.
+func IsValidVisibility(visibility string) bool {
+	switch visibility {
+	case VisibilityPublic, VisibilityUnlisted, VisibilityPrivate, VisibilityDirect:
+		return true
+	}
+	return false
+}
+
+type Client struct {
+	instanceURL string
+	accessToken string
+}
+
+func NewClient(instanceURL, accessToken string) *Client {
+	return &Client{instanceURL: instanceURL, accessToken: accessToken}
+}
+
+// PostStatus publishes a new post on the Mastodon account.
+func (c *Client) PostStatus(status, visibility string) error {
+	if c.instanceURL == "" || c.accessToken == "" {
+		return fmt.Errorf("mastodon: missing instance URL or access token")
+	}
+
+	if !IsValidVisibility(visibility) {
+		visibility = VisibilityPublic
+	}
+
+	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.instanceURL, "/api/v1/statuses")
+	if err != nil {
+		return fmt.Errorf(`mastodon: invalid API endpoint: %v`, err)
+	}
+
+	requestBody, err := json.Marshal(&statusRequest{Status: status, Visibility: visibility})
+	if err != nil {
+		return fmt.Errorf("mastodon: unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, apiEndpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("mastodon: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("Authorization", "Bearer "+c.accessToken)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("mastodon: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("mastodon: unable to publish post: url=%s status=%d", apiEndpoint, response.StatusCode)
+	}
+
+	return nil
+}
+
+// See https://docs.joinmastodon.org/methods/statuses/#create
+type statusRequest struct {
+	Status     string `json:"status"`
+	Visibility string `json:"visibility"`
+}
@@ -5,22 +5,32 @@ package matrixbot // import "miniflux.app/v2/internal/integration/matrixbot"
 
 import (
 	"fmt"
+	"html"
 	"strings"
 
 	"miniflux.app/v2/internal/model"
 )
 
 // PushEntries pushes entries to matrix chat using integration settings provided
-func PushEntries(feed *model.Feed, entries model.Entries, matrixBaseURL, matrixUsername, matrixPassword, matrixRoomID string) error {
+//
+// When an access token is provided, it is used directly instead of logging in with the username and password.
+func PushEntries(feed *model.Feed, entries model.Entries, matrixBaseURL, matrixUsername, matrixPassword, matrixAccessToken, matrixRoomID string) error {
 	client := NewClient(matrixBaseURL)
+
+	// Homeservers that don't publish a well-known document are reached directly.
+	homeServerURL := matrixBaseURL
 	discovery, err := client.DiscoverEndpoints()
-	if err != nil {
-		return err
+	if err == nil && discovery.HomeServerInformation.BaseURL != "" {
+		homeServerURL = discovery.HomeServerInformation.BaseURL
 	}
 
-	loginResponse, err := client.Login(discovery.HomeServerInformation.BaseURL, matrixUsername, matrixPassword)
-	if err != nil {
-		return err
+	accessToken := matrixAccessToken
+	if accessToken == "" {
+		loginResponse, err := client.Login(homeServerURL, matrixUsername, matrixPassword)
+		if err != nil {
+			return err
+		}
+		accessToken = loginResponse.AccessToken
 	}
 
 	var textMessages []string
@@ -28,12 +38,12 @@ func PushEntries(feed *model.Feed, entries model.Entries, matrixBaseURL, matrixU
 
 	for _, entry := range entries {
 		textMessages = append(textMessages, fmt.Sprintf(`[%s] %s - %s`, feed.Title, entry.Title, entry.URL))
-		formattedTextMessages = append(formattedTextMessages, fmt.Sprintf(`<li><strong>%s</strong>: <a href=%q>%s</a></li>`, feed.Title, entry.URL, entry.Title))
+		formattedTextMessages = append(formattedTextMessages, fmt.Sprintf(`<li><strong>%s</strong>: <a href=%q>%s</a></li>`, html.EscapeString(feed.Title), entry.URL, html.EscapeString(entry.Title)))
 	}
 
 	_, err = client.SendFormattedTextMessage(
-		discovery.HomeServerInformation.BaseURL,
-		loginResponse.AccessToken,
+		homeServerURL,
+		accessToken,
 		matrixRoomID,
 		strings.Join(textMessages, "\n"),
 		"<ul>"+strings.Join(formattedTextMessages, "\n")+"</ul>",
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package payloadtemplate // import "miniflux.app/v2/internal/integration/payloadtemplate"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"miniflux.app/v2/internal/reader/sanitizer"
+)
+
+var functions = template.FuncMap{
+	// json encodes a value, strings are quoted and escaped, e.g. {"title": {{ json .Entry.Title }}}.
+	"json": func(value any) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	"stripTags": sanitizer.StripTags,
+	"truncate": func(length int, text string) string {
+		if utf8.RuneCountInString(text) <= length {
+			return text
+		}
+		return string([]rune(text)[:length])
+	},
+	"join": strings.Join,
+}
+
+// Validate returns an error if the template cannot be parsed.
+func Validate(payloadTemplate string) error {
+	_, err := parse(payloadTemplate)
+	return err
+}
+
+// Render executes the template with the event and returns the request body.
+//
+// The event fields are the same as the default JSON payload of the integration.
+func Render(payloadTemplate string, event any) ([]byte, error) {
+	tmpl, err := parse(payloadTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, event); err != nil {
+		return nil, fmt.Errorf("payloadtemplate: unable to render payload: %v", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func parse(payloadTemplate string) (*template.Template, error) {
+	tmpl, err := template.New("payload").Funcs(functions).Option("missingkey=error").Parse(payloadTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("payloadtemplate: invalid template: %v", err)
+	}
+	return tmpl, nil
+}
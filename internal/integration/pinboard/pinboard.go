@@ -27,7 +27,7 @@ func NewClient(authToken string) *Client {
 	return &Client{authToken: authToken}
 }
 
-func (c *Client) CreateBookmark(entryURL, entryTitle, pinboardTags string, markAsUnread bool) error {
+func (c *Client) CreateBookmark(entryURL, entryTitle string, tags []string, markAsUnread bool) error {
 	if c.authToken == "" {
 		return errMissingCredentials
 	}
@@ -42,7 +42,7 @@ func (c *Client) CreateBookmark(entryURL, entryTitle, pinboardTags string, markA
 		return err
 	}
 
-	post.addTag(pinboardTags)
+	post.addTags(tags)
 	if markAsUnread {
 		post.SetToread()
 	}
@@ -6,8 +6,10 @@ package pinboard // import "miniflux.app/v2/internal/integration/pinboard"
 import (
 	"encoding/xml"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // Post a Pinboard bookmark.  "inspiration" from https://github.com/drags/pinboard/blob/master/posts.go#L32-L42
@@ -37,10 +39,21 @@ func NewPost(url string, description string) *Post {
 	}
 }
 
-func (p *Post) addTag(tag string) {
-	if !strings.Contains(p.Tags, tag) {
-		p.Tags += " " + tag
+func (p *Post) addTags(tags []string) {
+	existingTags := strings.Fields(p.Tags)
+	for _, tag := range tags {
+		if !slices.Contains(existingTags, tag) {
+			existingTags = append(existingTags, tag)
+		}
 	}
+	p.Tags = strings.Join(existingTags, " ")
+}
+
+// NormalizeTag converts a label to a Pinboard tag, Pinboard tags cannot contain spaces or commas.
+func NormalizeTag(label string) string {
+	return strings.Join(strings.FieldsFunc(label, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	}), "_")
 }
 
 func (p *Post) SetToread() {
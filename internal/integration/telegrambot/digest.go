@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package telegrambot // import "miniflux.app/v2/internal/integration/telegrambot"
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+const (
+	// DefaultDigestSize is the number of entries grouped in a single digest message.
+	DefaultDigestSize = 10
+
+	// Telegram rejects messages longer than 4096 characters.
+	maxMessageLength = 4096
+)
+
+// PushDigest sends entries grouped in as few messages as possible, at most digestSize entries per message.
+func PushDigest(entries model.Entries, botToken, chatID string, topicID *int64, digestSize int, disableWebPagePreview, disableNotification bool) error {
+	if digestSize <= 0 {
+		digestSize = DefaultDigestSize
+	}
+
+	client := NewClient(botToken, chatID)
+	for _, text := range formatDigestMessages(entries, digestSize) {
+		message := &MessageRequest{
+			ChatID:                chatID,
+			Text:                  text,
+			ParseMode:             HTMLFormatting,
+			DisableWebPagePreview: disableWebPagePreview,
+			DisableNotification:   disableNotification,
+		}
+
+		if topicID != nil {
+			message.MessageThreadID = *topicID
+		}
+
+		if _, err := client.SendMessage(message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatDigestMessages(entries model.Entries, digestSize int) []string {
+	var messages []string
+	var builder strings.Builder
+	var currentFeedID int64
+	count := 0
+
+	flush := func() {
+		if builder.Len() > 0 {
+			messages = append(messages, strings.TrimSpace(builder.String()))
+		}
+		builder.Reset()
+		currentFeedID = 0
+		count = 0
+	}
+
+	for _, entry := range entries {
+		line := fmt.Sprintf("• <a href=%q>%s</a>\n", entry.URL, html.EscapeString(entry.Title))
+
+		header := ""
+		if entry.Feed != nil && entry.Feed.ID != currentFeedID {
+			header = fmt.Sprintf("\n<b>%s</b>\n", html.EscapeString(entry.Feed.Title))
+		}
+
+		if count >= digestSize || builder.Len()+len(header)+len(line) > maxMessageLength {
+			flush()
+			if entry.Feed != nil {
+				header = fmt.Sprintf("<b>%s</b>\n", html.EscapeString(entry.Feed.Title))
+			}
+		}
+
+		if header != "" {
+			builder.WriteString(header)
+			currentFeedID = entry.Feed.ID
+		}
+
+		builder.WriteString(line)
+		count++
+	}
+
+	flush()
+	return messages
+}
+
+// ChatIDForCategory returns the chat ID configured for the given category, or the default chat ID.
+//
+// Rules are defined one per line with the format "Category Title=ChatID".
+func ChatIDForCategory(defaultChatID, rules, categoryTitle string) string {
+	for _, line := range strings.Split(rules, "\n") {
+		title, chatID, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		title = strings.TrimSpace(title)
+		chatID = strings.TrimSpace(chatID)
+		if title != "" && chatID != "" && strings.EqualFold(title, categoryTitle) {
+			return chatID
+		}
+	}
+
+	return defaultChatID
+}
+
+// IsQuietHour returns true when the given time falls inside the quiet hours window.
+//
+// The window starts at startHour (inclusive) and ends at endHour (exclusive) and may wrap around midnight.
+func IsQuietHour(now time.Time, startHour, endHour *int64) bool {
+	if startHour == nil || endHour == nil || *startHour == *endHour {
+		return false
+	}
+
+	hour := int64(now.Hour())
+	if *startHour < *endHour {
+		return hour >= *startHour && hour < *endHour
+	}
+
+	return hour >= *startHour || hour < *endHour
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package telegrambot // import "miniflux.app/v2/internal/integration/telegrambot"
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestFormatDigestMessagesGroupsEntriesByFeed(t *testing.T) {
+	firstFeed := &model.Feed{ID: 1, Title: "First & Co"}
+	secondFeed := &model.Feed{ID: 2, Title: "Second"}
+	entries := model.Entries{
+		{Title: "A <b>", URL: "https://example.org/a", Feed: firstFeed},
+		{Title: "B", URL: "https://example.org/b", Feed: firstFeed},
+		{Title: "C", URL: "https://example.org/c", Feed: secondFeed},
+	}
+
+	messages := formatDigestMessages(entries, 10)
+	if len(messages) != 1 {
+		t.Fatalf(`A single message should be sent, got %d`, len(messages))
+	}
+
+	expected := "<b>First &amp; Co</b>\n" +
+		"• <a href=\"https://example.org/a\">A &lt;b&gt;</a>\n" +
+		"• <a href=\"https://example.org/b\">B</a>\n" +
+		"\n<b>Second</b>\n" +
+		"• <a href=\"https://example.org/c\">C</a>"
+
+	if messages[0] != expected {
+		t.Errorf(`Unexpected digest message, got %q`, messages[0])
+	}
+}
+
+func TestFormatDigestMessagesSplitsOnDigestSize(t *testing.T) {
+	feed := &model.Feed{ID: 1, Title: "Feed"}
+	var entries model.Entries
+	for i := range 5 {
+		entries = append(entries, &model.Entry{Title: fmt.Sprintf("Entry %d", i), URL: "https://example.org/", Feed: feed})
+	}
+
+	messages := formatDigestMessages(entries, 2)
+	if len(messages) != 3 {
+		t.Fatalf(`Three messages should be sent, got %d`, len(messages))
+	}
+
+	for _, message := range messages {
+		if !strings.HasPrefix(message, "<b>Feed</b>\n") {
+			t.Errorf(`Each message should start with the feed title, got %q`, message)
+		}
+	}
+
+	if strings.Count(messages[2], "•") != 1 {
+		t.Errorf(`The last message should contain the remaining entry, got %q`, messages[2])
+	}
+}
+
+func TestFormatDigestMessagesSplitsOnMessageLength(t *testing.T) {
+	feed := &model.Feed{ID: 1, Title: "Feed"}
+	longTitle := strings.Repeat("x", 1500)
+	entries := model.Entries{
+		{Title: longTitle, URL: "https://example.org/1", Feed: feed},
+		{Title: longTitle, URL: "https://example.org/2", Feed: feed},
+		{Title: longTitle, URL: "https://example.org/3", Feed: feed},
+	}
+
+	messages := formatDigestMessages(entries, 10)
+	if len(messages) != 2 {
+		t.Fatalf(`Two messages should be sent, got %d`, len(messages))
+	}
+
+	for _, message := range messages {
+		if len(message) > maxMessageLength {
+			t.Errorf(`The message should not be longer than %d characters, got %d`, maxMessageLength, len(message))
+		}
+	}
+}
+
+func TestFormatDigestMessagesWithoutEntries(t *testing.T) {
+	if messages := formatDigestMessages(nil, 10); len(messages) != 0 {
+		t.Errorf(`No message should be sent, got %v`, messages)
+	}
+}
+
+func TestChatIDForCategory(t *testing.T) {
+	rules := "News=-100\n  Tech Blogs = -200 \ninvalid\nEmpty=\n=-300"
+
+	scenarios := []struct {
+		categoryTitle string
+		expected      string
+	}{
+		{"News", "-100"},
+		{"news", "-100"},
+		{"Tech Blogs", "-200"},
+		{"Empty", "default"},
+		{"", "default"},
+		{"Other", "default"},
+	}
+
+	for _, scenario := range scenarios {
+		if chatID := ChatIDForCategory("default", rules, scenario.categoryTitle); chatID != scenario.expected {
+			t.Errorf(`The category %q should use the chat %q, got %q`, scenario.categoryTitle, scenario.expected, chatID)
+		}
+	}
+}
+
+func TestIsQuietHour(t *testing.T) {
+	hour := func(h int64) *int64 { return &h }
+	at := func(h int) time.Time { return time.Date(2024, 1, 1, h, 30, 0, 0, time.UTC) }
+
+	scenarios := []struct {
+		now       time.Time
+		startHour *int64
+		endHour   *int64
+		expected  bool
+	}{
+		{at(3), nil, hour(6), false},
+		{at(3), hour(1), nil, false},
+		{at(3), hour(3), hour(3), false},
+		{at(1), hour(1), hour(6), true},
+		{at(5), hour(1), hour(6), true},
+		{at(6), hour(1), hour(6), false},
+		{at(0), hour(1), hour(6), false},
+		{at(23), hour(22), hour(7), true},
+		{at(2), hour(22), hour(7), true},
+		{at(7), hour(22), hour(7), false},
+		{at(12), hour(22), hour(7), false},
+	}
+
+	for i, scenario := range scenarios {
+		if result := IsQuietHour(scenario.now, scenario.startHour, scenario.endHour); result != scenario.expected {
+			t.Errorf(`Unexpected result for the scenario #%d at %d:30, got %v`, i, scenario.now.Hour(), result)
+		}
+	}
+}
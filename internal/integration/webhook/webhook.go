@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/integration/payloadtemplate"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/version"
 )
@@ -23,12 +24,14 @@ const (
 )
 
 type Client struct {
-	webhookURL    string
-	webhookSecret string
+	webhookURL      string
+	webhookSecret   string
+	payloadTemplate string
 }
 
-func NewClient(webhookURL, webhookSecret string) *Client {
-	return &Client{webhookURL, webhookSecret}
+// NewClient returns a webhook client, the default JSON payload is replaced by the payload template when defined.
+func NewClient(webhookURL, webhookSecret, payloadTemplate string) *Client {
+	return &Client{webhookURL, webhookSecret, payloadTemplate}
 }
 
 func (c *Client) SendSaveEntryWebhookEvent(entry *model.Entry) error {
@@ -116,8 +119,13 @@ func (c *Client) makeRequest(eventType string, payload any) error {
 		return fmt.Errorf(`webhook: missing webhook URL`)
 	}
 
-	requestBody, err := json.Marshal(payload)
-	if err != nil {
+	var requestBody []byte
+	var err error
+	if c.payloadTemplate != "" {
+		if requestBody, err = payloadtemplate.Render(c.payloadTemplate, payload); err != nil {
+			return fmt.Errorf("webhook: %v", err)
+		}
+	} else if requestBody, err = json.Marshal(payload); err != nil {
 		return fmt.Errorf("webhook: unable to encode request body: %v", err)
 	}
 
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package zotero // import "miniflux.app/v2/internal/integration/zotero"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/version"
+)
+
+const (
+	defaultClientTimeout = 10 * time.Second
+	defaultAPIEndpoint   = "https://api.zotero.org"
+)
+
+type Client struct {
+	userID        string
+	apiKey        string
+	collectionKey string
+}
+
+func NewClient(userID, apiKey, collectionKey string) *Client {
+	return &Client{userID: userID, apiKey: apiKey, collectionKey: collectionKey}
+}
+
+// CreateItem saves the entry as a webpage item in the Zotero library.
+func (c *Client) CreateItem(entry *model.Entry) error {
+	if c.userID == "" || c.apiKey == "" {
+		return fmt.Errorf("zotero: missing user ID or API key")
+	}
+
+	item := &webpageItem{
+		ItemType:   "webpage",
+		Title:      entry.Title,
+		URL:        entry.URL,
+		AccessDate: time.Now().UTC().Format(time.RFC3339),
+		Creators:   []creator{},
+		Tags:       []tag{},
+	}
+
+	if entry.Author != "" {
+		item.Creators = append(item.Creators, creator{CreatorType: "author", Name: entry.Author})
+	}
+
+	if !entry.Date.IsZero() {
+		item.Date = entry.Date.Format("2006-01-02")
+	}
+
+	if entry.Feed != nil {
+		item.WebsiteTitle = entry.Feed.Title
+	}
+
+	for _, entryTag := range entry.Tags {
+		item.Tags = append(item.Tags, tag{Tag: entryTag})
+	}
+
+	if c.collectionKey != "" {
+		item.Collections = []string{c.collectionKey}
+	}
+
+	requestBody, err := json.Marshal([]*webpageItem{item})
+	if err != nil {
+		return fmt.Errorf("zotero: unable to encode request body: %v", err)
+	}
+
+	apiEndpoint := defaultAPIEndpoint + "/users/" + url.PathEscape(c.userID) + "/items"
+	request, err := http.NewRequest(http.MethodPost, apiEndpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("zotero: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("Zotero-API-Key", c.apiKey)
+	request.Header.Set("Zotero-API-Version", "3")
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("zotero: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("zotero: unable to create item: url=%s status=%d", apiEndpoint, response.StatusCode)
+	}
+
+	// Zotero returns a successful status code even if some items are rejected.
+	var result writeResponse
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return fmt.Errorf("zotero: unable to decode response: %v", err)
+	}
+
+	for _, failure := range result.Failed {
+		return fmt.Errorf("zotero: unable to create item: code=%d message=%s", failure.Code, failure.Message)
+	}
+
+	return nil
+}
+
+// See https://www.zotero.org/support/dev/web_api/v3/write_requests#creating_multiple_items
+type webpageItem struct {
+	ItemType     string    `json:"itemType"`
+	Title        string    `json:"title"`
+	Creators     []creator `json:"creators"`
+	WebsiteTitle string    `json:"websiteTitle,omitempty"`
+	Date         string    `json:"date,omitempty"`
+	URL          string    `json:"url"`
+	AccessDate   string    `json:"accessDate"`
+	Tags         []tag     `json:"tags"`
+	Collections  []string  `json:"collections,omitempty"`
+}
+
+type creator struct {
+	CreatorType string `json:"creatorType"`
+	Name        string `json:"name"`
+}
+
+type tag struct {
+	Tag string `json:"tag"`
+}
+
+type writeResponse struct {
+	Failed map[string]struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"failed"`
+}
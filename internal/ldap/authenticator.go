@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ldap // import "miniflux.app/v2/internal/ldap"
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// ErrInvalidCredentials is returned when the user is unknown, not allowed, or the password is wrong.
+var ErrInvalidCredentials = errors.New("ldap: invalid credentials")
+
+// Settings describes how to find and authenticate users in the directory.
+type Settings struct {
+	URL           string
+	BindDN        string
+	BindPassword  string
+	BaseDN        string
+	UserFilter    string
+	GroupFilter   string
+	StartTLS      bool
+	TLSSkipVerify bool
+}
+
+// Authenticator checks user credentials against an LDAP directory or Active Directory.
+type Authenticator struct {
+	settings *Settings
+	timeout  time.Duration
+}
+
+// NewAuthenticator returns an authenticator using the given settings.
+func NewAuthenticator(settings *Settings) *Authenticator {
+	return &Authenticator{settings: settings, timeout: defaultTimeout}
+}
+
+// Authenticate searches the user with the service account, then binds as the user to verify the password.
+func (a *Authenticator) Authenticate(username, password string) error {
+	// Most servers treat a bind with an empty password as an anonymous bind that always succeeds.
+	if username == "" || password == "" {
+		return ErrInvalidCredentials
+	}
+
+	conn, err := Dial(a.settings.URL, a.settings.StartTLS, &tls.Config{InsecureSkipVerify: a.settings.TLSSkipVerify}, a.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if a.settings.BindDN != "" {
+		if err := conn.Bind(a.settings.BindDN, a.settings.BindPassword); err != nil {
+			return fmt.Errorf("ldap: unable to bind with the service account: %v", err)
+		}
+	}
+
+	entries, err := conn.Search(a.settings.BaseDN, a.userFilter(username), []string{"1.1"}, 2)
+	if err != nil {
+		return fmt.Errorf("ldap: unable to search the user: %v", err)
+	}
+
+	if len(entries) != 1 {
+		return ErrInvalidCredentials
+	}
+
+	if err := conn.Bind(entries[0].DN, password); err != nil {
+		var resultError *ResultError
+		if errors.As(err, &resultError) {
+			return ErrInvalidCredentials
+		}
+		return err
+	}
+
+	return nil
+}
+
+// userFilter returns the search filter of the user, restricted to the group filter when defined.
+func (a *Authenticator) userFilter(username string) string {
+	filter := wrapFilter(strings.ReplaceAll(a.settings.UserFilter, "%s", EscapeFilter(username)))
+	if a.settings.GroupFilter == "" {
+		return filter
+	}
+
+	return "(&" + filter + wrapFilter(a.settings.GroupFilter) + ")"
+}
+
+func wrapFilter(filter string) string {
+	filter = strings.TrimSpace(filter)
+	if strings.HasPrefix(filter, "(") {
+		return filter
+	}
+	return "(" + filter + ")"
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ldap // import "miniflux.app/v2/internal/ldap"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+)
+
+const (
+	testServiceDN       = "cn=miniflux,dc=example,dc=org"
+	testServicePassword = "service-secret"
+	testUserDN          = "uid=john,ou=people,dc=example,dc=org"
+	testUserPassword    = "john-secret"
+)
+
+// startTestServer runs a fake directory knowing the service account and a single user named john.
+func startTestServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestConnection(conn)
+		}
+	}()
+
+	return "ldap://" + listener.Addr().String()
+}
+
+func serveTestConnection(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	for {
+		message, err := readPacket(reader)
+		if err != nil {
+			return
+		}
+
+		messageID := message.children[0]
+		operation := message.children[1]
+
+		reply := func(response *packet) {
+			conn.Write(newConstructed(tagSequence, messageID, response).bytes())
+		}
+
+		switch operation.tag {
+		case tagBindRequest:
+			dn := string(operation.children[1].value)
+			password := string(operation.children[2].value)
+			code := int64(49)
+			if (dn == testServiceDN && password == testServicePassword) || (dn == testUserDN && password == testUserPassword) {
+				code = resultSuccess
+			}
+			reply(newResult(tagBindResponse, code))
+		case tagSearchRequest:
+			expected, _ := compileFilter("(&(uid=john)(memberOf=cn=readers,dc=example,dc=org))")
+			if bytes.Equal(operation.children[6].bytes(), expected.bytes()) {
+				reply(newConstructed(tagSearchResultEntry, newString(tagOctetString, testUserDN), newConstructed(tagSequence)))
+			}
+			reply(newResult(tagSearchResultDone, resultSuccess))
+		case tagUnbindRequest:
+			return
+		}
+	}
+}
+
+func newResult(tag byte, code int64) *packet {
+	return newConstructed(tag, newInteger(tagEnumerated, code), newString(tagOctetString, ""), newString(tagOctetString, ""))
+}
+
+func newTestAuthenticator(serverURL string) *Authenticator {
+	return NewAuthenticator(&Settings{
+		URL:          serverURL,
+		BindDN:       testServiceDN,
+		BindPassword: testServicePassword,
+		BaseDN:       "dc=example,dc=org",
+		UserFilter:   "(uid=%s)",
+		GroupFilter:  "memberOf=cn=readers,dc=example,dc=org",
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	authenticator := newTestAuthenticator(startTestServer(t))
+
+	if err := authenticator.Authenticate("john", testUserPassword); err != nil {
+		t.Errorf(`The user should be authenticated: %v`, err)
+	}
+
+	if err := authenticator.Authenticate("john", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf(`A wrong password should be rejected, got %v`, err)
+	}
+
+	if err := authenticator.Authenticate("john", ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf(`An empty password should be rejected, got %v`, err)
+	}
+
+	if err := authenticator.Authenticate("jane", testUserPassword); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf(`An unknown user should be rejected, got %v`, err)
+	}
+
+	if err := authenticator.Authenticate("*", testUserPassword); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf(`The username should be escaped in the filter, got %v`, err)
+	}
+}
+
+func TestAuthenticateWithWrongServiceAccount(t *testing.T) {
+	authenticator := newTestAuthenticator(startTestServer(t))
+	authenticator.settings.BindPassword = "wrong"
+
+	err := authenticator.Authenticate("john", testUserPassword)
+	if err == nil || errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf(`A service account error should not be reported as invalid credentials, got %v`, err)
+	}
+}
+
+func TestUserFilter(t *testing.T) {
+	authenticator := NewAuthenticator(&Settings{UserFilter: "(sAMAccountName=%s)"})
+	if result := authenticator.userFilter("j*"); result != `(sAMAccountName=j\2a)` {
+		t.Errorf(`Unexpected filter %q`, result)
+	}
+
+	authenticator.settings.GroupFilter = "(memberOf=cn=readers,dc=example,dc=org)"
+	if result := authenticator.userFilter("john"); result != `(&(sAMAccountName=john)(memberOf=cn=readers,dc=example,dc=org))` {
+		t.Errorf(`Unexpected filter %q`, result)
+	}
+}
@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ldap // import "miniflux.app/v2/internal/ldap"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// BER tags used by the LDAP protocol (RFC 4511).
+const (
+	tagBoolean     = 0x01
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagSequence    = 0x30
+	tagSet         = 0x31
+
+	tagBindRequest           = 0x60
+	tagBindResponse          = 0x61
+	tagUnbindRequest         = 0x42
+	tagSearchRequest         = 0x63
+	tagSearchResultEntry     = 0x64
+	tagSearchResultDone      = 0x65
+	tagSearchResultReference = 0x73
+	tagExtendedRequest       = 0x77
+	tagExtendedResponse      = 0x78
+
+	maxPacketSize = 10 * 1024 * 1024
+)
+
+// packet is a BER encoded type-length-value element.
+type packet struct {
+	tag      byte
+	value    []byte
+	children []*packet
+}
+
+func newPacket(tag byte, value []byte) *packet {
+	return &packet{tag: tag, value: value}
+}
+
+func newConstructed(tag byte, children ...*packet) *packet {
+	return &packet{tag: tag, children: children}
+}
+
+func newString(tag byte, value string) *packet {
+	return newPacket(tag, []byte(value))
+}
+
+func newInteger(tag byte, value int64) *packet {
+	b := []byte{byte(value)}
+	for value > 127 || value < -128 {
+		value >>= 8
+		b = append([]byte{byte(value)}, b...)
+	}
+	return newPacket(tag, b)
+}
+
+func newBoolean(value bool) *packet {
+	if value {
+		return newPacket(tagBoolean, []byte{0xff})
+	}
+	return newPacket(tagBoolean, []byte{0x00})
+}
+
+func (p *packet) isConstructed() bool {
+	return p.tag&0x20 != 0
+}
+
+func (p *packet) bytes() []byte {
+	content := p.value
+	if p.isConstructed() {
+		content = nil
+		for _, child := range p.children {
+			content = append(content, child.bytes()...)
+		}
+	}
+
+	return append(append([]byte{p.tag}, encodeLength(len(content))...), content...)
+}
+
+func (p *packet) integer() (int64, error) {
+	if len(p.value) == 0 || len(p.value) > 8 {
+		return 0, fmt.Errorf("ldap: invalid integer length %d", len(p.value))
+	}
+
+	value := int64(int8(p.value[0]))
+	for _, b := range p.value[1:] {
+		value = value<<8 | int64(b)
+	}
+	return value, nil
+}
+
+func (p *packet) child(index int) (*packet, error) {
+	if index >= len(p.children) {
+		return nil, fmt.Errorf("ldap: missing element %d in packet 0x%02x", index, p.tag)
+	}
+	return p.children[index], nil
+}
+
+func encodeLength(length int) []byte {
+	if length < 128 {
+		return []byte{byte(length)}
+	}
+
+	var b []byte
+	for length > 0 {
+		b = append([]byte{byte(length)}, b...)
+		length >>= 8
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+func readPacket(reader *bufio.Reader) (*packet, error) {
+	tag, err := reader.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	if tag&0x1f == 0x1f {
+		return nil, errors.New("ldap: multi-byte tags are not supported")
+	}
+
+	length, err := readLength(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	value := make([]byte, length)
+	if _, err := io.ReadFull(reader, value); err != nil {
+		return nil, err
+	}
+
+	return parsePacket(tag, value)
+}
+
+func readLength(reader *bufio.Reader) (int, error) {
+	first, err := reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	if first < 0x80 {
+		return int(first), nil
+	}
+
+	count := int(first & 0x7f)
+	if count == 0 || count > 4 {
+		return 0, errors.New("ldap: unsupported length encoding")
+	}
+
+	length := 0
+	for i := 0; i < count; i++ {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		length = length<<8 | int(b)
+	}
+
+	if length > maxPacketSize {
+		return 0, fmt.Errorf("ldap: packet too large (%d bytes)", length)
+	}
+
+	return length, nil
+}
+
+func parsePacket(tag byte, value []byte) (*packet, error) {
+	p := &packet{tag: tag, value: value}
+	if !p.isConstructed() {
+		return p, nil
+	}
+
+	for offset := 0; offset < len(value); {
+		if offset+2 > len(value) {
+			return nil, errors.New("ldap: truncated packet")
+		}
+
+		childTag := value[offset]
+		length, size, err := decodeLength(value[offset+1:])
+		if err != nil {
+			return nil, err
+		}
+
+		start := offset + 1 + size
+		if start+length > len(value) {
+			return nil, errors.New("ldap: truncated packet")
+		}
+
+		child, err := parsePacket(childTag, value[start:start+length])
+		if err != nil {
+			return nil, err
+		}
+
+		p.children = append(p.children, child)
+		offset = start + length
+	}
+
+	return p, nil
+}
+
+func decodeLength(b []byte) (length, size int, err error) {
+	if b[0] < 0x80 {
+		return int(b[0]), 1, nil
+	}
+
+	count := int(b[0] & 0x7f)
+	if count == 0 || count > 4 || len(b) < count+1 {
+		return 0, 0, errors.New("ldap: unsupported length encoding")
+	}
+
+	for i := 1; i <= count; i++ {
+		length = length<<8 | int(b[i])
+	}
+	return length, count + 1, nil
+}
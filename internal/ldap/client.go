@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ldap implements the subset of the LDAPv3 protocol needed to authenticate users: simple binds, searches and StartTLS.
+package ldap // import "miniflux.app/v2/internal/ldap"
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	resultSuccess = 0
+
+	startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+	scopeWholeSubtree  = 2
+	derefAliasesAlways = 3
+)
+
+// ResultError is returned when the server answers with a result code other than success.
+type ResultError struct {
+	Code    int64
+	Message string
+}
+
+func (e *ResultError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("ldap: result code %d", e.Code)
+	}
+	return fmt.Sprintf("ldap: result code %d: %s", e.Code, e.Message)
+}
+
+// Entry is a search result.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Attribute returns the first value of the attribute, attribute names are case insensitive.
+func (e *Entry) Attribute(name string) string {
+	for key, values := range e.Attributes {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// Conn is a connection to an LDAP server.
+type Conn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	timeout   time.Duration
+	messageID int64
+}
+
+// Dial connects to the server, the URL scheme must be ldap or ldaps.
+func Dial(serverURL string, startTLS bool, tlsConfig *tls.Config, timeout time.Duration) (*Conn, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: invalid server URL: %v", err)
+	}
+
+	host := u.Hostname()
+	port := u.Port()
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = host
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+
+	switch u.Scheme {
+	case "ldap":
+		if port == "" {
+			port = "389"
+		}
+		conn, err = dialer.Dial("tcp", net.JoinHostPort(host, port))
+	case "ldaps":
+		if port == "" {
+			port = "636"
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, port), tlsConfig)
+	default:
+		return nil, fmt.Errorf("ldap: unsupported URL scheme %q", u.Scheme)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("ldap: unable to connect to %s: %v", u.Host, err)
+	}
+
+	c := &Conn{conn: conn, reader: bufio.NewReader(conn), timeout: timeout}
+
+	if startTLS && u.Scheme == "ldap" {
+		if err := c.startTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Close sends an unbind request and closes the connection.
+func (c *Conn) Close() error {
+	c.send(newPacket(tagUnbindRequest, nil))
+	return c.conn.Close()
+}
+
+// Bind authenticates the connection with a simple bind.
+func (c *Conn) Bind(dn, password string) error {
+	request := newConstructed(tagBindRequest,
+		newInteger(tagInteger, 3),
+		newString(tagOctetString, dn),
+		newString(0x80, password),
+	)
+
+	response, err := c.roundTrip(request)
+	if err != nil {
+		return err
+	}
+
+	if response.tag != tagBindResponse {
+		return fmt.Errorf("ldap: unexpected response 0x%02x to a bind request", response.tag)
+	}
+
+	return checkResult(response)
+}
+
+// Search returns the entries below the base DN matching the filter.
+func (c *Conn) Search(baseDN, filter string, attributes []string, sizeLimit int64) ([]*Entry, error) {
+	compiledFilter, err := compileFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	attributeList := newConstructed(tagSequence)
+	for _, attribute := range attributes {
+		attributeList.children = append(attributeList.children, newString(tagOctetString, attribute))
+	}
+
+	request := newConstructed(tagSearchRequest,
+		newString(tagOctetString, baseDN),
+		newInteger(tagEnumerated, scopeWholeSubtree),
+		newInteger(tagEnumerated, derefAliasesAlways),
+		newInteger(tagInteger, sizeLimit),
+		newInteger(tagInteger, int64(c.timeout/time.Second)),
+		newBoolean(false),
+		compiledFilter,
+		attributeList,
+	)
+
+	messageID, err := c.send(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	for {
+		response, err := c.receive(messageID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch response.tag {
+		case tagSearchResultEntry:
+			entry, err := parseEntry(response)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case tagSearchResultReference:
+			// Referrals to other servers are not followed.
+		case tagSearchResultDone:
+			if err := checkResult(response); err != nil {
+				return entries, err
+			}
+			return entries, nil
+		default:
+			return nil, fmt.Errorf("ldap: unexpected response 0x%02x to a search request", response.tag)
+		}
+	}
+}
+
+func (c *Conn) startTLS(tlsConfig *tls.Config) error {
+	request := newConstructed(tagExtendedRequest, newString(0x80, startTLSOID))
+
+	response, err := c.roundTrip(request)
+	if err != nil {
+		return err
+	}
+
+	if response.tag != tagExtendedResponse {
+		return fmt.Errorf("ldap: unexpected response 0x%02x to a StartTLS request", response.tag)
+	}
+
+	if err := checkResult(response); err != nil {
+		return fmt.Errorf("ldap: StartTLS refused: %v", err)
+	}
+
+	tlsConn := tls.Client(c.conn, tlsConfig)
+	tlsConn.SetDeadline(time.Now().Add(c.timeout))
+	if err := tlsConn.Handshake(); err != nil {
+		return fmt.Errorf("ldap: TLS handshake failed: %v", err)
+	}
+
+	c.conn = tlsConn
+	c.reader = bufio.NewReader(tlsConn)
+	return nil
+}
+
+func (c *Conn) roundTrip(request *packet) (*packet, error) {
+	messageID, err := c.send(request)
+	if err != nil {
+		return nil, err
+	}
+	return c.receive(messageID)
+}
+
+func (c *Conn) send(operation *packet) (int64, error) {
+	c.messageID++
+	message := newConstructed(tagSequence, newInteger(tagInteger, c.messageID), operation)
+
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	if _, err := c.conn.Write(message.bytes()); err != nil {
+		return 0, fmt.Errorf("ldap: unable to send request: %v", err)
+	}
+
+	return c.messageID, nil
+}
+
+func (c *Conn) receive(messageID int64) (*packet, error) {
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	message, err := readPacket(c.reader)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: unable to read response: %v", err)
+	}
+
+	if message.tag != tagSequence || len(message.children) < 2 {
+		return nil, errors.New("ldap: invalid response message")
+	}
+
+	id, err := message.children[0].integer()
+	if err != nil {
+		return nil, err
+	}
+
+	if id != messageID {
+		return nil, fmt.Errorf("ldap: unexpected message ID %d instead of %d", id, messageID)
+	}
+
+	return message.children[1], nil
+}
+
+func checkResult(response *packet) error {
+	resultCode, err := response.child(0)
+	if err != nil {
+		return err
+	}
+
+	code, err := resultCode.integer()
+	if err != nil {
+		return err
+	}
+
+	if code == resultSuccess {
+		return nil
+	}
+
+	resultError := &ResultError{Code: code}
+	if diagnosticMessage, err := response.child(2); err == nil {
+		resultError.Message = string(diagnosticMessage.value)
+	}
+	return resultError
+}
+
+func parseEntry(response *packet) (*Entry, error) {
+	dn, err := response.child(0)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{DN: string(dn.value), Attributes: make(map[string][]string)}
+
+	attributes, err := response.child(1)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attribute := range attributes.children {
+		if len(attribute.children) < 2 {
+			return nil, errors.New("ldap: invalid attribute in search result")
+		}
+
+		name := string(attribute.children[0].value)
+		for _, value := range attribute.children[1].children {
+			entry.Attributes[name] = append(entry.Attributes[name], string(value.value))
+		}
+	}
+
+	return entry, nil
+}
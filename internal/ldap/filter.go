@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ldap // import "miniflux.app/v2/internal/ldap"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Filter choices (RFC 4511 section 4.5.1.7).
+const (
+	filterAnd            = 0xa0
+	filterOr             = 0xa1
+	filterNot            = 0xa2
+	filterEqualityMatch  = 0xa3
+	filterSubstrings     = 0xa4
+	filterGreaterOrEqual = 0xa5
+	filterLessOrEqual    = 0xa6
+	filterPresent        = 0x87
+	filterApproxMatch    = 0xa8
+
+	substringInitial = 0x80
+	substringAny     = 0x81
+	substringFinal   = 0x82
+)
+
+// EscapeFilter escapes the special characters of a value used in a search filter (RFC 4515).
+func EscapeFilter(value string) string {
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '*', '(', ')', '\\', 0:
+			fmt.Fprintf(&builder, `\%02x`, c)
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String()
+}
+
+// compileFilter converts the string representation of a search filter into its BER encoding.
+func compileFilter(filter string) (*packet, error) {
+	filter = strings.TrimSpace(filter)
+	if !strings.HasPrefix(filter, "(") {
+		filter = "(" + filter + ")"
+	}
+
+	p, offset, err := parseFilter(filter, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset != len(filter) {
+		return nil, fmt.Errorf("ldap: unexpected characters at the end of the filter %q", filter)
+	}
+
+	return p, nil
+}
+
+func parseFilter(filter string, offset int) (*packet, int, error) {
+	if offset >= len(filter) || filter[offset] != '(' {
+		return nil, 0, fmt.Errorf("ldap: expected '(' at position %d of the filter %q", offset, filter)
+	}
+	offset++
+
+	if offset >= len(filter) {
+		return nil, 0, fmt.Errorf("ldap: truncated filter %q", filter)
+	}
+
+	var p *packet
+	var err error
+
+	switch filter[offset] {
+	case '&', '|':
+		tag := byte(filterAnd)
+		if filter[offset] == '|' {
+			tag = filterOr
+		}
+
+		p = newConstructed(tag)
+		offset++
+		for offset < len(filter) && filter[offset] == '(' {
+			var child *packet
+			if child, offset, err = parseFilter(filter, offset); err != nil {
+				return nil, 0, err
+			}
+			p.children = append(p.children, child)
+		}
+
+		if len(p.children) == 0 {
+			return nil, 0, fmt.Errorf("ldap: empty filter list in %q", filter)
+		}
+	case '!':
+		var child *packet
+		if child, offset, err = parseFilter(filter, offset+1); err != nil {
+			return nil, 0, err
+		}
+		p = newConstructed(filterNot, child)
+	default:
+		end := strings.IndexByte(filter[offset:], ')')
+		if end < 0 {
+			return nil, 0, fmt.Errorf("ldap: unterminated filter %q", filter)
+		}
+
+		if p, err = parseItem(filter[offset : offset+end]); err != nil {
+			return nil, 0, err
+		}
+		offset += end
+	}
+
+	if offset >= len(filter) || filter[offset] != ')' {
+		return nil, 0, fmt.Errorf("ldap: expected ')' at position %d of the filter %q", offset, filter)
+	}
+
+	return p, offset + 1, nil
+}
+
+func parseItem(item string) (*packet, error) {
+	index := strings.IndexByte(item, '=')
+	if index <= 0 {
+		return nil, fmt.Errorf("ldap: invalid filter item %q", item)
+	}
+
+	attribute, value := item[:index], item[index+1:]
+	tag := byte(filterEqualityMatch)
+
+	switch attribute[len(attribute)-1] {
+	case '>':
+		tag, attribute = filterGreaterOrEqual, attribute[:len(attribute)-1]
+	case '<':
+		tag, attribute = filterLessOrEqual, attribute[:len(attribute)-1]
+	case '~':
+		tag, attribute = filterApproxMatch, attribute[:len(attribute)-1]
+	case ':':
+		return nil, fmt.Errorf("ldap: extensible match filters are not supported: %q", item)
+	}
+
+	if attribute == "" {
+		return nil, fmt.Errorf("ldap: invalid filter item %q", item)
+	}
+
+	if tag == filterEqualityMatch && value == "*" {
+		return newString(filterPresent, attribute), nil
+	}
+
+	if tag == filterEqualityMatch && strings.Contains(value, "*") {
+		return parseSubstrings(attribute, value)
+	}
+
+	unescaped, err := unescapeFilterValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return newConstructed(tag, newString(tagOctetString, attribute), newPacket(tagOctetString, unescaped)), nil
+}
+
+func parseSubstrings(attribute, value string) (*packet, error) {
+	parts := strings.Split(value, "*")
+	substrings := newConstructed(tagSequence)
+
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+
+		unescaped, err := unescapeFilterValue(part)
+		if err != nil {
+			return nil, err
+		}
+
+		tag := byte(substringAny)
+		switch i {
+		case 0:
+			tag = substringInitial
+		case len(parts) - 1:
+			tag = substringFinal
+		}
+		substrings.children = append(substrings.children, newPacket(tag, unescaped))
+	}
+
+	return newConstructed(filterSubstrings, newString(tagOctetString, attribute), substrings), nil
+}
+
+func unescapeFilterValue(value string) ([]byte, error) {
+	var result []byte
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if i+2 >= len(value) {
+				return nil, fmt.Errorf("ldap: invalid escape sequence in %q", value)
+			}
+			decoded, err := hex.DecodeString(value[i+1 : i+3])
+			if err != nil {
+				return nil, fmt.Errorf("ldap: invalid escape sequence in %q", value)
+			}
+			result = append(result, decoded...)
+			i += 2
+		case '(', ')', '*':
+			return nil, fmt.Errorf("ldap: unescaped special character in %q", value)
+		default:
+			result = append(result, value[i])
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ldap // import "miniflux.app/v2/internal/ldap"
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestCompileFilter(t *testing.T) {
+	scenarios := map[string]string{
+		"(cn=Babs Jensen)": "a311" + "0402636e" + "040b42616273204a656e73656e",
+		"cn=Babs Jensen":   "a311" + "0402636e" + "040b42616273204a656e73656e",
+		"(uid=*)":          "8703756964",
+		"(!(uid=a))":       "a20a" + "a308" + "0403756964" + "040161",
+		"(&(uid=a)(ou=b))": "a013" + "a308" + "0403756964" + "040161" + "a307" + "04026f75" + "040162",
+		"(|(uid=a)(ou=b))": "a113" + "a308" + "0403756964" + "040161" + "a307" + "04026f75" + "040162",
+		"(age>=18)":        "a509" + "0403616765" + "04023138",
+		"(age<=18)":        "a609" + "0403616765" + "04023138",
+		"(cn~=a)":          "a807" + "0402636e" + "040161",
+		"(cn=a*b*c)":       "a40f" + "0402636e" + "3009" + "800161" + "810162" + "820163",
+		"(cn=*b*)":         "a409" + "0402636e" + "3003" + "810162",
+		`(cn=a\2ab)`:       "a309" + "0402636e" + "0403612a62",
+	}
+
+	for filter, expected := range scenarios {
+		p, err := compileFilter(filter)
+		if err != nil {
+			t.Errorf(`Unable to compile %q: %v`, filter, err)
+			continue
+		}
+
+		if result := hex.EncodeToString(p.bytes()); result != expected {
+			t.Errorf(`Unexpected encoding of %q, got %s instead of %s`, filter, result, expected)
+		}
+	}
+}
+
+func TestCompileInvalidFilter(t *testing.T) {
+	filters := []string{
+		"",
+		"(cn=a",
+		"(=a)",
+		"(&)",
+		"(cn=a)(cn=b)",
+		`(cn=\zz)`,
+		"(cn:dn:=a)",
+	}
+
+	for _, filter := range filters {
+		if _, err := compileFilter(filter); err == nil {
+			t.Errorf(`The filter %q should be rejected`, filter)
+		}
+	}
+}
+
+func TestEscapeFilter(t *testing.T) {
+	scenarios := map[string]string{
+		"john":       "john",
+		"*":          `\2a`,
+		"a(b)c":      `a\28b\29c`,
+		`back\slash`: `back\5cslash`,
+		"nul\x00":    `nul\00`,
+	}
+
+	for input, expected := range scenarios {
+		if result := EscapeFilter(input); result != expected {
+			t.Errorf(`Unexpected escaping of %q, got %q instead of %q`, input, result, expected)
+		}
+	}
+}
+
+func TestIntegerEncoding(t *testing.T) {
+	scenarios := map[int64]string{
+		0:    "020100",
+		3:    "020103",
+		127:  "02017f",
+		128:  "02020080",
+		256:  "02020100",
+		-1:   "0201ff",
+		-129: "0202ff7f",
+	}
+
+	for value, expected := range scenarios {
+		p := newInteger(tagInteger, value)
+		if result := hex.EncodeToString(p.bytes()); result != expected {
+			t.Errorf(`Unexpected encoding of %d, got %s instead of %s`, value, result, expected)
+		}
+
+		if decoded, err := p.integer(); err != nil || decoded != value {
+			t.Errorf(`Unexpected decoding of %d, got %d (%v)`, value, decoded, err)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mail // import "miniflux.app/v2/internal/mail"
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+)
+
+// Message represents an email message.
+type Message struct {
+	To          string
+	Subject     string
+	TextBody    string
+	HTMLBody    string
+	Attachments []*Attachment
+}
+
+// Attachment represents a file attached to an email message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Sender delivers email messages through an SMTP server.
+type Sender struct {
+	host        string
+	port        int
+	username    string
+	password    string
+	from        string
+	implicitTLS bool
+}
+
+// NewSender returns a new Sender.
+func NewSender(host string, port int, username, password, from string, implicitTLS bool) *Sender {
+	return &Sender{host, port, username, password, from, implicitTLS}
+}
+
+// NewDefaultSender returns a Sender that uses the SMTP server defined in the configuration.
+func NewDefaultSender() *Sender {
+	return NewSender(
+		config.Opts.SMTPHost(),
+		config.Opts.SMTPPort(),
+		config.Opts.SMTPUsername(),
+		config.Opts.SMTPPassword(),
+		config.Opts.SMTPFrom(),
+		config.Opts.SMTPImplicitTLS(),
+	)
+}
+
+// Send delivers the message to its recipient.
+func (s *Sender) Send(message *Message) error {
+	if s.host == "" || s.from == "" {
+		return fmt.Errorf("mail: SMTP server is not configured")
+	}
+
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return fmt.Errorf("mail: invalid sender address: %v", err)
+	}
+
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("mail: invalid recipient address: %v", err)
+	}
+
+	body, err := buildMessage(from, to, message)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.dial()
+	if err != nil {
+		return fmt.Errorf("mail: unable to connect to SMTP server: %v", err)
+	}
+	defer client.Close()
+
+	if !s.implicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+				return fmt.Errorf("mail: unable to start TLS: %v", err)
+			}
+		}
+	}
+
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("mail: unable to authenticate: %v", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("mail: sender rejected: %v", err)
+	}
+
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("mail: recipient rejected: %v", err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("mail: unable to send message: %v", err)
+	}
+
+	if _, err := writer.Write(body); err != nil {
+		return fmt.Errorf("mail: unable to send message: %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("mail: unable to send message: %v", err)
+	}
+
+	return client.Quit()
+}
+
+func (s *Sender) dial() (*smtp.Client, error) {
+	address := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+
+	if s.implicitTLS {
+		conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: s.host})
+		if err != nil {
+			return nil, err
+		}
+		return smtp.NewClient(conn, s.host)
+	}
+
+	conn, err := dialer.Dial("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	return smtp.NewClient(conn, s.host)
+}
+
+func buildMessage(from, to *mail.Address, message *Message) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buffer, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buffer, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buffer, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buffer, "Message-ID: <%s@%s>\r\n", crypto.GenerateRandomStringHex(16), messageIDDomain(from))
+	fmt.Fprintf(&buffer, "MIME-Version: 1.0\r\n")
+
+	contentType, body, err := buildBody(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(message.Attachments) == 0 {
+		fmt.Fprintf(&buffer, "Content-Type: %s\r\n\r\n", contentType)
+		buffer.Write(body)
+		return buffer.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buffer)
+	fmt.Fprintf(&buffer, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", writer.Boundary())
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("mail: unable to build message: %v", err)
+	}
+
+	if _, err := part.Write(body); err != nil {
+		return nil, fmt.Errorf("mail: unable to build message: %v", err)
+	}
+
+	for _, attachment := range message.Attachments {
+		if err := writeAttachment(writer, attachment); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("mail: unable to build message: %v", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// buildBody returns the text and HTML alternatives of the message with their content type.
+func buildBody(message *Message) (string, []byte, error) {
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+
+	if err := writePart(writer, "text/plain; charset=utf-8", message.TextBody); err != nil {
+		return "", nil, err
+	}
+
+	if message.HTMLBody != "" {
+		if err := writePart(writer, "text/html; charset=utf-8", message.HTMLBody); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", nil, fmt.Errorf("mail: unable to build message: %v", err)
+	}
+
+	return mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": writer.Boundary()}), buffer.Bytes(), nil
+}
+
+func writeAttachment(writer *multipart.Writer, attachment *Attachment) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", attachment.ContentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("mail: unable to build message: %v", err)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	for len(encoded) > 0 {
+		lineLength := min(len(encoded), 76)
+		if _, err := io.WriteString(part, encoded[:lineLength]+"\r\n"); err != nil {
+			return fmt.Errorf("mail: unable to build message: %v", err)
+		}
+		encoded = encoded[lineLength:]
+	}
+
+	return nil
+}
+
+func writePart(writer *multipart.Writer, contentType, content string) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("mail: unable to build message: %v", err)
+	}
+
+	encoder := quotedprintable.NewWriter(part)
+	if _, err := encoder.Write([]byte(content)); err != nil {
+		return fmt.Errorf("mail: unable to build message: %v", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("mail: unable to build message: %v", err)
+	}
+
+	return nil
+}
+
+func messageIDDomain(from *mail.Address) string {
+	if index := strings.LastIndex(from.Address, "@"); index != -1 {
+		return from.Address[index+1:]
+	}
+	return "localhost"
+}
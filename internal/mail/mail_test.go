@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mail // import "miniflux.app/v2/internal/mail"
+
+import (
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestBuildMessage(t *testing.T) {
+	from := &mail.Address{Name: "Miniflux", Address: "miniflux@example.org"}
+	to := &mail.Address{Address: "user@example.org"}
+
+	message, err := buildMessage(from, to, &Message{
+		To:       "user@example.org",
+		Subject:  "Digest – Monday",
+		TextBody: "Hello",
+		HTMLBody: "<p>Hello</p>",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(message)))
+	if err != nil {
+		t.Fatalf(`Unable to parse generated message: %v`, err)
+	}
+
+	if got := parsed.Header.Get("From"); got != `"Miniflux" <miniflux@example.org>` {
+		t.Errorf(`Unexpected From header, got %q`, got)
+	}
+
+	if got := parsed.Header.Get("Subject"); !strings.HasPrefix(got, "=?utf-8?q?") {
+		t.Errorf(`Subject header should be encoded, got %q`, got)
+	}
+
+	if got := parsed.Header.Get("Message-Id"); !strings.HasSuffix(got, "@example.org>") {
+		t.Errorf(`Unexpected Message-ID header, got %q`, got)
+	}
+
+	if got := parsed.Header.Get("Content-Type"); !strings.HasPrefix(got, "multipart/alternative;") {
+		t.Errorf(`Unexpected Content-Type header, got %q`, got)
+	}
+}
+
+func TestBuildMessageWithAttachment(t *testing.T) {
+	from := &mail.Address{Address: "miniflux@example.org"}
+	to := &mail.Address{Address: "user@kindle.com"}
+
+	message, err := buildMessage(from, to, &Message{
+		To:       "user@kindle.com",
+		Subject:  "Article",
+		TextBody: "Hello",
+		Attachments: []*Attachment{
+			{Filename: "article.epub", ContentType: "application/epub+zip", Data: []byte("epub")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(message)))
+	if err != nil {
+		t.Fatalf(`Unable to parse generated message: %v`, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf(`Unexpected Content-Type header, got %q`, parsed.Header.Get("Content-Type"))
+	}
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var parts []*multipart.Part
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		parts = append(parts, part)
+		if len(parts) == 2 {
+			break
+		}
+	}
+
+	if len(parts) != 2 {
+		t.Fatalf(`Expected 2 parts, got %d`, len(parts))
+	}
+
+	if parts[1].FileName() != "article.epub" {
+		t.Errorf(`Unexpected attachment filename, got %q`, parts[1].FileName())
+	}
+}
+
+func TestSendWithoutServer(t *testing.T) {
+	sender := NewSender("", 587, "", "", "", false)
+	if err := sender.Send(&Message{To: "user@example.org"}); err == nil {
+		t.Error(`Sending without SMTP server should fail`)
+	}
+}
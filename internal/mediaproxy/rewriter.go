@@ -40,11 +40,7 @@ func genericProxyRewriter(router *mux.Router, proxifyFunction urlProxyRewriter,
 		switch mediaType {
 		case "image":
 			doc.Find("img, picture source").Each(func(i int, img *goquery.Selection) {
-				if srcAttrValue, ok := img.Attr("src"); ok {
-					if shouldProxy(srcAttrValue, proxyOption) {
-						img.SetAttr("src", proxifyFunction(router, srcAttrValue))
-					}
-				}
+				proxifyAttribute(img, "src", router, proxifyFunction, proxyOption)
 
 				if srcsetAttrValue, ok := img.Attr("srcset"); ok {
 					proxifySourceSet(img, router, proxifyFunction, proxyOption, srcsetAttrValue)
@@ -53,36 +49,19 @@ func genericProxyRewriter(router *mux.Router, proxifyFunction urlProxyRewriter,
 
 			if !slices.Contains(config.Opts.MediaProxyResourceTypes(), "video") {
 				doc.Find("video").Each(func(i int, video *goquery.Selection) {
-					if posterAttrValue, ok := video.Attr("poster"); ok {
-						if shouldProxy(posterAttrValue, proxyOption) {
-							video.SetAttr("poster", proxifyFunction(router, posterAttrValue))
-						}
-					}
+					proxifyAttribute(video, "poster", router, proxifyFunction, proxyOption)
 				})
 			}
 
 		case "audio":
 			doc.Find("audio, audio source").Each(func(i int, audio *goquery.Selection) {
-				if srcAttrValue, ok := audio.Attr("src"); ok {
-					if shouldProxy(srcAttrValue, proxyOption) {
-						audio.SetAttr("src", proxifyFunction(router, srcAttrValue))
-					}
-				}
+				proxifyAttribute(audio, "src", router, proxifyFunction, proxyOption)
 			})
 
 		case "video":
 			doc.Find("video, video source").Each(func(i int, video *goquery.Selection) {
-				if srcAttrValue, ok := video.Attr("src"); ok {
-					if shouldProxy(srcAttrValue, proxyOption) {
-						video.SetAttr("src", proxifyFunction(router, srcAttrValue))
-					}
-				}
-
-				if posterAttrValue, ok := video.Attr("poster"); ok {
-					if shouldProxy(posterAttrValue, proxyOption) {
-						video.SetAttr("poster", proxifyFunction(router, posterAttrValue))
-					}
-				}
+				proxifyAttribute(video, "src", router, proxifyFunction, proxyOption)
+				proxifyAttribute(video, "poster", router, proxifyFunction, proxyOption)
 			})
 		}
 	}
@@ -95,6 +74,12 @@ func genericProxyRewriter(router *mux.Router, proxifyFunction urlProxyRewriter,
 	return output
 }
 
+func proxifyAttribute(element *goquery.Selection, attrName string, router *mux.Router, proxifyFunction urlProxyRewriter, proxyOption string) {
+	if attrValue, ok := element.Attr(attrName); ok && shouldProxy(attrValue, proxyOption) {
+		element.SetAttr(attrName, proxifyFunction(router, attrValue))
+	}
+}
+
 func proxifySourceSet(element *goquery.Selection, router *mux.Router, proxifyFunction urlProxyRewriter, proxyOption, srcsetAttrValue string) {
 	imageCandidates := sanitizer.ParseSrcSetAttribute(srcsetAttrValue)
 
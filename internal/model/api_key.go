@@ -16,7 +16,11 @@ type APIKey struct {
 	Token       string
 	Description string
 	LastUsedAt  *time.Time
+	ExpiresAt   *time.Time
 	CreatedAt   time.Time
+
+	// Username is only populated when listing the keys of all users.
+	Username string
 }
 
 // NewAPIKey initializes a new APIKey.
@@ -28,5 +32,10 @@ func NewAPIKey(userID int64, description string) *APIKey {
 	}
 }
 
+// IsExpired returns true if the API key cannot be used anymore.
+func (a *APIKey) IsExpired() bool {
+	return a.ExpiresAt != nil && !a.ExpiresAt.After(time.Now())
+}
+
 // APIKeys represents a collection of API Key.
 type APIKeys []*APIKey
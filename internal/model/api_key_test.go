@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAPIKeyIsExpired(t *testing.T) {
+	apiKey := NewAPIKey(1, "test")
+	if apiKey.IsExpired() {
+		t.Error(`API keys without expiration date should never expire`)
+	}
+
+	future := time.Now().Add(time.Hour)
+	apiKey.ExpiresAt = &future
+	if apiKey.IsExpired() {
+		t.Error(`The API key should not be expired yet`)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	apiKey.ExpiresAt = &past
+	if !apiKey.IsExpired() {
+		t.Error(`The API key should be expired`)
+	}
+}
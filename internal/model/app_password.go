@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// App passwords scopes.
+const (
+	AppPasswordScopeFever        = "fever"
+	AppPasswordScopeGoogleReader = "googlereader"
+)
+
+// AppPassword represents a password restricted to one of the compatibility APIs.
+type AppPassword struct {
+	ID          int64
+	UserID      int64
+	Description string
+	Scope       string
+	LastUsedAt  *time.Time
+	CreatedAt   time.Time
+}
+
+// AppPasswords represents a collection of app passwords.
+type AppPasswords []*AppPassword
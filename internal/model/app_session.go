@@ -22,10 +22,11 @@ type SessionData struct {
 	PocketRequestToken  string          `json:"pocket_request_token"`
 	LastForceRefresh    string          `json:"last_force_refresh"`
 	WebAuthnSessionData WebAuthnSession `json:"webauthn_session_data"`
+	TOTPPendingUserID   int64           `json:"totp_pending_user_id"`
 }
 
 func (s SessionData) String() string {
-	return fmt.Sprintf(`CSRF=%q, OAuth2State=%q, OAuth2CodeVerifier=%q, FlashMsg=%q, FlashErrMsg=%q, Lang=%q, Theme=%q, PocketTkn=%q, LastForceRefresh=%s, WebAuthnSession=%q`,
+	return fmt.Sprintf(`CSRF=%q, OAuth2State=%q, OAuth2CodeVerifier=%q, FlashMsg=%q, FlashErrMsg=%q, Lang=%q, Theme=%q, PocketTkn=%q, LastForceRefresh=%s, WebAuthnSession=%q, TOTPPendingUserID=%d`,
 		s.CSRF,
 		s.OAuth2State,
 		s.OAuth2CodeVerifier,
@@ -36,6 +37,7 @@ func (s SessionData) String() string {
 		s.PocketRequestToken,
 		s.LastForceRefresh,
 		s.WebAuthnSessionData,
+		s.TOTPPendingUserID,
 	)
 }
 
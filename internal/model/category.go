@@ -13,6 +13,10 @@ type Category struct {
 	HideGlobally bool   `json:"hide_globally"`
 	FeedCount    *int   `json:"feed_count,omitempty"`
 	TotalUnread  *int   `json:"total_unread,omitempty"`
+
+	// Entries older than this number of days, read or unread, are changed to the archive status, starred and shared entries are kept, 0 disables the rule.
+	AutoArchiveAfterDays int    `json:"auto_archive_after_days"`
+	AutoArchiveStatus    string `json:"auto_archive_status"`
 }
 
 func (c *Category) String() string {
@@ -21,14 +25,24 @@ func (c *Category) String() string {
 
 // CategoryRequest represents the request to create or update a category.
 type CategoryRequest struct {
-	Title        string `json:"title"`
-	HideGlobally string `json:"hide_globally"`
+	Title                string  `json:"title"`
+	HideGlobally         string  `json:"hide_globally"`
+	AutoArchiveAfterDays *int    `json:"auto_archive_after_days"`
+	AutoArchiveStatus    *string `json:"auto_archive_status"`
 }
 
 // Patch updates category fields.
 func (cr *CategoryRequest) Patch(category *Category) {
 	category.Title = cr.Title
 	category.HideGlobally = cr.HideGlobally != ""
+
+	if cr.AutoArchiveAfterDays != nil {
+		category.AutoArchiveAfterDays = *cr.AutoArchiveAfterDays
+	}
+
+	if cr.AutoArchiveStatus != nil {
+		category.AutoArchiveStatus = *cr.AutoArchiveStatus
+	}
 }
 
 // Categories represents a list of categories.
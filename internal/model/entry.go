@@ -18,25 +18,43 @@ const (
 
 // Entry represents a feed item in the system.
 type Entry struct {
-	ID          int64         `json:"id"`
-	UserID      int64         `json:"user_id"`
-	FeedID      int64         `json:"feed_id"`
-	Status      string        `json:"status"`
-	Hash        string        `json:"hash"`
-	Title       string        `json:"title"`
-	URL         string        `json:"url"`
-	CommentsURL string        `json:"comments_url"`
-	Date        time.Time     `json:"published_at"`
-	CreatedAt   time.Time     `json:"created_at"`
-	ChangedAt   time.Time     `json:"changed_at"`
-	Content     string        `json:"content"`
-	Author      string        `json:"author"`
-	ShareCode   string        `json:"share_code"`
-	Starred     bool          `json:"starred"`
-	ReadingTime int           `json:"reading_time"`
-	Enclosures  EnclosureList `json:"enclosures"`
-	Feed        *Feed         `json:"feed,omitempty"`
-	Tags        []string      `json:"tags"`
+	ID              int64         `json:"id"`
+	UserID          int64         `json:"user_id"`
+	FeedID          int64         `json:"feed_id"`
+	Status          string        `json:"status"`
+	Hash            string        `json:"hash"`
+	Title           string        `json:"title"`
+	URL             string        `json:"url"`
+	CommentsURL     string        `json:"comments_url"`
+	CommentsFeedURL string        `json:"comments_feed_url"`
+	ThumbnailURL    string        `json:"thumbnail_url"`
+	Latitude        *float64      `json:"latitude,omitempty"`
+	Longitude       *float64      `json:"longitude,omitempty"`
+	Date            time.Time     `json:"published_at"`
+	CreatedAt       time.Time     `json:"created_at"`
+	ChangedAt       time.Time     `json:"changed_at"`
+	Content         string        `json:"content"`
+	Author          string        `json:"author"`
+	Language        string        `json:"language"`
+	ShareCode       string        `json:"share_code"`
+	Starred         bool          `json:"starred"`
+	ReadingTime     int           `json:"reading_time"`
+	Enclosures      EnclosureList `json:"enclosures"`
+	Feed            *Feed         `json:"feed,omitempty"`
+	Tags            []string      `json:"tags"`
+
+	// FeedContent keeps the content provided by the feed when it has been replaced by the scraped content.
+	FeedContent string `json:"feed_content"`
+
+	// The translation of the title and of the content, the language is the one of the user who requested it.
+	TranslatedTitle     string `json:"translated_title"`
+	TranslatedContent   string `json:"translated_content"`
+	TranslationLanguage string `json:"translation_language"`
+
+	// The podcast episodes are numbered, the episode type is "full", "trailer" or "bonus".
+	PodcastEpisode     int    `json:"podcast_episode"`
+	PodcastSeason      int    `json:"podcast_season"`
+	PodcastEpisodeType string `json:"podcast_episode_type"`
 }
 
 func NewEntry() *Entry {
@@ -75,6 +93,11 @@ type EntriesStatusUpdateRequest struct {
 	Status   string  `json:"status"`
 }
 
+// EntriesKindleRequest represents a request to send entries to a Kindle device.
+type EntriesKindleRequest struct {
+	EntryIDs []int64 `json:"entry_ids"`
+}
+
 // EntryUpdateRequest represents a request to update an entry.
 type EntryUpdateRequest struct {
 	Title   *string `json:"title"`
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"slices"
 	"time"
 
 	"miniflux.app/v2/internal/config"
@@ -23,38 +24,56 @@ const (
 
 // Feed represents a feed in the application.
 type Feed struct {
-	ID                          int64     `json:"id"`
-	UserID                      int64     `json:"user_id"`
-	FeedURL                     string    `json:"feed_url"`
-	SiteURL                     string    `json:"site_url"`
-	Title                       string    `json:"title"`
-	Description                 string    `json:"description"`
-	CheckedAt                   time.Time `json:"checked_at"`
-	NextCheckAt                 time.Time `json:"next_check_at"`
-	EtagHeader                  string    `json:"etag_header"`
-	LastModifiedHeader          string    `json:"last_modified_header"`
-	ParsingErrorMsg             string    `json:"parsing_error_message"`
-	ParsingErrorCount           int       `json:"parsing_error_count"`
-	ScraperRules                string    `json:"scraper_rules"`
-	RewriteRules                string    `json:"rewrite_rules"`
-	Crawler                     bool      `json:"crawler"`
-	BlocklistRules              string    `json:"blocklist_rules"`
-	KeeplistRules               string    `json:"keeplist_rules"`
-	UrlRewriteRules             string    `json:"urlrewrite_rules"`
-	UserAgent                   string    `json:"user_agent"`
-	Cookie                      string    `json:"cookie"`
-	Username                    string    `json:"username"`
-	Password                    string    `json:"password"`
-	Disabled                    bool      `json:"disabled"`
-	NoMediaPlayer               bool      `json:"no_media_player"`
-	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool      `json:"fetch_via_proxy"`
-	HideGlobally                bool      `json:"hide_globally"`
-	DisableHTTP2                bool      `json:"disable_http2"`
-	AppriseServiceURLs          string    `json:"apprise_service_urls"`
-	NtfyEnabled                 bool      `json:"ntfy_enabled"`
-	NtfyPriority                int       `json:"ntfy_priority"`
+	ID                          int64      `json:"id"`
+	UserID                      int64      `json:"user_id"`
+	FeedURL                     string     `json:"feed_url"`
+	SiteURL                     string     `json:"site_url"`
+	Title                       string     `json:"title"`
+	Description                 string     `json:"description"`
+	CheckedAt                   time.Time  `json:"checked_at"`
+	NextCheckAt                 time.Time  `json:"next_check_at"`
+	EtagHeader                  string     `json:"etag_header"`
+	LastModifiedHeader          string     `json:"last_modified_header"`
+	ParsingErrorMsg             string     `json:"parsing_error_message"`
+	ParsingErrorCount           int        `json:"parsing_error_count"`
+	ScraperRules                string     `json:"scraper_rules"`
+	RewriteRules                string     `json:"rewrite_rules"`
+	Crawler                     bool       `json:"crawler"`
+	BlocklistRules              string     `json:"blocklist_rules"`
+	KeeplistRules               string     `json:"keeplist_rules"`
+	UrlRewriteRules             string     `json:"urlrewrite_rules"`
+	UserAgent                   string     `json:"user_agent"`
+	Cookie                      string     `json:"cookie"`
+	Username                    string     `json:"username"`
+	Password                    string     `json:"password"`
+	Disabled                    bool       `json:"disabled"`
+	NoMediaPlayer               bool       `json:"no_media_player"`
+	IgnoreHTTPCache             bool       `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool       `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool       `json:"fetch_via_proxy"`
+	HideGlobally                bool       `json:"hide_globally"`
+	DisableHTTP2                bool       `json:"disable_http2"`
+	AppriseServiceURLs          string     `json:"apprise_service_urls"`
+	NtfyEnabled                 bool       `json:"ntfy_enabled"`
+	NtfyPriority                int        `json:"ntfy_priority"`
+	MatrixBotEnabled            bool       `json:"matrix_bot_enabled"`
+	PollingMinInterval          int        `json:"polling_min_interval"`
+	PollingMaxInterval          int        `json:"polling_max_interval"`
+	RefreshInterval             int        `json:"refresh_interval"`
+	PollingWeekdays             []int64    `json:"polling_weekdays"`
+	PollingWindowStart          string     `json:"polling_window_start"`
+	PollingWindowEnd            string     `json:"polling_window_end"`
+	AutoArchiveAfterDays        int        `json:"auto_archive_after_days"`
+	AutoArchiveStatus           string     `json:"auto_archive_status"`
+	CustomIcon                  bool       `json:"custom_icon"`
+	WebSubLeaseExpiresAt        *time.Time `json:"websub_lease_expires_at,omitempty"`
+	PageMonitorSelector         string     `json:"page_monitor_selector"`
+	RelaxedParsing              bool       `json:"relaxed_parsing"`
+	FetchViaHeadlessBrowser     bool       `json:"fetch_via_headless_browser"`
+	ContentExtractor            string     `json:"content_extractor"`
+	RewriteScript               string     `json:"rewrite_script"`
+	AutoTranslate               bool       `json:"auto_translate"`
+	NewsletterToken             string     `json:"-"`
 
 	// Non persisted attributes
 	Category *Category `json:"category,omitempty"`
@@ -63,9 +82,21 @@ type Feed struct {
 
 	TTL                    int    `json:"-"`
 	IconURL                string `json:"-"`
+	HubURL                 string `json:"-"`
 	UnreadCount            int    `json:"-"`
 	ReadCount              int    `json:"-"`
 	NumberOfVisibleEntries int    `json:"-"`
+
+	// The hours (GMT) and the days the publisher asks the aggregators to skip.
+	SkipHours []int          `json:"-"`
+	SkipDays  []time.Weekday `json:"-"`
+
+	// The categories declared by the feed itself, they can be used to file the feed when subscribing.
+	Categories []string `json:"-"`
+
+	// ScrapeEntryMetadata is true when the entries only have an URL, for example the pages of a sitemap.
+	// Their title and description are scraped from the web pages.
+	ScrapeEntryMetadata bool `json:"-"`
 }
 
 type FeedCounters struct {
@@ -84,6 +115,19 @@ func (f *Feed) String() string {
 	)
 }
 
+// IsNewsletter returns true if the entries of the feed are received by email instead of being fetched.
+func (f *Feed) IsNewsletter() bool {
+	return f.NewsletterToken != ""
+}
+
+// NewsletterAddress returns the email address receiving the newsletters of the feed.
+func (f *Feed) NewsletterAddress() string {
+	if f.NewsletterToken == "" || config.Opts.NewsletterDomain() == "" {
+		return ""
+	}
+	return f.NewsletterToken + "@" + config.Opts.NewsletterDomain()
+}
+
 // WithCategoryID initializes the category attribute of the feed.
 func (f *Feed) WithCategoryID(categoryID int64) {
 	f.Category = &Category{ID: categoryID}
@@ -111,27 +155,77 @@ func (f *Feed) CheckedNow() {
 }
 
 // ScheduleNextCheck set "next_check_at" of a feed based on the scheduler selected from the configuration.
+// The per-feed polling intervals, when defined, take precedence over the global ones.
+// A feed with an explicit refresh interval is not handled by the scheduler.
 func (f *Feed) ScheduleNextCheck(weeklyCount int, newTTL int) {
 	f.TTL = newTTL
 	// Default to the global config Polling Frequency.
 	var intervalMinutes int
-	switch config.Opts.PollingScheduler() {
-	case SchedulerEntryFrequency:
+	switch {
+	case f.RefreshInterval > 0:
+		intervalMinutes = max(f.RefreshInterval, config.Opts.SchedulerFeedRefreshMinInterval())
+	case config.Opts.PollingScheduler() == SchedulerEntryFrequency:
+		minInterval, maxInterval := f.entryFrequencyBounds()
 		if weeklyCount <= 0 {
-			intervalMinutes = config.Opts.SchedulerEntryFrequencyMaxInterval()
+			intervalMinutes = maxInterval
 		} else {
 			intervalMinutes = int(math.Round(float64(7*24*60) / float64(weeklyCount*config.Opts.SchedulerEntryFrequencyFactor())))
-			intervalMinutes = int(math.Min(float64(intervalMinutes), float64(config.Opts.SchedulerEntryFrequencyMaxInterval())))
-			intervalMinutes = int(math.Max(float64(intervalMinutes), float64(config.Opts.SchedulerEntryFrequencyMinInterval())))
+			intervalMinutes = int(math.Min(float64(intervalMinutes), float64(maxInterval)))
+			intervalMinutes = int(math.Max(float64(intervalMinutes), float64(minInterval)))
 		}
 	default:
-		intervalMinutes = config.Opts.SchedulerRoundRobinMinInterval()
+		intervalMinutes = max(config.Opts.SchedulerRoundRobinMinInterval(), f.PollingMinInterval)
 	}
 	// If the feed has a TTL defined, we use it to make sure we don't check it too often.
 	if newTTL > intervalMinutes && newTTL > 0 {
 		intervalMinutes = newTTL
 	}
 	f.NextCheckAt = time.Now().Add(time.Minute * time.Duration(intervalMinutes))
+
+	// The hub pushes the new entries of the feeds subscribed with WebSub, they are only checked as a fallback
+	// and to renew the lease. The regular polling starts again as soon as the lease expires.
+	if f.WebSubLeaseExpiresAt != nil && f.WebSubLeaseExpiresAt.After(time.Now()) {
+		fallbackCheckAt := time.Now().Add(WebSubFallbackInterval)
+		if renewalAt := f.WebSubLeaseExpiresAt.Add(-WebSubRenewalMargin); renewalAt.Before(fallbackCheckAt) {
+			fallbackCheckAt = renewalAt
+		}
+		if fallbackCheckAt.After(f.NextCheckAt) {
+			f.NextCheckAt = fallbackCheckAt
+		}
+	}
+
+	f.NextCheckAt = f.skipUnwantedHours(f.NextCheckAt)
+}
+
+// skipUnwantedHours postpones the check to the first hour not listed in the skipHours and skipDays hints of the feed.
+// The hints are ignored if they exclude the whole week.
+func (f *Feed) skipUnwantedHours(checkAt time.Time) time.Time {
+	if len(f.SkipHours) == 0 && len(f.SkipDays) == 0 {
+		return checkAt
+	}
+
+	candidate := checkAt.UTC()
+	for range 7 * 24 {
+		if !slices.Contains(f.SkipHours, candidate.Hour()) && !slices.Contains(f.SkipDays, candidate.Weekday()) {
+			return candidate.In(checkAt.Location())
+		}
+		candidate = candidate.Truncate(time.Hour).Add(time.Hour)
+	}
+
+	return checkAt
+}
+
+func (f *Feed) entryFrequencyBounds() (minInterval, maxInterval int) {
+	// A feed can poll less often than the global minimum, never more often.
+	minInterval = max(config.Opts.SchedulerEntryFrequencyMinInterval(), f.PollingMinInterval)
+
+	maxInterval = config.Opts.SchedulerEntryFrequencyMaxInterval()
+	if f.PollingMaxInterval > 0 {
+		maxInterval = f.PollingMaxInterval
+	}
+
+	// The maximum override can be lower than the minimum interval, the minimum always wins.
+	return minInterval, max(minInterval, maxInterval)
 }
 
 // FeedCreationRequest represents the request to create a feed.
@@ -155,6 +249,25 @@ type FeedCreationRequest struct {
 	HideGlobally                bool   `json:"hide_globally"`
 	UrlRewriteRules             string `json:"urlrewrite_rules"`
 	DisableHTTP2                bool   `json:"disable_http2"`
+
+	// RelaxedParsing repairs the slightly invalid XML documents instead of failing to parse them.
+	RelaxedParsing bool `json:"relaxed_parsing"`
+
+	// UseFeedCategory files the feed in the first category declared by the feed, the category is created if needed.
+	// The requested category is used when the feed doesn't declare any category.
+	UseFeedCategory bool `json:"use_feed_category"`
+
+	// PageMonitorSelector turns the feed into a monitor of the web page, an entry is created each time the selected fragment changes.
+	PageMonitorSelector string `json:"page_monitor_selector"`
+
+	// FetchViaHeadlessBrowser renders the web pages of the entries with the headless browser configured by the administrator.
+	FetchViaHeadlessBrowser bool `json:"fetch_via_headless_browser"`
+
+	// ContentExtractor is the algorithm used to find the content of the web pages when no scraper rule applies.
+	ContentExtractor string `json:"content_extractor"`
+
+	// RewriteScript is a Starlark script transforming the entries, it is ignored when the scripts are disabled on the instance.
+	RewriteScript string `json:"rewrite_script"`
 }
 
 type FeedCreationRequestFromSubscriptionDiscovery struct {
@@ -189,6 +302,28 @@ type FeedModificationRequest struct {
 	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
 	HideGlobally                *bool   `json:"hide_globally"`
 	DisableHTTP2                *bool   `json:"disable_http2"`
+	AppriseServiceURLs          *string `json:"apprise_service_urls"`
+	MatrixBotEnabled            *bool   `json:"matrix_bot_enabled"`
+	PollingMinInterval          *int    `json:"polling_min_interval"`
+	PollingMaxInterval          *int    `json:"polling_max_interval"`
+	RefreshInterval             *int    `json:"refresh_interval"`
+	PollingWeekdays             []int64 `json:"polling_weekdays"`
+	PollingWindowStart          *string `json:"polling_window_start"`
+	PollingWindowEnd            *string `json:"polling_window_end"`
+	AutoArchiveAfterDays        *int    `json:"auto_archive_after_days"`
+	AutoArchiveStatus           *string `json:"auto_archive_status"`
+	PageMonitorSelector         *string `json:"page_monitor_selector"`
+	RelaxedParsing              *bool   `json:"relaxed_parsing"`
+	FetchViaHeadlessBrowser     *bool   `json:"fetch_via_headless_browser"`
+	ContentExtractor            *string `json:"content_extractor"`
+	RewriteScript               *string `json:"rewrite_script"`
+
+	// AutoTranslate translates the new entries to the language of the user, when a translation service is configured.
+	AutoTranslate *bool `json:"auto_translate"`
+
+	// IconURL replaces the icon of the feed by the given image, data URLs are accepted.
+	// An empty string removes the custom icon.
+	IconURL *string `json:"icon_url"`
 }
 
 // Patch updates a feed with modified values.
@@ -280,6 +415,70 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 	if f.DisableHTTP2 != nil {
 		feed.DisableHTTP2 = *f.DisableHTTP2
 	}
+
+	if f.AppriseServiceURLs != nil {
+		feed.AppriseServiceURLs = *f.AppriseServiceURLs
+	}
+
+	if f.MatrixBotEnabled != nil {
+		feed.MatrixBotEnabled = *f.MatrixBotEnabled
+	}
+
+	if f.PollingMinInterval != nil {
+		feed.PollingMinInterval = *f.PollingMinInterval
+	}
+
+	if f.PollingMaxInterval != nil {
+		feed.PollingMaxInterval = *f.PollingMaxInterval
+	}
+
+	if f.RefreshInterval != nil {
+		feed.RefreshInterval = *f.RefreshInterval
+	}
+
+	if f.PollingWeekdays != nil {
+		feed.PollingWeekdays = f.PollingWeekdays
+	}
+
+	if f.PollingWindowStart != nil {
+		feed.PollingWindowStart = *f.PollingWindowStart
+	}
+
+	if f.PollingWindowEnd != nil {
+		feed.PollingWindowEnd = *f.PollingWindowEnd
+	}
+
+	if f.AutoArchiveAfterDays != nil {
+		feed.AutoArchiveAfterDays = *f.AutoArchiveAfterDays
+	}
+
+	if f.AutoArchiveStatus != nil {
+		feed.AutoArchiveStatus = *f.AutoArchiveStatus
+	}
+
+	if f.PageMonitorSelector != nil {
+		feed.PageMonitorSelector = *f.PageMonitorSelector
+	}
+
+	if f.RelaxedParsing != nil {
+		feed.RelaxedParsing = *f.RelaxedParsing
+	}
+
+	if f.FetchViaHeadlessBrowser != nil {
+		feed.FetchViaHeadlessBrowser = *f.FetchViaHeadlessBrowser
+	}
+
+	if f.ContentExtractor != nil {
+		feed.ContentExtractor = *f.ContentExtractor
+	}
+
+	if f.RewriteScript != nil {
+		feed.RewriteScript = *f.RewriteScript
+	}
+
+	if f.AutoTranslate != nil {
+		feed.AutoTranslate = *f.AutoTranslate
+	}
 }
 
 // Feeds is a list of feed
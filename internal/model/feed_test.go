@@ -212,6 +212,170 @@ func TestFeedScheduleNextCheckEntryFrequencyMinInterval(t *testing.T) {
 	checkTargetInterval(t, feed, targetInterval, timeBefore, "entry frequency min interval")
 }
 
+func TestFeedScheduleNextCheckEntryFrequencyFeedMinInterval(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "entry_frequency")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL", "500")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL", "5")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{PollingMinInterval: 120}
+	feed.ScheduleNextCheck(largeWeeklyCount, noNewTTL)
+
+	checkTargetInterval(t, feed, 120, timeBefore, "entry frequency feed min interval")
+}
+
+func TestFeedScheduleNextCheckEntryFrequencyFeedMaxInterval(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "entry_frequency")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL", "500")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL", "5")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{PollingMaxInterval: 60}
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	checkTargetInterval(t, feed, 60, timeBefore, "entry frequency feed max interval")
+}
+
+func TestFeedScheduleNextCheckEntryFrequencyFeedMinIntervalAboveGlobalMaxInterval(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "entry_frequency")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL", "500")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL", "5")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{PollingMinInterval: 1000}
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	checkTargetInterval(t, feed, 1000, timeBefore, "entry frequency feed min interval above global max interval")
+}
+
+func TestFeedScheduleNextCheckEntryFrequencyFeedMinIntervalBelowGlobalMinInterval(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "entry_frequency")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL", "500")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL", "30")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{PollingMinInterval: 1}
+	feed.ScheduleNextCheck(largeWeeklyCount, noNewTTL)
+
+	checkTargetInterval(t, feed, 30, timeBefore, "entry frequency feed min interval below global min interval")
+}
+
+func TestFeedScheduleNextCheckRoundRobinFeedMinInterval(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "round_robin")
+	os.Setenv("SCHEDULER_ROUND_ROBIN_MIN_INTERVAL", "30")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{PollingMinInterval: 90}
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	checkTargetInterval(t, feed, 90, timeBefore, "round robin feed min interval")
+}
+
+func TestFeedScheduleNextCheckWithRefreshInterval(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "entry_frequency")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{RefreshInterval: 15, PollingMinInterval: 120}
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	checkTargetInterval(t, feed, 15, timeBefore, "feed refresh interval")
+}
+
+func TestFeedScheduleNextCheckWithRefreshIntervalBelowMinimum(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SCHEDULER_FEED_REFRESH_MIN_INTERVAL", "30")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{RefreshInterval: 10}
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	checkTargetInterval(t, feed, 30, timeBefore, "feed refresh interval below the minimum")
+}
+
+func TestFeedScheduleNextCheckWithWebSubLease(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "round_robin")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	leaseExpiresAt := timeBefore.Add(7 * 24 * time.Hour)
+	feed := &Feed{WebSubLeaseExpiresAt: &leaseExpiresAt}
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	checkTargetInterval(t, feed, int(WebSubFallbackInterval.Minutes()), timeBefore, "active WebSub lease")
+
+	leaseExpiresAt = timeBefore.Add(WebSubRenewalMargin + 2*time.Hour)
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	checkTargetInterval(t, feed, 120, timeBefore, "WebSub lease renewal")
+
+	leaseExpiresAt = timeBefore.Add(-time.Hour)
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	checkTargetInterval(t, feed, config.Opts.SchedulerRoundRobinMinInterval(), timeBefore, "expired WebSub lease")
+}
+
 func TestFeedScheduleNextCheckEntryFrequencyFactor(t *testing.T) {
 	factor := 2
 	os.Clearenv()
@@ -309,3 +473,69 @@ func TestFeedScheduleNextCheckEntryFrequencyLargeNewTTL(t *testing.T) {
 		t.Error(`The next_check_at should be after timeBefore + entry frequency min interval`)
 	}
 }
+
+func TestFeedScheduleNextCheckWithSkipHours(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	feed := &Feed{}
+	feed.ScheduleNextCheck(0, noNewTTL)
+	defaultCheckAt := feed.NextCheckAt.UTC()
+
+	// Skip the hour of the default check and the following one.
+	feed.SkipHours = []int{defaultCheckAt.Hour(), defaultCheckAt.Add(time.Hour).Hour()}
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	expected := defaultCheckAt.Truncate(time.Hour).Add(2 * time.Hour)
+	if !feed.NextCheckAt.Equal(expected) {
+		t.Errorf(`The next_check_at should be postponed to %v, got %v`, expected, feed.NextCheckAt)
+	}
+}
+
+func TestFeedScheduleNextCheckWithSkipDays(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	feed := &Feed{}
+	feed.ScheduleNextCheck(0, noNewTTL)
+	defaultCheckAt := feed.NextCheckAt.UTC()
+
+	feed.SkipDays = []time.Weekday{defaultCheckAt.Weekday()}
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	if feed.NextCheckAt.UTC().Weekday() == defaultCheckAt.Weekday() || feed.NextCheckAt.UTC().Hour() != 0 {
+		t.Errorf(`The next_check_at should be postponed to the next day, got %v`, feed.NextCheckAt)
+	}
+}
+
+func TestFeedScheduleNextCheckWithEveryHourSkipped(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{}
+	for hour := range 24 {
+		feed.SkipHours = append(feed.SkipHours, hour)
+	}
+	feed.ScheduleNextCheck(0, noNewTTL)
+
+	checkTargetInterval(t, feed, config.Opts.SchedulerRoundRobinMinInterval(), timeBefore, "round robin min interval")
+}
@@ -14,6 +14,9 @@ type Icon struct {
 	Hash     string `json:"hash"`
 	MimeType string `json:"mime_type"`
 	Content  []byte `json:"-"`
+
+	// DarkVariant is the icon declared by the website for the dark color scheme.
+	DarkVariant *Icon `json:"-"`
 }
 
 // DataURL returns the data URL of the icon.
@@ -26,6 +29,7 @@ type Icons []*Icon
 
 // FeedIcon is a junction table between feeds and icons.
 type FeedIcon struct {
-	FeedID int64 `json:"feed_id"`
-	IconID int64 `json:"icon_id"`
+	FeedID     int64 `json:"feed_id"`
+	IconID     int64 `json:"icon_id"`
+	DarkIconID int64 `json:"dark_icon_id,omitempty"`
 }
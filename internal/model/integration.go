@@ -3,6 +3,21 @@
 
 package model // import "miniflux.app/v2/internal/model"
 
+// Telegram bot delivery modes.
+const (
+	TelegramBotDigestModeNone   = ""
+	TelegramBotDigestModeBatch  = "batch"
+	TelegramBotDigestModeHourly = "hourly"
+)
+
+// Email digest frequencies and contents.
+const (
+	EmailDigestFrequencyDaily  = "daily"
+	EmailDigestFrequencyWeekly = "weekly"
+	EmailDigestContentUnread   = "unread"
+	EmailDigestContentStarred  = "starred"
+)
+
 // Integration represents user integration settings.
 type Integration struct {
 	UserID                           int64
@@ -13,6 +28,8 @@ type Integration struct {
 	PinboardToken                    string
 	PinboardTags                     string
 	PinboardMarkAsUnread             bool
+	PinboardEntryTags                bool
+	PinboardCategoryTag              bool
 	InstapaperEnabled                bool
 	InstapaperUsername               string
 	InstapaperPassword               string
@@ -51,6 +68,11 @@ type Integration struct {
 	TelegramBotDisableWebPagePreview bool
 	TelegramBotDisableNotification   bool
 	TelegramBotDisableButtons        bool
+	TelegramBotCategoryChatIDs       string
+	TelegramBotDigestMode            string
+	TelegramBotDigestSize            int
+	TelegramBotQuietHoursStart       *int64
+	TelegramBotQuietHoursEnd         *int64
 	LinkAceEnabled                   bool
 	LinkAceURL                       string
 	LinkAceAPIKey                    string
@@ -70,6 +92,7 @@ type Integration struct {
 	MatrixBotPassword                string
 	MatrixBotURL                     string
 	MatrixBotChatID                  string
+	MatrixBotAccessToken             string
 	AppriseEnabled                   bool
 	AppriseURL                       string
 	AppriseServicesURL               string
@@ -87,6 +110,7 @@ type Integration struct {
 	ShaarliAPISecret                 string
 	WebhookEnabled                   bool
 	WebhookURL                       string
+	WebhookPayloadTemplate           string
 	WebhookSecret                    string
 	RSSBridgeEnabled                 bool
 	RSSBridgeURL                     string
@@ -104,4 +128,32 @@ type Integration struct {
 	NtfyUsername                     string
 	NtfyPassword                     string
 	NtfyIconURL                      string
+	EmailDigestEnabled               bool
+	EmailDigestAddress               string
+	EmailDigestFrequency             string
+	EmailDigestHour                  int
+	EmailDigestWeekday               int
+	EmailDigestContent               string
+	MastodonEnabled                  bool
+	MastodonURL                      string
+	MastodonAccessToken              string
+	MastodonVisibility               string
+	BlueskyEnabled                   bool
+	BlueskyPDSURL                    string
+	BlueskyHandle                    string
+	BlueskyAppPassword               string
+	ZoteroEnabled                    bool
+	ZoteroUserID                     string
+	ZoteroAPIKey                     string
+	ZoteroCollectionKey              string
+	HypothesisEnabled                bool
+	HypothesisAPIToken               string
+	HypothesisGroup                  string
+	HypothesisShowAnnotations        bool
+	HomeAssistantEnabled             bool
+	HomeAssistantWebhookURL          string
+	HomeAssistantRules               string
+	HomeAssistantPayloadTemplate     string
+	KindleEnabled                    bool
+	KindleEmail                      string
 }
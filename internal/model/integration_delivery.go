@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+const (
+	IntegrationDeliveryEventSaveEntry  = "save_entry"
+	IntegrationDeliveryEventNewEntries = "new_entries"
+
+	IntegrationDeliveryStatusPending = "pending"
+	IntegrationDeliveryStatusFailed  = "failed"
+)
+
+// IntegrationDelivery represents a failed call to a third-party service waiting to be retried.
+type IntegrationDelivery struct {
+	ID            int64
+	UserID        int64
+	Destination   string
+	EventType     string
+	FeedID        int64
+	EntryIDs      []int64
+	Status        string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
+
+// IntegrationDeliveries represents a list of deliveries.
+type IntegrationDeliveries []*IntegrationDelivery
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+// IntegrationRoutingRule restricts a third-party service to the entries of a category or a feed.
+type IntegrationRoutingRule struct {
+	ID            int64
+	UserID        int64
+	Destination   string
+	CategoryID    int64
+	CategoryTitle string
+	FeedID        int64
+	FeedTitle     string
+}
+
+// IntegrationRoutingRules represents a list of routing rules.
+type IntegrationRoutingRules []*IntegrationRoutingRule
+
+// Allows returns true if the entries of the feed can be sent to the destination.
+//
+// A destination without rules receives the entries of all feeds.
+func (rules IntegrationRoutingRules) Allows(destination string, feed *Feed) bool {
+	if feed == nil {
+		return true
+	}
+
+	hasRules := false
+	for _, rule := range rules {
+		if rule.Destination != destination {
+			continue
+		}
+
+		hasRules = true
+		if rule.FeedID != 0 && rule.FeedID == feed.ID {
+			return true
+		}
+
+		if rule.CategoryID != 0 && feed.Category != nil && rule.CategoryID == feed.Category.ID {
+			return true
+		}
+	}
+
+	return !hasRules
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "testing"
+
+func TestIntegrationRoutingRulesAllows(t *testing.T) {
+	rules := IntegrationRoutingRules{
+		{Destination: "telegram", CategoryID: 1},
+		{Destination: "wallabag", FeedID: 42},
+	}
+
+	securityFeed := &Feed{ID: 10, Category: &Category{ID: 1}}
+	longreadFeed := &Feed{ID: 42, Category: &Category{ID: 2}}
+
+	scenarios := []struct {
+		destination string
+		feed        *Feed
+		expected    bool
+	}{
+		{"telegram", securityFeed, true},
+		{"telegram", longreadFeed, false},
+		{"wallabag", longreadFeed, true},
+		{"wallabag", securityFeed, false},
+		{"pinboard", securityFeed, true},
+		{"telegram", nil, true},
+	}
+
+	for _, scenario := range scenarios {
+		if result := rules.Allows(scenario.destination, scenario.feed); result != scenario.expected {
+			t.Errorf(`Unexpected result for %q and %+v, got %v instead of %v`, scenario.destination, scenario.feed, result, scenario.expected)
+		}
+	}
+}
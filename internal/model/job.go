@@ -5,8 +5,9 @@ package model // import "miniflux.app/v2/internal/model"
 
 // Job represents a payload sent to the processing queue.
 type Job struct {
-	UserID int64
-	FeedID int64
+	UserID  int64
+	FeedID  int64
+	FeedURL string
 }
 
 // JobList represents a list of jobs.
@@ -9,6 +9,9 @@ import (
 	"miniflux.app/v2/internal/timezone"
 )
 
+// UserAuthSourceLDAP is the authentication source of the users created after an LDAP authentication.
+const UserAuthSourceLDAP = "ldap"
+
 // User represents a user in the system.
 type User struct {
 	ID                              int64      `json:"id"`
@@ -39,6 +42,14 @@ type User struct {
 	MediaPlaybackRate               float64    `json:"media_playback_rate"`
 	BlockFilterEntryRules           string     `json:"block_filter_entry_rules"`
 	KeepFilterEntryRules            string     `json:"keep_filter_entry_rules"`
+	FullName                        string     `json:"full_name"`
+	Email                           string     `json:"email"`
+
+	// IframeAllowedDomains lists the domains allowed to be embedded in the entries, in addition to the built-in ones.
+	IframeAllowedDomains []string `json:"iframe_allowed_domains"`
+
+	// RenderMath converts the LaTeX formulas of the new entries to MathML.
+	RenderMath bool `json:"render_math"`
 }
 
 // UserCreationRequest represents the request to create a user.
@@ -48,6 +59,9 @@ type UserCreationRequest struct {
 	IsAdmin         bool   `json:"is_admin"`
 	GoogleID        string `json:"google_id"`
 	OpenIDConnectID string `json:"openid_connect_id"`
+
+	// AuthSource records the authentication backend that created the user, like UserAuthSourceLDAP.
+	AuthSource string `json:"-"`
 }
 
 // UserModificationRequest represents the request to update a user.
@@ -78,6 +92,8 @@ type UserModificationRequest struct {
 	MediaPlaybackRate               *float64 `json:"media_playback_rate"`
 	BlockFilterEntryRules           *string  `json:"block_filter_entry_rules"`
 	KeepFilterEntryRules            *string  `json:"keep_filter_entry_rules"`
+	IframeAllowedDomains            []string `json:"iframe_allowed_domains"`
+	RenderMath                      *bool    `json:"render_math"`
 }
 
 // Patch updates the User object with the modification request.
@@ -185,6 +201,14 @@ func (u *UserModificationRequest) Patch(user *User) {
 	if u.KeepFilterEntryRules != nil {
 		user.KeepFilterEntryRules = *u.KeepFilterEntryRules
 	}
+
+	if u.IframeAllowedDomains != nil {
+		user.IframeAllowedDomains = u.IframeAllowedDomains
+	}
+
+	if u.RenderMath != nil {
+		user.RenderMath = *u.RenderMath
+	}
 }
 
 // UseTimezone converts last login date to the given timezone.
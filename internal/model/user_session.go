@@ -12,21 +12,23 @@ import (
 
 // UserSession represents a user session in the system.
 type UserSession struct {
-	ID        int64
-	UserID    int64
-	Token     string
-	CreatedAt time.Time
-	UserAgent string
-	IP        string
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Token      string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
 }
 
 func (u *UserSession) String() string {
 	return fmt.Sprintf(`ID=%q, UserID=%q, IP=%q, Token=%q`, u.ID, u.UserID, u.IP, u.Token)
 }
 
-// UseTimezone converts creation date to the given timezone.
+// UseTimezone converts creation and last seen dates to the given timezone.
 func (u *UserSession) UseTimezone(tz string) {
 	u.CreatedAt = timezone.Convert(tz, u.CreatedAt)
+	u.LastSeenAt = timezone.Convert(tz, u.LastSeenAt)
 }
 
 // UserSessions represents a list of sessions.
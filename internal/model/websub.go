@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+const (
+	// WebSubLeaseDuration is the lease requested to the hubs, they are free to choose another one.
+	WebSubLeaseDuration = 7 * 24 * time.Hour
+
+	// WebSubRenewalMargin is the delay before the end of the lease when the subscription is renewed.
+	// It's also the delay before sending a new request to a hub that didn't verify the previous one.
+	WebSubRenewalMargin = 12 * time.Hour
+
+	// WebSubFallbackInterval is the polling interval of the feeds that have an active subscription.
+	WebSubFallbackInterval = 24 * time.Hour
+)
+
+// WebSubSubscription represents the subscription of a feed to a WebSub hub.
+type WebSubSubscription struct {
+	FeedID         int64
+	UserID         int64
+	HubURL         string
+	TopicURL       string
+	Secret         string
+	RequestedAt    time.Time
+	LeaseExpiresAt *time.Time
+}
+
+// IsActive returns true if the hub verified the subscription and the lease is not expired.
+func (w *WebSubSubscription) IsActive() bool {
+	return w.LeaseExpiresAt != nil && w.LeaseExpiresAt.After(time.Now())
+}
+
+// NeedsRenewal returns true if the subscription must be requested again to the hub:
+// the lease is about to expire, or the hub didn't verify the previous request.
+func (w *WebSubSubscription) NeedsRenewal() bool {
+	if time.Since(w.RequestedAt) < WebSubRenewalMargin {
+		return false
+	}
+	return w.LeaseExpiresAt == nil || time.Until(*w.LeaseExpiresAt) < WebSubRenewalMargin
+}
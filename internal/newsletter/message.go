@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/sanitizer"
+)
+
+// maxMultipartDepth limits the nesting of the multipart messages.
+const maxMultipartDepth = 10
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+
+// ParseMessage converts an email message to an entry, the HTML body is preferred to the plain text body.
+func ParseMessage(r io.Reader) (*model.Entry, error) {
+	message, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("newsletter: unable to read message: %w", err)
+	}
+
+	htmlBody, textBody, err := readBody(textproto.MIMEHeader(message.Header), message.Body, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := model.NewEntry()
+	entry.Title = decodeHeader(message.Header.Get("Subject"))
+	entry.URL = archiveURL(message.Header)
+
+	if htmlBody != "" {
+		entry.Content = htmlBody
+	} else {
+		entry.Content = textToHTML(textBody)
+	}
+
+	// Fallback to the beginning of the content if the subject is empty.
+	if entry.Title == "" {
+		entry.Title = sanitizer.TruncateHTML(entry.Content, 100)
+	}
+
+	if from, err := (&mail.AddressParser{WordDecoder: wordDecoder}).Parse(message.Header.Get("From")); err == nil {
+		entry.Author = from.Name
+		if entry.Author == "" {
+			entry.Author = from.Address
+		}
+	}
+
+	if date, err := message.Header.Date(); err == nil {
+		entry.Date = date
+	} else {
+		entry.Date = time.Now()
+	}
+
+	// The same newsletter can be received twice, for example when it is sent to several addresses of the user.
+	for _, value := range []string{message.Header.Get("Message-Id"), entry.Title + entry.Content} {
+		if value = strings.TrimSpace(value); value != "" {
+			entry.Hash = crypto.Hash(value)
+			break
+		}
+	}
+
+	return entry, nil
+}
+
+// readBody returns the first HTML part and the first plain text part of the message, the attachments are ignored.
+func readBody(header textproto.MIMEHeader, body io.Reader, depth int) (htmlBody, textBody string, err error) {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		// The default content type of the messages.
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if disposition, _, _ := mime.ParseMediaType(header.Get("Content-Disposition")); disposition == "attachment" {
+		return "", "", nil
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		if depth >= maxMultipartDepth {
+			return "", "", nil
+		}
+
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", "", fmt.Errorf("newsletter: unable to read multipart message: %w", err)
+			}
+
+			partHTML, partText, err := readBody(part.Header, part, depth+1)
+			if err != nil {
+				return "", "", err
+			}
+
+			if htmlBody == "" {
+				htmlBody = partHTML
+			}
+			if textBody == "" {
+				textBody = partText
+			}
+		}
+
+		return htmlBody, textBody, nil
+	case mediaType == "text/html", mediaType == "text/plain":
+		content, err := decodeContent(header, body, params["charset"])
+		if err != nil {
+			return "", "", err
+		}
+
+		if mediaType == "text/html" {
+			return content, "", nil
+		}
+		return "", content, nil
+	}
+
+	return "", "", nil
+}
+
+func decodeContent(header textproto.MIMEHeader, body io.Reader, charsetLabel string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	if charsetLabel != "" {
+		reader, err := charset.NewReaderLabel(charsetLabel, body)
+		if err != nil {
+			return "", fmt.Errorf("newsletter: unsupported charset %q: %w", charsetLabel, err)
+		}
+		body = reader
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("newsletter: unable to decode message body: %w", err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+func decodeHeader(value string) string {
+	if decoded, err := wordDecoder.DecodeHeader(value); err == nil {
+		value = decoded
+	}
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// archiveURL returns the web version of the newsletter advertised by the sender, if any.
+// See https://datatracker.ietf.org/doc/html/rfc5064 and https://datatracker.ietf.org/doc/html/rfc2369.
+func archiveURL(header mail.Header) string {
+	for _, name := range []string{"Archived-At", "List-Archive", "List-Url"} {
+		for _, value := range strings.Split(header.Get(name), ",") {
+			value = strings.Trim(strings.TrimSpace(value), "<>")
+			if parsedURL, err := url.Parse(value); err == nil && (parsedURL.Scheme == "https" || parsedURL.Scheme == "http") {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// textToHTML converts the plain text body to paragraphs.
+func textToHTML(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var paragraphs []string
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, "<p>"+strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>")+"</p>")
+		}
+	}
+
+	return strings.Join(paragraphs, "")
+}
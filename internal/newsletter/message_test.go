@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+)
+
+func TestParseMultipartMessage(t *testing.T) {
+	data := strings.ReplaceAll(`From: =?utf-8?q?Caf=C3=A9_Weekly?= <news@example.org>
+To: 0123456789abcdef0123@newsletters.example.org
+Subject: =?utf-8?q?Issue_42=3A_caf=C3=A9?=
+Date: Tue, 10 Feb 2026 08:30:00 +0100
+Message-Id: <issue-42@example.org>
+Archived-At: <https://example.org/issues/42>
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=utf-8
+
+Plain text version.
+--inner
+Content-Type: text/html; charset=iso-8859-1
+Content-Transfer-Encoding: quoted-printable
+
+<p>Caf=E9 au lait</p>
+--inner--
+--outer
+Content-Type: text/html
+Content-Disposition: attachment; filename="other.html"
+
+<p>Attachment</p>
+--outer--
+`, "\n", "\r\n")
+
+	entry, err := ParseMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Title != "Issue 42: café" {
+		t.Errorf(`Incorrect title, got: %q`, entry.Title)
+	}
+
+	if entry.Author != "Café Weekly" {
+		t.Errorf(`Incorrect author, got: %q`, entry.Author)
+	}
+
+	if entry.URL != "https://example.org/issues/42" {
+		t.Errorf(`Incorrect URL, got: %q`, entry.URL)
+	}
+
+	if entry.Content != "<p>Café au lait</p>" {
+		t.Errorf(`Incorrect content, got: %q`, entry.Content)
+	}
+
+	if entry.Hash != crypto.Hash("<issue-42@example.org>") {
+		t.Errorf(`Incorrect hash, got: %q`, entry.Hash)
+	}
+
+	expectedDate := time.Date(2026, time.February, 10, 7, 30, 0, 0, time.UTC)
+	if !entry.Date.Equal(expectedDate) {
+		t.Errorf(`Incorrect date, got: %v`, entry.Date)
+	}
+}
+
+func TestParsePlainTextMessage(t *testing.T) {
+	data := "From: news@example.org\r\n" +
+		"Subject: Weekly digest\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		"Rmlyc3QgbGluZQpTZWNvbmQgbGluZSAmIG1vcmUKClNlY29uZCBwYXJh\r\n" +
+		"Z3JhcGg=\r\n"
+
+	entry, err := ParseMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Content != "<p>First line<br>Second line &amp; more</p><p>Second paragraph</p>" {
+		t.Errorf(`Incorrect content, got: %q`, entry.Content)
+	}
+
+	if entry.Author != "news@example.org" {
+		t.Errorf(`Incorrect author, got: %q`, entry.Author)
+	}
+
+	if entry.URL != "" {
+		t.Errorf(`Incorrect URL, got: %q`, entry.URL)
+	}
+
+	// The message without identifier is identified by its content.
+	if entry.Hash != crypto.Hash(entry.Title+entry.Content) {
+		t.Errorf(`Incorrect hash, got: %q`, entry.Hash)
+	}
+
+	if entry.Date.IsZero() {
+		t.Error(`The date of the message without date should be the current time`)
+	}
+}
+
+func TestParseMessageWithoutSubject(t *testing.T) {
+	data := "From: news@example.org\r\nContent-Type: text/html\r\n\r\n<p>Hello <b>world</b></p>\r\n"
+
+	entry, err := ParseMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Title != "Hello world" {
+		t.Errorf(`Incorrect title, got: %q`, entry.Title)
+	}
+}
+
+func TestParseInvalidMessage(t *testing.T) {
+	if _, err := ParseMessage(strings.NewReader("invalid message")); err == nil {
+		t.Error(`Parsing an invalid message should return an error`)
+	}
+}
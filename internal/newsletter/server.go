@@ -0,0 +1,434 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package newsletter receives the newsletters sent by email to the addresses generated for the feeds.
+// The SMTP listener only accepts the messages of the local recipients, it is not a relay.
+// See https://datatracker.ietf.org/doc/html/rfc5321.
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/storage"
+)
+
+const (
+	maxMessageSize    = 10 * 1024 * 1024
+	maxRecipients     = 100
+	connectionTimeout = 5 * time.Minute
+
+	// maxLineLength is larger than the 1000 characters allowed by the RFC, some senders don't wrap the HTML lines.
+	maxLineLength = 64 * 1024
+
+	// maxSessions limits the memory used by the messages being received.
+	maxSessions = 20
+)
+
+var errLineTooLong = errors.New("newsletter: line too long")
+
+// mailbox finds the feeds matching the recipients and stores the messages.
+type mailbox interface {
+	NewsletterFeed(token string) (userID, feedID int64, err error)
+	Deliver(userID, feedID int64, entry *model.Entry) error
+}
+
+type storageMailbox struct {
+	store *storage.Storage
+}
+
+func (m *storageMailbox) NewsletterFeed(token string) (userID, feedID int64, err error) {
+	return m.store.NewsletterFeed(token)
+}
+
+func (m *storageMailbox) Deliver(userID, feedID int64, entry *model.Entry) error {
+	if localizedError := feedHandler.PushNewsletterEntry(m.store, userID, feedID, entry); localizedError != nil {
+		return localizedError.Error()
+	}
+	return nil
+}
+
+// Server is a minimal SMTP server delivering the received messages to the newsletter feeds.
+type Server struct {
+	domain   string
+	mailbox  mailbox
+	listener net.Listener
+
+	sessions chan struct{}
+
+	mu          sync.Mutex
+	connections map[net.Conn]struct{}
+	closed      bool
+}
+
+// StartServer listens on the given address and serves the SMTP connections in the background.
+func StartServer(store *storage.Storage, listenAddr, domain string) (*Server, error) {
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("newsletter: unable to listen on %q: %w", listenAddr, err)
+	}
+
+	// The URL of the feeds created before a change of domain contains the previous address.
+	if count, err := store.UpdateNewsletterFeedURLs(strings.ToLower(domain)); err != nil {
+		slog.Error("Unable to update the address of the newsletter feeds", slog.Any("error", err))
+	} else if count > 0 {
+		slog.Info("Updated the address of the newsletter feeds", slog.Int64("count", count))
+	}
+
+	server := newServer(listener, domain, &storageMailbox{store})
+	slog.Info("Starting newsletter SMTP server",
+		slog.String("listen_address", listener.Addr().String()),
+		slog.String("domain", domain),
+	)
+
+	go server.serve()
+	return server, nil
+}
+
+func newServer(listener net.Listener, domain string, mailbox mailbox) *Server {
+	return &Server{
+		domain:      strings.ToLower(domain),
+		mailbox:     mailbox,
+		listener:    listener,
+		sessions:    make(chan struct{}, maxSessions),
+		connections: make(map[net.Conn]struct{}),
+	}
+}
+
+// Close stops the listener and the active connections, the messages being received are lost and sent again by the senders.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for conn := range s.connections {
+		conn.Close()
+	}
+
+	return s.listener.Close()
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+
+			if closed {
+				return
+			}
+
+			slog.Warn("Unable to accept SMTP connection", slog.Any("error", err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		select {
+		case s.sessions <- struct{}{}:
+		default:
+			slog.Warn("Rejecting SMTP connection, too many sessions", slog.String("remote_addr", conn.RemoteAddr().String()))
+			conn.SetDeadline(time.Now().Add(time.Second))
+			fmt.Fprintf(conn, "421 4.3.2 %s Too many connections, try again later\r\n", s.domain)
+			conn.Close()
+			continue
+		}
+
+		s.mu.Lock()
+		s.connections[conn] = struct{}{}
+		s.mu.Unlock()
+
+		go func() {
+			defer func() {
+				s.mu.Lock()
+				delete(s.connections, conn)
+				s.mu.Unlock()
+				conn.Close()
+				<-s.sessions
+			}()
+
+			newSession(s, conn).run()
+		}()
+	}
+}
+
+type recipient struct {
+	userID int64
+	feedID int64
+}
+
+type session struct {
+	server     *Server
+	conn       net.Conn
+	text       *textproto.Conn
+	remoteAddr string
+
+	transaction bool
+	sender      string
+	recipients  []recipient
+}
+
+func newSession(server *Server, conn net.Conn) *session {
+	return &session{
+		server:     server,
+		conn:       conn,
+		text:       textproto.NewConn(&boundedConn{Conn: conn, reader: &lineLimitReader{reader: conn, limit: maxLineLength}}),
+		remoteAddr: conn.RemoteAddr().String(),
+	}
+}
+
+func (s *session) reply(code int, message string) {
+	s.text.PrintfLine("%d %s", code, message)
+}
+
+func (s *session) reset() {
+	s.transaction = false
+	s.sender = ""
+	s.recipients = nil
+}
+
+func (s *session) run() {
+	s.conn.SetDeadline(time.Now().Add(connectionTimeout))
+	s.reply(220, s.server.domain+" ESMTP Miniflux")
+
+	for {
+		s.conn.SetDeadline(time.Now().Add(connectionTimeout))
+		line, err := s.text.ReadLine()
+		if err != nil {
+			if errors.Is(err, errLineTooLong) {
+				s.reply(500, "5.5.2 Line too long")
+			}
+			return
+		}
+
+		verb, argument, _ := strings.Cut(line, " ")
+		argument = strings.TrimSpace(argument)
+
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			s.reset()
+			s.reply(250, s.server.domain)
+		case "EHLO":
+			s.reset()
+			s.text.PrintfLine("250-%s", s.server.domain)
+			s.text.PrintfLine("250-SIZE %d", maxMessageSize)
+			s.text.PrintfLine("250-8BITMIME")
+			s.reply(250, "PIPELINING")
+		case "MAIL":
+			s.handleMail(argument)
+		case "RCPT":
+			s.handleRecipient(argument)
+		case "DATA":
+			if !s.handleData() {
+				return
+			}
+		case "RSET":
+			s.reset()
+			s.reply(250, "2.0.0 OK")
+		case "NOOP":
+			s.reply(250, "2.0.0 OK")
+		case "VRFY":
+			s.reply(252, "2.5.0 Cannot verify the user")
+		case "QUIT":
+			s.reply(221, "2.0.0 Bye")
+			return
+		default:
+			s.reply(502, "5.5.2 Command not recognized")
+		}
+	}
+}
+
+func (s *session) handleMail(argument string) {
+	if s.transaction {
+		s.reply(503, "5.5.1 Sender already specified")
+		return
+	}
+
+	address, params, found := parsePath(argument, "FROM:")
+	if !found {
+		s.reply(501, "5.5.4 Syntax: MAIL FROM:<address>")
+		return
+	}
+
+	for _, param := range params {
+		if name, value, _ := strings.Cut(param, "="); strings.EqualFold(name, "SIZE") {
+			if size, err := strconv.Atoi(value); err == nil && size > maxMessageSize {
+				s.reply(552, "5.3.4 Message size exceeds the limit")
+				return
+			}
+		}
+	}
+
+	// The null reverse-path of the bounces is accepted.
+	s.transaction = true
+	s.sender = address
+	s.reply(250, "2.1.0 OK")
+}
+
+func (s *session) handleRecipient(argument string) {
+	if !s.transaction {
+		s.reply(503, "5.5.1 Sender not specified")
+		return
+	}
+
+	address, _, found := parsePath(argument, "TO:")
+	if !found {
+		s.reply(501, "5.5.4 Syntax: RCPT TO:<address>")
+		return
+	}
+
+	if len(s.recipients) >= maxRecipients {
+		s.reply(452, "4.5.3 Too many recipients")
+		return
+	}
+
+	token, domain, found := strings.Cut(strings.ToLower(address), "@")
+	if !found || domain != s.server.domain {
+		s.reply(550, "5.7.1 Relaying denied")
+		return
+	}
+
+	userID, feedID, err := s.server.mailbox.NewsletterFeed(token)
+	if err != nil {
+		slog.Error("Unable to find newsletter feed", slog.Any("error", err))
+		s.reply(451, "4.3.0 Temporary failure")
+		return
+	}
+
+	if feedID == 0 {
+		slog.Debug("Rejecting newsletter sent to an unknown address",
+			slog.String("recipient", address),
+			slog.String("remote_addr", s.remoteAddr),
+		)
+		s.reply(550, "5.1.1 Unknown recipient")
+		return
+	}
+
+	s.recipients = append(s.recipients, recipient{userID, feedID})
+	s.reply(250, "2.1.5 OK")
+}
+
+// handleData receives the message and delivers it to the recipients, it returns false if the connection must be closed.
+func (s *session) handleData() bool {
+	if len(s.recipients) == 0 {
+		s.reply(503, "5.5.1 Recipients not specified")
+		return true
+	}
+
+	s.reply(354, "End data with <CR><LF>.<CR><LF>")
+
+	dotReader := s.text.DotReader()
+	content, err := io.ReadAll(io.LimitReader(dotReader, maxMessageSize+1))
+	if err != nil {
+		if errors.Is(err, errLineTooLong) {
+			s.reply(500, "5.5.2 Line too long")
+		}
+		return false
+	}
+	defer s.reset()
+
+	if len(content) > maxMessageSize {
+		// The rest of the message must be read before replying.
+		if _, err := io.Copy(io.Discard, dotReader); err != nil {
+			return false
+		}
+		s.reply(552, "5.3.4 Message size exceeds the limit")
+		return true
+	}
+
+	if _, err := ParseMessage(bytes.NewReader(content)); err != nil {
+		slog.Warn("Unable to parse newsletter",
+			slog.String("sender", s.sender),
+			slog.String("remote_addr", s.remoteAddr),
+			slog.Any("error", err),
+		)
+		s.reply(554, "5.6.0 Unable to parse the message")
+		return true
+	}
+
+	// Each feed gets its own entry, the entries are modified by the processing rules of the feed.
+	for _, recipient := range s.recipients {
+		entry, _ := ParseMessage(bytes.NewReader(content))
+		if err := s.server.mailbox.Deliver(recipient.userID, recipient.feedID, entry); err != nil {
+			slog.Error("Unable to store newsletter",
+				slog.Int64("user_id", recipient.userID),
+				slog.Int64("feed_id", recipient.feedID),
+				slog.Any("error", err),
+			)
+			s.reply(451, "4.3.0 Temporary failure")
+			return true
+		}
+	}
+
+	s.reply(250, "2.0.0 OK")
+	return true
+}
+
+// parsePath returns the address and the parameters of the MAIL and RCPT commands, for example "FROM:<user@example.org> SIZE=1024".
+func parsePath(argument, prefix string) (address string, params []string, found bool) {
+	if len(argument) < len(prefix) || !strings.EqualFold(argument[:len(prefix)], prefix) {
+		return "", nil, false
+	}
+
+	fields := strings.Fields(strings.TrimSpace(argument[len(prefix):]))
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "<") || !strings.HasSuffix(fields[0], ">") {
+		return "", nil, false
+	}
+
+	address = strings.TrimSuffix(strings.TrimPrefix(fields[0], "<"), ">")
+	if address != "" {
+		parsedAddress, err := mail.ParseAddress(address)
+		if err != nil {
+			return "", nil, false
+		}
+		address = parsedAddress.Address
+	}
+
+	return address, fields[1:], true
+}
+
+// boundedConn reads the connection through a reader limiting the size of the data buffered by textproto.
+type boundedConn struct {
+	net.Conn
+	reader io.Reader
+}
+
+func (c *boundedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+// lineLimitReader returns errLineTooLong once a line is longer than the limit, the lines are read entirely in memory otherwise.
+type lineLimitReader struct {
+	reader     io.Reader
+	limit      int
+	lineLength int
+}
+
+func (r *lineLimitReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	for i, b := range p[:n] {
+		if b == '\n' {
+			r.lineLength = 0
+			continue
+		}
+
+		r.lineLength++
+		if r.lineLength > r.limit {
+			return i, errLineTooLong
+		}
+	}
+	return n, err
+}
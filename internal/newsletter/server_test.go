@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"bufio"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// fakeMailbox knows a single address and keeps the received entries in memory.
+type fakeMailbox struct {
+	mu      sync.Mutex
+	entries map[int64][]*model.Entry
+}
+
+func (f *fakeMailbox) NewsletterFeed(token string) (userID, feedID int64, err error) {
+	if token == "0123456789abcdef0123" {
+		return 1, 2, nil
+	}
+	return 0, 0, nil
+}
+
+func (f *fakeMailbox) Deliver(userID, feedID int64, entry *model.Entry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries[feedID] = append(f.entries[feedID], entry)
+	return nil
+}
+
+func startTestServer(t *testing.T) (*Server, *fakeMailbox) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mailbox := &fakeMailbox{entries: make(map[int64][]*model.Entry)}
+	server := newServer(listener, "Newsletters.Example.org", mailbox)
+	go server.serve()
+	t.Cleanup(func() { server.Close() })
+
+	return server, mailbox
+}
+
+func TestDeliverNewsletter(t *testing.T) {
+	server, mailbox := startTestServer(t)
+
+	message := "From: news@example.org\r\nSubject: Hello\r\nMessage-Id: <1@example.org>\r\n\r\n.Leading dot\r\n"
+	err := smtp.SendMail(server.listener.Addr().String(), nil, "news@example.org", []string{"0123456789ABCDEF0123@newsletters.example.org"}, []byte(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mailbox.mu.Lock()
+	defer mailbox.mu.Unlock()
+
+	if len(mailbox.entries[2]) != 1 {
+		t.Fatalf(`The newsletter should be delivered to the feed, got %d entries`, len(mailbox.entries[2]))
+	}
+
+	entry := mailbox.entries[2][0]
+	if entry.Title != "Hello" {
+		t.Errorf(`Incorrect title, got: %q`, entry.Title)
+	}
+
+	if entry.Content != "<p>.Leading dot</p>" {
+		t.Errorf(`Incorrect content, got: %q`, entry.Content)
+	}
+}
+
+func TestRejectUnknownRecipients(t *testing.T) {
+	server, mailbox := startTestServer(t)
+
+	for _, address := range []string{"unknown@newsletters.example.org", "0123456789abcdef0123@example.org"} {
+		err := smtp.SendMail(server.listener.Addr().String(), nil, "news@example.org", []string{address}, []byte("Subject: Hello\r\n\r\nHello\r\n"))
+		if err == nil || !strings.HasPrefix(err.Error(), "550") {
+			t.Errorf(`The message sent to %q should be rejected, got %v`, address, err)
+		}
+	}
+
+	mailbox.mu.Lock()
+	defer mailbox.mu.Unlock()
+
+	if len(mailbox.entries) != 0 {
+		t.Errorf(`No newsletter should be delivered, got %d feeds`, len(mailbox.entries))
+	}
+}
+
+func TestRejectLongLines(t *testing.T) {
+	server, _ := startTestServer(t)
+
+	conn, err := net.Dial("tcp", server.listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	if _, err := reader.ReadString('\n'); err != nil {
+		t.Fatal(err)
+	}
+
+	go conn.Write([]byte("NOOP " + strings.Repeat("x", maxLineLength+1) + "\r\n"))
+
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "500") {
+		t.Errorf(`The long line should be rejected, got %q, %v`, line, err)
+	}
+}
+
+func TestLimitConcurrentSessions(t *testing.T) {
+	server, _ := startTestServer(t)
+
+	for i := 0; i < maxSessions; i++ {
+		conn, err := net.Dial("tcp", server.listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		if line, err := bufio.NewReader(conn).ReadString('\n'); err != nil || !strings.HasPrefix(line, "220") {
+			t.Fatalf(`Unexpected greeting, got %q, %v`, line, err)
+		}
+	}
+
+	conn, err := net.Dial("tcp", server.listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if line, err := bufio.NewReader(conn).ReadString('\n'); err != nil || !strings.HasPrefix(line, "421") {
+		t.Errorf(`The connection should be refused when too many sessions are open, got %q, %v`, line, err)
+	}
+}
+
+func TestLineLimitReader(t *testing.T) {
+	reader := &lineLimitReader{reader: strings.NewReader("short\r\n" + strings.Repeat("x", 11) + "\r\n"), limit: 10}
+
+	buffer := make([]byte, 64)
+	n, err := reader.Read(buffer)
+	if err != errLineTooLong {
+		t.Errorf(`The long line should be rejected, got %v`, err)
+	}
+
+	if data := string(buffer[:n]); data != "short\r\n"+strings.Repeat("x", 10) {
+		t.Errorf(`The data before the limit should be returned, got %q`, data)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	scenarios := []struct {
+		argument string
+		address  string
+		params   int
+		found    bool
+	}{
+		{"FROM:<news@example.org>", "news@example.org", 0, true},
+		{"from: <news@example.org> SIZE=1024 BODY=8BITMIME", "news@example.org", 2, true},
+		{"FROM:<>", "", 0, true},
+		{"FROM:news@example.org", "", 0, false},
+		{"TO:<news@example.org>", "", 0, false},
+		{"FROM:<invalid address>", "", 0, false},
+	}
+
+	for _, scenario := range scenarios {
+		address, params, found := parsePath(scenario.argument, "FROM:")
+		if address != scenario.address || len(params) != scenario.params || found != scenario.found {
+			t.Errorf(`Unexpected result for %q: %q, %v, %v`, scenario.argument, address, params, found)
+		}
+	}
+}
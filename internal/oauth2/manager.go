@@ -25,17 +25,17 @@ func (m *Manager) AddProvider(name string, provider Provider) {
 	m.providers[name] = provider
 }
 
-func NewManager(ctx context.Context, clientID, clientSecret, redirectURL, oidcDiscoveryEndpoint string) *Manager {
+func NewManager(ctx context.Context, clientID, clientSecret, redirectURL, oidcDiscoveryEndpoint, oidcGroupsClaim string) *Manager {
 	m := &Manager{providers: make(map[string]Provider)}
 	m.AddProvider("google", NewGoogleProvider(clientID, clientSecret, redirectURL))
 
 	if oidcDiscoveryEndpoint != "" {
-		if genericOidcProvider, err := NewOidcProvider(ctx, clientID, clientSecret, redirectURL, oidcDiscoveryEndpoint); err != nil {
+		if genericOidcProvider, err := NewOidcProvider(ctx, DefaultOIDCProvider, clientID, clientSecret, redirectURL, oidcDiscoveryEndpoint, oidcGroupsClaim); err != nil {
 			slog.Error("Failed to initialize OIDC provider",
 				slog.Any("error", err),
 			)
 		} else {
-			m.AddProvider("oidc", genericOidcProvider)
+			m.AddProvider(DefaultOIDCProvider, genericOidcProvider)
 		}
 	}
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"miniflux.app/v2/internal/model"
 
@@ -18,23 +19,30 @@ var (
 	ErrEmptyUsername = errors.New("oidc: username is empty")
 )
 
+// DefaultOIDCProvider is the name of the provider configured with the OAUTH2_* options.
+const DefaultOIDCProvider = "oidc"
+
 type oidcProvider struct {
+	name         string
 	clientID     string
 	clientSecret string
 	redirectURL  string
+	groupsClaim  string
 	provider     *oidc.Provider
 }
 
-func NewOidcProvider(ctx context.Context, clientID, clientSecret, redirectURL, discoveryEndpoint string) (*oidcProvider, error) {
+func NewOidcProvider(ctx context.Context, name, clientID, clientSecret, redirectURL, discoveryEndpoint, groupsClaim string) (*oidcProvider, error) {
 	provider, err := oidc.NewProvider(ctx, discoveryEndpoint)
 	if err != nil {
 		return nil, fmt.Errorf(`oidc: failed to initialize provider %q: %w`, discoveryEndpoint, err)
 	}
 
 	return &oidcProvider{
+		name:         name,
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		redirectURL:  redirectURL,
+		groupsClaim:  groupsClaim,
 		provider:     provider,
 	}, nil
 }
@@ -67,7 +75,7 @@ func (o *oidcProvider) GetProfile(ctx context.Context, code, codeVerifier string
 
 	profile := &Profile{
 		Key: o.GetUserExtraKey(),
-		ID:  userInfo.Subject,
+		ID:  OIDCProfileID(o.name, userInfo.Subject),
 	}
 
 	var userClaims userClaims
@@ -86,6 +94,14 @@ func (o *oidcProvider) GetProfile(ctx context.Context, code, codeVerifier string
 		return nil, ErrEmptyUsername
 	}
 
+	if o.groupsClaim != "" {
+		var claims map[string]any
+		if err := userInfo.Claims(&claims); err != nil {
+			return nil, fmt.Errorf(`oidc: failed to parse user claims: %w`, err)
+		}
+		profile.Groups = claimValues(claims, o.groupsClaim)
+	}
+
 	return profile, nil
 }
 
@@ -101,9 +117,67 @@ func (o *oidcProvider) UnsetUserProfileID(user *model.User) {
 	user.OpenIDConnectID = ""
 }
 
+// OIDCProfileID returns the identifier stored for the subject, it is prefixed by the name of the provider.
+func OIDCProfileID(provider, subject string) string {
+	return provider + ":" + subject
+}
+
+// LegacyOIDCProfileID returns the identifier stored for a subject of the default provider before the subjects were prefixed.
+// Only the subjects containing a colon were not prefixed by the database migration.
+func LegacyOIDCProfileID(profileID string) (string, bool) {
+	subject, found := strings.CutPrefix(profileID, DefaultOIDCProvider+":")
+	if !found || !strings.Contains(subject, ":") || strings.HasPrefix(subject, DefaultOIDCProvider+":") {
+		return "", false
+	}
+	return subject, true
+}
+
+// LinkedOIDCProvider returns the provider of the OpenID Connect identifier among the additional providers, or the default one.
+// The identifiers without the prefix of an additional provider were stored for the default provider.
+func LinkedOIDCProvider(openIDConnectID string, providers []string) string {
+	if openIDConnectID == "" {
+		return ""
+	}
+
+	for _, provider := range providers {
+		if strings.HasPrefix(openIDConnectID, provider+":") {
+			return provider
+		}
+	}
+
+	return DefaultOIDCProvider
+}
+
 type userClaims struct {
 	Email             string `json:"email"`
 	Profile           string `json:"profile"`
 	Name              string `json:"name"`
 	PreferredUsername string `json:"preferred_username"`
 }
+
+// claimValues returns the values of a string or list claim, nested claims are separated by dots.
+func claimValues(claims map[string]any, name string) []string {
+	var value any = claims
+	for _, key := range strings.Split(name, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		var values []string
+		for _, item := range value {
+			if item, ok := item.(string); ok {
+				values = append(values, item)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2 // import "miniflux.app/v2/internal/oauth2"
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestClaimValues(t *testing.T) {
+	var claims map[string]any
+	data := `{"groups": ["admins", "users", 42], "role": "editor", "realm_access": {"roles": ["ops"]}}`
+	if err := json.Unmarshal([]byte(data), &claims); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string][]string{
+		"groups":             {"admins", "users"},
+		"role":               {"editor"},
+		"realm_access.roles": {"ops"},
+		"realm_access.other": nil,
+		"role.nested":        nil,
+		"missing":            nil,
+	}
+
+	for name, expected := range scenarios {
+		if result := claimValues(claims, name); !slices.Equal(result, expected) {
+			t.Errorf(`Unexpected values for claim %q: got %v instead of %v`, name, result, expected)
+		}
+	}
+}
+
+func TestProfileIsMemberOf(t *testing.T) {
+	profile := Profile{Groups: []string{"users", "ops"}}
+
+	if !profile.IsMemberOf([]string{"admins", "ops"}) {
+		t.Error(`The user should be a member of the ops group`)
+	}
+
+	if profile.IsMemberOf([]string{"admins"}) || profile.IsMemberOf(nil) {
+		t.Error(`The user should not be a member of the admins group`)
+	}
+}
+
+func TestLinkedOIDCProvider(t *testing.T) {
+	providers := []string{"corp", "partner"}
+
+	scenarios := map[string]string{
+		"":                     "",
+		"subject":              DefaultOIDCProvider,
+		"corp:subject":         "corp",
+		"partner:corp:subject": "partner",
+		"other:subject":        DefaultOIDCProvider,
+		"oidc:corp:subject":    DefaultOIDCProvider,
+	}
+
+	for openIDConnectID, expected := range scenarios {
+		if result := LinkedOIDCProvider(openIDConnectID, providers); result != expected {
+			t.Errorf(`Unexpected provider for %q: got %q instead of %q`, openIDConnectID, result, expected)
+		}
+	}
+
+	if id := OIDCProfileID("corp", "subject"); LinkedOIDCProvider(id, providers) != "corp" {
+		t.Errorf(`Unexpected profile ID: got %q`, id)
+	}
+
+	if id := OIDCProfileID(DefaultOIDCProvider, "corp:subject"); LinkedOIDCProvider(id, providers) != DefaultOIDCProvider {
+		t.Errorf(`The profile ID of the default provider should be prefixed: got %q`, id)
+	}
+
+	if id, found := LegacyOIDCProfileID(OIDCProfileID(DefaultOIDCProvider, "urn:subject")); !found || id != "urn:subject" {
+		t.Errorf(`Unexpected legacy profile ID: got %q`, id)
+	}
+
+	if _, found := LegacyOIDCProfileID(OIDCProfileID(DefaultOIDCProvider, "subject")); found {
+		t.Error(`The subjects without colon were prefixed by the migration`)
+	}
+
+	if _, found := LegacyOIDCProfileID(OIDCProfileID(DefaultOIDCProvider, "oidc:subject")); found {
+		t.Error(`The legacy profile ID should not look like a prefixed one`)
+	}
+
+	if _, found := LegacyOIDCProfileID(OIDCProfileID("corp", "subject")); found {
+		t.Error(`The additional providers don't have legacy profile IDs`)
+	}
+}
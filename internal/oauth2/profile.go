@@ -5,6 +5,7 @@ package oauth2 // import "miniflux.app/v2/internal/oauth2"
 
 import (
 	"fmt"
+	"slices"
 )
 
 // Profile is the OAuth2 user profile.
@@ -12,6 +13,17 @@ type Profile struct {
 	Key      string
 	ID       string
 	Username string
+	Groups   []string
+}
+
+// IsMemberOf returns true if the user belongs to one of the groups.
+func (p Profile) IsMemberOf(groups []string) bool {
+	for _, group := range groups {
+		if slices.Contains(p.Groups, group) {
+			return true
+		}
+	}
+	return false
 }
 
 func (p Profile) String() string {
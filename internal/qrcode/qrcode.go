@@ -0,0 +1,502 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package qrcode implements a minimal QR Code encoder (byte mode, error correction level M, versions 1 to 10).
+package qrcode // import "miniflux.app/v2/internal/qrcode"
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDataTooLong is returned when the data does not fit in the largest supported version.
+var ErrDataTooLong = errors.New("qrcode: data too long")
+
+const quietZone = 4
+
+// versionInfo describes the error correction blocks of a version at level M.
+type versionInfo struct {
+	ecCodewords     int
+	group1Blocks    int
+	group1Codewords int
+	group2Blocks    int
+	group2Codewords int
+	alignments      []int
+}
+
+var versions = []versionInfo{
+	{},
+	{10, 1, 16, 0, 0, nil},
+	{16, 1, 28, 0, 0, []int{6, 18}},
+	{26, 1, 44, 0, 0, []int{6, 22}},
+	{18, 2, 32, 0, 0, []int{6, 26}},
+	{24, 2, 43, 0, 0, []int{6, 30}},
+	{16, 4, 27, 0, 0, []int{6, 34}},
+	{18, 4, 31, 0, 0, []int{6, 22, 38}},
+	{22, 2, 38, 2, 39, []int{6, 24, 42}},
+	{22, 3, 36, 2, 37, []int{6, 26, 46}},
+	{26, 4, 43, 1, 44, []int{6, 28, 50}},
+}
+
+func (v versionInfo) dataCodewords() int {
+	return v.group1Blocks*v.group1Codewords + v.group2Blocks*v.group2Codewords
+}
+
+// Code is a QR Code symbol, modules are true when dark.
+type Code struct {
+	Size    int
+	modules [][]bool
+}
+
+// Dark returns true if the module at the given row and column is dark.
+func (c *Code) Dark(row, col int) bool {
+	return c.modules[row][col]
+}
+
+// Encode returns the QR Code of the given data.
+func Encode(data []byte) (*Code, error) {
+	version := 0
+	for v := 1; v < len(versions); v++ {
+		if 4+countBits(v)+8*len(data) <= versions[v].dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+
+	if version == 0 {
+		return nil, ErrDataTooLong
+	}
+
+	codewords := addErrorCorrection(versions[version], encodeData(version, data))
+
+	s := newSymbol(version)
+	s.drawFunctionPatterns()
+	s.drawCodewords(codewords)
+
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		s.applyMask(mask)
+		s.drawFormatBits(mask)
+		if penalty := s.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		s.applyMask(mask)
+	}
+
+	s.applyMask(bestMask)
+	s.drawFormatBits(bestMask)
+
+	return &Code{Size: s.size, modules: s.modules}, nil
+}
+
+// SVG returns the QR Code as an SVG document.
+func (c *Code) SVG() string {
+	var path strings.Builder
+	for row := 0; row < c.Size; row++ {
+		for col := 0; col < c.Size; col++ {
+			if c.modules[row][col] {
+				fmt.Fprintf(&path, "M%d,%dh1v1h-1z", col+quietZone, row+quietZone)
+			}
+		}
+	}
+
+	dimension := c.Size + 2*quietZone
+	return fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges"><rect width="100%%" height="100%%" fill="#fff"/><path d="%s" fill="#000"/></svg>`,
+		dimension, dimension, path.String(),
+	)
+}
+
+// DataURL returns the QR Code as an SVG image embedded in a data URL.
+func (c *Code) DataURL() string {
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(c.SVG()))
+}
+
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+type bitBuffer struct {
+	bits []bool
+}
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		b.bits = append(b.bits, (value>>i)&1 == 1)
+	}
+}
+
+func encodeData(version int, data []byte) []byte {
+	capacity := versions[version].dataCodewords() * 8
+
+	buffer := &bitBuffer{}
+	buffer.append(0b0100, 4)
+	buffer.append(len(data), countBits(version))
+	for _, b := range data {
+		buffer.append(int(b), 8)
+	}
+
+	buffer.append(0, min(4, capacity-len(buffer.bits)))
+	buffer.append(0, (8-len(buffer.bits)%8)%8)
+
+	codewords := make([]byte, 0, capacity/8)
+	for i := 0; i < len(buffer.bits); i += 8 {
+		var codeword byte
+		for j := 0; j < 8; j++ {
+			if buffer.bits[i+j] {
+				codeword |= 1 << (7 - j)
+			}
+		}
+		codewords = append(codewords, codeword)
+	}
+
+	for pad := byte(0xEC); len(codewords) < capacity/8; pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+
+	return codewords
+}
+
+// addErrorCorrection splits the data in blocks and interleaves them with their error correction codewords.
+func addErrorCorrection(info versionInfo, data []byte) []byte {
+	var blocks [][]byte
+	offset := 0
+	for i := 0; i < info.group1Blocks+info.group2Blocks; i++ {
+		length := info.group1Codewords
+		if i >= info.group1Blocks {
+			length = info.group2Codewords
+		}
+		blocks = append(blocks, data[offset:offset+length])
+		offset += length
+	}
+
+	generator := generatorPolynomial(info.ecCodewords)
+	ecBlocks := make([][]byte, len(blocks))
+	for i, block := range blocks {
+		ecBlocks[i] = reedSolomonRemainder(block, generator)
+	}
+
+	var result []byte
+	for i := 0; i < max(info.group1Codewords, info.group2Codewords); i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+
+	for i := 0; i < info.ecCodewords; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+
+	return result
+}
+
+// gfMultiply multiplies two elements of GF(256) with the polynomial x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	var result byte
+	for i := 7; i >= 0; i-- {
+		carry := result >> 7
+		result = result<<1 ^ carry*0x1D
+		result ^= (y >> i & 1) * x
+	}
+	return result
+}
+
+// generatorPolynomial returns the coefficients of the generator polynomial, highest degree first, without the leading 1.
+func generatorPolynomial(degree int) []byte {
+	coefficients := make([]byte, degree)
+	coefficients[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			coefficients[j] = gfMultiply(coefficients[j], root)
+			if j+1 < degree {
+				coefficients[j] ^= coefficients[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+
+	return coefficients
+}
+
+func reedSolomonRemainder(data, generator []byte) []byte {
+	remainder := make([]byte, len(generator))
+	for _, b := range data {
+		factor := b ^ remainder[0]
+		copy(remainder, remainder[1:])
+		remainder[len(remainder)-1] = 0
+		for i := range remainder {
+			remainder[i] ^= gfMultiply(generator[i], factor)
+		}
+	}
+	return remainder
+}
+
+type symbol struct {
+	version    int
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+func newSymbol(version int) *symbol {
+	size := 17 + 4*version
+	s := &symbol{version: version, size: size}
+	s.modules = make([][]bool, size)
+	s.isFunction = make([][]bool, size)
+	for i := range s.modules {
+		s.modules[i] = make([]bool, size)
+		s.isFunction[i] = make([]bool, size)
+	}
+	return s
+}
+
+func (s *symbol) setFunction(row, col int, dark bool) {
+	s.modules[row][col] = dark
+	s.isFunction[row][col] = true
+}
+
+func (s *symbol) drawFunctionPatterns() {
+	for i := 0; i < s.size; i++ {
+		s.setFunction(6, i, i%2 == 0)
+		s.setFunction(i, 6, i%2 == 0)
+	}
+
+	s.drawFinderPattern(3, 3)
+	s.drawFinderPattern(3, s.size-4)
+	s.drawFinderPattern(s.size-4, 3)
+
+	alignments := versions[s.version].alignments
+	last := len(alignments) - 1
+	for i, row := range alignments {
+		for j, col := range alignments {
+			// Skip the three corners occupied by the finder patterns.
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			s.drawAlignmentPattern(row, col)
+		}
+	}
+
+	// Reserve the format areas, the actual bits are drawn after masking.
+	s.drawFormatBits(0)
+	s.drawVersion()
+}
+
+func (s *symbol) drawFinderPattern(centerRow, centerCol int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			row, col := centerRow+dy, centerCol+dx
+			if row < 0 || row >= s.size || col < 0 || col >= s.size {
+				continue
+			}
+			distance := max(abs(dx), abs(dy))
+			s.setFunction(row, col, distance != 2 && distance != 4)
+		}
+	}
+}
+
+func (s *symbol) drawAlignmentPattern(centerRow, centerCol int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			s.setFunction(centerRow+dy, centerCol+dx, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+func (s *symbol) drawFormatBits(mask int) {
+	// Error correction level M is encoded as 00.
+	data := mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = remainder<<1 ^ (remainder>>9)*0x537
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>i)&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		s.setFunction(i, 8, bit(i))
+	}
+	s.setFunction(7, 8, bit(6))
+	s.setFunction(8, 8, bit(7))
+	s.setFunction(8, 7, bit(8))
+	for i := 9; i < 15; i++ {
+		s.setFunction(8, 14-i, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		s.setFunction(8, s.size-1-i, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		s.setFunction(s.size-15+i, 8, bit(i))
+	}
+
+	s.setFunction(s.size-8, 8, true)
+}
+
+func (s *symbol) drawVersion() {
+	if s.version < 7 {
+		return
+	}
+
+	remainder := s.version
+	for i := 0; i < 12; i++ {
+		remainder = remainder<<1 ^ (remainder>>11)*0x1F25
+	}
+	bits := s.version<<12 | remainder
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 == 1
+		a := s.size - 11 + i%3
+		b := i / 3
+		s.setFunction(b, a, dark)
+		s.setFunction(a, b, dark)
+	}
+}
+
+// drawCodewords places the codewords in the two-module wide columns, zigzagging from the bottom right corner.
+func (s *symbol) drawCodewords(codewords []byte) {
+	i := 0
+	for right := s.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		for vertical := 0; vertical < s.size; vertical++ {
+			for j := 0; j < 2; j++ {
+				col := right - j
+				row := vertical
+				if (right+1)&2 == 0 {
+					row = s.size - 1 - vertical
+				}
+
+				if !s.isFunction[row][col] && i < len(codewords)*8 {
+					s.modules[row][col] = (codewords[i>>3]>>(7-i&7))&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask toggles the data modules, applying the same mask twice restores the symbol.
+func (s *symbol) applyMask(mask int) {
+	for row := 0; row < s.size; row++ {
+		for col := 0; col < s.size; col++ {
+			if s.isFunction[row][col] {
+				continue
+			}
+
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (col+row)%2 == 0
+			case 1:
+				invert = row%2 == 0
+			case 2:
+				invert = col%3 == 0
+			case 3:
+				invert = (col+row)%3 == 0
+			case 4:
+				invert = (col/3+row/2)%2 == 0
+			case 5:
+				invert = col*row%2+col*row%3 == 0
+			case 6:
+				invert = (col*row%2+col*row%3)%2 == 0
+			case 7:
+				invert = ((col+row)%2+col*row%3)%2 == 0
+			}
+
+			if invert {
+				s.modules[row][col] = !s.modules[row][col]
+			}
+		}
+	}
+}
+
+// penalty scores the readability of the symbol, lower is better.
+func (s *symbol) penalty() int {
+	result := 0
+	dark := 0
+
+	for i := 0; i < s.size; i++ {
+		result += linePenalty(func(j int) bool { return s.modules[i][j] }, s.size)
+		result += linePenalty(func(j int) bool { return s.modules[j][i] }, s.size)
+	}
+
+	for row := 0; row < s.size; row++ {
+		for col := 0; col < s.size; col++ {
+			if s.modules[row][col] {
+				dark++
+			}
+
+			if row+1 < s.size && col+1 < s.size {
+				color := s.modules[row][col]
+				if color == s.modules[row][col+1] && color == s.modules[row+1][col] && color == s.modules[row+1][col+1] {
+					result += 3
+				}
+			}
+		}
+	}
+
+	total := s.size * s.size
+	result += abs(dark*20-total*10) / total * 10
+
+	return result
+}
+
+var finderLikePatterns = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+func linePenalty(module func(int) bool, size int) int {
+	result := 0
+
+	runLength := 1
+	for i := 1; i <= size; i++ {
+		if i < size && module(i) == module(i-1) {
+			runLength++
+			continue
+		}
+		if runLength >= 5 {
+			result += 3 + runLength - 5
+		}
+		runLength = 1
+	}
+
+	for i := 0; i+11 <= size; i++ {
+		for _, pattern := range finderLikePatterns {
+			matches := true
+			for j, dark := range pattern {
+				if module(i+j) != dark {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				result += 40
+			}
+		}
+	}
+
+	return result
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
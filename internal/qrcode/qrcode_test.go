@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package qrcode // import "miniflux.app/v2/internal/qrcode"
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// Data codewords of "HELLO WORLD" encoded as 1-M.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expected := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if result := reedSolomonRemainder(data, generatorPolynomial(10)); !bytes.Equal(result, expected) {
+		t.Errorf(`Unexpected error correction codewords, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestEncodeSelectsSmallestVersion(t *testing.T) {
+	scenarios := map[int]int{
+		1:   21,
+		14:  21,
+		15:  25,
+		106: 41,
+		107: 45,
+		213: 57,
+	}
+
+	for length, size := range scenarios {
+		code, err := Encode(bytes.Repeat([]byte("a"), length))
+		if err != nil {
+			t.Fatalf(`Unable to encode %d bytes: %v`, length, err)
+		}
+
+		if code.Size != size {
+			t.Errorf(`Unexpected size for %d bytes, got %d instead of %d`, length, code.Size, size)
+		}
+	}
+}
+
+func TestEncodeDataTooLong(t *testing.T) {
+	if _, err := Encode(bytes.Repeat([]byte("a"), 214)); err != ErrDataTooLong {
+		t.Errorf(`Expected ErrDataTooLong, got %v`, err)
+	}
+}
+
+func TestEncodeFunctionPatterns(t *testing.T) {
+	code, err := Encode([]byte("otpauth://totp/Miniflux:admin?secret=JBSWY3DPEHPK3PXP&issuer=Miniflux"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Finder patterns have a dark center and a light separator.
+	for _, corner := range [][2]int{{3, 3}, {3, code.Size - 4}, {code.Size - 4, 3}} {
+		if !code.Dark(corner[0], corner[1]) {
+			t.Errorf(`The finder pattern center at %v should be dark`, corner)
+		}
+	}
+
+	if code.Dark(7, 7) || code.Dark(7, code.Size-8) || code.Dark(code.Size-8, 7) {
+		t.Error(`The finder pattern separators should be light`)
+	}
+
+	// The dark module is always set.
+	if !code.Dark(code.Size-8, 8) {
+		t.Error(`The dark module should be set`)
+	}
+
+	// Both copies of the format information must be identical.
+	var first, second int
+	for i := 0; i <= 5; i++ {
+		first = first<<1 | boolToInt(code.Dark(i, 8))
+	}
+	first = first<<1 | boolToInt(code.Dark(7, 8))
+	first = first<<1 | boolToInt(code.Dark(8, 8))
+	first = first<<1 | boolToInt(code.Dark(8, 7))
+	for i := 9; i < 15; i++ {
+		first = first<<1 | boolToInt(code.Dark(8, 14-i))
+	}
+
+	for i := 0; i < 8; i++ {
+		second = second<<1 | boolToInt(code.Dark(8, code.Size-1-i))
+	}
+	for i := 8; i < 15; i++ {
+		second = second<<1 | boolToInt(code.Dark(code.Size-15+i, 8))
+	}
+
+	if first != second {
+		t.Errorf(`The format information copies differ: %015b and %015b`, first, second)
+	}
+}
+
+func TestFormatBitsForMaskZero(t *testing.T) {
+	s := newSymbol(1)
+	s.drawFormatBits(0)
+
+	var bits strings.Builder
+	for i := 0; i <= 5; i++ {
+		bits.WriteByte(byte('0' + boolToInt(s.modules[i][8])))
+	}
+	bits.WriteByte(byte('0' + boolToInt(s.modules[7][8])))
+	bits.WriteByte(byte('0' + boolToInt(s.modules[8][8])))
+	bits.WriteByte(byte('0' + boolToInt(s.modules[8][7])))
+	for i := 9; i < 15; i++ {
+		bits.WriteByte(byte('0' + boolToInt(s.modules[8][14-i])))
+	}
+
+	// The bits are read from the least significant one.
+	if result := reverse(bits.String()); result != "101010000010010" {
+		t.Errorf(`Unexpected format information, got %s`, result)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	code, err := Encode([]byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svg := code.SVG()
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `viewBox="0 0 29 29"`) {
+		t.Errorf(`Unexpected SVG document: %s`, svg)
+	}
+
+	if !strings.HasPrefix(code.DataURL(), "data:image/svg+xml;base64,") {
+		t.Errorf(`Unexpected data URL: %s`, code.DataURL())
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package activitypub reads the outbox of the Fediverse accounts, for example Mastodon or Pleroma accounts.
+// See https://www.w3.org/TR/activitystreams-core/ and https://www.w3.org/TR/activitypub/#outbox.
+package activitypub // import "miniflux.app/v2/internal/reader/activitypub"
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// MimeType is the media type of the ActivityPub documents.
+const MimeType = "application/activity+json"
+
+// Namespace is the JSON-LD context of the ActivityStreams documents.
+const Namespace = "https://www.w3.org/ns/activitystreams"
+
+// Actor is the profile of an account, it links to the outbox listing the activities of the account.
+type Actor struct {
+	ID                string `json:"id"`
+	Type              string `json:"type"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferredUsername"`
+	Outbox            string `json:"outbox"`
+	URL               Links  `json:"url"`
+}
+
+// Collection is an OrderedCollection or an OrderedCollectionPage.
+// The first page of the collection is either embedded or referenced by its URL.
+type Collection struct {
+	ID           string     `json:"id"`
+	Type         string     `json:"type"`
+	First        Reference  `json:"first"`
+	OrderedItems []Activity `json:"orderedItems"`
+	Items        []Activity `json:"items"`
+}
+
+// Activity is an entry of the outbox, usually the creation of a Note.
+// The objects of the boosts ("Announce" activities) are only referenced by their URL.
+type Activity struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Actor     Reference `json:"actor"`
+	Object    Reference `json:"object"`
+	Published string    `json:"published"`
+}
+
+// Object is the content of an activity, for example a Note or an Article, or one of its attachments.
+type Object struct {
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	Name         string            `json:"name"`
+	Summary      string            `json:"summary"`
+	Content      string            `json:"content"`
+	ContentMap   map[string]string `json:"contentMap"`
+	MediaType    string            `json:"mediaType"`
+	Published    string            `json:"published"`
+	Updated      string            `json:"updated"`
+	AttributedTo Reference         `json:"attributedTo"`
+	URL          Links             `json:"url"`
+	Attachment   []Object          `json:"attachment"`
+	Tag          []Object          `json:"tag"`
+}
+
+// Reference is a property holding either the URL of an object or the object itself.
+type Reference struct {
+	ID         string
+	Object     *Object
+	Collection *Collection
+}
+
+func (r *Reference) UnmarshalJSON(data []byte) error {
+	switch {
+	case len(data) > 0 && data[0] == '"':
+		return json.Unmarshal(data, &r.ID)
+	case len(data) > 0 && data[0] == '[':
+		// Only the first value of the multiple references is used.
+		var values []Reference
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		if len(values) > 0 {
+			*r = values[0]
+		}
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		var object Object
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		r.ID = object.ID
+		r.Object = &object
+
+		if strings.Contains(object.Type, "Collection") {
+			var collection Collection
+			if err := json.Unmarshal(data, &collection); err != nil {
+				return err
+			}
+			r.Collection = &collection
+		}
+	}
+	return nil
+}
+
+// Links is the "url" property, a single URL, a Link object or a list of them.
+type Links []Link
+
+// Link is a URL and its media type, the media type is usually missing.
+type Link struct {
+	Href      string `json:"href"`
+	MediaType string `json:"mediaType"`
+}
+
+func (l *Links) UnmarshalJSON(data []byte) error {
+	switch {
+	case len(data) > 0 && data[0] == '"':
+		var href string
+		if err := json.Unmarshal(data, &href); err != nil {
+			return err
+		}
+		*l = Links{{Href: href}}
+	case len(data) > 0 && data[0] == '[':
+		var values []Links
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		for _, value := range values {
+			*l = append(*l, value...)
+		}
+	case len(data) > 0 && data[0] == '{':
+		var link Link
+		if err := json.Unmarshal(data, &link); err != nil {
+			return err
+		}
+		*l = Links{link}
+	}
+	return nil
+}
+
+// PageURL returns the URL of the web page, the first link without media type or with an HTML media type.
+func (l Links) PageURL() string {
+	for _, link := range l {
+		if link.MediaType == "" || link.MediaType == "text/html" {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	return ""
+}
+
+// FirstURL returns the first URL of the list.
+func (l Links) FirstURL() string {
+	for _, link := range l {
+		if href := strings.TrimSpace(link.Href); href != "" {
+			return href
+		}
+	}
+	return ""
+}
+
+// WebFinger is the response of the WebFinger endpoint, it links the handle of an account to its actor.
+// See https://www.rfc-editor.org/rfc/rfc7033.
+type WebFinger struct {
+	Subject string          `json:"subject"`
+	Links   []WebFingerLink `json:"links"`
+}
+
+// WebFingerLink is a link of the WebFinger response.
+type WebFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type"`
+	Href string `json:"href"`
+}
+
+// ActorURL returns the URL of the ActivityPub actor of the account.
+func (w *WebFinger) ActorURL() string {
+	for _, link := range w.Links {
+		if link.Rel == "self" && (link.Type == MimeType || strings.HasPrefix(link.Type, "application/ld+json")) {
+			return link.Href
+		}
+	}
+	return ""
+}
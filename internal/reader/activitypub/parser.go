@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activitypub // import "miniflux.app/v2/internal/reader/activitypub"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/urllib"
+)
+
+var handleRegex = regexp.MustCompile(`^@?([\w.-]+)@([\w-]+(?:\.[\w-]+)+)$`)
+
+// Parse returns a normalized feed struct from a page of the outbox of an account.
+// Only the public posts of the account are converted to entries, the boosts are ignored since their content is not embedded.
+func Parse(baseURL string, data io.Reader) (*model.Feed, error) {
+	collection := new(Collection)
+	if err := json.NewDecoder(data).Decode(collection); err != nil {
+		return nil, fmt.Errorf("activitypub: unable to parse outbox: %w", err)
+	}
+
+	activities := append(collection.OrderedItems, collection.Items...)
+	if len(activities) == 0 && collection.First.Collection != nil {
+		activities = append(collection.First.Collection.OrderedItems, collection.First.Collection.Items...)
+	}
+
+	feed := &model.Feed{
+		FeedURL: baseURL,
+		SiteURL: baseURL,
+	}
+
+	for _, activity := range activities {
+		if activity.Type != "Create" || activity.Object.Object == nil {
+			continue
+		}
+
+		// The account is only referenced by the activities.
+		if feed.Title == "" && activity.Actor.ID != "" {
+			feed.SiteURL = activity.Actor.ID
+			feed.Title = Handle(activity.Actor.ID)
+		}
+
+		feed.Entries = append(feed.Entries, newEntry(feed.SiteURL, &activity))
+	}
+
+	// Fallback to the feed URL if the outbox is empty.
+	if feed.Title == "" {
+		feed.Title = feed.FeedURL
+	}
+
+	return feed, nil
+}
+
+func newEntry(siteURL string, activity *Activity) *model.Entry {
+	object := activity.Object.Object
+
+	entry := model.NewEntry()
+	for _, value := range []string{object.URL.PageURL(), object.ID, activity.ID} {
+		if value != "" {
+			if absoluteURL, err := urllib.AbsoluteURL(siteURL, value); err == nil {
+				entry.URL = absoluteURL
+				break
+			}
+		}
+	}
+
+	// Populate the entry content, the posts translated in several languages have a map of contents.
+	entry.Content = strings.TrimSpace(object.Content)
+	if len(object.ContentMap) == 1 {
+		for language, content := range object.ContentMap {
+			entry.Language = language
+			if entry.Content == "" {
+				entry.Content = strings.TrimSpace(content)
+			}
+		}
+	}
+
+	// The posts don't have any title, except the articles. The summary of the posts is the content warning.
+	for _, value := range []string{object.Name, object.Summary, sanitizer.TruncateHTML(entry.Content, 100)} {
+		if value = strings.TrimSpace(value); value != "" {
+			entry.Title = value
+			break
+		}
+	}
+
+	// Fallback to the entry URL if the title is empty.
+	if entry.Title == "" {
+		entry.Title = entry.URL
+	}
+
+	// Populate the entry date.
+	for _, value := range []string{object.Published, activity.Published, object.Updated} {
+		if value == "" {
+			continue
+		}
+
+		if entryDate, err := date.Parse(value); err != nil {
+			slog.Debug("Unable to parse date from ActivityPub object",
+				slog.String("date", value),
+				slog.String("url", entry.URL),
+				slog.Any("error", err),
+			)
+		} else {
+			entry.Date = entryDate
+			break
+		}
+	}
+	if entry.Date.IsZero() {
+		entry.Date = time.Now()
+	}
+
+	// Populate the entry author.
+	for _, value := range []string{object.AttributedTo.ID, activity.Actor.ID} {
+		if value != "" {
+			entry.Author = Handle(value)
+			break
+		}
+	}
+
+	// Populate the entry enclosures with the media attachments.
+	for _, attachment := range object.Attachment {
+		attachmentURL := attachment.URL.FirstURL()
+		if attachmentURL == "" {
+			continue
+		}
+
+		if absoluteURL, err := urllib.AbsoluteURL(siteURL, attachmentURL); err == nil {
+			entry.Enclosures = append(entry.Enclosures, &model.Enclosure{
+				URL:      absoluteURL,
+				MimeType: attachment.MediaType,
+			})
+		}
+	}
+
+	// Populate the entry tags with the hashtags.
+	for _, tag := range object.Tag {
+		if tag.Type == "Hashtag" {
+			if name := strings.TrimPrefix(strings.TrimSpace(tag.Name), "#"); name != "" {
+				entry.Tags = append(entry.Tags, name)
+			}
+		}
+	}
+
+	// Generate a hash for the entry.
+	for _, value := range []string{object.ID, activity.ID, entry.URL} {
+		if value != "" {
+			entry.Hash = crypto.Hash(value)
+			break
+		}
+	}
+
+	return entry
+}
+
+// Handle returns the Fediverse handle of the account from the URL of its actor, for example "@user@mastodon.social".
+// The name is the last segment of the URL, which is the username for most of the servers.
+func Handle(actorURL string) string {
+	parsedURL, err := url.Parse(actorURL)
+	if err != nil || parsedURL.Host == "" {
+		return actorURL
+	}
+
+	name := strings.TrimPrefix(path.Base(strings.TrimSuffix(parsedURL.Path, "/")), "@")
+	if name == "" || name == "." || name == "/" {
+		return parsedURL.Host
+	}
+
+	return "@" + name + "@" + parsedURL.Host
+}
+
+// ProfileURL converts a Fediverse handle, for example "@user@mastodon.social", to the URL of the profile of the account.
+// Any other value is returned unchanged.
+func ProfileURL(value string) string {
+	value = strings.TrimSpace(value)
+	if matches := handleRegex.FindStringSubmatch(value); matches != nil {
+		return "https://" + strings.ToLower(matches[2]) + "/@" + matches[1]
+	}
+	return value
+}
@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activitypub // import "miniflux.app/v2/internal/reader/activitypub"
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+)
+
+func TestParseOutboxPage(t *testing.T) {
+	data := `{
+		"@context": ["https://www.w3.org/ns/activitystreams"],
+		"id": "https://mastodon.example/users/alice/outbox?page=true",
+		"type": "OrderedCollectionPage",
+		"orderedItems": [
+			{
+				"id": "https://mastodon.example/users/alice/statuses/2/activity",
+				"type": "Create",
+				"actor": "https://mastodon.example/users/alice",
+				"published": "2026-03-02T10:00:00Z",
+				"object": {
+					"id": "https://mastodon.example/users/alice/statuses/2",
+					"type": "Note",
+					"summary": "Spoilers",
+					"url": "https://mastodon.example/@alice/2",
+					"published": "2026-03-02T10:00:00Z",
+					"attributedTo": "https://mastodon.example/users/alice",
+					"content": "<p>The end of the <a href=\"https://mastodon.example/tags/movie\">#movie</a></p>",
+					"contentMap": {"en": "<p>The end of the movie</p>"},
+					"attachment": [
+						{"type": "Document", "mediaType": "image/png", "url": "https://files.mastodon.example/1.png", "name": "A picture"},
+						{"type": "Document", "mediaType": "video/mp4", "url": [{"type": "Link", "href": "/files/2.mp4"}]}
+					],
+					"tag": [
+						{"type": "Mention", "href": "https://mastodon.example/users/bob", "name": "@bob"},
+						{"type": "Hashtag", "href": "https://mastodon.example/tags/movie", "name": "#movie"}
+					]
+				}
+			},
+			{
+				"id": "https://mastodon.example/users/alice/statuses/1/activity",
+				"type": "Announce",
+				"actor": "https://mastodon.example/users/alice",
+				"published": "2026-03-01T10:00:00Z",
+				"object": "https://other.example/notes/1"
+			}
+		]
+	}`
+
+	feed, err := Parse("https://mastodon.example/users/alice/outbox?page=true", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "@alice@mastodon.example" {
+		t.Errorf(`Incorrect title, got: %q`, feed.Title)
+	}
+
+	if feed.SiteURL != "https://mastodon.example/users/alice" {
+		t.Errorf(`Incorrect site URL, got: %q`, feed.SiteURL)
+	}
+
+	if feed.FeedURL != "https://mastodon.example/users/alice/outbox?page=true" {
+		t.Errorf(`Incorrect feed URL, got: %q`, feed.FeedURL)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf(`The boosts should be ignored, got %d entries`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "Spoilers" {
+		t.Errorf(`Incorrect entry title, got: %q`, entry.Title)
+	}
+
+	if entry.URL != "https://mastodon.example/@alice/2" {
+		t.Errorf(`Incorrect entry URL, got: %q`, entry.URL)
+	}
+
+	if entry.Hash != crypto.Hash("https://mastodon.example/users/alice/statuses/2") {
+		t.Errorf(`Incorrect entry hash, got: %q`, entry.Hash)
+	}
+
+	if entry.Author != "@alice@mastodon.example" {
+		t.Errorf(`Incorrect entry author, got: %q`, entry.Author)
+	}
+
+	if entry.Language != "en" {
+		t.Errorf(`Incorrect entry language, got: %q`, entry.Language)
+	}
+
+	if entry.Content != `<p>The end of the <a href="https://mastodon.example/tags/movie">#movie</a></p>` {
+		t.Errorf(`Incorrect entry content, got: %q`, entry.Content)
+	}
+
+	if !entry.Date.Equal(time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Incorrect entry date, got: %v`, entry.Date)
+	}
+
+	if len(entry.Tags) != 1 || entry.Tags[0] != "movie" {
+		t.Errorf(`Incorrect entry tags, got: %v`, entry.Tags)
+	}
+
+	if len(entry.Enclosures) != 2 {
+		t.Fatalf(`Incorrect number of enclosures, got: %d`, len(entry.Enclosures))
+	}
+
+	if entry.Enclosures[0].URL != "https://files.mastodon.example/1.png" || entry.Enclosures[0].MimeType != "image/png" {
+		t.Errorf(`Incorrect first enclosure, got: %v`, entry.Enclosures[0])
+	}
+
+	if entry.Enclosures[1].URL != "https://mastodon.example/files/2.mp4" || entry.Enclosures[1].MimeType != "video/mp4" {
+		t.Errorf(`Incorrect second enclosure, got: %v`, entry.Enclosures[1])
+	}
+}
+
+func TestParseOutboxWithEmbeddedFirstPage(t *testing.T) {
+	data := `{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://pleroma.example/users/bob/outbox",
+		"type": "OrderedCollection",
+		"first": {
+			"id": "https://pleroma.example/users/bob/outbox?page=true",
+			"type": "OrderedCollectionPage",
+			"orderedItems": [
+				{
+					"type": "Create",
+					"actor": "https://pleroma.example/users/bob",
+					"object": {
+						"id": "https://pleroma.example/objects/1",
+						"type": "Article",
+						"name": "A long post",
+						"content": "<p>Hello</p>"
+					}
+				}
+			]
+		}
+	}`
+
+	feed, err := Parse("https://pleroma.example/users/bob/outbox", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "A long post" {
+		t.Errorf(`Incorrect entry title, got: %q`, entry.Title)
+	}
+
+	// The object ID is the fallback URL.
+	if entry.URL != "https://pleroma.example/objects/1" {
+		t.Errorf(`Incorrect entry URL, got: %q`, entry.URL)
+	}
+
+	if entry.Date.IsZero() {
+		t.Error(`The entry without date should have the current date`)
+	}
+}
+
+func TestParseNoteWithoutSummary(t *testing.T) {
+	data := `{
+		"type": "OrderedCollectionPage",
+		"orderedItems": [
+			{
+				"type": "Create",
+				"actor": "https://mastodon.example/users/alice",
+				"object": {"id": "https://mastodon.example/users/alice/statuses/3", "type": "Note", "content": "<p>Just <b>a</b> note</p>"}
+			}
+		]
+	}`
+
+	feed, err := Parse("https://mastodon.example/users/alice/outbox?page=true", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	if feed.Entries[0].Title != "Just a note" {
+		t.Errorf(`Incorrect entry title, got: %q`, feed.Entries[0].Title)
+	}
+}
+
+func TestParseEmptyOutbox(t *testing.T) {
+	feed, err := Parse("https://mastodon.example/users/alice/outbox?page=true", bytes.NewBufferString(`{"type": "OrderedCollectionPage", "orderedItems": []}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "https://mastodon.example/users/alice/outbox?page=true" {
+		t.Errorf(`Incorrect title, got: %q`, feed.Title)
+	}
+}
+
+func TestParseInvalidOutbox(t *testing.T) {
+	if _, err := Parse("https://mastodon.example/outbox", bytes.NewBufferString(`{"orderedItems": "invalid"}`)); err == nil {
+		t.Error(`Parsing an invalid outbox should return an error`)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	scenarios := map[string]string{
+		"https://mastodon.example/users/alice": "@alice@mastodon.example",
+		"https://mastodon.example/@alice/":     "@alice@mastodon.example",
+		"https://mastodon.example/":            "mastodon.example",
+		"not a URL":                            "not a URL",
+	}
+
+	for actorURL, expected := range scenarios {
+		if result := Handle(actorURL); result != expected {
+			t.Errorf(`Unexpected handle for %q, got %q instead of %q`, actorURL, result, expected)
+		}
+	}
+}
+
+func TestProfileURL(t *testing.T) {
+	scenarios := map[string]string{
+		"@alice@mastodon.example":            "https://mastodon.example/@alice",
+		" alice@Mastodon.Example ":           "https://mastodon.example/@alice",
+		"@alice.bob@social.mastodon.example": "https://social.mastodon.example/@alice.bob",
+		"https://mastodon.example/@alice":    "https://mastodon.example/@alice",
+		"@alice@localhost":                   "@alice@localhost",
+		"mailto:alice@mastodon.example":      "mailto:alice@mastodon.example",
+	}
+
+	for value, expected := range scenarios {
+		if result := ProfileURL(value); result != expected {
+			t.Errorf(`Unexpected profile URL for %q, got %q instead of %q`, value, result, expected)
+		}
+	}
+}
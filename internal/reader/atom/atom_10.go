@@ -8,6 +8,8 @@ import (
 	"html"
 	"strings"
 
+	"miniflux.app/v2/internal/reader/dublincore"
+	"miniflux.app/v2/internal/reader/geo"
 	"miniflux.app/v2/internal/reader/media"
 	"miniflux.app/v2/internal/reader/sanitizer"
 )
@@ -148,7 +150,23 @@ type Atom10Entry struct {
 	// elements.
 	Categories AtomCategories `xml:"http://www.w3.org/2005/Atom category"`
 
+	// The "thr:in-reply-to" element is used to indicate that an entry is a
+	// response to another resource.
+	//
+	// Specs: https://datatracker.ietf.org/doc/html/rfc4685#section-3
+	InReplyTo []AtomInReplyTo `xml:"http://purl.org/syndication/thread/1.0 in-reply-to"`
+
 	media.MediaItemElement
+	dublincore.DublinCoreMetadataElement
+	geo.GeoItemElement
+}
+
+// AtomInReplyTo identifies the resource an entry responds to, the "ref" attribute is
+// the identifier of the resource and "href" is the IRI where it can be retrieved.
+type AtomInReplyTo struct {
+	Ref  string `xml:"ref,attr"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr"`
 }
 
 // A Text construct contains human-readable text, usually in small
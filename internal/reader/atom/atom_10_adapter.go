@@ -39,6 +39,13 @@ func (a *Atom10Adapter) BuildFeed(baseURL string) *model.Feed {
 		feed.FeedURL = baseURL
 	}
 
+	// Populate the WebSub hub URL.
+	if hubURL := a.atomFeed.Links.firstLinkWithRelation("hub"); hubURL != "" {
+		if absoluteHubURL, err := urllib.AbsoluteURL(baseURL, hubURL); err == nil {
+			feed.HubURL = absoluteHubURL
+		}
+	}
+
 	// Populate the site URL.
 	siteURL := a.atomFeed.Links.OriginalLink()
 	if siteURL != "" {
@@ -65,6 +72,10 @@ func (a *Atom10Adapter) BuildFeed(baseURL string) *model.Feed {
 			feed.IconURL = absoluteLogoURL
 		}
 	}
+
+	// Populate the feed categories.
+	feed.Categories = slices.Compact(a.atomFeed.Categories.CategoryNames())
+
 	feed.Entries = a.populateEntries(feed.SiteURL)
 	return feed
 }
@@ -77,6 +88,17 @@ func (a *Atom10Adapter) populateEntries(siteURL string) model.Entries {
 
 		// Populate the entry URL.
 		entry.URL = atomEntry.Links.OriginalLink()
+
+		// The comments of a comment feed may not have their own page, fallback to the resource they reply to.
+		if entry.URL == "" {
+			for _, inReplyTo := range atomEntry.InReplyTo {
+				if href := strings.TrimSpace(inReplyTo.Href); href != "" {
+					entry.URL = href
+					break
+				}
+			}
+		}
+
 		if entry.URL != "" {
 			if absoluteEntryURL, err := urllib.AbsoluteURL(siteURL, entry.URL); err == nil {
 				entry.URL = absoluteEntryURL
@@ -103,6 +125,11 @@ func (a *Atom10Adapter) populateEntries(siteURL string) model.Entries {
 
 		// Populate the entry author.
 		authors := atomEntry.Authors.PersonNames()
+		if len(authors) == 0 {
+			authors = atomEntry.DublinCoreMetadataElement.Authors()
+		} else {
+			authors = append(authors, atomEntry.Contributors()...)
+		}
 		if len(authors) == 0 {
 			authors = a.atomFeed.Authors.PersonNames()
 		}
@@ -130,7 +157,7 @@ func (a *Atom10Adapter) populateEntries(siteURL string) model.Entries {
 		}
 
 		// Populate categories.
-		categories := atomEntry.Categories.CategoryNames()
+		categories := append(atomEntry.Categories.CategoryNames(), atomEntry.Subjects()...)
 		if len(categories) == 0 {
 			categories = a.atomFeed.Categories.CategoryNames()
 		}
@@ -146,14 +173,34 @@ func (a *Atom10Adapter) populateEntries(siteURL string) model.Entries {
 			entry.CommentsURL = commentsURL
 		}
 
+		// Populate the comments feed URL if defined.
+		if commentsFeedURL := atomEntry.Links.CommentsFeedLink(); commentsFeedURL != "" {
+			if absoluteCommentsFeedURL, err := urllib.AbsoluteURL(siteURL, commentsFeedURL); err == nil {
+				entry.CommentsFeedURL = absoluteCommentsFeedURL
+			}
+		}
+
 		// Generate the entry hash.
-		for _, value := range []string{atomEntry.ID, atomEntry.Links.OriginalLink()} {
+		for _, value := range []string{atomEntry.ID, atomEntry.Identifier(), atomEntry.Links.OriginalLink()} {
 			if value != "" {
 				entry.Hash = crypto.Hash(value)
 				break
 			}
 		}
 
+		// Populate the entry location.
+		if latitude, longitude, found := atomEntry.Coordinates(); found {
+			entry.Latitude = &latitude
+			entry.Longitude = &longitude
+		}
+
+		// Populate the entry thumbnail.
+		if thumbnailURL := atomEntry.FirstThumbnailURL(); thumbnailURL != "" {
+			if absoluteThumbnailURL, err := urllib.AbsoluteURL(siteURL, thumbnailURL); err == nil {
+				entry.ThumbnailURL = absoluteThumbnailURL
+			}
+		}
+
 		// Populate the entry enclosures.
 		uniqueEnclosuresMap := make(map[string]bool)
 
@@ -217,6 +217,26 @@ func TestParseFeedURL(t *testing.T) {
 	}
 }
 
+func TestParseFeedHubURL(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom">
+	  <title>Example Feed</title>
+	  <link rel="alternate" type="text/html" href="https://example.org/"/>
+	  <link rel="self" type="application/atom+xml" href="https://example.org/feed"/>
+	  <link rel="hub" href="https://hub.example.org/"/>
+	  <updated>2003-12-13T18:30:02Z</updated>
+	</feed>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)), "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+}
+
 func TestParseFeedWithRelativeFeedURL(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 	<feed xmlns="http://www.w3.org/2005/Atom">
@@ -1451,6 +1471,10 @@ A website: http://example.org/</media:description>
 		t.Fatalf("Incorrect number of entries, got: %d", len(feed.Entries))
 	}
 
+	if feed.Entries[0].ThumbnailURL != "https://www.example.org/duplicate-thumbnail.jpg" {
+		t.Errorf("Incorrect thumbnail URL, got: %q", feed.Entries[0].ThumbnailURL)
+	}
+
 	if len(feed.Entries[0].Enclosures) != 4 {
 		t.Fatalf("Incorrect number of enclosures, got: %d", len(feed.Entries[0].Enclosures))
 	}
@@ -1781,6 +1805,10 @@ func TestParseFeedWithCategories(t *testing.T) {
 	if result != expected {
 		t.Errorf("Incorrect entry category, got %q instead of %q", result, expected)
 	}
+
+	if len(feed.Categories) != 1 || feed.Categories[0] != expected {
+		t.Errorf("Incorrect feed categories, got %v", feed.Categories)
+	}
 }
 
 func TestParseFeedWithIconURL(t *testing.T) {
@@ -1800,3 +1828,113 @@ func TestParseFeedWithIconURL(t *testing.T) {
 		t.Errorf("Incorrect icon URL, got: %s", feed.IconURL)
 	}
 }
+
+func TestParseEntryWithDublinCoreMetadata(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/">
+	  <title>Example Feed</title>
+	  <link href="https://example.org/"/>
+	  <author><name>Feed Author</name></author>
+	  <entry>
+		<title>Test</title>
+		<link href="https://example.org/item"/>
+		<updated>2003-12-13T18:30:02Z</updated>
+		<category term="Tech"/>
+		<dc:creator>Jane Doe</dc:creator>
+		<dc:contributor>John Doe</dc:contributor>
+		<dc:subject>Science</dc:subject>
+		<dc:subject>Tech</dc:subject>
+		<dc:identifier>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a</dc:identifier>
+	  </entry>
+	</feed>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)), "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Author != "Jane Doe, John Doe" {
+		t.Errorf("Incorrect entry author, got: %q", feed.Entries[0].Author)
+	}
+
+	if len(feed.Entries[0].Tags) != 2 || feed.Entries[0].Tags[0] != "Science" || feed.Entries[0].Tags[1] != "Tech" {
+		t.Errorf("Incorrect entry tags, got: %v", feed.Entries[0].Tags)
+	}
+
+	if feed.Entries[0].Hash != "3841e5cf232f5111fc5841e9eba5f4b26d95e7d7124902e0f7272729d65601a6" {
+		t.Errorf("Incorrect entry hash, got: %s", feed.Entries[0].Hash)
+	}
+}
+
+func TestParseEntryWithGeoRSSWhere(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom" xmlns:georss="http://www.georss.org/georss" xmlns:gml="http://www.opengis.net/gml">
+	  <title>Example Feed</title>
+	  <link href="https://example.org/"/>
+	  <entry>
+		<title>Test</title>
+		<link href="https://example.org/item"/>
+		<updated>2003-12-13T18:30:02Z</updated>
+		<georss:where>
+		  <gml:Point>
+			<gml:pos>45.256 -71.92</gml:pos>
+		  </gml:Point>
+		</georss:where>
+	  </entry>
+	</feed>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)), "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Latitude == nil || *feed.Entries[0].Latitude != 45.256 {
+		t.Errorf("Incorrect entry latitude, got: %v", feed.Entries[0].Latitude)
+	}
+
+	if feed.Entries[0].Longitude == nil || *feed.Entries[0].Longitude != -71.92 {
+		t.Errorf("Incorrect entry longitude, got: %v", feed.Entries[0].Longitude)
+	}
+}
+
+func TestParseEntryWithCommentsFeedURL(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom" xmlns:thr="http://purl.org/syndication/thread/1.0">
+	  <title>Example Feed</title>
+	  <link href="https://example.org/"/>
+	  <entry>
+		<title>Article</title>
+		<link href="https://example.org/article"/>
+		<link rel="replies" type="text/html" href="https://example.org/article#comments"/>
+		<link rel="replies" href="/article/comments.atom" thr:count="3"/>
+		<updated>2003-12-13T18:30:02Z</updated>
+	  </entry>
+	  <entry>
+		<title>Comment</title>
+		<id>tag:example.org,2003:comment-1</id>
+		<thr:in-reply-to ref="tag:example.org,2003:article" href="https://example.org/article" type="text/html"/>
+		<updated>2003-12-13T18:30:02Z</updated>
+	  </entry>
+	</feed>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)), "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].CommentsURL != "https://example.org/article#comments" {
+		t.Errorf("Incorrect entry comments URL, got: %s", feed.Entries[0].CommentsURL)
+	}
+
+	if feed.Entries[0].CommentsFeedURL != "https://example.org/article/comments.atom" {
+		t.Errorf("Incorrect entry comments feed URL, got: %s", feed.Entries[0].CommentsFeedURL)
+	}
+
+	if feed.Entries[1].URL != "https://example.org/article" {
+		t.Errorf("Incorrect entry URL, got: %s", feed.Entries[1].URL)
+	}
+
+	if feed.Entries[1].CommentsFeedURL != "" {
+		t.Errorf("Incorrect entry comments feed URL, got: %s", feed.Entries[1].CommentsFeedURL)
+	}
+}
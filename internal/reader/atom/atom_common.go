@@ -72,6 +72,13 @@ func (a AtomLinks) OriginalLink() string {
 	return ""
 }
 
+// CommentsFeedLink returns the "replies" link pointing to a feed of comments.
+// If the type attribute is omitted, its value is assumed to be "application/atom+xml".
+// Specs: https://datatracker.ietf.org/doc/html/rfc4685#section-4
+func (a AtomLinks) CommentsFeedLink() string {
+	return a.firstLinkWithRelationAndType("replies", "", "application/atom+xml", "application/rss+xml")
+}
+
 func (a AtomLinks) firstLinkWithRelation(relation string) string {
 	for _, link := range a {
 		if strings.EqualFold(link.Rel, relation) {
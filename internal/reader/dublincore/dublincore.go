@@ -3,6 +3,12 @@
 
 package dublincore // import "miniflux.app/v2/internal/reader/dublincore"
 
+import (
+	"strings"
+
+	"miniflux.app/v2/internal/reader/sanitizer"
+)
+
 type DublinCoreChannelElement struct {
 	DublinCoreCreator string `xml:"http://purl.org/dc/elements/1.1/ creator"`
 }
@@ -10,6 +16,51 @@ type DublinCoreChannelElement struct {
 type DublinCoreItemElement struct {
 	DublinCoreTitle   string `xml:"http://purl.org/dc/elements/1.1/ title"`
 	DublinCoreDate    string `xml:"http://purl.org/dc/elements/1.1/ date"`
-	DublinCoreCreator string `xml:"http://purl.org/dc/elements/1.1/ creator"`
 	DublinCoreContent string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+
+	DublinCoreMetadataElement
+}
+
+// DublinCoreMetadataElement contains the Dublin Core elements describing an entry, they are also used in Atom feeds.
+// Specs: https://www.dublincore.org/specifications/dublin-core/dcmi-terms/
+type DublinCoreMetadataElement struct {
+	DublinCoreCreator      string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	DublinCoreContributors []string `xml:"http://purl.org/dc/elements/1.1/ contributor"`
+	DublinCoreSubjects     []string `xml:"http://purl.org/dc/elements/1.1/ subject"`
+	DublinCoreIdentifier   string   `xml:"http://purl.org/dc/elements/1.1/ identifier"`
+}
+
+// Authors returns the creator followed by the contributors, without duplicates.
+func (d *DublinCoreMetadataElement) Authors() []string {
+	return cleanValues(append([]string{d.DublinCoreCreator}, d.DublinCoreContributors...))
+}
+
+// Contributors returns the names of the contributors, without duplicates.
+func (d *DublinCoreMetadataElement) Contributors() []string {
+	return cleanValues(d.DublinCoreContributors)
+}
+
+// Subjects returns the subjects, they are used as entry tags.
+func (d *DublinCoreMetadataElement) Subjects() []string {
+	return cleanValues(d.DublinCoreSubjects)
+}
+
+// Identifier returns the unambiguous reference to the entry, usually an URL or an URN.
+func (d *DublinCoreMetadataElement) Identifier() string {
+	return strings.TrimSpace(d.DublinCoreIdentifier)
+}
+
+func cleanValues(values []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+
+	for _, value := range values {
+		value = strings.TrimSpace(sanitizer.StripTags(value))
+		if value != "" && !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+
+	return result
 }
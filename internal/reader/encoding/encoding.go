@@ -6,9 +6,11 @@ package encoding // import "miniflux.app/v2/internal/reader/encoding"
 import (
 	"bytes"
 	"io"
+	"unicode"
 	"unicode/utf8"
 
 	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding/charmap"
 )
 
 // CharsetReader is used when the XML encoding is specified for the input document.
@@ -24,14 +26,147 @@ import (
 // - Feeds with wrong encoding defined and already in UTF-8
 func CharsetReader(charsetLabel string, input io.Reader) (io.Reader, error) {
 	buffer, _ := io.ReadAll(input)
-	r := bytes.NewReader(buffer)
+	return bytes.NewReader(ConvertToUTF8(buffer, charsetLabel)), nil
+}
+
+var byteOrderMarks = []struct {
+	bom     []byte
+	charset string
+}{
+	{[]byte{0xef, 0xbb, 0xbf}, "utf-8"},
+	{[]byte{0xfe, 0xff}, "utf-16be"},
+	{[]byte{0xff, 0xfe}, "utf-16le"},
+}
+
+// fallbackCharsets are the legacy charsets tried when the encoding of the document is unknown.
+// The first charset wins in case of tie, the single-byte charsets decode most of the documents without errors.
+var fallbackCharsets = []string{"windows-1252", "shift_jis", "gbk", "big5"}
+
+// ConvertToUTF8 converts the document to UTF-8, the encoding is found with the following fallback chain:
+//
+//  1. The byte order mark of the document.
+//  2. The document is already valid UTF-8, it's returned as is even if another charset is declared.
+//  3. The declared charset, if the document is valid in this charset.
+//  4. A document mostly encoded in UTF-8 with a few invalid bytes, they are decoded as Windows-1252.
+//  5. The legacy charset decoding the document with the fewest invalid characters and symbols.
+func ConvertToUTF8(data []byte, declaredCharset string) []byte {
+	for _, byteOrderMark := range byteOrderMarks {
+		if bytes.HasPrefix(data, byteOrderMark.bom) {
+			data = data[len(byteOrderMark.bom):]
+			if byteOrderMark.charset != "utf-8" {
+				if converted, ok := decode(data, byteOrderMark.charset); ok {
+					return converted
+				}
+			}
+			break
+		}
+	}
+
+	if utf8.Valid(data) {
+		return data
+	}
+
+	if declaredCharset != "" {
+		if converted, ok := decode(data, declaredCharset); ok && countInvalidRunes(converted) == 0 {
+			return converted
+		}
+	}
+
+	if validRunes, invalidBytes := countMultiByteRunes(data); validRunes > 0 && invalidBytes <= 2*validRunes {
+		return repairUTF8(data)
+	}
+
+	var bestResult []byte
+	bestScore := -1
+	for _, fallbackCharset := range fallbackCharsets {
+		converted, ok := decode(data, fallbackCharset)
+		if !ok {
+			continue
+		}
+
+		if score := unlikelyRunesScore(converted); bestScore == -1 || score < bestScore {
+			bestResult, bestScore = converted, score
+		}
+	}
+
+	if bestResult == nil {
+		return data
+	}
+
+	return bestResult
+}
+
+func decode(data []byte, charsetLabel string) ([]byte, bool) {
+	encoding, _ := charset.Lookup(charsetLabel)
+	if encoding == nil {
+		return nil, false
+	}
 
-	// The document is already UTF-8, do not do anything (avoid double-encoding).
-	// That means the specified encoding in XML prolog is wrong.
-	if utf8.Valid(buffer) {
-		return r, nil
+	converted, err := encoding.NewDecoder().Bytes(data)
+	if err != nil {
+		return nil, false
 	}
 
-	// Transform document to UTF-8 from the specified encoding in XML prolog.
-	return charset.NewReaderLabel(charsetLabel, r)
+	return converted, true
+}
+
+// countInvalidRunes returns the number of replacement characters and invalid bytes of an UTF-8 document.
+func countInvalidRunes(data []byte) int {
+	count := 0
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError {
+			count++
+		}
+		data = data[size:]
+	}
+	return count
+}
+
+// unlikelyRunesScore scores the decoded document like a naive charset detector, the lower the better.
+// The invalid characters are penalized more than the symbols since the symbols are expected in small numbers, like the quotation marks.
+func unlikelyRunesScore(data []byte) int {
+	score := 0
+	for _, r := range string(data) {
+		switch {
+		case r == utf8.RuneError:
+			score += 2
+		case r >= utf8.RuneSelf && !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			score++
+		}
+	}
+	return score
+}
+
+// countMultiByteRunes returns the number of valid UTF-8 sequences longer than one byte, and the number of invalid bytes.
+func countMultiByteRunes(data []byte) (validRunes, invalidBytes int) {
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		switch {
+		case r == utf8.RuneError && size == 1:
+			invalidBytes++
+		case size > 1:
+			validRunes++
+		}
+		data = data[size:]
+	}
+	return validRunes, invalidBytes
+}
+
+// repairUTF8 decodes the invalid bytes of an UTF-8 document as Windows-1252 characters.
+func repairUTF8(data []byte) []byte {
+	windows1252 := charmap.Windows1252.NewDecoder()
+	repaired := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size == 1 {
+			if converted, err := windows1252.Bytes(data[:1]); err == nil {
+				repaired = append(repaired, converted...)
+			}
+		} else {
+			repaired = append(repaired, data[:size]...)
+		}
+		data = data[size:]
+	}
+	return repaired
 }
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package encoding // import "miniflux.app/v2/internal/reader/encoding"
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestConvertToUTF8(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		data            string
+		declaredCharset string
+		expected        string
+	}{
+		{"valid UTF-8", "café", "", "café"},
+		{"valid UTF-8 declared as another charset", "café", "iso-8859-1", "café"},
+		{"UTF-8 byte order mark", "\xef\xbb\xbfcafé", "", "café"},
+		{"UTF-16LE byte order mark", "\xff\xfec\x00a\x00f\x00\xe9\x00", "", "café"},
+		{"UTF-16BE byte order mark", "\xfe\xff\x00c\x00a\x00f\x00\xe9", "", "café"},
+		{"declared charset", "\xcf\xf0\xe8\xe2\xe5\xf2", "windows-1251", "Привет"},
+		{"Windows-1252 declared as UTF-8", "caf\xe9 \x93quoted\x94", "utf-8", "café “quoted”"},
+		{"Windows-1252 without declared charset", "caf\xe9", "", "café"},
+		{"unknown declared charset", "caf\xe9", "invalid", "café"},
+		{"UTF-8 with a few Windows-1252 characters", "café d\xe9j\xe0 vu", "", "café déjà vu"},
+		{"Shift_JIS without declared charset", "\x82\xb1\x82\xf1\x82\xc9\x82\xbf\x82\xcd", "", "こんにちは"},
+	}
+
+	for _, scenario := range scenarios {
+		if result := string(ConvertToUTF8([]byte(scenario.data), scenario.declaredCharset)); result != scenario.expected {
+			t.Errorf(`Unexpected result for %s, got %q instead of %q`, scenario.name, result, scenario.expected)
+		}
+	}
+}
+
+func TestCharsetReaderWithInvalidCharset(t *testing.T) {
+	reader, err := CharsetReader("invalid", strings.NewReader("caf\xe9"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := io.ReadAll(reader)
+	if string(data) != "café" {
+		t.Errorf(`Unexpected result, got %q`, data)
+	}
+}
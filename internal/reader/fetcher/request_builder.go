@@ -4,8 +4,10 @@
 package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
 
 import (
+	"bytes"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
 	"log/slog"
 	"net"
 	"net/http"
@@ -20,13 +22,15 @@ const (
 )
 
 type RequestBuilder struct {
-	headers          http.Header
-	clientProxyURL   string
-	useClientProxy   bool
-	clientTimeout    int
-	withoutRedirects bool
-	ignoreTLSErrors  bool
-	disableHTTP2     bool
+	headers            http.Header
+	acceptHeader       string
+	clientProxyURL     string
+	useClientProxy     bool
+	clientTimeout      int
+	withoutRedirects   bool
+	ignoreTLSErrors    bool
+	disableHTTP2       bool
+	headlessBrowserURL string
 }
 
 func NewRequestBuilder() *RequestBuilder {
@@ -41,6 +45,12 @@ func (r *RequestBuilder) WithHeader(key, value string) *RequestBuilder {
 	return r
 }
 
+// WithAcceptHeader overrides the default Accept header, an empty value restores the default one.
+func (r *RequestBuilder) WithAcceptHeader(value string) *RequestBuilder {
+	r.acceptHeader = value
+	return r
+}
+
 func (r *RequestBuilder) WithETag(etag string) *RequestBuilder {
 	if etag != "" {
 		r.headers.Set("If-None-Match", etag)
@@ -48,6 +58,13 @@ func (r *RequestBuilder) WithETag(etag string) *RequestBuilder {
 	return r
 }
 
+// WithDeltaEncoding asks the server to only send the items added since the cached version of the feed.
+// The cached version is identified by the If-None-Match header. See https://datatracker.ietf.org/doc/html/rfc3229.
+func (r *RequestBuilder) WithDeltaEncoding() *RequestBuilder {
+	r.headers.Set("A-IM", "feed")
+	return r
+}
+
 func (r *RequestBuilder) WithLastModified(lastModified string) *RequestBuilder {
 	if lastModified != "" {
 		r.headers.Set("If-Modified-Since", lastModified)
@@ -71,6 +88,14 @@ func (r *RequestBuilder) WithCookie(cookie string) *RequestBuilder {
 	return r
 }
 
+// WithTraceParent propagates the trace of the span making the request, an empty value is ignored.
+func (r *RequestBuilder) WithTraceParent(traceParent string) *RequestBuilder {
+	if traceParent != "" {
+		r.headers.Set("traceparent", traceParent)
+	}
+	return r
+}
+
 func (r *RequestBuilder) WithUsernameAndPassword(username, password string) *RequestBuilder {
 	if username != "" && password != "" {
 		r.headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
@@ -98,6 +123,13 @@ func (r *RequestBuilder) WithoutRedirects() *RequestBuilder {
 	return r
 }
 
+// WithHeadlessBrowser renders the page with the headless browser service instead of downloading it directly.
+// An empty value downloads the page directly.
+func (r *RequestBuilder) WithHeadlessBrowser(serviceURL string) *RequestBuilder {
+	r.headlessBrowserURL = serviceURL
+	return r
+}
+
 func (r *RequestBuilder) DisableHTTP2(value bool) *RequestBuilder {
 	r.disableHTTP2 = value
 	return r
@@ -108,6 +140,16 @@ func (r *RequestBuilder) IgnoreTLSErrors(value bool) *RequestBuilder {
 	return r
 }
 
+// WithNetworkSettingsOnly returns a new request builder with the timeout and the proxy settings only.
+// The headers, the credentials and the TLS exceptions of the feed are not sent to the third-party services.
+func (r *RequestBuilder) WithNetworkSettingsOnly() *RequestBuilder {
+	requestBuilder := NewRequestBuilder()
+	requestBuilder.clientTimeout = r.clientTimeout
+	requestBuilder.clientProxyURL = r.clientProxyURL
+	requestBuilder.useClientProxy = r.useClientProxy
+	return requestBuilder
+}
+
 func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, error) {
 	// We get the safe ciphers
 	ciphers := tls.CipherSuites()
@@ -151,7 +193,8 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
 	}
 
-	if r.useClientProxy && r.clientProxyURL != "" {
+	// The headless browser downloads the page itself, the proxy is not used to reach the rendering service.
+	if r.useClientProxy && r.clientProxyURL != "" && r.headlessBrowserURL == "" {
 		if proxyURL, err := url.Parse(r.clientProxyURL); err != nil {
 			slog.Warn("Unable to parse proxy URL",
 				slog.String("proxy_url", r.clientProxyURL),
@@ -174,6 +217,10 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 
 	client.Transport = transport
 
+	if r.headlessBrowserURL != "" {
+		return r.executeHeadlessBrowserRequest(client, requestURL)
+	}
+
 	req, err := http.NewRequest("GET", requestURL, nil)
 	if err != nil {
 		return nil, err
@@ -181,7 +228,11 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 
 	req.Header = r.headers
 	req.Header.Set("Accept-Encoding", "br, gzip")
-	req.Header.Set("Accept", defaultAcceptHeader)
+	if r.acceptHeader != "" {
+		req.Header.Set("Accept", r.acceptHeader)
+	} else {
+		req.Header.Set("Accept", defaultAcceptHeader)
+	}
 	req.Header.Set("Connection", "close")
 
 	slog.Debug("Making outgoing request", slog.Group("request",
@@ -197,3 +248,49 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 
 	return client.Do(req)
 }
+
+// executeHeadlessBrowserRequest asks the rendering service to load the page and to return the HTML document once the scripts have run.
+// The service must be compatible with the Browserless /content API. The response is attached to the page request,
+// the effective URL is the page URL instead of the service URL.
+//
+// The headers are not forwarded: the service would send them to every resource of the page, including the third-party ones.
+// The pages needing a cookie or a password are rendered without them.
+func (r *RequestBuilder) executeHeadlessBrowserRequest(client *http.Client, requestURL string) (*http.Response, error) {
+	pageRequest, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.headers.Get("Cookie") != "" || r.headers.Get("Authorization") != "" {
+		slog.Warn("The cookie and the credentials are not sent to the headless browser",
+			slog.String("url", requestURL),
+		)
+	}
+
+	payload, err := json.Marshal(map[string]string{"url": requestURL})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", r.headlessBrowserURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("Connection", "close")
+
+	slog.Debug("Making outgoing request with headless browser", slog.Group("request",
+		slog.String("url", requestURL),
+		slog.String("headless_browser_url", r.headlessBrowserURL),
+	))
+
+	response, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Request = pageRequest
+	return response, nil
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExecuteRequestWithHeadlessBrowser(t *testing.T) {
+	var renderedURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/content" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var payload struct {
+			URL string `json:"url"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		renderedURL = payload.URL
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><body><p>Rendered content</p></body></html>`))
+	}))
+	defer server.Close()
+
+	requestBuilder := NewRequestBuilder()
+	requestBuilder.WithHeadlessBrowser(server.URL + "/content")
+
+	responseHandler := NewResponseHandler(requestBuilder.ExecuteRequest("https://example.org/article"))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		t.Fatalf(`Rendering the page should not return any error: %v`, localizedError.Error())
+	}
+
+	if renderedURL != "https://example.org/article" {
+		t.Errorf(`Unexpected page sent to the headless browser, got %q`, renderedURL)
+	}
+
+	if effectiveURL := responseHandler.EffectiveURL(); effectiveURL != "https://example.org/article" {
+		t.Errorf(`The effective URL should be the page URL, got %q`, effectiveURL)
+	}
+
+	body, err := io.ReadAll(responseHandler.Body(1024))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != `<html><body><p>Rendered content</p></body></html>` {
+		t.Errorf(`Unexpected rendered document, got %q`, body)
+	}
+}
+
+func TestExecuteRequestWithTraceParent(t *testing.T) {
+	var traceParent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	for _, expected := range []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ""} {
+		requestBuilder := NewRequestBuilder()
+		requestBuilder.WithTraceParent(expected)
+
+		responseHandler := NewResponseHandler(requestBuilder.ExecuteRequest(server.URL))
+		responseHandler.Close()
+
+		if traceParent != expected {
+			t.Errorf(`Unexpected traceparent header, got %q instead of %q`, traceParent, expected)
+		}
+	}
+}
@@ -51,6 +51,41 @@ func (r *ResponseHandler) ETag() string {
 	return r.httpResponse.Header.Get("ETag")
 }
 
+// LinkHeader returns the URL of the first "Link" header with the given relation, for example the WebSub hub of the feed.
+// The URL is relative to the effective URL of the response. See https://datatracker.ietf.org/doc/html/rfc8288.
+func (r *ResponseHandler) LinkHeader(relation string) string {
+	for _, header := range r.httpResponse.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, params, found := strings.Cut(link, ";")
+			target = strings.TrimSpace(target)
+			if !found || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range strings.Split(params, ";") {
+				name, value, _ := strings.Cut(param, "=")
+				if !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+					if !strings.EqualFold(rel, relation) {
+						continue
+					}
+
+					linkURL, err := r.httpResponse.Request.URL.Parse(strings.Trim(target, "<>"))
+					if err != nil {
+						return ""
+					}
+					return linkURL.String()
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
 func (r *ResponseHandler) IsModified(lastEtagValue, lastModifiedValue string) bool {
 	if r.httpResponse.StatusCode == http.StatusNotModified {
 		return false
@@ -67,6 +102,24 @@ func (r *ResponseHandler) IsModified(lastEtagValue, lastModifiedValue string) bo
 	return true
 }
 
+// IsDeltaFeed returns true when the server replied with the "feed" instance manipulation (226 IM Used status code).
+// The response body is a partial feed containing only the new items, the missing items are not removed from the feed.
+func (r *ResponseHandler) IsDeltaFeed() bool {
+	if r.httpResponse.StatusCode != http.StatusIMUsed {
+		return false
+	}
+
+	for _, header := range r.httpResponse.Header.Values("IM") {
+		for _, manipulation := range strings.Split(header, ",") {
+			if strings.EqualFold(strings.TrimSpace(manipulation), "feed") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (r *ResponseHandler) Close() {
 	if r.httpResponse != nil && r.httpResponse.Body != nil && r.clientErr == nil {
 		r.httpResponse.Body.Close()
@@ -5,6 +5,7 @@ package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
 )
 
@@ -67,3 +68,80 @@ func TestIsModified(t *testing.T) {
 		})
 	}
 }
+
+func TestLinkHeader(t *testing.T) {
+	header := http.Header{}
+	header.Add("Link", `<https://example.org/feed.xml>; rel="self", </hub>; rel="hub"`)
+	header.Add("Link", `<https://other.example.org/hub>; rel="hub"`)
+
+	rh := ResponseHandler{
+		httpResponse: &http.Response{
+			Header:  header,
+			Request: &http.Request{URL: &url.URL{Scheme: "https", Host: "example.org", Path: "/feed"}},
+		},
+	}
+
+	if result := rh.LinkHeader("hub"); result != "https://example.org/hub" {
+		t.Errorf(`Unexpected hub URL, got %q`, result)
+	}
+
+	if result := rh.LinkHeader("self"); result != "https://example.org/feed.xml" {
+		t.Errorf(`Unexpected self URL, got %q`, result)
+	}
+
+	if result := rh.LinkHeader("alternate"); result != "" {
+		t.Errorf(`Unexpected alternate URL, got %q`, result)
+	}
+}
+
+func TestIsDeltaFeed(t *testing.T) {
+	var testCases = map[string]struct {
+		Status      int
+		IM          string
+		IsDeltaFeed bool
+	}{
+		"Full feed":                   {Status: 200, IM: "", IsDeltaFeed: false},
+		"Delta feed":                  {Status: 226, IM: "feed", IsDeltaFeed: true},
+		"Delta feed with compression": {Status: 226, IM: "gzip, Feed", IsDeltaFeed: true},
+		"Other instance manipulation": {Status: 226, IM: "vcdiff", IsDeltaFeed: false},
+		"IM header without 226":       {Status: 200, IM: "feed", IsDeltaFeed: false},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(tt *testing.T) {
+			header := http.Header{}
+			if tc.IM != "" {
+				header.Add("IM", tc.IM)
+			}
+			rh := ResponseHandler{
+				httpResponse: &http.Response{
+					StatusCode: tc.Status,
+					Header:     header,
+				},
+			}
+			if tc.IsDeltaFeed != rh.IsDeltaFeed() {
+				tt.Error(name)
+			}
+		})
+	}
+}
+
+func TestIsModifiedWithDeltaFeed(t *testing.T) {
+	header := http.Header{}
+	header.Add("ETag", "xyz789")
+	header.Add("IM", "feed")
+	rh := ResponseHandler{
+		httpResponse: &http.Response{
+			StatusCode:    http.StatusIMUsed,
+			Header:        header,
+			ContentLength: -1,
+		},
+	}
+
+	if !rh.IsModified("abc123", "") {
+		t.Error(`A delta feed with a new ETag must be considered as modified`)
+	}
+
+	if rh.LocalizedError() != nil {
+		t.Errorf(`A delta feed must not be considered as an error, got %v`, rh.LocalizedError().Error())
+	}
+}
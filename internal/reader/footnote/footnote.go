@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package footnote // import "miniflux.app/v2/internal/reader/footnote"
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// classes are the CSS classes of the inline footnotes, the sanitizer only keeps these ones.
+var classes = []string{"footnote", "footnote-content"}
+
+// The footnotes containing these elements cannot be displayed in the middle of a paragraph.
+const blockElements = "address, article, aside, blockquote, details, div, dl, fieldset, figure, footer, form, h1, h2, h3, h4, h5, h6, header, hr, iframe, ol, pre, section, table, ul, video"
+
+// The longest reference text, like [12] or *.
+const maxReferenceLength = 6
+
+// IsFootnoteClass returns true if the value is the CSS class of an inline footnote.
+func IsFootnoteClass(value string) bool {
+	return slices.Contains(classes, value)
+}
+
+// InlineFootnotes moves the footnotes next to their references, like <sup><a href="#fn1">1</a></sup>,
+// to display them without jumping to the end of the entry. The footnotes are removed from the end of the
+// entry once inlined, the other ones are left untouched.
+func InlineFootnotes(entryContent string) string {
+	if !strings.Contains(entryContent, `href="#`) {
+		return entryContent
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(entryContent))
+	if err != nil {
+		return entryContent
+	}
+
+	var inlinedNotes []*html.Node
+
+	doc.Find(`a[href^="#"]`).Each(func(i int, link *goquery.Selection) {
+		if !isFootnoteReference(link) {
+			return
+		}
+
+		note := findFootnote(doc, link)
+		if note == nil {
+			return
+		}
+
+		content, ok := footnoteContent(note)
+		if !ok {
+			return
+		}
+
+		reference := link
+		if parent := link.Parent(); parent.Is("sup") && parent.Children().Length() == 1 {
+			reference = parent
+		}
+
+		referenceHTML, err := goquery.OuterHtml(reference)
+		if err != nil {
+			return
+		}
+
+		reference.ReplaceWithHtml(`<span class="footnote">` + referenceHTML + `<span class="footnote-content">` + content + `</span></span>`)
+
+		if !slices.Contains(inlinedNotes, note.Get(0)) {
+			inlinedNotes = append(inlinedNotes, note.Get(0))
+		}
+	})
+
+	if len(inlinedNotes) == 0 {
+		return entryContent
+	}
+
+	for _, node := range inlinedNotes {
+		removeFootnote(doc.FindNodes(node))
+	}
+
+	output, _ := doc.Find("body").First().Html()
+	return output
+}
+
+// isFootnoteReference returns true if the link looks like a footnote number or symbol.
+func isFootnoteReference(link *goquery.Selection) bool {
+	text := strings.TrimSpace(link.Text())
+	if text == "" || utf8.RuneCountInString(text) > maxReferenceLength {
+		return false
+	}
+
+	if link.Parent().Is("sup") || link.ChildrenFiltered("sup").Length() == 1 {
+		return true
+	}
+
+	return link.AttrOr("role", "") == "doc-noteref" ||
+		link.AttrOr("rel", "") == "footnote" ||
+		strings.Contains(link.AttrOr("class", ""), "footnote")
+}
+
+// findFootnote returns the element targeted by the link when it looks like a footnote.
+func findFootnote(doc *goquery.Document, link *goquery.Selection) *goquery.Selection {
+	id := strings.TrimPrefix(link.AttrOr("href", ""), "#")
+	if unescapedID, err := url.PathUnescape(id); err == nil {
+		id = unescapedID
+	}
+
+	if id == "" {
+		return nil
+	}
+
+	note := doc.Find("[id]").FilterFunction(func(i int, element *goquery.Selection) bool {
+		return element.AttrOr("id", "") == id
+	}).First()
+
+	if note.Length() == 0 || !note.Is("li, p, div, aside") || strings.TrimSpace(note.Text()) == "" {
+		return nil
+	}
+
+	// A link to an element containing the reference is not a footnote, like a link to the top of a section.
+	if link.Closest("[id]").FilterFunction(func(i int, element *goquery.Selection) bool {
+		return element.AttrOr("id", "") == id
+	}).Length() > 0 {
+		return nil
+	}
+
+	lowerID := strings.ToLower(id)
+	role := note.AttrOr("role", "")
+	if strings.Contains(lowerID, "fn") || strings.Contains(lowerID, "note") ||
+		role == "doc-endnote" || role == "doc-footnote" ||
+		note.Closest(`[class*="footnote"], [role="doc-endnotes"]`).Length() > 0 {
+		return note
+	}
+
+	return nil
+}
+
+// footnoteContent returns the content of the footnote without its links back to the reference, the footnotes
+// containing block elements are not returned.
+func footnoteContent(note *goquery.Selection) (string, bool) {
+	content := note.Clone()
+	content.Find(`a[href^="#"]`).FilterFunction(func(i int, link *goquery.Selection) bool {
+		return isBackReference(link)
+	}).Remove()
+
+	if content.Find(blockElements).Length() > 0 {
+		return "", false
+	}
+
+	var parts []string
+	if paragraphs := content.ChildrenFiltered("p"); paragraphs.Length() > 0 {
+		if paragraphs.Length() != content.Children().Length() {
+			return "", false
+		}
+
+		paragraphs.Each(func(i int, paragraph *goquery.Selection) {
+			if part, err := paragraph.Html(); err == nil && strings.TrimSpace(part) != "" {
+				parts = append(parts, strings.TrimSpace(part))
+			}
+		})
+	} else if part, err := content.Html(); err == nil && strings.TrimSpace(part) != "" {
+		parts = append(parts, strings.TrimSpace(part))
+	}
+
+	if len(parts) == 0 || content.Find("p").Length() > content.ChildrenFiltered("p").Length() {
+		return "", false
+	}
+
+	return strings.Join(parts, "<br/>"), true
+}
+
+// isBackReference returns true if the link goes back to the reference of the footnote.
+func isBackReference(link *goquery.Selection) bool {
+	if link.AttrOr("role", "") == "doc-backlink" {
+		return true
+	}
+
+	class := link.AttrOr("class", "")
+	if strings.Contains(class, "back") || strings.Contains(class, "reverse") || link.AttrOr("rev", "") == "footnote" {
+		return true
+	}
+
+	switch strings.TrimSpace(link.Text()) {
+	case "↩", "↩︎", "↩️", "↑", "^", "⤴", "⮐":
+		return true
+	}
+
+	return false
+}
+
+// removeFootnote removes the footnote and the lists and sections left empty without it.
+func removeFootnote(note *goquery.Selection) {
+	element := note
+	for {
+		parent := element.Parent()
+		element.Remove()
+
+		if parent.Length() == 0 || parent.Is("body") || !isEmpty(parent) {
+			return
+		}
+
+		// The separator displayed before the footnotes is removed with them.
+		if previous := parent.Prev(); previous.Is("hr") {
+			previous.Remove()
+		}
+
+		element = parent
+	}
+}
+
+func isEmpty(element *goquery.Selection) bool {
+	return strings.TrimSpace(element.Text()) == "" && element.Find("img, picture, video, audio, iframe").Length() == 0
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package footnote // import "miniflux.app/v2/internal/reader/footnote"
+
+import "testing"
+
+func TestInlineFootnotes(t *testing.T) {
+	scenarios := map[string]string{
+		// Content without footnotes is kept as is.
+		`<p>Some text</p>`: `<p>Some text</p>`,
+		`<p><a href="#section">Section</a></p><h2 id="section">Section</h2>`: `<p><a href="#section">Section</a></p><h2 id="section">Section</h2>`,
+
+		// Markdown footnotes, like the ones of goldmark and kramdown.
+		`<p>Text<sup id="fnref:1"><a href="#fn:1" class="footnote-ref" role="doc-noteref">1</a></sup>.</p><div class="footnotes" role="doc-endnotes"><hr><ol><li id="fn:1"><p>The note.&nbsp;<a href="#fnref:1" class="footnote-backref" role="doc-backlink">↩︎</a></p></li></ol></div>`: `<p>Text<span class="footnote"><sup id="fnref:1"><a href="#fn:1" class="footnote-ref" role="doc-noteref">1</a></sup><span class="footnote-content">The note.</span></span>.</p>`,
+
+		// Pandoc footnotes.
+		`<p>Text<a href="#fn1" class="footnote-ref" id="fnref1" role="doc-noteref"><sup>1</sup></a></p><section class="footnotes" role="doc-endnotes"><hr /><ol><li id="fn1" role="doc-endnote"><p>First paragraph.</p><p>Second <em>paragraph</em>. <a href="#fnref1" class="footnote-back" role="doc-backlink">↩︎</a></p></li></ol></section>`: `<p>Text<span class="footnote"><a href="#fn1" class="footnote-ref" id="fnref1" role="doc-noteref"><sup>1</sup></a><span class="footnote-content">First paragraph.<br/>Second <em>paragraph</em>.</span></span></p>`,
+
+		// markdown-it footnotes with the separator outside of the footnotes section.
+		`<p>A<sup class="footnote-ref"><a href="#fn1" id="fnref1">[1]</a></sup> B</p><hr class="footnotes-sep"><section class="footnotes"><ol class="footnotes-list"><li id="fn1" class="footnote-item"><p>Note <a href="#fnref1" class="footnote-backref">↩︎</a></p></li></ol></section>`: `<p>A<span class="footnote"><sup class="footnote-ref"><a href="#fn1" id="fnref1">[1]</a></sup><span class="footnote-content">Note</span></span> B</p>`,
+
+		// Only the inlined footnotes are removed from the end of the entry.
+		`<p>A<sup><a href="#fn1">1</a></sup></p><ol><li id="fn1">Short note</li><li id="fn2"><p>Long note</p><ul><li>Item</li></ul></li></ol>`: `<p>A<span class="footnote"><sup><a href="#fn1">1</a></sup><span class="footnote-content">Short note</span></span></p><ol><li id="fn2"><p>Long note</p><ul><li>Item</li></ul></li></ol>`,
+
+		// A footnote referenced twice is inlined twice.
+		`<p>A<sup><a href="#note-1">1</a></sup> B<sup><a href="#note-1">1</a></sup></p><ol><li id="note-1">Note</li></ol>`: `<p>A<span class="footnote"><sup><a href="#note-1">1</a></sup><span class="footnote-content">Note</span></span> B<span class="footnote"><sup><a href="#note-1">1</a></sup><span class="footnote-content">Note</span></span></p>`,
+
+		// Links to missing or unrelated elements are kept.
+		`<p>A<sup><a href="#fn1">1</a></sup></p>`:                                     `<p>A<sup><a href="#fn1">1</a></sup></p>`,
+		`<p>A<sup><a href="#intro">1</a></sup></p><div id="intro">Introduction</div>`: `<p>A<sup><a href="#intro">1</a></sup></p><div id="intro">Introduction</div>`,
+		`<p>A <a href="#fn1">a long link text</a></p><ol><li id="fn1">Note</li></ol>`: `<p>A <a href="#fn1">a long link text</a></p><ol><li id="fn1">Note</li></ol>`,
+	}
+
+	for input, expected := range scenarios {
+		if output := InlineFootnotes(input); output != expected {
+			t.Errorf(`Unexpected output for %q: got %q instead of %q`, input, output, expected)
+		}
+	}
+}
+
+func TestIsFootnoteClass(t *testing.T) {
+	if !IsFootnoteClass("footnote") || !IsFootnoteClass("footnote-content") {
+		t.Error(`The inline footnote classes should be allowed`)
+	}
+
+	if IsFootnoteClass("footnotes") {
+		t.Error(`The other classes should not be allowed`)
+	}
+}
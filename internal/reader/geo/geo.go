@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package geo reads the location of the feed items.
+// Specs:
+// https://www.ogc.org/standard/georss/
+// https://www.w3.org/2003/01/geo/
+package geo // import "miniflux.app/v2/internal/reader/geo"
+
+import (
+	"strconv"
+	"strings"
+)
+
+// GeoItemElement contains the GeoRSS and W3C Basic Geo elements of an item.
+type GeoItemElement struct {
+	GeoRSSPoint  string      `xml:"http://www.georss.org/georss point"`
+	GeoRSSWhere  GeoRSSWhere `xml:"http://www.georss.org/georss where"`
+	GeoLatitude  string      `xml:"http://www.w3.org/2003/01/geo/wgs84_pos# lat"`
+	GeoLongitude string      `xml:"http://www.w3.org/2003/01/geo/wgs84_pos# long"`
+	GeoPoint     W3CGeoPoint `xml:"http://www.w3.org/2003/01/geo/wgs84_pos# Point"`
+}
+
+// GeoRSSWhere is the GML encoding of GeoRSS, only points are supported.
+//
+//	<georss:where><gml:Point><gml:pos>45.256 -71.92</gml:pos></gml:Point></georss:where>
+type GeoRSSWhere struct {
+	Position string `xml:"http://www.opengis.net/gml Point>pos"`
+}
+
+// W3CGeoPoint is a point of the W3C Basic Geo vocabulary.
+//
+//	<geo:Point><geo:lat>55.701</geo:lat><geo:long>12.552</geo:long></geo:Point>
+type W3CGeoPoint struct {
+	Latitude  string `xml:"http://www.w3.org/2003/01/geo/wgs84_pos# lat"`
+	Longitude string `xml:"http://www.w3.org/2003/01/geo/wgs84_pos# long"`
+}
+
+// Coordinates returns the latitude and the longitude of the item, the first valid location is used.
+func (g *GeoItemElement) Coordinates() (latitude, longitude float64, found bool) {
+	for _, pair := range []string{g.GeoRSSPoint, g.GeoRSSWhere.Position} {
+		if fields := strings.FieldsFunc(pair, isSeparator); len(fields) == 2 {
+			if latitude, longitude, found = parseCoordinates(fields[0], fields[1]); found {
+				return latitude, longitude, true
+			}
+		}
+	}
+
+	if latitude, longitude, found = parseCoordinates(g.GeoLatitude, g.GeoLongitude); found {
+		return latitude, longitude, true
+	}
+
+	return parseCoordinates(g.GeoPoint.Latitude, g.GeoPoint.Longitude)
+}
+
+// isSeparator accepts commas since some publishers separate the GeoRSS coordinates with them.
+func isSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+func parseCoordinates(latitudeValue, longitudeValue string) (latitude, longitude float64, found bool) {
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(latitudeValue), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return 0, 0, false
+	}
+
+	longitude, err = strconv.ParseFloat(strings.TrimSpace(longitudeValue), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return 0, 0, false
+	}
+
+	return latitude, longitude, true
+}
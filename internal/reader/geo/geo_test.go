@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package geo // import "miniflux.app/v2/internal/reader/geo"
+
+import "testing"
+
+func TestCoordinates(t *testing.T) {
+	scenarios := []struct {
+		element   GeoItemElement
+		found     bool
+		latitude  float64
+		longitude float64
+	}{
+		{GeoItemElement{}, false, 0, 0},
+		{GeoItemElement{GeoRSSPoint: "45.256 -71.92"}, true, 45.256, -71.92},
+		{GeoItemElement{GeoRSSPoint: " 45.256,-71.92 "}, true, 45.256, -71.92},
+		{GeoItemElement{GeoRSSPoint: "45.256"}, false, 0, 0},
+		{GeoItemElement{GeoRSSPoint: "95 10"}, false, 0, 0},
+		{GeoItemElement{GeoRSSPoint: "10 190"}, false, 0, 0},
+		{GeoItemElement{GeoRSSPoint: "invalid", GeoLatitude: "55.701", GeoLongitude: "12.552"}, true, 55.701, 12.552},
+		{GeoItemElement{GeoRSSWhere: GeoRSSWhere{Position: "45.256 -71.92"}}, true, 45.256, -71.92},
+		{GeoItemElement{GeoPoint: W3CGeoPoint{Latitude: "55.701", Longitude: "12.552"}}, true, 55.701, 12.552},
+		{GeoItemElement{GeoLatitude: "55.701"}, false, 0, 0},
+	}
+
+	for _, scenario := range scenarios {
+		latitude, longitude, found := scenario.element.Coordinates()
+		if found != scenario.found || latitude != scenario.latitude || longitude != scenario.longitude {
+			t.Errorf(`Unexpected coordinates for %+v, got %v %v %v`, scenario.element, latitude, longitude, found)
+		}
+	}
+}
@@ -5,8 +5,11 @@ package handler // import "miniflux.app/v2/internal/reader/handler"
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"io"
 	"log/slog"
+	"time"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/integration"
@@ -14,11 +17,16 @@ import (
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/icon"
+	"miniflux.app/v2/internal/reader/pagemonitor"
 	"miniflux.app/v2/internal/reader/parser"
 	"miniflux.app/v2/internal/reader/processor"
 	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/tracing"
 )
 
+// maxIconRetryDelay is the longest delay between two icon lookups of a feed without icon.
+const maxIconRetryDelay = 30 * 24 * time.Hour
+
 var (
 	ErrCategoryNotFound = errors.New("fetcher: category not found")
 	ErrFeedNotFound     = errors.New("fetcher: feed not found")
@@ -44,10 +52,11 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		return nil, locale.NewLocalizedErrorWrapper(ErrDuplicatedFeed, "error.duplicated_feed")
 	}
 
-	subscription, parseErr := parser.ParseFeed(feedCreationRequest.FeedURL, feedCreationRequest.Content)
+	subscription, parseErr := parseFeed(feedCreationRequest.FeedURL, "", feedCreationRequest.RelaxedParsing, feedCreationRequest.Content)
 	if parseErr != nil {
 		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 	}
+	webSubHubURL, webSubTopicURL := subscription.HubURL, subscription.FeedURL
 
 	subscription.UserID = userID
 	subscription.UserAgent = feedCreationRequest.UserAgent
@@ -68,9 +77,19 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 	subscription.LastModifiedHeader = feedCreationRequest.LastModified
 	subscription.FeedURL = feedCreationRequest.FeedURL
 	subscription.DisableHTTP2 = feedCreationRequest.DisableHTTP2
+	subscription.RelaxedParsing = feedCreationRequest.RelaxedParsing
+	subscription.FetchViaHeadlessBrowser = feedCreationRequest.FetchViaHeadlessBrowser
+	subscription.ContentExtractor = feedCreationRequest.ContentExtractor
+	subscription.RewriteScript = feedCreationRequest.RewriteScript
 	subscription.WithCategoryID(feedCreationRequest.CategoryID)
 	subscription.CheckedNow()
 
+	if feedCreationRequest.UseFeedCategory {
+		if storeErr := fileFeedInItsCategory(store, subscription); storeErr != nil {
+			return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+		}
+	}
+
 	processor.ProcessFeedEntries(store, subscription, user, true)
 
 	if storeErr := store.CreateFeed(subscription); storeErr != nil {
@@ -97,10 +116,13 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		store,
 		requestBuilder,
 		subscription.ID,
+		subscription.Title,
 		subscription.SiteURL,
 		subscription.IconURL,
 	)
 
+	checkWebSubSubscription(store, subscription.ID, webSubHubURL, webSubTopicURL)
+
 	return subscription, nil
 }
 
@@ -148,10 +170,11 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, locale.NewLocalizedErrorWrapper(ErrDuplicatedFeed, "error.duplicated_feed")
 	}
 
-	subscription, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+	subscription, parseErr := parseFeed(responseHandler.EffectiveURL(), feedCreationRequest.PageMonitorSelector, feedCreationRequest.RelaxedParsing, bytes.NewReader(responseBody))
 	if parseErr != nil {
 		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 	}
+	webSubHubURL, webSubTopicURL := webSubLinks(responseHandler, subscription)
 
 	subscription.UserID = userID
 	subscription.UserAgent = feedCreationRequest.UserAgent
@@ -169,12 +192,23 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	subscription.BlocklistRules = feedCreationRequest.BlocklistRules
 	subscription.KeeplistRules = feedCreationRequest.KeeplistRules
 	subscription.UrlRewriteRules = feedCreationRequest.UrlRewriteRules
+	subscription.PageMonitorSelector = feedCreationRequest.PageMonitorSelector
+	subscription.RelaxedParsing = feedCreationRequest.RelaxedParsing
+	subscription.FetchViaHeadlessBrowser = feedCreationRequest.FetchViaHeadlessBrowser
+	subscription.ContentExtractor = feedCreationRequest.ContentExtractor
+	subscription.RewriteScript = feedCreationRequest.RewriteScript
 	subscription.EtagHeader = responseHandler.ETag()
 	subscription.LastModifiedHeader = responseHandler.LastModified()
 	subscription.FeedURL = responseHandler.EffectiveURL()
 	subscription.WithCategoryID(feedCreationRequest.CategoryID)
 	subscription.CheckedNow()
 
+	if feedCreationRequest.UseFeedCategory {
+		if storeErr := fileFeedInItsCategory(store, subscription); storeErr != nil {
+			return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+		}
+	}
+
 	processor.ProcessFeedEntries(store, subscription, user, true)
 
 	if storeErr := store.CreateFeed(subscription); storeErr != nil {
@@ -191,17 +225,74 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		store,
 		requestBuilder,
 		subscription.ID,
+		subscription.Title,
 		subscription.SiteURL,
 		subscription.IconURL,
 	)
+
+	checkWebSubSubscription(store, subscription.ID, webSubHubURL, webSubTopicURL)
+
 	return subscription, nil
 }
 
-// RefreshFeed refreshes a feed.
-func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool) *locale.LocalizedErrorWrapper {
-	slog.Debug("Begin feed refresh process",
+// fileFeedInItsCategory moves the new feed to the category declared by the feed itself.
+// An existing category with the same title is preferred, otherwise the first category of the feed is created.
+func fileFeedInItsCategory(store *storage.Storage, feed *model.Feed) error {
+	for _, title := range feed.Categories {
+		category, err := store.CategoryByTitle(feed.UserID, title)
+		if err != nil {
+			return err
+		}
+		if category != nil {
+			feed.WithCategoryID(category.ID)
+			return nil
+		}
+	}
+
+	if len(feed.Categories) == 0 {
+		return nil
+	}
+
+	category, err := store.CreateCategory(feed.UserID, &model.CategoryRequest{Title: feed.Categories[0]})
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Created category from the feed",
+		slog.Int64("user_id", feed.UserID),
+		slog.Int64("category_id", category.ID),
+		slog.String("category_title", category.Title),
+	)
+
+	feed.WithCategoryID(category.ID)
+	return nil
+}
+
+// RefreshFeed refreshes a feed, the span stored in the context becomes the parent of the refresh span.
+func RefreshFeed(ctx context.Context, store *storage.Storage, userID, feedID int64, forceRefresh bool) *locale.LocalizedErrorWrapper {
+	ctx, span := tracing.Start(ctx, "RefreshFeed",
+		tracing.Int64("user_id", userID),
+		tracing.Int64("feed_id", feedID),
+		tracing.Bool("force_refresh", forceRefresh),
+	)
+	defer span.End()
+
+	localizedError := refreshFeed(ctx, store, userID, feedID, forceRefresh)
+	if localizedError != nil {
+		span.RecordError(localizedError.Error())
+	}
+
+	return localizedError
+}
+
+func refreshFeed(ctx context.Context, store *storage.Storage, userID, feedID int64, forceRefresh bool) *locale.LocalizedErrorWrapper {
+	logger := slog.With(
+		slog.String("component", "refresh"),
 		slog.Int64("user_id", userID),
 		slog.Int64("feed_id", feedID),
+	)
+
+	logger.Debug("Begin feed refresh process",
 		slog.Bool("force_refresh", forceRefresh),
 	)
 
@@ -219,6 +310,14 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		return locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found")
 	}
 
+	// The hubs can't reach Miniflux anymore when WebSub is disabled, the feeds must be polled again.
+	if !config.Opts.WebSub() && originalFeed.WebSubLeaseExpiresAt != nil {
+		if storeErr := store.RemoveWebSubSubscription(feedID); storeErr != nil {
+			return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+		}
+		originalFeed.WebSubLeaseExpiresAt = nil
+	}
+
 	weeklyEntryCount := 0
 	newTTL := 0
 	if config.Opts.PollingScheduler() == model.SchedulerEntryFrequency {
@@ -232,6 +331,14 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 	originalFeed.CheckedNow()
 	originalFeed.ScheduleNextCheck(weeklyEntryCount, newTTL)
 
+	// The newsletters are received by email, there is no document to fetch.
+	if originalFeed.IsNewsletter() {
+		if storeErr := store.UpdateFeed(originalFeed); storeErr != nil {
+			return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+		}
+		return nil
+	}
+
 	requestBuilder := fetcher.NewRequestBuilder()
 	requestBuilder.WithUsernameAndPassword(originalFeed.Username, originalFeed.Password)
 	requestBuilder.WithUserAgent(originalFeed.UserAgent, config.Opts.HTTPClientUserAgent())
@@ -246,13 +353,24 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 	if !ignoreHTTPCache {
 		requestBuilder.WithETag(originalFeed.EtagHeader)
 		requestBuilder.WithLastModified(originalFeed.LastModifiedHeader)
+
+		// The items of a web page monitor are not published incrementally, the whole page is always needed.
+		if originalFeed.EtagHeader != "" && originalFeed.PageMonitorSelector == "" {
+			requestBuilder.WithDeltaEncoding()
+		}
 	}
 
+	_, fetchSpan := tracing.StartWithKind(ctx, "FetchFeed", tracing.KindClient, tracing.String("url.full", originalFeed.FeedURL))
+	requestBuilder.WithTraceParent(fetchSpan.TraceParent())
 	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(originalFeed.FeedURL))
 	defer responseHandler.Close()
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		fetchSpan.RecordError(localizedError.Error())
+	}
+	fetchSpan.End()
 
 	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
-		slog.Warn("Unable to fetch feed", slog.String("feed_url", originalFeed.FeedURL), slog.Any("error", localizedError.Error()))
+		logger.Warn("Unable to fetch feed", slog.String("feed_url", originalFeed.FeedURL), slog.Any("error", localizedError.Error()))
 		originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
 		store.UpdateFeedError(originalFeed)
 		return localizedError
@@ -266,18 +384,18 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 	}
 
 	if ignoreHTTPCache || responseHandler.IsModified(originalFeed.EtagHeader, originalFeed.LastModifiedHeader) {
-		slog.Debug("Feed modified",
-			slog.Int64("user_id", userID),
-			slog.Int64("feed_id", feedID),
-		)
+		logger.Debug("Feed modified")
 
 		responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
 		if localizedError != nil {
-			slog.Warn("Unable to fetch feed", slog.String("feed_url", originalFeed.FeedURL), slog.Any("error", localizedError.Error()))
+			logger.Warn("Unable to fetch feed", slog.String("feed_url", originalFeed.FeedURL), slog.Any("error", localizedError.Error()))
 			return localizedError
 		}
 
-		updatedFeed, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+		_, parseSpan := tracing.Start(ctx, "ParseFeed", tracing.Int("body_size", len(responseBody)))
+		updatedFeed, parseErr := parseFeed(responseHandler.EffectiveURL(), originalFeed.PageMonitorSelector, originalFeed.RelaxedParsing, bytes.NewReader(responseBody))
+		parseSpan.RecordError(parseErr)
+		parseSpan.End()
 		if parseErr != nil {
 			localizedError := locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 
@@ -292,21 +410,37 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 
 		// If the feed has a TTL defined, we use it to make sure we don't check it too often.
 		newTTL = updatedFeed.TTL
+		originalFeed.SkipHours = updatedFeed.SkipHours
+		originalFeed.SkipDays = updatedFeed.SkipDays
+		originalFeed.ScrapeEntryMetadata = updatedFeed.ScrapeEntryMetadata
 		// Set the next check at with updated arguments.
 		originalFeed.ScheduleNextCheck(weeklyEntryCount, newTTL)
-		slog.Debug("Updated next check date",
-			slog.Int64("user_id", userID),
-			slog.Int64("feed_id", feedID),
+		logger.Debug("Updated next check date",
 			slog.Int("ttl", newTTL),
 			slog.Time("new_next_check_at", originalFeed.NextCheckAt),
 		)
 
 		originalFeed.Entries = updatedFeed.Entries
+		_, processSpan := tracing.Start(ctx, "ProcessFeedEntries", tracing.Int("entries", len(originalFeed.Entries)))
 		processor.ProcessFeedEntries(store, originalFeed, user, forceRefresh)
+		processSpan.End()
 
 		// We don't update existing entries when the crawler is enabled (we crawl only inexisting entries). Unless it is forced to refresh
-		updateExistingEntries := forceRefresh || !originalFeed.Crawler
-		newEntries, storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, updateExistingEntries)
+		// The metadata of the entries are also only scraped for the new entries.
+		updateExistingEntries := forceRefresh || (!originalFeed.Crawler && !originalFeed.ScrapeEntryMetadata)
+		_, storeSpan := tracing.StartWithKind(ctx, "RefreshFeedEntries", tracing.KindClient, tracing.String("db.system", "postgresql"))
+		var newEntries model.Entries
+		var storeErr error
+		if responseHandler.IsDeltaFeed() {
+			// A delta feed only contains the new items, the entries missing from the document are still in the feed.
+			logger.Debug("Received delta feed", slog.Int("entries", len(originalFeed.Entries)))
+			newEntries, storeErr = store.AddFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, updateExistingEntries)
+		} else {
+			newEntries, storeErr = store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, updateExistingEntries)
+		}
+		storeSpan.RecordError(storeErr)
+		storeSpan.SetAttributes(tracing.Int("new_entries", len(newEntries)))
+		storeSpan.End()
 		if storeErr != nil {
 			localizedError := locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 			originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
@@ -314,15 +448,24 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			return localizedError
 		}
 
+		// A quiet feed that suddenly publishes again must be checked sooner, without waiting for the next refresh.
+		if len(newEntries) > 0 && config.Opts.PollingScheduler() == model.SchedulerEntryFrequency {
+			if weeklyEntryCount, storeErr = store.WeeklyFeedEntryCount(userID, feedID); storeErr == nil {
+				originalFeed.ScheduleNextCheck(weeklyEntryCount, newTTL)
+				logger.Debug("Updated next check date after new entries",
+					slog.Int("weekly_entry_count", weeklyEntryCount),
+					slog.Time("new_next_check_at", originalFeed.NextCheckAt),
+				)
+			}
+		}
+
 		userIntegrations, intErr := store.Integration(userID)
 		if intErr != nil {
-			slog.Error("Fetching integrations failed; the refresh process will go on, but no integrations will run this time",
-				slog.Int64("user_id", userID),
-				slog.Int64("feed_id", feedID),
+			logger.Error("Fetching integrations failed; the refresh process will go on, but no integrations will run this time",
 				slog.Any("error", intErr),
 			)
 		} else if userIntegrations != nil && len(newEntries) > 0 {
-			go integration.PushEntries(originalFeed, newEntries, userIntegrations)
+			go integration.PushEntries(store, originalFeed, newEntries, userIntegrations, user)
 		}
 
 		// We update caching headers only if the feed has been modified,
@@ -334,20 +477,26 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			store,
 			requestBuilder,
 			originalFeed.ID,
+			originalFeed.Title,
 			originalFeed.SiteURL,
 			updatedFeed.IconURL,
 		)
+
+		webSubHubURL, webSubTopicURL := webSubLinks(responseHandler, updatedFeed)
+		checkWebSubSubscription(store, originalFeed.ID, webSubHubURL, webSubTopicURL)
 	} else {
-		slog.Debug("Feed not modified",
-			slog.Int64("user_id", userID),
-			slog.Int64("feed_id", feedID),
-		)
+		logger.Debug("Feed not modified")
+		renewWebSubSubscription(store, originalFeed.ID)
 	}
 
 	originalFeed.ResetErrorCounter()
 
-	if storeErr := store.UpdateFeed(originalFeed); storeErr != nil {
-		localizedError := locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	_, updateSpan := tracing.StartWithKind(ctx, "UpdateFeed", tracing.KindClient, tracing.String("db.system", "postgresql"))
+	updateErr := store.UpdateFeed(originalFeed)
+	updateSpan.RecordError(updateErr)
+	updateSpan.End()
+	if updateErr != nil {
+		localizedError := locale.NewLocalizedErrorWrapper(updateErr, "error.database_error", updateErr)
 		originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
 		store.UpdateFeedError(originalFeed)
 		return localizedError
@@ -356,31 +505,167 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 	return nil
 }
 
-func checkFeedIcon(store *storage.Storage, requestBuilder *fetcher.RequestBuilder, feedID int64, websiteURL, feedIconURL string) {
-	if !store.HasIcon(feedID) {
-		iconFinder := icon.NewIconFinder(requestBuilder, websiteURL, feedIconURL)
-		if icon, err := iconFinder.FindIcon(); err != nil {
-			slog.Debug("Unable to find feed icon",
+// parseFeed parses the feed document, or extracts the monitored fragment when the feed is a web page monitor.
+func parseFeed(baseURL, pageMonitorSelector string, relaxedParsing bool, data io.ReadSeeker) (*model.Feed, error) {
+	if pageMonitorSelector != "" {
+		return pagemonitor.Parse(baseURL, data, pageMonitorSelector)
+	}
+	if relaxedParsing {
+		return parser.ParseRelaxedFeed(baseURL, data)
+	}
+	return parser.ParseFeed(baseURL, data)
+}
+
+// RefreshFeedIcon downloads the icon of the feed again and replaces the stored icon if a new one is found.
+// The previous icon is kept when the website doesn't have any icon anymore, custom icons are never replaced.
+func RefreshFeedIcon(store *storage.Storage, userID, feedID int64) error {
+	feed, err := store.FeedByID(userID, feedID)
+	if err != nil {
+		return err
+	}
+
+	if feed == nil {
+		return ErrFeedNotFound
+	}
+
+	if feed.CustomIcon {
+		return nil
+	}
+
+	if err := store.TouchFeedIcon(feedID); err != nil {
+		return err
+	}
+
+	iconFinder := icon.NewIconFinder(newIconRequestBuilder(feed), feed.SiteURL, "")
+	icon, err := iconFinder.FindIcon()
+	if err != nil {
+		return err
+	}
+
+	if icon == nil {
+		slog.Debug("No icon found, keeping the previous icon",
+			slog.Int64("feed_id", feedID),
+			slog.String("website_url", feed.SiteURL),
+		)
+		return nil
+	}
+
+	return store.ReplaceFeedIcon(feedID, icon)
+}
+
+// SetCustomFeedIcon replaces the icon of the feed by the image available at the given URL.
+// An empty URL removes the custom icon and the icon of the website is discovered again.
+func SetCustomFeedIcon(store *storage.Storage, userID, feedID int64, iconURL string) error {
+	feed, err := store.FeedByID(userID, feedID)
+	if err != nil {
+		return err
+	}
+
+	if feed == nil {
+		return ErrFeedNotFound
+	}
+
+	if iconURL == "" {
+		if !feed.CustomIcon {
+			return nil
+		}
+
+		if err := store.ResetCustomFeedIcon(feedID); err != nil {
+			return err
+		}
+
+		return RefreshFeedIcon(store, userID, feedID)
+	}
+
+	iconFinder := icon.NewIconFinder(newIconRequestBuilder(feed), feed.SiteURL, "")
+	icon, err := iconFinder.FetchCustomIcon(iconURL)
+	if err != nil {
+		return err
+	}
+
+	return store.SetCustomFeedIcon(feedID, icon)
+}
+
+// UploadCustomFeedIcon replaces the icon of the feed by an image uploaded by the user.
+func UploadCustomFeedIcon(store *storage.Storage, feedID int64, content []byte, contentType string) error {
+	icon, err := icon.NewIconFromContent(content, contentType)
+	if err != nil {
+		return err
+	}
+
+	return store.SetCustomFeedIcon(feedID, icon)
+}
+
+func newIconRequestBuilder(feed *model.Feed) *fetcher.RequestBuilder {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUsernameAndPassword(feed.Username, feed.Password)
+	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
+	requestBuilder.UseProxy(feed.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+	return requestBuilder
+}
+
+// checkFeedIcon looks for the icon of the website when the feed doesn't have one yet.
+// A placeholder icon made of the first letter of the title is used until an icon is found.
+func checkFeedIcon(store *storage.Storage, requestBuilder *fetcher.RequestBuilder, feedID int64, title, websiteURL, feedIconURL string) {
+	if store.HasDiscoveredIcon(feedID) {
+		return
+	}
+
+	cooldown := time.Duration(config.Opts.IconRetryCooldownHours()) * time.Hour
+	if cooldown > 0 && store.IsIconLookupDeferred(feedID) {
+		slog.Debug("Skipping icon lookup after a recent failure",
+			slog.Int64("feed_id", feedID),
+			slog.String("website_url", websiteURL),
+		)
+		return
+	}
+
+	iconFinder := icon.NewIconFinder(requestBuilder, websiteURL, feedIconURL)
+	feedIcon, err := iconFinder.FindIcon()
+	switch {
+	case err != nil:
+		slog.Debug("Unable to find feed icon",
+			slog.Int64("feed_id", feedID),
+			slog.String("website_url", websiteURL),
+			slog.String("feed_icon_url", feedIconURL),
+			slog.Any("error", err),
+		)
+	case feedIcon == nil:
+		slog.Debug("No icon found",
+			slog.Int64("feed_id", feedID),
+			slog.String("website_url", websiteURL),
+			slog.String("feed_icon_url", feedIconURL),
+		)
+	default:
+		if err := store.CreateFeedIcon(feedID, feedIcon); err != nil {
+			slog.Error("Unable to store feed icon",
 				slog.Int64("feed_id", feedID),
 				slog.String("website_url", websiteURL),
 				slog.String("feed_icon_url", feedIconURL),
 				slog.Any("error", err),
 			)
-		} else if icon == nil {
-			slog.Debug("No icon found",
+		}
+		return
+	}
+
+	if cooldown > 0 {
+		if err := store.RecordIconFailure(feedID, cooldown, maxIconRetryDelay); err != nil {
+			slog.Error("Unable to record icon failure",
 				slog.Int64("feed_id", feedID),
-				slog.String("website_url", websiteURL),
-				slog.String("feed_icon_url", feedIconURL),
+				slog.Any("error", err),
 			)
-		} else {
-			if err := store.CreateFeedIcon(feedID, icon); err != nil {
-				slog.Error("Unable to store feed icon",
-					slog.Int64("feed_id", feedID),
-					slog.String("website_url", websiteURL),
-					slog.String("feed_icon_url", feedIconURL),
-					slog.Any("error", err),
-				)
-			}
 		}
 	}
+
+	if err := store.CreatePlaceholderFeedIcon(feedID, icon.NewPlaceholderIcon(title, websiteURL)); err != nil {
+		slog.Error("Unable to store placeholder icon",
+			slog.Int64("feed_id", feedID),
+			slog.Any("error", err),
+		)
+	}
 }
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"log/slog"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/icon"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/storage"
+)
+
+// CreateNewsletterFeed creates a feed without document, its entries are the newsletters sent to a generated email address.
+func CreateNewsletterFeed(store *storage.Storage, userID, categoryID int64, title string) (*model.Feed, *locale.LocalizedErrorWrapper) {
+	if !store.CategoryIDExists(userID, categoryID) {
+		return nil, locale.NewLocalizedErrorWrapper(ErrCategoryNotFound, "error.category_not_found")
+	}
+
+	// The token is the local part of the address, it must be hard to guess to prevent spam.
+	feed := &model.Feed{
+		UserID:          userID,
+		Title:           title,
+		NewsletterToken: crypto.GenerateRandomStringHex(10),
+	}
+	feed.FeedURL = "mailto:" + feed.NewsletterAddress()
+	feed.WithCategoryID(categoryID)
+	feed.CheckedNow()
+
+	if storeErr := store.CreateFeed(feed); storeErr != nil {
+		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	slog.Debug("Created newsletter feed",
+		slog.Int64("user_id", userID),
+		slog.Int64("feed_id", feed.ID),
+		slog.String("address", feed.NewsletterAddress()),
+	)
+
+	if err := store.CreatePlaceholderFeedIcon(feed.ID, icon.NewPlaceholderIcon(feed.Title, feed.FeedURL)); err != nil {
+		slog.Error("Unable to store placeholder feed icon",
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+	}
+
+	return feed, nil
+}
+
+// PushNewsletterEntry stores a newsletter received by email as a new entry of the feed.
+func PushNewsletterEntry(store *storage.Storage, userID, feedID int64, entry *model.Entry) *locale.LocalizedErrorWrapper {
+	user, storeErr := store.UserByID(userID)
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	feed, storeErr := store.FeedByID(userID, feedID)
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	if feed == nil {
+		return locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found")
+	}
+
+	if feed.Disabled {
+		return nil
+	}
+
+	feed.Entries = model.Entries{entry}
+	processor.ProcessFeedEntries(store, feed, user, false)
+
+	newEntries, storeErr := store.AddFeedEntries(userID, feedID, feed.Entries, false)
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	slog.Debug("Received newsletter",
+		slog.Int64("user_id", userID),
+		slog.Int64("feed_id", feedID),
+		slog.Int("new_entries", len(newEntries)),
+	)
+
+	userIntegrations, intErr := store.Integration(userID)
+	if intErr != nil {
+		slog.Error("Fetching integrations failed; the newsletter is stored, but no integrations will run this time",
+			slog.Int64("user_id", userID),
+			slog.Any("error", intErr),
+		)
+	} else if userIntegrations != nil && len(newEntries) > 0 {
+		go integration.PushEntries(store, feed, newEntries, userIntegrations, user)
+	}
+
+	return nil
+}
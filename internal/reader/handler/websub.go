@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"bytes"
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/reader/websub"
+	"miniflux.app/v2/internal/storage"
+)
+
+// PushFeedContent stores the entries of a feed document sent by a WebSub hub, without fetching the feed.
+func PushFeedContent(store *storage.Storage, userID, feedID int64, content []byte) *locale.LocalizedErrorWrapper {
+	user, storeErr := store.UserByID(userID)
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	feed, storeErr := store.FeedByID(userID, feedID)
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	if feed == nil {
+		return locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found")
+	}
+
+	if feed.Disabled {
+		return nil
+	}
+
+	updatedFeed, parseErr := parseFeed(feed.FeedURL, "", feed.RelaxedParsing, bytes.NewReader(content))
+	if parseErr != nil {
+		return locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
+	}
+
+	feed.Entries = updatedFeed.Entries
+	processor.ProcessFeedEntries(store, feed, user, false)
+
+	// The hubs usually send only the new entries, the other entries of the feed must be kept.
+	newEntries, storeErr := store.AddFeedEntries(userID, feedID, feed.Entries, !feed.Crawler)
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	slog.Debug("Received entries from WebSub hub",
+		slog.Int64("user_id", userID),
+		slog.Int64("feed_id", feedID),
+		slog.Int("entries", len(feed.Entries)),
+		slog.Int("new_entries", len(newEntries)),
+	)
+
+	userIntegrations, intErr := store.Integration(userID)
+	if intErr != nil {
+		slog.Error("Fetching integrations failed; the pushed entries are stored, but no integrations will run this time",
+			slog.Int64("user_id", userID),
+			slog.Any("error", intErr),
+		)
+	} else if userIntegrations != nil && len(newEntries) > 0 {
+		go integration.PushEntries(store, feed, newEntries, userIntegrations, user)
+	}
+
+	return nil
+}
+
+// checkWebSubSubscription subscribes the feed to the hub it advertises, and renews the subscription before the end of the lease.
+// The topic is the URL of the feed given by the publisher, it can be different from the URL used to fetch the feed.
+func checkWebSubSubscription(store *storage.Storage, feedID int64, hubURL, topicURL string) {
+	if !config.Opts.WebSub() {
+		return
+	}
+
+	subscription, err := store.WebSubSubscription(feedID)
+	if err != nil {
+		slog.Error("Unable to fetch WebSub subscription", slog.Int64("feed_id", feedID), slog.Any("error", err))
+		return
+	}
+
+	if hubURL == "" || topicURL == "" {
+		if subscription != nil {
+			if err := store.RemoveWebSubSubscription(feedID); err != nil {
+				slog.Error("Unable to remove WebSub subscription", slog.Int64("feed_id", feedID), slog.Any("error", err))
+			}
+		}
+		return
+	}
+
+	secret := crypto.GenerateRandomStringHex(20)
+	if subscription != nil && subscription.HubURL == hubURL && subscription.TopicURL == topicURL {
+		if !subscription.NeedsRenewal() {
+			return
+		}
+
+		// The hub keeps signing the content with the current secret until it verifies the renewal.
+		secret = subscription.Secret
+	}
+
+	if err := store.SaveWebSubSubscription(feedID, hubURL, topicURL, secret); err != nil {
+		slog.Error("Unable to save WebSub subscription", slog.Int64("feed_id", feedID), slog.Any("error", err))
+		return
+	}
+
+	if err := websub.Subscribe(hubURL, topicURL, websub.CallbackURL(feedID), secret, model.WebSubLeaseDuration); err != nil {
+		slog.Warn("Unable to subscribe to WebSub hub",
+			slog.Int64("feed_id", feedID),
+			slog.String("hub_url", hubURL),
+			slog.String("topic_url", topicURL),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	slog.Debug("Subscription request sent to WebSub hub",
+		slog.Int64("feed_id", feedID),
+		slog.String("hub_url", hubURL),
+		slog.String("topic_url", topicURL),
+	)
+}
+
+// renewWebSubSubscription renews the current subscription of the feed when the feed was not modified.
+func renewWebSubSubscription(store *storage.Storage, feedID int64) {
+	if !config.Opts.WebSub() {
+		return
+	}
+
+	if subscription, err := store.WebSubSubscription(feedID); err == nil && subscription != nil {
+		checkWebSubSubscription(store, feedID, subscription.HubURL, subscription.TopicURL)
+	}
+}
+
+// webSubLinks returns the hub and the topic of the feed, the HTTP headers take precedence over the feed document.
+func webSubLinks(responseHandler *fetcher.ResponseHandler, feed *model.Feed) (hubURL, topicURL string) {
+	hubURL, topicURL = feed.HubURL, feed.FeedURL
+	if headerHubURL := responseHandler.LinkHeader("hub"); headerHubURL != "" {
+		hubURL = headerHubURL
+		if headerTopicURL := responseHandler.LinkHeader("self"); headerTopicURL != "" {
+			topicURL = headerTopicURL
+		}
+	}
+	return hubURL, topicURL
+}
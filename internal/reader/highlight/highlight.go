@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package highlight // import "miniflux.app/v2/internal/reader/highlight"
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"golang.org/x/net/html"
+)
+
+// Larger code blocks are not highlighted to bound the processing time of the entries.
+const maxCodeBlockSize = 64 * 1024
+
+// classes are the CSS classes of the highlighted tokens, the sanitizer only keeps these ones.
+var classes = []string{
+	"hl-comment",
+	"hl-keyword",
+	"hl-operator",
+	"hl-type",
+	"hl-constant",
+	"hl-number",
+	"hl-string",
+	"hl-function",
+	"hl-builtin",
+	"hl-tag",
+	"hl-attribute",
+	"hl-inserted",
+	"hl-deleted",
+	"hl-heading",
+}
+
+// IsHighlightClass returns true if the value is the CSS class of a highlighted token.
+func IsHighlightClass(value string) bool {
+	return slices.Contains(classes, value)
+}
+
+// HighlightCodeBlocks highlights the syntax of the code blocks marked with their language,
+// like <pre><code class="language-go"> or <pre class="lang-go">. The other blocks are left untouched.
+func HighlightCodeBlocks(entryContent string) string {
+	if !strings.Contains(entryContent, "lang") {
+		return entryContent
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(entryContent))
+	if err != nil {
+		return entryContent
+	}
+
+	changed := false
+
+	doc.Find("pre").Each(func(i int, pre *goquery.Selection) {
+		block := pre
+		if code := pre.ChildrenFiltered("code"); code.Length() == 1 && pre.Children().Length() == 1 {
+			block = code
+		}
+
+		// The code blocks containing markup, like links or already highlighted tokens, are kept as is.
+		if block.Children().Length() > 0 {
+			return
+		}
+
+		language := codeLanguage(block)
+		if language == "" && block != pre {
+			language = codeLanguage(pre)
+		}
+
+		if language == "" {
+			return
+		}
+
+		lexer := lexers.Get(language)
+		if lexer == nil {
+			return
+		}
+
+		source := block.Text()
+		if len(source) > maxCodeBlockSize {
+			return
+		}
+
+		highlightedCode, err := highlightSource(lexer, source)
+		if err != nil {
+			slog.Debug("Unable to highlight code block",
+				slog.String("language", language),
+				slog.Any("error", err),
+			)
+			return
+		}
+
+		block.SetHtml(highlightedCode)
+		changed = true
+	})
+
+	if !changed {
+		return entryContent
+	}
+
+	output, _ := doc.Find("body").First().Html()
+	return output
+}
+
+// codeLanguage returns the language of the element from its language-* or lang-* class, or from its lang attribute.
+func codeLanguage(element *goquery.Selection) string {
+	for _, class := range strings.Fields(element.AttrOr("class", "")) {
+		for _, prefix := range []string{"language-", "lang-"} {
+			if language, found := strings.CutPrefix(class, prefix); found && language != "" {
+				return strings.ToLower(language)
+			}
+		}
+	}
+
+	if element.Is("pre") {
+		return strings.ToLower(strings.TrimSpace(element.AttrOr("lang", "")))
+	}
+
+	return ""
+}
+
+func highlightSource(lexer chroma.Lexer, source string) (string, error) {
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, source)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer strings.Builder
+	for _, token := range iterator.Tokens() {
+		value := html.EscapeString(token.Value)
+		if class := tokenClass(token.Type); class != "" && strings.TrimSpace(token.Value) != "" {
+			buffer.WriteString(`<span class="` + class + `">` + value + `</span>`)
+		} else {
+			buffer.WriteString(value)
+		}
+	}
+
+	// Some lexers add a line feed at the end of the source.
+	output := buffer.String()
+	if !strings.HasSuffix(source, "\n") {
+		output = strings.TrimSuffix(output, "\n")
+	}
+
+	return output, nil
+}
+
+func tokenClass(tokenType chroma.TokenType) string {
+	switch {
+	case tokenType == chroma.KeywordType:
+		return "hl-type"
+	case tokenType == chroma.KeywordConstant:
+		return "hl-constant"
+	case tokenType.InCategory(chroma.Keyword):
+		return "hl-keyword"
+	case tokenType.InCategory(chroma.Comment):
+		return "hl-comment"
+	case tokenType.InCategory(chroma.Operator):
+		return "hl-operator"
+	case tokenType.InSubCategory(chroma.LiteralString):
+		return "hl-string"
+	case tokenType.InSubCategory(chroma.LiteralNumber):
+		return "hl-number"
+	case tokenType == chroma.NameFunction || tokenType == chroma.NameFunctionMagic || tokenType == chroma.NameDecorator:
+		return "hl-function"
+	case tokenType == chroma.NameClass || tokenType == chroma.NameNamespace || tokenType == chroma.NameException:
+		return "hl-type"
+	case tokenType == chroma.NameConstant || tokenType == chroma.NameBuiltinPseudo:
+		return "hl-constant"
+	case tokenType == chroma.NameBuiltin:
+		return "hl-builtin"
+	case tokenType == chroma.NameTag:
+		return "hl-tag"
+	case tokenType == chroma.NameAttribute:
+		return "hl-attribute"
+	case tokenType == chroma.GenericInserted:
+		return "hl-inserted"
+	case tokenType == chroma.GenericDeleted:
+		return "hl-deleted"
+	case tokenType == chroma.GenericHeading || tokenType == chroma.GenericSubheading:
+		return "hl-heading"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package highlight // import "miniflux.app/v2/internal/reader/highlight"
+
+import (
+	"testing"
+)
+
+func TestHighlightCodeBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "code element with language class",
+			input:    `<pre><code class="language-go">func main() {}</code></pre>`,
+			expected: `<pre><code class="language-go"><span class="hl-keyword">func</span> <span class="hl-function">main</span>() {}</code></pre>`,
+		},
+		{
+			name:     "pre element with lang class",
+			input:    `<pre class="lang-python"># comment</pre>`,
+			expected: `<pre class="lang-python"><span class="hl-comment"># comment</span></pre>`,
+		},
+		{
+			name:     "special characters are escaped",
+			input:    `<pre><code class="language-c">a &lt; b</code></pre>`,
+			expected: `<pre><code class="language-c">a <span class="hl-operator">&lt;</span> b</code></pre>`,
+		},
+		{
+			name:     "code block without language",
+			input:    `<pre><code>func main() {}</code></pre>`,
+			expected: `<pre><code>func main() {}</code></pre>`,
+		},
+		{
+			name:     "unknown language",
+			input:    `<p>Some language</p><pre><code class="language-unknown">func main() {}</code></pre>`,
+			expected: `<p>Some language</p><pre><code class="language-unknown">func main() {}</code></pre>`,
+		},
+		{
+			name:     "code block with markup",
+			input:    `<pre><code class="language-go">func <a href="https://example.org/">main</a>() {}</code></pre>`,
+			expected: `<pre><code class="language-go">func <a href="https://example.org/">main</a>() {}</code></pre>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if output := HighlightCodeBlocks(test.input); output != test.expected {
+				t.Errorf("Unexpected output:\ngot:  %s\nwant: %s", output, test.expected)
+			}
+		})
+	}
+}
+
+func TestIsHighlightClass(t *testing.T) {
+	if !IsHighlightClass("hl-keyword") {
+		t.Error(`The hl-keyword class should be valid`)
+	}
+
+	if IsHighlightClass("hl-keyword hidden") || IsHighlightClass("hidden") {
+		t.Error(`The other classes should not be valid`)
+	}
+}
@@ -4,12 +4,20 @@
 package icon // import "miniflux.app/v2/internal/reader/icon"
 
 import (
+	"cmp"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
+	"mime"
+	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"miniflux.app/v2/internal/config"
@@ -63,7 +71,20 @@ func (f *IconFinder) FindIcon() (*model.Icon, error) {
 		return icon, nil
 	}
 
-	return f.FetchDefaultIcon()
+	if !config.Opts.HasIconService() {
+		return f.FetchDefaultIcon()
+	}
+
+	if icon, err := f.FetchDefaultIcon(); err != nil {
+		slog.Debug("Unable to download default icon",
+			slog.String("website_url", f.websiteURL),
+			slog.Any("error", err),
+		)
+	} else if icon != nil {
+		return icon, nil
+	}
+
+	return f.FetchIconFromService(config.Opts.IconServiceURL())
 }
 
 func (f *IconFinder) FetchDefaultIcon() (*model.Icon, error) {
@@ -84,6 +105,26 @@ func (f *IconFinder) FetchDefaultIcon() (*model.Icon, error) {
 	return icon, nil
 }
 
+// FetchIconFromService downloads the icon from a favicon service.
+// The {hostname} and {url} placeholders of the template are replaced by the website hostname and the escaped website URL.
+func (f *IconFinder) FetchIconFromService(serviceURL string) (*model.Icon, error) {
+	iconURL := iconServiceURL(serviceURL, f.websiteURL)
+	if iconURL == "" {
+		return nil, fmt.Errorf(`icon: unable to find the hostname of %q`, f.websiteURL)
+	}
+
+	slog.Debug("Fetching icon from favicon service",
+		slog.String("website_url", f.websiteURL),
+		slog.String("icon_url", iconURL),
+	)
+
+	// The settings of the feed, like its credentials and cookies, are not sent to the favicon service.
+	requestBuilder := f.requestBuilder.WithNetworkSettingsOnly()
+	requestBuilder.WithUserAgent("", config.Opts.HTTPClientUserAgent())
+
+	return f.downloadIcon(requestBuilder, iconURL)
+}
+
 func (f *IconFinder) FetchFeedIcon() (*model.Icon, error) {
 	slog.Debug("Fetching feed icon",
 		slog.String("website_url", f.websiteURL),
@@ -98,6 +139,8 @@ func (f *IconFinder) FetchFeedIcon() (*model.Icon, error) {
 	return f.DownloadIcon(iconURL)
 }
 
+// FetchIconsFromHTMLDocument downloads the icon declared in the website index page.
+// The icon declared for the dark color scheme, if any, is attached to the icon as its dark variant.
 func (f *IconFinder) FetchIconsFromHTMLDocument() (*model.Icon, error) {
 	slog.Debug("Searching icons from HTML document",
 		slog.String("website_url", f.websiteURL),
@@ -112,7 +155,7 @@ func (f *IconFinder) FetchIconsFromHTMLDocument() (*model.Icon, error) {
 		return nil, fmt.Errorf("icon: unable to download website index page: %w", localizedError.Error())
 	}
 
-	iconURLs, err := findIconURLsFromHTMLDocument(
+	icons, err := findIconURLsFromHTMLDocument(
 		responseHandler.Body(config.Opts.HTTPClientMaxBodySize()),
 		responseHandler.ContentType(),
 	)
@@ -122,9 +165,46 @@ func (f *IconFinder) FetchIconsFromHTMLDocument() (*model.Icon, error) {
 
 	slog.Debug("Searched icon from HTML document",
 		slog.String("website_url", f.websiteURL),
-		slog.String("icon_urls", strings.Join(iconURLs, ",")),
+		slog.String("icon_urls", strings.Join(icons.iconURLs, ",")),
+		slog.String("dark_icon_urls", strings.Join(icons.darkIconURLs, ",")),
+		slog.String("manifest_url", icons.manifestURL),
 	)
 
+	// Websites declaring only a dark icon are using it for all color schemes.
+	if len(icons.iconURLs) == 0 {
+		icons.iconURLs, icons.darkIconURLs = icons.darkIconURLs, nil
+	}
+
+	if len(icons.iconURLs) == 0 && icons.manifestURL != "" {
+		manifestURL, err := urllib.AbsoluteURL(rootURL, icons.manifestURL)
+		if err != nil {
+			return nil, fmt.Errorf(`icon: unable to convert manifest URL to absolute URL: %w`, err)
+		}
+
+		if icons.iconURLs, err = f.FetchIconURLsFromManifest(manifestURL); err != nil {
+			return nil, err
+		}
+	}
+
+	icon, err := f.downloadFirstIcon(icons.iconURLs)
+	if err != nil || icon == nil {
+		return icon, err
+	}
+
+	if darkIcon, err := f.downloadFirstIcon(icons.darkIconURLs); err != nil {
+		slog.Debug("Unable to download dark icon from HTML document",
+			slog.String("website_url", f.websiteURL),
+			slog.Any("error", err),
+		)
+	} else if darkIcon != nil && darkIcon.Hash != icon.Hash {
+		icon.DarkVariant = darkIcon
+	}
+
+	return icon, nil
+}
+
+// downloadFirstIcon returns the first icon of the list that can be downloaded.
+func (f *IconFinder) downloadFirstIcon(iconURLs []string) (*model.Icon, error) {
 	for _, iconURL := range iconURLs {
 		if strings.HasPrefix(iconURL, "data:") {
 			slog.Debug("Found icon with data URL",
@@ -133,7 +213,7 @@ func (f *IconFinder) FetchIconsFromHTMLDocument() (*model.Icon, error) {
 			return parseImageDataURL(iconURL)
 		}
 
-		iconURL, err = urllib.AbsoluteURL(f.websiteURL, iconURL)
+		iconURL, err := urllib.AbsoluteURL(f.websiteURL, iconURL)
 		if err != nil {
 			return nil, fmt.Errorf(`icon: unable to convert icon URL to absolute URL: %w`, err)
 		}
@@ -156,41 +236,187 @@ func (f *IconFinder) FetchIconsFromHTMLDocument() (*model.Icon, error) {
 	return nil, nil
 }
 
+// FetchIconURLsFromManifest returns the absolute URLs of the icons declared in the web app manifest, the largest icons first.
+func (f *IconFinder) FetchIconURLsFromManifest(manifestURL string) ([]string, error) {
+	slog.Debug("Searching icons from web app manifest",
+		slog.String("website_url", f.websiteURL),
+		slog.String("manifest_url", manifestURL),
+	)
+
+	responseHandler := fetcher.NewResponseHandler(f.requestBuilder.ExecuteRequest(manifestURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, fmt.Errorf("icon: unable to download web app manifest: %w", localizedError.Error())
+	}
+
+	return findIconURLsFromManifest(responseHandler.Body(config.Opts.HTTPClientMaxBodySize()), manifestURL)
+}
+
 func (f *IconFinder) DownloadIcon(iconURL string) (*model.Icon, error) {
+	return f.downloadIcon(f.requestBuilder, iconURL)
+}
+
+func (f *IconFinder) downloadIcon(requestBuilder *fetcher.RequestBuilder, iconURL string) (*model.Icon, error) {
 	slog.Debug("Downloading icon",
 		slog.String("website_url", f.websiteURL),
 		slog.String("icon_url", iconURL),
 	)
 
-	responseHandler := fetcher.NewResponseHandler(f.requestBuilder.ExecuteRequest(iconURL))
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(iconURL))
 	defer responseHandler.Close()
 
 	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
 		return nil, fmt.Errorf("icon: unable to download website icon: %w", localizedError.Error())
 	}
 
-	responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	responseBody, localizedError := responseHandler.ReadBody(config.Opts.IconMaxSize())
 	if localizedError != nil {
 		return nil, fmt.Errorf("icon: unable to read response body: %w", localizedError.Error())
 	}
 
-	icon := &model.Icon{
-		Hash:     crypto.HashFromBytes(responseBody),
-		MimeType: responseHandler.ContentType(),
-		Content:  responseBody,
+	// Many servers send a wrong content type for icons.
+	mimeType := detectIconMimeType(responseBody, responseHandler.ContentType())
+	if mimeType == "" {
+		return nil, fmt.Errorf("icon: the content of %q is not an image", iconURL)
+	}
+
+	return newIcon(responseBody, mimeType)
+}
+
+// FetchCustomIcon returns the icon chosen by the user for the feed, the URL can be a data URL.
+func (f *IconFinder) FetchCustomIcon(iconURL string) (*model.Icon, error) {
+	if !strings.HasPrefix(iconURL, "data:") {
+		return f.DownloadIcon(iconURL)
+	}
+
+	icon, err := parseImageDataURL(iconURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if detectIconMimeType(icon.Content, icon.MimeType) == "" {
+		return nil, errors.New("icon: the content of the data URL is not an image")
 	}
 
 	return icon, nil
 }
 
-func findIconURLsFromHTMLDocument(body io.Reader, contentType string) ([]string, error) {
-	queries := []string{
-		"link[rel='icon' i]",
-		"link[rel='shortcut icon' i]",
-		"link[rel='icon shortcut' i]",
-		"link[rel='apple-touch-icon-precomposed.png']",
+// NewIconFromContent returns an icon for an image uploaded by the user.
+func NewIconFromContent(content []byte, declaredMimeType string) (*model.Icon, error) {
+	mimeType := detectIconMimeType(content, declaredMimeType)
+	if mimeType == "" {
+		return nil, errors.New("icon: the uploaded file is not an image")
+	}
+
+	return newIcon(content, mimeType)
+}
+
+// newIcon returns an icon for the given content, SVG images are sanitized
+// and the other images are converted to PNG images of at most 128x128 pixels.
+// Icons larger than the configured size or dimensions are rejected.
+func newIcon(content []byte, mimeType string) (*model.Icon, error) {
+	if maxSize := config.Opts.IconMaxSize(); int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("%w: %d bytes instead of %d bytes at most", errIconTooLarge, len(content), maxSize)
+	}
+
+	if isICOIcon(content) {
+		if pngContent, err := convertICOToPNG(content); err != nil {
+			slog.Debug("Unable to convert ICO icon to PNG, keeping the original file", slog.Any("error", err))
+		} else {
+			content, mimeType = pngContent, "image/png"
+		}
+	} else if isSVGIcon(mimeType, content) {
+		sanitizedContent, err := sanitizeSVG(content)
+		if err != nil {
+			return nil, err
+		}
+		content, mimeType = sanitizedContent, svgMimeType
+	}
+
+	if mimeType != svgMimeType {
+		if normalizedContent, err := normalizeRasterIcon(content, config.Opts.IconMaxDimension()); errors.Is(err, errIconTooLarge) {
+			return nil, err
+		} else if err != nil {
+			slog.Debug("Unable to normalize icon, keeping the original file", slog.Any("error", err))
+		} else {
+			content, mimeType = normalizedContent, "image/png"
+		}
+	}
+
+	return &model.Icon{
+		Hash:     crypto.HashFromBytes(content),
+		Content:  content,
+		MimeType: mimeType,
+	}, nil
+}
+
+// detectIconMimeType returns the type of the image found in the content, or an empty string if it's not an image.
+// The declared type is used only for the image formats that are not detected from the content.
+func detectIconMimeType(content []byte, declaredMimeType string) string {
+	switch {
+	case isICOIcon(content):
+		return "image/x-icon"
+	case isSVGIcon(declaredMimeType, content):
+		return svgMimeType
+	}
+
+	detectedMimeType := http.DetectContentType(content)
+	if strings.HasPrefix(detectedMimeType, "image/") {
+		return detectedMimeType
+	}
+
+	mediaType, _, err := mime.ParseMediaType(declaredMimeType)
+	if detectedMimeType == "application/octet-stream" && err == nil && strings.HasPrefix(mediaType, "image/") {
+		return mediaType
+	}
+
+	return ""
+}
+
+func iconServiceURL(serviceURL, websiteURL string) string {
+	parsedURL, err := url.Parse(websiteURL)
+	if err != nil || parsedURL.Hostname() == "" {
+		return ""
+	}
+
+	return strings.NewReplacer(
+		"{hostname}", url.PathEscape(parsedURL.Hostname()),
+		"{url}", url.QueryEscape(websiteURL),
+	).Replace(serviceURL)
+}
+
+type iconCandidate struct {
+	url   string
+	score int
+}
+
+// sortIconCandidates returns the unique icon URLs, the highest scores first.
+func sortIconCandidates(candidates []iconCandidate) []string {
+	slices.SortStableFunc(candidates, func(a, b iconCandidate) int {
+		return cmp.Compare(b.score, a.score)
+	})
+
+	var iconURLs []string
+	for _, candidate := range candidates {
+		if !slices.Contains(iconURLs, candidate.url) {
+			iconURLs = append(iconURLs, candidate.url)
+		}
 	}
 
+	return iconURLs
+}
+
+// documentIcons contains the icons declared in an HTML document, the largest icons first.
+type documentIcons struct {
+	iconURLs     []string
+	darkIconURLs []string
+	manifestURL  string
+}
+
+// findIconURLsFromHTMLDocument returns the icons declared in the document and the URL of the web app manifest if any.
+// Icons restricted to the dark color scheme with the media attribute are returned separately.
+func findIconURLsFromHTMLDocument(body io.Reader, contentType string) (*documentIcons, error) {
 	htmlDocumentReader, err := charset.NewReader(body, contentType)
 	if err != nil {
 		return nil, fmt.Errorf("icon: unable to create charset reader: %w", err)
@@ -201,23 +427,142 @@ func findIconURLsFromHTMLDocument(body io.Reader, contentType string) ([]string,
 		return nil, fmt.Errorf("icon: unable to read document: %v", err)
 	}
 
-	var iconURLs []string
-	for _, query := range queries {
-		slog.Debug("Searching icon URL in HTML document", slog.String("query", query))
-
-		doc.Find(query).Each(func(i int, s *goquery.Selection) {
-			if href, exists := s.Attr("href"); exists {
-				if iconURL := strings.TrimSpace(href); iconURL != "" {
-					iconURLs = append(iconURLs, iconURL)
-					slog.Debug("Found icon URL in HTML document",
-						slog.String("query", query),
-						slog.String("icon_url", iconURL))
-				}
+	icons := &documentIcons{}
+	if href, exists := doc.Find("link[rel='manifest' i][href]").First().Attr("href"); exists {
+		icons.manifestURL = strings.TrimSpace(href)
+	}
+
+	var candidates, darkCandidates []iconCandidate
+	doc.Find("link[rel][href]").Each(func(i int, s *goquery.Selection) {
+		rel, _ := s.Attr("rel")
+		if !isIconRelation(rel) {
+			return
+		}
+
+		href, _ := s.Attr("href")
+		iconURL := strings.TrimSpace(href)
+		if iconURL == "" {
+			return
+		}
+
+		sizes, _ := s.Attr("sizes")
+		mimeType, _ := s.Attr("type")
+		media, _ := s.Attr("media")
+		score := iconScore(rel, sizes, mimeType)
+
+		slog.Debug("Found icon URL in HTML document",
+			slog.String("rel", rel),
+			slog.String("sizes", sizes),
+			slog.String("media", media),
+			slog.Int("score", score),
+			slog.String("icon_url", iconURL))
+
+		if isDarkColorSchemeMedia(media) {
+			darkCandidates = append(darkCandidates, iconCandidate{url: iconURL, score: score})
+		} else {
+			candidates = append(candidates, iconCandidate{url: iconURL, score: score})
+		}
+	})
+
+	icons.iconURLs = sortIconCandidates(candidates)
+	icons.darkIconURLs = sortIconCandidates(darkCandidates)
+	return icons, nil
+}
+
+// isDarkColorSchemeMedia returns true for media queries like "(prefers-color-scheme: dark)".
+func isDarkColorSchemeMedia(media string) bool {
+	media = strings.Join(strings.Fields(strings.ToLower(media)), "")
+	return strings.Contains(media, "prefers-color-scheme:dark")
+}
+
+// findIconURLsFromManifest returns the absolute icon URLs declared in a web app manifest, the largest icons first.
+// Monochrome icons are ignored since they are meant to be used as a mask.
+// See https://developer.mozilla.org/en-US/docs/Web/Manifest/icons.
+func findIconURLsFromManifest(body io.Reader, manifestURL string) ([]string, error) {
+	var manifest struct {
+		Icons []struct {
+			Src     string `json:"src"`
+			Sizes   string `json:"sizes"`
+			Type    string `json:"type"`
+			Purpose string `json:"purpose"`
+		} `json:"icons"`
+	}
+
+	if err := json.NewDecoder(body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("icon: unable to decode web app manifest: %v", err)
+	}
+
+	var candidates []iconCandidate
+	for _, manifestIcon := range manifest.Icons {
+		src := strings.TrimSpace(manifestIcon.Src)
+		if src == "" {
+			continue
+		}
+
+		if purposes := strings.Fields(strings.ToLower(manifestIcon.Purpose)); len(purposes) > 0 && !slices.Contains(purposes, "any") && !slices.Contains(purposes, "maskable") {
+			continue
+		}
+
+		iconURL := src
+		if !strings.HasPrefix(src, "data:") {
+			var err error
+			if iconURL, err = urllib.AbsoluteURL(manifestURL, src); err != nil {
+				continue
 			}
-		})
+		}
+
+		candidates = append(candidates, iconCandidate{url: iconURL, score: iconScore("icon", manifestIcon.Sizes, manifestIcon.Type)})
+	}
+
+	return sortIconCandidates(candidates), nil
+}
+
+// isIconRelation returns true for the "icon", "shortcut icon", "apple-touch-icon" and "apple-touch-icon-precomposed" relations.
+// The "mask-icon" relation is ignored since it's a monochrome icon.
+func isIconRelation(rel string) bool {
+	for _, value := range strings.Fields(strings.ToLower(rel)) {
+		switch value {
+		case "icon", "apple-touch-icon", "apple-touch-icon-precomposed":
+			return true
+		}
+	}
+	return false
+}
+
+// iconScore returns the number of pixels of the largest size declared by the icon.
+// Scalable icons get the highest score, and Apple touch icons are 180x180 by default.
+func iconScore(rel, sizes, mimeType string) int {
+	score := 0
+	for _, size := range strings.Fields(strings.ToLower(sizes)) {
+		if size == "any" {
+			return math.MaxInt32
+		}
+
+		width, height, found := strings.Cut(size, "x")
+		if !found {
+			continue
+		}
+
+		w, errW := strconv.Atoi(width)
+		h, errH := strconv.Atoi(height)
+		if errW == nil && errH == nil && w > 0 && h > 0 {
+			score = max(score, w*h)
+		}
+	}
+
+	if score > 0 {
+		return score
 	}
 
-	return iconURLs, nil
+	if strings.EqualFold(strings.TrimSpace(mimeType), "image/svg+xml") {
+		return math.MaxInt32
+	}
+
+	if strings.Contains(strings.ToLower(rel), "apple-touch-icon") {
+		return 180 * 180
+	}
+
+	return 0
 }
 
 // https://developer.mozilla.org/en-US/docs/Web/HTTP/Basics_of_HTTP/Data_URIs#syntax
@@ -239,6 +584,15 @@ func parseImageDataURL(value string) (*model.Icon, error) {
 	encoding := matches[re.SubexpIndex("encoding")]
 	data := matches[re.SubexpIndex("data")]
 
+	// Oversized data URLs are rejected before being decoded.
+	dataSize := int64(len(data))
+	if encoding == "base64" {
+		dataSize = int64(base64.StdEncoding.DecodedLen(len(data)))
+	}
+	if maxSize := config.Opts.IconMaxSize(); dataSize > maxSize {
+		return nil, fmt.Errorf("%w: the data URL contains more than %d bytes", errIconTooLarge, maxSize)
+	}
+
 	var blob []byte
 	switch encoding {
 	case "base64":
@@ -257,9 +611,5 @@ func parseImageDataURL(value string) (*model.Icon, error) {
 		blob = []byte(data)
 	}
 
-	return &model.Icon{
-		Hash:     crypto.HashFromBytes(blob),
-		Content:  blob,
-		MimeType: mediaType,
-	}, nil
+	return newIcon(blob, mediaType)
 }
@@ -4,10 +4,25 @@
 package icon // import "miniflux.app/v2/internal/reader/icon"
 
 import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
 	"strings"
 	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
 )
 
+func TestMain(m *testing.M) {
+	config.Opts = config.NewOptions()
+	exitCode := m.Run()
+	os.Exit(exitCode)
+}
+
 func TestParseImageDataURL(t *testing.T) {
 	iconURL := "data:image/webp;base64,UklGRhQJAABXRUJQVlA4TAcJAAAvv8AvEIU1atuOza3OCSaanSeobUa17T61bdu2bVtRbdvtDmrb7gSTdibJXOG81/d9z/vsX3utCLi1bbuJ3hKeVEymRRuaSnCVSBWIBmwP410h0IHJXDyfZCfRNhklFS/sufGPbPHPjT0vVJRkhE1BwxFZ5EhDQVjkrEjIJokVOVHMhAuyyoUpUUCbDbLLhjbRFkO+kWG+GRLT0+YTWeaTNjEdW2SaLTEtU2SbOTGVnAuyzY0nYgobZJwtMZkxD2ScB2NiEg2yTkOQcULWOZFRIvOU1Mg8FS/IPC8ckHkOXJF5riRknoT/pb1t6iwPetFIH3jNY660i/khw/3dq4W09ZbNIbN1TjOeFD2iB2T1KmIM0x0yuhOxbod81vueWK0GQDa3IuZ1kM2bifkdZPM94s4CuRxN3GUhl2KvC7kUez3I5TjiLge5/Ji4s0AuBxPzO8jmbsS8GrLZ4G9itVoM8nkssW6CjLb3BDFGaoCcdnU/KXxMb8hrnZ18Ttr82UHqILvtrO50j/vOaDKpyY/ecKWNdYJst1MP/7fxHwtYyprWtrGNrG0pfcyqDjI7r22d6V4faCJttfjOa4Y6155WMwuUpsEw5spQjW62d7tvif+H4YapCAkFYkaofB1DNJEaIqFAzAgVdrCTkaS2SCgQM0Jla/uQ1BoJBWJGqKTBTaT2SCgQM0IFfXxMEkBCgZgR/I2MJSkgoUDMCPaWmkkSSCgQM4K7pmaSBhIKxIxgLqCRJIKEAjEjePWGk1SQUCBmBO8kksgoj0BCgZgRrDn8Q+zfDXKkzaxt0gb2coX3SMVNnnG85XSAlAIxI1hXEneEzbWH6fsYpJX4zV52mlXVQ2qBmBGcWY0jXquTdYC21/En8YY7z7q6QoqBmBGc44jXag8o7Ot3Yp0DiQZiRnDeI97FYGyglTj/mgvSDMSMYCxGvG91BWcQsa6BNAMxIxgHEe9gsBbVSpwxekCSgZgRjCHEGqcBvBeJtRckGYgZwfiGWA+CeSixnoAkAzEjFDcQ73AwBxCrST2kGIgZobgP8VYDs4MWYi0LKQZiRihej3izgvsZsfaEFAMxIxRvR6yJ2oP7IrFOhxQDMSMU70+sRrAfIdYNkGIgZoTi/Yn1I9gDiTUQUgzEjFC8P7F+BHsgsQZCioGYEYp3IlYj2A8TayCkGIgZoXgT4nUE91ViXQ0pBmJGKF6GePOC+w2xTocUAzEjFPcm3sZgdtNKrH0gxUDMCMZvxDoXzDWJtxqkGIgZwXicWO+CeT6xWvWCFAMxIxgnEm9xsNr5mlifQJKBmBGMJYl3K1hbEO8aSDIQM4JR52tiTbQMGPU+It56kGQgZgTndOJ9JEDxecT7XntIMhAzgjO7ZuI9rwGK9tJKvLMhzUDMCNZNxHxXP2izi0u0Em+cWSHNQMwI1hyaiDneXVbTHqad0zF+IO4FkGggZgTveOKP9qLbXOo813vYl8T/XW9INBAzgtfBf0ntdoBUAzEjmPP5m9TqVkg2EDOCu6ZmUps3dYFkAzEj2NtoIbV4z4yQbiBmBH9jY0j1R5gJEg7EjFBBHx+Taj+kAVIOxIxQSReXGU+q2ewYdZB0IGaEyhZzj4mkam/oD4kHYkaosI8PSJW+tb06SD0QM0JFnZyjhVRnuJ3UQ/qBmBEqWcQIUpU/3GAVKEUgZoQKttNEKh/nZWdaVXsoSSBmBP8kraToAdd51Pt+MoZM86v3PetOZ9hBfx2hRIGYEewzSeFZ6mBqnZ4mBShlIGYE9xBSeAOUPRAzgtlfCyn6UTcoeyBmBPNZUngalD4QM4LXjxRvDKUPxIzgnUCKl4XSB2JG8J4kxftB6QMxI3jfkeIfzQ9lD8SM4I0hxm/2UQ/lDsSM4I0i1p/usLul9IDyBmJG8D4jfpPvfekDwxS95RlPutMljrGlxdRD2oGYEbyHSU1a/Ncl1tcR0g3EjODtT2r2l1stC6kGYkbwehhDavi69SHNQMwI5mmkpk+YF1IMxIxgdvIBqWmj7SDBQMwIbl+NpLZnQHqBmBHsdTST2l4GyQViRvDXMprU9hhILRAzQgWLGkZqOsFqkFggZoRKOtrPd6SWX+oMaQViRqhgUcd7QTOp6dGQViBmBLeXw71Pav6LLpBUIGYEb1aXaSIp7AlJBWJGcDo50RiSxtOQVCBmBKOv90gqE/SClAIxIxRvbSxJZyNIqZ35mF2hcC8TSUJnQwm30krMH93jOJtYTX/zaXNhS5m0lq0c7GxDfWoi8R+B8vXRRKx/3GpVdVBBd1sYrImY70PpOhhJrEHmgIpncivxfofSHUCcJttBVU4g1hgoW72fiNFkFajSY8RC2XYkzh5QrRWJhbI9SIxXoGp1GokxHkpWbxwxNoPqDSPGL1CyZYgxXheo3hvEeBdKthMxPoYqfkaMB6BkJxHjVaheMIEYZ0HJziXGO1C9vYizBZTscmKM1R6q1cnnxJioN5TsLOKsCdW6ljhvQtmOIc7jUKVTiXUElG0HYu0O1ejhJmI1mxHKNoBYzTaFiuvs4mfi3Qql6+RfYk10tk5QUXube4OY4y0I5XuUmF/bUxdwO1jRxb4n9uVQwn2J/ZdbbWNWKGpnXhs42SMaSQXfC1DCHhpJJT97we0uca5jHeJYk45znmsN9JJP/UsqnGAtKOWFJJ2ToZwz+J2kcqs6KOkuJJGB2kNZ69xFkrhaeyhvF2+S2v/jICh1T6+TWn9qAJS8m8dITce7WAOUvs6xWkjtnrEYZGFpw0mNXrMB5KKdPXxNqj/OIMtDTjra0eukqhM9azcBsrOg03xMqvSLIXYzM2RqAfu600cmkIr+9oKL7GQRyFyDFe3hDHd4xcd+NZ601ehbIzzuNqfbyxrmhKx219Ns5jN5bj1N6g6pkZB5EldknisHZJ4DL8g8L9TIPBXPyDwlGSdknRMZQYOs0xCTKEjIOImCmMwKGWdDTCHnimxzJSemMkO2WRDTskWm2RHT0eUTWeaTLjE9Q/6QYX4YEm3RYYvssqVDFDDjgqxyYU4UM2JDQjZJbBgRFgVLzsgiZ5YUhE1GSc0Le+48kC0e3NnzQk1JRrQNAA=="
 	icon, err := parseImageDataURL(iconURL)
@@ -59,8 +74,8 @@ func TestParseImageWithRawSVGEncodedInUTF8(t *testing.T) {
 		t.Fatal(`Image hash should be computed`)
 	}
 
-	if string(icon.Content) != `<svg xmlns='http://www.w3.org/2000/svg' viewBox='0 0 456 456'><circle></circle></svg>` {
-		t.Fatal(`Invalid SVG content`)
+	if string(icon.Content) != `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 456 456"><circle></circle></svg>` {
+		t.Fatalf(`Invalid SVG content, got %q`, icon.Content)
 	}
 }
 
@@ -112,10 +127,11 @@ func TestParseDocumentWithWhitespaceIconURL(t *testing.T) {
 		/static/img/favicon.ico
 	">`
 
-	iconURLs, err := findIconURLsFromHTMLDocument(strings.NewReader(html), "text/html")
+	icons, err := findIconURLsFromHTMLDocument(strings.NewReader(html), "text/html")
 	if err != nil {
 		t.Fatal(err)
 	}
+	iconURLs := icons.iconURLs
 
 	if len(iconURLs) != 1 {
 		t.Fatalf(`Invalid number of icon URLs, got %d`, len(iconURLs))
@@ -125,3 +141,222 @@ func TestParseDocumentWithWhitespaceIconURL(t *testing.T) {
 		t.Errorf(`Invalid icon URL, got %q`, iconURLs[0])
 	}
 }
+
+func TestParseDocumentWithIconSizes(t *testing.T) {
+	html := `
+		<link rel="icon" href="/favicon-16.png" sizes="16x16">
+		<link rel="apple-touch-icon" href="/apple-touch-icon.png">
+		<link rel="icon" href="/favicon-32.png" sizes="32x32">
+		<link rel="icon" href="/favicon-192.png" sizes="48x48 192x192">
+		<link rel="mask-icon" href="/mask.svg">
+		<link rel="shortcut icon" href="/favicon.ico">
+	`
+
+	icons, err := findIconURLsFromHTMLDocument(strings.NewReader(html), "text/html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	iconURLs := icons.iconURLs
+
+	expected := []string{"/favicon-192.png", "/apple-touch-icon.png", "/favicon-32.png", "/favicon-16.png", "/favicon.ico"}
+	if !slices.Equal(iconURLs, expected) {
+		t.Errorf(`Invalid icon URLs, got %v instead of %v`, iconURLs, expected)
+	}
+}
+
+func TestParseDocumentWithScalableIcon(t *testing.T) {
+	html := `
+		<link rel="apple-touch-icon-precomposed" href="/apple-touch-icon.png" sizes="152x152">
+		<link rel="icon" href="/favicon.svg" type="image/svg+xml">
+		<link rel="ICON" href="/favicon.png" sizes="any">
+	`
+
+	icons, err := findIconURLsFromHTMLDocument(strings.NewReader(html), "text/html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	iconURLs := icons.iconURLs
+
+	expected := []string{"/favicon.svg", "/favicon.png", "/apple-touch-icon.png"}
+	if !slices.Equal(iconURLs, expected) {
+		t.Errorf(`Invalid icon URLs, got %v instead of %v`, iconURLs, expected)
+	}
+}
+
+func TestIconScore(t *testing.T) {
+	scenarios := []struct {
+		rel, sizes, mimeType string
+		expected             int
+	}{
+		{"icon", "", "", 0},
+		{"icon", "16x16", "", 256},
+		{"icon", "16X16 64x64", "", 4096},
+		{"icon", "invalid", "", 0},
+		{"icon", "0x0", "", 0},
+		{"icon", "any", "", math.MaxInt32},
+		{"icon", "", "image/svg+xml", math.MaxInt32},
+		{"apple-touch-icon", "", "", 180 * 180},
+		{"apple-touch-icon", "120x120", "", 120 * 120},
+	}
+
+	for _, scenario := range scenarios {
+		if result := iconScore(scenario.rel, scenario.sizes, scenario.mimeType); result != scenario.expected {
+			t.Errorf(`Unexpected score for %+v, got %d`, scenario, result)
+		}
+	}
+}
+
+func TestParseDocumentWithManifest(t *testing.T) {
+	html := `<link rel="Manifest" href="/site.webmanifest">`
+
+	icons, err := findIconURLsFromHTMLDocument(strings.NewReader(html), "text/html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(icons.iconURLs) != 0 {
+		t.Errorf(`No icon URL should be found, got %v`, icons.iconURLs)
+	}
+
+	if icons.manifestURL != "/site.webmanifest" {
+		t.Errorf(`Invalid manifest URL, got %q`, icons.manifestURL)
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	manifest := `{
+		"name": "Example",
+		"icons": [
+			{"src": "icons/icon-48.png", "sizes": "48x48", "type": "image/png"},
+			{"src": "/icons/icon-512.png", "sizes": "512x512", "type": "image/png"},
+			{"src": "icons/monochrome.png", "sizes": "1024x1024", "purpose": "monochrome"},
+			{"src": "https://cdn.example.org/icon-192.png", "sizes": "192x192", "purpose": "any maskable"},
+			{"src": ""}
+		]
+	}`
+
+	iconURLs, err := findIconURLsFromManifest(strings.NewReader(manifest), "https://example.org/static/manifest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"https://example.org/icons/icon-512.png",
+		"https://cdn.example.org/icon-192.png",
+		"https://example.org/static/icons/icon-48.png",
+	}
+	if !slices.Equal(iconURLs, expected) {
+		t.Errorf(`Invalid icon URLs, got %v instead of %v`, iconURLs, expected)
+	}
+}
+
+func TestParseInvalidManifest(t *testing.T) {
+	if _, err := findIconURLsFromManifest(strings.NewReader("<html>"), "https://example.org/manifest.json"); err == nil {
+		t.Error(`An invalid manifest should return an error`)
+	}
+}
+
+func TestIconServiceURL(t *testing.T) {
+	scenarios := []struct {
+		serviceURL, websiteURL, expected string
+	}{
+		{"https://icons.duckduckgo.com/ip3/{hostname}.ico", "https://www.example.org:8443/blog/", "https://icons.duckduckgo.com/ip3/www.example.org.ico"},
+		{"https://icons.example.org/?url={url}&size=64", "https://example.org/a?b=c", "https://icons.example.org/?url=https%3A%2F%2Fexample.org%2Fa%3Fb%3Dc&size=64"},
+		{"https://icons.example.org/{hostname}", "/relative/path", ""},
+	}
+
+	for _, scenario := range scenarios {
+		if result := iconServiceURL(scenario.serviceURL, scenario.websiteURL); result != scenario.expected {
+			t.Errorf(`Unexpected icon URL for %q, got %q instead of %q`, scenario.websiteURL, result, scenario.expected)
+		}
+	}
+}
+
+func TestFetchIconFromServiceWithoutFeedSettings(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("\x89PNG\r\n\x1a\n"))
+	}))
+	defer server.Close()
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUsernameAndPassword("user", "secret")
+	requestBuilder.WithCookie("session=secret")
+	requestBuilder.WithUserAgent("Custom Agent", "Default Agent")
+
+	iconFinder := NewIconFinder(requestBuilder, "https://example.org/", "")
+	if _, err := iconFinder.FetchIconFromService(server.URL + "/{hostname}.ico"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"Authorization", "Cookie"} {
+		if value := header.Get(name); value != "" {
+			t.Errorf(`The %s header should not be sent to the favicon service, got %q`, name, value)
+		}
+	}
+
+	if userAgent := header.Get("User-Agent"); userAgent == "Custom Agent" {
+		t.Error(`The user agent of the feed should not be sent to the favicon service`)
+	}
+}
+
+func TestDetectIconMimeType(t *testing.T) {
+	scenarios := []struct {
+		content, declaredMimeType, expected string
+	}{
+		{"\x89PNG\r\n\x1a\n", "text/html", "image/png"},
+		{"\x00\x00\x01\x00\x01\x00", "application/octet-stream", "image/x-icon"},
+		{"GIF89a", "", "image/gif"},
+		{`<svg xmlns="http://www.w3.org/2000/svg"></svg>`, "text/plain", "image/svg+xml"},
+		{`<svg></svg>`, "image/svg+xml; charset=utf-8", "image/svg+xml"},
+		{"\x00\x00\x00\x1cftypavif", "image/avif", "image/avif"},
+		{"\x00\x00\x00\x1cftypavif", "application/octet-stream", ""},
+		{"<!DOCTYPE html><html><body>Not Found</body></html>", "text/html", ""},
+		{"<!DOCTYPE html><html><body>Not Found</body></html>", "image/png", ""},
+		{"", "image/png", ""},
+	}
+
+	for _, scenario := range scenarios {
+		if result := detectIconMimeType([]byte(scenario.content), scenario.declaredMimeType); result != scenario.expected {
+			t.Errorf(`Unexpected type for %q declared as %q, got %q instead of %q`, scenario.content, scenario.declaredMimeType, result, scenario.expected)
+		}
+	}
+}
+
+func TestParseDocumentWithDarkIcons(t *testing.T) {
+	html := `
+		<link rel="icon" href="/favicon-light.png" media="(prefers-color-scheme: light)">
+		<link rel="icon" href="/favicon-dark-16.png" sizes="16x16" media="(prefers-color-scheme:dark)">
+		<link rel="icon" href="/favicon-dark-32.png" sizes="32x32" media="screen and (prefers-color-scheme: DARK)">
+		<link rel="shortcut icon" href="/favicon.ico">
+	`
+
+	icons, err := findIconURLsFromHTMLDocument(strings.NewReader(html), "text/html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"/favicon-light.png", "/favicon.ico"}; !slices.Equal(icons.iconURLs, expected) {
+		t.Errorf(`Invalid icon URLs, got %v instead of %v`, icons.iconURLs, expected)
+	}
+
+	if expected := []string{"/favicon-dark-32.png", "/favicon-dark-16.png"}; !slices.Equal(icons.darkIconURLs, expected) {
+		t.Errorf(`Invalid dark icon URLs, got %v instead of %v`, icons.darkIconURLs, expected)
+	}
+}
+
+func TestParseOversizedImageDataURL(t *testing.T) {
+	iconURL := "data:image/png;base64," + strings.Repeat("A", int(config.Opts.IconMaxSize())*2)
+	if _, err := parseImageDataURL(iconURL); !errors.Is(err, errIconTooLarge) {
+		t.Fatalf(`Oversized data URLs should be rejected, got %v`, err)
+	}
+}
+
+func TestNewIconRejectsOversizedContent(t *testing.T) {
+	content := make([]byte, config.Opts.IconMaxSize()+1)
+	if _, err := newIcon(content, "image/png"); !errors.Is(err, errIconTooLarge) {
+		t.Fatalf(`Oversized icons should be rejected, got %v`, err)
+	}
+}
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package icon // import "miniflux.app/v2/internal/reader/icon"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+const (
+	icoHeaderSize      = 6
+	icoEntrySize       = 16
+	bitmapInfoSize     = 40
+	maxBitmapDimension = 1024
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// icoEntry is an image of the ICO directory.
+// See https://learn.microsoft.com/en-us/previous-versions/ms997538(v=msdn.10).
+type icoEntry struct {
+	width    int
+	height   int
+	bitCount int
+	data     []byte
+}
+
+// isICOIcon returns true if the content starts with the header of an ICO file.
+// The content type is ignored since the servers use many different values for this format.
+func isICOIcon(content []byte) bool {
+	return len(content) >= icoHeaderSize && bytes.Equal(content[:4], []byte{0, 0, 1, 0}) && binary.LittleEndian.Uint16(content[4:6]) > 0
+}
+
+// convertICOToPNG extracts the largest image of the ICO file and returns it as a PNG image.
+func convertICOToPNG(content []byte) ([]byte, error) {
+	entry, err := findLargestICOEntry(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(entry.data, pngSignature) {
+		return entry.data, nil
+	}
+
+	img, err := decodeICOBitmap(entry.data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, fmt.Errorf("icon: unable to encode PNG image: %v", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func findLargestICOEntry(content []byte) (*icoEntry, error) {
+	count := int(binary.LittleEndian.Uint16(content[4:6]))
+	if len(content) < icoHeaderSize+count*icoEntrySize {
+		return nil, errors.New("icon: truncated ICO directory")
+	}
+
+	var largest *icoEntry
+	for i := range count {
+		header := content[icoHeaderSize+i*icoEntrySize : icoHeaderSize+(i+1)*icoEntrySize]
+		size := int64(binary.LittleEndian.Uint32(header[8:12]))
+		offset := int64(binary.LittleEndian.Uint32(header[12:16]))
+		if size == 0 || offset+size > int64(len(content)) {
+			continue
+		}
+
+		// A width or a height of 0 means 256 pixels.
+		entry := &icoEntry{
+			width:    int(header[0]),
+			height:   int(header[1]),
+			bitCount: int(binary.LittleEndian.Uint16(header[6:8])),
+			data:     content[offset : offset+size],
+		}
+		if entry.width == 0 {
+			entry.width = 256
+		}
+		if entry.height == 0 {
+			entry.height = 256
+		}
+
+		if largest == nil || entry.width*entry.height > largest.width*largest.height ||
+			(entry.width*entry.height == largest.width*largest.height && entry.bitCount > largest.bitCount) {
+			largest = entry
+		}
+	}
+
+	if largest == nil {
+		return nil, errors.New("icon: no valid image in ICO file")
+	}
+
+	return largest, nil
+}
+
+// decodeICOBitmap decodes a device-independent bitmap stored without file header.
+// The height of the bitmap includes the AND mask that defines the transparent pixels.
+func decodeICOBitmap(data []byte) (image.Image, error) {
+	if len(data) < bitmapInfoSize {
+		return nil, errors.New("icon: truncated bitmap header")
+	}
+
+	headerSize := int(binary.LittleEndian.Uint32(data[0:4]))
+	width := int(int32(binary.LittleEndian.Uint32(data[4:8])))
+	height := int(int32(binary.LittleEndian.Uint32(data[8:12]))) / 2
+	bitCount := int(binary.LittleEndian.Uint16(data[14:16]))
+	compression := binary.LittleEndian.Uint32(data[16:20])
+	colorsUsed := int(binary.LittleEndian.Uint32(data[32:36]))
+
+	if headerSize < bitmapInfoSize || headerSize > len(data) {
+		return nil, errors.New("icon: invalid bitmap header size")
+	}
+
+	if width <= 0 || height <= 0 || width > maxBitmapDimension || height > maxBitmapDimension {
+		return nil, fmt.Errorf("icon: invalid bitmap dimensions %dx%d", width, height)
+	}
+
+	// Only uncompressed bitmaps are used in ICO files, 32 bits images can also declare bit fields.
+	if compression != 0 && (compression != 3 || bitCount != 32) {
+		return nil, fmt.Errorf("icon: unsupported bitmap compression %d", compression)
+	}
+
+	var palette []color.NRGBA
+	offset := headerSize
+	switch bitCount {
+	case 1, 4, 8:
+		if colorsUsed == 0 || colorsUsed > 1<<bitCount {
+			colorsUsed = 1 << bitCount
+		}
+		if offset+colorsUsed*4 > len(data) {
+			return nil, errors.New("icon: truncated bitmap palette")
+		}
+		for i := range colorsUsed {
+			entry := data[offset+i*4:]
+			palette = append(palette, color.NRGBA{R: entry[2], G: entry[1], B: entry[0], A: 0xff})
+		}
+		offset += colorsUsed * 4
+	case 24, 32:
+	default:
+		return nil, fmt.Errorf("icon: unsupported bitmap depth %d", bitCount)
+	}
+
+	rowSize := (width*bitCount + 31) / 32 * 4
+	maskRowSize := (width + 31) / 32 * 4
+	pixels := data[offset:]
+	if len(pixels) < rowSize*height {
+		return nil, errors.New("icon: truncated bitmap data")
+	}
+
+	// The mask is optional for 32 bits images since they have an alpha channel.
+	mask := pixels[rowSize*height:]
+	hasMask := len(mask) >= maskRowSize*height
+	hasAlpha := false
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		// Rows are stored from the bottom to the top.
+		row := pixels[(height-1-y)*rowSize:]
+		for x := range width {
+			var c color.NRGBA
+			switch bitCount {
+			case 32:
+				c = color.NRGBA{R: row[x*4+2], G: row[x*4+1], B: row[x*4], A: row[x*4+3]}
+				hasAlpha = hasAlpha || c.A != 0
+			case 24:
+				c = color.NRGBA{R: row[x*3+2], G: row[x*3+1], B: row[x*3], A: 0xff}
+			default:
+				index := int(row[x*bitCount/8]>>(8-bitCount-(x*bitCount%8))) & (1<<bitCount - 1)
+				if index < len(palette) {
+					c = palette[index]
+				}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	if bitCount == 32 && !hasAlpha && !hasMask {
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xff
+		}
+	}
+
+	if hasMask && (bitCount != 32 || !hasAlpha) {
+		for y := range height {
+			row := mask[(height-1-y)*maskRowSize:]
+			for x := range width {
+				transparent := row[x/8]&(0x80>>(x%8)) != 0
+				pixel := img.NRGBAAt(x, y)
+				if transparent {
+					pixel.A = 0
+				} else {
+					pixel.A = 0xff
+				}
+				img.SetNRGBA(x, y, pixel)
+			}
+		}
+	}
+
+	return img, nil
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package icon // import "miniflux.app/v2/internal/reader/icon"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+type testICOImage struct {
+	width, height, bitCount int
+	data                    []byte
+}
+
+func buildICO(images ...testICOImage) []byte {
+	var buffer bytes.Buffer
+	binary.Write(&buffer, binary.LittleEndian, []uint16{0, 1, uint16(len(images))})
+
+	offset := icoHeaderSize + len(images)*icoEntrySize
+	for _, img := range images {
+		buffer.Write([]byte{byte(img.width), byte(img.height), 0, 0})
+		binary.Write(&buffer, binary.LittleEndian, []uint16{1, uint16(img.bitCount)})
+		binary.Write(&buffer, binary.LittleEndian, []uint32{uint32(len(img.data)), uint32(offset)})
+		offset += len(img.data)
+	}
+
+	for _, img := range images {
+		buffer.Write(img.data)
+	}
+
+	return buffer.Bytes()
+}
+
+func buildBitmap(width, height, bitCount int, palette, pixels, mask []byte) []byte {
+	var buffer bytes.Buffer
+	binary.Write(&buffer, binary.LittleEndian, []uint32{bitmapInfoSize, uint32(width), uint32(height * 2)})
+	binary.Write(&buffer, binary.LittleEndian, []uint16{1, uint16(bitCount)})
+	binary.Write(&buffer, binary.LittleEndian, []uint32{0, 0, 0, 0, 0, 0})
+	buffer.Write(palette)
+	buffer.Write(pixels)
+	buffer.Write(mask)
+	return buffer.Bytes()
+}
+
+func decodeTestPNG(t *testing.T, data []byte) image.Image {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf(`The result should be a PNG image: %v`, err)
+	}
+	return img
+}
+
+func TestIsICOIcon(t *testing.T) {
+	if !isICOIcon(buildICO(testICOImage{16, 16, 32, []byte{0}})) {
+		t.Error(`The ICO file should be detected`)
+	}
+
+	for _, content := range [][]byte{nil, {0, 0, 1, 0, 0, 0}, {0, 0, 2, 0, 1, 0}, pngSignature} {
+		if isICOIcon(content) {
+			t.Errorf(`The content %v should not be detected as ICO`, content)
+		}
+	}
+}
+
+func TestConvertICOWithLargestBitmap(t *testing.T) {
+	// 2x1 pixels in BGRA, the rows are padded to 4 bytes.
+	small := buildBitmap(1, 1, 32, nil, []byte{0, 0, 0xff, 0xff}, []byte{0, 0, 0, 0})
+	large := buildBitmap(2, 2, 32, nil, []byte{
+		0xff, 0, 0, 0xff, 0, 0xff, 0, 0x80,
+		0, 0, 0xff, 0xff, 0, 0, 0, 0,
+	}, nil)
+
+	result, err := convertICOToPNG(buildICO(testICOImage{1, 1, 32, small}, testICOImage{2, 2, 32, large}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img := decodeTestPNG(t, result)
+	if img.Bounds().Dx() != 2 || img.Bounds().Dy() != 2 {
+		t.Fatalf(`The largest image should be extracted, got %v`, img.Bounds())
+	}
+
+	// The bottom row is stored first.
+	scenarios := map[image.Point]color.NRGBA{
+		{0, 0}: {R: 0xff, A: 0xff},
+		{1, 0}: {},
+		{0, 1}: {B: 0xff, A: 0xff},
+		{1, 1}: {G: 0xff, A: 0x80},
+	}
+	for point, expected := range scenarios {
+		if pixel := color.NRGBAModel.Convert(img.At(point.X, point.Y)).(color.NRGBA); pixel != expected {
+			t.Errorf(`Invalid pixel at %v, got %v instead of %v`, point, pixel, expected)
+		}
+	}
+}
+
+func TestConvertICOWithPaletteAndMask(t *testing.T) {
+	palette := []byte{0, 0, 0, 0, 0xff, 0xff, 0xff, 0}
+	pixels := []byte{0b10000000, 0, 0, 0, 0b01000000, 0, 0, 0}
+	mask := []byte{0, 0, 0, 0, 0b10000000, 0, 0, 0}
+
+	result, err := convertICOToPNG(buildICO(testICOImage{2, 2, 1, buildBitmap(2, 2, 1, palette, pixels, mask)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img := decodeTestPNG(t, result)
+	scenarios := map[image.Point]color.NRGBA{
+		{0, 0}: {},
+		{1, 0}: {R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		{0, 1}: {R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		{1, 1}: {A: 0xff},
+	}
+	for point, expected := range scenarios {
+		if pixel := color.NRGBAModel.Convert(img.At(point.X, point.Y)).(color.NRGBA); pixel != expected {
+			t.Errorf(`Invalid pixel at %v, got %v instead of %v`, point, pixel, expected)
+		}
+	}
+}
+
+func TestConvertICOWithEmbeddedPNG(t *testing.T) {
+	var pngImage bytes.Buffer
+	png.Encode(&pngImage, image.NewNRGBA(image.Rect(0, 0, 256, 256)))
+
+	bitmap := buildBitmap(1, 1, 24, nil, []byte{0, 0, 0, 0}, []byte{0, 0, 0, 0})
+	result, err := convertICOToPNG(buildICO(testICOImage{1, 1, 24, bitmap}, testICOImage{0, 0, 32, pngImage.Bytes()}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(result, pngImage.Bytes()) {
+		t.Error(`The embedded PNG image should be returned as is`)
+	}
+}
+
+func TestConvertInvalidICO(t *testing.T) {
+	scenarios := [][]byte{
+		{0, 0, 1, 0, 2, 0},
+		buildICO(testICOImage{16, 16, 32, nil}),
+		buildICO(testICOImage{16, 16, 32, []byte("invalid")}),
+		buildICO(testICOImage{2, 2, 16, buildBitmap(2, 2, 16, nil, make([]byte, 8), nil)}),
+		buildICO(testICOImage{2, 2, 32, buildBitmap(2, 2, 32, nil, make([]byte, 4), nil)}),
+	}
+
+	for _, content := range scenarios {
+		if _, err := convertICOToPNG(content); err == nil {
+			t.Errorf(`An error should be returned for %v`, content)
+		}
+	}
+}
+
+func TestNewIconConvertsICO(t *testing.T) {
+	content := buildICO(testICOImage{1, 1, 24, buildBitmap(1, 1, 24, nil, []byte{0, 0, 0xff, 0}, []byte{0, 0, 0, 0})})
+
+	icon, err := newIcon(content, "image/vnd.microsoft.icon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if icon.MimeType != "image/png" {
+		t.Errorf(`Invalid mime type, got %q`, icon.MimeType)
+	}
+
+	decodeTestPNG(t, icon.Content)
+}
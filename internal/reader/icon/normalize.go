@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package icon // import "miniflux.app/v2/internal/reader/icon"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+
+	// Register the decoders of the formats used by icons.
+	_ "image/gif"
+	_ "image/jpeg"
+)
+
+// normalizedIconSize is the maximum width and height of stored icons.
+const normalizedIconSize = 128
+
+// errIconTooLarge is returned for the icons exceeding the configured size or dimensions, they are never stored.
+var errIconTooLarge = errors.New("icon: the icon is too large")
+
+// normalizeRasterIcon converts PNG, JPEG and GIF icons to a PNG image of at most 128x128 pixels.
+// Smaller images are not enlarged, and animated images are replaced by their first frame.
+// Images wider or taller than maxDimension are rejected before being decoded.
+func normalizeRasterIcon(content []byte, maxDimension int) ([]byte, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("icon: unsupported image format: %v", err)
+	}
+
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, fmt.Errorf("icon: invalid image dimensions %dx%d", config.Width, config.Height)
+	}
+
+	if config.Width > maxDimension || config.Height > maxDimension {
+		return nil, fmt.Errorf("%w: %dx%d pixels", errIconTooLarge, config.Width, config.Height)
+	}
+
+	if format == "png" && config.Width <= normalizedIconSize && config.Height <= normalizedIconSize {
+		return content, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("icon: unable to decode image: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, resizeIcon(img, normalizedIconSize)); err != nil {
+		return nil, fmt.Errorf("icon: unable to encode PNG image: %v", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// resizeIcon scales the image down to fit in a square of the given size, the aspect ratio is preserved.
+// Each pixel of the result is the average of the source pixels it covers.
+func resizeIcon(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return src
+	}
+
+	dstWidth, dstHeight := size, size
+	if width > height {
+		dstHeight = max(1, height*size/width)
+	} else if height > width {
+		dstWidth = max(1, width*size/height)
+	}
+
+	// Averaging premultiplied colors avoids dark borders around transparent areas.
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := range dstHeight {
+		y0, y1 := y*height/dstHeight, max((y+1)*height/dstHeight, y*height/dstHeight+1)
+		for x := range dstWidth {
+			x0, x1 := x*width/dstWidth, max((x+1)*width/dstWidth, x*width/dstWidth+1)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := rgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(rgba.Pix[offset])
+					g += uint64(rgba.Pix[offset+1])
+					b += uint64(rgba.Pix[offset+2])
+					a += uint64(rgba.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = uint8(a / count)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package icon // import "miniflux.app/v2/internal/reader/icon"
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func newTestImage(width, height int, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodeTestPNG(img image.Image) []byte {
+	var buffer bytes.Buffer
+	png.Encode(&buffer, img)
+	return buffer.Bytes()
+}
+
+func TestNormalizeLargePNG(t *testing.T) {
+	result, err := normalizeRasterIcon(encodeTestPNG(newTestImage(512, 256, color.NRGBA{R: 0xff, A: 0xff})), 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img := decodeTestPNG(t, result)
+	if img.Bounds().Dx() != 128 || img.Bounds().Dy() != 64 {
+		t.Fatalf(`The image should be resized to 128x64, got %v`, img.Bounds())
+	}
+
+	if pixel := color.NRGBAModel.Convert(img.At(10, 10)).(color.NRGBA); pixel != (color.NRGBA{R: 0xff, A: 0xff}) {
+		t.Errorf(`Invalid pixel color, got %v`, pixel)
+	}
+}
+
+func TestNormalizeSmallPNGIsUnchanged(t *testing.T) {
+	content := encodeTestPNG(newTestImage(32, 32, color.White))
+
+	result, err := normalizeRasterIcon(content, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(result, content) {
+		t.Error(`Small PNG images should not be encoded again`)
+	}
+}
+
+func TestNormalizeJPEGAndGIF(t *testing.T) {
+	var jpegImage, gifImage bytes.Buffer
+	jpeg.Encode(&jpegImage, newTestImage(200, 300, color.White), nil)
+	gif.Encode(&gifImage, newTestImage(16, 16, color.Black), nil)
+
+	scenarios := map[string]struct {
+		content       []byte
+		width, height int
+	}{
+		"jpeg": {jpegImage.Bytes(), 85, 128},
+		"gif":  {gifImage.Bytes(), 16, 16},
+	}
+
+	for name, scenario := range scenarios {
+		result, err := normalizeRasterIcon(scenario.content, 4096)
+		if err != nil {
+			t.Fatalf(`Unable to normalize %s image: %v`, name, err)
+		}
+
+		img := decodeTestPNG(t, result)
+		if img.Bounds().Dx() != scenario.width || img.Bounds().Dy() != scenario.height {
+			t.Errorf(`Invalid dimensions of %s image, got %v`, name, img.Bounds())
+		}
+	}
+}
+
+func TestNormalizeUnsupportedImage(t *testing.T) {
+	if _, err := normalizeRasterIcon([]byte("not an image"), 4096); err == nil {
+		t.Error(`An error should be returned for unsupported images`)
+	}
+}
+
+func TestNormalizeImageTooLarge(t *testing.T) {
+	_, err := normalizeRasterIcon(encodeTestPNG(newTestImage(300, 20, color.White)), 256)
+	if !errors.Is(err, errIconTooLarge) {
+		t.Fatalf(`Images wider than the maximum dimension should be rejected, got %v`, err)
+	}
+}
+
+func TestResizeIconAveragesTransparentPixels(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 0xff, A: 0xff})
+
+	result := resizeIcon(img, 1)
+	pixel := color.NRGBAModel.Convert(result.At(0, 0)).(color.NRGBA)
+	if pixel.R != 0xff || pixel.G != 0 || pixel.A != 0x7f {
+		t.Errorf(`Transparent pixels should not darken the result, got %v`, pixel)
+	}
+}
+
+func TestNewIconNormalizesImages(t *testing.T) {
+	var jpegImage bytes.Buffer
+	jpeg.Encode(&jpegImage, newTestImage(256, 256, color.White), nil)
+
+	icon, err := newIcon(jpegImage.Bytes(), "image/jpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if icon.MimeType != "image/png" {
+		t.Errorf(`Invalid mime type, got %q`, icon.MimeType)
+	}
+
+	if img := decodeTestPNG(t, icon.Content); img.Bounds().Dx() != 128 {
+		t.Errorf(`Invalid icon dimensions, got %v`, img.Bounds())
+	}
+}
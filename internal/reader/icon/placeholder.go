@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package icon // import "miniflux.app/v2/internal/reader/icon"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+)
+
+// placeholderColors are dark enough to keep a white letter readable.
+var placeholderColors = []string{
+	"#c0392b", "#d35400", "#b7950b", "#27ae60", "#16a085", "#2980b9",
+	"#8e44ad", "#2c3e50", "#c2185b", "#5d4037", "#00796b", "#455a64",
+}
+
+// NewPlaceholderIcon returns a colored tile with the first letter of the feed title, used when the website doesn't have any icon.
+// The color is derived from the website hostname, so the same feed always gets the same icon.
+func NewPlaceholderIcon(title, websiteURL string) *model.Icon {
+	key := websiteURL
+	if parsedURL, err := url.Parse(websiteURL); err == nil && parsedURL.Hostname() != "" {
+		key = strings.TrimPrefix(parsedURL.Hostname(), "www.")
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	color := placeholderColors[hash.Sum32()%uint32(len(placeholderColors))]
+
+	var letter bytes.Buffer
+	xml.EscapeText(&letter, []byte(placeholderLetter(title, key)))
+
+	content := []byte(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="32" height="32" viewBox="0 0 32 32">`+
+			`<rect width="32" height="32" rx="6" fill="%s"/>`+
+			`<text x="16" y="22" fill="#fff" font-family="system-ui, sans-serif" font-size="18" font-weight="bold" text-anchor="middle">%s</text>`+
+			`</svg>`,
+		color, letter.String(),
+	))
+
+	return &model.Icon{
+		Hash:     crypto.HashFromBytes(content),
+		Content:  content,
+		MimeType: svgMimeType,
+	}
+}
+
+// placeholderLetter returns the first letter or digit of the title, or of the hostname when the title has none.
+func placeholderLetter(values ...string) string {
+	for _, value := range values {
+		for _, r := range value {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return string(unicode.ToUpper(r))
+			}
+		}
+	}
+	return "#"
+}
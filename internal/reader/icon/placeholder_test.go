@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package icon // import "miniflux.app/v2/internal/reader/icon"
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewPlaceholderIcon(t *testing.T) {
+	icon := NewPlaceholderIcon("miniflux releases", "https://www.miniflux.app/releases")
+	if icon.MimeType != svgMimeType {
+		t.Fatalf(`Invalid mime type, got %q`, icon.MimeType)
+	}
+
+	if !strings.Contains(string(icon.Content), `>M</text>`) {
+		t.Errorf(`The icon should contain the first letter of the title, got %s`, icon.Content)
+	}
+
+	if sanitized, err := sanitizeSVG(icon.Content); err != nil || !bytes.Contains(sanitized, []byte(`>M</text>`)) {
+		t.Errorf(`The placeholder should be a valid SVG image: %v`, err)
+	}
+
+	if other := NewPlaceholderIcon("Miniflux", "https://miniflux.app/"); other.Hash != icon.Hash {
+		t.Error(`The same website should get the same placeholder`)
+	}
+}
+
+func TestPlaceholderLetter(t *testing.T) {
+	scenarios := map[string][]string{
+		"É": {"   élan", "example.org"},
+		"4": {"404 Media", "404media.co"},
+		"E": {"« »", "example.org"},
+		"#": {"", ""},
+	}
+
+	for expected, values := range scenarios {
+		if result := placeholderLetter(values...); result != expected {
+			t.Errorf(`Invalid letter for %v, got %q instead of %q`, values, result, expected)
+		}
+	}
+}
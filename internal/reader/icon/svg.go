@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package icon // import "miniflux.app/v2/internal/reader/icon"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+)
+
+const svgMimeType = "image/svg+xml"
+
+// Elements removed from SVG icons with all their children.
+var forbiddenSVGElements = map[string]bool{
+	"script":           true,
+	"foreignobject":    true,
+	"iframe":           true,
+	"object":           true,
+	"embed":            true,
+	"audio":            true,
+	"video":            true,
+	"handler":          true,
+	"listener":         true,
+	"animate":          true,
+	"animatemotion":    true,
+	"animatetransform": true,
+	"set":              true,
+	"discard":          true,
+}
+
+// isSVGIcon returns true if the content is an SVG image.
+// Some servers don't send the right content type, so the content is inspected when the type is not an image.
+func isSVGIcon(contentType string, content []byte) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && mediaType == svgMimeType {
+		return true
+	}
+
+	if err == nil && strings.HasPrefix(mediaType, "image/") {
+		return false
+	}
+
+	head := content[:min(len(content), 1024)]
+	return bytes.Contains(bytes.ToLower(head), []byte("<svg"))
+}
+
+// sanitizeSVG removes scripts, event handlers and external references from an SVG document.
+// The document is always re-serialized, the original bytes are never returned even when nothing has been removed.
+func sanitizeSVG(content []byte) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	var buffer bytes.Buffer
+	hasRootElement := false
+	skipDepth := 0
+	var openElements []xml.Name
+
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("icon: invalid SVG document: %v", err)
+		}
+
+		if skipDepth > 0 {
+			switch token.(type) {
+			case xml.StartElement:
+				skipDepth++
+			case xml.EndElement:
+				skipDepth--
+			}
+			continue
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			localName := strings.ToLower(t.Name.Local)
+			if !hasRootElement && localName != "svg" {
+				return nil, errors.New("icon: the root element of the document is not an SVG element")
+			}
+			hasRootElement = true
+
+			if forbiddenSVGElements[localName] {
+				skipDepth = 1
+				continue
+			}
+
+			buffer.WriteString("<" + qualifiedName(t.Name))
+			for _, attr := range t.Attr {
+				if !isSafeSVGAttribute(attr) {
+					continue
+				}
+				buffer.WriteString(" " + qualifiedName(attr.Name) + `="`)
+				xml.EscapeText(&buffer, []byte(attr.Value))
+				buffer.WriteString(`"`)
+			}
+			buffer.WriteString(">")
+			openElements = append(openElements, t.Name)
+		case xml.EndElement:
+			if len(openElements) == 0 || openElements[len(openElements)-1] != t.Name {
+				return nil, fmt.Errorf("icon: invalid SVG document: unexpected end element %s", qualifiedName(t.Name))
+			}
+			openElements = openElements[:len(openElements)-1]
+			buffer.WriteString("</" + qualifiedName(t.Name) + ">")
+		case xml.CharData:
+			if len(openElements) > 0 && strings.EqualFold(openElements[len(openElements)-1].Local, "style") && hasExternalReference(string(t)) {
+				continue
+			}
+			xml.EscapeText(&buffer, t)
+		case xml.Comment:
+			buffer.WriteString("<!--")
+			buffer.Write(t)
+			buffer.WriteString("-->")
+		case xml.ProcInst:
+			if t.Target != "xml" {
+				continue
+			}
+			buffer.WriteString("<?xml " + string(t.Inst) + "?>")
+		case xml.Directive:
+			// Document type declarations can define entities and reference external resources, they are dropped.
+		}
+	}
+
+	if !hasRootElement {
+		return nil, errors.New("icon: the document doesn't contain any SVG element")
+	}
+
+	if len(openElements) > 0 {
+		return nil, errors.New("icon: invalid SVG document: unexpected end of document")
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// isSafeSVGAttribute rejects event handlers and references to external resources.
+// Only references to elements of the document and embedded raster images are allowed.
+func isSafeSVGAttribute(attr xml.Attr) bool {
+	name := strings.ToLower(attr.Name.Local)
+	value := strings.ToLower(strings.TrimSpace(attr.Value))
+
+	if strings.HasPrefix(name, "on") || strings.Contains(value, "javascript:") {
+		return false
+	}
+
+	if name == "href" || name == "src" {
+		if strings.HasPrefix(value, "#") {
+			return true
+		}
+		return strings.HasPrefix(value, "data:image/") && !strings.HasPrefix(value, "data:"+svgMimeType)
+	}
+
+	return !hasExternalReference(value)
+}
+
+// hasExternalReference returns true if the CSS value imports a style sheet or uses a URL that is not a fragment.
+func hasExternalReference(value string) bool {
+	value = strings.ToLower(value)
+	if strings.Contains(value, "@import") {
+		return true
+	}
+
+	for {
+		index := strings.Index(value, "url(")
+		if index == -1 {
+			return false
+		}
+
+		value = strings.TrimLeft(value[index+len("url("):], " \t\r\n'\"")
+		if !strings.HasPrefix(value, "#") {
+			return true
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package icon // import "miniflux.app/v2/internal/reader/icon"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsSVGIcon(t *testing.T) {
+	scenarios := []struct {
+		contentType string
+		content     string
+		expected    bool
+	}{
+		{"image/svg+xml", "", true},
+		{"image/svg+xml; charset=utf-8", "", true},
+		{"image/png", "<svg></svg>", false},
+		{"text/plain", `<?xml version="1.0"?><svg></svg>`, true},
+		{"", "<SVG></SVG>", true},
+		{"application/octet-stream", "\x00\x00\x01\x00", false},
+	}
+
+	for _, scenario := range scenarios {
+		if result := isSVGIcon(scenario.contentType, []byte(scenario.content)); result != scenario.expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, scenario.contentType, result, scenario.expected)
+		}
+	}
+}
+
+func TestSanitizeSVGWithoutChange(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<!-- Icon -->
+<svg xmlns='http://www.w3.org/2000/svg' xmlns:xlink="http://www.w3.org/1999/xlink" viewBox='0 0 16 16'>
+	<defs><linearGradient id="g"><stop offset="0" stop-color="#fff"/></linearGradient></defs>
+	<circle cx="8" cy="8" r="8" fill="url(#g)"/>
+	<use xlink:href="#g"/>
+</svg>`
+
+	output, err := sanitizeSVG([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{`viewBox="0 0 16 16"`, `<stop offset="0" stop-color="#fff"></stop>`, `fill="url(#g)"`, `xlink:href="#g"`, `<!-- Icon -->`} {
+		if !strings.Contains(string(output), expected) {
+			t.Errorf(`The safe content should be kept, %q not found in %q`, expected, output)
+		}
+	}
+
+	again, err := sanitizeSVG(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(again) != string(output) {
+		t.Errorf(`The re-serialized document should be stable, got %q instead of %q`, again, output)
+	}
+}
+
+func TestSanitizeSVGRemovesScripts(t *testing.T) {
+	input := `<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)">
+<script type="text/javascript"><![CDATA[alert(1)]]></script>
+<foreignObject><body xmlns="http://www.w3.org/1999/xhtml"><script>alert(1)</script></body></foreignObject>
+<a href="javascript:alert(1)"><rect width="16" height="16" onclick="alert(1)"/></a>
+<set attributeName="href" to="javascript:alert(1)"/>
+</svg>`
+
+	output, err := sanitizeSVG([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, forbidden := range []string{"script", "foreignObject", "alert", "onload", "onclick", "<set"} {
+		if strings.Contains(string(output), forbidden) {
+			t.Errorf(`The document should not contain %q, got %q`, forbidden, output)
+		}
+	}
+
+	if !strings.Contains(string(output), `<rect width="16" height="16">`) {
+		t.Errorf(`The safe elements should be kept, got %q`, output)
+	}
+}
+
+func TestSanitizeSVGRemovesExternalReferences(t *testing.T) {
+	input := `<!DOCTYPE svg [<!ENTITY ext SYSTEM "file:///etc/passwd">]>
+<?xml-stylesheet href="https://example.org/style.css"?>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink">
+<style>@import url("https://example.org/style.css");</style>
+<style>circle { fill: red; }</style>
+<image href="https://example.org/tracker.png"/>
+<image xlink:href="data:image/png;base64,iVBORw0KGgo="/>
+<image href="data:image/svg+xml;base64,PHN2Zz48L3N2Zz4="/>
+<rect style="fill: url( 'https://example.org/pattern.svg#p' )"/>
+<rect filter="url(#blur)"/>
+</svg>`
+
+	output, err := sanitizeSVG([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, forbidden := range []string{"https://example.org", "ENTITY", "xml-stylesheet", "@import", "data:image/svg+xml"} {
+		if strings.Contains(string(output), forbidden) {
+			t.Errorf(`The document should not contain %q, got %q`, forbidden, output)
+		}
+	}
+
+	for _, expected := range []string{"circle { fill: red; }", `xlink:href="data:image/png;base64,iVBORw0KGgo="`, `filter="url(#blur)"`} {
+		if !strings.Contains(string(output), expected) {
+			t.Errorf(`The document should contain %q, got %q`, expected, output)
+		}
+	}
+}
+
+func TestSanitizeInvalidSVG(t *testing.T) {
+	for _, input := range []string{"", "<html><body></body></html>", "<svg><circle></svg>", "not an image"} {
+		if _, err := sanitizeSVG([]byte(input)); err == nil {
+			t.Errorf(`An error should be returned for %q`, input)
+		}
+	}
+}
+
+func TestParseImageDataURLWithUnsafeSVG(t *testing.T) {
+	icon, err := parseImageDataURL(`data:image/svg+xml;utf8,<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(icon.Content) != `<svg xmlns="http://www.w3.org/2000/svg"></svg>` {
+		t.Errorf(`Invalid SVG content, got %q`, icon.Content)
+	}
+}
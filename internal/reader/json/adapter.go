@@ -64,11 +64,26 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 		}
 	}
 
+	// Populate the WebSub hub URL if present.
+	for _, hub := range j.jsonFeed.Hubs {
+		if strings.EqualFold(hub.Type, "WebSub") {
+			if absoluteHubURL, err := urllib.AbsoluteURL(feed.FeedURL, strings.TrimSpace(hub.URL)); err == nil {
+				feed.HubURL = absoluteHubURL
+				break
+			}
+		}
+	}
+
 	for _, item := range j.jsonFeed.Items {
 		entry := model.NewEntry()
 		entry.Title = strings.TrimSpace(item.Title)
 		entry.URL = strings.TrimSpace(item.URL)
 
+		// Fallback to the external URL if the item doesn't have its own page.
+		if entry.URL == "" {
+			entry.URL = strings.TrimSpace(item.ExternalURL)
+		}
+
 		// Make sure the entry URL is absolute.
 		if entryURL, err := urllib.AbsoluteURL(feed.SiteURL, entry.URL); err == nil {
 			entry.URL = entryURL
@@ -118,16 +133,10 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 		}
 
 		// Populate the entry author.
-		itemAuthors := j.jsonFeed.Authors
-		itemAuthors = append(itemAuthors, item.Authors...)
-		itemAuthors = append(itemAuthors, item.Author, j.jsonFeed.Author)
-
-		var authorNames []string
-		for _, author := range itemAuthors {
-			authorName := strings.TrimSpace(author.Name)
-			if authorName != "" {
-				authorNames = append(authorNames, authorName)
-			}
+		// The item authors take precedence over the feed authors.
+		authorNames := findAuthorNames(item.Authors, item.Author)
+		if len(authorNames) == 0 {
+			authorNames = findAuthorNames(j.jsonFeed.Authors, j.jsonFeed.Author)
 		}
 
 		slices.Sort(authorNames)
@@ -135,17 +144,45 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 		entry.Author = strings.Join(authorNames, ", ")
 
 		// Populate the entry enclosures.
+		// The attachments sharing the same title are alternate formats of the same resource, they are all kept.
+		duplicates := make(map[string]bool)
 		for _, attachment := range item.Attachments {
 			attachmentURL := strings.TrimSpace(attachment.URL)
 			if attachmentURL != "" {
-				if absoluteAttachmentURL, err := urllib.AbsoluteURL(feed.SiteURL, attachmentURL); err == nil {
+				if absoluteAttachmentURL, err := urllib.AbsoluteURL(feed.SiteURL, attachmentURL); err == nil && !duplicates[absoluteAttachmentURL] {
+					duplicates[absoluteAttachmentURL] = true
 					entry.Enclosures = append(entry.Enclosures, &model.Enclosure{
 						URL:      absoluteAttachmentURL,
-						MimeType: attachment.MimeType,
+						MimeType: strings.TrimSpace(attachment.MimeType),
 						Size:     attachment.Size,
 					})
 				}
 			}
+
+			// Set podcast listening time.
+			if attachment.Duration > 0 {
+				entry.ReadingTime = max(entry.ReadingTime, attachment.Duration/60)
+			}
+		}
+
+		// Populate the entry thumbnail.
+		for _, imageURL := range []string{item.ImageURL, item.BannerImageURL} {
+			imageURL = strings.TrimSpace(imageURL)
+			if imageURL != "" {
+				if absoluteImageURL, err := urllib.AbsoluteURL(feed.SiteURL, imageURL); err == nil {
+					entry.ThumbnailURL = absoluteImageURL
+					break
+				}
+			}
+		}
+
+		// Populate the entry language.
+		for _, language := range []string{item.Language, j.jsonFeed.Language} {
+			language = strings.TrimSpace(language)
+			if language != "" {
+				entry.Language = language
+				break
+			}
 		}
 
 		// Populate the entry tags.
@@ -157,7 +194,7 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 		}
 
 		// Generate a hash for the entry.
-		for _, value := range []string{item.ID, item.URL, item.ContentText + item.ContentHTML + item.Summary} {
+		for _, value := range []string{item.ID, item.URL, item.ExternalURL, item.ContentText + item.ContentHTML + item.Summary} {
 			value = strings.TrimSpace(value)
 			if value != "" {
 				entry.Hash = crypto.Hash(value)
@@ -170,3 +207,14 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 
 	return feed
 }
+
+func findAuthorNames(authors []JSONAuthor, deprecatedAuthor JSONAuthor) []string {
+	var authorNames []string
+	for _, author := range append(authors, deprecatedAuthor) {
+		authorName := strings.TrimSpace(author.Name)
+		if authorName != "" {
+			authorNames = append(authorNames, authorName)
+		}
+	}
+	return authorNames
+}
@@ -4,17 +4,25 @@
 package json // import "miniflux.app/v2/internal/reader/json"
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/encoding"
 )
 
 // Parse returns a normalized feed struct from a JSON feed.
 func Parse(baseURL string, data io.Reader) (*model.Feed, error) {
+	buffer, err := io.ReadAll(data)
+	if err != nil {
+		return nil, fmt.Errorf("json: unable to read feed: %w", err)
+	}
+
+	// The JSON feeds must be encoded in UTF-8, but some of them are served in a legacy charset.
 	jsonFeed := new(JSONFeed)
-	if err := json.NewDecoder(data).Decode(&jsonFeed); err != nil {
+	if err := json.NewDecoder(bytes.NewReader(encoding.ConvertToUTF8(buffer, ""))).Decode(&jsonFeed); err != nil {
 		return nil, fmt.Errorf("json: unable to parse feed: %w", err)
 	}
 
@@ -196,6 +196,29 @@ func TestParseFeedWithFeedURLWithTrailingSpace(t *testing.T) {
 	}
 }
 
+func TestParseFeedHubURL(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "My Example Feed",
+		"home_page_url": "https://example.org/",
+		"feed_url": "https://example.org/feed.json",
+		"hubs": [
+			{"type": "rssCloud", "url": "https://cloud.example.org/"},
+			{"type": "WebSub", "url": "https://hub.example.org/"}
+		],
+		"items": []
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+}
+
 func TestParseFeedWithRelativeFeedURL(t *testing.T) {
 	data := `{
 		"version": "https://jsonfeed.org/version/1",
@@ -891,3 +914,171 @@ func TestParseInvalidJSON(t *testing.T) {
 		t.Error("Parse should returns an error")
 	}
 }
+
+func TestParseItemAuthorsOverrideFeedAuthors(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "Example",
+		"home_page_url": "https://example.org/",
+		"authors": [{"name": "Feed Author"}],
+		"items": [
+			{
+				"id": "1",
+				"url": "https://example.org/1",
+				"authors": [{"name": "Item Author"}, {"name": "Other Author"}]
+			},
+			{
+				"id": "2",
+				"url": "https://example.org/2"
+			}
+		]
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Author != "Item Author, Other Author" {
+		t.Errorf("Incorrect entry author, got: %s", feed.Entries[0].Author)
+	}
+
+	if feed.Entries[1].Author != "Feed Author" {
+		t.Errorf("Incorrect entry author, got: %s", feed.Entries[1].Author)
+	}
+}
+
+func TestParseItemWithExternalURL(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "Example",
+		"home_page_url": "https://example.org/",
+		"items": [
+			{
+				"id": "1",
+				"url": "https://example.org/1",
+				"external_url": "https://example.com/article"
+			},
+			{
+				"external_url": "https://example.com/other-article",
+				"content_text": "Some text"
+			}
+		]
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].URL != "https://example.org/1" {
+		t.Errorf("Incorrect entry URL, got: %s", feed.Entries[0].URL)
+	}
+
+	if feed.Entries[1].URL != "https://example.com/other-article" {
+		t.Errorf("Incorrect entry URL, got: %s", feed.Entries[1].URL)
+	}
+}
+
+func TestParseItemWithImages(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "Example",
+		"home_page_url": "https://example.org/",
+		"items": [
+			{
+				"id": "1",
+				"url": "https://example.org/1",
+				"image": "/image.jpg",
+				"banner_image": "/banner.jpg"
+			},
+			{
+				"id": "2",
+				"url": "https://example.org/2",
+				"banner_image": "/banner.jpg"
+			}
+		]
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].ThumbnailURL != "https://example.org/image.jpg" {
+		t.Errorf("Incorrect entry thumbnail URL, got: %s", feed.Entries[0].ThumbnailURL)
+	}
+
+	if feed.Entries[1].ThumbnailURL != "https://example.org/banner.jpg" {
+		t.Errorf("Incorrect entry thumbnail URL, got: %s", feed.Entries[1].ThumbnailURL)
+	}
+}
+
+func TestParseItemWithLanguage(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "Example",
+		"home_page_url": "https://example.org/",
+		"language": "en-US",
+		"items": [
+			{
+				"id": "1",
+				"url": "https://example.org/1",
+				"language": "fr"
+			},
+			{
+				"id": "2",
+				"url": "https://example.org/2"
+			}
+		]
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Language != "fr" {
+		t.Errorf("Incorrect entry language, got: %s", feed.Entries[0].Language)
+	}
+
+	if feed.Entries[1].Language != "en-US" {
+		t.Errorf("Incorrect entry language, got: %s", feed.Entries[1].Language)
+	}
+}
+
+func TestParseItemWithMultipleAttachments(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "Example",
+		"home_page_url": "https://example.org/",
+		"items": [
+			{
+				"id": "1",
+				"url": "https://example.org/1",
+				"attachments": [
+					{"url": "https://example.org/episode.mp3", "mime_type": "audio/mpeg", "title": "Episode", "size_in_bytes": 123, "duration_in_seconds": 1830},
+					{"url": "https://example.org/episode.m4a", "mime_type": "audio/mp4", "title": "Episode", "size_in_bytes": 456, "duration_in_seconds": 1830},
+					{"url": "https://example.org/episode.mp3", "mime_type": "audio/mpeg"}
+				]
+			}
+		]
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries[0].Enclosures) != 2 {
+		t.Fatalf("Incorrect number of enclosures, got: %d", len(feed.Entries[0].Enclosures))
+	}
+
+	if feed.Entries[0].Enclosures[1].URL != "https://example.org/episode.m4a" || feed.Entries[0].Enclosures[1].MimeType != "audio/mp4" || feed.Entries[0].Enclosures[1].Size != 456 {
+		t.Errorf("Incorrect enclosure, got: %+v", feed.Entries[0].Enclosures[1])
+	}
+
+	if feed.Entries[0].ReadingTime != 30 {
+		t.Errorf("Incorrect entry reading time, got: %d", feed.Entries[0].ReadingTime)
+	}
+}
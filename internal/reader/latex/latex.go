@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package latex // import "miniflux.app/v2/internal/reader/latex"
+
+import (
+	"io"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// The formulas are not searched in the content of these elements.
+var skippedTags = []string{"code", "kbd", "math", "pre", "samp", "script", "style", "svg", "textarea"}
+
+var delimiters = []struct {
+	opening string
+	closing string
+	display bool
+}{
+	{"$$", "$$", true},
+	{`\[`, `\]`, true},
+	{`\(`, `\)`, false},
+}
+
+// RenderMath replaces the LaTeX formulas delimited by $$…$$, \[…\] and \(…\) in the text of the HTML document
+// with MathML elements. The formulas that cannot be converted are left untouched.
+func RenderMath(input string) string {
+	if !strings.Contains(input, "$$") && !strings.Contains(input, `\[`) && !strings.Contains(input, `\(`) {
+		return input
+	}
+
+	var buffer strings.Builder
+	var skippedStack []string
+
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if tokenizer.Err() == io.EOF {
+				return buffer.String()
+			}
+			return input
+		}
+
+		raw := string(tokenizer.Raw())
+
+		switch tokenType {
+		case html.TextToken:
+			if len(skippedStack) == 0 {
+				raw = renderFormulas(raw)
+			}
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			if tagName := string(name); slices.Contains(skippedTags, tagName) {
+				skippedStack = append(skippedStack, tagName)
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if len(skippedStack) > 0 && skippedStack[len(skippedStack)-1] == string(name) {
+				skippedStack = skippedStack[:len(skippedStack)-1]
+			}
+		}
+
+		buffer.WriteString(raw)
+	}
+}
+
+// renderFormulas converts the formulas of an escaped text.
+func renderFormulas(text string) string {
+	var buffer strings.Builder
+
+	for {
+		start := -1
+		var opening, closing string
+		var display bool
+		for _, delimiter := range delimiters {
+			if index := strings.Index(text, delimiter.opening); index >= 0 && (start < 0 || index < start) {
+				start, opening, closing, display = index, delimiter.opening, delimiter.closing, delimiter.display
+			}
+		}
+
+		if start < 0 {
+			break
+		}
+
+		end := strings.Index(text[start+len(opening):], closing)
+		if end < 0 {
+			break
+		}
+		end += start + len(opening)
+
+		buffer.WriteString(text[:start])
+		formula := strings.TrimSpace(html.UnescapeString(text[start+len(opening) : end]))
+		if mathML, err := ToMathML(formula, display); formula != "" && err == nil {
+			buffer.WriteString(mathML)
+		} else {
+			buffer.WriteString(text[start : end+len(closing)])
+		}
+
+		text = text[end+len(closing):]
+	}
+
+	buffer.WriteString(text)
+	return buffer.String()
+}
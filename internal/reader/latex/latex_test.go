@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package latex // import "miniflux.app/v2/internal/reader/latex"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMathWithoutFormula(t *testing.T) {
+	input := `<p>Nothing to <b>render</b> here, it costs $5.</p>`
+	if output := RenderMath(input); output != input {
+		t.Errorf(`The content should not be modified, got %s`, output)
+	}
+}
+
+func TestRenderMathDelimiters(t *testing.T) {
+	input := `<p>Inline \(a &lt; b\), display $$x^2$$ and \[y\].</p>`
+	output := RenderMath(input)
+
+	expected := `<p>Inline <math><semantics><mrow><mi>a</mi><mo>&lt;</mo><mi>b</mi></mrow><annotation encoding="application/x-tex">a &lt; b</annotation></semantics></math>, ` +
+		`display <math display="block"><semantics><mrow><msup><mi>x</mi><mn>2</mn></msup></mrow><annotation encoding="application/x-tex">x^2</annotation></semantics></math> ` +
+		`and <math display="block"><semantics><mrow><mi>y</mi></mrow><annotation encoding="application/x-tex">y</annotation></semantics></math>.</p>`
+
+	if output != expected {
+		t.Errorf(`Unexpected output: %s`, output)
+	}
+}
+
+func TestRenderMathSkipsCode(t *testing.T) {
+	input := `<pre><code>echo $$x$$</code></pre><p><code>\(x\)</code></p>`
+	if output := RenderMath(input); output != input {
+		t.Errorf(`The code should not be modified, got %s`, output)
+	}
+}
+
+func TestRenderMathKeepsInvalidFormula(t *testing.T) {
+	input := `<p>$$\unknowncommand{x}$$ and $$x$$</p>`
+	output := RenderMath(input)
+
+	if !strings.HasPrefix(output, `<p>$$\unknowncommand{x}$$ and <math display="block">`) {
+		t.Errorf(`The invalid formula should be kept, got %s`, output)
+	}
+}
+
+func TestRenderMathWithUnclosedDelimiter(t *testing.T) {
+	input := `<p>\(x</p><p>y\)</p>`
+	if output := RenderMath(input); output != input {
+		t.Errorf(`The formulas spanning several elements should not be converted, got %s`, output)
+	}
+}
@@ -0,0 +1,803 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package latex // import "miniflux.app/v2/internal/reader/latex"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	maxFormulaLength = 10000
+	maxNestingDepth  = 64
+)
+
+var textReplacer = strings.NewReplacer(`\{`, "{", `\}`, "}", `\$`, "$", `\%`, "%", `\&`, "&", `\_`, "_", `\#`, "#", `\ `, " ", "~", "\u00a0")
+
+// ToMathML converts a LaTeX formula to a MathML element, the display formulas are rendered as blocks.
+// Only the commands commonly used in the articles are supported, an error is returned for the other ones.
+func ToMathML(tex string, display bool) (string, error) {
+	if len(tex) > maxFormulaLength {
+		return "", fmt.Errorf("latex: the formula is longer than %d characters", maxFormulaLength)
+	}
+
+	p := &parser{input: []rune(tex), display: display}
+	rows, err := p.parseRows()
+	if err != nil {
+		return "", err
+	}
+
+	if !p.eof() {
+		return "", fmt.Errorf("latex: unexpected %q at position %d", string(p.input[p.pos:min(p.pos+10, len(p.input))]), p.pos)
+	}
+
+	var buffer strings.Builder
+	if display {
+		buffer.WriteString(`<math display="block">`)
+	} else {
+		buffer.WriteString(`<math>`)
+	}
+
+	content := buildTable(rows, "")
+	if len(rows) == 1 && len(rows[0]) == 1 {
+		content = rows[0][0]
+	}
+
+	// The first child of the semantics element is the rendered formula, it must be a single element.
+	if !strings.HasPrefix(content, "<mrow>") {
+		content = "<mrow>" + content + "</mrow>"
+	}
+
+	buffer.WriteString("<semantics>")
+	buffer.WriteString(content)
+	buffer.WriteString(`<annotation encoding="application/x-tex">`)
+	buffer.WriteString(html.EscapeString(tex))
+	buffer.WriteString("</annotation></semantics></math>")
+
+	return buffer.String(), nil
+}
+
+type parser struct {
+	input   []rune
+	pos     int
+	display bool
+	depth   int
+
+	// variant is the font applied to the letters and digits, see fontCommands.
+	variant string
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// peekCommand returns the name of the command at the current position without consuming it.
+func (p *parser) peekCommand() string {
+	if p.peek() != '\\' || p.pos+1 >= len(p.input) {
+		return ""
+	}
+
+	end := p.pos + 1
+	for end < len(p.input) && isASCIILetter(p.input[end]) {
+		end++
+	}
+
+	if end == p.pos+1 {
+		return string(p.input[p.pos+1])
+	}
+	return string(p.input[p.pos+1 : end])
+}
+
+func (p *parser) readCommand() string {
+	name := p.peekCommand()
+	if name == "" {
+		p.pos++
+		return ""
+	}
+
+	p.pos += 1 + len([]rune(name))
+	if isASCIILetter([]rune(name)[0]) {
+		p.skipSpaces()
+	}
+	return name
+}
+
+// readRawGroup returns the unparsed content of the group at the current position.
+func (p *parser) readRawGroup() (string, error) {
+	p.skipSpaces()
+	if p.peek() != '{' {
+		return "", errors.New("latex: missing group")
+	}
+
+	level := 0
+	for i := p.pos; i < len(p.input); i++ {
+		switch p.input[i] {
+		case '\\':
+			i++
+		case '{':
+			level++
+		case '}':
+			level--
+			if level == 0 {
+				content := string(p.input[p.pos+1 : i])
+				p.pos = i + 1
+				return content, nil
+			}
+		}
+	}
+
+	return "", errors.New("latex: missing closing brace")
+}
+
+// readOptionalArgument returns the unparsed content of the brackets at the current position, if any.
+func (p *parser) readOptionalArgument() (string, bool) {
+	p.skipSpaces()
+	if p.peek() != '[' {
+		return "", false
+	}
+
+	level := 0
+	for i := p.pos + 1; i < len(p.input); i++ {
+		switch p.input[i] {
+		case '\\':
+			i++
+		case '{':
+			level++
+		case '}':
+			level--
+		case ']':
+			if level == 0 {
+				content := string(p.input[p.pos+1 : i])
+				p.pos = i + 1
+				return content, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// parseRows parses the cells of a table separated by & and \\, a formula without separators is a single cell.
+func (p *parser) parseRows() ([][]string, error) {
+	var rows [][]string
+	var cells []string
+
+	for {
+		nodes, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		cells = append(cells, row(nodes))
+
+		switch {
+		case p.peek() == '&':
+			p.pos++
+		case p.peekCommand() == `\`:
+			p.readCommand()
+			p.readOptionalArgument()
+			rows = append(rows, cells)
+			cells = nil
+		default:
+			// A trailing line break doesn't add an empty row.
+			if len(rows) == 0 || len(cells) > 1 || cells[0] != "<mrow></mrow>" {
+				rows = append(rows, cells)
+			}
+			return rows, nil
+		}
+	}
+}
+
+// parseList parses the elements until the end of the group, of the table cell or of the formula.
+func (p *parser) parseList() ([]string, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+
+	if p.depth > maxNestingDepth {
+		return nil, errors.New("latex: the formula is too deeply nested")
+	}
+
+	var nodes []string
+	for {
+		p.skipSpaces()
+		if p.eof() || p.peek() == '}' || p.peek() == '&' {
+			return nodes, nil
+		}
+
+		switch p.peekCommand() {
+		case `\`, "right", "end":
+			return nodes, nil
+		}
+
+		node, err := p.parseScripted()
+		if err != nil {
+			return nil, err
+		}
+
+		if node != "" {
+			nodes = append(nodes, node)
+		}
+	}
+}
+
+// parseScripted parses an element followed by its subscript, superscript and primes.
+func (p *parser) parseScripted() (string, error) {
+	base, limits, err := p.parseAtom()
+	if err != nil {
+		return "", err
+	}
+
+	var sub string
+	var sup []string
+	hasSub, hasSup := false, false
+
+scripts:
+	for {
+		p.skipSpaces()
+		switch p.peek() {
+		case '_':
+			if hasSub {
+				return "", errors.New("latex: double subscript")
+			}
+			p.pos++
+			if sub, err = p.parseArgument(); err != nil {
+				return "", err
+			}
+			hasSub = true
+		case '^':
+			if hasSup && (len(sup) == 0 || sup[len(sup)-1] != "<mo>′</mo>") {
+				return "", errors.New("latex: double superscript")
+			}
+			p.pos++
+			node, err := p.parseArgument()
+			if err != nil {
+				return "", err
+			}
+			sup = append(sup, node)
+			hasSup = true
+		case '\'':
+			p.pos++
+			sup = append(sup, "<mo>′</mo>")
+			hasSup = true
+		default:
+			switch p.peekCommand() {
+			case "limits":
+				p.readCommand()
+				limits = true
+			case "nolimits":
+				p.readCommand()
+				limits = false
+			default:
+				break scripts
+			}
+		}
+	}
+
+	if !hasSub && !hasSup {
+		return base, nil
+	}
+
+	if base == "" {
+		base = "<mrow></mrow>"
+	}
+
+	underOver := limits && p.display
+	switch {
+	case hasSub && hasSup && underOver:
+		return "<munderover>" + base + sub + row(sup) + "</munderover>", nil
+	case hasSub && hasSup:
+		return "<msubsup>" + base + sub + row(sup) + "</msubsup>", nil
+	case hasSub && underOver:
+		return "<munder>" + base + sub + "</munder>", nil
+	case hasSub:
+		return "<msub>" + base + sub + "</msub>", nil
+	case underOver:
+		return "<mover>" + base + row(sup) + "</mover>", nil
+	default:
+		return "<msup>" + base + row(sup) + "</msup>", nil
+	}
+}
+
+// parseArgument parses a group or a single element, without its scripts.
+func (p *parser) parseArgument() (string, error) {
+	p.skipSpaces()
+	switch {
+	case p.eof() || p.peek() == '}' || p.peek() == '&':
+		return "", errors.New("latex: missing argument")
+	case isDigit(p.peek()):
+		// Only the first digit belongs to the argument: x^23 is x squared followed by 3.
+		p.pos++
+		return "<mn>" + p.applyVariant(string(p.input[p.pos-1])) + "</mn>", nil
+	}
+
+	node, _, err := p.parseAtom()
+	if err == nil && node == "" {
+		node = "<mrow></mrow>"
+	}
+	return node, err
+}
+
+// parseGroup parses the elements between braces.
+func (p *parser) parseGroup() (string, error) {
+	p.pos++
+	nodes, err := p.parseList()
+	if err != nil {
+		return "", err
+	}
+
+	if p.peek() != '}' {
+		return "", errors.New("latex: missing closing brace")
+	}
+	p.pos++
+
+	return row(nodes), nil
+}
+
+// parseAtom parses a single element, the returned boolean is true when its limits are placed above and below in display mode.
+func (p *parser) parseAtom() (string, bool, error) {
+	if p.eof() {
+		return "", false, errors.New("latex: missing argument")
+	}
+
+	r := p.peek()
+	switch {
+	case r == '{':
+		node, err := p.parseGroup()
+		return node, false, err
+	case r == '\\':
+		return p.parseCommand()
+	case r == '^' || r == '_':
+		return "", false, nil
+	case isDigit(r) || (r == '.' && p.pos+1 < len(p.input) && isDigit(p.input[p.pos+1])):
+		start := p.pos
+		for !p.eof() && (isDigit(p.peek()) || (p.peek() == '.' && p.pos+1 < len(p.input) && isDigit(p.input[p.pos+1]))) {
+			p.pos++
+		}
+		return "<mn>" + p.applyVariant(string(p.input[start:p.pos])) + "</mn>", false, nil
+	case unicode.IsLetter(r):
+		start := p.pos
+		p.pos++
+		if p.variant == "normal" {
+			for !p.eof() && unicode.IsLetter(p.peek()) {
+				p.pos++
+			}
+		}
+		return p.identifier(string(p.input[start:p.pos])), false, nil
+	case r == '~':
+		p.pos++
+		return "<mtext>\u00a0</mtext>", false, nil
+	case r == '\'':
+		p.pos++
+		return "<mo>′</mo>", false, nil
+	case r == '$' || r == '%' || r == '#':
+		return "", false, fmt.Errorf("latex: unsupported character %q", r)
+	}
+
+	p.pos++
+	switch r {
+	case '-':
+		return operator("−"), false, nil
+	case '*':
+		return operator("∗"), false, nil
+	default:
+		return operator(string(r)), false, nil
+	}
+}
+
+func (p *parser) parseCommand() (string, bool, error) {
+	if p.pos+1 >= len(p.input) {
+		return "", false, errors.New("latex: trailing backslash")
+	}
+
+	name := p.readCommand()
+
+	if symbol, found := identifiers[name]; found {
+		return "<mi>" + symbol + "</mi>", false, nil
+	}
+
+	if symbol, found := uprightIdentifiers[name]; found {
+		return `<mi mathvariant="normal">` + symbol + "</mi>", false, nil
+	}
+
+	if symbol, found := operators[name]; found {
+		return operator(symbol), false, nil
+	}
+
+	if largeOperator, found := largeOperators[name]; found {
+		return operator(largeOperator.symbol), largeOperator.limits, nil
+	}
+
+	if limits, found := functions[name]; found {
+		return "<mi>" + name + "</mi>", limits, nil
+	}
+
+	if width, found := spaces[name]; found {
+		return `<mspace width="` + width + `"/>`, false, nil
+	}
+
+	if ignoredCommands[name] {
+		return "", false, nil
+	}
+
+	if symbol, found := accents[name]; found {
+		argument, err := p.parseArgument()
+		if err != nil {
+			return "", false, err
+		}
+		stretchy := strings.HasPrefix(name, "wide") || name == "overline"
+		return fmt.Sprintf(`<mover accent="true">%s<mo stretchy="%t">%s</mo></mover>`, argument, stretchy, html.EscapeString(symbol)), false, nil
+	}
+
+	if size, found := delimiterSizes[name]; found {
+		delimiter, err := p.parseDelimiter()
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf(`<mo stretchy="true" minsize="%s" maxsize="%s">%s</mo>`, size, size, html.EscapeString(delimiter)), false, nil
+	}
+
+	if variant, found := fontCommands[name]; found {
+		limits := false
+		if name == "operatorname" && p.peek() == '*' {
+			p.pos++
+			limits = true
+		}
+
+		previousVariant := p.variant
+		p.variant = variant
+		argument, err := p.parseArgument()
+		p.variant = previousVariant
+		return argument, limits, err
+	}
+
+	switch name {
+	case "{", "}", "%", "$", "#", "&", "_", "/":
+		return operator(name), false, nil
+	case "|":
+		return operator("‖"), false, nil
+	case "frac", "dfrac", "tfrac", "cfrac":
+		numerator, denominator, err := p.parseTwoArguments()
+		if err != nil {
+			return "", false, err
+		}
+		return "<mfrac>" + numerator + denominator + "</mfrac>", false, nil
+	case "binom", "dbinom", "tbinom":
+		top, bottom, err := p.parseTwoArguments()
+		if err != nil {
+			return "", false, err
+		}
+		return `<mrow><mo>(</mo><mfrac linethickness="0">` + top + bottom + `</mfrac><mo>)</mo></mrow>`, false, nil
+	case "sqrt":
+		index, hasIndex := p.readOptionalArgument()
+		radicand, err := p.parseArgument()
+		if err != nil {
+			return "", false, err
+		}
+		if !hasIndex {
+			return "<msqrt>" + radicand + "</msqrt>", false, nil
+		}
+		indexNode, err := p.parseSubFormula(index)
+		if err != nil {
+			return "", false, err
+		}
+		return "<mroot>" + radicand + indexNode + "</mroot>", false, nil
+	case "underline":
+		argument, err := p.parseArgument()
+		if err != nil {
+			return "", false, err
+		}
+		return `<munder accentunder="true">` + argument + `<mo stretchy="true">_</mo></munder>`, false, nil
+	case "overbrace":
+		argument, err := p.parseArgument()
+		if err != nil {
+			return "", false, err
+		}
+		return `<mover>` + argument + `<mo stretchy="true">⏞</mo></mover>`, true, nil
+	case "underbrace":
+		argument, err := p.parseArgument()
+		if err != nil {
+			return "", false, err
+		}
+		return `<munder>` + argument + `<mo stretchy="true">⏟</mo></munder>`, true, nil
+	case "overset", "stackrel":
+		over, base, err := p.parseTwoArguments()
+		if err != nil {
+			return "", false, err
+		}
+		return "<mover>" + base + over + "</mover>", false, nil
+	case "underset":
+		under, base, err := p.parseTwoArguments()
+		if err != nil {
+			return "", false, err
+		}
+		return "<munder>" + base + under + "</munder>", false, nil
+	case "text", "textrm", "textnormal", "textit", "textbf", "textsf", "texttt", "mbox", "hbox":
+		text, err := p.readRawGroup()
+		if err != nil {
+			return "", false, err
+		}
+		return "<mtext>" + html.EscapeString(textReplacer.Replace(text)) + "</mtext>", false, nil
+	case "phantom":
+		argument, err := p.parseArgument()
+		if err != nil {
+			return "", false, err
+		}
+		return "<mphantom>" + argument + "</mphantom>", false, nil
+	case "not":
+		p.skipSpaces()
+		node, _, err := p.parseAtom()
+		if err != nil {
+			return "", false, err
+		}
+		if !strings.HasPrefix(node, "<mo>") {
+			return "", false, errors.New(`latex: \not must be followed by an operator`)
+		}
+		return strings.TrimSuffix(node, "</mo>") + "̸</mo>", false, nil
+	case "pmod":
+		argument, err := p.parseArgument()
+		if err != nil {
+			return "", false, err
+		}
+		return `<mrow><mspace width="1em"/><mo>(</mo><mi>mod</mi><mspace width="0.3333em"/>` + argument + `<mo>)</mo></mrow>`, false, nil
+	case "bmod", "mod":
+		return "<mo>mod</mo>", false, nil
+	case "left":
+		return p.parseFenced()
+	case "middle":
+		delimiter, err := p.parseDelimiter()
+		if err != nil {
+			return "", false, err
+		}
+		return `<mo stretchy="true">` + html.EscapeString(delimiter) + `</mo>`, false, nil
+	case "begin":
+		environment, err := p.readRawGroup()
+		if err != nil {
+			return "", false, err
+		}
+		node, err := p.parseEnvironment(environment)
+		return node, false, err
+	case "tag", "label":
+		_, err := p.readRawGroup()
+		return "", false, err
+	}
+
+	return "", false, fmt.Errorf(`latex: unsupported command \%s`, name)
+}
+
+func (p *parser) parseTwoArguments() (string, string, error) {
+	first, err := p.parseArgument()
+	if err != nil {
+		return "", "", err
+	}
+
+	second, err := p.parseArgument()
+	if err != nil {
+		return "", "", err
+	}
+
+	return first, second, nil
+}
+
+// parseSubFormula parses a part of the formula that is not delimited by braces, like the index of a root.
+func (p *parser) parseSubFormula(tex string) (string, error) {
+	subParser := &parser{input: []rune(tex), display: p.display, depth: p.depth, variant: p.variant}
+	nodes, err := subParser.parseList()
+	if err != nil {
+		return "", err
+	}
+
+	if !subParser.eof() {
+		return "", fmt.Errorf("latex: unexpected %q", string(subParser.input[subParser.pos:]))
+	}
+
+	return row(nodes), nil
+}
+
+// parseFenced parses the elements between \left and \right, the delimiters stretch to the height of the content.
+func (p *parser) parseFenced() (string, bool, error) {
+	opening, err := p.parseDelimiter()
+	if err != nil {
+		return "", false, err
+	}
+
+	nodes, err := p.parseList()
+	if err != nil {
+		return "", false, err
+	}
+
+	if p.peekCommand() != "right" {
+		return "", false, errors.New(`latex: missing \right`)
+	}
+	p.readCommand()
+
+	closing, err := p.parseDelimiter()
+	if err != nil {
+		return "", false, err
+	}
+
+	return "<mrow>" + fence(opening) + strings.Join(nodes, "") + fence(closing) + "</mrow>", false, nil
+}
+
+// parseDelimiter returns the symbol of the delimiter following \left, \right, \middle or \big, the empty delimiter is an empty string.
+func (p *parser) parseDelimiter() (string, error) {
+	p.skipSpaces()
+	if p.eof() {
+		return "", errors.New("latex: missing delimiter")
+	}
+
+	if p.peek() == '\\' {
+		name := p.readCommand()
+		switch name {
+		case "{", "}":
+			return name, nil
+		case "|":
+			return "‖", nil
+		}
+		if symbol, found := operators[name]; found {
+			return symbol, nil
+		}
+		return "", fmt.Errorf(`latex: invalid delimiter \%s`, name)
+	}
+
+	r := p.peek()
+	p.pos++
+	switch r {
+	case '.':
+		return "", nil
+	case '<':
+		return "⟨", nil
+	case '>':
+		return "⟩", nil
+	case '(', ')', '[', ']', '|', '/':
+		return string(r), nil
+	}
+
+	return "", fmt.Errorf("latex: invalid delimiter %q", r)
+}
+
+// parseEnvironment parses the content of \begin{environment} until the matching \end.
+func (p *parser) parseEnvironment(environment string) (string, error) {
+	delimiters, found := matrixDelimiters[environment]
+	if !found {
+		return "", fmt.Errorf("latex: unsupported environment %q", environment)
+	}
+
+	// The vertical alignment and the column specification don't change the rendering.
+	switch environment {
+	case "aligned", "gathered":
+		p.readOptionalArgument()
+	case "array", "alignedat":
+		p.readOptionalArgument()
+		if _, err := p.readRawGroup(); err != nil {
+			return "", err
+		}
+	}
+
+	rows, err := p.parseRows()
+	if err != nil {
+		return "", err
+	}
+
+	if p.peekCommand() != "end" {
+		return "", fmt.Errorf(`latex: missing \end{%s}`, environment)
+	}
+	p.readCommand()
+
+	if name, err := p.readRawGroup(); err != nil || name != environment {
+		return "", fmt.Errorf(`latex: missing \end{%s}`, environment)
+	}
+
+	var content string
+	switch {
+	case len(rows) == 1 && len(rows[0]) == 1:
+		content = rows[0][0]
+	case environment == "cases":
+		content = buildTable(rows, "left left")
+	case strings.HasPrefix(environment, "align") || environment == "split":
+		content = buildTable(rows, "right left")
+	default:
+		content = buildTable(rows, "")
+	}
+
+	if delimiters[0] == "" && delimiters[1] == "" {
+		return content, nil
+	}
+
+	return "<mrow>" + fence(delimiters[0]) + content + fence(delimiters[1]) + "</mrow>", nil
+}
+
+// applyVariant replaces the letters and digits by the mathematical alphanumeric symbols of the current font.
+func (p *parser) applyVariant(text string) string {
+	variant, found := mathVariants[p.variant]
+	if !found {
+		return html.EscapeString(text)
+	}
+
+	var buffer strings.Builder
+	for _, r := range text {
+		switch {
+		case variant.exceptions[r] != 0:
+			buffer.WriteRune(variant.exceptions[r])
+		case r >= 'A' && r <= 'Z':
+			buffer.WriteRune(variant.capital + r - 'A')
+		case r >= 'a' && r <= 'z':
+			buffer.WriteRune(variant.small + r - 'a')
+		case r >= '0' && r <= '9' && variant.digit != 0:
+			buffer.WriteRune(variant.digit + r - '0')
+		default:
+			buffer.WriteString(html.EscapeString(string(r)))
+		}
+	}
+
+	return buffer.String()
+}
+
+func (p *parser) identifier(name string) string {
+	if p.variant == "normal" && len([]rune(name)) == 1 {
+		return `<mi mathvariant="normal">` + html.EscapeString(name) + "</mi>"
+	}
+	return "<mi>" + p.applyVariant(name) + "</mi>"
+}
+
+func operator(symbol string) string {
+	return "<mo>" + html.EscapeString(symbol) + "</mo>"
+}
+
+func fence(delimiter string) string {
+	if delimiter == "" {
+		return ""
+	}
+	return `<mo fence="true" stretchy="true">` + html.EscapeString(delimiter) + "</mo>"
+}
+
+func row(nodes []string) string {
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+	return "<mrow>" + strings.Join(nodes, "") + "</mrow>"
+}
+
+func buildTable(rows [][]string, columnAlign string) string {
+	var buffer strings.Builder
+	if columnAlign != "" {
+		buffer.WriteString(`<mtable columnalign="` + columnAlign + `">`)
+	} else {
+		buffer.WriteString("<mtable>")
+	}
+
+	for _, cells := range rows {
+		buffer.WriteString("<mtr>")
+		for _, cell := range cells {
+			buffer.WriteString("<mtd>" + cell + "</mtd>")
+		}
+		buffer.WriteString("</mtr>")
+	}
+
+	buffer.WriteString("</mtable>")
+	return buffer.String()
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package latex // import "miniflux.app/v2/internal/reader/latex"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToMathML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		display  bool
+		expected string
+	}{
+		{
+			name:     "superscript",
+			input:    `x^2`,
+			expected: `<mrow><msup><mi>x</mi><mn>2</mn></msup></mrow>`,
+		},
+		{
+			name:     "only the first digit is a script",
+			input:    `x^23`,
+			expected: `<mrow><msup><mi>x</mi><mn>2</mn></msup><mn>3</mn></mrow>`,
+		},
+		{
+			name:     "fraction",
+			input:    `\frac{a}{b}`,
+			expected: `<mrow><mfrac><mi>a</mi><mi>b</mi></mfrac></mrow>`,
+		},
+		{
+			name:     "limits of a sum in display mode",
+			input:    `\sum_{i=1}^n i`,
+			display:  true,
+			expected: `<mrow><munderover><mo>∑</mo><mrow><mi>i</mi><mo>=</mo><mn>1</mn></mrow><mi>n</mi></munderover><mi>i</mi></mrow>`,
+		},
+		{
+			name:     "limits of a sum in inline mode",
+			input:    `\sum_{i=1}^n i`,
+			expected: `<mrow><msubsup><mo>∑</mo><mrow><mi>i</mi><mo>=</mo><mn>1</mn></mrow><mi>n</mi></msubsup><mi>i</mi></mrow>`,
+		},
+		{
+			name:     "root with index",
+			input:    `\sqrt[3]{x}`,
+			expected: `<mrow><mroot><mi>x</mi><mn>3</mn></mroot></mrow>`,
+		},
+		{
+			name:     "double-struck letter",
+			input:    `\mathbb{R}`,
+			expected: `<mrow><mi>ℝ</mi></mrow>`,
+		},
+		{
+			name:     "bold letters",
+			input:    `\mathbf{ab}`,
+			expected: `<mrow><mi>𝐚</mi><mi>𝐛</mi></mrow>`,
+		},
+		{
+			name:     "prime",
+			input:    `f'`,
+			expected: `<mrow><msup><mi>f</mi><mo>′</mo></msup></mrow>`,
+		},
+		{
+			name:     "stretchy delimiters",
+			input:    `\left( x \right.`,
+			expected: `<mrow><mo fence="true" stretchy="true">(</mo><mi>x</mi></mrow>`,
+		},
+		{
+			name:     "matrix",
+			input:    `\begin{bmatrix} a & b \\ c & d \end{bmatrix}`,
+			expected: `<mrow><mo fence="true" stretchy="true">[</mo><mtable><mtr><mtd><mi>a</mi></mtd><mtd><mi>b</mi></mtd></mtr><mtr><mtd><mi>c</mi></mtd><mtd><mi>d</mi></mtd></mtr></mtable><mo fence="true" stretchy="true">]</mo></mrow>`,
+		},
+		{
+			name:     "text is escaped",
+			input:    `\text{a<b}`,
+			expected: `<mrow><mtext>a&lt;b</mtext></mrow>`,
+		},
+		{
+			name:     "negated operator",
+			input:    `\not\in`,
+			expected: `<mrow><mo>∉</mo></mrow>`,
+		},
+		{
+			name:     "function name",
+			input:    `\sin x`,
+			expected: `<mrow><mi>sin</mi><mi>x</mi></mrow>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := ToMathML(test.input, test.display)
+			if err != nil {
+				t.Fatalf(`Unexpected error: %v`, err)
+			}
+
+			if !strings.Contains(output, "<semantics>"+test.expected+"<annotation") {
+				t.Errorf(`Unexpected output for %q: %s`, test.input, output)
+			}
+		})
+	}
+}
+
+func TestToMathMLWrapsTheFormula(t *testing.T) {
+	output, err := ToMathML(`a < b`, true)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	expected := `<math display="block"><semantics><mrow><mi>a</mi><mo>&lt;</mo><mi>b</mi></mrow><annotation encoding="application/x-tex">a &lt; b</annotation></semantics></math>`
+	if output != expected {
+		t.Errorf(`Unexpected output: %s`, output)
+	}
+}
+
+func TestToMathMLWithInvalidFormula(t *testing.T) {
+	formulas := []string{
+		`\unknowncommand`,
+		`x^{2`,
+		`x}`,
+		`x^2^3`,
+		`\left( x`,
+		`\begin{pmatrix} a \end{bmatrix}`,
+		`\begin{tikzpicture}\end{tikzpicture}`,
+		`\frac{a}`,
+		`\`,
+		`50%`,
+		strings.Repeat("{", 100) + strings.Repeat("}", 100),
+		strings.Repeat("x", maxFormulaLength+1),
+	}
+
+	for _, formula := range formulas {
+		if _, err := ToMathML(formula, false); err == nil {
+			t.Errorf(`The formula %q should not be converted`, formula)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package latex // import "miniflux.app/v2/internal/reader/latex"
+
+// identifiers are rendered as <mi> elements.
+var identifiers = map[string]string{
+	"alpha":      "α",
+	"beta":       "β",
+	"gamma":      "γ",
+	"delta":      "δ",
+	"epsilon":    "ϵ",
+	"varepsilon": "ε",
+	"zeta":       "ζ",
+	"eta":        "η",
+	"theta":      "θ",
+	"vartheta":   "ϑ",
+	"iota":       "ι",
+	"kappa":      "κ",
+	"lambda":     "λ",
+	"mu":         "μ",
+	"nu":         "ν",
+	"xi":         "ξ",
+	"omicron":    "ο",
+	"pi":         "π",
+	"varpi":      "ϖ",
+	"rho":        "ρ",
+	"varrho":     "ϱ",
+	"sigma":      "σ",
+	"varsigma":   "ς",
+	"tau":        "τ",
+	"upsilon":    "υ",
+	"phi":        "ϕ",
+	"varphi":     "φ",
+	"chi":        "χ",
+	"psi":        "ψ",
+	"omega":      "ω",
+	"infty":      "∞",
+	"ell":        "ℓ",
+	"hbar":       "ℏ",
+	"imath":      "ı",
+	"jmath":      "ȷ",
+	"aleph":      "ℵ",
+	"partial":    "∂",
+	"nabla":      "∇",
+	"emptyset":   "∅",
+	"varnothing": "∅",
+	"Re":         "ℜ",
+	"Im":         "ℑ",
+	"wp":         "℘",
+}
+
+// uprightIdentifiers are the capital Greek letters, they are not rendered in italic.
+var uprightIdentifiers = map[string]string{
+	"Gamma":   "Γ",
+	"Delta":   "Δ",
+	"Theta":   "Θ",
+	"Lambda":  "Λ",
+	"Xi":      "Ξ",
+	"Pi":      "Π",
+	"Sigma":   "Σ",
+	"Upsilon": "Υ",
+	"Phi":     "Φ",
+	"Psi":     "Ψ",
+	"Omega":   "Ω",
+}
+
+// operators are rendered as <mo> elements.
+var operators = map[string]string{
+	"pm":             "±",
+	"mp":             "∓",
+	"times":          "×",
+	"div":            "÷",
+	"cdot":           "⋅",
+	"ast":            "∗",
+	"star":           "⋆",
+	"circ":           "∘",
+	"bullet":         "∙",
+	"oplus":          "⊕",
+	"ominus":         "⊖",
+	"otimes":         "⊗",
+	"odot":           "⊙",
+	"cup":            "∪",
+	"cap":            "∩",
+	"sqcup":          "⊔",
+	"sqcap":          "⊓",
+	"setminus":       "∖",
+	"wedge":          "∧",
+	"land":           "∧",
+	"vee":            "∨",
+	"lor":            "∨",
+	"neg":            "¬",
+	"lnot":           "¬",
+	"dagger":         "†",
+	"leq":            "≤",
+	"le":             "≤",
+	"geq":            "≥",
+	"ge":             "≥",
+	"neq":            "≠",
+	"ne":             "≠",
+	"approx":         "≈",
+	"equiv":          "≡",
+	"sim":            "∼",
+	"simeq":          "≃",
+	"cong":           "≅",
+	"propto":         "∝",
+	"ll":             "≪",
+	"gg":             "≫",
+	"prec":           "≺",
+	"succ":           "≻",
+	"preceq":         "⪯",
+	"succeq":         "⪰",
+	"subset":         "⊂",
+	"subseteq":       "⊆",
+	"supset":         "⊃",
+	"supseteq":       "⊇",
+	"in":             "∈",
+	"notin":          "∉",
+	"ni":             "∋",
+	"perp":           "⊥",
+	"parallel":       "∥",
+	"mid":            "∣",
+	"models":         "⊨",
+	"vdash":          "⊢",
+	"top":            "⊤",
+	"bot":            "⊥",
+	"forall":         "∀",
+	"exists":         "∃",
+	"nexists":        "∄",
+	"to":             "→",
+	"rightarrow":     "→",
+	"leftarrow":      "←",
+	"gets":           "←",
+	"Rightarrow":     "⇒",
+	"Leftarrow":      "⇐",
+	"leftrightarrow": "↔",
+	"Leftrightarrow": "⇔",
+	"longrightarrow": "⟶",
+	"longleftarrow":  "⟵",
+	"implies":        "⟹",
+	"impliedby":      "⟸",
+	"iff":            "⟺",
+	"mapsto":         "↦",
+	"uparrow":        "↑",
+	"downarrow":      "↓",
+	"angle":          "∠",
+	"triangle":       "△",
+	"therefore":      "∴",
+	"because":        "∵",
+	"ldots":          "…",
+	"dots":           "…",
+	"cdots":          "⋯",
+	"vdots":          "⋮",
+	"ddots":          "⋱",
+	"colon":          ":",
+	"coloneqq":       "≔",
+	"prime":          "′",
+	"vert":           "|",
+	"lvert":          "|",
+	"rvert":          "|",
+	"Vert":           "‖",
+	"lVert":          "‖",
+	"rVert":          "‖",
+	"langle":         "⟨",
+	"rangle":         "⟩",
+	"lfloor":         "⌊",
+	"rfloor":         "⌋",
+	"lceil":          "⌈",
+	"rceil":          "⌉",
+	"lbrace":         "{",
+	"rbrace":         "}",
+	"lbrack":         "[",
+	"rbrack":         "]",
+	"backslash":      "\\",
+}
+
+// largeOperators are rendered as <mo> elements, the ones placing their limits above and below in display mode are true.
+var largeOperators = map[string]struct {
+	symbol string
+	limits bool
+}{
+	"sum":       {"∑", true},
+	"prod":      {"∏", true},
+	"coprod":    {"∐", true},
+	"bigcup":    {"⋃", true},
+	"bigcap":    {"⋂", true},
+	"bigoplus":  {"⨁", true},
+	"bigotimes": {"⨂", true},
+	"bigvee":    {"⋁", true},
+	"bigwedge":  {"⋀", true},
+	"int":       {"∫", false},
+	"iint":      {"∬", false},
+	"iiint":     {"∭", false},
+	"oint":      {"∮", false},
+}
+
+// functions are rendered as upright <mi> elements, the ones placing their limits below in display mode are true.
+var functions = map[string]bool{
+	"sin":    false,
+	"cos":    false,
+	"tan":    false,
+	"cot":    false,
+	"sec":    false,
+	"csc":    false,
+	"arcsin": false,
+	"arccos": false,
+	"arctan": false,
+	"sinh":   false,
+	"cosh":   false,
+	"tanh":   false,
+	"coth":   false,
+	"log":    false,
+	"ln":     false,
+	"lg":     false,
+	"exp":    false,
+	"det":    false,
+	"dim":    false,
+	"ker":    false,
+	"deg":    false,
+	"gcd":    false,
+	"hom":    false,
+	"arg":    false,
+	"Pr":     false,
+	"lim":    true,
+	"limsup": true,
+	"liminf": true,
+	"max":    true,
+	"min":    true,
+	"sup":    true,
+	"inf":    true,
+	"argmax": true,
+	"argmin": true,
+}
+
+// accents are placed above their argument, the wide ones stretch to the width of the argument.
+var accents = map[string]string{
+	"hat":       "^",
+	"widehat":   "^",
+	"bar":       "¯",
+	"overline":  "‾",
+	"vec":       "→",
+	"dot":       "˙",
+	"ddot":      "¨",
+	"tilde":     "~",
+	"widetilde": "~",
+	"check":     "ˇ",
+	"breve":     "˘",
+	"acute":     "´",
+	"grave":     "`",
+}
+
+// spaces are the widths of the spacing commands.
+var spaces = map[string]string{
+	",":          "0.1667em",
+	"thinspace":  "0.1667em",
+	":":          "0.2222em",
+	">":          "0.2222em",
+	"medspace":   "0.2222em",
+	";":          "0.2778em",
+	"thickspace": "0.2778em",
+	" ":          "0.25em",
+	"enspace":    "0.5em",
+	"quad":       "1em",
+	"qquad":      "2em",
+}
+
+// delimiterSizes are the heights of the fixed size delimiters.
+var delimiterSizes = map[string]string{
+	"big":   "1.2em",
+	"bigl":  "1.2em",
+	"bigr":  "1.2em",
+	"bigm":  "1.2em",
+	"Big":   "1.8em",
+	"Bigl":  "1.8em",
+	"Bigr":  "1.8em",
+	"Bigm":  "1.8em",
+	"bigg":  "2.4em",
+	"biggl": "2.4em",
+	"biggr": "2.4em",
+	"biggm": "2.4em",
+	"Bigg":  "3em",
+	"Biggl": "3em",
+	"Biggr": "3em",
+	"Biggm": "3em",
+}
+
+// ignoredCommands don't change the rendering of the formula.
+var ignoredCommands = map[string]bool{
+	"displaystyle": true,
+	"textstyle":    true,
+	"scriptstyle":  true,
+	"nonumber":     true,
+	"notag":        true,
+	"limits":       true,
+	"nolimits":     true,
+	"hline":        true,
+	"!":            true,
+	"negthinspace": true,
+}
+
+// matrixDelimiters are the fences of the matrix environments.
+var matrixDelimiters = map[string][2]string{
+	"matrix":      {"", ""},
+	"smallmatrix": {"", ""},
+	"pmatrix":     {"(", ")"},
+	"bmatrix":     {"[", "]"},
+	"Bmatrix":     {"{", "}"},
+	"vmatrix":     {"|", "|"},
+	"Vmatrix":     {"‖", "‖"},
+	"cases":       {"{", ""},
+	"array":       {"", ""},
+	"aligned":     {"", ""},
+	"align":       {"", ""},
+	"align*":      {"", ""},
+	"alignedat":   {"", ""},
+	"gathered":    {"", ""},
+	"gather":      {"", ""},
+	"gather*":     {"", ""},
+	"split":       {"", ""},
+	"equation":    {"", ""},
+	"equation*":   {"", ""},
+}
+
+// mathVariants are the offsets of the capital letters, small letters and digits of the mathematical alphanumeric symbols.
+var mathVariants = map[string]struct {
+	capital, small, digit rune
+	exceptions            map[rune]rune
+}{
+	"bold": {0x1D400, 0x1D41A, 0x1D7CE, nil},
+	"script": {0x1D49C, 0x1D4B6, 0, map[rune]rune{
+		'B': 'ℬ', 'E': 'ℰ', 'F': 'ℱ', 'H': 'ℋ', 'I': 'ℐ', 'L': 'ℒ', 'M': 'ℳ', 'R': 'ℛ', 'e': 'ℯ', 'g': 'ℊ', 'o': 'ℴ',
+	}},
+	"fraktur": {0x1D504, 0x1D51E, 0, map[rune]rune{
+		'C': 'ℭ', 'H': 'ℌ', 'I': 'ℑ', 'R': 'ℜ', 'Z': 'ℨ',
+	}},
+	"double-struck": {0x1D538, 0x1D552, 0x1D7D8, map[rune]rune{
+		'C': 'ℂ', 'H': 'ℍ', 'N': 'ℕ', 'P': 'ℙ', 'Q': 'ℚ', 'R': 'ℝ', 'Z': 'ℤ',
+	}},
+	"sans-serif": {0x1D5A0, 0x1D5BA, 0x1D7E2, nil},
+	"monospace":  {0x1D670, 0x1D68A, 0x1D7F6, nil},
+}
+
+// fontCommands select the variant of the letters and digits of their argument.
+var fontCommands = map[string]string{
+	"mathbf":       "bold",
+	"boldsymbol":   "bold",
+	"bm":           "bold",
+	"mathcal":      "script",
+	"mathscr":      "script",
+	"mathfrak":     "fraktur",
+	"mathbb":       "double-struck",
+	"mathsf":       "sans-serif",
+	"mathtt":       "monospace",
+	"mathrm":       "normal",
+	"mathit":       "",
+	"mathop":       "",
+	"mathrel":      "",
+	"mathbin":      "",
+	"mathord":      "",
+	"operatorname": "normal",
+}
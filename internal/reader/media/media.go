@@ -31,6 +31,23 @@ func (e *MediaItemElement) AllMediaThumbnails() []Thumbnail {
 	return items
 }
 
+// FirstThumbnailURL returns the URL of the first thumbnail, or of the first image content when the item has no thumbnail.
+func (e *MediaItemElement) FirstThumbnailURL() string {
+	for _, thumbnail := range e.AllMediaThumbnails() {
+		if thumbnailURL := strings.TrimSpace(thumbnail.URL); thumbnailURL != "" {
+			return thumbnailURL
+		}
+	}
+
+	for _, content := range e.AllMediaContents() {
+		if contentURL := strings.TrimSpace(content.URL); contentURL != "" && content.IsImage() {
+			return contentURL
+		}
+	}
+
+	return ""
+}
+
 // AllMediaContents returns all content elements merged together.
 func (e *MediaItemElement) AllMediaContents() []Content {
 	var items []Content
@@ -102,6 +119,11 @@ func (mc *Content) MimeType() string {
 	}
 }
 
+// IsImage returns true if the content is an image.
+func (mc *Content) IsImage() bool {
+	return mc.Medium == "image" || strings.HasPrefix(mc.Type, "image/")
+}
+
 // Size returns the attachment size.
 func (mc *Content) Size() int64 {
 	size, _ := strconv.ParseInt(mc.FileSize, 10, 0)
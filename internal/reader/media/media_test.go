@@ -30,6 +30,26 @@ func TestContentMimeType(t *testing.T) {
 	}
 }
 
+func TestFirstThumbnailURL(t *testing.T) {
+	scenarios := []struct {
+		element  MediaItemElement
+		expected string
+	}{
+		{MediaItemElement{}, ""},
+		{MediaItemElement{MediaThumbnails: []Thumbnail{{URL: " "}, {URL: " https://example.org/thumbnail.jpg "}}}, "https://example.org/thumbnail.jpg"},
+		{MediaItemElement{MediaGroups: []Group{{MediaThumbnails: []Thumbnail{{URL: "https://example.org/group.jpg"}}}}}, "https://example.org/group.jpg"},
+		{MediaItemElement{MediaContents: []Content{{URL: "https://example.org/video.mp4", Type: "video/mp4"}, {URL: "https://example.org/image.png", Type: "image/png"}}}, "https://example.org/image.png"},
+		{MediaItemElement{MediaContents: []Content{{URL: "https://example.org/image", Medium: "image"}}}, "https://example.org/image"},
+		{MediaItemElement{MediaContents: []Content{{URL: "https://example.org/audio.mp3", Medium: "audio"}}}, ""},
+	}
+
+	for _, scenario := range scenarios {
+		if result := scenario.element.FirstThumbnailURL(); result != scenario.expected {
+			t.Errorf(`Unexpected thumbnail URL, got %q instead of %q`, result, scenario.expected)
+		}
+	}
+}
+
 func TestContentSize(t *testing.T) {
 	scenarios := []struct {
 		inputSize    string
@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mf2 reads the entries of HTML pages marked up with microformats2 (h-feed and h-entry).
+// See https://microformats.org/wiki/h-feed and https://microformats.org/wiki/h-entry.
+package mf2 // import "miniflux.app/v2/internal/reader/mf2"
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// HasEntries returns true if the HTML document contains an h-feed or some h-entry.
+func HasEntries(data io.Reader) bool {
+	doc, err := goquery.NewDocumentFromReader(data)
+	if err != nil {
+		return false
+	}
+	return doc.Find(".h-feed, .h-entry").Length() > 0
+}
+
+// Parse returns a normalized feed struct from an HTML page marked up with microformats2.
+func Parse(baseURL string, data io.Reader) (*model.Feed, error) {
+	htmlDocumentReader, err := charset.NewReader(data, "text/html")
+	if err != nil {
+		return nil, fmt.Errorf("mf2: unable to read document: %w", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(htmlDocumentReader)
+	if err != nil {
+		return nil, fmt.Errorf("mf2: unable to parse document: %w", err)
+	}
+
+	if hrefValue, exists := doc.Find("head base").First().Attr("href"); exists {
+		if absoluteURL, err := urllib.AbsoluteURL(baseURL, strings.TrimSpace(hrefValue)); err == nil {
+			baseURL = absoluteURL
+		}
+	}
+
+	feed := &model.Feed{
+		FeedURL: baseURL,
+		SiteURL: baseURL,
+	}
+
+	// The entries are the children of the first h-feed, or the top-level h-entry of the page when there is no h-feed.
+	var root *goquery.Selection
+	var items *goquery.Selection
+	if hfeed := doc.Find(".h-feed").First(); hfeed.Length() > 0 {
+		root = hfeed
+		items = properties(hfeed, "h-entry")
+	} else {
+		items = properties(doc.Selection, "h-entry")
+	}
+
+	if root != nil {
+		feed.Title = propertyText(root, "p-name")
+		if photoURL := propertyURL(root, "u-photo", baseURL); photoURL != "" {
+			feed.IconURL = photoURL
+		}
+	}
+
+	// Fallback to the page title, then to the site URL.
+	if feed.Title == "" {
+		feed.Title = strings.TrimSpace(doc.Find("head title").First().Text())
+	}
+	if feed.Title == "" {
+		feed.Title = feed.SiteURL
+	}
+
+	var feedAuthor string
+	if root != nil {
+		feedAuthor = authorName(root)
+	}
+
+	items.Each(func(_ int, item *goquery.Selection) {
+		entry := model.NewEntry()
+		entry.URL = propertyURL(item, "u-url", baseURL)
+		if entry.URL == "" {
+			entry.URL = baseURL
+		}
+
+		// The entry name is optional: notes usually only have some content.
+		summary := propertyText(item, "p-summary")
+		contentHTML := propertyHTML(item, "e-content")
+		entry.Title = propertyText(item, "p-name")
+		if entry.Title == "" {
+			for _, value := range []string{summary, propertyText(item, "e-content")} {
+				if value != "" {
+					entry.Title = sanitizer.TruncateHTML(value, 100)
+					break
+				}
+			}
+		}
+
+		// Fallback to the entry URL if the title is empty.
+		if entry.Title == "" {
+			entry.Title = entry.URL
+		}
+
+		// Populate the entry content.
+		for _, value := range []string{contentHTML, summary} {
+			if value != "" {
+				entry.Content = value
+				break
+			}
+		}
+
+		// Populate the entry date.
+		for _, class := range []string{"dt-published", "dt-updated"} {
+			value := propertyDate(item, class)
+			if value == "" {
+				continue
+			}
+
+			if entryDate, err := date.Parse(value); err != nil {
+				slog.Debug("Unable to parse date from h-entry",
+					slog.String("date", value),
+					slog.String("url", entry.URL),
+					slog.Any("error", err),
+				)
+			} else {
+				entry.Date = entryDate
+				break
+			}
+		}
+		if entry.Date.IsZero() {
+			entry.Date = time.Now()
+		}
+
+		// Populate the entry author.
+		entry.Author = authorName(item)
+		if entry.Author == "" {
+			entry.Author = feedAuthor
+		}
+
+		// Populate the entry tags.
+		properties(item, "p-category").Each(func(_ int, s *goquery.Selection) {
+			if tag := textValue(s); tag != "" {
+				entry.Tags = append(entry.Tags, tag)
+			}
+		})
+		slices.Sort(entry.Tags)
+		entry.Tags = slices.Compact(entry.Tags)
+
+		// Generate a hash for the entry.
+		for _, value := range []string{propertyURL(item, "u-uid", baseURL), propertyURL(item, "u-url", baseURL), contentHTML + summary + entry.Title} {
+			if value != "" {
+				entry.Hash = crypto.Hash(value)
+				break
+			}
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	})
+
+	return feed, nil
+}
+
+// authorName returns the name of the p-author property, which is either plain text or an h-card.
+func authorName(item *goquery.Selection) string {
+	author := properties(item, "p-author").First()
+	if author.Length() == 0 {
+		return ""
+	}
+
+	if author.HasClass("h-card") {
+		if name := propertyText(author, "p-name"); name != "" {
+			return name
+		}
+	}
+
+	return textValue(author)
+}
+
+// isRoot returns true if the element is the root of a microformat, its class names start with "h-".
+func isRoot(s *goquery.Selection) bool {
+	for _, class := range strings.Fields(s.AttrOr("class", "")) {
+		if strings.HasPrefix(class, "h-") {
+			return true
+		}
+	}
+	return false
+}
+
+// belongsTo returns true if the closest microformat ancestor of the element is the given root.
+// The properties of the nested microformats, for example a quoted h-cite, must be ignored.
+func belongsTo(s, root *goquery.Selection) bool {
+	for parent := s.Parent(); parent.Length() > 0; parent = parent.Parent() {
+		if parent.Nodes[0] == root.Nodes[0] {
+			return true
+		}
+		if isRoot(parent) {
+			return false
+		}
+	}
+
+	// The document itself is the root of the top-level microformats.
+	return root.Nodes[0].Type == html.DocumentNode
+}
+
+// properties returns the elements with the given class name that belong to the root.
+func properties(root *goquery.Selection, class string) *goquery.Selection {
+	return root.Find("." + class).FilterFunction(func(_ int, s *goquery.Selection) bool {
+		return belongsTo(s, root)
+	})
+}
+
+func propertyText(root *goquery.Selection, class string) string {
+	return textValue(properties(root, class).First())
+}
+
+func propertyHTML(root *goquery.Selection, class string) string {
+	property := properties(root, class).First()
+	if property.Length() == 0 {
+		return ""
+	}
+
+	content, _ := property.Html()
+	return strings.TrimSpace(content)
+}
+
+func propertyURL(root *goquery.Selection, class, baseURL string) string {
+	property := properties(root, class).First()
+	if property.Length() == 0 {
+		return ""
+	}
+
+	var value string
+	switch goquery.NodeName(property) {
+	case "a", "area", "link":
+		value = property.AttrOr("href", "")
+	case "img", "audio", "video", "source", "iframe":
+		value = property.AttrOr("src", "")
+	case "object":
+		value = property.AttrOr("data", "")
+	default:
+		value = textValue(property)
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+
+	absoluteURL, err := urllib.AbsoluteURL(baseURL, value)
+	if err != nil {
+		return ""
+	}
+	return absoluteURL
+}
+
+func propertyDate(root *goquery.Selection, class string) string {
+	property := properties(root, class).First()
+	if property.Length() == 0 {
+		return ""
+	}
+
+	switch goquery.NodeName(property) {
+	case "time", "ins", "del":
+		if value, exists := property.Attr("datetime"); exists {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	return textValue(property)
+}
+
+// textValue returns the plain text value of a property, following the microformats2 parsing rules for the elements carrying it in an attribute.
+func textValue(s *goquery.Selection) string {
+	if s.Length() == 0 {
+		return ""
+	}
+
+	var value string
+	switch goquery.NodeName(s) {
+	case "abbr", "link":
+		value = s.AttrOr("title", "")
+	case "data", "input":
+		value = s.AttrOr("value", "")
+	case "img", "area":
+		value = s.AttrOr("alt", "")
+	}
+
+	if value == "" {
+		value = s.Text()
+	}
+
+	return strings.Join(strings.Fields(value), " ")
+}
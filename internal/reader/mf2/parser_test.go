@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mf2 // import "miniflux.app/v2/internal/reader/mf2"
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHFeed(t *testing.T) {
+	data := `<!DOCTYPE html>
+	<html>
+	<head><title>Page Title</title></head>
+	<body>
+		<div class="h-feed">
+			<h1 class="p-name">My Blog</h1>
+			<a class="p-author h-card" href="/about"><span class="p-name">Jane Doe</span></a>
+			<article class="h-entry">
+				<h2 class="p-name">First Post</h2>
+				<a class="u-url" href="/2024/first-post">Permalink</a>
+				<time class="dt-published" datetime="2024-01-02T10:00:00Z">January 2</time>
+				<a class="p-category" href="/tags/go">go</a>
+				<a class="p-category" href="/tags/indieweb">indieweb</a>
+				<div class="e-content"><p>Hello <b>world</b></p></div>
+			</article>
+			<article class="h-entry">
+				<a class="u-url" href="https://example.org/2024/note"><time class="dt-published" datetime="2024-01-03 08:30:00">January 3</time></a>
+				<p class="p-author h-card"><span class="p-name">John Doe</span></p>
+				<div class="e-content">Just a short note.</div>
+				<blockquote class="h-cite"><span class="p-name">Quoted</span><a class="u-url" href="/quoted">quoted</a></blockquote>
+			</article>
+		</div>
+	</body>
+	</html>`
+
+	feed, err := Parse("https://example.org/", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "My Blog" {
+		t.Errorf(`Incorrect title, got: %q`, feed.Title)
+	}
+
+	if feed.FeedURL != "https://example.org/" || feed.SiteURL != "https://example.org/" {
+		t.Errorf(`Incorrect URLs, got: %q and %q`, feed.FeedURL, feed.SiteURL)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "First Post" {
+		t.Errorf(`Incorrect entry title, got: %q`, entry.Title)
+	}
+
+	if entry.URL != "https://example.org/2024/first-post" {
+		t.Errorf(`Incorrect entry URL, got: %q`, entry.URL)
+	}
+
+	if !entry.Date.Equal(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Incorrect entry date, got: %v`, entry.Date)
+	}
+
+	if entry.Content != "<p>Hello <b>world</b></p>" {
+		t.Errorf(`Incorrect entry content, got: %q`, entry.Content)
+	}
+
+	if entry.Author != "Jane Doe" {
+		t.Errorf(`The entry author should be the feed author, got: %q`, entry.Author)
+	}
+
+	if len(entry.Tags) != 2 || entry.Tags[0] != "go" || entry.Tags[1] != "indieweb" {
+		t.Errorf(`Incorrect entry tags, got: %v`, entry.Tags)
+	}
+
+	entry = feed.Entries[1]
+	if entry.Title != "Just a short note." {
+		t.Errorf(`The entry title should fallback to the content, got: %q`, entry.Title)
+	}
+
+	if entry.URL != "https://example.org/2024/note" {
+		t.Errorf(`The properties of the nested microformats should be ignored, got: %q`, entry.URL)
+	}
+
+	if entry.Author != "John Doe" {
+		t.Errorf(`Incorrect entry author, got: %q`, entry.Author)
+	}
+
+	if entry.Date.Year() != 2024 || entry.Date.Day() != 3 {
+		t.Errorf(`Incorrect entry date, got: %v`, entry.Date)
+	}
+
+	if feed.Entries[0].Hash == feed.Entries[1].Hash {
+		t.Error(`The entries should have different hashes`)
+	}
+}
+
+func TestParseTopLevelHEntries(t *testing.T) {
+	data := `<html>
+	<head><title>Notes</title><base href="https://example.org/notes/"></head>
+	<body>
+		<div class="h-entry">
+			<a class="u-url u-uid" href="1">1</a>
+			<div class="p-summary">First note</div>
+		</div>
+		<div class="h-entry">
+			<a class="u-url" href="2">2</a>
+			<p class="p-name">Second note</p>
+		</div>
+	</body>
+	</html>`
+
+	feed, err := Parse("https://example.org/notes", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Notes" {
+		t.Errorf(`The feed title should fallback to the page title, got: %q`, feed.Title)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	if feed.Entries[0].URL != "https://example.org/notes/1" {
+		t.Errorf(`The entry URL should use the base URL of the page, got: %q`, feed.Entries[0].URL)
+	}
+
+	if feed.Entries[0].Title != "First note" || feed.Entries[0].Content != "First note" {
+		t.Errorf(`The entry title and content should fallback to the summary, got: %q and %q`, feed.Entries[0].Title, feed.Entries[0].Content)
+	}
+
+	if feed.Entries[1].Title != "Second note" {
+		t.Errorf(`Incorrect entry title, got: %q`, feed.Entries[1].Title)
+	}
+}
+
+func TestParsePageWithoutEntries(t *testing.T) {
+	feed, err := Parse("https://example.org/", strings.NewReader(`<html><body><p>Hello</p></body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "https://example.org/" {
+		t.Errorf(`The feed title should fallback to the site URL, got: %q`, feed.Title)
+	}
+
+	if len(feed.Entries) != 0 {
+		t.Errorf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+}
+
+func TestHasEntries(t *testing.T) {
+	scenarios := map[string]bool{
+		`<html><body><div class="h-feed"></div></body></html>`:               true,
+		`<html><body><article class="post h-entry"></article></body></html>`: true,
+		`<html><body><div class="h-card"></div></body></html>`:               false,
+		`<html><body><div class="h-entry-like"></div></body></html>`:         false,
+	}
+
+	for data, expected := range scenarios {
+		if result := HasEntries(strings.NewReader(data)); result != expected {
+			t.Errorf(`Unexpected result for %q, got %v`, data, result)
+		}
+	}
+}
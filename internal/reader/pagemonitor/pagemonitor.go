@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pagemonitor turns a fragment of a web page into a feed, for the websites without any feed.
+// The entry hash is based on the text of the fragment: a new entry is created each time the text changes.
+package pagemonitor // import "miniflux.app/v2/internal/reader/pagemonitor"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html/charset"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// ErrFragmentNotFound is returned when no element of the page matches the selector.
+var ErrFragmentNotFound = errors.New("pagemonitor: no element matches the selector")
+
+// Parse returns a feed with a single entry containing the elements of the page matching the CSS selector.
+func Parse(pageURL string, data io.Reader, selector string) (*model.Feed, error) {
+	htmlDocumentReader, err := charset.NewReader(data, "text/html")
+	if err != nil {
+		return nil, fmt.Errorf("pagemonitor: unable to read document: %w", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(htmlDocumentReader)
+	if err != nil {
+		return nil, fmt.Errorf("pagemonitor: unable to parse document: %w", err)
+	}
+
+	fragment := doc.Find(selector)
+	if fragment.Length() == 0 {
+		return nil, ErrFragmentNotFound
+	}
+
+	var contents, texts []string
+	fragment.Each(func(_ int, s *goquery.Selection) {
+		if content, err := goquery.OuterHtml(s); err == nil {
+			contents = append(contents, content)
+		}
+
+		// The whitespaces are not significant, the markup is ignored to avoid the changes of attributes like CSRF tokens.
+		if text := strings.Join(strings.Fields(s.Text()), " "); text != "" {
+			texts = append(texts, text)
+		}
+	})
+
+	feed := &model.Feed{
+		FeedURL: pageURL,
+		SiteURL: pageURL,
+		Title:   strings.Join(strings.Fields(doc.Find("head title").First().Text()), " "),
+	}
+
+	if hrefValue, exists := doc.Find("head base").First().Attr("href"); exists {
+		if absoluteURL, err := urllib.AbsoluteURL(pageURL, strings.TrimSpace(hrefValue)); err == nil {
+			feed.SiteURL = absoluteURL
+		}
+	}
+
+	if feed.Title == "" {
+		feed.Title = pageURL
+	}
+
+	entry := model.NewEntry()
+	entry.URL = pageURL
+	entry.Title = feed.Title
+	entry.Date = time.Now()
+	entry.Content = strings.Join(contents, "\n")
+
+	// Images and embedded media have no text, the markup is used instead.
+	hashValue := strings.Join(texts, "\n")
+	if hashValue == "" {
+		hashValue = entry.Content
+	}
+	entry.Hash = crypto.Hash(pageURL + "\n" + hashValue)
+
+	feed.Entries = append(feed.Entries, entry)
+	return feed, nil
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pagemonitor // import "miniflux.app/v2/internal/reader/pagemonitor"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const page = `<!DOCTYPE html>
+<html>
+<head><title> Product page </title></head>
+<body>
+	<div class="price" data-csrf="%s">
+		<span>Price:</span> <strong>%s</strong>
+	</div>
+	<div class="price">Shipping: free</div>
+</body>
+</html>`
+
+func parsePage(t *testing.T, token, price string) string {
+	feed, err := Parse("https://example.org/product", strings.NewReader(fmt.Sprintf(page, token, price)), "div.price")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Product page" {
+		t.Errorf(`Incorrect feed title, got %q`, feed.Title)
+	}
+
+	if feed.SiteURL != "https://example.org/product" {
+		t.Errorf(`Incorrect site URL, got %q`, feed.SiteURL)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf(`Incorrect number of entries, got %d`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.URL != "https://example.org/product" || entry.Title != "Product page" {
+		t.Errorf(`Incorrect entry, got %q %q`, entry.URL, entry.Title)
+	}
+
+	if !strings.Contains(entry.Content, "<strong>"+price+"</strong>") || !strings.Contains(entry.Content, "Shipping: free") {
+		t.Errorf(`Incorrect entry content, got %q`, entry.Content)
+	}
+
+	return entry.Hash
+}
+
+func TestParse(t *testing.T) {
+	hash := parsePage(t, "token1", "10 EUR")
+
+	if parsePage(t, "token2", "10 EUR") != hash {
+		t.Error(`The hash should not change when only the markup changes`)
+	}
+
+	if parsePage(t, "token1", "12 EUR") == hash {
+		t.Error(`The hash should change when the text changes`)
+	}
+}
+
+func TestParseWithoutMatchingElement(t *testing.T) {
+	_, err := Parse("https://example.org/", strings.NewReader(`<html><body><p>Text</p></body></html>`), "div.missing")
+	if !errors.Is(err, ErrFragmentNotFound) {
+		t.Errorf(`Parsing a page without matching element should fail, got %v`, err)
+	}
+}
+
+func TestParseWithoutTitle(t *testing.T) {
+	feed, err := Parse("https://example.org/", strings.NewReader(`<html><body><img src="/image.png"></body></html>`), "img")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "https://example.org/" || feed.Entries[0].Title != "https://example.org/" {
+		t.Errorf(`The page URL should be used as title, got %q`, feed.Title)
+	}
+
+	if feed.Entries[0].Hash == "" {
+		t.Error(`The markup should be used to generate the hash when the fragment has no text`)
+	}
+}
@@ -5,19 +5,27 @@ package parser // import "miniflux.app/v2/internal/reader/parser"
 
 import (
 	"bytes"
+	"encoding/json"
 	"encoding/xml"
 	"io"
+	"slices"
+	"strings"
 
+	"miniflux.app/v2/internal/reader/activitypub"
+	"miniflux.app/v2/internal/reader/mf2"
 	rxml "miniflux.app/v2/internal/reader/xml"
 )
 
 // List of feed formats.
 const (
-	FormatRDF     = "rdf"
-	FormatRSS     = "rss"
-	FormatAtom    = "atom"
-	FormatJSON    = "json"
-	FormatUnknown = "unknown"
+	FormatRDF         = "rdf"
+	FormatRSS         = "rss"
+	FormatAtom        = "atom"
+	FormatJSON        = "json"
+	FormatMF2         = "h-feed"
+	FormatActivityPub = "activitypub"
+	FormatSitemap     = "sitemap"
+	FormatUnknown     = "unknown"
 )
 
 // DetectFeedFormat tries to guess the feed format from input data.
@@ -26,6 +34,10 @@ func DetectFeedFormat(r io.ReadSeeker) (string, string) {
 	r.Read(data)
 
 	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		r.Seek(0, io.SeekStart)
+		if isActivityStreamsDocument(r) {
+			return FormatActivityPub, ""
+		}
 		return FormatJSON, ""
 	}
 
@@ -51,9 +63,73 @@ func DetectFeedFormat(r io.ReadSeeker) (string, string) {
 				return FormatAtom, "1.0"
 			case "RDF":
 				return FormatRDF, ""
+			case "urlset":
+				return FormatSitemap, ""
 			}
 		}
 	}
 
+	// HTML pages marked up with microformats2 are checked last, since parsing the whole document is slower.
+	r.Seek(0, io.SeekStart)
+	if mf2.HasEntries(r) {
+		return FormatMF2, ""
+	}
+
 	return FormatUnknown, ""
 }
+
+// activityStreamsTypes are the types of the top-level ActivityStreams objects that can be read as a feed.
+var activityStreamsTypes = []string{
+	"Application",
+	"Collection",
+	"CollectionPage",
+	"Group",
+	"OrderedCollection",
+	"OrderedCollectionPage",
+	"Organization",
+	"Person",
+	"Service",
+}
+
+// isActivityStreamsDocument checks the top-level "@context" and "type" fields of the JSON object.
+// The nested objects are ignored: a JSON Feed can quote an ActivityStreams document in its items.
+func isActivityStreamsDocument(r io.Reader) bool {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return false
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return false
+		}
+
+		switch token {
+		case "@context":
+			var contexts []json.RawMessage
+			if err := json.Unmarshal(value, &contexts); err != nil {
+				contexts = []json.RawMessage{value}
+			}
+
+			for _, context := range contexts {
+				var namespace string
+				if json.Unmarshal(context, &namespace) == nil && strings.TrimSuffix(namespace, "#") == activitypub.Namespace {
+					return true
+				}
+			}
+		case "type":
+			var objectType string
+			if json.Unmarshal(value, &objectType) == nil && slices.Contains(activityStreamsTypes, objectType) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
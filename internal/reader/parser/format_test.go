@@ -67,6 +67,54 @@ func TestDetectJSON(t *testing.T) {
 	}
 }
 
+func TestDetectActivityPub(t *testing.T) {
+	data := `
+	{
+		"@context": ["https://www.w3.org/ns/activitystreams", {"ostatus": "http://ostatus.org#"}],
+		"type": "OrderedCollectionPage",
+		"orderedItems": []
+	}
+	`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatActivityPub {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatActivityPub)
+	}
+}
+
+func TestDetectActivityPubWithoutContext(t *testing.T) {
+	data := `{"id": "https://example.org/users/alice/outbox", "type": "OrderedCollection", "totalItems": 0}`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatActivityPub {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatActivityPub)
+	}
+}
+
+func TestDetectJSONFeedQuotingActivityStreams(t *testing.T) {
+	data := `
+	{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "Notes about https://www.w3.org/ns/activitystreams",
+		"items": [{"id": "1", "content_text": "{\"@context\": \"https://www.w3.org/ns/activitystreams\"}", "type": "Person"}]
+	}
+	`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatJSON {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatJSON)
+	}
+}
+
+func TestDetectSitemap(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://example.org/</loc></url></urlset>`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatSitemap {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatSitemap)
+	}
+}
+
 func TestDetectUnknown(t *testing.T) {
 	data := `
 	<!DOCTYPE html> <html> </html>
@@ -77,3 +125,12 @@ func TestDetectUnknown(t *testing.T) {
 		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatUnknown)
 	}
 }
+
+func TestDetectMicroformats(t *testing.T) {
+	data := `<!DOCTYPE html><html><body><div class="h-feed"><article class="h-entry"></article></div></body></html>`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatMF2 {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatMF2)
+	}
+}
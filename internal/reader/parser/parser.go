@@ -4,14 +4,19 @@
 package parser // import "miniflux.app/v2/internal/reader/parser"
 
 import (
+	"bytes"
 	"errors"
 	"io"
 
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/activitypub"
 	"miniflux.app/v2/internal/reader/atom"
 	"miniflux.app/v2/internal/reader/json"
+	"miniflux.app/v2/internal/reader/mf2"
 	"miniflux.app/v2/internal/reader/rdf"
 	"miniflux.app/v2/internal/reader/rss"
+	"miniflux.app/v2/internal/reader/sitemap"
+	"miniflux.app/v2/internal/reader/xml"
 )
 
 var ErrFeedFormatNotDetected = errors.New("parser: unable to detect feed format")
@@ -20,6 +25,18 @@ var ErrFeedFormatNotDetected = errors.New("parser: unable to detect feed format"
 func ParseFeed(baseURL string, r io.ReadSeeker) (*model.Feed, error) {
 	r.Seek(0, io.SeekStart)
 	format, version := DetectFeedFormat(r)
+
+	// The relative URLs of the XML feeds are resolved against their xml:base attributes first.
+	switch format {
+	case FormatAtom, FormatRSS, FormatRDF:
+		r.Seek(0, io.SeekStart)
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(xml.ResolveXMLBase(baseURL, data))
+	}
+
 	switch format {
 	case FormatAtom:
 		r.Seek(0, io.SeekStart)
@@ -33,7 +50,34 @@ func ParseFeed(baseURL string, r io.ReadSeeker) (*model.Feed, error) {
 	case FormatRDF:
 		r.Seek(0, io.SeekStart)
 		return rdf.Parse(baseURL, r)
+	case FormatMF2:
+		r.Seek(0, io.SeekStart)
+		return mf2.Parse(baseURL, r)
+	case FormatActivityPub:
+		r.Seek(0, io.SeekStart)
+		return activitypub.Parse(baseURL, r)
+	case FormatSitemap:
+		r.Seek(0, io.SeekStart)
+		return sitemap.Parse(baseURL, r)
 	default:
 		return nil, ErrFeedFormatNotDetected
 	}
 }
+
+// ParseRelaxedFeed repairs the slightly invalid XML documents before parsing them.
+// The JSON feeds and the web pages are parsed as usual.
+func ParseRelaxedFeed(baseURL string, r io.ReadSeeker) (*model.Feed, error) {
+	r.Seek(0, io.SeekStart)
+	switch format, _ := DetectFeedFormat(r); format {
+	case FormatJSON, FormatMF2, FormatActivityPub:
+		return ParseFeed(baseURL, r)
+	}
+
+	r.Seek(0, io.SeekStart)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseFeed(baseURL, bytes.NewReader(xml.Recover(data)))
+}
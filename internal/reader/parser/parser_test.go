@@ -403,3 +403,140 @@ func TestParseEmptyFeed(t *testing.T) {
 		t.Error("ParseFeed must returns an error")
 	}
 }
+
+func TestParseRelaxedRSS(t *testing.T) {
+	data := `
+	<?xml version="1.0"?>
+	<rss version="2.0">
+	<channel>
+		<title>Fish & Chips</title>
+		<link>https://example.org/</link>
+		<item>
+			<title>First</title>
+			<link>https://example.org/?id=1&lang=en</link>
+			<description>Line<br>Other line</span></description>
+		</item>
+		<item>
+			<title>Second</title>
+			<link>https://example.org/?id=2&lang=en</link>
+	`
+
+	// The decoding stops at the mismatched tag without relaxed parsing.
+	if feed, err := ParseFeed("https://example.org/", strings.NewReader(data)); err == nil && len(feed.Entries) == 2 {
+		t.Error("The second entry should not be parsed without relaxed parsing")
+	}
+
+	feed, err := ParseRelaxedFeed("https://example.org/", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Fish & Chips" {
+		t.Errorf("Incorrect title, got: %s", feed.Title)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf("Incorrect number of entries, got: %d", len(feed.Entries))
+	}
+
+	if feed.Entries[1].URL != "https://example.org/?id=2&lang=en" {
+		t.Errorf("Incorrect entry URL, got: %s", feed.Entries[1].URL)
+	}
+}
+
+func TestParseRelaxedJSONFeed(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "Fish & Chips",
+		"items": [{"id": "1", "url": "https://example.org/?id=1&lang=en", "content_text": "a < b"}]
+	}`
+
+	feed, err := ParseRelaxedFeed("https://example.org/", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Fish & Chips" {
+		t.Errorf("Incorrect title, got: %s", feed.Title)
+	}
+
+	if len(feed.Entries) != 1 || feed.Entries[0].URL != "https://example.org/?id=1&lang=en" {
+		t.Errorf("The JSON feed should not be modified, got: %v", feed.Entries)
+	}
+}
+
+func TestParseAtomFeedWithXMLBase(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom" xml:base="https://example.org/blog/">
+		<title>Example Feed</title>
+		<link href="./"/>
+		<entry xml:base="2026/">
+			<title>Example Entry</title>
+			<link href="article.html"/>
+			<link rel="enclosure" type="audio/mpeg" href="episode.mp3"/>
+			<id>urn:uuid:60a76c80-d399-11d9-b91C-0003939e0af6</id>
+			<updated>2026-10-01T18:30:02Z</updated>
+			<content type="html" xml:base="images/">&lt;img src="photo.jpg"&gt;</content>
+		</entry>
+	</feed>`
+
+	feed, err := ParseFeed("https://example.org/feed.xml", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.SiteURL != "https://example.org/blog/" {
+		t.Errorf("Incorrect site URL, got: %s", feed.SiteURL)
+	}
+
+	entry := feed.Entries[0]
+	if entry.URL != "https://example.org/blog/2026/article.html" {
+		t.Errorf("Incorrect entry URL, got: %s", entry.URL)
+	}
+
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].URL != "https://example.org/blog/2026/episode.mp3" {
+		t.Errorf("Incorrect enclosures, got: %v", entry.Enclosures)
+	}
+
+	if entry.Content != `<img src="https://example.org/blog/2026/images/photo.jpg">` {
+		t.Errorf("Incorrect entry content, got: %s", entry.Content)
+	}
+}
+
+func TestParseRSSFeedWithXMLBase(t *testing.T) {
+	data := `<?xml version="1.0"?>
+	<rss version="2.0">
+	<channel xml:base="https://example.org/blog/">
+		<title>Example Feed</title>
+		<link>./</link>
+		<item>
+			<title>Example Entry</title>
+			<link>article.html</link>
+			<enclosure url="episode.mp3" length="1" type="audio/mpeg"/>
+			<description>&lt;a href="other.html"&gt;Other&lt;/a&gt;</description>
+		</item>
+	</channel>
+	</rss>`
+
+	feed, err := ParseFeed("https://example.org/rss.xml", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.SiteURL != "https://example.org/blog/" {
+		t.Errorf("Incorrect site URL, got: %s", feed.SiteURL)
+	}
+
+	entry := feed.Entries[0]
+	if entry.URL != "https://example.org/blog/article.html" {
+		t.Errorf("Incorrect entry URL, got: %s", entry.URL)
+	}
+
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].URL != "https://example.org/blog/episode.mp3" {
+		t.Errorf("Incorrect enclosures, got: %v", entry.Enclosures)
+	}
+
+	if entry.Content != `<a href="https://example.org/blog/other.html">Other</a>` {
+		t.Errorf("Incorrect entry content, got: %s", entry.Content)
+	}
+}
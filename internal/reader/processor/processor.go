@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"regexp"
 	"slices"
 	"strconv"
@@ -14,15 +15,22 @@ import (
 	"time"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/footnote"
+	"miniflux.app/v2/internal/reader/highlight"
+	"miniflux.app/v2/internal/reader/latex"
+	"miniflux.app/v2/internal/reader/readability"
 	"miniflux.app/v2/internal/reader/readingtime"
 	"miniflux.app/v2/internal/reader/rewrite"
 	"miniflux.app/v2/internal/reader/sanitizer"
 	"miniflux.app/v2/internal/reader/scraper"
+	"miniflux.app/v2/internal/reader/sitemap"
 	"miniflux.app/v2/internal/reader/urlcleaner"
 	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/translation"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/tdewolff/minify/v2"
@@ -42,6 +50,9 @@ var (
 // ProcessFeedEntries downloads original web page for entries and apply filters.
 func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.User, forceRefresh bool) {
 	var filteredEntries model.Entries
+	rewriteScript := newRewriteScript(feed)
+	translator := newTranslator(feed)
+	translationDeadline := time.Now().Add(maxTranslationDurationPerRefresh)
 
 	// Process older entries first
 	for i := len(feed.Entries) - 1; i >= 0; i-- {
@@ -63,9 +74,27 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 			entry.URL = cleanedURL
 		}
 
+		for _, enclosure := range entry.Enclosures {
+			if cleanedURL, err := urlcleaner.RemoveTrackingParameters(enclosure.URL); err == nil {
+				enclosure.URL = cleanedURL
+			}
+		}
+
 		pageBaseURL := ""
 		rewrittenURL := rewriteEntryURL(feed, entry)
 		entryIsNew := store.IsNewEntry(feed.ID, entry.Hash)
+		if feed.ScrapeEntryMetadata && (entryIsNew || forceRefresh) {
+			if err := sitemap.ScrapeEntryMetadata(newEntryRequestBuilder(feed, entry.URL), entry); err != nil {
+				slog.Warn("Unable to scrape entry metadata",
+					slog.Int64("user_id", user.ID),
+					slog.String("entry_url", entry.URL),
+					slog.Int64("feed_id", feed.ID),
+					slog.String("feed_url", feed.FeedURL),
+					slog.Any("error", err),
+				)
+			}
+		}
+
 		if feed.Crawler && (entryIsNew || forceRefresh) {
 			slog.Debug("Scraping entry",
 				slog.Int64("user_id", user.ID),
@@ -79,35 +108,12 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 				slog.String("rewritten_url", rewrittenURL),
 			)
 
-			startTime := time.Now()
-
-			requestBuilder := fetcher.NewRequestBuilder()
-			requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
-			requestBuilder.WithCookie(feed.Cookie)
-			requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
-			requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
-			requestBuilder.UseProxy(feed.FetchViaProxy)
-			requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
-			requestBuilder.DisableHTTP2(feed.DisableHTTP2)
-
-			scrapedPageBaseURL, extractedContent, scraperErr := scraper.ScrapeWebsite(
-				requestBuilder,
-				rewrittenURL,
-				feed.ScraperRules,
-			)
+			scrapedPageBaseURL, extractedContent, scraperErr := scrapeWebsiteWithCache(store, feed, rewrittenURL, forceRefresh)
 
 			if scrapedPageBaseURL != "" {
 				pageBaseURL = scrapedPageBaseURL
 			}
 
-			if config.Opts.HasMetricsCollector() {
-				status := "success"
-				if scraperErr != nil {
-					status = "error"
-				}
-				metric.ScraperRequestDuration.WithLabelValues(status).Observe(time.Since(startTime).Seconds())
-			}
-
 			if scraperErr != nil {
 				slog.Warn("Unable to scrape entry",
 					slog.Int64("user_id", user.ID),
@@ -118,18 +124,29 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 				)
 			} else if extractedContent != "" {
 				// We replace the entry content only if the scraper doesn't return any error.
+				// The content of the feed is kept to let the user switch back to it.
+				entry.FeedContent = sanitizer.SanitizeWithIframeDomains(rewrittenURL, renderContent(user, entry.Content), user.IframeAllowedDomains)
 				entry.Content = minifyEntryContent(extractedContent)
 			}
 		}
 
 		rewrite.Rewriter(rewrittenURL, entry, feed.RewriteRules)
+		applyRewriteScript(rewriteScript, feed, entry)
 
 		if pageBaseURL == "" {
 			pageBaseURL = rewrittenURL
 		}
 
 		// The sanitizer should always run at the end of the process to make sure unsafe HTML is filtered out.
-		entry.Content = sanitizer.Sanitize(pageBaseURL, entry.Content)
+		entry.Content = sanitizer.SanitizeWithIframeDomains(pageBaseURL, renderContent(user, entry.Content), user.IframeAllowedDomains)
+
+		// The existing entries keep their translation, the service is only called once for each entry.
+		// The other entries are translated on demand once the service fails or the time of the refresh is spent.
+		if entryIsNew && translator != nil {
+			if time.Now().After(translationDeadline) || !applyTranslation(translator, feed, entry, user) {
+				translator = nil
+			}
+		}
 
 		updateEntryReadingTime(store, feed, entry, entryIsNew, user)
 		filteredEntries = append(filteredEntries, entry)
@@ -138,6 +155,201 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 	feed.Entries = filteredEntries
 }
 
+// renderContent makes the lazy-loaded images visible, inlines the footnotes, highlights the code blocks and
+// converts the LaTeX formulas to MathML, according to the settings of the instance and of the user.
+// The result must be sanitized.
+func renderContent(user *model.User, content string) string {
+	content = rewrite.NormalizeLazyImages(content)
+	content = footnote.InlineFootnotes(content)
+
+	if config.Opts.HasSyntaxHighlighting() {
+		content = highlight.HighlightCodeBlocks(content)
+	}
+
+	if user.RenderMath {
+		content = latex.RenderMath(content)
+	}
+
+	return content
+}
+
+// newRewriteScript compiles the rewrite script of the feed, nil is returned when the feed doesn't have any
+// usable script or when the scripts are disabled on this instance.
+func newRewriteScript(feed *model.Feed) *rewrite.Script {
+	if feed.RewriteScript == "" || !config.Opts.HasRewriteScripts() {
+		return nil
+	}
+
+	script, err := rewrite.NewScript(feed.RewriteScript)
+	if err != nil {
+		slog.Warn("Unable to compile the rewrite script",
+			slog.Int64("feed_id", feed.ID),
+			slog.String("feed_url", feed.FeedURL),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+
+	return script
+}
+
+// applyRewriteScript runs the script on the entry, the entry is left unchanged if the script fails.
+func applyRewriteScript(script *rewrite.Script, feed *model.Feed, entry *model.Entry) {
+	if script == nil {
+		return
+	}
+
+	if err := script.Apply(entry); err != nil {
+		slog.Warn("Unable to apply the rewrite script",
+			slog.String("entry_url", entry.URL),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("feed_url", feed.FeedURL),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// maxTranslationDurationPerRefresh limits the time spent translating the new entries of a feed, a slow translation
+// service would block the workers otherwise.
+const maxTranslationDurationPerRefresh = time.Minute
+
+// newTranslator returns the translator of the instance when the entries of the feed are translated automatically.
+func newTranslator(feed *model.Feed) translation.Translator {
+	if !feed.AutoTranslate || !config.Opts.HasTranslation() {
+		return nil
+	}
+
+	return translation.NewTranslator()
+}
+
+// applyTranslation translates the entry to the language of the user, the entry is left untranslated and false is
+// returned if the translation fails.
+func applyTranslation(translator translation.Translator, feed *model.Feed, entry *model.Entry, user *model.User) bool {
+	if translator == nil || !translation.ShouldTranslateEntry(entry, user.Language) {
+		return true
+	}
+
+	if err := translation.TranslateEntry(translator, entry, user.Language); err != nil {
+		slog.Warn("Unable to translate entry",
+			slog.Int64("user_id", user.ID),
+			slog.String("entry_url", entry.URL),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("feed_url", feed.FeedURL),
+			slog.Any("error", err),
+		)
+		return false
+	}
+
+	return true
+}
+
+// scrapeWebsiteWithCache reuses the content scraped from the same web page during the cache TTL, the cache is skipped for the feeds with cookies or credentials.
+func scrapeWebsiteWithCache(store *storage.Storage, feed *model.Feed, pageURL string, forceRefresh bool) (string, string, error) {
+	// The content downloaded with credentials or without TLS verification is not shared with the other users.
+	useCache := config.Opts.HasScraperCache() && feed.Cookie == "" && feed.Username == "" && feed.Password == "" && !feed.AllowSelfSignedCertificates
+	urlHash := scrapedContentHash(feed, pageURL)
+
+	if useCache && !forceRefresh {
+		pageBaseURL, content, found, err := store.ScrapedContent(urlHash, config.Opts.ScraperCacheTTL())
+		if err != nil {
+			slog.Warn("Unable to fetch scraped content from the cache",
+				slog.Int64("feed_id", feed.ID),
+				slog.String("page_url", pageURL),
+				slog.Any("error", err),
+			)
+		} else if found {
+			slog.Debug("Using scraped content from the cache",
+				slog.Int64("feed_id", feed.ID),
+				slog.String("page_url", pageURL),
+			)
+			return pageBaseURL, content, nil
+		}
+	}
+
+	startTime := time.Now()
+
+	pageBaseURL, content, err := scraper.ScrapeWebsite(
+		newEntryRequestBuilder(feed, pageURL),
+		pageURL,
+		feed.ScraperRules,
+		readability.GetExtractor(feed.ContentExtractor),
+	)
+
+	if config.Opts.HasMetricsCollector() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metric.ScraperRequestDuration.WithLabelValues(status).Observe(time.Since(startTime).Seconds())
+	}
+
+	if useCache && err == nil && content != "" {
+		if storeErr := store.StoreScrapedContent(urlHash, pageURL, pageBaseURL, content); storeErr != nil {
+			slog.Warn("Unable to save scraped content to the cache",
+				slog.Int64("feed_id", feed.ID),
+				slog.String("page_url", pageURL),
+				slog.Any("error", storeErr),
+			)
+		}
+	}
+
+	return pageBaseURL, content, err
+}
+
+// scrapedContentHash identifies the scraped content of a web page, the settings changing the extracted content
+// and the settings of the request builder are part of the key.
+func scrapedContentHash(feed *model.Feed, pageURL string) string {
+	headlessBrowser := feed.FetchViaHeadlessBrowser && config.Opts.HasHeadlessBrowser() && isHeadlessBrowserAllowed(pageURL)
+	return crypto.Hash(strings.Join([]string{
+		pageURL,
+		feed.ScraperRules,
+		feed.ContentExtractor,
+		feed.UserAgent,
+		strconv.FormatBool(headlessBrowser),
+		strconv.FormatBool(feed.FetchViaProxy),
+		strconv.FormatBool(feed.DisableHTTP2),
+	}, "\n"))
+}
+
+// newEntryRequestBuilder returns a request builder downloading the web page of an entry with the settings of the feed.
+func newEntryRequestBuilder(feed *model.Feed, pageURL string) *fetcher.RequestBuilder {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
+	requestBuilder.UseProxy(feed.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+
+	if feed.FetchViaHeadlessBrowser && config.Opts.HasHeadlessBrowser() && isHeadlessBrowserAllowed(pageURL) {
+		requestBuilder.WithHeadlessBrowser(config.Opts.HeadlessBrowserURL())
+	}
+
+	return requestBuilder
+}
+
+// isHeadlessBrowserAllowed returns true if the administrator allows the domain of the page, or one of its parent domains, to be rendered.
+func isHeadlessBrowserAllowed(pageURL string) bool {
+	allowedDomains := config.Opts.HeadlessBrowserAllowedDomains()
+	if len(allowedDomains) == 0 {
+		return false
+	}
+
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil {
+		return false
+	}
+
+	for hostname := strings.ToLower(parsedURL.Hostname()); hostname != ""; _, hostname, _ = strings.Cut(hostname, ".") {
+		if slices.Contains(allowedDomains, hostname) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func isBlockedEntry(feed *model.Feed, entry *model.Entry, user *model.User) bool {
 	if user.BlockFilterEntryRules != "" {
 		rules := strings.Split(user.BlockFilterEntryRules, "\n")
@@ -282,19 +494,11 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 	startTime := time.Now()
 	rewrittenEntryURL := rewriteEntryURL(feed, entry)
 
-	requestBuilder := fetcher.NewRequestBuilder()
-	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
-	requestBuilder.WithCookie(feed.Cookie)
-	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
-	requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
-	requestBuilder.UseProxy(feed.FetchViaProxy)
-	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
-	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
-
 	pageBaseURL, extractedContent, scraperErr := scraper.ScrapeWebsite(
-		requestBuilder,
+		newEntryRequestBuilder(feed, rewrittenEntryURL),
 		rewrittenEntryURL,
 		feed.ScraperRules,
+		readability.GetExtractor(feed.ContentExtractor),
 	)
 
 	if config.Opts.HasMetricsCollector() {
@@ -310,6 +514,10 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 	}
 
 	if extractedContent != "" {
+		// The entry content is only the feed content when the page has never been scraped.
+		if entry.FeedContent == "" {
+			entry.FeedContent = entry.Content
+		}
 		entry.Content = minifyEntryContent(extractedContent)
 		if user.ShowReadingTime {
 			entry.ReadingTime = readingtime.EstimateReadingTime(entry.Content, user.DefaultReadingSpeed, user.CJKReadingSpeed)
@@ -317,7 +525,16 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 	}
 
 	rewrite.Rewriter(rewrittenEntryURL, entry, entry.Feed.RewriteRules)
-	entry.Content = sanitizer.Sanitize(pageBaseURL, entry.Content)
+	applyRewriteScript(newRewriteScript(feed), feed, entry)
+	entry.Content = sanitizer.SanitizeWithIframeDomains(pageBaseURL, renderContent(user, entry.Content), user.IframeAllowedDomains)
+
+	// The translation of the previous content is outdated.
+	if extractedContent != "" {
+		entry.TranslatedTitle = ""
+		entry.TranslatedContent = ""
+		entry.TranslationLanguage = ""
+		applyTranslation(newTranslator(feed), feed, entry, user)
+	}
 
 	return nil
 }
@@ -4,6 +4,11 @@
 package processor // import "miniflux.app/v2/internal/reader/processor"
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -146,3 +151,167 @@ func TestMinifyEntryContent(t *testing.T) {
 		t.Errorf(`Unexpected result, got %q`, result)
 	}
 }
+
+func TestIsHeadlessBrowserAllowed(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("HEADLESS_BROWSER_ALLOWED_DOMAINS", "example.org")
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	var scenarios = []struct {
+		pageURL  string
+		expected bool
+	}{
+		{"https://example.org/article", true},
+		{"https://blog.Example.org/article", true},
+		{"https://example.com/article", false},
+		{"https://notexample.org/article", false},
+	}
+	for _, tc := range scenarios {
+		if result := isHeadlessBrowserAllowed(tc.pageURL); result != tc.expected {
+			t.Errorf(`Unexpected result, got %v for %q`, result, tc.pageURL)
+		}
+	}
+
+	os.Clearenv()
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if isHeadlessBrowserAllowed("https://example.com/article") {
+		t.Errorf(`No domain should be allowed when the list is empty`)
+	}
+}
+
+func TestProcessEntryWebPageKeepsFeedContent(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><body><article><p>This is the scraped content of the article.</p></article></body></html>`))
+	}))
+	defer server.Close()
+
+	feed := &model.Feed{}
+	entry := &model.Entry{URL: server.URL, Content: "<p>Feed excerpt</p>", Feed: feed}
+
+	for range 2 {
+		if err := ProcessEntryWebPage(feed, entry, &model.User{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if entry.FeedContent != "<p>Feed excerpt</p>" {
+		t.Errorf(`Unexpected feed content, got %q`, entry.FeedContent)
+	}
+
+	if entry.Content == entry.FeedContent {
+		t.Errorf(`The entry content should be replaced by the scraped content`)
+	}
+}
+
+func TestRenderContentMath(t *testing.T) {
+	config.Opts = config.NewOptions()
+	content := `<p>$$x^2$$</p>`
+
+	if output := renderContent(&model.User{}, content); output != content {
+		t.Errorf(`The formulas should not be converted when the user didn't enable it, got %s`, output)
+	}
+
+	if output := renderContent(&model.User{RenderMath: true}, content); !strings.HasPrefix(output, `<p><math display="block">`) {
+		t.Errorf(`The formulas should be converted, got %s`, output)
+	}
+}
+
+func TestRenderContentSyntaxHighlighting(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("DISABLE_SYNTAX_HIGHLIGHTING", "1")
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	content := `<pre><code class="language-go">return nil</code></pre>`
+	if output := renderContent(&model.User{}, content); output != content {
+		t.Errorf(`The code should not be highlighted when the syntax highlighting is disabled, got %s`, output)
+	}
+
+	config.Opts = config.NewOptions()
+	if output := renderContent(&model.User{}, content); !strings.Contains(output, `<span class="hl-keyword">return</span>`) {
+		t.Errorf(`The code should be highlighted, got %s`, output)
+	}
+}
+
+func TestScrapedContentHash(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	feed := &model.Feed{ScraperRules: "article"}
+	hash := scrapedContentHash(feed, "https://example.org/article")
+
+	if hash != scrapedContentHash(&model.Feed{ScraperRules: "article"}, "https://example.org/article") {
+		t.Errorf(`The feeds with the same settings should share the scraped content`)
+	}
+
+	if hash == scrapedContentHash(feed, "https://example.org/other-article") {
+		t.Errorf(`The pages should not share the scraped content`)
+	}
+
+	if hash == scrapedContentHash(&model.Feed{ScraperRules: "div.content"}, "https://example.org/article") {
+		t.Errorf(`The feeds with different scraper rules should not share the scraped content`)
+	}
+
+	if hash == scrapedContentHash(&model.Feed{ScraperRules: "article", FetchViaProxy: true}, "https://example.org/article") {
+		t.Errorf(`The feeds fetched through the proxy should not share the scraped content`)
+	}
+
+	if hash == scrapedContentHash(&model.Feed{ScraperRules: "article", DisableHTTP2: true}, "https://example.org/article") {
+		t.Errorf(`The feeds without HTTP/2 should not share the scraped content`)
+	}
+}
+
+type fakeTranslator struct {
+	err error
+}
+
+func (f *fakeTranslator) TranslateHTML(documents []string, language string) ([]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []string{"Bonjour", "<p>Bonjour</p>"}, nil
+}
+
+func TestApplyTranslation(t *testing.T) {
+	feed := &model.Feed{ID: 1}
+	user := &model.User{ID: 1, Language: "fr_FR"}
+
+	entry := &model.Entry{Title: "Hello", Content: "<p>Hello</p>", Language: "en"}
+	if !applyTranslation(&fakeTranslator{}, feed, entry, user) {
+		t.Fatal(`The translation should succeed`)
+	}
+
+	if entry.TranslatedTitle != "Bonjour" || entry.TranslationLanguage != "fr_FR" {
+		t.Errorf(`Unexpected translation, got %q in %q`, entry.TranslatedTitle, entry.TranslationLanguage)
+	}
+
+	entry = &model.Entry{Title: "Hello", Content: "<p>Hello</p>", Language: "en"}
+	if applyTranslation(&fakeTranslator{err: errors.New("unavailable")}, feed, entry, user) {
+		t.Error(`The failure of the translation service should be reported`)
+	}
+
+	if entry.TranslatedContent != "" {
+		t.Errorf(`The entry should not be translated, got %q`, entry.TranslatedContent)
+	}
+}
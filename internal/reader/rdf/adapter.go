@@ -39,6 +39,9 @@ func (r *RDFAdapter) BuildFeed(baseURL string) *model.Feed {
 		feed.SiteURL = siteURL
 	}
 
+	// The update period of the syndication module is used as TTL.
+	feed.TTL = r.rdf.Channel.UpdateInterval()
+
 	for _, item := range r.rdf.Items {
 		entry := model.NewEntry()
 		itemLink := strings.TrimSpace(item.Link)
@@ -74,7 +77,10 @@ func (r *RDFAdapter) BuildFeed(baseURL string) *model.Feed {
 		}
 
 		// Generate the entry hash.
-		hashValue := itemLink
+		hashValue := item.Identifier()
+		if hashValue == "" {
+			hashValue = itemLink
+		}
 		if hashValue == "" {
 			hashValue = item.Title + item.Description // Fallback to the title and description if the link is empty.
 		}
@@ -96,13 +102,21 @@ func (r *RDFAdapter) BuildFeed(baseURL string) *model.Feed {
 		}
 
 		// Populate the entry author.
-		switch {
-		case item.DublinCoreCreator != "":
-			entry.Author = stripTags(item.DublinCoreCreator)
-		case r.rdf.Channel.DublinCoreCreator != "":
+		if authors := item.Authors(); len(authors) > 0 {
+			entry.Author = strings.Join(authors, ", ")
+		} else if r.rdf.Channel.DublinCoreCreator != "" {
 			entry.Author = stripTags(r.rdf.Channel.DublinCoreCreator)
 		}
 
+		// Populate the entry tags.
+		entry.Tags = append(entry.Tags, item.Subjects()...)
+
+		// Populate the entry location.
+		if latitude, longitude, found := item.Coordinates(); found {
+			entry.Latitude = &latitude
+			entry.Longitude = &longitude
+		}
+
 		feed.Entries = append(feed.Entries, entry)
 	}
 
@@ -203,6 +203,10 @@ func TestParseRDFSampleWithDublinCore(t *testing.T) {
 		t.Errorf("Incorrect site URL, got: %s", feed.SiteURL)
 	}
 
+	if feed.TTL != 30 {
+		t.Errorf("Incorrect TTL, got: %d", feed.TTL)
+	}
+
 	if len(feed.Entries) != 1 {
 		t.Errorf("Incorrect number of entries, got: %d", len(feed.Entries))
 	}
@@ -845,3 +849,69 @@ func TestParseFeedWithInvalidCharacterEntity(t *testing.T) {
 		t.Errorf(`Incorrect URL, got: %q`, feed.SiteURL)
 	}
 }
+
+func TestParseItemWithDublinCoreMetadata(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+	  <channel>
+			<title>Example Feed</title>
+			<link>http://example.org/</link>
+	  </channel>
+	  <item>
+			<title>Title</title>
+			<link>http://example.org/item</link>
+			<dc:creator>Jane Doe</dc:creator>
+			<dc:contributor>John Doe</dc:contributor>
+			<dc:contributor>Jane Doe</dc:contributor>
+			<dc:subject>Science</dc:subject>
+			<dc:subject> Physics </dc:subject>
+			<dc:identifier>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a</dc:identifier>
+	  </item>
+	</rdf:RDF>`
+
+	feed, err := Parse("http://example.org", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Author != "Jane Doe, John Doe" {
+		t.Errorf(`Incorrect entry author, got: %q`, feed.Entries[0].Author)
+	}
+
+	if len(feed.Entries[0].Tags) != 2 || feed.Entries[0].Tags[0] != "Science" || feed.Entries[0].Tags[1] != "Physics" {
+		t.Errorf(`Incorrect entry tags, got: %v`, feed.Entries[0].Tags)
+	}
+
+	if feed.Entries[0].Hash != "3841e5cf232f5111fc5841e9eba5f4b26d95e7d7124902e0f7272729d65601a6" {
+		t.Errorf(`Incorrect entry hash, got: %q`, feed.Entries[0].Hash)
+	}
+}
+
+func TestParseItemWithW3CGeoLocation(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:geo="http://www.w3.org/2003/01/geo/wgs84_pos#">
+	  <channel>
+			<title>Example Feed</title>
+			<link>http://example.org/</link>
+	  </channel>
+	  <item>
+			<title>Title</title>
+			<link>http://example.org/item</link>
+			<geo:lat>55.701</geo:lat>
+			<geo:long>12.552</geo:long>
+	  </item>
+	</rdf:RDF>`
+
+	feed, err := Parse("http://example.org", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Latitude == nil || *feed.Entries[0].Latitude != 55.701 {
+		t.Errorf(`Incorrect entry latitude, got: %v`, feed.Entries[0].Latitude)
+	}
+
+	if feed.Entries[0].Longitude == nil || *feed.Entries[0].Longitude != 12.552 {
+		t.Errorf(`Incorrect entry longitude, got: %v`, feed.Entries[0].Longitude)
+	}
+}
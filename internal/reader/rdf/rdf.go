@@ -7,6 +7,8 @@ import (
 	"encoding/xml"
 
 	"miniflux.app/v2/internal/reader/dublincore"
+	"miniflux.app/v2/internal/reader/geo"
+	"miniflux.app/v2/internal/reader/syndication"
 )
 
 // RDF sepcs: https://web.resource.org/rss/1.0/spec
@@ -21,6 +23,7 @@ type RDFChannel struct {
 	Link        string `xml:"link"`
 	Description string `xml:"description"`
 	dublincore.DublinCoreChannelElement
+	syndication.SyndicationChannelElement
 }
 
 type RDFItem struct {
@@ -28,4 +31,5 @@ type RDFItem struct {
 	Link        string `xml:"link"`
 	Description string `xml:"description"`
 	dublincore.DublinCoreItemElement
+	geo.GeoItemElement
 }
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readability // import "miniflux.app/v2/internal/reader/readability"
+
+import "io"
+
+// List of content extractors.
+const (
+	ExtractorReadability = "readability"
+	ExtractorParagraphs  = "paragraphs"
+)
+
+// Extractor finds the main content of a web page.
+type Extractor interface {
+	ExtractContent(page io.Reader) (baseURL string, extractedContent string, err error)
+}
+
+type extractorFunc func(page io.Reader) (baseURL string, extractedContent string, err error)
+
+func (f extractorFunc) ExtractContent(page io.Reader) (baseURL string, extractedContent string, err error) {
+	return f(page)
+}
+
+var extractors = map[string]Extractor{
+	ExtractorReadability: extractorFunc(ExtractContent),
+	ExtractorParagraphs:  extractorFunc(ExtractParagraphs),
+}
+
+// GetExtractor returns the extractor with the given name, the readability algorithm is used by default.
+func GetExtractor(name string) Extractor {
+	if extractor, found := extractors[name]; found {
+		return extractor
+	}
+	return extractors[ExtractorReadability]
+}
+
+// IsValidExtractor returns true if the name is empty or the name of an extractor.
+func IsValidExtractor(name string) bool {
+	_, found := extractors[name]
+	return name == "" || found
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readability // import "miniflux.app/v2/internal/reader/readability"
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"miniflux.app/v2/internal/urllib"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	minParagraphLength       = 20
+	maxParagraphLinkDensity  = 0.5
+	minContainerTextFraction = 0.8
+)
+
+// ExtractParagraphs returns the smallest element holding most of the paragraphs of the page.
+// Pages without any paragraph are handed to the readability algorithm.
+func ExtractParagraphs(page io.Reader) (baseURL string, extractedContent string, err error) {
+	data, err := io.ReadAll(page)
+	if err != nil {
+		return "", "", err
+	}
+
+	document, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return "", "", err
+	}
+
+	if hrefValue, exists := document.Find("head base").First().Attr("href"); exists {
+		hrefValue = strings.TrimSpace(hrefValue)
+		if urllib.IsAbsoluteURL(hrefValue) {
+			baseURL = hrefValue
+		}
+	}
+
+	document.Find("script,style,noscript,nav,footer,aside,form").Each(func(i int, s *goquery.Selection) {
+		removeNodes(s)
+	})
+	removeUnlikelyCandidates(document)
+
+	paragraphLengths := make(map[*html.Node]int)
+	document.Find("p,pre").Each(func(i int, s *goquery.Selection) {
+		textLength := utf8.RuneCountInString(strings.TrimSpace(s.Text()))
+		if textLength >= minParagraphLength && getLinkDensity(s) < maxParagraphLinkDensity {
+			paragraphLengths[s.Get(0)] = textLength
+		}
+	})
+
+	container := document.Find("body").First()
+	if len(paragraphLengths) == 0 || container.Length() == 0 {
+		slog.Debug("No paragraph found, falling back to readability", slog.String("base_url", baseURL))
+		return ExtractContent(bytes.NewReader(data))
+	}
+
+	// Walk down the tree as long as a single child holds most of the paragraph text.
+	totalLength := getParagraphLength(container.Get(0), paragraphLengths)
+	for {
+		var nextContainer *goquery.Selection
+		container.Children().EachWithBreak(func(i int, s *goquery.Selection) bool {
+			if float32(getParagraphLength(s.Get(0), paragraphLengths)) >= minContainerTextFraction*float32(totalLength) {
+				nextContainer = s
+				return false
+			}
+			return true
+		})
+
+		if nextContainer == nil || paragraphLengths[nextContainer.Get(0)] > 0 {
+			break
+		}
+		container = nextContainer
+	}
+
+	// The body element is replaced by a div to keep the same kind of output as the readability algorithm.
+	if container.Is("body") {
+		innerHTML, err := container.Html()
+		if err != nil {
+			return "", "", err
+		}
+		return baseURL, "<div>" + strings.TrimSpace(innerHTML) + "</div>", nil
+	}
+
+	extractedContent, err = goquery.OuterHtml(container)
+	if err != nil {
+		return "", "", err
+	}
+
+	return baseURL, extractedContent, nil
+}
+
+func getParagraphLength(node *html.Node, paragraphLengths map[*html.Node]int) int {
+	length := paragraphLengths[node]
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		length += getParagraphLength(child, paragraphLengths)
+	}
+	return length
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readability // import "miniflux.app/v2/internal/reader/readability"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractParagraphs(t *testing.T) {
+	html := `
+		<html>
+			<head>
+				<base href="https://example.org/">
+			</head>
+			<body>
+				<nav><p>Home, Archives, About this website</p></nav>
+				<div class="wrapper">
+					<div class="post"><p>This is the first paragraph of the article.</p><p>This is the second paragraph of the article.</p></div>
+					<div class="share"><a href="/share">Share</a></div>
+				</div>
+				<footer><p>Copyright notice of the website</p></footer>
+			</body>
+		</html>`
+
+	baseURL, content, err := ExtractParagraphs(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if baseURL != "https://example.org/" {
+		t.Errorf(`Unexpected base URL, got %q instead of "https://example.org/"`, baseURL)
+	}
+
+	expected := `<div class="post"><p>This is the first paragraph of the article.</p><p>This is the second paragraph of the article.</p></div>`
+	if content != expected {
+		t.Errorf(`Unexpected content, got %q instead of %q`, content, expected)
+	}
+}
+
+func TestExtractParagraphsIgnoresLinkLists(t *testing.T) {
+	html := `
+		<html>
+			<body>
+				<div id="links"><p><a href="/a">A list of links to other articles</a></p></div>
+				<article><p>This is the only paragraph of the article.</p></article>
+			</body>
+		</html>`
+
+	_, content, err := ExtractParagraphs(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<article><p>This is the only paragraph of the article.</p></article>`
+	if content != expected {
+		t.Errorf(`Unexpected content, got %q instead of %q`, content, expected)
+	}
+}
+
+func TestExtractParagraphsFallback(t *testing.T) {
+	html := `<html><body><div>Short text</div></body></html>`
+
+	_, content, err := ExtractParagraphs(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, expected, err := ExtractContent(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if content != expected {
+		t.Errorf(`Unexpected content, got %q instead of %q`, content, expected)
+	}
+}
+
+func TestGetExtractor(t *testing.T) {
+	if !IsValidExtractor("") || !IsValidExtractor(ExtractorParagraphs) || !IsValidExtractor(ExtractorReadability) {
+		t.Error(`The empty value and the built-in extractors should be valid`)
+	}
+
+	if IsValidExtractor("unknown") {
+		t.Error(`An unknown extractor should not be valid`)
+	}
+
+	if GetExtractor("unknown") == nil {
+		t.Error(`The readability extractor should be used by default`)
+	}
+}
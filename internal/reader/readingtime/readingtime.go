@@ -6,34 +6,45 @@ package readingtime
 
 import (
 	"math"
-	"strings"
-	"unicode/utf8"
+	"unicode"
 
 	"miniflux.app/v2/internal/reader/sanitizer"
-
-	"github.com/abadojack/whatlanggo"
 )
 
 // EstimateReadingTime returns the estimated reading time of an article in minute.
+// The Chinese, Japanese and Korean characters are counted with the CJK reading speed, in characters per minute,
+// and the other words with the default reading speed, in words per minute. The articles mixing both are estimated correctly.
 func EstimateReadingTime(content string, defaultReadingSpeed, cjkReadingSpeed int) int {
-	sanitizedContent := sanitizer.StripTags(content)
-
-	// Litterature on language detection says that around 100 signes is enough, we're safe here.
-	truncationPoint := int(math.Min(float64(len(sanitizedContent)), 250))
-
-	// We're only interested in identifying Japanse/Chinese/Korean
-	options := whatlanggo.Options{
-		Whitelist: map[whatlanggo.Lang]bool{
-			whatlanggo.Jpn: true,
-			whatlanggo.Cmn: true,
-			whatlanggo.Kor: true,
-		},
-	}
-	langInfo := whatlanggo.DetectWithOptions(sanitizedContent[:truncationPoint], options)
+	nbOfWords, nbOfCJKCharacters := countWordsAndCJKCharacters(sanitizer.StripTags(content))
+
+	minutes := float64(nbOfWords)/float64(defaultReadingSpeed) + float64(nbOfCJKCharacters)/float64(cjkReadingSpeed)
+	return int(math.Ceil(minutes))
+}
 
-	if langInfo.IsReliable() {
-		return int(math.Ceil(float64(utf8.RuneCountInString(sanitizedContent)) / float64(cjkReadingSpeed)))
+// countWordsAndCJKCharacters returns the number of words written without CJK characters and the number of CJK characters.
+// A word is a sequence of characters without spaces containing at least a letter or a digit, the CJK punctuation is not counted.
+func countWordsAndCJKCharacters(text string) (nbOfWords, nbOfCJKCharacters int) {
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isCJKCharacter(r):
+			nbOfCJKCharacters++
+			inWord = false
+		case unicode.IsSpace(r):
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if !inWord {
+				nbOfWords++
+				inWord = true
+			}
+		}
 	}
-	nbOfWords := len(strings.Fields(sanitizedContent))
-	return int(math.Ceil(float64(nbOfWords) / float64(defaultReadingSpeed)))
+
+	return nbOfWords, nbOfCJKCharacters
+}
+
+// isCJKCharacter returns true for the Chinese, Japanese and Korean characters, the prolonged sound mark of the
+// Japanese words written in katakana is shared by several scripts.
+func isCJKCharacter(r rune) bool {
+	return r == 'ー' || unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
 }
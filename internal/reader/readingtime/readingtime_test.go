@@ -3,7 +3,10 @@
 
 package readingtime
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 var samples = map[string]string{
 	"shortenglish": `This is a short paragraph in english, less than 250 chars.`,
@@ -67,7 +70,7 @@ func TestEstimateReadingTime(t *testing.T) {
 		"shortchinese": 1,
 		"english":      2,
 		"chinese":      2,
-		"korean":       5,
+		"korean":       4,
 	}
 
 	for language, sample := range samples {
@@ -79,6 +82,42 @@ func TestEstimateReadingTime(t *testing.T) {
 	}
 }
 
+func TestEstimateReadingTimeOfMixedContent(t *testing.T) {
+	// The English introduction should not hide the Japanese text.
+	content := "<p>Photo: Example Press</p><p>" + strings.Repeat("今日はとても良い天気ですね。", 100) + "</p>"
+	if got := EstimateReadingTime(content, 200, 500); got != 3 {
+		t.Errorf(`Wrong reading time, got %d instead of 3`, got)
+	}
+
+	// The English words of a Chinese article are read at the default speed.
+	content = strings.Repeat("这是一篇关于 Go programming language 的文章。", 50)
+	if got := EstimateReadingTime(content, 200, 500); got != 2 {
+		t.Errorf(`Wrong reading time, got %d instead of 2`, got)
+	}
+}
+
+func TestCountWordsAndCJKCharacters(t *testing.T) {
+	var scenarios = []struct {
+		text              string
+		nbOfWords         int
+		nbOfCJKCharacters int
+	}{
+		{"", 0, 0},
+		{"Hello, world!", 2, 0},
+		{"l'été - 2024", 2, 0},
+		{"東京タワーへ行きました。", 0, 11},
+		{"「iPhone 15」を買った", 2, 4},
+		{"세계 인권 선언(UDHR)", 1, 6},
+	}
+
+	for _, tc := range scenarios {
+		nbOfWords, nbOfCJKCharacters := countWordsAndCJKCharacters(tc.text)
+		if nbOfWords != tc.nbOfWords || nbOfCJKCharacters != tc.nbOfCJKCharacters {
+			t.Errorf(`Unexpected count for %q, got %d words and %d CJK characters`, tc.text, nbOfWords, nbOfCJKCharacters)
+		}
+	}
+}
+
 func BenchmarkEstimateReadingTime(b *testing.B) {
 	for range b.N {
 		for _, sample := range samples {
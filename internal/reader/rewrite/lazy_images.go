@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rewrite // import "miniflux.app/v2/internal/reader/rewrite"
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Ordered most preferred to least preferred.
+var (
+	lazySrcAttrs = []string{
+		"data-src",
+		"data-lazy-src",
+		"data-original",
+		"data-lazy",
+		"data-echo",
+		"data-url",
+	}
+
+	lazySrcsetAttrs = []string{
+		"data-srcset",
+		"data-lazy-srcset",
+		"data-original-set",
+	}
+)
+
+// The file names of the images displayed by the lazy loading scripts until the real image is loaded.
+var placeholderKeywords = []string{"blank", "empty", "lazy", "loading", "pixel", "placeholder", "spacer", "transparent"}
+
+// NormalizeLazyImages makes the lazy-loaded images visible without JavaScript: the real image URLs stored in the
+// data-src or data-srcset attributes are promoted to src and srcset, and the images only available in a <noscript>
+// element are unwrapped. The images with a real source are left untouched.
+func NormalizeLazyImages(entryContent string) string {
+	if !strings.Contains(entryContent, "data-") && !strings.Contains(entryContent, "<noscript") {
+		return entryContent
+	}
+
+	// A leading <noscript> element would be moved to the <head> of the document otherwise.
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader("<body>" + entryContent))
+	if err != nil {
+		return entryContent
+	}
+
+	changed := false
+
+	// The <noscript> fallbacks are handled first, they replace the placeholder they are attached to.
+	doc.Find("noscript").Each(func(i int, noscript *goquery.Selection) {
+		matches := imgRegex.FindAllString(noscript.Text(), 2)
+		if len(matches) != 1 {
+			return
+		}
+
+		changed = true
+
+		previous := noscript.Prev()
+		switch {
+		case !previous.Is("img"):
+			noscript.ReplaceWithHtml(matches[0])
+		case isLazyImage(previous):
+			previous.ReplaceWithHtml(matches[0])
+			noscript.Remove()
+		default:
+			noscript.Remove()
+		}
+	})
+
+	doc.Find("img,picture source").Each(func(i int, element *goquery.Selection) {
+		lazy := element.Is("img") && isLazyImage(element)
+
+		if lazy {
+			if src := lazyAttr(element, lazySrcAttrs); src != "" {
+				element.SetAttr("src", src)
+				changed = true
+			}
+		}
+
+		srcset := strings.TrimSpace(element.AttrOr("srcset", ""))
+		if srcset == "" || strings.HasPrefix(srcset, "data:") || lazy {
+			if lazySrcset := lazyAttr(element, lazySrcsetAttrs); lazySrcset != "" {
+				element.SetAttr("srcset", lazySrcset)
+				changed = true
+			}
+		}
+	})
+
+	if !changed {
+		return entryContent
+	}
+
+	output, _ := doc.Find("body").First().Html()
+	return output
+}
+
+// isLazyImage returns true if the image doesn't have a source yet, or only a placeholder.
+func isLazyImage(img *goquery.Selection) bool {
+	for _, class := range strings.Fields(img.AttrOr("class", "")) {
+		if class == "lazyload" || class == "lazy" {
+			return true
+		}
+	}
+
+	src := strings.TrimSpace(img.AttrOr("src", ""))
+	if src == "" || strings.HasPrefix(src, "data:") {
+		return true
+	}
+
+	fileName := strings.ToLower(src)
+	if index := strings.IndexAny(fileName, "?#"); index >= 0 {
+		fileName = fileName[:index]
+	}
+	fileName = fileName[strings.LastIndex(fileName, "/")+1:]
+
+	for _, keyword := range placeholderKeywords {
+		if strings.Contains(fileName, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lazyAttr returns the value of the first lazy loading attribute of the element.
+func lazyAttr(element *goquery.Selection, attrs []string) string {
+	for _, attr := range attrs {
+		if value := strings.TrimSpace(element.AttrOr(attr, "")); value != "" && !strings.HasPrefix(value, "data:") {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rewrite // import "miniflux.app/v2/internal/reader/rewrite"
+
+import "testing"
+
+func TestNormalizeLazyImages(t *testing.T) {
+	scenarios := map[string]string{
+		// Images without lazy loading are kept as is.
+		`<p><img src="https://example.org/image.jpg" alt="Image"/></p>`: `<p><img src="https://example.org/image.jpg" alt="Image"/></p>`,
+		`<p>No image</p>`: `<p>No image</p>`,
+
+		// Lazy sources are promoted.
+		`<img data-src="https://example.org/image.jpg" alt="Image"/>`:                                                `<img data-src="https://example.org/image.jpg" alt="Image" src="https://example.org/image.jpg"/>`,
+		`<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" data-lazy-src="https://example.org/image.jpg"/>`:      `<img src="https://example.org/image.jpg" data-lazy-src="https://example.org/image.jpg"/>`,
+		`<img src="https://example.org/placeholder.png?v=1" data-original="https://example.org/image.jpg"/>`:         `<img src="https://example.org/image.jpg" data-original="https://example.org/image.jpg"/>`,
+		`<img class="lazyload" src="https://example.org/image-small.jpg" data-src="https://example.org/image.jpg"/>`: `<img class="lazyload" src="https://example.org/image.jpg" data-src="https://example.org/image.jpg"/>`,
+
+		// Real sources are not replaced.
+		`<img src="https://example.org/image.jpg" data-src="https://example.org/other.jpg"/>`: `<img src="https://example.org/image.jpg" data-src="https://example.org/other.jpg"/>`,
+
+		// Lazy srcset are promoted.
+		`<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" data-src="https://example.org/a.jpg" data-srcset="https://example.org/a.jpg 1x, https://example.org/b.jpg 2x"/>`: `<img src="https://example.org/a.jpg" data-src="https://example.org/a.jpg" data-srcset="https://example.org/a.jpg 1x, https://example.org/b.jpg 2x" srcset="https://example.org/a.jpg 1x, https://example.org/b.jpg 2x"/>`,
+		`<picture><source data-srcset="https://example.org/a.webp" type="image/webp"/><img src="https://example.org/a.jpg"/></picture>`:                                         `<picture><source data-srcset="https://example.org/a.webp" type="image/webp" srcset="https://example.org/a.webp"/><img src="https://example.org/a.jpg"/></picture>`,
+
+		// Noscript images are unwrapped and replace their placeholder.
+		`<noscript><img src="https://example.org/image.jpg"/></noscript>`:                                                        `<img src="https://example.org/image.jpg"/>`,
+		`<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw="/><noscript><img src="https://example.org/image.jpg"/></noscript>`: `<img src="https://example.org/image.jpg"/>`,
+		`<img src="https://example.org/image.jpg"/><noscript><img src="https://example.org/image.jpg"/></noscript>`:              `<img src="https://example.org/image.jpg"/>`,
+		`<noscript><p>Enable JavaScript</p></noscript>`:                                                                          `<noscript><p>Enable JavaScript</p></noscript>`,
+	}
+
+	for input, expected := range scenarios {
+		if output := NormalizeLazyImages(input); output != expected {
+			t.Errorf(`Unexpected output for %q: got %q instead of %q`, input, output, expected)
+		}
+	}
+}
@@ -29,6 +29,14 @@ var (
 	textLinkRegex  = regexp.MustCompile(`(?mi)(\bhttps?:\/\/[-A-Z0-9+&@#\/%?=~_|!:,.;]*[-A-Z0-9+&@#\/%=~_|])`)
 )
 
+const (
+	// maxReplacePatternLength limits the size of the regular expressions written by the users.
+	maxReplacePatternLength = 1024
+
+	// maxReplacementGrowth limits how much a replace rule can increase the size of the text, in bytes.
+	maxReplacementGrowth = 1024 * 1024
+)
+
 func addImageTitle(entryURL, entryContent string) string {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(entryContent))
 	if err != nil {
@@ -303,12 +311,44 @@ func replaceTextLinks(input string) string {
 	return textLinkRegex.ReplaceAllString(input, `<a href="${1}">${1}</a>`)
 }
 
-func replaceCustom(entryContent string, searchTerm string, replaceTerm string) string {
+// compileReplacePattern compiles the regular expression of a replace rule.
+// The regexp package runs in linear time, only the size of the patterns needs to be limited.
+func compileReplacePattern(searchTerm string) (*regexp.Regexp, error) {
+	if len(searchTerm) > maxReplacePatternLength {
+		return nil, fmt.Errorf("rewrite: the pattern is longer than %d characters", maxReplacePatternLength)
+	}
+
 	re, err := regexp.Compile(searchTerm)
-	if err == nil {
-		return re.ReplaceAllString(entryContent, replaceTerm)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite: invalid pattern %q: %w", searchTerm, err)
 	}
-	return entryContent
+
+	return re, nil
+}
+
+// replaceCustom behaves like regexp.ReplaceAllString, the text is left unchanged when the pattern is invalid
+// or when the replacements would make the text grow by more than maxReplacementGrowth bytes.
+func replaceCustom(entryContent string, searchTerm string, replaceTerm string) string {
+	re, err := compileReplacePattern(searchTerm)
+	if err != nil {
+		slog.Debug("Unable to apply the replace rule", slog.Any("error", err))
+		return entryContent
+	}
+
+	var output []byte
+	lastIndex := 0
+	for _, match := range re.FindAllStringSubmatchIndex(entryContent, -1) {
+		output = append(output, entryContent[lastIndex:match[0]]...)
+		output = re.ExpandString(output, replaceTerm, entryContent, match)
+		lastIndex = match[1]
+
+		if len(output) > len(entryContent)+maxReplacementGrowth {
+			slog.Debug("Ignoring the replace rule, the replacements are too large", slog.String("pattern", searchTerm))
+			return entryContent
+		}
+	}
+
+	return string(append(output, entryContent[lastIndex:]...))
 }
 
 func removeCustom(entryContent string, selector string) string {
@@ -4,12 +4,14 @@
 package rewrite // import "miniflux.app/v2/internal/reader/rewrite"
 
 import (
+	"fmt"
 	"log/slog"
 	"strconv"
 	"strings"
 	"text/scanner"
 
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/siterules"
 	"miniflux.app/v2/internal/urllib"
 
 	"golang.org/x/text/cases"
@@ -120,6 +122,24 @@ func Rewriter(entryURL string, entry *model.Entry, customRewriteRules string) {
 	}
 }
 
+// ValidateRules returns an error when the regular expression of a replace rule can't be used.
+func ValidateRules(rulesText string) error {
+	for _, rule := range parseRules(rulesText) {
+		switch rule.name {
+		case "replace", "replace_title":
+			if len(rule.args) < 2 {
+				return fmt.Errorf(`rewrite: the %s rule requires a pattern and a replacement`, rule.name)
+			}
+
+			if _, err := compileReplacePattern(rule.args[0]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func parseRules(rulesText string) (rules []rule) {
 	scan := scanner.Scanner{Mode: scanner.ScanIdents | scanner.ScanStrings}
 	scan.Init(strings.NewReader(rulesText))
@@ -140,6 +160,11 @@ func parseRules(rulesText string) (rules []rule) {
 }
 
 func getPredefinedRewriteRules(entryURL string) string {
+	// The rules of the site rules file take precedence over the bundled rules.
+	if rules := siterules.RewriteRules(entryURL); rules != "" {
+		return rules
+	}
+
 	urlDomain := urllib.Domain(entryURL)
 	for domain, rules := range predefinedRules {
 		if strings.Contains(urlDomain, domain) {
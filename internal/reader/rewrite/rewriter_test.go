@@ -514,6 +514,43 @@ func TestRewriteReplaceTitleCustom(t *testing.T) {
 	}
 }
 
+func TestRewriteReplaceCustomWithEmptyMatches(t *testing.T) {
+	testEntry := &model.Entry{Content: `abc`}
+	Rewriter("https://example.org/article", testEntry, `replace("x*"|"-")`)
+
+	if testEntry.Content != `-a-b-c-` {
+		t.Errorf(`Not expected output: got %q instead of "-a-b-c-"`, testEntry.Content)
+	}
+}
+
+func TestRewriteReplaceCustomWithTooLargeReplacements(t *testing.T) {
+	content := strings.Repeat("a", 1024)
+	testEntry := &model.Entry{Content: content}
+	Rewriter("https://example.org/article", testEntry, `replace("a"|"`+strings.Repeat("b", 2048)+`")`)
+
+	if testEntry.Content != content {
+		t.Errorf(`The content should not be modified when the replacements are too large`)
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	scenarios := map[string]bool{
+		``:                                     true,
+		`add_image_title,nl2br`:                true,
+		`replace("article/(.*).svg"|"$1.png")`: true,
+		`replace_title("(?i)^a"|"b")`:          true,
+		`replace("(unclosed"|"replacement")`:   false,
+		`replace_title("pattern")`:             false,
+		`replace("` + strings.Repeat("a", 1025) + `"|"b")`: false,
+	}
+
+	for rules, expected := range scenarios {
+		if err := ValidateRules(rules); (err == nil) != expected {
+			t.Errorf(`Unexpected result for %q, got %v`, rules, err)
+		}
+	}
+}
+
 func TestRewriteRemoveCustom(t *testing.T) {
 	controlEntry := &model.Entry{
 		Title:   `A title`,
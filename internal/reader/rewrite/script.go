@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rewrite // import "miniflux.app/v2/internal/reader/rewrite"
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+const (
+	// maxScriptExecutionSteps limits the computation done by a script for each entry.
+	maxScriptExecutionSteps = 1_000_000
+
+	// maxScriptExecutionTime cancels the script when a few steps produce large values, Starlark limits each repetition to 1 GiB.
+	maxScriptExecutionTime = time.Second
+
+	// maxScriptStringSize limits the size in bytes of the title and the content returned by a script.
+	maxScriptStringSize = 10 << 20
+)
+
+// Script is a Starlark program transforming the entries of a feed.
+//
+// The program must define a function named rewrite taking a dict with the title, url, content, author and tags of the entry.
+// The title and the content can be changed in the dict. The program can't access the network or the file system.
+//
+//	def rewrite(entry):
+//	    entry["content"] = entry["content"].replace("http://", "https://")
+type Script struct {
+	rewriteFunc starlark.Callable
+}
+
+// NewScript compiles the script and returns an error if the rewrite function is not defined.
+func NewScript(source string) (*Script, error) {
+	program, err := compileScript(source)
+	if err != nil {
+		return nil, err
+	}
+
+	thread, stop := newScriptThread()
+	globals, err := program.Init(thread, nil)
+	stop()
+	if err != nil {
+		return nil, fmt.Errorf("rewrite: unable to load the script: %w", err)
+	}
+
+	rewriteFunc, ok := globals["rewrite"].(starlark.Callable)
+	if !ok {
+		return nil, errors.New("rewrite: the script must define a rewrite(entry) function")
+	}
+
+	return &Script{rewriteFunc: rewriteFunc}, nil
+}
+
+// ValidateScript returns an error if the script can't be compiled, the script is not executed.
+func ValidateScript(source string) error {
+	_, err := compileScript(source)
+	return err
+}
+
+func compileScript(source string) (*starlark.Program, error) {
+	file, err := (&syntax.FileOptions{}).Parse("rewrite.star", source, 0)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite: unable to load the script: %w", err)
+	}
+
+	definesRewriteFunc := false
+	for _, stmt := range file.Stmts {
+		switch s := stmt.(type) {
+		case *syntax.LoadStmt:
+			return nil, errors.New("rewrite: the script can't load modules")
+		case *syntax.DefStmt:
+			definesRewriteFunc = definesRewriteFunc || s.Name.Name == "rewrite"
+		case *syntax.AssignStmt:
+			if ident, ok := s.LHS.(*syntax.Ident); ok && ident.Name == "rewrite" {
+				definesRewriteFunc = true
+			}
+		}
+	}
+
+	if !definesRewriteFunc {
+		return nil, errors.New("rewrite: the script must define a rewrite(entry) function")
+	}
+
+	program, err := starlark.FileProgram(file, func(string) bool { return false })
+	if err != nil {
+		return nil, fmt.Errorf("rewrite: unable to load the script: %w", err)
+	}
+
+	return program, nil
+}
+
+// Apply runs the script on the entry.
+func (s *Script) Apply(entry *model.Entry) error {
+	tags := make([]starlark.Value, 0, len(entry.Tags))
+	for _, tag := range entry.Tags {
+		tags = append(tags, starlark.String(tag))
+	}
+
+	fields := starlark.NewDict(5)
+	fields.SetKey(starlark.String("title"), starlark.String(entry.Title))
+	fields.SetKey(starlark.String("url"), starlark.String(entry.URL))
+	fields.SetKey(starlark.String("content"), starlark.String(entry.Content))
+	fields.SetKey(starlark.String("author"), starlark.String(entry.Author))
+	fields.SetKey(starlark.String("tags"), starlark.NewList(tags))
+
+	thread, stop := newScriptThread()
+	_, err := starlark.Call(thread, s.rewriteFunc, starlark.Tuple{fields}, nil)
+	stop()
+	if err != nil {
+		return fmt.Errorf("rewrite: unable to run the script: %w", err)
+	}
+
+	title, err := getScriptStringField(fields, "title")
+	if err != nil {
+		return err
+	}
+
+	content, err := getScriptStringField(fields, "content")
+	if err != nil {
+		return err
+	}
+
+	entry.Title = title
+	entry.Content = content
+	return nil
+}
+
+// newScriptThread returns a thread limited by the execution steps and time, stop must be called after the execution.
+func newScriptThread() (thread *starlark.Thread, stop func()) {
+	thread = &starlark.Thread{
+		Name: "rewrite",
+		Print: func(thread *starlark.Thread, msg string) {
+			slog.Debug("Rewrite script output", slog.String("message", msg))
+		},
+	}
+	thread.SetMaxExecutionSteps(maxScriptExecutionSteps)
+
+	timer := time.AfterFunc(maxScriptExecutionTime, func() {
+		thread.Cancel("the script took too long")
+	})
+	return thread, func() { timer.Stop() }
+}
+
+func getScriptStringField(fields *starlark.Dict, name string) (string, error) {
+	value, found, err := fields.Get(starlark.String(name))
+	if err != nil || !found {
+		return "", fmt.Errorf("rewrite: the script removed the %s field", name)
+	}
+
+	text, ok := starlark.AsString(value)
+	if !ok {
+		return "", fmt.Errorf("rewrite: the %s field must be a string, got %s", name, value.Type())
+	}
+
+	if len(text) > maxScriptStringSize {
+		return "", fmt.Errorf("rewrite: the %s field is larger than %d bytes", name, maxScriptStringSize)
+	}
+
+	return text, nil
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rewrite // import "miniflux.app/v2/internal/reader/rewrite"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestScriptApply(t *testing.T) {
+	script, err := NewScript(`
+def rewrite(entry):
+    if "example.org" in entry["url"]:
+        entry["title"] = entry["title"].upper()
+    entry["content"] = entry["content"].replace("http://", "https://")
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := &model.Entry{
+		URL:     "https://example.org/article",
+		Title:   "A title",
+		Content: `<img src="http://example.org/image.png">`,
+	}
+	if err := script.Apply(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Title != "A TITLE" {
+		t.Errorf(`Unexpected title, got %q`, entry.Title)
+	}
+
+	if entry.Content != `<img src="https://example.org/image.png">` {
+		t.Errorf(`Unexpected content, got %q`, entry.Content)
+	}
+}
+
+func TestScriptWithoutRewriteFunction(t *testing.T) {
+	if err := ValidateScript(`x = 1`); err == nil {
+		t.Error(`A script without rewrite function should not be valid`)
+	}
+
+	if err := ValidateScript(`def rewrite(entry) pass`); err == nil {
+		t.Error(`A script with a syntax error should not be valid`)
+	}
+}
+
+func TestScriptCannotLoadModules(t *testing.T) {
+	if err := ValidateScript("load(\"os.star\", \"os\")\ndef rewrite(entry):\n    pass\n"); err == nil {
+		t.Error(`A script should not be able to load modules`)
+	}
+}
+
+func TestScriptExecutionLimit(t *testing.T) {
+	script, err := NewScript(`
+def rewrite(entry):
+    for i in range(100000000):
+        entry["content"] = str(i)
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := &model.Entry{Content: "unchanged"}
+	if err := script.Apply(entry); err == nil {
+		t.Error(`The script should be stopped after too many steps`)
+	}
+
+	if entry.Content != "unchanged" {
+		t.Errorf(`The entry should not be modified when the script fails, got %q`, entry.Content)
+	}
+}
+
+func TestScriptWithInvalidField(t *testing.T) {
+	script, err := NewScript(`
+def rewrite(entry):
+    entry["content"] = 42
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := script.Apply(&model.Entry{}); err == nil {
+		t.Error(`The content must remain a string`)
+	}
+}
+
+func TestValidateScriptDoesNotRunTheScript(t *testing.T) {
+	if err := ValidateScript("x = \"x\" * (1 << 40)\ndef rewrite(entry):\n    pass\n"); err != nil {
+		t.Errorf(`The validation should only compile the script, got %v`, err)
+	}
+}
+
+func TestScriptSizeLimits(t *testing.T) {
+	scenarios := map[string]string{
+		"content size":   `entry["content"] = "x" * (11 << 20)`,
+		"title size":     `entry["title"] = "x" * (11 << 20)`,
+		"repetition":     `entry["content"] = "x" * (1 << 40)`,
+		"execution time": "for i in range(1000):\n        s = \"x\" * (100 << 20)",
+	}
+
+	for name, body := range scenarios {
+		script, err := NewScript("def rewrite(entry):\n    " + body + "\n")
+		if err != nil {
+			t.Fatalf(`Unable to compile the %s script: %v`, name, err)
+		}
+
+		entry := &model.Entry{Title: "x", Content: "x"}
+		if err := script.Apply(entry); err == nil {
+			t.Errorf(`The %s script should exceed the limits`, name)
+		}
+
+		if entry.Title != "x" || entry.Content != "x" {
+			t.Errorf(`The entry should not be modified by the %s script`, name)
+		}
+	}
+}
+
+func TestScriptCanExtendTheTags(t *testing.T) {
+	script, err := NewScript(`
+def rewrite(entry):
+    tags = entry["tags"]
+    tags += ["b"]
+    if len(entry["tags"]) == 2:
+        entry["title"] = ", ".join(entry["tags"])
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := &model.Entry{Tags: []string{"a"}}
+	if err := script.Apply(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Title != "a, b" {
+		t.Errorf(`Unexpected title, got %q`, entry.Title)
+	}
+}
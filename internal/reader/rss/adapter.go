@@ -7,12 +7,14 @@ import (
 	"html"
 	"log/slog"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/atom"
 	"miniflux.app/v2/internal/reader/date"
 	"miniflux.app/v2/internal/reader/sanitizer"
 	"miniflux.app/v2/internal/urllib"
@@ -49,17 +51,36 @@ func (r *RSSAdapter) BuildFeed(baseURL string) *model.Feed {
 		}
 	}
 
+	// Try to find the WebSub hub URL from the Atom links.
+	for _, atomLink := range r.rss.Channel.AtomLinks.Links {
+		atomLinkHref := strings.TrimSpace(atomLink.Href)
+		if atomLinkHref != "" && atomLink.Rel == "hub" {
+			if absoluteHubURL, err := urllib.AbsoluteURL(feed.FeedURL, atomLinkHref); err == nil {
+				feed.HubURL = absoluteHubURL
+				break
+			}
+		}
+	}
+
 	// Fallback to the site URL if the title is empty.
 	if feed.Title == "" {
 		feed.Title = feed.SiteURL
 	}
 
-	// Get TTL if defined.
+	// Get TTL if defined, the longest of the TTL and the update period of the syndication module is used.
 	if r.rss.Channel.TTL != "" {
-		if ttl, err := strconv.Atoi(r.rss.Channel.TTL); err == nil {
+		if ttl, err := strconv.Atoi(strings.TrimSpace(r.rss.Channel.TTL)); err == nil {
 			feed.TTL = ttl
 		}
 	}
+	feed.TTL = max(feed.TTL, r.rss.Channel.UpdateInterval())
+
+	// Get the hours and the days the aggregators can skip.
+	feed.SkipHours = findSkipHours(&r.rss.Channel)
+	feed.SkipDays = findSkipDays(&r.rss.Channel)
+
+	// Get the categories of the channel.
+	feed.Categories = findFeedCategories(&r.rss.Channel)
 
 	// Get the feed icon URL if defined.
 	if r.rss.Channel.Image != nil {
@@ -103,6 +124,8 @@ func (r *RSSAdapter) BuildFeed(baseURL string) *model.Feed {
 		// Generate the entry hash.
 		if item.GUID.Data != "" {
 			entry.Hash = crypto.Hash(item.GUID.Data)
+		} else if identifier := item.Identifier(); identifier != "" {
+			entry.Hash = crypto.Hash(identifier)
 		} else if entryURL != "" {
 			entry.Hash = crypto.Hash(entryURL)
 		}
@@ -112,10 +135,53 @@ func (r *RSSAdapter) BuildFeed(baseURL string) *model.Feed {
 			entry.CommentsURL = absoluteCommentsURL
 		}
 
-		// Set podcast listening time.
+		// Find CommentsFeedURL if defined, from the Atom "replies" link first.
+		for _, commentsFeedURL := range []string{atom.AtomLinks(item.AtomLinks.Links).CommentsFeedLink(), strings.TrimSpace(item.CommentsFeedURL)} {
+			if commentsFeedURL != "" {
+				if absoluteCommentsFeedURL, err := urllib.AbsoluteURL(feed.SiteURL, commentsFeedURL); err == nil {
+					entry.CommentsFeedURL = absoluteCommentsFeedURL
+					break
+				}
+			}
+		}
+
+		// Populate the entry thumbnail.
+		if thumbnailURL := item.FirstThumbnailURL(); thumbnailURL != "" {
+			if absoluteThumbnailURL, err := urllib.AbsoluteURL(feed.SiteURL, thumbnailURL); err == nil {
+				entry.ThumbnailURL = absoluteThumbnailURL
+			}
+		}
+
+		// Populate the entry location.
+		if latitude, longitude, found := item.Coordinates(); found {
+			entry.Latitude = &latitude
+			entry.Longitude = &longitude
+		}
+
+		// Set podcast listening time, the duration is also the duration of the episode media.
 		if item.ItunesDuration != "" {
-			if duration, err := getDurationInMinutes(item.ItunesDuration); err == nil {
-				entry.ReadingTime = duration
+			if duration, err := getDurationInSeconds(item.ItunesDuration); err == nil {
+				entry.ReadingTime = duration / 60
+				for _, enclosure := range entry.Enclosures {
+					if strings.HasPrefix(enclosure.MimeType, "audio/") || strings.HasPrefix(enclosure.MimeType, "video/") {
+						enclosure.Duration = int64(duration)
+					}
+				}
+			}
+		}
+
+		// Populate the podcast episode metadata.
+		entry.PodcastEpisode = parsePodcastNumber(item.ItunesEpisode)
+		entry.PodcastSeason = parsePodcastNumber(item.ItunesSeason)
+		switch episodeType := strings.ToLower(strings.TrimSpace(item.ItunesEpisodeType)); episodeType {
+		case "full", "trailer", "bonus":
+			entry.PodcastEpisodeType = episodeType
+		}
+
+		// Fallback to the episode artwork if the entry doesn't have any thumbnail.
+		if entry.ThumbnailURL == "" && item.ItunesImage.Href != "" {
+			if absoluteImageURL, err := urllib.AbsoluteURL(feed.SiteURL, strings.TrimSpace(item.ItunesImage.Href)); err == nil {
+				entry.ThumbnailURL = absoluteImageURL
 			}
 		}
 
@@ -130,6 +196,7 @@ func (r *RSSAdapter) BuildFeed(baseURL string) *model.Feed {
 				entry.Tags = append(entry.Tags, tag)
 			}
 		}
+		entry.Tags = append(entry.Tags, item.Subjects()...)
 		if len(entry.Tags) == 0 {
 			for _, tag := range r.rss.Channel.Categories {
 				if tag != "" {
@@ -169,6 +236,40 @@ func findFeedAuthor(rssChannel *RSSChannel) string {
 	return sanitizer.StripTags(strings.TrimSpace(author))
 }
 
+func findFeedCategories(rssChannel *RSSChannel) []string {
+	var categories []string
+	for _, category := range slices.Concat(rssChannel.Categories, rssChannel.AtomCategories.CategoryNames(), rssChannel.GetItunesCategories()) {
+		if category = strings.TrimSpace(sanitizer.StripTags(category)); category != "" && !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+func findSkipHours(rssChannel *RSSChannel) []int {
+	var hours []int
+	for _, value := range rssChannel.SkipHours {
+		// Some feeds use 24 for midnight.
+		if hour, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && hour >= 0 && hour <= 24 {
+			hours = append(hours, hour%24)
+		}
+	}
+	return hours
+}
+
+func findSkipDays(rssChannel *RSSChannel) []time.Weekday {
+	var days []time.Weekday
+	for _, value := range rssChannel.SkipDays {
+		value = strings.ToLower(strings.TrimSpace(value))
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if value == strings.ToLower(weekday.String()) {
+				days = append(days, weekday)
+			}
+		}
+	}
+	return days
+}
+
 func findEntryTitle(rssItem *RSSItem) string {
 	title := rssItem.Title
 
@@ -258,7 +359,15 @@ func findEntryAuthor(rssItem *RSSItem) string {
 		author = rssItem.Author.Inner
 	}
 
-	return strings.TrimSpace(sanitizer.StripTags(author))
+	// The Dublin Core contributors are listed after the main author.
+	authors := rssItem.Contributors()
+	if author = strings.TrimSpace(sanitizer.StripTags(author)); author != "" {
+		authors = append([]string{author}, slices.DeleteFunc(authors, func(contributor string) bool {
+			return contributor == author
+		})...)
+	}
+
+	return strings.Join(authors, ", ")
 }
 
 func findEntryEnclosures(rssItem *RSSItem, siteURL string) model.EnclosureList {
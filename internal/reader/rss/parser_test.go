@@ -133,6 +133,31 @@ func TestParseFeedWithFeedURLWithTrailingSpace(t *testing.T) {
 	}
 }
 
+func TestParseFeedHubURL(t *testing.T) {
+	data := `<?xml version="1.0"?>
+		<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<atom:link href="https://example.org/rss" type="application/rss+xml" rel="self"></atom:link>
+			<atom:link href="https://hub.example.org/" rel="hub"></atom:link>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item</link>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+}
+
 func TestParseFeedWithRelativeFeedURL(t *testing.T) {
 	data := `<?xml version="1.0"?>
 		<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
@@ -1507,6 +1532,10 @@ func TestParseEntryWithMediaGroup(t *testing.T) {
 	if len(feed.Entries) != 1 {
 		t.Errorf("Incorrect number of entries, got: %d", len(feed.Entries))
 	}
+	if feed.Entries[0].ThumbnailURL != "https://example.org/image.jpg" {
+		t.Errorf("Incorrect thumbnail URL, got: %q", feed.Entries[0].ThumbnailURL)
+	}
+
 	if len(feed.Entries[0].Enclosures) != 6 {
 		t.Fatalf("Incorrect number of enclosures, got: %d", len(feed.Entries[0].Enclosures))
 	}
@@ -1539,6 +1568,31 @@ func TestParseEntryWithMediaGroup(t *testing.T) {
 	}
 }
 
+func TestParseEntryWithImageMediaContentAsThumbnail(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/">
+		<channel>
+		<title>My Example Feed</title>
+		<link>https://example.org</link>
+		<item>
+			<title>Example Item</title>
+			<link>http://www.example.org/entries/1</link>
+			<media:content url="https://example.org/video.mp4" type="video/mp4"></media:content>
+			<media:content url="/image.jpg" medium="image"></media:content>
+		</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].ThumbnailURL != "https://example.org/image.jpg" {
+		t.Errorf("Incorrect thumbnail URL, got: %q", feed.Entries[0].ThumbnailURL)
+	}
+}
+
 func TestParseEntryWithMediaContent(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 		<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/">
@@ -1680,6 +1734,59 @@ func TestParseItunesDuration(t *testing.T) {
 	}
 }
 
+func TestParseItunesEpisodeMetadata(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+		<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+		<channel>
+			<title>Podcast Example</title>
+			<link>http://www.example.com/index.html</link>
+			<item>
+				<title>Podcast Episode</title>
+				<guid>http://example.com/episode.m4a</guid>
+				<enclosure url="http://example.com/episode.m4a" length="1024" type="audio/x-m4a" />
+				<itunes:duration>3725</itunes:duration>
+				<itunes:episode> 12 </itunes:episode>
+				<itunes:season>2</itunes:season>
+				<itunes:episodeType>Bonus</itunes:episodeType>
+				<itunes:image href="/artwork/12.jpg" />
+			</item>
+			<item>
+				<title>Podcast Trailer</title>
+				<guid>http://example.com/trailer.m4a</guid>
+				<itunes:episode>invalid</itunes:episode>
+				<itunes:episodeType>unknown</itunes:episodeType>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := feed.Entries[0]
+	if entry.ReadingTime != 62 {
+		t.Errorf(`Unexpected podcast duration, got %d`, entry.ReadingTime)
+	}
+
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].Duration != 3725 {
+		t.Errorf(`Unexpected enclosure duration, got %v`, entry.Enclosures)
+	}
+
+	if entry.PodcastEpisode != 12 || entry.PodcastSeason != 2 || entry.PodcastEpisodeType != "bonus" {
+		t.Errorf(`Unexpected podcast episode, got %d, %d, %q`, entry.PodcastEpisode, entry.PodcastSeason, entry.PodcastEpisodeType)
+	}
+
+	if entry.ThumbnailURL != "http://www.example.com/artwork/12.jpg" {
+		t.Errorf(`Unexpected thumbnail URL, got %q`, entry.ThumbnailURL)
+	}
+
+	entry = feed.Entries[1]
+	if entry.PodcastEpisode != 0 || entry.PodcastSeason != 0 || entry.PodcastEpisodeType != "" {
+		t.Errorf(`Unexpected podcast episode, got %d, %d, %q`, entry.PodcastEpisode, entry.PodcastSeason, entry.PodcastEpisodeType)
+	}
+}
+
 func TestParseIncorrectItunesDuration(t *testing.T) {
 	data := `<?xml version="1.0" encoding="UTF-8"?>
 		<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
@@ -1869,6 +1976,41 @@ func TestParseFeedWithCategories(t *testing.T) {
 	}
 }
 
+func TestParseFeedWithChannelCategories(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss xmlns:a10="http://www.w3.org/2005/Atom" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<category> Technology </category>
+			<a10:category term="science" label="Science" />
+			<a10:category term="Technology" />
+			<itunes:category text="News" />
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item</link>
+				<category>Item category</category>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"Technology", "Science", "News"}
+	if len(feed.Categories) != len(expected) {
+		t.Fatalf("Incorrect feed categories, got: %v", feed.Categories)
+	}
+
+	for i, category := range feed.Categories {
+		if category != expected[i] {
+			t.Errorf("Incorrect feed category, got: %q", category)
+		}
+	}
+}
+
 func TestParseEntryWithCategories(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 		<rss xmlns:atom="http://www.w3.org/2005/Atom" version="2.0">
@@ -2058,3 +2200,203 @@ func TestParseFeedWithIncorrectTTLValue(t *testing.T) {
 		t.Errorf("Incorrect TTL, got: %d", feed.TTL)
 	}
 }
+
+func TestParseFeedWithSyndicationUpdatePeriod(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:sy="http://purl.org/rss/1.0/modules/syndication/">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<ttl>60</ttl>
+			<sy:updatePeriod>daily</sy:updatePeriod>
+			<sy:updateFrequency>4</sy:updateFrequency>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item</link>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The longest interval is used.
+	if feed.TTL != 360 {
+		t.Errorf("Incorrect TTL, got: %d", feed.TTL)
+	}
+}
+
+func TestParseFeedWithSkipHoursAndSkipDays(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<skipHours>
+				<hour>0</hour>
+				<hour> 23 </hour>
+				<hour>24</hour>
+				<hour>invalid</hour>
+			</skipHours>
+			<skipDays>
+				<day>Saturday</day>
+				<day>sunday</day>
+				<day>Someday</day>
+			</skipDays>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item</link>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.SkipHours) != 3 || feed.SkipHours[0] != 0 || feed.SkipHours[1] != 23 || feed.SkipHours[2] != 0 {
+		t.Errorf("Incorrect skip hours, got: %v", feed.SkipHours)
+	}
+
+	if len(feed.SkipDays) != 2 || feed.SkipDays[0] != time.Saturday || feed.SkipDays[1] != time.Sunday {
+		t.Errorf("Incorrect skip days, got: %v", feed.SkipDays)
+	}
+}
+
+func TestParseEntryWithDublinCoreMetadata(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+		<channel>
+		<title>Example</title>
+		<link>https://example.org/</link>
+		<item>
+			<title>Test</title>
+			<link>https://example.org/item</link>
+			<dc:creator>Jane Doe</dc:creator>
+			<dc:contributor>John Doe</dc:contributor>
+			<dc:subject>Science</dc:subject>
+			<dc:subject>Physics</dc:subject>
+			<dc:identifier>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a</dc:identifier>
+		</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Author != "Jane Doe, John Doe" {
+		t.Errorf("Incorrect entry author, got: %q", feed.Entries[0].Author)
+	}
+
+	if len(feed.Entries[0].Tags) != 2 || feed.Entries[0].Tags[0] != "Science" || feed.Entries[0].Tags[1] != "Physics" {
+		t.Errorf("Incorrect entry tags, got: %v", feed.Entries[0].Tags)
+	}
+
+	if feed.Entries[0].Hash != "3841e5cf232f5111fc5841e9eba5f4b26d95e7d7124902e0f7272729d65601a6" {
+		t.Errorf("Incorrect entry hash, got: %s", feed.Entries[0].Hash)
+	}
+}
+
+func TestParseEntryWithDublinCoreContributorOnly(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+		<channel>
+		<title>Example</title>
+		<link>https://example.org/</link>
+		<item>
+			<title>Test</title>
+			<link>https://example.org/item</link>
+			<author>jane@example.org (Jane Doe)</author>
+			<dc:contributor>John Doe</dc:contributor>
+		</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Author != "jane@example.org (Jane Doe), John Doe" {
+		t.Errorf("Incorrect entry author, got: %q", feed.Entries[0].Author)
+	}
+
+	if feed.Entries[0].Hash != "442bc9458cd0bf1bdfa2b5e98e84d2ccd400445c2d90a8cda786bc776ced4219" {
+		t.Errorf("Incorrect entry hash, got: %s", feed.Entries[0].Hash)
+	}
+}
+
+func TestParseEntryWithGeoRSSPoint(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:georss="http://www.georss.org/georss">
+		<channel>
+		<title>Example</title>
+		<link>https://example.org/</link>
+		<item>
+			<title>Test</title>
+			<link>https://example.org/item</link>
+			<georss:point>45.256 -71.92</georss:point>
+		</item>
+		<item>
+			<title>Test</title>
+			<link>https://example.org/item2</link>
+		</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Latitude == nil || *feed.Entries[0].Latitude != 45.256 {
+		t.Errorf("Incorrect entry latitude, got: %v", feed.Entries[0].Latitude)
+	}
+
+	if feed.Entries[0].Longitude == nil || *feed.Entries[0].Longitude != -71.92 {
+		t.Errorf("Incorrect entry longitude, got: %v", feed.Entries[0].Longitude)
+	}
+
+	if feed.Entries[1].Latitude != nil || feed.Entries[1].Longitude != nil {
+		t.Errorf("The entry should not have any location")
+	}
+}
+
+func TestParseEntryWithCommentsFeedURL(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:wfw="http://wellformedweb.org/CommentAPI/" xmlns:atom="http://www.w3.org/2005/Atom">
+		<channel>
+		<title>Example</title>
+		<link>https://example.org/</link>
+		<item>
+			<title>Test</title>
+			<link>https://example.org/item</link>
+			<wfw:commentRss>https://example.org/item/feed/</wfw:commentRss>
+		</item>
+		<item>
+			<title>Test</title>
+			<link>https://example.org/item2</link>
+			<atom:link rel="replies" type="application/atom+xml" href="/item2/comments.atom"/>
+			<wfw:commentRss>https://example.org/item2/feed/</wfw:commentRss>
+		</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].CommentsFeedURL != "https://example.org/item/feed/" {
+		t.Errorf("Incorrect entry comments feed URL, got: %q", feed.Entries[0].CommentsFeedURL)
+	}
+
+	if feed.Entries[1].CommentsFeedURL != "https://example.org/item2/comments.atom" {
+		t.Errorf("Incorrect entry comments feed URL, got: %q", feed.Entries[1].CommentsFeedURL)
+	}
+}
@@ -12,10 +12,10 @@ import (
 
 var ErrInvalidDurationFormat = errors.New("rss: invalid duration format")
 
-func getDurationInMinutes(rawDuration string) (int, error) {
+func getDurationInSeconds(rawDuration string) (int, error) {
 	var sumSeconds int
 
-	durationParts := strings.Split(rawDuration, ":")
+	durationParts := strings.Split(strings.TrimSpace(rawDuration), ":")
 	if len(durationParts) > 3 {
 		return 0, ErrInvalidDurationFormat
 	}
@@ -29,5 +29,13 @@ func getDurationInMinutes(rawDuration string) (int, error) {
 		sumSeconds += int(math.Pow(60, float64(len(durationParts)-i-1))) * durationPartValue
 	}
 
-	return sumSeconds / 60, nil
+	return sumSeconds, nil
+}
+
+// parsePodcastNumber returns the episode or season number, zero when the number is missing or invalid.
+func parsePodcastNumber(rawNumber string) int {
+	if number, err := strconv.Atoi(strings.TrimSpace(rawNumber)); err == nil && number > 0 {
+		return number
+	}
+	return 0
 }
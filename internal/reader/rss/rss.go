@@ -8,10 +8,13 @@ import (
 	"strconv"
 	"strings"
 
+	"miniflux.app/v2/internal/reader/atom"
 	"miniflux.app/v2/internal/reader/dublincore"
+	"miniflux.app/v2/internal/reader/geo"
 	"miniflux.app/v2/internal/reader/googleplay"
 	"miniflux.app/v2/internal/reader/itunes"
 	"miniflux.app/v2/internal/reader/media"
+	"miniflux.app/v2/internal/reader/syndication"
 )
 
 // Specs: https://www.rssboard.org/rss-specification
@@ -57,6 +60,9 @@ type RSSChannel struct {
 	// Categories is a collection of categories to which the channel belongs.
 	Categories []string `xml:"rss category"`
 
+	// AtomCategories are the Atom categories of the channel, they are used by the .NET syndication library.
+	AtomCategories atom.AtomCategories `xml:"http://www.w3.org/2005/Atom category"`
+
 	// Generator is a string indicating the program used to generate the channel.
 	Generator string `xml:"rss generator"`
 
@@ -88,6 +94,7 @@ type RSSChannel struct {
 	AtomLinks
 	itunes.ItunesChannelElement
 	googleplay.GooglePlayChannelElement
+	syndication.SyndicationChannelElement
 }
 
 type RSSCloud struct {
@@ -130,6 +137,10 @@ type RSSItem struct {
 	// If present, it contains the URL of the comments page for the item.
 	CommentsURL string `xml:"rss comments"`
 
+	// <wfw:commentRss> contains the URL of the RSS feed of the comments for the item.
+	// Specs: https://www.rssboard.org/comment-api
+	CommentsFeedURL string `xml:"http://wellformedweb.org/CommentAPI/ commentRss"`
+
 	// <enclosure> is an optional sub-element of <item>.
 	// It has three required attributes. url says where the enclosure is located,
 	// length says how big it is in bytes, and type says what its type is, a standard MIME type.
@@ -161,6 +172,7 @@ type RSSItem struct {
 	Source RSSSource `xml:"rss source"`
 
 	dublincore.DublinCoreItemElement
+	geo.GeoItemElement
 	FeedBurnerItemElement
 	media.MediaItemElement
 	AtomAuthor
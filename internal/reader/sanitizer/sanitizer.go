@@ -6,12 +6,15 @@ package sanitizer // import "miniflux.app/v2/internal/reader/sanitizer"
 import (
 	"fmt"
 	"io"
+	"net/url"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/footnote"
+	"miniflux.app/v2/internal/reader/highlight"
 	"miniflux.app/v2/internal/reader/urlcleaner"
 	"miniflux.app/v2/internal/urllib"
 
@@ -24,6 +27,7 @@ var (
 		"a":          {"href", "title", "id"},
 		"abbr":       {"title"},
 		"acronym":    {"title"},
+		"annotation": {"encoding"},
 		"aside":      {},
 		"audio":      {"src"},
 		"blockquote": {},
@@ -50,6 +54,26 @@ var (
 		"ins":        {},
 		"kbd":        {},
 		"li":         {"id"},
+		"math":       {"display"},
+		"mfrac":      {"linethickness"},
+		"mi":         {"mathvariant"},
+		"mn":         {},
+		"mo":         {"fence", "stretchy", "minsize", "maxsize"},
+		"mover":      {"accent"},
+		"mphantom":   {},
+		"mroot":      {},
+		"mrow":       {},
+		"mspace":     {"width"},
+		"msqrt":      {},
+		"msub":       {},
+		"msubsup":    {},
+		"msup":       {},
+		"mtable":     {"columnalign"},
+		"mtd":        {},
+		"mtext":      {},
+		"mtr":        {},
+		"munder":     {"accentunder"},
+		"munderover": {},
 		"ol":         {"id"},
 		"p":          {},
 		"picture":    {},
@@ -61,7 +85,9 @@ var (
 		"ruby":       {},
 		"s":          {},
 		"samp":       {},
+		"semantics":  {},
 		"source":     {"src", "type", "srcset", "sizes", "media"},
+		"span":       {"class"},
 		"strong":     {},
 		"sub":        {},
 		"sup":        {"id"},
@@ -81,11 +107,21 @@ var (
 
 // Sanitize returns safe HTML.
 func Sanitize(baseURL, input string) string {
+	return SanitizeWithIframeDomains(baseURL, input, nil)
+}
+
+// SanitizeWithIframeDomains returns safe HTML, the iframes of the given domains and of their subdomains are kept
+// in addition to the built-in and the instance allowlists.
+func SanitizeWithIframeDomains(baseURL, input string, iframeDomains []string) string {
 	var buffer strings.Builder
 	var tagStack []string
 	var parentTag string
 	var blockedStack []string
 
+	// The span elements are only kept for the highlighted code tokens and the inline footnotes,
+	// their end tags are kept accordingly.
+	var spanStack []bool
+
 	tokenizer := html.NewTokenizer(strings.NewReader(input))
 	for {
 		if tokenizer.Next() == html.ErrorToken {
@@ -112,7 +148,7 @@ func Sanitize(baseURL, input string) string {
 
 			buffer.WriteString(html.EscapeString(token.Data))
 		case html.StartTagToken:
-			tagName := token.DataAtom.String()
+			tagName := tokenTagName(token)
 			parentTag = tagName
 
 			if isPixelTracker(tagName, token.Attr) {
@@ -122,9 +158,14 @@ func Sanitize(baseURL, input string) string {
 			if isBlockedTag(tagName) || slices.ContainsFunc(token.Attr, func(attr html.Attribute) bool { return attr.Key == "hidden" }) {
 				blockedStack = append(blockedStack, tagName)
 			} else if len(blockedStack) == 0 && isValidTag(tagName) {
-				attrNames, htmlAttributes := sanitizeAttributes(baseURL, tagName, token.Attr)
+				attrNames, htmlAttributes := sanitizeAttributes(baseURL, tagName, token.Attr, iframeDomains)
+				isKept := hasRequiredAttributes(tagName, attrNames)
 
-				if hasRequiredAttributes(tagName, attrNames) {
+				if tagName == "span" {
+					spanStack = append(spanStack, isKept)
+				}
+
+				if isKept {
 					if len(attrNames) > 0 {
 						buffer.WriteString("<" + tagName + " " + htmlAttributes + ">")
 					} else {
@@ -135,19 +176,26 @@ func Sanitize(baseURL, input string) string {
 				}
 			}
 		case html.EndTagToken:
-			tagName := token.DataAtom.String()
+			tagName := tokenTagName(token)
 			if len(blockedStack) > 0 && blockedStack[len(blockedStack)-1] == tagName {
 				blockedStack = blockedStack[:len(blockedStack)-1]
+			} else if tagName == "span" && len(blockedStack) == 0 {
+				if len(spanStack) > 0 {
+					if spanStack[len(spanStack)-1] {
+						buffer.WriteString("</span>")
+					}
+					spanStack = spanStack[:len(spanStack)-1]
+				}
 			} else if len(blockedStack) == 0 && isValidTag(tagName) && slices.Contains(tagStack, tagName) {
 				buffer.WriteString("</" + tagName + ">")
 			}
 		case html.SelfClosingTagToken:
-			tagName := token.DataAtom.String()
+			tagName := tokenTagName(token)
 			if isPixelTracker(tagName, token.Attr) {
 				continue
 			}
 			if isValidTag(tagName) && len(blockedStack) == 0 {
-				attrNames, htmlAttributes := sanitizeAttributes(baseURL, tagName, token.Attr)
+				attrNames, htmlAttributes := sanitizeAttributes(baseURL, tagName, token.Attr, iframeDomains)
 				if hasRequiredAttributes(tagName, attrNames) {
 					if len(attrNames) > 0 {
 						buffer.WriteString("<" + tagName + " " + htmlAttributes + "/>")
@@ -160,7 +208,15 @@ func Sanitize(baseURL, input string) string {
 	}
 }
 
-func sanitizeAttributes(baseURL, tagName string, attributes []html.Attribute) ([]string, string) {
+// tokenTagName returns the name of the element, most MathML elements are not known by the HTML tokenizer.
+func tokenTagName(token html.Token) string {
+	if token.DataAtom != 0 {
+		return token.DataAtom.String()
+	}
+	return token.Data
+}
+
+func sanitizeAttributes(baseURL, tagName string, attributes []html.Attribute, iframeDomains []string) ([]string, string) {
 	var htmlAttrs, attrNames []string
 	var err error
 	var isImageLargerThanLayout bool
@@ -178,6 +234,12 @@ func sanitizeAttributes(baseURL, tagName string, attributes []html.Attribute) ([
 			continue
 		}
 
+		// Only the classes of the highlighted code tokens and of the inline footnotes are kept,
+		// the styles of the website are not available.
+		if attribute.Key == "class" && !highlight.IsHighlightClass(value) && !footnote.IsFootnoteClass(value) {
+			continue
+		}
+
 		if (tagName == "img" || tagName == "source") && attribute.Key == "srcset" {
 			value = sanitizeSrcsetAttr(baseURL, value)
 		}
@@ -195,7 +257,7 @@ func sanitizeAttributes(baseURL, tagName string, attributes []html.Attribute) ([
 		if isExternalResourceAttribute(attribute.Key) {
 			switch {
 			case tagName == "iframe":
-				if !isValidIframeSource(baseURL, attribute.Val) {
+				if !isValidIframeSource(baseURL, attribute.Val, iframeDomains) {
 					continue
 				}
 				value = rewriteIframeURL(attribute.Val)
@@ -299,6 +361,7 @@ func hasRequiredAttributes(tagName string, attributes []string) bool {
 		"iframe": {"src"},
 		"img":    {"src"},
 		"source": {"src", "srcset"},
+		"span":   {"class"},
 	}
 
 	if attrs, ok := elements[tagName]; ok {
@@ -379,7 +442,7 @@ func isBlockedResource(src string) bool {
 	})
 }
 
-func isValidIframeSource(baseURL, src string) bool {
+func isValidIframeSource(baseURL, src string, iframeDomains []string) bool {
 	whitelist := []string{
 		"bandcamp.com",
 		"cdn.embedly.com",
@@ -405,7 +468,33 @@ func isValidIframeSource(baseURL, src string) bool {
 		return true
 	}
 
-	return slices.Contains(whitelist, strings.TrimPrefix(domain, "www."))
+	if slices.Contains(whitelist, strings.TrimPrefix(domain, "www.")) {
+		return true
+	}
+
+	// allow iframe from the domains configured by the administrator and by the user
+	if config.Opts != nil && isIframeDomainAllowed(src, config.Opts.IframeAllowedDomains()) {
+		return true
+	}
+
+	return isIframeDomainAllowed(src, iframeDomains)
+}
+
+func isIframeDomainAllowed(src string, allowedDomains []string) bool {
+	if len(allowedDomains) == 0 {
+		return false
+	}
+
+	parsedURL, err := url.Parse(src)
+	if err != nil {
+		return false
+	}
+
+	hostname := strings.ToLower(parsedURL.Hostname())
+	return hostname != "" && slices.ContainsFunc(allowedDomains, func(domain string) bool {
+		domain = strings.ToLower(domain)
+		return hostname == domain || strings.HasSuffix(hostname, "."+domain)
+	})
 }
 
 func rewriteIframeURL(link string) string {
@@ -232,6 +232,86 @@ func TestIFrameWithChildElements(t *testing.T) {
 	}
 }
 
+func TestIFrameFromUserAllowedDomain(t *testing.T) {
+	input := `<iframe src="https://video.peertube.example.org/videos/embed/123"></iframe>`
+	expected := `<iframe src="https://video.peertube.example.org/videos/embed/123" sandbox="allow-scripts allow-same-origin allow-popups allow-popups-to-escape-sandbox" loading="lazy"></iframe>`
+	output := SanitizeWithIframeDomains("http://example.com/", input, []string{"peertube.example.org"})
+
+	if expected != output {
+		t.Errorf(`Wrong output: "%s" != "%s"`, expected, output)
+	}
+}
+
+func TestIFrameFromSimilarDomainIsRemoved(t *testing.T) {
+	input := `<iframe src="https://notpeertube.example.org/videos/embed/123"></iframe>`
+	expected := ``
+	output := SanitizeWithIframeDomains("http://example.com/", input, []string{"peertube.example.org"})
+
+	if expected != output {
+		t.Errorf(`Wrong output: "%s" != "%s"`, expected, output)
+	}
+}
+
+func TestIFrameFromInstanceAllowedDomain(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("IFRAME_ALLOWED_DOMAINS", "bandcamp.com")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+	defer func() { config.Opts = config.NewOptions() }()
+
+	input := `<iframe src="https://bandcamp.com/EmbeddedPlayer/album=1/"></iframe>`
+	expected := `<iframe src="https://bandcamp.com/EmbeddedPlayer/album=1/" sandbox="allow-scripts allow-same-origin allow-popups allow-popups-to-escape-sandbox" loading="lazy"></iframe>`
+	output := Sanitize("http://example.com/", input)
+
+	if expected != output {
+		t.Errorf(`Wrong output: "%s" != "%s"`, expected, output)
+	}
+}
+
+func TestMathML(t *testing.T) {
+	input := `<math display="block"><semantics><mrow><msup><mi mathvariant="normal">x</mi><mn>2</mn></msup><mo stretchy="false">+</mo><mspace width="1em"/></mrow><annotation encoding="application/x-tex">x^2+</annotation></semantics></math>`
+	output := Sanitize("http://example.org/", input)
+
+	if input != output {
+		t.Errorf(`Wrong output: "%s" != "%s"`, input, output)
+	}
+}
+
+func TestMathMLWithInvalidAttributes(t *testing.T) {
+	input := `<math href="javascript:alert(1)"><mi onclick="alert(1)">x</mi><maction actiontype="statusline"><mi>y</mi></maction></math>`
+	expected := `<math><mi>x</mi><mi>y</mi></math>`
+	output := Sanitize("http://example.org/", input)
+
+	if expected != output {
+		t.Errorf(`Wrong output: "%s" != "%s"`, expected, output)
+	}
+}
+
+func TestHighlightedCode(t *testing.T) {
+	input := `<pre><code class="language-go"><span class="hl-keyword">func</span> <span class="title" style="color: red">main</span>()</code></pre>`
+	expected := `<pre><code><span class="hl-keyword">func</span> main()</code></pre>`
+	output := Sanitize("http://example.org/", input)
+
+	if expected != output {
+		t.Errorf(`Wrong output: "%s" != "%s"`, expected, output)
+	}
+}
+
+func TestInlineFootnote(t *testing.T) {
+	input := `<p>Text<span class="footnote"><sup id="fnref1"><a href="#fn1" class="footnote-ref">1</a></sup><span class="footnote-content">The <em>note</em>.</span></span></p>`
+	expected := `<p>Text<span class="footnote"><sup id="fnref1"><a href="#fn1">1</a></sup><span class="footnote-content">The <em>note</em>.</span></span></p>`
+	output := Sanitize("http://example.org/", input)
+
+	if expected != output {
+		t.Errorf(`Wrong output: "%s" != "%s"`, expected, output)
+	}
+}
+
 func TestAnchorLink(t *testing.T) {
 	input := `<p>This link is <a href="#some-anchor">an anchor</a></p>`
 	expected := `<p>This link is <a href="#some-anchor">an anchor</a></p>`
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scraper // import "miniflux.app/v2/internal/reader/scraper"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+)
+
+// The scraper rules are a CSS selector, or a list of functions separated by semicolons:
+//
+//	remove(".ads, .share"); attribute("img", "data-src", "src"); select("article .content") || select("main")
+//
+// The functions are applied in order to the page:
+//   - select(selector) extracts the matching elements, the elements of several select() are concatenated.
+//   - remove(selector) deletes the matching elements, for example the advertisements.
+//   - attribute(selector, source, destination) copies an attribute of the matching elements, for example the lazy-loaded images.
+//
+// The alternatives separated by "||" are ordered fallbacks, the first one extracting some content is used.
+// An alternative can also be a CSS selector, "article .content || main" is the same as select("article .content") || select("main").
+
+type scraperRuleStep struct {
+	function  string
+	arguments []string
+}
+
+// scraperRule is one of the alternatives of the scraper rules.
+type scraperRule []scraperRuleStep
+
+var scraperRuleFunctions = map[string]int{
+	"select":    1,
+	"remove":    1,
+	"attribute": 3,
+}
+
+// ValidateRules returns an error if the scraper rules cannot be parsed.
+func ValidateRules(rules string) error {
+	_, err := parseRules(rules)
+	return err
+}
+
+func parseRules(rules string) ([]scraperRule, error) {
+	var alternatives []scraperRule
+	for _, alternative := range splitRules(rules, "||") {
+		rule, err := parseRule(strings.TrimSpace(alternative))
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, rule)
+	}
+	return alternatives, nil
+}
+
+func parseRule(alternative string) (scraperRule, error) {
+	if alternative == "" {
+		return nil, errors.New("scraper: empty rule")
+	}
+
+	steps := splitRules(alternative, ";")
+	if len(steps) == 1 && !isScraperRuleFunction(alternative) {
+		if _, err := cascadia.Compile(alternative); err != nil {
+			return nil, fmt.Errorf("scraper: invalid CSS selector %q: %v", alternative, err)
+		}
+		return scraperRule{{function: "select", arguments: []string{alternative}}}, nil
+	}
+
+	var rule scraperRule
+	hasSelect := false
+	for _, step := range steps {
+		parsedStep, err := parseRuleStep(strings.TrimSpace(step))
+		if err != nil {
+			return nil, err
+		}
+		hasSelect = hasSelect || parsedStep.function == "select"
+		rule = append(rule, parsedStep)
+	}
+
+	if !hasSelect {
+		return nil, fmt.Errorf("scraper: the rule %q doesn't select any content", alternative)
+	}
+
+	return rule, nil
+}
+
+func isScraperRuleFunction(step string) bool {
+	name, _, found := strings.Cut(step, "(")
+	if !found {
+		return false
+	}
+	_, exists := scraperRuleFunctions[strings.TrimSpace(name)]
+	return exists
+}
+
+func parseRuleStep(step string) (scraperRuleStep, error) {
+	name, arguments, found := strings.Cut(step, "(")
+	name = strings.TrimSpace(name)
+	argumentCount, exists := scraperRuleFunctions[name]
+	if !found || !exists {
+		return scraperRuleStep{}, fmt.Errorf("scraper: unknown function in %q", step)
+	}
+
+	arguments, found = strings.CutSuffix(strings.TrimSpace(arguments), ")")
+	if !found {
+		return scraperRuleStep{}, fmt.Errorf("scraper: missing closing parenthesis in %q", step)
+	}
+
+	values, err := parseRuleArguments(arguments)
+	if err != nil {
+		return scraperRuleStep{}, fmt.Errorf("scraper: %v in %q", err, step)
+	}
+
+	if len(values) != argumentCount {
+		return scraperRuleStep{}, fmt.Errorf("scraper: %s() expects %d arguments in %q", name, argumentCount, step)
+	}
+
+	if _, err := cascadia.Compile(values[0]); err != nil {
+		return scraperRuleStep{}, fmt.Errorf("scraper: invalid CSS selector %q: %v", values[0], err)
+	}
+
+	for _, value := range values[1:] {
+		if strings.TrimSpace(value) == "" {
+			return scraperRuleStep{}, fmt.Errorf("scraper: empty attribute name in %q", step)
+		}
+	}
+
+	return scraperRuleStep{function: name, arguments: values}, nil
+}
+
+// parseRuleArguments parses a list of double-quoted strings separated by commas, the quotes can be escaped with a backslash.
+func parseRuleArguments(input string) ([]string, error) {
+	var values []string
+	for {
+		input = strings.TrimSpace(input)
+		if !strings.HasPrefix(input, `"`) {
+			return nil, errors.New("the arguments must be double-quoted strings")
+		}
+
+		var value strings.Builder
+		closed := false
+		i := 1
+		for ; i < len(input); i++ {
+			if input[i] == '\\' && i+1 < len(input) {
+				i++
+				value.WriteByte(input[i])
+				continue
+			}
+			if input[i] == '"' {
+				closed = true
+				break
+			}
+			value.WriteByte(input[i])
+		}
+
+		if !closed {
+			return nil, errors.New("unterminated string")
+		}
+
+		values = append(values, value.String())
+		input = strings.TrimSpace(input[i+1:])
+		if input == "" {
+			return values, nil
+		}
+
+		var found bool
+		if input, found = strings.CutPrefix(input, ","); !found {
+			return nil, errors.New("the arguments must be separated by commas")
+		}
+	}
+}
+
+// splitRules splits the rules on the separator, except inside the quoted strings of the selectors and the arguments.
+func splitRules(rules, separator string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(rules); i++ {
+		switch {
+		case quote != 0 && rules[i] == '\\':
+			i++
+		case quote != 0:
+			if rules[i] == quote {
+				quote = 0
+			}
+		case rules[i] == '"' || rules[i] == '\'':
+			quote = rules[i]
+		case strings.HasPrefix(rules[i:], separator):
+			parts = append(parts, rules[start:i])
+			i += len(separator) - 1
+			start = i + 1
+		}
+	}
+	return append(parts, rules[start:])
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scraper // import "miniflux.app/v2/internal/reader/scraper"
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	var scenarios = []struct {
+		rules    string
+		expected []scraperRule
+	}{
+		{
+			`article .content, .author`,
+			[]scraperRule{{{"select", []string{"article .content, .author"}}}},
+		},
+		{
+			`article .content || main`,
+			[]scraperRule{{{"select", []string{"article .content"}}}, {{"select", []string{"main"}}}},
+		},
+		{
+			`a[title="a || b; c"]`,
+			[]scraperRule{{{"select", []string{`a[title="a || b; c"]`}}}},
+		},
+		{
+			`remove(".ads, .share"); attribute("img", "data-src", "src"); select("article")`,
+			[]scraperRule{{
+				{"remove", []string{".ads, .share"}},
+				{"attribute", []string{"img", "data-src", "src"}},
+				{"select", []string{"article"}},
+			}},
+		},
+		{
+			`select("a[title=\"x\"]") || select ( "main" )`,
+			[]scraperRule{{{"select", []string{`a[title="x"]`}}}, {{"select", []string{"main"}}}},
+		},
+	}
+
+	for _, tc := range scenarios {
+		result, err := parseRules(tc.rules)
+		if err != nil {
+			t.Errorf(`Parsing %q should not return any error: %v`, tc.rules, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf(`Unexpected rules for %q, got %v`, tc.rules, result)
+		}
+	}
+}
+
+func TestParseInvalidRules(t *testing.T) {
+	var scenarios = []string{
+		`article[`,
+		`article ||`,
+		`select("article"`,
+		`select(article)`,
+		`select("article", "main")`,
+		`attribute("img", "data-src")`,
+		`attribute("img", "data-src", " ")`,
+		`remove(".ads")`,
+		`remove(".ads"); main`,
+		`hide(".ads"); select("main")`,
+		`select("main[")`,
+		`select("main" "article")`,
+	}
+
+	for _, rules := range scenarios {
+		if err := ValidateRules(rules); err == nil {
+			t.Errorf(`Parsing %q should return an error`, rules)
+		}
+	}
+}
+
+func TestPredefinedRulesAreValid(t *testing.T) {
+	for domain, rules := range predefinedRules {
+		if err := ValidateRules(rules); err != nil {
+			t.Errorf(`The predefined rules of %q are not valid: %v`, domain, err)
+		}
+	}
+}
+
+func TestFindContentWithRuleFunctions(t *testing.T) {
+	html := `<html><body>
+		<article>
+			<p>Text</p>
+			<img data-src="image.jpg" src="placeholder.gif">
+			<div class="ads">Advertisement</div>
+		</article>
+		<aside class="author">Author</aside>
+	</body></html>`
+
+	_, content, err := findContentUsingCustomRules(strings.NewReader(html), `remove(".ads"); attribute("img", "data-src", "src"); select("article"); select(".author")`)
+	if err != nil {
+		t.Fatalf(`Scraping error: %v`, err)
+	}
+
+	if strings.Contains(content, "Advertisement") {
+		t.Errorf(`The removed elements should not be extracted, got %q`, content)
+	}
+
+	if !strings.Contains(content, `src="image.jpg"`) {
+		t.Errorf(`The attribute should be copied, got %q`, content)
+	}
+
+	if !strings.HasSuffix(content, `<aside class="author">Author</aside>`) {
+		t.Errorf(`The selected elements should be concatenated in order, got %q`, content)
+	}
+}
+
+func TestFindContentWithFallbackRules(t *testing.T) {
+	html := `<html><body><main><p>Main content</p><div class="ads">Advertisement</div></main></body></html>`
+
+	_, content, err := findContentUsingCustomRules(strings.NewReader(html), `remove("main .ads"); select("article") || select("main")`)
+	if err != nil {
+		t.Fatalf(`Scraping error: %v`, err)
+	}
+
+	expected := `<main><p>Main content</p><div class="ads">Advertisement</div></main>`
+	if content != expected {
+		t.Errorf(`The fallback should start again from the original page, got %q instead of %q`, content, expected)
+	}
+}
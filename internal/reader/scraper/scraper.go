@@ -4,31 +4,123 @@
 package scraper // import "miniflux.app/v2/internal/reader/scraper"
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"log/slog"
+	"net/url"
+	"path"
+	"strconv"
 	"strings"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/readability"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/reader/siterules"
 	"miniflux.app/v2/internal/urllib"
 
 	"github.com/PuerkitoBio/goquery"
 	"golang.org/x/net/html/charset"
 )
 
-func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules string) (baseURL string, extractedContent string, err error) {
+// maxArticlePages is the maximum number of pages stitched together when an article is split into several pages.
+const maxArticlePages = 10
+
+// ScrapeWebsite downloads the page and extracts its content with the custom rules, or with the extractor when no rule applies.
+func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules string, extractor readability.Extractor) (baseURL string, extractedContent string, err error) {
+	firstPage, err := scrapePage(requestBuilder, pageURL, rules, extractor)
+	if err != nil {
+		return "", "", err
+	}
+
+	// The next pages of the article are appended to the content of the first page.
+	articleURL := firstPage.url
+	extractedContent = firstPage.content
+	visitedPages := map[string]bool{pageURL: true, articleURL: true}
+	nextPageURL := findNextPageURL(bytes.NewReader(firstPage.document), articleURL, articleURL, 1)
+	for page := 2; nextPageURL != "" && !visitedPages[nextPageURL] && page <= maxArticlePages; page++ {
+		visitedPages[nextPageURL] = true
+
+		slog.Debug("Scraping the next page of the article",
+			slog.String("website_url", articleURL),
+			slog.String("next_page_url", nextPageURL),
+			slog.Int("page", page),
+		)
+
+		nextPage, pageErr := scrapePage(requestBuilder, nextPageURL, rules, extractor)
+		if pageErr != nil {
+			slog.Warn("Unable to scrape the next page of the article",
+				slog.String("website_url", articleURL),
+				slog.String("next_page_url", nextPageURL),
+				slog.Any("error", pageErr),
+			)
+			break
+		}
+
+		// The relative URLs of the next pages are not relative to the first page, the whole content is sanitized by the caller.
+		extractedContent += absoluteContentURLs(nextPage.baseURL, nextPage.content)
+		visitedPages[nextPage.url] = true
+		nextPageURL = findNextPageURL(bytes.NewReader(nextPage.document), articleURL, nextPage.url, page)
+	}
+
+	return firstPage.baseURL, extractedContent, nil
+}
+
+// absoluteContentURLs resolves the URLs of the content against the base URL of its page, the content is returned unchanged if it can't be parsed.
+func absoluteContentURLs(baseURL, content string) string {
+	document, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return content
+	}
+
+	document.Find("[href], [src], [poster], [cite], [srcset]").Each(func(i int, element *goquery.Selection) {
+		for _, attribute := range []string{"href", "src", "poster", "cite"} {
+			if value, found := element.Attr(attribute); found {
+				if absoluteURL, err := urllib.AbsoluteURL(baseURL, value); err == nil {
+					element.SetAttr(attribute, absoluteURL)
+				}
+			}
+		}
+
+		if value, found := element.Attr("srcset"); found {
+			imageCandidates := sanitizer.ParseSrcSetAttribute(value)
+			for _, imageCandidate := range imageCandidates {
+				if absoluteURL, err := urllib.AbsoluteURL(baseURL, imageCandidate.ImageURL); err == nil {
+					imageCandidate.ImageURL = absoluteURL
+				}
+			}
+			element.SetAttr("srcset", imageCandidates.String())
+		}
+	})
+
+	output, err := document.Find("body").Html()
+	if err != nil {
+		return content
+	}
+
+	return output
+}
+
+type scrapedPage struct {
+	url      string
+	baseURL  string
+	content  string
+	document []byte
+}
+
+// scrapePage downloads a single page and extracts its content, the HTML document is kept to find the next page of the article.
+func scrapePage(requestBuilder *fetcher.RequestBuilder, pageURL, rules string, extractor readability.Extractor) (*scrapedPage, error) {
 	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(pageURL))
 	defer responseHandler.Close()
 
 	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
 		slog.Warn("Unable to scrape website", slog.String("website_url", pageURL), slog.Any("error", localizedError.Error()))
-		return "", "", localizedError.Error()
+		return nil, localizedError.Error()
 	}
 
 	if !isAllowedContentType(responseHandler.ContentType()) {
-		return "", "", fmt.Errorf("scraper: this resource is not a HTML document (%s)", responseHandler.ContentType())
+		return nil, fmt.Errorf("scraper: this resource is not a HTML document (%s)", responseHandler.ContentType())
 	}
 
 	// The entry URL could redirect somewhere else.
@@ -44,20 +136,26 @@ func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules string
 		responseHandler.ContentType(),
 	)
 	if err != nil {
-		return "", "", fmt.Errorf("scraper: unable to read HTML document with charset reader: %v", err)
+		return nil, fmt.Errorf("scraper: unable to read HTML document with charset reader: %v", err)
 	}
 
+	htmlDocument, err := io.ReadAll(htmlDocumentReader)
+	if err != nil {
+		return nil, fmt.Errorf("scraper: unable to read HTML document: %v", err)
+	}
+
+	var baseURL, extractedContent string
 	if sameSite && rules != "" {
 		slog.Debug("Extracting content with custom rules",
 			"url", pageURL,
 			"rules", rules,
 		)
-		baseURL, extractedContent, err = findContentUsingCustomRules(htmlDocumentReader, rules)
+		baseURL, extractedContent, err = findContentUsingCustomRules(bytes.NewReader(htmlDocument), rules)
 	} else {
-		slog.Debug("Extracting content with readability",
+		slog.Debug("Extracting content with extractor",
 			"url", pageURL,
 		)
-		baseURL, extractedContent, err = readability.ExtractContent(htmlDocumentReader)
+		baseURL, extractedContent, err = extractor.ExtractContent(bytes.NewReader(htmlDocument))
 	}
 
 	if baseURL == "" {
@@ -66,32 +164,128 @@ func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules string
 		slog.Debug("Using base URL from HTML document", "base_url", baseURL)
 	}
 
-	return baseURL, extractedContent, nil
+	return &scrapedPage{url: pageURL, baseURL: baseURL, content: extractedContent, document: htmlDocument}, nil
 }
 
-func findContentUsingCustomRules(page io.Reader, rules string) (baseURL string, extractedContent string, err error) {
+// findNextPageURL returns the URL of the page following the given page number, declared with rel="next" or with a link to the next page number.
+// The next page must be below the path of the article to avoid following the links to the next articles.
+func findNextPageURL(page io.Reader, articleURL, pageURL string, pageNumber int) string {
 	document, err := goquery.NewDocumentFromReader(page)
 	if err != nil {
-		return "", "", err
+		return ""
 	}
 
-	if hrefValue, exists := document.Find("head base").First().Attr("href"); exists {
-		hrefValue = strings.TrimSpace(hrefValue)
-		if urllib.IsAbsoluteURL(hrefValue) {
-			baseURL = hrefValue
+	var candidates []string
+	document.Find(`link[rel~="next"], a[rel~="next"]`).Each(func(i int, s *goquery.Selection) {
+		if href, exists := s.Attr("href"); exists {
+			candidates = append(candidates, href)
 		}
-	}
+	})
 
-	document.Find(rules).Each(func(i int, s *goquery.Selection) {
-		if content, err := goquery.OuterHtml(s); err == nil {
-			extractedContent += content
+	nextPageNumber := strconv.Itoa(pageNumber + 1)
+	document.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		if strings.TrimSpace(s.Text()) == nextPageNumber {
+			candidates = append(candidates, s.AttrOr("href", ""))
 		}
 	})
 
+	for _, candidate := range candidates {
+		nextPageURL, err := urllib.AbsoluteURL(pageURL, strings.TrimSpace(candidate))
+		if err != nil || nextPageURL == pageURL {
+			continue
+		}
+
+		if isArticlePage(articleURL, nextPageURL) {
+			return nextPageURL
+		}
+	}
+
+	return ""
+}
+
+// isArticlePage returns true if the page URL is on the same host and below the path of the article.
+// For example "/article.html" continues on "/article-2.html", "/article/2/" or "/article.html?page=2".
+func isArticlePage(articleURL, pageURL string) bool {
+	parsedArticleURL, err := url.Parse(articleURL)
+	if err != nil {
+		return false
+	}
+
+	parsedPageURL, err := url.Parse(pageURL)
+	if err != nil || parsedPageURL.Host != parsedArticleURL.Host {
+		return false
+	}
+
+	articlePath := strings.TrimSuffix(parsedArticleURL.Path, "/")
+	articlePath = strings.TrimSuffix(articlePath, path.Ext(articlePath))
+	if articlePath == "" {
+		return false
+	}
+
+	return strings.HasPrefix(parsedPageURL.Path, articlePath)
+}
+
+func findContentUsingCustomRules(page io.Reader, rules string) (baseURL string, extractedContent string, err error) {
+	alternatives, err := parseRules(rules)
+	if err != nil {
+		return "", "", err
+	}
+
+	htmlDocument, err := io.ReadAll(page)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Each alternative modifies the document, the fallbacks start again from the original page.
+	for _, rule := range alternatives {
+		document, err := goquery.NewDocumentFromReader(bytes.NewReader(htmlDocument))
+		if err != nil {
+			return "", "", err
+		}
+
+		if hrefValue, exists := document.Find("head base").First().Attr("href"); exists {
+			hrefValue = strings.TrimSpace(hrefValue)
+			if urllib.IsAbsoluteURL(hrefValue) {
+				baseURL = hrefValue
+			}
+		}
+
+		if extractedContent = applyRule(document, rule); strings.TrimSpace(extractedContent) != "" {
+			break
+		}
+	}
+
 	return baseURL, extractedContent, nil
 }
 
+func applyRule(document *goquery.Document, rule scraperRule) (extractedContent string) {
+	for _, step := range rule {
+		switch step.function {
+		case "remove":
+			document.Find(step.arguments[0]).Remove()
+		case "attribute":
+			document.Find(step.arguments[0]).Each(func(i int, s *goquery.Selection) {
+				if value, exists := s.Attr(step.arguments[1]); exists {
+					s.SetAttr(step.arguments[2], value)
+				}
+			})
+		case "select":
+			document.Find(step.arguments[0]).Each(func(i int, s *goquery.Selection) {
+				if content, err := goquery.OuterHtml(s); err == nil {
+					extractedContent += content
+				}
+			})
+		}
+	}
+	return extractedContent
+}
+
 func getPredefinedScraperRules(websiteURL string) string {
+	// The rules of the site rules file take precedence over the bundled rules.
+	if rules := siterules.ScraperRules(websiteURL); rules != "" {
+		return rules
+	}
+
 	urlDomain := urllib.Domain(websiteURL)
 	urlDomain = strings.TrimPrefix(urlDomain, "www.")
 
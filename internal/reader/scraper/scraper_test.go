@@ -5,9 +5,15 @@ package scraper // import "miniflux.app/v2/internal/reader/scraper"
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/readability"
 )
 
 func TestGetPredefinedRules(t *testing.T) {
@@ -137,3 +143,89 @@ func TestParseMissingBaseURLWithCustomRules(t *testing.T) {
 		t.Errorf(`Unexpected base URL, got %q instead of ""`, baseURL)
 	}
 }
+
+func TestFindNextPageURL(t *testing.T) {
+	var scenarios = []struct {
+		html     string
+		pageURL  string
+		page     int
+		expected string
+	}{
+		{`<html><head><link rel="next" href="/article/2/"></head><body></body></html>`, "https://example.org/article/", 1, "https://example.org/article/2/"},
+		{`<html><body><a rel="next" href="article.html?page=2">Next</a></body></html>`, "https://example.org/article.html", 1, "https://example.org/article.html?page=2"},
+		{`<html><body><a href="/article-2.html">1</a> <a href="/article-3.html">3</a> <a href="/article-2.html"> 2 </a></body></html>`, "https://example.org/article.html", 1, "https://example.org/article-2.html"},
+		{`<html><body><a href="/article-3.html">3</a></body></html>`, "https://example.org/article-2.html", 1, ""},
+		{`<html><body><a href="/article-3.html">3</a></body></html>`, "https://example.org/article-2.html", 2, "https://example.org/article-3.html"},
+		{`<html><body><a rel="next" href="/another-article/">Next article</a></body></html>`, "https://example.org/article/", 1, ""},
+		{`<html><body><a rel="next" href="https://example.com/article/2/">Next</a></body></html>`, "https://example.org/article/", 1, ""},
+		{`<html><body><a rel="next" href="/article/">Next</a></body></html>`, "https://example.org/article/", 1, ""},
+		{`<html><body><p>Single page article</p></body></html>`, "https://example.org/article/", 1, ""},
+	}
+
+	for _, tc := range scenarios {
+		if result := findNextPageURL(strings.NewReader(tc.html), "https://example.org/article.html", tc.pageURL, tc.page); result != tc.expected {
+			t.Errorf(`Unexpected next page for %q, got %q instead of %q`, tc.pageURL, result, tc.expected)
+		}
+	}
+}
+
+func TestScrapeWebsiteWithMultiplePages(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	pages := map[string]string{
+		"/article/":   `<html><head><link rel="next" href="/article/2/"></head><body><div class="content"><p>First page</p></div></body></html>`,
+		"/article/2/": `<html><body><div class="content"><p>Second page <img src="image.jpg" srcset="small.jpg 1x, large.jpg 2x"> <span data-info="raw">kept</span></p></div><a href="/article/3/">3</a></body></html>`,
+		"/article/3/": `<html><body><div class="content"><p>Last page</p></div><a href="/article/">1</a></body></html>`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, found := pages[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	baseURL, content, err := ScrapeWebsite(fetcher.NewRequestBuilder(), server.URL+"/article/", "div.content", readability.GetExtractor(""))
+	if err != nil {
+		t.Fatalf(`Scraping error: %v`, err)
+	}
+
+	if baseURL != server.URL+"/article/" {
+		t.Errorf(`Unexpected base URL, got %q`, baseURL)
+	}
+
+	for _, expected := range []string{"First page", "Second page", "Last page", server.URL + "/article/2/image.jpg", server.URL + "/article/2/large.jpg 2x", `data-info="raw"`} {
+		if !strings.Contains(content, expected) {
+			t.Errorf(`The content should contain %q, got %q`, expected, content)
+		}
+	}
+
+	if strings.Count(content, "First page") != 1 {
+		t.Errorf(`The first page should not be stitched twice, got %q`, content)
+	}
+}
+
+func TestIsArticlePage(t *testing.T) {
+	var scenarios = []struct {
+		articleURL string
+		pageURL    string
+		expected   bool
+	}{
+		{"https://example.org/article/", "https://example.org/article/2/", true},
+		{"https://example.org/article.html", "https://example.org/article-2.html", true},
+		{"https://example.org/article.html", "https://example.org/article.html?page=2", true},
+		{"https://example.org/article/", "https://example.org/another-article/", false},
+		{"https://example.org/article/", "https://www.example.org/article/2/", false},
+		{"https://example.org/", "https://example.org/page/2/", false},
+	}
+
+	for _, tc := range scenarios {
+		if result := isArticlePage(tc.articleURL, tc.pageURL); result != tc.expected {
+			t.Errorf(`Unexpected result for %q and %q, got %v`, tc.articleURL, tc.pageURL, result)
+		}
+	}
+}
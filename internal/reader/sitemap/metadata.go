@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/urllib"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html/charset"
+)
+
+// ScrapeEntryMetadata downloads the web page of the entry to find its title, description, thumbnail and author.
+// The Open Graph properties are preferred to the HTML meta tags, the values already known are kept.
+func ScrapeEntryMetadata(requestBuilder *fetcher.RequestBuilder, entry *model.Entry) error {
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(entry.URL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return localizedError.Error()
+	}
+
+	if contentType := responseHandler.ContentType(); contentType != "" && !strings.Contains(contentType, "html") {
+		return fmt.Errorf("sitemap: the page is not a HTML document: %s", contentType)
+	}
+
+	return parseEntryMetadata(entry, responseHandler.ContentType(), responseHandler.Body(config.Opts.HTTPClientMaxBodySize()))
+}
+
+func parseEntryMetadata(entry *model.Entry, contentType string, body io.Reader) error {
+	htmlDocumentReader, err := charset.NewReader(body, contentType)
+	if err != nil {
+		return fmt.Errorf("sitemap: unable to read page: %w", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(htmlDocumentReader)
+	if err != nil {
+		return fmt.Errorf("sitemap: unable to parse page: %w", err)
+	}
+
+	metaContent := func(names ...string) string {
+		for _, name := range names {
+			selection := doc.Find(`meta[property="` + name + `"], meta[name="` + name + `"]`).First()
+			if value := strings.Join(strings.Fields(selection.AttrOr("content", "")), " "); value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	if entry.Title == entry.URL {
+		title := metaContent("og:title", "twitter:title")
+		if title == "" {
+			title = strings.Join(strings.Fields(doc.Find("head title").First().Text()), " ")
+		}
+		if title != "" {
+			entry.Title = title
+		}
+	}
+
+	if entry.Content == "" {
+		if description := metaContent("og:description", "twitter:description", "description"); description != "" {
+			entry.Content = "<p>" + html.EscapeString(description) + "</p>"
+		}
+	}
+
+	if entry.ThumbnailURL == "" {
+		if image := metaContent("og:image", "og:image:url", "twitter:image"); image != "" {
+			if absoluteImageURL, err := urllib.AbsoluteURL(entry.URL, image); err == nil {
+				entry.ThumbnailURL = absoluteImageURL
+			}
+		}
+	}
+
+	if entry.Author == "" {
+		entry.Author = metaContent("author")
+	}
+
+	return nil
+}
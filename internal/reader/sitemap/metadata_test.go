@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"strings"
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestParseEntryMetadata(t *testing.T) {
+	data := `<html><head>
+		<title>Page title | Example</title>
+		<meta property="og:title" content="  The article ">
+		<meta property="og:description" content="A <short> summary">
+		<meta property="og:image" content="/images/article.jpg">
+		<meta name="author" content="Alice">
+	</head><body></body></html>`
+
+	entry := model.NewEntry()
+	entry.URL = "https://example.org/posts/1"
+	entry.Title = entry.URL
+
+	if err := parseEntryMetadata(entry, "text/html; charset=utf-8", strings.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Title != "The article" {
+		t.Errorf(`Incorrect title, got: %q`, entry.Title)
+	}
+
+	if entry.Content != "<p>A &lt;short&gt; summary</p>" {
+		t.Errorf(`Incorrect content, got: %q`, entry.Content)
+	}
+
+	if entry.ThumbnailURL != "https://example.org/images/article.jpg" {
+		t.Errorf(`Incorrect thumbnail URL, got: %q`, entry.ThumbnailURL)
+	}
+
+	if entry.Author != "Alice" {
+		t.Errorf(`Incorrect author, got: %q`, entry.Author)
+	}
+}
+
+func TestParseEntryMetadataKeepsKnownValues(t *testing.T) {
+	data := `<html><head><title>Page title</title><meta name="description" content="Description"></head></html>`
+
+	entry := model.NewEntry()
+	entry.URL = "https://example.org/news/1"
+	entry.Title = "Breaking news"
+
+	if err := parseEntryMetadata(entry, "text/html", strings.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Title != "Breaking news" {
+		t.Errorf(`The title of the sitemap should be kept, got: %q`, entry.Title)
+	}
+
+	if entry.Content != "<p>Description</p>" {
+		t.Errorf(`Incorrect content, got: %q`, entry.Content)
+	}
+}
+
+func TestParseEntryMetadataWithHTMLTitle(t *testing.T) {
+	entry := model.NewEntry()
+	entry.URL = "https://example.org/about"
+	entry.Title = entry.URL
+
+	if err := parseEntryMetadata(entry, "text/html", strings.NewReader(`<html><head><title> About
+		us </title></head></html>`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Title != "About us" {
+		t.Errorf(`Incorrect title, got: %q`, entry.Title)
+	}
+}
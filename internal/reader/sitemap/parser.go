@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/xml"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// maxEntries is the number of pages of the sitemap converted to entries, the most recently modified pages are kept.
+const maxEntries = 20
+
+// Parse returns a feed with an entry for each of the newest pages of the sitemap.
+func Parse(baseURL string, data io.ReadSeeker) (*model.Feed, error) {
+	urlSet := new(URLSet)
+	if err := xml.NewXMLDecoder(data).Decode(urlSet); err != nil {
+		return nil, fmt.Errorf("sitemap: unable to parse sitemap: %w", err)
+	}
+
+	feed := &model.Feed{
+		FeedURL:             baseURL,
+		SiteURL:             urllib.RootURL(baseURL),
+		Title:               urllib.Domain(baseURL),
+		ScrapeEntryMetadata: true,
+	}
+
+	seen := make(map[string]bool)
+	for _, page := range urlSet.URLs {
+		entryURL, err := urllib.AbsoluteURL(baseURL, strings.TrimSpace(page.Location))
+		if err != nil || page.Location == "" || seen[entryURL] {
+			continue
+		}
+		seen[entryURL] = true
+		feed.Entries = append(feed.Entries, newEntry(entryURL, &page))
+	}
+
+	// The pages without date are the least recent ones.
+	slices.SortStableFunc(feed.Entries, func(a, b *model.Entry) int {
+		return b.Date.Compare(a.Date)
+	})
+	if len(feed.Entries) > maxEntries {
+		feed.Entries = feed.Entries[:maxEntries]
+	}
+
+	for _, entry := range feed.Entries {
+		if entry.Date.IsZero() {
+			entry.Date = time.Now()
+		}
+	}
+
+	return feed, nil
+}
+
+func newEntry(entryURL string, page *URL) *model.Entry {
+	entry := model.NewEntry()
+	entry.URL = entryURL
+	entry.Hash = crypto.Hash(entryURL)
+	entry.Title = entryURL
+
+	dateValues := []string{page.LastModified}
+	if page.News != nil {
+		if title := strings.TrimSpace(page.News.Title); title != "" {
+			entry.Title = title
+		}
+		dateValues = []string{page.News.PublicationDate, page.LastModified}
+	}
+
+	for _, value := range dateValues {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+
+		if entryDate, err := date.Parse(value); err != nil {
+			slog.Debug("Unable to parse date from sitemap",
+				slog.String("date", value),
+				slog.String("url", entryURL),
+				slog.Any("error", err),
+			)
+		} else {
+			entry.Date = entryDate
+			break
+		}
+	}
+
+	for _, image := range page.Images {
+		if imageURL, err := urllib.AbsoluteURL(entryURL, strings.TrimSpace(image.Location)); err == nil && image.Location != "" {
+			entry.ThumbnailURL = imageURL
+			break
+		}
+	}
+
+	return entry
+}
+
+// ParseIndex returns the URLs of the sitemaps listed in a sitemap index, the most recently modified sitemaps first.
+func ParseIndex(baseURL string, data io.ReadSeeker) ([]string, error) {
+	index := new(Index)
+	if err := xml.NewXMLDecoder(data).Decode(index); err != nil {
+		return nil, fmt.Errorf("sitemap: unable to parse sitemap index: %w", err)
+	}
+
+	// The sitemaps without date keep their order, after the dated ones.
+	slices.SortStableFunc(index.Sitemaps, func(a, b IndexEntry) int {
+		return strings.Compare(normalizedDate(b.LastModified), normalizedDate(a.LastModified))
+	})
+
+	var sitemapURLs []string
+	for _, sitemap := range index.Sitemaps {
+		if sitemapURL, err := urllib.AbsoluteURL(baseURL, strings.TrimSpace(sitemap.Location)); err == nil && sitemap.Location != "" {
+			sitemapURLs = append(sitemapURLs, sitemapURL)
+		}
+	}
+
+	return sitemapURLs, nil
+}
+
+func normalizedDate(value string) string {
+	if parsedDate, err := date.Parse(strings.TrimSpace(value)); err == nil {
+		return parsedDate.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
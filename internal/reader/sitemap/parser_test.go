@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+)
+
+func TestParseSitemap(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">
+		<url><loc>https://example.org/</loc></url>
+		<url>
+			<loc>https://example.org/posts/old</loc>
+			<lastmod>2025-03-01</lastmod>
+		</url>
+		<url>
+			<loc> /posts/new </loc>
+			<lastmod>2026-10-01T10:00:00+00:00</lastmod>
+			<image:image><image:loc>/images/new.jpg</image:loc></image:image>
+		</url>
+		<url><loc>https://example.org/posts/old</loc></url>
+	</urlset>`
+
+	feed, err := Parse("https://example.org/sitemap.xml", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "example.org" {
+		t.Errorf(`Incorrect title, got: %q`, feed.Title)
+	}
+
+	if feed.SiteURL != "https://example.org/" {
+		t.Errorf(`Incorrect site URL, got: %q`, feed.SiteURL)
+	}
+
+	if !feed.ScrapeEntryMetadata {
+		t.Error(`The metadata of the entries should be scraped`)
+	}
+
+	if len(feed.Entries) != 3 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	// The newest pages come first, the pages without date last.
+	for i, expected := range []string{"https://example.org/posts/new", "https://example.org/posts/old", "https://example.org/"} {
+		if feed.Entries[i].URL != expected {
+			t.Errorf(`Incorrect URL for entry %d, got: %q`, i, feed.Entries[i].URL)
+		}
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != entry.URL {
+		t.Errorf(`The URL should be the placeholder title, got: %q`, entry.Title)
+	}
+
+	if entry.Hash != crypto.Hash("https://example.org/posts/new") {
+		t.Errorf(`Incorrect entry hash, got: %q`, entry.Hash)
+	}
+
+	if !entry.Date.Equal(time.Date(2026, time.October, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Incorrect entry date, got: %v`, entry.Date)
+	}
+
+	if entry.ThumbnailURL != "https://example.org/images/new.jpg" {
+		t.Errorf(`Incorrect thumbnail URL, got: %q`, entry.ThumbnailURL)
+	}
+
+	if feed.Entries[2].Date.IsZero() {
+		t.Error(`The entry without date should have the current date`)
+	}
+}
+
+func TestParseNewsSitemap(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:news="http://www.google.com/schemas/sitemap-news/0.9">
+		<url>
+			<loc>https://example.org/news/1</loc>
+			<lastmod>2026-10-02</lastmod>
+			<news:news>
+				<news:publication><news:name>Example</news:name><news:language>en</news:language></news:publication>
+				<news:publication_date>2026-10-01T08:00:00Z</news:publication_date>
+				<news:title>Breaking news</news:title>
+			</news:news>
+		</url>
+	</urlset>`
+
+	feed, err := Parse("https://example.org/news-sitemap.xml", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	if feed.Entries[0].Title != "Breaking news" {
+		t.Errorf(`Incorrect entry title, got: %q`, feed.Entries[0].Title)
+	}
+
+	if !feed.Entries[0].Date.Equal(time.Date(2026, time.October, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf(`The publication date should be preferred, got: %v`, feed.Entries[0].Date)
+	}
+}
+
+func TestParseLargeSitemap(t *testing.T) {
+	var builder strings.Builder
+	builder.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	for day := 1; day <= 28; day++ {
+		fmt.Fprintf(&builder, `<url><loc>https://example.org/%d</loc><lastmod>2026-02-%02d</lastmod></url>`, day, day)
+	}
+	builder.WriteString(`</urlset>`)
+
+	feed, err := Parse("https://example.org/sitemap.xml", strings.NewReader(builder.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != maxEntries {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	if feed.Entries[0].URL != "https://example.org/28" || feed.Entries[maxEntries-1].URL != "https://example.org/9" {
+		t.Errorf(`Only the newest pages should be kept, got %q to %q`, feed.Entries[0].URL, feed.Entries[maxEntries-1].URL)
+	}
+}
+
+func TestParseIndex(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+		<sitemap><loc>https://example.org/undated.xml</loc></sitemap>
+		<sitemap><loc>https://example.org/pages.xml</loc><lastmod>2025-01-01</lastmod></sitemap>
+		<sitemap><loc>/posts.xml</loc><lastmod>2026-10-01T10:00:00+02:00</lastmod></sitemap>
+	</sitemapindex>`
+
+	sitemapURLs, err := ParseIndex("https://example.org/sitemap.xml", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"https://example.org/posts.xml", "https://example.org/pages.xml", "https://example.org/undated.xml"}
+	if len(sitemapURLs) != len(expected) {
+		t.Fatalf(`Incorrect number of sitemaps, got: %v`, sitemapURLs)
+	}
+
+	for i := range expected {
+		if sitemapURLs[i] != expected[i] {
+			t.Errorf(`Incorrect sitemap %d, got: %q`, i, sitemapURLs[i])
+		}
+	}
+}
+
+func TestParseInvalidSitemap(t *testing.T) {
+	if _, err := Parse("https://example.org/sitemap.xml", strings.NewReader(`invalid`)); err == nil {
+		t.Error(`Parsing an invalid sitemap should return an error`)
+	}
+}
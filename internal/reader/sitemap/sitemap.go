@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sitemap turns the sitemap of a website into a feed, for the websites without any feed.
+// The sitemaps only list URLs, the title and the description of the entries are scraped from the web pages.
+// Specs: https://www.sitemaps.org/protocol.html
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+// URLSet is a sitemap, the list of the pages of a website.
+type URLSet struct {
+	URLs []URL `xml:"url"`
+}
+
+// URL is a page of the website.
+// The Google News extension gives the title and the publication date of the articles.
+type URL struct {
+	Location     string    `xml:"loc"`
+	LastModified string    `xml:"lastmod"`
+	News         *NewsItem `xml:"http://www.google.com/schemas/sitemap-news/0.9 news"`
+	Images       []Image   `xml:"http://www.google.com/schemas/sitemap-image/1.1 image"`
+}
+
+// NewsItem is an article of the Google News sitemaps.
+type NewsItem struct {
+	Title           string `xml:"http://www.google.com/schemas/sitemap-news/0.9 title"`
+	PublicationDate string `xml:"http://www.google.com/schemas/sitemap-news/0.9 publication_date"`
+}
+
+// Image is an image of the page, listed by the Google image extension.
+type Image struct {
+	Location string `xml:"http://www.google.com/schemas/sitemap-image/1.1 loc"`
+}
+
+// Index is a sitemap index, the large websites split their sitemap in several files.
+type Index struct {
+	Sitemaps []IndexEntry `xml:"sitemap"`
+}
+
+// IndexEntry is a sitemap listed in a sitemap index.
+type IndexEntry struct {
+	Location     string `xml:"loc"`
+	LastModified string `xml:"lastmod"`
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package siterules // import "miniflux.app/v2/internal/reader/siterules"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+)
+
+var argumentReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// Parse reads a site rules file, the file is inspired by the FiveFilters site configuration files but uses CSS selectors:
+//
+//	# The blank lines and the lines starting with "#" are ignored.
+//	[example.org, example.com]
+//	body: article .content
+//	body: main
+//	strip: .advertisement, .share
+//	rewrite: add_image_title
+//
+// Each section lists the domains of the websites, the subdomains use the same rules.
+// The "body" selectors are ordered fallbacks, the "strip" selectors are removed before extracting the body,
+// the "scraper" and "rewrite" directives define the scraper rules and the rewrite rules directly.
+func Parse(r io.Reader) (map[string]*SiteRules, error) {
+	sites := make(map[string]*SiteRules)
+
+	var domains []string
+	var section *siteSection
+	flush := func() {
+		if section == nil {
+			return
+		}
+		rules := section.siteRules()
+		for _, domain := range domains {
+			sites[domain] = rules
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if header, found := strings.CutPrefix(line, "["); found {
+			header, found = strings.CutSuffix(header, "]")
+			if !found {
+				return nil, fmt.Errorf("siterules: line %d: missing closing bracket", lineNumber)
+			}
+
+			if err := section.validate(); err != nil {
+				return nil, fmt.Errorf("siterules: section %v: %v", domains, err)
+			}
+			flush()
+
+			domains = nil
+			for _, domain := range strings.Split(header, ",") {
+				if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+					domains = append(domains, domain)
+				}
+			}
+			if len(domains) == 0 {
+				return nil, fmt.Errorf("siterules: line %d: empty list of domains", lineNumber)
+			}
+			section = &siteSection{}
+			continue
+		}
+
+		if section == nil {
+			return nil, fmt.Errorf("siterules: line %d: the rules must follow a list of domains", lineNumber)
+		}
+
+		directive, value, found := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		if !found || value == "" {
+			return nil, fmt.Errorf("siterules: line %d: invalid directive %q", lineNumber, line)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(directive)) {
+		case "body", "strip":
+			if _, err := cascadia.Compile(value); err != nil {
+				return nil, fmt.Errorf("siterules: line %d: invalid CSS selector %q: %v", lineNumber, value, err)
+			}
+			if strings.EqualFold(strings.TrimSpace(directive), "body") {
+				section.bodies = append(section.bodies, value)
+			} else {
+				section.strips = append(section.strips, value)
+			}
+		case "scraper":
+			section.scraperRules = value
+		case "rewrite":
+			section.rewriteRules = value
+		default:
+			return nil, fmt.Errorf("siterules: line %d: unknown directive %q", lineNumber, directive)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("siterules: unable to read the rules: %v", err)
+	}
+
+	if err := section.validate(); err != nil {
+		return nil, fmt.Errorf("siterules: section %v: %v", domains, err)
+	}
+	flush()
+
+	return sites, nil
+}
+
+type siteSection struct {
+	bodies       []string
+	strips       []string
+	scraperRules string
+	rewriteRules string
+}
+
+func (s *siteSection) validate() error {
+	if s == nil {
+		return nil
+	}
+
+	if len(s.strips) > 0 && len(s.bodies) == 0 {
+		return fmt.Errorf(`the "strip" directive requires a "body" directive`)
+	}
+
+	if len(s.bodies) > 0 && s.scraperRules != "" {
+		return fmt.Errorf(`the "body" and "scraper" directives cannot be combined`)
+	}
+
+	return nil
+}
+
+// siteRules converts the directives into the scraper rules, each body is a fallback stripped of the same elements.
+func (s *siteSection) siteRules() *SiteRules {
+	rules := &SiteRules{ScraperRules: s.scraperRules, RewriteRules: s.rewriteRules}
+
+	var alternatives []string
+	for _, body := range s.bodies {
+		var steps []string
+		for _, strip := range s.strips {
+			steps = append(steps, `remove("`+argumentReplacer.Replace(strip)+`")`)
+		}
+		steps = append(steps, `select("`+argumentReplacer.Replace(body)+`")`)
+		alternatives = append(alternatives, strings.Join(steps, "; "))
+	}
+
+	if len(alternatives) > 0 {
+		rules.ScraperRules = strings.Join(alternatives, " || ")
+	}
+
+	return rules
+}
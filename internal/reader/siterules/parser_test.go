@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package siterules // import "miniflux.app/v2/internal/reader/siterules"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSiteRules(t *testing.T) {
+	data := `
+		# Publishers
+		[Example.org, example.com]
+		body: article .content
+		body: main
+		strip: .ads, .share
+		rewrite: add_image_title
+
+		[example.net]
+		scraper: select("div[data-role=\"body\"]")
+
+		[example.info]
+		body: div[itemprop="articleBody"]
+	`
+
+	sites, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf(`Parsing the site rules should not return any error: %v`, err)
+	}
+
+	if len(sites) != 4 {
+		t.Fatalf(`Incorrect number of domains, got %d`, len(sites))
+	}
+
+	expectedScraperRules := `remove(".ads, .share"); select("article .content") || remove(".ads, .share"); select("main")`
+	for _, domain := range []string{"example.org", "example.com"} {
+		if sites[domain].ScraperRules != expectedScraperRules {
+			t.Errorf(`Unexpected scraper rules for %q, got %q`, domain, sites[domain].ScraperRules)
+		}
+
+		if sites[domain].RewriteRules != "add_image_title" {
+			t.Errorf(`Unexpected rewrite rules for %q, got %q`, domain, sites[domain].RewriteRules)
+		}
+	}
+
+	if sites["example.net"].ScraperRules != `select("div[data-role=\"body\"]")` {
+		t.Errorf(`Unexpected scraper rules, got %q`, sites["example.net"].ScraperRules)
+	}
+
+	if sites["example.info"].ScraperRules != `select("div[itemprop=\"articleBody\"]")` {
+		t.Errorf(`The quotes of the selectors should be escaped, got %q`, sites["example.info"].ScraperRules)
+	}
+}
+
+func TestParseInvalidSiteRules(t *testing.T) {
+	var scenarios = []string{
+		"body: article",
+		"[example.org\nbody: article",
+		"[ , ]\nbody: article",
+		"[example.org]\nbody article",
+		"[example.org]\nbody:",
+		"[example.org]\nbody: article[",
+		"[example.org]\ntitle: h1",
+		"[example.org]\nstrip: .ads",
+		"[example.org]\nbody: article\nscraper: select(\"main\")",
+		"[example.org]\nstrip: .ads\n[example.com]\nbody: article",
+	}
+
+	for _, data := range scenarios {
+		if _, err := Parse(strings.NewReader(data)); err == nil {
+			t.Errorf(`Parsing %q should return an error`, data)
+		}
+	}
+}
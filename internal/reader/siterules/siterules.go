@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package siterules // import "miniflux.app/v2/internal/reader/siterules"
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
+)
+
+// SiteRules are the rules loaded from the site rules file for a website.
+type SiteRules struct {
+	ScraperRules string
+	RewriteRules string
+}
+
+var (
+	mu    sync.RWMutex
+	sites = make(map[string]*SiteRules)
+)
+
+// LoadFromSource loads the site rules from a local file or from a HTTP(S) URL, the previous rules are replaced.
+func LoadFromSource(source string) error {
+	var reader io.ReadCloser
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		requestBuilder := fetcher.NewRequestBuilder()
+		requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+		requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
+		requestBuilder.WithUserAgent(config.Opts.HTTPClientUserAgent(), config.Opts.HTTPClientUserAgent())
+		requestBuilder.WithAcceptHeader("text/plain, */*;q=0.9")
+
+		responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(source))
+		defer responseHandler.Close()
+
+		if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+			return fmt.Errorf("siterules: unable to download %q: %w", source, localizedError.Error())
+		}
+
+		reader = responseHandler.Body(config.Opts.HTTPClientMaxBodySize())
+	} else {
+		file, err := os.Open(source)
+		if err != nil {
+			return fmt.Errorf("siterules: unable to open %q: %w", source, err)
+		}
+		reader = file
+	}
+	defer reader.Close()
+
+	loadedSites, err := Parse(reader)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	sites = loadedSites
+	mu.Unlock()
+
+	slog.Info("Site rules loaded", slog.String("source", source), slog.Int("domains", len(loadedSites)))
+	return nil
+}
+
+// ScraperRules returns the scraper rules of the website from the site rules file, or an empty string.
+func ScraperRules(websiteURL string) string {
+	if rules := findSiteRules(websiteURL); rules != nil {
+		return rules.ScraperRules
+	}
+	return ""
+}
+
+// RewriteRules returns the rewrite rules of the website from the site rules file, or an empty string.
+func RewriteRules(websiteURL string) string {
+	if rules := findSiteRules(websiteURL); rules != nil {
+		return rules.RewriteRules
+	}
+	return ""
+}
+
+// findSiteRules returns the rules of the hostname, or of its closest parent domain.
+func findSiteRules(websiteURL string) *SiteRules {
+	parsedURL, err := url.Parse(websiteURL)
+	if err != nil {
+		return nil
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for hostname := strings.ToLower(parsedURL.Hostname()); hostname != ""; _, hostname, _ = strings.Cut(hostname, ".") {
+		if rules, found := sites[hostname]; found {
+			return rules
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package siterules // import "miniflux.app/v2/internal/reader/siterules"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+)
+
+func TestLoadSiteRulesFromFile(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	filename := filepath.Join(t.TempDir(), "site-rules.txt")
+	if err := os.WriteFile(filename, []byte("[example.org]\nbody: article\nrewrite: add_image_title\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadFromSource(filename); err != nil {
+		t.Fatalf(`Loading the site rules should not return any error: %v`, err)
+	}
+
+	if rules := ScraperRules("https://blog.example.org/article"); rules != `select("article")` {
+		t.Errorf(`The subdomains should use the rules of the domain, got %q`, rules)
+	}
+
+	if rules := RewriteRules("https://example.org/article"); rules != "add_image_title" {
+		t.Errorf(`Unexpected rewrite rules, got %q`, rules)
+	}
+
+	if rules := ScraperRules("https://notexample.org/article"); rules != "" {
+		t.Errorf(`Unexpected scraper rules for another domain, got %q`, rules)
+	}
+}
+
+func TestLoadSiteRulesFromURL(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("[example.com]\nbody: main\n"))
+	}))
+	defer server.Close()
+
+	if err := LoadFromSource(server.URL + "/site-rules.txt"); err != nil {
+		t.Fatalf(`Loading the site rules should not return any error: %v`, err)
+	}
+
+	if rules := ScraperRules("https://example.com/article"); rules != `select("main")` {
+		t.Errorf(`Unexpected scraper rules, got %q`, rules)
+	}
+
+	if rules := ScraperRules("https://example.org/article"); rules != "" {
+		t.Errorf(`The previous rules should be replaced, got %q`, rules)
+	}
+}
+
+func TestLoadMissingSiteRulesFile(t *testing.T) {
+	if err := LoadFromSource(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error(`Loading a missing file should return an error`)
+	}
+}
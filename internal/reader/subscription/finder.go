@@ -5,19 +5,23 @@ package subscription // import "miniflux.app/v2/internal/reader/subscription"
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/url"
 	"regexp"
+	"slices"
 	"strings"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/integration/rssbridge"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/activitypub"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/parser"
+	"miniflux.app/v2/internal/reader/sitemap"
 	"miniflux.app/v2/internal/urllib"
 
 	"github.com/PuerkitoBio/goquery"
@@ -25,8 +29,14 @@ import (
 )
 
 var (
-	youtubeHostRegex    = regexp.MustCompile(`youtube\.com$`)
-	youtubeChannelRegex = regexp.MustCompile(`channel/(.*)$`)
+	youtubeHostRegex      = regexp.MustCompile(`youtube\.com$`)
+	youtubeChannelRegex   = regexp.MustCompile(`^/channel/(UC[\w-]+)`)
+	youtubeCustomURLRegex = regexp.MustCompile(`^/(@[^/]+|c/[^/]+|user/[^/]+)`)
+	youtubeChannelIDRegex = regexp.MustCompile(`"(?:channelId|externalId)":"(UC[\w-]+)"`)
+	redditHostRegex       = regexp.MustCompile(`(^|\.)reddit\.com$`)
+	redditListingRegex    = regexp.MustCompile(`^/(?:r/[\w+-]+|(?:u|user)/[\w-]+(?:/m/[\w-]+)?)(?:/(?:hot|new|top|rising|controversial|best|submitted|comments|overview))?/?$`)
+	redditSearchRegex     = regexp.MustCompile(`^(?:/r/[\w+-]+)?/search/?$`)
+	fediverseProfileRegex = regexp.MustCompile(`^/(?:@|users/)([\w.-]+)/?$`)
 )
 
 type SubscriptionFinder struct {
@@ -50,6 +60,31 @@ func (f *SubscriptionFinder) FeedResponseInfo() *model.FeedCreationRequestFromSu
 }
 
 func (f *SubscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string) (Subscriptions, *locale.LocalizedErrorWrapper) {
+	// The social networks configured by the administrator are replaced by their privacy frontend, the frontend exposes the feeds.
+	if frontendURL := rewriteWithAlternativeFrontend(websiteURL); frontendURL != websiteURL {
+		slog.Debug("Website replaced by its alternative frontend", slog.String("website_url", websiteURL), slog.String("frontend_url", frontendURL))
+		websiteURL = frontendURL
+	}
+
+	// Step 1) Check if the website URL is a Reddit listing.
+	// Reddit often rejects the requests of the bots, the feed URL is found without downloading the page.
+	if subscriptions, localizedError := f.FindSubscriptionsFromRedditPage(websiteURL); localizedError != nil {
+		return nil, localizedError
+	} else if len(subscriptions) > 0 {
+		slog.Debug("Subscriptions found from Reddit page", slog.String("website_url", websiteURL), slog.Any("subscriptions", subscriptions))
+		return subscriptions, nil
+	}
+
+	// Step 2) Check if the website URL is the profile of a Fediverse account.
+	// The profile page is an HTML page without feed, the outbox of the account is found with WebFinger.
+	slog.Debug("Try to detect feeds from Fediverse profile", slog.String("website_url", websiteURL))
+	if subscriptions, localizedError := f.FindSubscriptionsFromFediverseProfile(websiteURL); localizedError != nil {
+		return nil, localizedError
+	} else if len(subscriptions) > 0 {
+		slog.Debug("Subscriptions found from Fediverse profile", slog.String("website_url", websiteURL), slog.Any("subscriptions", subscriptions))
+		return subscriptions, nil
+	}
+
 	responseHandler := fetcher.NewResponseHandler(f.requestBuilder.ExecuteRequest(websiteURL))
 	defer responseHandler.Close()
 
@@ -70,13 +105,15 @@ func (f *SubscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string)
 		LastModified: responseHandler.LastModified(),
 	}
 
-	// Step 1) Check if the website URL is already a feed.
-	if feedFormat, _ := parser.DetectFeedFormat(f.feedResponseInfo.Content); feedFormat != parser.FormatUnknown {
+	// Step 3) Check if the website URL is already a feed.
+	// The pages marked up with microformats2 are only used as a last resort, see step 10.
+	feedFormat, _ := parser.DetectFeedFormat(f.feedResponseInfo.Content)
+	if feedFormat != parser.FormatUnknown && feedFormat != parser.FormatMF2 {
 		f.feedDownloaded = true
 		return Subscriptions{NewSubscription(responseHandler.EffectiveURL(), responseHandler.EffectiveURL(), feedFormat)}, nil
 	}
 
-	// Step 2) Check if the website URL is a YouTube channel.
+	// Step 4) Check if the website URL is a YouTube channel.
 	slog.Debug("Try to detect feeds from YouTube channel page", slog.String("website_url", websiteURL))
 	if subscriptions, localizedError := f.FindSubscriptionsFromYouTubeChannelPage(websiteURL); localizedError != nil {
 		return nil, localizedError
@@ -85,7 +122,7 @@ func (f *SubscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string)
 		return subscriptions, nil
 	}
 
-	// Step 3) Check if the website URL is a YouTube playlist.
+	// Step 5) Check if the website URL is a YouTube playlist.
 	slog.Debug("Try to detect feeds from YouTube playlist page", slog.String("website_url", websiteURL))
 	if subscriptions, localizedError := f.FindSubscriptionsFromYouTubePlaylistPage(websiteURL); localizedError != nil {
 		return nil, localizedError
@@ -94,7 +131,17 @@ func (f *SubscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string)
 		return subscriptions, nil
 	}
 
-	// Step 4) Parse web page to find feeds from HTML meta tags.
+	// Step 6) Check if the website URL is a YouTube handle or a custom channel URL.
+	// Those URLs don't contain the channel ID, it has to be found in the page itself.
+	slog.Debug("Try to detect feeds from YouTube handle page", slog.String("website_url", websiteURL))
+	if subscriptions, localizedError := f.FindSubscriptionsFromYouTubeHandlePage(websiteURL, bytes.NewReader(responseBody)); localizedError != nil {
+		return nil, localizedError
+	} else if len(subscriptions) > 0 {
+		slog.Debug("Subscriptions found from YouTube handle page", slog.String("website_url", websiteURL), slog.Any("subscriptions", subscriptions))
+		return subscriptions, nil
+	}
+
+	// Step 7) Parse web page to find feeds from HTML meta tags.
 	slog.Debug("Try to detect feeds from HTML meta tags",
 		slog.String("website_url", websiteURL),
 		slog.String("content_type", responseHandler.ContentType()),
@@ -106,7 +153,7 @@ func (f *SubscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string)
 		return subscriptions, nil
 	}
 
-	// Step 5) Check if the website URL can use RSS-Bridge.
+	// Step 8) Check if the website URL can use RSS-Bridge.
 	if rssBridgeURL != "" {
 		slog.Debug("Try to detect feeds with RSS-Bridge", slog.String("website_url", websiteURL))
 		if subscriptions, localizedError := f.FindSubscriptionsFromRSSBridge(websiteURL, rssBridgeURL); localizedError != nil {
@@ -117,7 +164,7 @@ func (f *SubscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string)
 		}
 	}
 
-	// Step 6) Check if the website has a known feed URL.
+	// Step 9) Check if the website has a known feed URL.
 	slog.Debug("Try to detect feeds from well-known URLs", slog.String("website_url", websiteURL))
 	if subscriptions, localizedError := f.FindSubscriptionsFromWellKnownURLs(websiteURL); localizedError != nil {
 		return nil, localizedError
@@ -126,6 +173,22 @@ func (f *SubscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string)
 		return subscriptions, nil
 	}
 
+	// Step 10) Check if the web page itself is marked up with microformats2.
+	if feedFormat == parser.FormatMF2 {
+		slog.Debug("Subscription found from microformats2 markup", slog.String("website_url", websiteURL))
+		f.feedDownloaded = true
+		return Subscriptions{NewSubscription(responseHandler.EffectiveURL(), responseHandler.EffectiveURL(), feedFormat)}, nil
+	}
+
+	// Step 11) Check if the website has a sitemap, the newest pages of the sitemap become the entries of a synthetic feed.
+	slog.Debug("Try to detect feeds from sitemap", slog.String("website_url", websiteURL))
+	if subscriptions, localizedError := f.FindSubscriptionsFromSitemap(websiteURL); localizedError != nil {
+		return nil, localizedError
+	} else if len(subscriptions) > 0 {
+		slog.Debug("Subscriptions found from sitemap", slog.String("website_url", websiteURL), slog.Any("subscriptions", subscriptions))
+		return subscriptions, nil
+	}
+
 	return nil, nil
 }
 
@@ -308,11 +371,273 @@ func (f *SubscriptionFinder) FindSubscriptionsFromYouTubePlaylistPage(websiteURL
 		return nil, nil
 	}
 
-	if (strings.HasPrefix(decodedUrl.Path, "/watch") && decodedUrl.Query().Has("list")) || strings.HasPrefix(decodedUrl.Path, "/playlist") {
-		playlistID := decodedUrl.Query().Get("list")
+	playlistID := decodedUrl.Query().Get("list")
+	if playlistID != "" && (strings.HasPrefix(decodedUrl.Path, "/watch") || strings.HasPrefix(decodedUrl.Path, "/playlist")) {
 		feedURL := fmt.Sprintf(`https://www.youtube.com/feeds/videos.xml?playlist_id=%s`, playlistID)
 		return Subscriptions{NewSubscription(websiteURL, feedURL, parser.FormatAtom)}, nil
 	}
 
 	return nil, nil
 }
+
+// FindSubscriptionsFromYouTubeHandlePage returns the feed of the channel behind a handle (/@name) or a legacy custom URL (/c/name and /user/name).
+func (f *SubscriptionFinder) FindSubscriptionsFromYouTubeHandlePage(websiteURL string, body io.Reader) (Subscriptions, *locale.LocalizedErrorWrapper) {
+	decodedUrl, err := url.Parse(websiteURL)
+	if err != nil {
+		return nil, locale.NewLocalizedErrorWrapper(err, "error.invalid_site_url", err)
+	}
+
+	if !youtubeHostRegex.MatchString(decodedUrl.Host) || !youtubeCustomURLRegex.MatchString(decodedUrl.Path) {
+		return nil, nil
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, locale.NewLocalizedErrorWrapper(err, "error.unable_to_parse_html_document", err)
+	}
+
+	channelID := findYouTubeChannelID(content)
+	if channelID == "" {
+		slog.Debug("Unable to find the YouTube channel ID in the page", slog.String("website_url", websiteURL))
+		return nil, nil
+	}
+
+	feedURL := fmt.Sprintf(`https://www.youtube.com/feeds/videos.xml?channel_id=%s`, channelID)
+	return Subscriptions{NewSubscription(websiteURL, feedURL, parser.FormatAtom)}, nil
+}
+
+// findYouTubeChannelID looks for the channel ID in the HTML markup first, then in the JSON data embedded in the page.
+func findYouTubeChannelID(content []byte) string {
+	if doc, err := goquery.NewDocumentFromReader(bytes.NewReader(content)); err == nil {
+		for _, query := range []string{`meta[itemprop="channelId"]`, `meta[itemprop="identifier"]`} {
+			if value := strings.TrimSpace(doc.Find(query).First().AttrOr("content", "")); strings.HasPrefix(value, "UC") {
+				return value
+			}
+		}
+
+		for _, query := range []string{`link[rel="canonical"]`, `meta[property="og:url"]`} {
+			selection := doc.Find(query).First()
+			value := selection.AttrOr("href", selection.AttrOr("content", ""))
+			if canonicalURL, err := url.Parse(strings.TrimSpace(value)); err == nil {
+				if matches := youtubeChannelRegex.FindStringSubmatch(canonicalURL.Path); len(matches) == 2 {
+					return matches[1]
+				}
+			}
+		}
+	}
+
+	if matches := youtubeChannelIDRegex.FindSubmatch(content); len(matches) == 2 {
+		return string(matches[1])
+	}
+
+	return ""
+}
+
+// FindSubscriptionsFromRedditPage converts the URL of a subreddit, a multireddit, a user or a search to its feed URL.
+// The sort order and the other query parameters are kept.
+func (f *SubscriptionFinder) FindSubscriptionsFromRedditPage(websiteURL string) (Subscriptions, *locale.LocalizedErrorWrapper) {
+	decodedUrl, err := url.Parse(websiteURL)
+	if err != nil {
+		return nil, locale.NewLocalizedErrorWrapper(err, "error.invalid_site_url", err)
+	}
+
+	if !redditHostRegex.MatchString(decodedUrl.Hostname()) {
+		return nil, nil
+	}
+
+	path := strings.TrimSuffix(decodedUrl.Path, "/")
+	switch {
+	case redditListingRegex.MatchString(decodedUrl.Path):
+		path += "/.rss"
+	case redditSearchRegex.MatchString(decodedUrl.Path):
+		path += ".rss"
+	default:
+		return nil, nil
+	}
+
+	if strings.HasPrefix(path, "/u/") {
+		path = "/user/" + strings.TrimPrefix(path, "/u/")
+	}
+
+	feedURL := url.URL{Scheme: "https", Host: "www.reddit.com", Path: path, RawQuery: decodedUrl.RawQuery}
+	return Subscriptions{NewSubscription(websiteURL, feedURL.String(), parser.FormatAtom)}, nil
+}
+
+// FindSubscriptionsFromFediverseProfile finds the outbox of the account from the URL of its profile, for example "https://mastodon.social/@user".
+// The website URL is not a Fediverse profile when the server doesn't know the account, no error is returned in this case.
+func (f *SubscriptionFinder) FindSubscriptionsFromFediverseProfile(websiteURL string) (Subscriptions, *locale.LocalizedErrorWrapper) {
+	decodedUrl, err := url.Parse(websiteURL)
+	if err != nil {
+		return nil, locale.NewLocalizedErrorWrapper(err, "error.invalid_site_url", err)
+	}
+
+	matches := fediverseProfileRegex.FindStringSubmatch(decodedUrl.Path)
+	if len(matches) != 2 {
+		return nil, nil
+	}
+
+	webFingerURL := url.URL{
+		Scheme:   decodedUrl.Scheme,
+		Host:     decodedUrl.Host,
+		Path:     "/.well-known/webfinger",
+		RawQuery: url.Values{"resource": {"acct:" + matches[1] + "@" + decodedUrl.Host}}.Encode(),
+	}
+
+	var webFinger activitypub.WebFinger
+	if err := f.fetchJSONDocument(webFingerURL.String(), "application/jrd+json, application/json", &webFinger); err != nil {
+		slog.Debug("Unable to find the Fediverse account", slog.String("website_url", websiteURL), slog.Any("error", err))
+		return nil, nil
+	}
+
+	actorURL := webFinger.ActorURL()
+	if actorURL == "" {
+		return nil, nil
+	}
+
+	var actor activitypub.Actor
+	if err := f.fetchJSONDocument(actorURL, activitypub.MimeType, &actor); err != nil || actor.Outbox == "" {
+		slog.Debug("Unable to find the outbox of the Fediverse account", slog.String("actor_url", actorURL), slog.Any("error", err))
+		return nil, nil
+	}
+
+	// The outbox itself only contains the number of activities, they are listed in its first page.
+	feedURL := actor.Outbox
+	var outbox activitypub.Collection
+	if err := f.fetchJSONDocument(actor.Outbox, activitypub.MimeType, &outbox); err != nil {
+		slog.Debug("Unable to fetch the outbox of the Fediverse account", slog.String("outbox_url", actor.Outbox), slog.Any("error", err))
+	} else if outbox.First.ID != "" {
+		if absoluteURL, err := urllib.AbsoluteURL(actor.Outbox, outbox.First.ID); err == nil {
+			feedURL = absoluteURL
+		}
+	}
+
+	title := actor.Name
+	if title == "" {
+		title = activitypub.Handle(actor.ID)
+	}
+
+	return Subscriptions{NewSubscription(title, feedURL, parser.FormatActivityPub)}, nil
+}
+
+// fetchJSONDocument downloads a JSON document with the given Accept header, the default Accept header is restored afterward.
+func (f *SubscriptionFinder) fetchJSONDocument(documentURL, acceptHeader string, document any) error {
+	responseBody, err := f.fetchDocument(documentURL, acceptHeader)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(responseBody, document)
+}
+
+func (f *SubscriptionFinder) fetchDocument(documentURL, acceptHeader string) ([]byte, error) {
+	f.requestBuilder.WithAcceptHeader(acceptHeader)
+	defer f.requestBuilder.WithAcceptHeader("")
+
+	responseHandler := fetcher.NewResponseHandler(f.requestBuilder.ExecuteRequest(documentURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	if localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	return responseBody, nil
+}
+
+// FindSubscriptionsFromSitemap returns a synthetic feed built from the sitemap of the website.
+// The sitemaps are listed in the robots.txt file, "/sitemap.xml" is the fallback.
+// The most recently modified sitemap of a sitemap index is used.
+func (f *SubscriptionFinder) FindSubscriptionsFromSitemap(websiteURL string) (Subscriptions, *locale.LocalizedErrorWrapper) {
+	websiteURLRoot := urllib.RootURL(websiteURL)
+
+	var sitemapURLs []string
+	if robotsFile, err := f.fetchDocument(websiteURLRoot+"robots.txt", "text/plain"); err == nil {
+		for _, line := range strings.Split(string(robotsFile), "\n") {
+			if directive, value, found := strings.Cut(line, ":"); found && strings.EqualFold(strings.TrimSpace(directive), "sitemap") {
+				if sitemapURL, err := urllib.AbsoluteURL(websiteURLRoot, strings.TrimSpace(value)); err == nil {
+					sitemapURLs = append(sitemapURLs, sitemapURL)
+				}
+			}
+		}
+	}
+
+	if sitemapURL := websiteURLRoot + "sitemap.xml"; !slices.Contains(sitemapURLs, sitemapURL) {
+		sitemapURLs = append(sitemapURLs, sitemapURL)
+	}
+
+	for _, sitemapURL := range sitemapURLs {
+		if feedURL := f.findSitemapWithPages(sitemapURL, true); feedURL != "" {
+			return Subscriptions{NewSubscription(urllib.Domain(websiteURL), feedURL, parser.FormatSitemap)}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findSitemapWithPages returns the URL of the sitemap if it lists some pages, or the URL of the newest sitemap of the index.
+func (f *SubscriptionFinder) findSitemapWithPages(sitemapURL string, followIndex bool) string {
+	document, err := f.fetchDocument(sitemapURL, "application/xml")
+	if err != nil {
+		slog.Debug("Unable to fetch sitemap", slog.String("sitemap_url", sitemapURL), slog.Any("error", err))
+		return ""
+	}
+
+	if format, _ := parser.DetectFeedFormat(bytes.NewReader(document)); format == parser.FormatSitemap {
+		if feed, err := sitemap.Parse(sitemapURL, bytes.NewReader(document)); err == nil && len(feed.Entries) > 0 {
+			return sitemapURL
+		}
+		return ""
+	}
+
+	if !followIndex {
+		return ""
+	}
+
+	childURLs, err := sitemap.ParseIndex(sitemapURL, bytes.NewReader(document))
+	if err != nil || len(childURLs) == 0 {
+		return ""
+	}
+
+	return f.findSitemapWithPages(childURLs[0], false)
+}
+
+// rewriteWithAlternativeFrontend replaces the scheme and the host of the website by the alternative frontend configured for its hostname.
+// The path of the frontend, if any, is prepended to the path of the website.
+func rewriteWithAlternativeFrontend(websiteURL string) string {
+	frontends := config.Opts.SubscriptionAlternativeFrontends()
+	if len(frontends) == 0 {
+		return websiteURL
+	}
+
+	parsedWebsiteURL, err := url.Parse(websiteURL)
+	if err != nil || parsedWebsiteURL.Host == "" {
+		return websiteURL
+	}
+
+	// The subdomains, for example "mobile.twitter.com", use the frontend of the domain.
+	for hostname := strings.ToLower(parsedWebsiteURL.Hostname()); hostname != ""; _, hostname, _ = strings.Cut(hostname, ".") {
+		frontend, found := frontends[hostname]
+		if !found {
+			continue
+		}
+
+		parsedFrontendURL, err := url.Parse(frontend)
+		if err != nil || parsedFrontendURL.Host == "" {
+			return websiteURL
+		}
+
+		rewrittenURL := *parsedWebsiteURL
+		rewrittenURL.Scheme = parsedFrontendURL.Scheme
+		rewrittenURL.Host = parsedFrontendURL.Host
+		rewrittenURL.User = nil
+		rewrittenURL.Path = parsedFrontendURL.Path + parsedWebsiteURL.Path
+		rewrittenURL.RawPath = ""
+		return rewrittenURL.String()
+	}
+
+	return websiteURL
+}
@@ -4,8 +4,15 @@
 package subscription
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/parser"
 )
 
 func TestFindYoutubePlaylistFeed(t *testing.T) {
@@ -125,6 +132,11 @@ func TestFindYoutubeChannelFeed(t *testing.T) {
 			websiteURL: "https://www.youtube.com/channel/UC-Qj80avWItNRjkZ41rzHyw",
 			feedURL:    "https://www.youtube.com/feeds/videos.xml?channel_id=UC-Qj80avWItNRjkZ41rzHyw",
 		},
+		// Channel URL with a tab
+		{
+			websiteURL: "https://www.youtube.com/channel/UC-Qj80avWItNRjkZ41rzHyw/videos",
+			feedURL:    "https://www.youtube.com/feeds/videos.xml?channel_id=UC-Qj80avWItNRjkZ41rzHyw",
+		},
 		// Channel URL with name
 		{
 			websiteURL: "https://www.youtube.com/@ABCDEFG",
@@ -481,3 +493,247 @@ func TestParseWebPageWithNoHref(t *testing.T) {
 		t.Fatal(`Incorrect number of subscriptions returned`)
 	}
 }
+
+func TestFindYoutubeHandleFeed(t *testing.T) {
+	scenarios := []struct {
+		websiteURL string
+		page       string
+		feedURL    string
+	}{
+		// Handle URL with the channel ID in the meta tags
+		{
+			websiteURL: "https://www.youtube.com/@ABCDEFG",
+			page:       `<html><head><meta itemprop="channelId" content="UC-Qj80avWItNRjkZ41rzHyw"></head></html>`,
+			feedURL:    "https://www.youtube.com/feeds/videos.xml?channel_id=UC-Qj80avWItNRjkZ41rzHyw",
+		},
+		// Handle URL with a tab and the channel ID in the canonical link
+		{
+			websiteURL: "https://www.youtube.com/@ABCDEFG/videos",
+			page:       `<html><head><link rel="canonical" href="https://www.youtube.com/channel/UC-Qj80avWItNRjkZ41rzHyw"></head></html>`,
+			feedURL:    "https://www.youtube.com/feeds/videos.xml?channel_id=UC-Qj80avWItNRjkZ41rzHyw",
+		},
+		// Custom URL with the channel ID in the embedded JSON data
+		{
+			websiteURL: "https://m.youtube.com/c/ABCDEFG",
+			page:       `<html><body><script>var ytInitialData = {"metadata":{"channelMetadataRenderer":{"externalId":"UC-Qj80avWItNRjkZ41rzHyw"}}};</script></body></html>`,
+			feedURL:    "https://www.youtube.com/feeds/videos.xml?channel_id=UC-Qj80avWItNRjkZ41rzHyw",
+		},
+		// Legacy user URL without any channel ID
+		{
+			websiteURL: "https://www.youtube.com/user/ABCDEFG",
+			page:       `<html><head><title>ABCDEFG</title></head></html>`,
+		},
+		// Channel URL
+		{
+			websiteURL: "https://www.youtube.com/channel/UC-Qj80avWItNRjkZ41rzHyw",
+			page:       `<html><head><meta itemprop="channelId" content="UC-Qj80avWItNRjkZ41rzHyw"></head></html>`,
+		},
+		// Non-Youtube URL
+		{
+			websiteURL: "https://www.example.com/@ABCDEFG",
+			page:       `<html><head><meta itemprop="channelId" content="UC-Qj80avWItNRjkZ41rzHyw"></head></html>`,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		subscriptions, localizedError := NewSubscriptionFinder(nil).FindSubscriptionsFromYouTubeHandlePage(scenario.websiteURL, strings.NewReader(scenario.page))
+		if localizedError != nil {
+			t.Fatalf(`Parsing a YouTube handle page should not return any error: %v`, localizedError)
+		}
+
+		if scenario.feedURL == "" {
+			if len(subscriptions) > 0 {
+				t.Fatalf(`Parsing %q should not return any subscription`, scenario.websiteURL)
+			}
+			continue
+		}
+
+		if len(subscriptions) != 1 {
+			t.Fatalf(`Incorrect number of subscriptions returned for %q`, scenario.websiteURL)
+		}
+
+		if subscriptions[0].URL != scenario.feedURL {
+			t.Errorf(`Unexpected Feed, got %s, instead of %s`, subscriptions[0].URL, scenario.feedURL)
+		}
+	}
+}
+
+func TestFindRedditFeed(t *testing.T) {
+	scenarios := map[string]string{
+		"https://www.reddit.com/r/golang":                                     "https://www.reddit.com/r/golang/.rss",
+		"https://old.reddit.com/r/golang/":                                    "https://www.reddit.com/r/golang/.rss",
+		"https://reddit.com/r/golang/top/?t=week":                             "https://www.reddit.com/r/golang/top/.rss?t=week",
+		"https://www.reddit.com/r/golang+rust/new":                            "https://www.reddit.com/r/golang+rust/new/.rss",
+		"https://www.reddit.com/user/spez/submitted/":                         "https://www.reddit.com/user/spez/submitted/.rss",
+		"https://www.reddit.com/u/spez":                                       "https://www.reddit.com/user/spez/.rss",
+		"https://www.reddit.com/user/spez/m/programming/":                     "https://www.reddit.com/user/spez/m/programming/.rss",
+		"https://www.reddit.com/search/?q=miniflux&sort=new":                  "https://www.reddit.com/search.rss?q=miniflux&sort=new",
+		"https://www.reddit.com/r/selfhosted/search?q=miniflux&restrict_sr=1": "https://www.reddit.com/r/selfhosted/search.rss?q=miniflux&restrict_sr=1",
+		"https://www.reddit.com/r/golang/comments/abc123/some_post/":          "",
+		"https://www.reddit.com/r/golang/.rss":                                "",
+		"https://www.reddit.com/":                                             "",
+		"https://www.notreddit.com/r/golang":                                  "",
+	}
+
+	for websiteURL, feedURL := range scenarios {
+		subscriptions, localizedError := NewSubscriptionFinder(nil).FindSubscriptionsFromRedditPage(websiteURL)
+		if localizedError != nil {
+			t.Fatalf(`Parsing a Reddit URL should not return any error: %v`, localizedError)
+		}
+
+		if feedURL == "" {
+			if len(subscriptions) > 0 {
+				t.Errorf(`Parsing %q should not return any subscription, got %s`, websiteURL, subscriptions[0].URL)
+			}
+			continue
+		}
+
+		if len(subscriptions) != 1 {
+			t.Fatalf(`Incorrect number of subscriptions returned for %q`, websiteURL)
+		}
+
+		if subscriptions[0].URL != feedURL {
+			t.Errorf(`Unexpected feed for %q, got %s, instead of %s`, websiteURL, subscriptions[0].URL, feedURL)
+		}
+	}
+
+	if _, localizedError := NewSubscriptionFinder(nil).FindSubscriptionsFromRedditPage("https://example|org/"); localizedError == nil {
+		t.Error(`Parsing an invalid URL should return an error`)
+	}
+}
+
+func TestFindFediverseProfileFeed(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/webfinger":
+			if r.URL.Query().Get("resource") != "acct:alice@"+r.Host {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(`{"links": [{"rel": "self", "type": "application/activity+json", "href": "` + server.URL + `/users/alice"}]}`))
+		case "/users/alice":
+			if r.Header.Get("Accept") != "application/activity+json" {
+				w.Write([]byte(`<html></html>`))
+				return
+			}
+			w.Write([]byte(`{"id": "` + server.URL + `/users/alice", "type": "Person", "name": "Alice", "outbox": "` + server.URL + `/users/alice/outbox"}`))
+		case "/users/alice/outbox":
+			w.Write([]byte(`{"type": "OrderedCollection", "first": "` + server.URL + `/users/alice/outbox?page=true"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	subscriptions, localizedError := NewSubscriptionFinder(fetcher.NewRequestBuilder()).FindSubscriptionsFromFediverseProfile(server.URL + "/@alice")
+	if localizedError != nil {
+		t.Fatalf(`Finding the Fediverse account should not return any error: %v`, localizedError)
+	}
+
+	if len(subscriptions) != 1 {
+		t.Fatalf(`Incorrect number of subscriptions returned`)
+	}
+
+	if subscriptions[0].Title != "Alice" {
+		t.Errorf(`Unexpected title, got %q`, subscriptions[0].Title)
+	}
+
+	if subscriptions[0].URL != server.URL+"/users/alice/outbox?page=true" {
+		t.Errorf(`Unexpected feed URL, got %q`, subscriptions[0].URL)
+	}
+
+	if subscriptions[0].Type != parser.FormatActivityPub {
+		t.Errorf(`Unexpected feed type, got %q`, subscriptions[0].Type)
+	}
+
+	// The unknown accounts and the other pages are not Fediverse profiles.
+	for _, websiteURL := range []string{server.URL + "/@bob", server.URL + "/about"} {
+		subscriptions, localizedError := NewSubscriptionFinder(fetcher.NewRequestBuilder()).FindSubscriptionsFromFediverseProfile(websiteURL)
+		if localizedError != nil || len(subscriptions) != 0 {
+			t.Errorf(`No subscription should be found for %q, got %v, %v`, websiteURL, subscriptions, localizedError)
+		}
+	}
+}
+
+func TestFindSitemapFeed(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			w.Write([]byte("User-agent: *\nDisallow: /admin\nSitemap: /sitemap_index.xml\n"))
+		case "/sitemap_index.xml":
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+				<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+					<sitemap><loc>/pages.xml</loc><lastmod>2025-01-01</lastmod></sitemap>
+					<sitemap><loc>/posts.xml</loc><lastmod>2026-10-01T10:00:00+00:00</lastmod></sitemap>
+				</sitemapindex>`))
+		case "/posts.xml":
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+				<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+					<url><loc>/posts/1</loc><lastmod>2026-10-01</lastmod></url>
+				</urlset>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	subscriptions, localizedError := NewSubscriptionFinder(fetcher.NewRequestBuilder()).FindSubscriptionsFromSitemap(server.URL + "/about")
+	if localizedError != nil {
+		t.Fatalf(`Finding the sitemap should not return any error: %v`, localizedError)
+	}
+
+	if len(subscriptions) != 1 {
+		t.Fatalf(`Incorrect number of subscriptions returned`)
+	}
+
+	if subscriptions[0].URL != server.URL+"/posts.xml" {
+		t.Errorf(`Unexpected feed URL, got %q`, subscriptions[0].URL)
+	}
+
+	if subscriptions[0].Type != parser.FormatSitemap {
+		t.Errorf(`Unexpected feed type, got %q`, subscriptions[0].Type)
+	}
+}
+
+func TestFindSitemapFeedWithoutSitemap(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	subscriptions, localizedError := NewSubscriptionFinder(fetcher.NewRequestBuilder()).FindSubscriptionsFromSitemap(server.URL)
+	if localizedError != nil || len(subscriptions) != 0 {
+		t.Errorf(`No subscription should be found, got %v, %v`, subscriptions, localizedError)
+	}
+}
+
+func TestRewriteWithAlternativeFrontend(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SUBSCRIPTION_ALTERNATIVE_FRONTENDS", "twitter.com=https://nitter.example.org/, reddit.com = https://frontends.example.org/teddit")
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	scenarios := map[string]string{
+		"https://twitter.com/miniflux":                "https://nitter.example.org/miniflux",
+		"https://mobile.Twitter.com/miniflux?lang=en": "https://nitter.example.org/miniflux?lang=en",
+		"http://www.reddit.com/r/golang/":             "https://frontends.example.org/teddit/r/golang/",
+		"https://nottwitter.com/miniflux":             "https://nottwitter.com/miniflux",
+		"https://example.org/twitter.com":             "https://example.org/twitter.com",
+		"not a URL":                                   "not a URL",
+	}
+
+	for websiteURL, expected := range scenarios {
+		if result := rewriteWithAlternativeFrontend(websiteURL); result != expected {
+			t.Errorf(`Unexpected URL for %q, got %q instead of %q`, websiteURL, result, expected)
+		}
+	}
+}
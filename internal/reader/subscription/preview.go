@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package subscription // import "miniflux.app/v2/internal/reader/subscription"
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/parser"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// SubscriptionPreview summarizes the content of a discovered feed.
+type SubscriptionPreview struct {
+	EntryCount int
+	UpdatedAt  time.Time
+}
+
+const (
+	// maxSubscriptionPreviews is the number of discovered feeds downloaded to show a preview, the other ones are listed without it.
+	maxSubscriptionPreviews = 20
+
+	// maxConcurrentPreviews is the number of discovered feeds downloaded at the same time.
+	maxConcurrentPreviews = 4
+)
+
+// PreviewSubscriptions downloads the discovered feeds in parallel to show their title, entry count and last update.
+// Each download gets its own request builder, the credentials are only sent to the host of the website URL entered by the user.
+// The subscriptions that cannot be downloaded or parsed don't have a preview.
+func PreviewSubscriptions(subscriptions Subscriptions, websiteURL string, newRequestBuilder func(withCredentials bool) *fetcher.RequestBuilder) {
+	websiteHost := urllib.Domain(websiteURL)
+	semaphore := make(chan struct{}, maxConcurrentPreviews)
+
+	var wg sync.WaitGroup
+	for i, subscription := range subscriptions {
+		if i >= maxSubscriptionPreviews {
+			break
+		}
+
+		wg.Add(1)
+		go func(subscription *Subscription) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			withCredentials := strings.EqualFold(urllib.Domain(subscription.URL), websiteHost)
+			feed, err := fetchSubscriptionFeed(newRequestBuilder(withCredentials), subscription.URL)
+			if err != nil {
+				slog.Debug("Unable to preview subscription",
+					slog.String("feed_url", subscription.URL),
+					slog.Any("error", err),
+				)
+				return
+			}
+
+			if feed.Title != "" {
+				subscription.Title = feed.Title
+			}
+			subscription.Preview = newSubscriptionPreview(feed)
+		}(subscription)
+	}
+	wg.Wait()
+}
+
+func fetchSubscriptionFeed(requestBuilder *fetcher.RequestBuilder, feedURL string) (*model.Feed, error) {
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(feedURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	if localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	return parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+}
+
+func newSubscriptionPreview(feed *model.Feed) *SubscriptionPreview {
+	preview := &SubscriptionPreview{EntryCount: len(feed.Entries)}
+	for _, entry := range feed.Entries {
+		if entry.Date.After(preview.UpdatedAt) {
+			preview.UpdatedAt = entry.Date
+		}
+	}
+	return preview
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package subscription // import "miniflux.app/v2/internal/reader/subscription"
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
+)
+
+func TestPreviewSubscriptions(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed.xml":
+			w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+				<rss version="2.0">
+				<channel>
+					<title>Example Blog</title>
+					<link>https://example.org/</link>
+					<item>
+						<title>First post</title>
+						<link>https://example.org/first</link>
+						<pubDate>Tue, 01 Oct 2024 10:00:00 GMT</pubDate>
+					</item>
+					<item>
+						<title>Second post</title>
+						<link>https://example.org/second</link>
+						<pubDate>Wed, 02 Oct 2024 10:00:00 GMT</pubDate>
+					</item>
+				</channel>
+				</rss>`))
+		case "/comments.xml":
+			w.Write([]byte(`<html><body>Not a feed</body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	subscriptions := Subscriptions{
+		NewSubscription("RSS", server.URL+"/feed.xml", "rss"),
+		NewSubscription("Comments", server.URL+"/comments.xml", "rss"),
+		NewSubscription("Missing", server.URL+"/missing.xml", "atom"),
+	}
+
+	PreviewSubscriptions(subscriptions, server.URL, func(bool) *fetcher.RequestBuilder {
+		return fetcher.NewRequestBuilder()
+	})
+
+	if subscriptions[0].Title != "Example Blog" {
+		t.Errorf(`The title of the feed should replace the discovered title, got %q`, subscriptions[0].Title)
+	}
+
+	if subscriptions[0].Preview == nil {
+		t.Fatal(`The feed should have a preview`)
+	}
+
+	if subscriptions[0].Preview.EntryCount != 2 {
+		t.Errorf(`Incorrect entry count, got %d`, subscriptions[0].Preview.EntryCount)
+	}
+
+	expectedDate := time.Date(2024, time.October, 2, 10, 0, 0, 0, time.UTC)
+	if !subscriptions[0].Preview.UpdatedAt.Equal(expectedDate) {
+		t.Errorf(`Incorrect last update, got %v instead of %v`, subscriptions[0].Preview.UpdatedAt, expectedDate)
+	}
+
+	for _, subscription := range subscriptions[1:] {
+		if subscription.Preview != nil {
+			t.Errorf(`The subscription %q should not have a preview`, subscription.URL)
+		}
+	}
+
+	if subscriptions[1].Title != "Comments" {
+		t.Errorf(`The discovered title should be kept when the feed cannot be parsed, got %q`, subscriptions[1].Title)
+	}
+}
+
+func TestPreviewSubscriptionsOnlySendsCredentialsToTheWebsiteHost(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	newFeedHandler := func(expectedCookie string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if cookie := r.Header.Get("Cookie"); cookie != expectedCookie {
+				http.Error(w, "unexpected cookie: "+cookie, http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?><rss version="2.0"><channel><title>Feed</title></channel></rss>`))
+		}
+	}
+
+	websiteServer := httptest.NewServer(newFeedHandler("session=secret"))
+	defer websiteServer.Close()
+
+	otherServer := httptest.NewServer(newFeedHandler(""))
+	defer otherServer.Close()
+
+	subscriptions := Subscriptions{
+		NewSubscription("Website", websiteServer.URL+"/feed.xml", "rss"),
+		NewSubscription("Other", otherServer.URL+"/feed.xml", "rss"),
+	}
+
+	PreviewSubscriptions(subscriptions, websiteServer.URL+"/", func(withCredentials bool) *fetcher.RequestBuilder {
+		requestBuilder := fetcher.NewRequestBuilder()
+		if withCredentials {
+			requestBuilder.WithCookie("session=secret")
+		}
+		return requestBuilder
+	})
+
+	if subscriptions[0].Preview == nil {
+		t.Error(`The cookie should be sent to the website host`)
+	}
+
+	if subscriptions[1].Preview == nil {
+		t.Error(`The cookie should not be sent to another host`)
+	}
+}
+
+func TestPreviewSubscriptionsIsLimited(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	var mu sync.Mutex
+	var requests, running, maxRunning int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?><rss version="2.0"><channel><title>Feed</title></channel></rss>`))
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	var subscriptions Subscriptions
+	for i := range maxSubscriptionPreviews + 10 {
+		subscriptions = append(subscriptions, NewSubscription("Feed", fmt.Sprintf("%s/feed%d.xml", server.URL, i), "rss"))
+	}
+
+	PreviewSubscriptions(subscriptions, server.URL, func(bool) *fetcher.RequestBuilder {
+		return fetcher.NewRequestBuilder()
+	})
+
+	if requests != maxSubscriptionPreviews {
+		t.Errorf(`Only %d feeds should be downloaded, got %d`, maxSubscriptionPreviews, requests)
+	}
+
+	if maxRunning > maxConcurrentPreviews {
+		t.Errorf(`Only %d feeds should be downloaded at the same time, got %d`, maxConcurrentPreviews, maxRunning)
+	}
+
+	if subscriptions[len(subscriptions)-1].Preview != nil {
+		t.Error(`The subscriptions over the limit should not have a preview`)
+	}
+}
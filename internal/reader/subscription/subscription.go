@@ -10,6 +10,9 @@ type Subscription struct {
 	Title string `json:"title"`
 	URL   string `json:"url"`
 	Type  string `json:"type"`
+
+	// Only loaded when the discovered feeds are previewed before subscribing.
+	Preview *SubscriptionPreview `json:"-"`
 }
 
 func NewSubscription(title, url, kind string) *Subscription {
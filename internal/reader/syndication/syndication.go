@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package syndication // import "miniflux.app/v2/internal/reader/syndication"
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SyndicationChannelElement contains the update cadence advertised by the publisher.
+// Specs: https://web.resource.org/rss/1.0/modules/syndication/
+type SyndicationChannelElement struct {
+	SyndicationUpdatePeriod    string `xml:"http://purl.org/rss/1.0/modules/syndication/ updatePeriod"`
+	SyndicationUpdateFrequency string `xml:"http://purl.org/rss/1.0/modules/syndication/ updateFrequency"`
+}
+
+var updatePeriodMinutes = map[string]int{
+	"hourly":  60,
+	"daily":   24 * 60,
+	"weekly":  7 * 24 * 60,
+	"monthly": 30 * 24 * 60,
+	"yearly":  365 * 24 * 60,
+}
+
+// UpdateInterval returns the number of minutes between two updates of the channel, or 0 if the period is not defined.
+// The frequency is the number of updates during the period, it defaults to 1.
+func (s *SyndicationChannelElement) UpdateInterval() int {
+	minutes, found := updatePeriodMinutes[strings.ToLower(strings.TrimSpace(s.SyndicationUpdatePeriod))]
+	if !found {
+		return 0
+	}
+
+	if frequency, err := strconv.Atoi(strings.TrimSpace(s.SyndicationUpdateFrequency)); err == nil && frequency > 1 {
+		return minutes / frequency
+	}
+
+	return minutes
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package syndication // import "miniflux.app/v2/internal/reader/syndication"
+
+import "testing"
+
+func TestUpdateInterval(t *testing.T) {
+	scenarios := []struct {
+		period    string
+		frequency string
+		expected  int
+	}{
+		{"", "", 0},
+		{"unknown", "2", 0},
+		{"hourly", "", 60},
+		{"hourly", "2", 30},
+		{" Daily ", "1", 1440},
+		{"daily", "invalid", 1440},
+		{"weekly", "0", 10080},
+		{"yearly", "", 525600},
+	}
+
+	for _, scenario := range scenarios {
+		element := SyndicationChannelElement{SyndicationUpdatePeriod: scenario.period, SyndicationUpdateFrequency: scenario.frequency}
+		if result := element.UpdateInterval(); result != scenario.expected {
+			t.Errorf(`Unexpected interval for %q/%q, got %d instead of %d`, scenario.period, scenario.frequency, result, scenario.expected)
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+
+	"miniflux.app/v2/internal/config"
 )
 
 // Interesting lists:
@@ -79,10 +81,11 @@ func RemoveTrackingParameters(inputURL string) (string, error) {
 
 	queryParams := parsedURL.Query()
 	hasTrackers := false
+	hostname := strings.ToLower(parsedURL.Hostname())
 
 	// Remove tracking parameters
 	for param := range queryParams {
-		if trackingParams[strings.ToLower(param)] {
+		if isTrackingParameter(hostname, strings.ToLower(param)) {
 			queryParams.Del(param)
 			hasTrackers = true
 		}
@@ -101,3 +104,38 @@ func RemoveTrackingParameters(inputURL string) (string, error) {
 
 	return cleanedURL, nil
 }
+
+// isTrackingParameter returns true if the parameter must be removed from the URLs of the hostname.
+// The exceptions configured by the administrator take precedence over the built-in and the configured parameters.
+func isTrackingParameter(hostname, param string) bool {
+	if config.Opts == nil {
+		return trackingParams[param]
+	}
+
+	for _, exception := range config.Opts.TrackingParametersExceptions() {
+		if domain, name, found := strings.Cut(exception, ":"); found && name == param && matchDomain(hostname, domain) {
+			return false
+		}
+	}
+
+	if trackingParams[param] {
+		return true
+	}
+
+	for _, trackingParam := range config.Opts.TrackingParameters() {
+		domain, name, found := strings.Cut(trackingParam, ":")
+		if !found {
+			name = trackingParam
+		}
+
+		if name == param && (!found || matchDomain(hostname, domain)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchDomain(hostname, domain string) bool {
+	return hostname == domain || strings.HasSuffix(hostname, "."+domain)
+}
@@ -5,8 +5,11 @@ package urlcleaner // import "miniflux.app/v2/internal/reader/urlcleaner"
 
 import (
 	"net/url"
+	"os"
 	"reflect"
 	"testing"
+
+	"miniflux.app/v2/internal/config"
 )
 
 func TestRemoveTrackingParams(t *testing.T) {
@@ -103,6 +106,41 @@ func TestRemoveTrackingParams(t *testing.T) {
 	}
 }
 
+func TestRemoveConfiguredTrackingParams(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("TRACKING_PARAMETERS", "Ref, example.org:id")
+	os.Setenv("TRACKING_PARAMETERS_EXCEPTIONS", "example.net:ref, example.com:utm_source")
+	defer os.Clearenv()
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+	defer func() { config.Opts = nil }()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"https://example.org/page?ref=feed&id=123&page=2", "https://example.org/page?page=2"},
+		{"https://blog.example.org/page?id=123", "https://blog.example.org/page"},
+		{"https://notexample.org/page?id=123&ref=feed", "https://notexample.org/page?id=123"},
+		{"https://www.example.net/page?ref=feed", "https://www.example.net/page?ref=feed"},
+		{"https://example.com/page?utm_source=feed&utm_medium=email", "https://example.com/page?utm_source=feed"},
+	}
+
+	for _, tt := range tests {
+		result, err := RemoveTrackingParameters(tt.input)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !urlsEqual(result, tt.expected) {
+			t.Errorf("removeTrackingParams(%q) = %q, want %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
 // urlsEqual compares two URLs for equality, ignoring the order of query parameters
 func urlsEqual(url1, url2 string) bool {
 	u1, err1 := url.Parse(url1)
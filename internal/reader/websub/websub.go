@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package websub subscribes the feeds to the hubs advertised by the publishers.
+// See https://www.w3.org/TR/websub/.
+package websub // import "miniflux.app/v2/internal/reader/websub"
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/version"
+)
+
+const defaultClientTimeout = 10 * time.Second
+
+// CallbackURL returns the URL where the hub verifies the subscription of the feed and sends its new content.
+func CallbackURL(feedID int64) string {
+	return config.Opts.BaseURL() + "/websub/" + strconv.FormatInt(feedID, 10)
+}
+
+// Subscribe asks the hub to send the updates of the topic to the callback URL.
+// The hub verifies the intent of the subscriber afterward, by sending a request to the callback URL.
+func Subscribe(hubURL, topicURL, callbackURL, secret string, lease time.Duration) error {
+	return sendRequest(hubURL, url.Values{
+		"hub.mode":          {"subscribe"},
+		"hub.topic":         {topicURL},
+		"hub.callback":      {callbackURL},
+		"hub.secret":        {secret},
+		"hub.lease_seconds": {strconv.Itoa(int(lease.Seconds()))},
+	})
+}
+
+func sendRequest(hubURL string, values url.Values) error {
+	request, err := http.NewRequest(http.MethodPost, hubURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return fmt.Errorf("websub: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("websub: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("websub: the hub rejected the request: url=%s status=%d", hubURL, response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package websub // import "miniflux.app/v2/internal/reader/websub"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf(`Unexpected method %q`, r.Method)
+		}
+
+		if r.FormValue("hub.mode") != "subscribe" ||
+			r.FormValue("hub.topic") != "https://example.org/feed" ||
+			r.FormValue("hub.callback") != "https://miniflux.example.org/websub/1" ||
+			r.FormValue("hub.secret") != "secret" ||
+			r.FormValue("hub.lease_seconds") != "3600" {
+			t.Errorf(`Unexpected form %v`, r.Form)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	if err := Subscribe(server.URL, "https://example.org/feed", "https://miniflux.example.org/websub/1", "secret", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubscribeRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	if err := Subscribe(server.URL, "https://example.org/feed", "https://miniflux.example.org/websub/1", "secret", time.Hour); err == nil {
+		t.Fatal(`A rejected request should return an error`)
+	}
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "miniflux.app/v2/internal/reader/xml"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"miniflux.app/v2/internal/reader/encoding"
+	"miniflux.app/v2/internal/urllib"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	// The attributes containing an URL, for example the links, the enclosures and the images of XHTML contents.
+	urlAttributes = map[string]bool{"href": true, "src": true, "url": true, "poster": true}
+
+	// The elements containing an URL, for example the RSS links and the Atom icons.
+	urlElements = map[string]bool{"link": true, "comments": true, "commentRss": true, "icon": true, "logo": true, "uri": true, "url": true}
+
+	// The elements containing escaped HTML.
+	htmlElements = map[string]bool{"description": true, "encoded": true, "content": true, "summary": true}
+)
+
+type baseElement struct {
+	base     string
+	declared bool
+	isURL    bool
+	isHTML   bool
+}
+
+// ResolveXMLBase makes the relative URLs of the document absolute according to the xml:base attributes.
+// The base URL is inherited by the children of the element declaring it and a relative xml:base is resolved against its parent.
+// Only the URLs of the elements having a xml:base attribute or one of their ancestors are modified, the other relative URLs
+// are resolved by the parsers as usual.
+// See https://www.w3.org/TR/xmlbase/ and https://datatracker.ietf.org/doc/html/rfc4287#section-2.
+func ResolveXMLBase(documentURL string, data []byte) []byte {
+	if !bytes.Contains(data, []byte("xml:base")) {
+		return data
+	}
+
+	data = encoding.ConvertToUTF8(data, procInst("encoding", string(data)))
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Entity = xml.HTMLEntity
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	var output bytes.Buffer
+	var stack []baseElement
+	var pendingText strings.Builder
+	var offset int64
+
+	flushPendingText := func() {
+		if pendingText.Len() == 0 {
+			return
+		}
+
+		current := stack[len(stack)-1]
+		text := pendingText.String()
+		pendingText.Reset()
+
+		switch {
+		case current.isURL:
+			if absoluteURL, err := urllib.AbsoluteURL(current.base, strings.TrimSpace(text)); err == nil && strings.TrimSpace(text) != "" {
+				text = absoluteURL
+			}
+		case current.isHTML:
+			text = resolveHTMLURLs(current.base, text)
+		}
+
+		xml.EscapeText(&output, []byte(text))
+	}
+
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			break
+		}
+
+		end := decoder.InputOffset()
+		raw := data[offset:end]
+		offset = end
+
+		if charData, ok := token.(xml.CharData); ok && len(stack) > 0 && stack[len(stack)-1].declared && (stack[len(stack)-1].isURL || stack[len(stack)-1].isHTML) {
+			pendingText.Write(charData)
+			continue
+		}
+
+		if len(stack) > 0 {
+			flushPendingText()
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			current := baseElement{base: documentURL}
+			if len(stack) > 0 {
+				current.base, current.declared = stack[len(stack)-1].base, stack[len(stack)-1].declared
+			}
+
+			contentType := ""
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "xml" && attr.Name.Local == "base":
+					if base, err := urllib.AbsoluteURL(current.base, strings.TrimSpace(attr.Value)); err == nil {
+						current.base, current.declared = base, true
+					}
+				case attr.Name.Space == "" && attr.Name.Local == "type":
+					contentType = strings.ToLower(attr.Value)
+				}
+			}
+
+			current.isURL = urlElements[t.Name.Local]
+			current.isHTML = htmlElements[t.Name.Local] && contentType != "text" && contentType != "xhtml"
+			stack = append(stack, current)
+
+			if current.declared {
+				writeStartElement(&output, t, current.base, bytes.HasSuffix(raw, []byte("/>")))
+			} else {
+				output.Write(raw)
+			}
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			output.Write(raw)
+		default:
+			output.Write(raw)
+		}
+	}
+
+	output.Write(data[offset:])
+	return output.Bytes()
+}
+
+// writeStartElement writes the start tag with its URL attributes resolved against the base URL.
+// The names are written with their original prefixes, the raw tokens are not translated.
+func writeStartElement(output *bytes.Buffer, element xml.StartElement, base string, selfClosing bool) {
+	output.WriteByte('<')
+	output.WriteString(qualifiedName(element.Name))
+
+	for _, attr := range element.Attr {
+		value := attr.Value
+		if attr.Name.Space == "" && urlAttributes[attr.Name.Local] && strings.TrimSpace(value) != "" {
+			if absoluteURL, err := urllib.AbsoluteURL(base, strings.TrimSpace(value)); err == nil {
+				value = absoluteURL
+			}
+		}
+
+		output.WriteByte(' ')
+		output.WriteString(qualifiedName(attr.Name))
+		output.WriteString(`="`)
+		xml.EscapeText(output, []byte(value))
+		output.WriteByte('"')
+	}
+
+	if selfClosing {
+		output.WriteString("/>")
+	} else {
+		output.WriteByte('>')
+	}
+}
+
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// resolveHTMLURLs makes the relative URLs of a HTML fragment absolute, the rest of the fragment is kept as is.
+func resolveHTMLURLs(base, fragment string) string {
+	var output strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(fragment))
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			return output.String()
+		}
+
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			output.Write(tokenizer.Raw())
+			continue
+		}
+
+		raw := string(tokenizer.Raw())
+		token := tokenizer.Token()
+		modified := false
+		for index, attr := range token.Attr {
+			if attr.Namespace == "" && urlAttributes[attr.Key] && strings.TrimSpace(attr.Val) != "" && !urllib.IsAbsoluteURL(strings.TrimSpace(attr.Val)) {
+				if absoluteURL, err := urllib.AbsoluteURL(base, strings.TrimSpace(attr.Val)); err == nil {
+					token.Attr[index].Val = absoluteURL
+					modified = true
+				}
+			}
+		}
+
+		if modified {
+			output.WriteString(token.String())
+		} else {
+			output.WriteString(raw)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "miniflux.app/v2/internal/reader/xml"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveXMLBaseWithoutBase(t *testing.T) {
+	data := `<rss><channel><link>/blog</link></channel></rss>`
+	if result := string(ResolveXMLBase("https://example.org/feed.xml", []byte(data))); result != data {
+		t.Errorf(`The document without xml:base should not be modified, got: %q`, result)
+	}
+}
+
+func TestResolveXMLBaseHierarchy(t *testing.T) {
+	data := `<feed xmlns="http://www.w3.org/2005/Atom" xml:base="/blog/">` +
+		`<link href="index.html"/>` +
+		`<entry xml:base="2026/">` +
+		`<link rel="alternate" href="article.html"/>` +
+		`<link rel="enclosure" xml:base="https://cdn.example.org/media/" href="episode.mp3"/>` +
+		`</entry>` +
+		`</feed>`
+
+	expected := `<feed xmlns="http://www.w3.org/2005/Atom" xml:base="/blog/">` +
+		`<link href="https://example.org/blog/index.html"/>` +
+		`<entry xml:base="2026/">` +
+		`<link rel="alternate" href="https://example.org/blog/2026/article.html"/>` +
+		`<link rel="enclosure" xml:base="https://cdn.example.org/media/" href="https://cdn.example.org/media/episode.mp3"/>` +
+		`</entry>` +
+		`</feed>`
+
+	if result := string(ResolveXMLBase("https://example.org/feed.xml", []byte(data))); result != expected {
+		t.Errorf(`Incorrect document, got: %q`, result)
+	}
+}
+
+func TestResolveXMLBaseOnlyInsideTheDeclaringElement(t *testing.T) {
+	data := `<channel><item xml:base="https://example.org/a/"><link>1.html</link></item><item><link>2.html</link></item></channel>`
+	expected := `<channel><item xml:base="https://example.org/a/"><link>https://example.org/a/1.html</link></item><item><link>2.html</link></item></channel>`
+
+	if result := string(ResolveXMLBase("https://example.org/feed.xml", []byte(data))); result != expected {
+		t.Errorf(`Incorrect document, got: %q`, result)
+	}
+}
+
+func TestResolveXMLBaseInEscapedHTML(t *testing.T) {
+	data := `<item xml:base="https://example.org/posts/"><description>&lt;p&gt;&lt;a href="1.html"&gt;Link&lt;/a&gt; &lt;img src="/image.png"&gt;&lt;/p&gt;</description>` +
+		`<content:encoded><![CDATA[<a href="https://other.example/">Absolute</a>]]></content:encoded></item>`
+
+	result := string(ResolveXMLBase("https://example.org/feed.xml", []byte(data)))
+
+	for _, expected := range []string{
+		`&lt;a href=&#34;https://example.org/posts/1.html&#34;&gt;Link&lt;/a&gt;`,
+		`&lt;img src=&#34;https://example.org/image.png&#34;&gt;`,
+		`&lt;a href=&#34;https://other.example/&#34;&gt;Absolute&lt;/a&gt;`,
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf(`The document should contain %q, got: %q`, expected, result)
+		}
+	}
+}
+
+func TestResolveXMLBaseInXHTMLContent(t *testing.T) {
+	data := `<content type="xhtml" xml:base="https://example.org/posts/"><div xmlns="http://www.w3.org/1999/xhtml"><a href="1.html">1 &lt; 2</a></div></content>`
+	expected := `<content type="xhtml" xml:base="https://example.org/posts/"><div xmlns="http://www.w3.org/1999/xhtml"><a href="https://example.org/posts/1.html">1 &lt; 2</a></div></content>`
+
+	if result := string(ResolveXMLBase("https://example.org/feed.xml", []byte(data))); result != expected {
+		t.Errorf(`Incorrect document, got: %q`, result)
+	}
+}
+
+func TestResolveXMLBaseKeepsTextContent(t *testing.T) {
+	data := `<summary type="text" xml:base="https://example.org/">Use &lt;a href="1.html"&gt;</summary>`
+	if result := string(ResolveXMLBase("https://example.org/feed.xml", []byte(data))); result != data {
+		t.Errorf(`The text content should not be modified, got: %q`, result)
+	}
+}
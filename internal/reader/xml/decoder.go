@@ -6,41 +6,25 @@ package xml // import "miniflux.app/v2/internal/reader/xml"
 import (
 	"bytes"
 	"encoding/xml"
-	"fmt"
 	"io"
 	"strings"
 
 	"miniflux.app/v2/internal/reader/encoding"
 )
 
-// NewXMLDecoder returns a XML decoder that filters illegal characters.
+// NewXMLDecoder returns a XML decoder that converts the document to UTF-8 and filters illegal characters.
 func NewXMLDecoder(data io.ReadSeeker) *xml.Decoder {
-	var decoder *xml.Decoder
 	buffer, _ := io.ReadAll(data)
-	enc := procInst("encoding", string(buffer))
-	if enc != "" && enc != "utf-8" && enc != "UTF-8" && !strings.EqualFold(enc, "utf-8") {
-		// filter invalid chars later within decoder.CharsetReader
-		data.Seek(0, io.SeekStart)
-		decoder = xml.NewDecoder(data)
-	} else {
-		// filter invalid chars now, since decoder.CharsetReader not called for utf-8 content
-		filteredBytes := bytes.Map(filterValidXMLChar, buffer)
-		decoder = xml.NewDecoder(bytes.NewReader(filteredBytes))
-	}
+	buffer = encoding.ConvertToUTF8(buffer, procInst("encoding", string(buffer)))
+	filteredBytes := bytes.Map(filterValidXMLChar, buffer)
 
+	decoder := xml.NewDecoder(bytes.NewReader(filteredBytes))
 	decoder.Entity = xml.HTMLEntity
 	decoder.Strict = false
+
+	// The document is already converted to UTF-8, the encoding specified in the XML prolog is ignored.
 	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
-		utf8Reader, err := encoding.CharsetReader(charset, input)
-		if err != nil {
-			return nil, err
-		}
-		rawData, err := io.ReadAll(utf8Reader)
-		if err != nil {
-			return nil, fmt.Errorf("encoding: unable to read data: %w", err)
-		}
-		filteredBytes := bytes.Map(filterValidXMLChar, rawData)
-		return bytes.NewReader(filteredBytes), nil
+		return input, nil
 	}
 
 	return decoder
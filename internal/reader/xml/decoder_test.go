@@ -81,3 +81,56 @@ func TestXMLDocumentWithIncorrectEncodingField(t *testing.T) {
 		t.Errorf("Incorrect entry title, expected: %s, got: %s", expected, x.Title)
 	}
 }
+
+func TestXMLDocumentWindows1252EncodedDeclaredAsUTF8(t *testing.T) {
+	type myxml struct {
+		XMLName xml.Name `xml:"rss"`
+		Version string   `xml:"version,attr"`
+		Title   string   `xml:"title"`
+	}
+
+	expected := "Café “Au Lait”"
+	data := "<?xml version=\"1.0\" encoding=\"UTF-8\"?><rss version=\"2.0\"><title>Caf\xe9 \x93Au Lait\x94</title></rss>"
+	reader := strings.NewReader(data)
+
+	var x myxml
+
+	decoder := NewXMLDecoder(reader)
+	err := decoder.Decode(&x)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if x.Title != expected {
+		t.Errorf("Incorrect entry title, expected: %s, got: %s", expected, x.Title)
+	}
+}
+
+func TestXMLDocumentUTF16EncodedWithByteOrderMark(t *testing.T) {
+	type myxml struct {
+		XMLName xml.Name `xml:"rss"`
+		Version string   `xml:"version,attr"`
+		Title   string   `xml:"title"`
+	}
+
+	expected := "Café"
+	var data strings.Builder
+	data.WriteString("\xff\xfe")
+	for _, r := range `<?xml version="1.0" encoding="UTF-16"?><rss version="2.0"><title>Café</title></rss>` {
+		data.WriteByte(byte(r))
+		data.WriteByte(byte(r >> 8))
+	}
+	reader := strings.NewReader(data.String())
+
+	var x myxml
+
+	decoder := NewXMLDecoder(reader)
+	err := decoder.Decode(&x)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if x.Title != expected {
+		t.Errorf("Incorrect entry title, expected: %s, got: %s", expected, x.Title)
+	}
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "miniflux.app/v2/internal/reader/xml"
+
+import (
+	"bytes"
+	"regexp"
+
+	"miniflux.app/v2/internal/reader/encoding"
+)
+
+var entityReferenceRegex = regexp.MustCompile(`^&(?:[A-Za-z][A-Za-z0-9]*|#[0-9]+|#[xX][0-9A-Fa-f]+);`)
+
+// Recover repairs the slightly invalid XML documents before decoding them:
+//
+//   - The control characters forbidden by XML are removed
+//   - The ampersands and the lower-than signs that are not escaped are escaped
+//   - The elements closed by another element are closed, the closing tags without opening tags are removed
+//   - The elements still open at the end of the document are closed
+//
+// The comments, the CDATA sections and the processing instructions are kept as is.
+func Recover(data []byte) []byte {
+	// The document is converted to UTF-8 first, the repairs are done byte by byte.
+	data = encoding.ConvertToUTF8(data, procInst("encoding", string(data)))
+
+	// Some servers add spaces or garbage before the XML prolog.
+	if index := bytes.IndexByte(data, '<'); index > 0 {
+		data = data[index:]
+	}
+
+	var stack []string
+	output := make([]byte, 0, len(data))
+
+	for len(data) > 0 {
+		switch {
+		case bytes.HasPrefix(data, []byte("<!--")):
+			output, data = copyUntil(output, data, "-->")
+		case bytes.HasPrefix(data, []byte("<![CDATA[")):
+			output, data = copyUntil(output, data, "]]>")
+		case bytes.HasPrefix(data, []byte("<?")):
+			output, data = copyUntil(output, data, "?>")
+		case bytes.HasPrefix(data, []byte("<!")):
+			// The document type declaration may contain an internal subset between brackets.
+			if end, subset := bytes.IndexByte(data, '>'), bytes.IndexByte(data, '['); subset != -1 && subset < end {
+				output, data = copyUntil(output, data, "]>")
+			} else {
+				output, data = copyUntil(output, data, ">")
+			}
+		case bytes.HasPrefix(data, []byte("</")):
+			end := bytes.IndexByte(data, '>')
+			if end == -1 || bytes.IndexByte(data[1:end], '<') != -1 {
+				output = append(output, "&lt;"...)
+				data = data[1:]
+				continue
+			}
+
+			name := string(bytes.TrimSpace(data[2:end]))
+			data = data[end+1:]
+
+			// The closing tags without matching opening tag are removed.
+			for index := len(stack) - 1; index >= 0; index-- {
+				if stack[index] == name {
+					for len(stack) > index {
+						output = append(output, "</"+stack[len(stack)-1]+">"...)
+						stack = stack[:len(stack)-1]
+					}
+					break
+				}
+			}
+		case data[0] == '<' && len(data) > 1 && isNameStartChar(data[1]):
+			end := tagEnd(data)
+			if end == -1 {
+				// The tag is never closed, the lower-than sign is not the beginning of a tag.
+				output = append(output, "&lt;"...)
+				data = data[1:]
+				continue
+			}
+
+			tag := data[:end+1]
+			data = data[end+1:]
+
+			if !bytes.HasSuffix(tag, []byte("/>")) {
+				stack = append(stack, string(tagName(tag)))
+			}
+			output = append(output, escapeAmpersands(tag)...)
+		case data[0] == '<':
+			output = append(output, "&lt;"...)
+			data = data[1:]
+		case data[0] == '&':
+			if entityReferenceRegex.Match(data) {
+				output = append(output, '&')
+			} else {
+				output = append(output, "&amp;"...)
+			}
+			data = data[1:]
+		case isForbiddenControlChar(data[0]):
+			data = data[1:]
+		default:
+			output = append(output, data[0])
+			data = data[1:]
+		}
+	}
+
+	for index := len(stack) - 1; index >= 0; index-- {
+		output = append(output, "</"+stack[index]+">"...)
+	}
+
+	return output
+}
+
+// copyUntil copies the data until the end marker, the end marker is added if it's missing.
+func copyUntil(output, data []byte, endMarker string) ([]byte, []byte) {
+	end := bytes.Index(data[1:], []byte(endMarker))
+	if end == -1 {
+		return append(append(output, data...), endMarker...), nil
+	}
+
+	end += 1 + len(endMarker)
+	return append(output, data[:end]...), data[end:]
+}
+
+// tagEnd returns the index of the character closing the tag, the quoted attribute values may contain this character.
+func tagEnd(data []byte) int {
+	var quote byte
+	for index, char := range data {
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case char == '>':
+			return index
+		case char == '<' && index > 0:
+			// An opening tag that is never closed.
+			return -1
+		}
+	}
+	return -1
+}
+
+func tagName(tag []byte) []byte {
+	name := tag[1:]
+	if end := bytes.IndexAny(name, " \t\r\n/>"); end != -1 {
+		name = name[:end]
+	}
+	return name
+}
+
+func escapeAmpersands(tag []byte) []byte {
+	if bytes.IndexByte(tag, '&') == -1 {
+		return tag
+	}
+
+	escaped := make([]byte, 0, len(tag))
+	for index, char := range tag {
+		if char == '&' && !entityReferenceRegex.Match(tag[index:]) {
+			escaped = append(escaped, "&amp;"...)
+		} else {
+			escaped = append(escaped, char)
+		}
+	}
+	return escaped
+}
+
+func isNameStartChar(char byte) bool {
+	return char == '_' || char == ':' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= 0x80
+}
+
+func isForbiddenControlChar(char byte) bool {
+	return char < 0x20 && char != 0x09 && char != 0x0A && char != 0x0D
+}
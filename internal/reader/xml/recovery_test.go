@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "miniflux.app/v2/internal/reader/xml"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"valid document", `<?xml version="1.0"?><rss><title>A &amp; B</title></rss>`, `<?xml version="1.0"?><rss><title>A &amp; B</title></rss>`},
+		{"leading spaces", "\n  <rss></rss>", `<rss></rss>`},
+		{"unescaped ampersands", `<a href="/?a=1&b=2">Fish & Chips &eacute; &#233; &#xE9;</a>`, `<a href="/?a=1&amp;b=2">Fish &amp; Chips &eacute; &#233; &#xE9;</a>`},
+		{"unescaped lower-than signs", `<p>1 < 2 and 3 <4</p>`, `<p>1 &lt; 2 and 3 &lt;4</p>`},
+		{"control characters", "<p>a\x00b\x1bc\td</p>", "<p>abc\td</p>"},
+		{"unclosed element", `<item><description>Line<br>Other line</description></item>`, `<item><description>Line<br>Other line</br></description></item>`},
+		{"unexpected closing tag", `<item><title>Title</b></title></item>`, `<item><title>Title</title></item>`},
+		{"truncated document", `<rss><channel><title>Title</title>`, `<rss><channel><title>Title</title></channel></rss>`},
+		{"self-closing element", `<item><enclosure url="a.mp3" /></item>`, `<item><enclosure url="a.mp3" /></item>`},
+		{"comment and CDATA", `<p><!-- a < b & c --><![CDATA[<b>&</b>]]></p>`, `<p><!-- a < b & c --><![CDATA[<b>&</b>]]></p>`},
+		{"unterminated CDATA", `<p><![CDATA[text`, `<p><![CDATA[text]]></p>`},
+		{"document type declaration", `<!DOCTYPE rss [<!ENTITY a "b">]><rss></rss>`, `<!DOCTYPE rss [<!ENTITY a "b">]><rss></rss>`},
+	}
+
+	for _, scenario := range scenarios {
+		if result := string(Recover([]byte(scenario.input))); result != scenario.expected {
+			t.Errorf(`Unexpected result for %s, got %q instead of %q`, scenario.name, result, scenario.expected)
+		}
+	}
+}
+
+func TestRecoverBrokenDocumentCanBeDecoded(t *testing.T) {
+	type myxml struct {
+		XMLName xml.Name `xml:"rss"`
+		Title   string   `xml:"channel>title"`
+		Items   []string `xml:"channel>item>title"`
+	}
+
+	data := `<rss><channel><title>Fish & Chips</title><item><title>First</title><description><p>Unclosed</description></item><item><title>Second</title></item>`
+
+	var x myxml
+	if err := NewXMLDecoder(bytes.NewReader(Recover([]byte(data)))).Decode(&x); err != nil {
+		t.Fatal(err)
+	}
+
+	if x.Title != "Fish & Chips" {
+		t.Errorf(`Incorrect title, got: %q`, x.Title)
+	}
+
+	if len(x.Items) != 2 || x.Items[1] != "Second" {
+		t.Errorf(`Incorrect items, got: %v`, x.Items)
+	}
+}
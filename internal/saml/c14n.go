@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package saml // import "miniflux.app/v2/internal/saml"
+
+import (
+	"sort"
+	"strings"
+)
+
+// canonicalizer implements Exclusive XML Canonicalization 1.0 (https://www.w3.org/TR/xml-exc-c14n/) of an element subtree.
+type canonicalizer struct {
+	inclusivePrefixes map[string]bool
+	withComments      bool
+	exclude           *element
+	builder           strings.Builder
+}
+
+func canonicalize(e *element, inclusivePrefixes []string, withComments bool, exclude *element) string {
+	c := &canonicalizer{
+		inclusivePrefixes: make(map[string]bool),
+		withComments:      withComments,
+		exclude:           exclude,
+	}
+
+	for _, prefix := range inclusivePrefixes {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		c.inclusivePrefixes[prefix] = true
+	}
+
+	c.writeElement(e, map[string]string{})
+	return c.builder.String()
+}
+
+type attribute struct {
+	namespace string
+	local     string
+	qualified string
+	value     string
+}
+
+func (c *canonicalizer) writeElement(e *element, rendered map[string]string) {
+	// Prefixes visibly utilized by the element and its attributes, the default namespace is used by unprefixed elements only.
+	utilized := map[string]bool{e.prefix: true}
+	for _, attr := range e.attrs {
+		if attr.Name.Space != "" && attr.Name.Space != "xml" {
+			utilized[attr.Name.Space] = true
+		}
+	}
+	for prefix := range c.inclusivePrefixes {
+		if _, found := e.lookupNamespace(prefix); found {
+			utilized[prefix] = true
+		}
+	}
+
+	var declarations []string
+	scope := make(map[string]string, len(rendered))
+	for prefix, uri := range rendered {
+		scope[prefix] = uri
+	}
+
+	for prefix := range utilized {
+		uri, _ := e.lookupNamespace(prefix)
+		previous, wasRendered := rendered[prefix]
+		if prefix == "" && uri == "" && !wasRendered {
+			continue
+		}
+		if wasRendered && previous == uri {
+			continue
+		}
+		scope[prefix] = uri
+		declarations = append(declarations, prefix)
+	}
+	sort.Strings(declarations)
+
+	var attrs []attribute
+	for _, attr := range e.attrs {
+		a := attribute{local: attr.Name.Local, qualified: attr.Name.Local, value: attr.Value}
+		if attr.Name.Space != "" {
+			a.namespace, _ = e.lookupNamespace(attr.Name.Space)
+			a.qualified = attr.Name.Space + ":" + attr.Name.Local
+		}
+		attrs = append(attrs, a)
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		if attrs[i].namespace != attrs[j].namespace {
+			return attrs[i].namespace < attrs[j].namespace
+		}
+		return attrs[i].local < attrs[j].local
+	})
+
+	name := qualifiedName(e.prefix, e.local)
+	c.builder.WriteString("<" + name)
+	for _, prefix := range declarations {
+		if prefix == "" {
+			c.builder.WriteString(` xmlns="`)
+		} else {
+			c.builder.WriteString(` xmlns:` + prefix + `="`)
+		}
+		c.builder.WriteString(escapeAttribute(scope[prefix]) + `"`)
+	}
+	for _, attr := range attrs {
+		c.builder.WriteString(" " + attr.qualified + `="` + escapeAttribute(attr.value) + `"`)
+	}
+	c.builder.WriteString(">")
+
+	for _, child := range e.children {
+		switch child := child.(type) {
+		case *element:
+			if child != c.exclude {
+				c.writeElement(child, scope)
+			}
+		case string:
+			c.builder.WriteString(escapeText(child))
+		case comment:
+			if c.withComments {
+				c.builder.WriteString("<!--" + string(child) + "-->")
+			}
+		case procInst:
+			c.builder.WriteString("<?" + child.target)
+			if child.inst != "" {
+				c.builder.WriteString(" " + child.inst)
+			}
+			c.builder.WriteString("?>")
+		}
+	}
+
+	c.builder.WriteString("</" + name + ">")
+}
+
+func qualifiedName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+var textReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+
+var attributeReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+
+func escapeText(text string) string {
+	return textReplacer.Replace(text)
+}
+
+func escapeAttribute(value string) string {
+	return attributeReplacer.Replace(value)
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package saml // import "miniflux.app/v2/internal/saml"
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCanonicalizeDocument(t *testing.T) {
+	input, err := os.ReadFile("testdata/c14n_input.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Generated with "xmllint --exc-c14n", which keeps the comments.
+	expected, err := os.ReadFile("testdata/c14n_expected.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := parseDocument(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result := canonicalize(root, nil, true, nil); result != string(expected) {
+		t.Errorf("Unexpected canonical form:\n%s\ninstead of:\n%s", result, expected)
+	}
+}
+
+func TestCanonicalizeSubtree(t *testing.T) {
+	input := `<a:Root xmlns:a="urn:a" xmlns:b="urn:b" xmlns="urn:default"><a:Child b:attr="1"><!-- c --><b:Leaf/><Plain/></a:Child></a:Root>`
+
+	root, err := parseDocument([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	child := root.childElements()[0]
+
+	expected := `<a:Child xmlns:a="urn:a" xmlns:b="urn:b" b:attr="1"><b:Leaf></b:Leaf><Plain xmlns="urn:default"></Plain></a:Child>`
+	if result := canonicalize(child, nil, false, nil); result != expected {
+		t.Errorf("Unexpected canonical form:\n%s\ninstead of:\n%s", result, expected)
+	}
+
+	expected = `<a:Child xmlns="urn:default" xmlns:a="urn:a" xmlns:b="urn:b" b:attr="1"><b:Leaf></b:Leaf><Plain></Plain></a:Child>`
+	if result := canonicalize(child, []string{"#default"}, false, nil); result != expected {
+		t.Errorf("Unexpected canonical form with inclusive namespaces:\n%s\ninstead of:\n%s", result, expected)
+	}
+
+	expected = `<a:Child xmlns:a="urn:a" xmlns:b="urn:b" b:attr="1"><Plain xmlns="urn:default"></Plain></a:Child>`
+	if result := canonicalize(child, nil, false, child.childElements()[0]); result != expected {
+		t.Errorf("Unexpected canonical form with an excluded element:\n%s\ninstead of:\n%s", result, expected)
+	}
+}
+
+func TestParseDocumentRejectsDTD(t *testing.T) {
+	input := `<?xml version="1.0"?><!DOCTYPE foo [<!ENTITY xxe "test">]><foo>&xxe;</foo>`
+	if _, err := parseDocument([]byte(input)); err == nil {
+		t.Error(`Documents with a DTD should be rejected`)
+	}
+}
+
+func TestParseDocumentRejectsMismatchedElements(t *testing.T) {
+	if _, err := parseDocument([]byte(`<a><b></a></b>`)); err == nil {
+		t.Error(`Mismatched elements should be rejected`)
+	}
+}
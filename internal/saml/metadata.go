@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package saml // import "miniflux.app/v2/internal/saml"
+
+import (
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+)
+
+const (
+	namespaceMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+	namespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	namespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+	bindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	bindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	nameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+)
+
+// IdentityProvider holds the settings imported from the metadata of the identity provider.
+type IdentityProvider struct {
+	EntityID     string
+	SSOURL       string
+	Certificates []*x509.Certificate
+}
+
+type entityDescriptor struct {
+	EntityID         string            `xml:"entityID,attr"`
+	IDPSSODescriptor *idpSSODescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+}
+
+type idpSSODescriptor struct {
+	KeyDescriptors       []keyDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	SingleSignOnServices []endpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+}
+
+type keyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo>X509Data>X509Certificate"`
+}
+
+type endpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+type metadataDocument struct {
+	XMLName xml.Name
+	entityDescriptor
+	Entities []entityDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+}
+
+// ParseIdentityProviderMetadata extracts the entity ID, the single sign-on URL and the signing certificates from the metadata.
+func ParseIdentityProviderMetadata(data []byte) (*IdentityProvider, error) {
+	var document metadataDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("saml: unable to parse identity provider metadata: %v", err)
+	}
+
+	var descriptor *entityDescriptor
+	switch {
+	case document.XMLName.Space == namespaceMetadata && document.XMLName.Local == "EntityDescriptor":
+		descriptor = &document.entityDescriptor
+	case document.XMLName.Space == namespaceMetadata && document.XMLName.Local == "EntitiesDescriptor":
+		for i := range document.Entities {
+			if document.Entities[i].IDPSSODescriptor != nil {
+				descriptor = &document.Entities[i]
+				break
+			}
+		}
+	}
+
+	if descriptor == nil || descriptor.IDPSSODescriptor == nil {
+		return nil, errors.New("saml: no identity provider found in the metadata")
+	}
+
+	idp := &IdentityProvider{EntityID: descriptor.EntityID}
+	for _, service := range descriptor.IDPSSODescriptor.SingleSignOnServices {
+		if service.Binding == bindingHTTPRedirect {
+			idp.SSOURL = service.Location
+			break
+		}
+	}
+
+	for _, key := range descriptor.IDPSSODescriptor.KeyDescriptors {
+		if key.Use != "" && key.Use != "signing" {
+			continue
+		}
+
+		for _, encodedCertificate := range key.Certificates {
+			der, err := decodeBase64(encodedCertificate)
+			if err != nil {
+				return nil, fmt.Errorf("saml: invalid certificate in the metadata: %v", err)
+			}
+
+			certificate, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, fmt.Errorf("saml: invalid certificate in the metadata: %v", err)
+			}
+			idp.Certificates = append(idp.Certificates, certificate)
+		}
+	}
+
+	switch {
+	case idp.EntityID == "":
+		return nil, errors.New("saml: the identity provider has no entity ID")
+	case idp.SSOURL == "":
+		return nil, errors.New("saml: the identity provider does not support the HTTP-Redirect binding")
+	case len(idp.Certificates) == 0:
+		return nil, errors.New("saml: the identity provider has no signing certificate")
+	}
+
+	return idp, nil
+}
+
+// Metadata returns the metadata document of the service provider to import in the identity provider.
+func (sp *ServiceProvider) Metadata() []byte {
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<md:EntityDescriptor xmlns:md="%s" entityID="%s">
+  <md:SPSSODescriptor AuthnRequestsSigned="false" WantAssertionsSigned="true" protocolSupportEnumeration="%s">
+    <md:NameIDFormat>%s</md:NameIDFormat>
+    <md:AssertionConsumerService Binding="%s" Location="%s" index="0" isDefault="true"/>
+  </md:SPSSODescriptor>
+</md:EntityDescriptor>
+`,
+		namespaceMetadata,
+		html.EscapeString(sp.EntityID),
+		namespaceProtocol,
+		nameIDFormatUnspecified,
+		bindingHTTPPost,
+		html.EscapeString(sp.ACSURL),
+	))
+}
@@ -0,0 +1,396 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package saml implements a SAML 2.0 service provider using the HTTP-Redirect binding for requests
+// and the HTTP-POST binding for signed responses.
+package saml // import "miniflux.app/v2/internal/saml"
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+)
+
+const (
+	statusSuccess = "urn:oasis:names:tc:SAML:2.0:status:Success"
+
+	confirmationMethodBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+	// Tolerated clock difference between the identity provider and Miniflux.
+	clockSkew = 3 * time.Minute
+
+	// RequestLifetime is the time given to users to authenticate with the identity provider.
+	RequestLifetime = 10 * time.Minute
+)
+
+// ServiceProvider validates the responses of an identity provider.
+type ServiceProvider struct {
+	EntityID         string
+	ACSURL           string
+	IdentityProvider *IdentityProvider
+
+	key            string
+	mu             sync.Mutex
+	seenAssertions map[string]time.Time
+}
+
+// Assertion is the identity asserted by the identity provider.
+type Assertion struct {
+	NameID     string
+	Attributes map[string][]string
+}
+
+// Attribute returns the first value of the attribute.
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// HasAttributeValue returns true if one of the values of the attribute is equal to value.
+func (a *Assertion) HasAttributeValue(name, value string) bool {
+	for _, v := range a.Attributes[name] {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// NewServiceProvider returns a service provider trusting the given identity provider.
+func NewServiceProvider(entityID, acsURL string, identityProvider *IdentityProvider) *ServiceProvider {
+	return &ServiceProvider{
+		EntityID:         entityID,
+		ACSURL:           acsURL,
+		IdentityProvider: identityProvider,
+		key:              crypto.GenerateRandomStringHex(32),
+	}
+}
+
+// NewRequest returns the ID and the relay state of a new authentication request started by userID, 0 for anonymous users.
+//
+// Browsers do not send the session cookies with the cross-site POST of the identity provider,
+// so the request ID is derived from the relay state instead of being stored in the session.
+func (sp *ServiceProvider) NewRequest(userID int64, now time.Time) (requestID, relayState string) {
+	relayState = fmt.Sprintf("%d.%d.%s", userID, now.Add(RequestLifetime).Unix(), crypto.GenerateRandomStringHex(8))
+	return sp.requestID(relayState), relayState
+}
+
+// ParseRelayState returns the request ID and the user ID of the authentication request.
+func (sp *ServiceProvider) ParseRelayState(relayState string, now time.Time) (requestID string, userID int64, err error) {
+	parts := strings.Split(relayState, ".")
+	if len(parts) != 3 {
+		return "", 0, errors.New("saml: invalid relay state")
+	}
+
+	userID, err = strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", 0, errors.New("saml: invalid relay state")
+	}
+
+	expiration, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, errors.New("saml: invalid relay state")
+	}
+
+	if now.Unix() > expiration {
+		return "", 0, errors.New("saml: the authentication request has expired")
+	}
+
+	return sp.requestID(relayState), userID, nil
+}
+
+func (sp *ServiceProvider) requestID(relayState string) string {
+	// IDs must not start with a digit to be valid xs:ID values.
+	return "_" + crypto.GenerateSHA256Hmac(sp.key, []byte(relayState))
+}
+
+// AuthnRequestURL returns the URL of the identity provider starting the authentication.
+func (sp *ServiceProvider) AuthnRequestURL(requestID, relayState string) (string, error) {
+	request := fmt.Sprintf(
+		`<samlp:AuthnRequest xmlns:samlp="%s" xmlns:saml="%s" ID="%s" Version="2.0" IssueInstant="%s" Destination="%s" AssertionConsumerServiceURL="%s" ProtocolBinding="%s"><saml:Issuer>%s</saml:Issuer><samlp:NameIDPolicy Format="%s" AllowCreate="true"/></samlp:AuthnRequest>`,
+		namespaceProtocol,
+		namespaceAssertion,
+		html.EscapeString(requestID),
+		time.Now().UTC().Format(time.RFC3339),
+		html.EscapeString(sp.IdentityProvider.SSOURL),
+		html.EscapeString(sp.ACSURL),
+		bindingHTTPPost,
+		html.EscapeString(sp.EntityID),
+		nameIDFormatUnspecified,
+	)
+
+	var buffer bytes.Buffer
+	writer, err := flate.NewWriter(&buffer, flate.DefaultCompression)
+	if err != nil {
+		return "", fmt.Errorf("saml: unable to compress the request: %v", err)
+	}
+	writer.Write([]byte(request))
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("saml: unable to compress the request: %v", err)
+	}
+
+	ssoURL, err := url.Parse(sp.IdentityProvider.SSOURL)
+	if err != nil {
+		return "", fmt.Errorf("saml: invalid single sign-on URL: %v", err)
+	}
+
+	values := ssoURL.Query()
+	values.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buffer.Bytes()))
+	if relayState != "" {
+		values.Set("RelayState", relayState)
+	}
+	ssoURL.RawQuery = values.Encode()
+
+	return ssoURL.String(), nil
+}
+
+// ParseResponse verifies the base64 encoded response sent to the assertion consumer service and returns the assertion.
+//
+// Unsolicited responses are rejected, requestID must be the ID of the authentication request.
+func (sp *ServiceProvider) ParseResponse(encodedResponse, requestID string, now time.Time) (*Assertion, error) {
+	if requestID == "" {
+		return nil, errors.New("saml: unsolicited responses are not supported")
+	}
+
+	data, err := decodeBase64(encodedResponse)
+	if err != nil {
+		return nil, fmt.Errorf("saml: invalid response encoding: %v", err)
+	}
+
+	response, err := parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !response.is(namespaceProtocol, "Response") || response.attrValue("Version") != "2.0" {
+		return nil, errors.New("saml: the document is not a SAML 2.0 response")
+	}
+
+	if destination, found := response.attr("Destination"); found && destination != sp.ACSURL {
+		return nil, fmt.Errorf("saml: unexpected response destination %q", destination)
+	}
+
+	if response.attrValue("InResponseTo") != requestID {
+		return nil, errors.New("saml: the response does not match the authentication request")
+	}
+
+	for _, issuer := range response.find(namespaceAssertion, "Issuer") {
+		if issuer.text() != sp.IdentityProvider.EntityID {
+			return nil, fmt.Errorf("saml: unexpected response issuer %q", issuer.text())
+		}
+	}
+
+	if err := checkStatus(response); err != nil {
+		return nil, err
+	}
+
+	if len(response.find(namespaceAssertion, "EncryptedAssertion")) > 0 {
+		return nil, errors.New("saml: encrypted assertions are not supported")
+	}
+
+	assertion, err := response.findOne(namespaceAssertion, "Assertion")
+	if err != nil {
+		return nil, err
+	}
+
+	// Either the response or the assertion must be signed, signatures that are present must be valid.
+	responseErr := verifySignature(response, sp.IdentityProvider.Certificates)
+	if responseErr != nil && !errors.Is(responseErr, errNotSigned) {
+		return nil, responseErr
+	}
+
+	assertionErr := verifySignature(assertion, sp.IdentityProvider.Certificates)
+	if assertionErr != nil && !(errors.Is(assertionErr, errNotSigned) && responseErr == nil) {
+		return nil, assertionErr
+	}
+
+	return sp.parseAssertion(assertion, requestID, now)
+}
+
+func checkStatus(response *element) error {
+	status, err := response.findOne(namespaceProtocol, "Status")
+	if err != nil {
+		return err
+	}
+
+	statusCode, err := status.findOne(namespaceProtocol, "StatusCode")
+	if err != nil {
+		return err
+	}
+
+	if value := statusCode.attrValue("Value"); value != statusSuccess {
+		return fmt.Errorf("saml: the identity provider returned the status %q", value)
+	}
+
+	return nil
+}
+
+func (sp *ServiceProvider) parseAssertion(assertion *element, requestID string, now time.Time) (*Assertion, error) {
+	issuer, err := assertion.findOne(namespaceAssertion, "Issuer")
+	if err != nil {
+		return nil, err
+	}
+
+	if issuer.text() != sp.IdentityProvider.EntityID {
+		return nil, fmt.Errorf("saml: unexpected assertion issuer %q", issuer.text())
+	}
+
+	expiration, err := checkConditions(assertion, sp.EntityID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := assertion.findOne(namespaceAssertion, "Subject")
+	if err != nil {
+		return nil, err
+	}
+
+	nameID, err := subject.findOne(namespaceAssertion, "NameID")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sp.checkSubjectConfirmation(subject, requestID, now); err != nil {
+		return nil, err
+	}
+
+	result := &Assertion{NameID: nameID.text(), Attributes: make(map[string][]string)}
+	if result.NameID == "" {
+		return nil, errors.New("saml: empty NameID")
+	}
+
+	for _, statement := range assertion.find(namespaceAssertion, "AttributeStatement") {
+		for _, attribute := range statement.find(namespaceAssertion, "Attribute") {
+			var values []string
+			for _, value := range attribute.find(namespaceAssertion, "AttributeValue") {
+				values = append(values, value.text())
+			}
+
+			for _, name := range []string{attribute.attrValue("Name"), attribute.attrValue("FriendlyName")} {
+				if name != "" {
+					result.Attributes[name] = append(result.Attributes[name], values...)
+				}
+			}
+		}
+	}
+
+	if err := sp.markAssertionAsSeen(assertion.attrValue("ID"), expiration, now); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// checkConditions validates the validity period and the audience, it returns the expiration of the assertion.
+func checkConditions(assertion *element, audience string, now time.Time) (time.Time, error) {
+	conditions, err := assertion.findOne(namespaceAssertion, "Conditions")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if notBefore, found := conditions.attr("NotBefore"); found {
+		t, err := time.Parse(time.RFC3339Nano, notBefore)
+		if err != nil || now.Add(clockSkew).Before(t) {
+			return time.Time{}, errors.New("saml: the assertion is not yet valid")
+		}
+	}
+
+	expiration := now.Add(clockSkew)
+	if notOnOrAfter, found := conditions.attr("NotOnOrAfter"); found {
+		t, err := time.Parse(time.RFC3339Nano, notOnOrAfter)
+		if err != nil || !now.Add(-clockSkew).Before(t) {
+			return time.Time{}, errors.New("saml: the assertion has expired")
+		}
+		expiration = t.Add(clockSkew)
+	}
+
+	restrictions := conditions.find(namespaceAssertion, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return time.Time{}, errors.New("saml: the assertion has no audience restriction")
+	}
+
+	// Every restriction must include the service provider.
+	for _, restriction := range restrictions {
+		allowed := false
+		for _, a := range restriction.find(namespaceAssertion, "Audience") {
+			if a.text() == audience {
+				allowed = true
+			}
+		}
+
+		if !allowed {
+			return time.Time{}, errors.New("saml: the assertion is intended for another audience")
+		}
+	}
+
+	return expiration, nil
+}
+
+func (sp *ServiceProvider) checkSubjectConfirmation(subject *element, requestID string, now time.Time) error {
+	for _, confirmation := range subject.find(namespaceAssertion, "SubjectConfirmation") {
+		if confirmation.attrValue("Method") != confirmationMethodBearer {
+			continue
+		}
+
+		data, err := confirmation.findOne(namespaceAssertion, "SubjectConfirmationData")
+		if err != nil {
+			continue
+		}
+
+		if data.attrValue("Recipient") != sp.ACSURL {
+			continue
+		}
+
+		if inResponseTo, found := data.attr("InResponseTo"); found && inResponseTo != requestID {
+			continue
+		}
+
+		notOnOrAfter, err := time.Parse(time.RFC3339Nano, data.attrValue("NotOnOrAfter"))
+		if err != nil || !now.Add(-clockSkew).Before(notOnOrAfter) {
+			continue
+		}
+
+		return nil
+	}
+
+	return errors.New("saml: no valid bearer subject confirmation")
+}
+
+// markAssertionAsSeen prevents the same assertion from being used several times.
+func (sp *ServiceProvider) markAssertionAsSeen(id string, expiration, now time.Time) error {
+	if id == "" {
+		return errors.New("saml: the assertion has no ID")
+	}
+
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	if sp.seenAssertions == nil {
+		sp.seenAssertions = make(map[string]time.Time)
+	}
+
+	for seenID, seenExpiration := range sp.seenAssertions {
+		if now.After(seenExpiration) {
+			delete(sp.seenAssertions, seenID)
+		}
+	}
+
+	if _, seen := sp.seenAssertions[id]; seen {
+		return errors.New("saml: the assertion has already been used")
+	}
+
+	sp.seenAssertions[id] = expiration
+	return nil
+}
@@ -0,0 +1,341 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package saml // import "miniflux.app/v2/internal/saml"
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testIdPEntityID = "https://idp.example.org/metadata"
+	testSPEntityID  = "https://miniflux.example.org/saml/metadata"
+	testACSURL      = "https://miniflux.example.org/saml/acs"
+	testRequestID   = "_request"
+)
+
+var testNow = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+type testIdentityProvider struct {
+	key         *rsa.PrivateKey
+	certificate *x509.Certificate
+}
+
+func newTestIdentityProvider(t *testing.T) *testIdentityProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.org"},
+		NotBefore:    testNow.Add(-time.Hour),
+		NotAfter:     testNow.Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testIdentityProvider{key: key, certificate: certificate}
+}
+
+func (idp *testIdentityProvider) serviceProvider() *ServiceProvider {
+	return NewServiceProvider(testSPEntityID, testACSURL, &IdentityProvider{
+		EntityID:     testIdPEntityID,
+		SSOURL:       "https://idp.example.org/sso?tenant=1",
+		Certificates: []*x509.Certificate{idp.certificate},
+	})
+}
+
+// sign inserts an enveloped signature after the Issuer of the element.
+func (idp *testIdentityProvider) sign(t *testing.T, document, id string) string {
+	t.Helper()
+
+	root, err := parseDocument([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := sha256.Sum256([]byte(canonicalize(root, nil, false, nil)))
+	signedInfo := fmt.Sprintf(`<ds:SignedInfo xmlns:ds="%s"><ds:CanonicalizationMethod Algorithm="%s"/><ds:SignatureMethod Algorithm="%s"/><ds:Reference URI="#%s"><ds:Transforms><ds:Transform Algorithm="%s"/><ds:Transform Algorithm="%s"/></ds:Transforms><ds:DigestMethod Algorithm="%s"/><ds:DigestValue>%s</ds:DigestValue></ds:Reference></ds:SignedInfo>`,
+		namespaceDSig, algorithmExcC14N, algorithmRSASHA256, id, transformEnveloped, algorithmExcC14N, algorithmSHA256,
+		base64.StdEncoding.EncodeToString(digest[:]),
+	)
+
+	signedInfoElement, err := parseDocument([]byte(signedInfo))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hashed := sha256.Sum256([]byte(canonicalize(signedInfoElement, nil, false, nil)))
+	signatureValue, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature := fmt.Sprintf(`<ds:Signature xmlns:ds="%s">%s<ds:SignatureValue>%s</ds:SignatureValue></ds:Signature>`,
+		namespaceDSig,
+		strings.Replace(signedInfo, ` xmlns:ds="`+namespaceDSig+`"`, "", 1),
+		base64.StdEncoding.EncodeToString(signatureValue),
+	)
+
+	position := strings.Index(document, "</saml:Issuer>") + len("</saml:Issuer>")
+	return document[:position] + signature + document[position:]
+}
+
+type testAssertionOptions struct {
+	id           string
+	audience     string
+	inResponseTo string
+	notOnOrAfter time.Time
+}
+
+func testAssertion(options testAssertionOptions) string {
+	if options.id == "" {
+		options.id = "_assertion"
+	}
+	if options.audience == "" {
+		options.audience = testSPEntityID
+	}
+	if options.inResponseTo == "" {
+		options.inResponseTo = testRequestID
+	}
+	if options.notOnOrAfter.IsZero() {
+		options.notOnOrAfter = testNow.Add(5 * time.Minute)
+	}
+
+	return fmt.Sprintf(`<saml:Assertion xmlns:saml="%s" ID="%s" Version="2.0" IssueInstant="%s">
+  <saml:Issuer>%s</saml:Issuer>
+  <saml:Subject>
+    <saml:NameID>jdoe</saml:NameID>
+    <saml:SubjectConfirmation Method="%s">
+      <saml:SubjectConfirmationData InResponseTo="%s" Recipient="%s" NotOnOrAfter="%s"/>
+    </saml:SubjectConfirmation>
+  </saml:Subject>
+  <saml:Conditions NotBefore="%s" NotOnOrAfter="%s">
+    <saml:AudienceRestriction><saml:Audience>%s</saml:Audience></saml:AudienceRestriction>
+  </saml:Conditions>
+  <saml:AttributeStatement>
+    <saml:Attribute Name="urn:oid:0.9.2342.19200300.100.1.3" FriendlyName="mail"><saml:AttributeValue>jdoe@example.org</saml:AttributeValue></saml:Attribute>
+    <saml:Attribute Name="groups"><saml:AttributeValue>users</saml:AttributeValue><saml:AttributeValue>admins</saml:AttributeValue></saml:Attribute>
+  </saml:AttributeStatement>
+</saml:Assertion>`,
+		namespaceAssertion,
+		options.id,
+		testNow.Format(time.RFC3339),
+		testIdPEntityID,
+		confirmationMethodBearer,
+		options.inResponseTo,
+		testACSURL,
+		options.notOnOrAfter.Format(time.RFC3339),
+		testNow.Add(-time.Minute).Format(time.RFC3339),
+		options.notOnOrAfter.Format(time.RFC3339),
+		options.audience,
+	)
+}
+
+func testResponse(assertion string) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(`<samlp:Response xmlns:samlp="%s" xmlns:saml="%s" ID="_response" Version="2.0" IssueInstant="%s" Destination="%s" InResponseTo="%s"><saml:Issuer>%s</saml:Issuer><samlp:Status><samlp:StatusCode Value="%s"/></samlp:Status>%s</samlp:Response>`,
+		namespaceProtocol,
+		namespaceAssertion,
+		testNow.Format(time.RFC3339),
+		testACSURL,
+		testRequestID,
+		testIdPEntityID,
+		statusSuccess,
+		assertion,
+	)))
+}
+
+func TestParseResponseWithSignedAssertion(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+	sp := idp.serviceProvider()
+
+	response := testResponse(idp.sign(t, testAssertion(testAssertionOptions{}), "_assertion"))
+	assertion, err := sp.ParseResponse(response, testRequestID, testNow)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if assertion.NameID != "jdoe" {
+		t.Errorf(`Unexpected NameID: got %q`, assertion.NameID)
+	}
+
+	if value := assertion.Attribute("mail"); value != "jdoe@example.org" {
+		t.Errorf(`Unexpected attribute value by friendly name: got %q`, value)
+	}
+
+	if value := assertion.Attribute("urn:oid:0.9.2342.19200300.100.1.3"); value != "jdoe@example.org" {
+		t.Errorf(`Unexpected attribute value by name: got %q`, value)
+	}
+
+	if !assertion.HasAttributeValue("groups", "admins") || assertion.HasAttributeValue("groups", "guests") {
+		t.Errorf(`Unexpected attribute values: got %v`, assertion.Attributes["groups"])
+	}
+
+	if _, err := sp.ParseResponse(response, testRequestID, testNow); err == nil {
+		t.Error(`Replayed assertions should be rejected`)
+	}
+}
+
+func TestParseResponseRejectsInvalidAssertions(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+	otherIdP := newTestIdentityProvider(t)
+
+	scenarios := map[string]string{
+		"unsigned":          testResponse(testAssertion(testAssertionOptions{})),
+		"untrusted key":     testResponse(otherIdP.sign(t, testAssertion(testAssertionOptions{}), "_assertion")),
+		"tampered":          testResponse(strings.Replace(idp.sign(t, testAssertion(testAssertionOptions{}), "_assertion"), "<saml:NameID>jdoe", "<saml:NameID>admin", 1)),
+		"wrong audience":    testResponse(idp.sign(t, testAssertion(testAssertionOptions{audience: "https://other.example.org"}), "_assertion")),
+		"wrong request":     testResponse(idp.sign(t, testAssertion(testAssertionOptions{inResponseTo: "_other"}), "_assertion")),
+		"expired":           testResponse(idp.sign(t, testAssertion(testAssertionOptions{notOnOrAfter: testNow.Add(-10 * time.Minute)}), "_assertion")),
+		"wrong reference":   testResponse(idp.sign(t, strings.Replace(testAssertion(testAssertionOptions{}), `ID="_assertion"`, `ID="_other"`, 1), "_assertion")),
+		"invalid base64":    "not base64!",
+		"not a SAML answer": base64.StdEncoding.EncodeToString([]byte(`<html/>`)),
+	}
+
+	for name, response := range scenarios {
+		sp := idp.serviceProvider()
+		if _, err := sp.ParseResponse(response, testRequestID, testNow); err == nil {
+			t.Errorf(`The %s response should be rejected`, name)
+		}
+	}
+
+	sp := idp.serviceProvider()
+	response := testResponse(idp.sign(t, testAssertion(testAssertionOptions{}), "_assertion"))
+	if _, err := sp.ParseResponse(response, "", testNow); err == nil {
+		t.Error(`Unsolicited responses should be rejected`)
+	}
+}
+
+func TestRelayState(t *testing.T) {
+	sp := newTestIdentityProvider(t).serviceProvider()
+	requestID, relayState := sp.NewRequest(42, testNow)
+
+	if len(relayState) > 80 {
+		t.Errorf(`The relay state must not exceed 80 bytes: got %d`, len(relayState))
+	}
+
+	parsedRequestID, userID, err := sp.ParseRelayState(relayState, testNow.Add(time.Minute))
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if parsedRequestID != requestID || userID != 42 {
+		t.Errorf(`Unexpected request: got %q and %d`, parsedRequestID, userID)
+	}
+
+	if forgedRequestID, _, err := sp.ParseRelayState(strings.Replace(relayState, "42.", "1.", 1), testNow); err != nil || forgedRequestID == requestID {
+		t.Error(`A modified relay state should not match the request`)
+	}
+
+	if _, _, err := sp.ParseRelayState(relayState, testNow.Add(time.Hour)); err == nil {
+		t.Error(`Expired relay states should be rejected`)
+	}
+
+	if other := newTestIdentityProvider(t).serviceProvider(); other.requestID(relayState) == requestID {
+		t.Error(`Request IDs should depend on the service provider key`)
+	}
+}
+
+func TestAuthnRequestURL(t *testing.T) {
+	sp := newTestIdentityProvider(t).serviceProvider()
+
+	redirectURL, err := sp.AuthnRequestURL(testRequestID, "state")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	parsedURL, err := url.Parse(redirectURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsedURL.Host != "idp.example.org" || parsedURL.Query().Get("tenant") != "1" || parsedURL.Query().Get("RelayState") != "state" {
+		t.Errorf(`Unexpected redirect URL: %s`, redirectURL)
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(parsedURL.Query().Get("SAMLRequest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := io.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := parseDocument(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !request.is(namespaceProtocol, "AuthnRequest") || request.attrValue("ID") != testRequestID || request.attrValue("AssertionConsumerServiceURL") != testACSURL {
+		t.Errorf(`Unexpected authentication request: %s`, data)
+	}
+}
+
+func TestParseIdentityProviderMetadata(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+	certificate := base64.StdEncoding.EncodeToString(idp.certificate.Raw)
+
+	metadata := fmt.Sprintf(`<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="%s" xmlns:ds="%s" entityID="%s">
+  <md:IDPSSODescriptor protocolSupportEnumeration="%s">
+    <md:KeyDescriptor use="encryption"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>invalid</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+    <md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+      %s
+    </ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+    <md:SingleSignOnService Binding="%s" Location="https://idp.example.org/sso/post"/>
+    <md:SingleSignOnService Binding="%s" Location="https://idp.example.org/sso/redirect"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`,
+		namespaceMetadata, namespaceDSig, testIdPEntityID, namespaceProtocol, certificate, bindingHTTPPost, bindingHTTPRedirect,
+	)
+
+	provider, err := ParseIdentityProviderMetadata([]byte(metadata))
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if provider.EntityID != testIdPEntityID {
+		t.Errorf(`Unexpected entity ID: got %q`, provider.EntityID)
+	}
+
+	if provider.SSOURL != "https://idp.example.org/sso/redirect" {
+		t.Errorf(`Unexpected single sign-on URL: got %q`, provider.SSOURL)
+	}
+
+	if len(provider.Certificates) != 1 || !provider.Certificates[0].Equal(idp.certificate) {
+		t.Errorf(`Unexpected certificates: got %d`, len(provider.Certificates))
+	}
+
+	if _, err := ParseIdentityProviderMetadata([]byte(`<md:EntityDescriptor xmlns:md="` + namespaceMetadata + `" entityID="x"/>`)); err == nil {
+		t.Error(`Metadata without identity provider descriptor should be rejected`)
+	}
+}
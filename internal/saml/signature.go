@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package saml // import "miniflux.app/v2/internal/saml"
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+const (
+	namespaceDSig        = "http://www.w3.org/2000/09/xmldsig#"
+	namespaceExcC14N     = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	transformEnveloped   = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	algorithmExcC14N     = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	algorithmExcC14NWC   = "http://www.w3.org/2001/10/xml-exc-c14n#WithComments"
+	algorithmSHA256      = "http://www.w3.org/2001/04/xmlenc#sha256"
+	algorithmSHA512      = "http://www.w3.org/2001/04/xmlenc#sha512"
+	algorithmRSASHA256   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algorithmRSASHA512   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	algorithmECDSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	algorithmECDSASHA512 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha512"
+)
+
+var digestAlgorithms = map[string]crypto.Hash{
+	algorithmSHA256: crypto.SHA256,
+	algorithmSHA512: crypto.SHA512,
+}
+
+var signatureAlgorithms = map[string]crypto.Hash{
+	algorithmRSASHA256:   crypto.SHA256,
+	algorithmRSASHA512:   crypto.SHA512,
+	algorithmECDSASHA256: crypto.SHA256,
+	algorithmECDSASHA512: crypto.SHA512,
+}
+
+// errNotSigned is returned when the element has no signature.
+var errNotSigned = errors.New("saml: the element is not signed")
+
+// verifySignature checks the enveloped signature of the element against the trusted certificates.
+//
+// Only signatures referencing the element itself are accepted, so the verified content is the one used afterward.
+func verifySignature(e *element, certificates []*x509.Certificate) error {
+	signatures := e.find(namespaceDSig, "Signature")
+	switch len(signatures) {
+	case 0:
+		return errNotSigned
+	case 1:
+	default:
+		return errors.New("saml: several signatures found")
+	}
+	signature := signatures[0]
+
+	id := e.attrValue("ID")
+	if id == "" {
+		return errors.New("saml: the signed element has no ID")
+	}
+
+	signedInfo, err := signature.findOne(namespaceDSig, "SignedInfo")
+	if err != nil {
+		return err
+	}
+
+	canonicalizationMethod, err := signedInfo.findOne(namespaceDSig, "CanonicalizationMethod")
+	if err != nil {
+		return err
+	}
+
+	signatureMethod, err := signedInfo.findOne(namespaceDSig, "SignatureMethod")
+	if err != nil {
+		return err
+	}
+
+	hash, supported := signatureAlgorithms[signatureMethod.attrValue("Algorithm")]
+	if !supported {
+		return fmt.Errorf("saml: unsupported signature algorithm %q", signatureMethod.attrValue("Algorithm"))
+	}
+
+	reference, err := signedInfo.findOne(namespaceDSig, "Reference")
+	if err != nil {
+		return err
+	}
+
+	if reference.attrValue("URI") != "#"+id {
+		return errors.New("saml: the signature does not reference the signed element")
+	}
+
+	if err := verifyDigest(e, signature, reference); err != nil {
+		return err
+	}
+
+	canonicalSignedInfo, err := canonicalizeWithMethod(signedInfo, canonicalizationMethod, nil)
+	if err != nil {
+		return err
+	}
+
+	signatureValueElement, err := signature.findOne(namespaceDSig, "SignatureValue")
+	if err != nil {
+		return err
+	}
+
+	signatureValue, err := decodeBase64(signatureValueElement.text())
+	if err != nil {
+		return fmt.Errorf("saml: invalid signature value: %v", err)
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(canonicalSignedInfo))
+	hashed := hasher.Sum(nil)
+
+	for _, certificate := range certificates {
+		if checkSignature(certificate, hash, hashed, signatureValue) {
+			return nil
+		}
+	}
+
+	return errors.New("saml: invalid signature")
+}
+
+func verifyDigest(e, signature, reference *element) error {
+	transforms, err := reference.findOne(namespaceDSig, "Transforms")
+	if err != nil {
+		return err
+	}
+
+	var canonicalization *element
+	enveloped := false
+	for _, transform := range transforms.find(namespaceDSig, "Transform") {
+		switch transform.attrValue("Algorithm") {
+		case transformEnveloped:
+			enveloped = true
+		case algorithmExcC14N, algorithmExcC14NWC:
+			canonicalization = transform
+		default:
+			return fmt.Errorf("saml: unsupported transform %q", transform.attrValue("Algorithm"))
+		}
+	}
+
+	if !enveloped || canonicalization == nil {
+		return errors.New("saml: the signature must be enveloped and use exclusive canonicalization")
+	}
+
+	digestMethod, err := reference.findOne(namespaceDSig, "DigestMethod")
+	if err != nil {
+		return err
+	}
+
+	hash, supported := digestAlgorithms[digestMethod.attrValue("Algorithm")]
+	if !supported {
+		return fmt.Errorf("saml: unsupported digest algorithm %q", digestMethod.attrValue("Algorithm"))
+	}
+
+	digestValueElement, err := reference.findOne(namespaceDSig, "DigestValue")
+	if err != nil {
+		return err
+	}
+
+	expectedDigest, err := decodeBase64(digestValueElement.text())
+	if err != nil {
+		return fmt.Errorf("saml: invalid digest value: %v", err)
+	}
+
+	canonicalElement, err := canonicalizeWithMethod(e, canonicalization, signature)
+	if err != nil {
+		return err
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(canonicalElement))
+	if subtle.ConstantTimeCompare(hasher.Sum(nil), expectedDigest) != 1 {
+		return errors.New("saml: the digest of the signed element does not match")
+	}
+
+	return nil
+}
+
+func canonicalizeWithMethod(e, method, exclude *element) (string, error) {
+	algorithm := method.attrValue("Algorithm")
+	if algorithm != algorithmExcC14N && algorithm != algorithmExcC14NWC {
+		return "", fmt.Errorf("saml: unsupported canonicalization algorithm %q", algorithm)
+	}
+
+	var prefixes []string
+	for _, inclusiveNamespaces := range method.find(namespaceExcC14N, "InclusiveNamespaces") {
+		prefixes = append(prefixes, strings.Fields(inclusiveNamespaces.attrValue("PrefixList"))...)
+	}
+
+	return canonicalize(e, prefixes, algorithm == algorithmExcC14NWC, exclude), nil
+}
+
+func checkSignature(certificate *x509.Certificate, hash crypto.Hash, hashed, signature []byte) bool {
+	switch publicKey := certificate.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(publicKey, hash, hashed, signature) == nil
+	case *ecdsa.PublicKey:
+		// XML signatures store the raw r and s values instead of an ASN.1 structure.
+		if len(signature)%2 != 0 {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:len(signature)/2])
+		s := new(big.Int).SetBytes(signature[len(signature)/2:])
+		return ecdsa.Verify(publicKey, hashed, r, s)
+	default:
+		return false
+	}
+}
+
+func decodeBase64(value string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+}
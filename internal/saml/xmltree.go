@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package saml // import "miniflux.app/v2/internal/saml"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// element is a node of a parsed XML document that keeps the namespace prefixes,
+// the standard library decoder drops them but they are needed to canonicalize signed content.
+type element struct {
+	prefix     string
+	local      string
+	attrs      []xml.Attr
+	namespaces []xml.Attr
+	children   []any
+	parent     *element
+}
+
+type comment string
+
+type procInst struct {
+	target string
+	inst   string
+}
+
+// parseDocument returns the root element of the document, documents with a DTD are rejected.
+func parseDocument(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var root, current *element
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("saml: unable to parse XML document: %v", err)
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			e := &element{prefix: token.Name.Space, local: token.Name.Local, parent: current}
+			for _, attr := range token.Attr {
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					e.namespaces = append(e.namespaces, attr)
+				} else {
+					e.attrs = append(e.attrs, attr)
+				}
+			}
+
+			if current == nil {
+				if root != nil {
+					return nil, errors.New("saml: the XML document has several root elements")
+				}
+				root = e
+			} else {
+				current.children = append(current.children, e)
+			}
+			current = e
+		case xml.EndElement:
+			if current == nil || current.prefix != token.Name.Space || current.local != token.Name.Local {
+				return nil, errors.New("saml: mismatched XML end element")
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, string(token))
+			}
+		case xml.Comment:
+			if current != nil {
+				current.children = append(current.children, comment(token))
+			}
+		case xml.ProcInst:
+			if current != nil {
+				current.children = append(current.children, procInst{token.Target, string(token.Inst)})
+			}
+		case xml.Directive:
+			return nil, errors.New("saml: XML directives are not allowed")
+		}
+	}
+
+	if root == nil || current != nil {
+		return nil, errors.New("saml: incomplete XML document")
+	}
+
+	return root, nil
+}
+
+// lookupNamespace returns the namespace URI bound to the prefix in the scope of the element.
+func (e *element) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return xmlNamespace, true
+	}
+
+	for current := e; current != nil; current = current.parent {
+		for _, ns := range current.namespaces {
+			if (prefix == "" && ns.Name.Space == "" && ns.Name.Local == "xmlns") || (prefix != "" && ns.Name.Space == "xmlns" && ns.Name.Local == prefix) {
+				return ns.Value, true
+			}
+		}
+	}
+
+	return "", prefix == ""
+}
+
+func (e *element) namespace() string {
+	uri, _ := e.lookupNamespace(e.prefix)
+	return uri
+}
+
+func (e *element) is(namespace, local string) bool {
+	return e.local == local && e.namespace() == namespace
+}
+
+func (e *element) attr(local string) (string, bool) {
+	for _, attr := range e.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == local {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+func (e *element) attrValue(local string) string {
+	value, _ := e.attr(local)
+	return value
+}
+
+func (e *element) childElements() []*element {
+	var result []*element
+	for _, child := range e.children {
+		if child, ok := child.(*element); ok {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// find returns the direct children with the given name.
+func (e *element) find(namespace, local string) []*element {
+	var result []*element
+	for _, child := range e.childElements() {
+		if child.is(namespace, local) {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// findOne returns the only direct child with the given name, several children are considered as an error.
+func (e *element) findOne(namespace, local string) (*element, error) {
+	children := e.find(namespace, local)
+	switch len(children) {
+	case 0:
+		return nil, fmt.Errorf("saml: missing %s element", local)
+	case 1:
+		return children[0], nil
+	default:
+		return nil, fmt.Errorf("saml: duplicate %s element", local)
+	}
+}
+
+// text returns the concatenated character data of the element.
+func (e *element) text() string {
+	var builder strings.Builder
+	for _, child := range e.children {
+		if text, ok := child.(string); ok {
+			builder.WriteString(text)
+		}
+	}
+	return strings.TrimSpace(builder.String())
+}
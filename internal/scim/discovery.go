@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scim // import "miniflux.app/v2/internal/scim"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/config"
+)
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type filterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type bulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type serviceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 supported              `json:"patch"`
+	Bulk                  bulkSupported          `json:"bulk"`
+	Filter                filterSupported        `json:"filter"`
+	ChangePassword        supported              `json:"changePassword"`
+	Sort                  supported              `json:"sort"`
+	ETag                  supported              `json:"etag"`
+	AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+}
+
+type resourceType struct {
+	Schemas  []string      `json:"schemas"`
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Endpoint string        `json:"endpoint"`
+	Schema   string        `json:"schema"`
+	Meta     *resourceMeta `json:"meta"`
+}
+
+type schemaAttribute struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	MultiValued bool   `json:"multiValued"`
+	Required    bool   `json:"required"`
+	CaseExact   bool   `json:"caseExact"`
+	Mutability  string `json:"mutability"`
+	Returned    string `json:"returned"`
+	Uniqueness  string `json:"uniqueness"`
+}
+
+type schemaDefinition struct {
+	Schemas    []string          `json:"schemas"`
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Attributes []schemaAttribute `json:"attributes"`
+	Meta       *resourceMeta     `json:"meta"`
+}
+
+func (h *handler) serviceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeResource(w, r, http.StatusOK, &serviceProviderConfig{
+		Schemas: []string{serviceProviderSchema},
+		Patch:   supported{Supported: true},
+		Filter:  filterSupported{Supported: true, MaxResults: maxPageSize},
+		AuthenticationSchemes: []authenticationScheme{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "OAuth Bearer Token",
+				Description: "Authentication with the token defined by SCIM_TOKEN",
+			},
+		},
+	})
+}
+
+func (h *handler) resourceTypes(w http.ResponseWriter, r *http.Request) {
+	writeResource(w, r, http.StatusOK, newListResponse([]interface{}{
+		&resourceType{
+			Schemas:  []string{resourceTypeSchema},
+			ID:       "User",
+			Name:     "User",
+			Endpoint: "/Users",
+			Schema:   userSchema,
+			Meta: &resourceMeta{
+				ResourceType: "ResourceType",
+				Location:     config.Opts.BaseURL() + "/scim/v2/ResourceTypes/User",
+			},
+		},
+	}, 1, 1))
+}
+
+func (h *handler) schemas(w http.ResponseWriter, r *http.Request) {
+	writeResource(w, r, http.StatusOK, newListResponse([]interface{}{
+		&schemaDefinition{
+			Schemas: []string{schemaDefinitionSchema},
+			ID:      userSchema,
+			Name:    "User",
+			Attributes: []schemaAttribute{
+				{Name: "userName", Type: "string", Required: true, Mutability: "readWrite", Returned: "default", Uniqueness: "server"},
+				{Name: "active", Type: "boolean", Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+			},
+			Meta: &resourceMeta{
+				ResourceType: "Schema",
+				Location:     config.Opts.BaseURL() + "/scim/v2/Schemas/" + userSchema,
+			},
+		},
+	}, 1, 1))
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scim // import "miniflux.app/v2/internal/scim"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// userFilter is the only form of SCIM filter used by identity providers to look up users: userName eq "value".
+type userFilter struct {
+	UserName string
+}
+
+func parseUserFilter(filter string) (*userFilter, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return nil, nil
+	}
+
+	fields := strings.SplitN(filter, " ", 3)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("scim: unsupported filter %q", filter)
+	}
+
+	attribute := strings.TrimPrefix(strings.ToLower(fields[0]), strings.ToLower(userSchema)+":")
+	if attribute != "username" {
+		return nil, fmt.Errorf("scim: unsupported filter attribute %q", fields[0])
+	}
+
+	if !strings.EqualFold(fields[1], "eq") {
+		return nil, fmt.Errorf("scim: unsupported filter operator %q", fields[1])
+	}
+
+	value, err := strconv.Unquote(strings.TrimSpace(fields[2]))
+	if err != nil {
+		return nil, fmt.Errorf("scim: invalid filter value %q", fields[2])
+	}
+
+	return &userFilter{UserName: value}, nil
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scim // import "miniflux.app/v2/internal/scim"
+
+import "testing"
+
+func TestParseUserFilter(t *testing.T) {
+	scenarios := map[string]string{
+		`userName eq "alice"`: "alice",
+		`username EQ "Bob"`:   "Bob",
+		`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "carol@example.org"`: "carol@example.org",
+		`userName eq "with space"`: "with space",
+	}
+
+	for input, expected := range scenarios {
+		filter, err := parseUserFilter(input)
+		if err != nil {
+			t.Fatalf(`Unexpected error for %q: %v`, input, err)
+		}
+
+		if filter == nil || filter.UserName != expected {
+			t.Errorf(`Unexpected filter for %q, got %+v instead of %q`, input, filter, expected)
+		}
+	}
+}
+
+func TestParseEmptyUserFilter(t *testing.T) {
+	filter, err := parseUserFilter("  ")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if filter != nil {
+		t.Fatalf(`An empty filter should not return a filter, got %+v`, filter)
+	}
+}
+
+func TestParseUnsupportedUserFilter(t *testing.T) {
+	for _, input := range []string{
+		`externalId eq "123"`,
+		`userName co "ali"`,
+		`userName eq alice`,
+		`userName`,
+	} {
+		if _, err := parseUserFilter(input); err == nil {
+			t.Errorf(`The filter %q should not be supported`, input)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scim // import "miniflux.app/v2/internal/scim"
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/validator"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	store *storage.Storage
+}
+
+// Serve declares the SCIM 2.0 provisioning routes.
+func Serve(router *mux.Router, store *storage.Storage) {
+	handler := &handler{store}
+
+	sr := router.PathPrefix("/scim/v2").Subrouter()
+	sr.Use(authenticate)
+	sr.HandleFunc("/ServiceProviderConfig", handler.serviceProviderConfig).Methods(http.MethodGet)
+	sr.HandleFunc("/ResourceTypes", handler.resourceTypes).Methods(http.MethodGet)
+	sr.HandleFunc("/Schemas", handler.schemas).Methods(http.MethodGet)
+	sr.HandleFunc("/Users", handler.users).Methods(http.MethodGet)
+	sr.HandleFunc("/Users", handler.createUser).Methods(http.MethodPost)
+	sr.HandleFunc("/Users/{userID:[0-9]+}", handler.userByID).Methods(http.MethodGet)
+	sr.HandleFunc("/Users/{userID:[0-9]+}", handler.replaceUser).Methods(http.MethodPut)
+	sr.HandleFunc("/Users/{userID:[0-9]+}", handler.patchUser).Methods(http.MethodPatch)
+	sr.HandleFunc("/Users/{userID:[0-9]+}", handler.removeUser).Methods(http.MethodDelete)
+}
+
+func (h *handler) users(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseUserFilter(request.QueryStringParam(r, "filter", ""))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, errorTypeInvalidFilter, err.Error())
+		return
+	}
+
+	startIndex := max(request.QueryIntParam(r, "startIndex", 1), 1)
+	count := min(max(request.QueryIntParam(r, "count", defaultPageSize), 0), maxPageSize)
+
+	var users model.Users
+	if filter != nil {
+		user, err := h.store.UserByUsername(filter.UserName)
+		if err != nil {
+			writeServerError(w, r, err)
+			return
+		}
+
+		if user != nil {
+			users = append(users, user)
+		}
+	} else {
+		users, err = h.store.Users()
+		if err != nil {
+			writeServerError(w, r, err)
+			return
+		}
+	}
+
+	disabledUserIDs, err := h.store.DisabledUserIDs()
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+
+	var resources []interface{}
+	for i := startIndex - 1; i < len(users) && len(resources) < count; i++ {
+		resources = append(resources, newUserResource(users[i], disabledUserIDs[users[i].ID]))
+	}
+
+	writeResource(w, r, http.StatusOK, newListResponse(resources, len(users), startIndex))
+}
+
+func (h *handler) userByID(w http.ResponseWriter, r *http.Request) {
+	user := h.fetchUser(w, r)
+	if user == nil {
+		return
+	}
+
+	h.writeUser(w, r, http.StatusOK, user)
+}
+
+func (h *handler) createUser(w http.ResponseWriter, r *http.Request) {
+	var userRequest userRequest
+	if err := json.NewDecoder(r.Body).Decode(&userRequest); err != nil {
+		writeError(w, r, http.StatusBadRequest, errorTypeInvalidSyntax, err.Error())
+		return
+	}
+
+	if userRequest.UserName == "" {
+		writeError(w, r, http.StatusBadRequest, errorTypeInvalidValue, "the userName attribute is required")
+		return
+	}
+
+	if h.store.UserExists(userRequest.UserName) {
+		writeError(w, r, http.StatusConflict, errorTypeUniqueness, "a user with the same userName already exists")
+		return
+	}
+
+	user, err := h.store.CreateUser(&model.UserCreationRequest{Username: userRequest.UserName})
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+
+	if err := h.store.SetSCIMProvisioned(user.ID); err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+
+	if userRequest.ExternalID != nil {
+		if err := h.store.SetSCIMExternalID(user.ID, *userRequest.ExternalID); err != nil {
+			writeServerError(w, r, err)
+			return
+		}
+	}
+
+	if userRequest.Active != nil && !*userRequest.Active {
+		if err := h.store.SetUserDisabled(user.ID, true); err != nil {
+			writeServerError(w, r, err)
+			return
+		}
+	}
+
+	slog.Info("[SCIM] User provisioned",
+		slog.Int64("user_id", user.ID),
+		slog.String("username", user.Username),
+	)
+
+	h.writeUser(w, r, http.StatusCreated, user)
+}
+
+func (h *handler) replaceUser(w http.ResponseWriter, r *http.Request) {
+	user := h.fetchUser(w, r)
+	if user == nil {
+		return
+	}
+
+	var userRequest userRequest
+	if err := json.NewDecoder(r.Body).Decode(&userRequest); err != nil {
+		writeError(w, r, http.StatusBadRequest, errorTypeInvalidSyntax, err.Error())
+		return
+	}
+
+	changes := &userChanges{ExternalID: userRequest.ExternalID, Active: userRequest.Active}
+	if userRequest.UserName != "" {
+		changes.UserName = &userRequest.UserName
+	}
+
+	h.updateUser(w, r, user, changes)
+}
+
+func (h *handler) patchUser(w http.ResponseWriter, r *http.Request) {
+	user := h.fetchUser(w, r)
+	if user == nil {
+		return
+	}
+
+	var patchRequest patchRequest
+	if err := json.NewDecoder(r.Body).Decode(&patchRequest); err != nil {
+		writeError(w, r, http.StatusBadRequest, errorTypeInvalidSyntax, err.Error())
+		return
+	}
+
+	changes := &userChanges{}
+	if err := changes.apply(patchRequest.Operations); err != nil {
+		writeError(w, r, http.StatusBadRequest, errorTypeInvalidValue, err.Error())
+		return
+	}
+
+	h.updateUser(w, r, user, changes)
+}
+
+func (h *handler) removeUser(w http.ResponseWriter, r *http.Request) {
+	user := h.fetchUser(w, r)
+	if user == nil {
+		return
+	}
+
+	h.store.RemoveUserAsync(user.ID)
+
+	slog.Info("[SCIM] User deprovisioned",
+		slog.Int64("user_id", user.ID),
+		slog.String("username", user.Username),
+	)
+
+	builder := response.New(w, r)
+	builder.WithStatus(http.StatusNoContent)
+	builder.Write()
+}
+
+func (h *handler) updateUser(w http.ResponseWriter, r *http.Request, user *model.User, changes *userChanges) {
+	if changes.UserName != nil && *changes.UserName != user.Username {
+		userModificationRequest := &model.UserModificationRequest{Username: changes.UserName}
+		if validationErr := validator.ValidateUserModification(h.store, user.ID, userModificationRequest); validationErr != nil {
+			writeError(w, r, http.StatusBadRequest, errorTypeInvalidValue, validationErr.Error().Error())
+			return
+		}
+
+		userModificationRequest.Patch(user)
+		if err := h.store.UpdateUser(user); err != nil {
+			writeServerError(w, r, err)
+			return
+		}
+	}
+
+	if changes.ExternalID != nil {
+		if err := h.store.SetSCIMExternalID(user.ID, *changes.ExternalID); err != nil {
+			writeServerError(w, r, err)
+			return
+		}
+	}
+
+	if changes.Active != nil {
+		if err := h.store.SetUserDisabled(user.ID, !*changes.Active); err != nil {
+			writeServerError(w, r, err)
+			return
+		}
+
+		slog.Info("[SCIM] User status updated",
+			slog.Int64("user_id", user.ID),
+			slog.String("username", user.Username),
+			slog.Bool("active", *changes.Active),
+		)
+	}
+
+	h.writeUser(w, r, http.StatusOK, user)
+}
+
+func (h *handler) fetchUser(w http.ResponseWriter, r *http.Request) *model.User {
+	user, err := h.store.UserByID(request.RouteInt64Param(r, "userID"))
+	if err != nil {
+		writeServerError(w, r, err)
+		return nil
+	}
+
+	if user == nil {
+		writeError(w, r, http.StatusNotFound, "", "user not found")
+		return nil
+	}
+
+	return user
+}
+
+func (h *handler) writeUser(w http.ResponseWriter, r *http.Request, statusCode int, user *model.User) {
+	disabled, err := h.store.IsUserDisabled(user.ID)
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+
+	writeResource(w, r, statusCode, newUserResource(user, disabled))
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scim // import "miniflux.app/v2/internal/scim"
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type userRequest struct {
+	Schemas    []string `json:"schemas"`
+	UserName   string   `json:"userName"`
+	ExternalID *string  `json:"externalId"`
+	Active     *bool    `json:"active"`
+}
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// userChanges contains the attributes modified by a PATCH request.
+type userChanges struct {
+	UserName   *string
+	ExternalID *string
+	Active     *bool
+}
+
+// apply merges the operations into the changes.
+// Attributes not stored by Miniflux (name, emails, etc.) are ignored.
+func (c *userChanges) apply(operations []patchOperation) error {
+	for _, operation := range operations {
+		switch strings.ToLower(operation.Op) {
+		case "add", "replace":
+		default:
+			return fmt.Errorf("scim: unsupported patch operation %q", operation.Op)
+		}
+
+		if operation.Path != "" {
+			if err := c.set(operation.Path, operation.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return fmt.Errorf("scim: invalid patch value: %v", err)
+		}
+
+		for attribute, value := range attributes {
+			if err := c.set(attribute, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *userChanges) set(path string, value json.RawMessage) error {
+	path = strings.TrimPrefix(strings.ToLower(path), strings.ToLower(userSchema)+":")
+
+	switch path {
+	case "username":
+		var userName string
+		if err := json.Unmarshal(value, &userName); err != nil {
+			return fmt.Errorf("scim: invalid userName value: %v", err)
+		}
+		c.UserName = &userName
+	case "externalid":
+		var externalID string
+		if err := json.Unmarshal(value, &externalID); err != nil {
+			return fmt.Errorf("scim: invalid externalId value: %v", err)
+		}
+		c.ExternalID = &externalID
+	case "active":
+		active, err := parseBoolValue(value)
+		if err != nil {
+			return err
+		}
+		c.Active = &active
+	}
+
+	return nil
+}
+
+// parseBoolValue accepts JSON booleans and the "True"/"False" strings sent by some identity providers.
+func parseBoolValue(value json.RawMessage) (bool, error) {
+	var result bool
+	if err := json.Unmarshal(value, &result); err == nil {
+		return result, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(value, &str); err == nil {
+		switch strings.ToLower(str) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+
+	return false, fmt.Errorf("scim: invalid boolean value %s", value)
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scim // import "miniflux.app/v2/internal/scim"
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func parsePatchRequest(t *testing.T, body string) *userChanges {
+	t.Helper()
+
+	var patchRequest patchRequest
+	if err := json.Unmarshal([]byte(body), &patchRequest); err != nil {
+		t.Fatalf(`Unable to decode the patch request: %v`, err)
+	}
+
+	changes := &userChanges{}
+	if err := changes.apply(patchRequest.Operations); err != nil {
+		t.Fatalf(`Unable to apply the patch request: %v`, err)
+	}
+
+	return changes
+}
+
+func TestPatchWithPath(t *testing.T) {
+	changes := parsePatchRequest(t, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "replace", "path": "active", "value": false},
+			{"op": "Replace", "path": "userName", "value": "alice"}
+		]
+	}`)
+
+	if changes.Active == nil || *changes.Active {
+		t.Errorf(`The user should be deactivated, got %v`, changes.Active)
+	}
+
+	if changes.UserName == nil || *changes.UserName != "alice" {
+		t.Errorf(`Unexpected userName, got %v`, changes.UserName)
+	}
+}
+
+func TestPatchWithoutPath(t *testing.T) {
+	changes := parsePatchRequest(t, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "replace", "value": {"active": "True", "displayName": "Alice"}}
+		]
+	}`)
+
+	if changes.Active == nil || !*changes.Active {
+		t.Errorf(`The user should be activated, got %v`, changes.Active)
+	}
+
+	if changes.UserName != nil {
+		t.Errorf(`The userName should not change, got %q`, *changes.UserName)
+	}
+}
+
+func TestPatchIgnoresUnknownAttributes(t *testing.T) {
+	changes := parsePatchRequest(t, `{
+		"Operations": [
+			{"op": "add", "path": "emails[type eq \"work\"].value", "value": "alice@example.org"},
+			{"op": "replace", "path": "name.givenName", "value": "Alice"}
+		]
+	}`)
+
+	if changes.Active != nil || changes.UserName != nil {
+		t.Errorf(`Unknown attributes should be ignored, got %+v`, changes)
+	}
+}
+
+func TestPatchWithUnsupportedOperation(t *testing.T) {
+	changes := &userChanges{}
+	if err := changes.apply([]patchOperation{{Op: "remove", Path: "active"}}); err == nil {
+		t.Fatal(`The remove operation should not be supported`)
+	}
+}
+
+func TestPatchWithInvalidBoolean(t *testing.T) {
+	changes := &userChanges{}
+	if err := changes.apply([]patchOperation{{Op: "replace", Path: "active", Value: json.RawMessage(`"maybe"`)}}); err == nil {
+		t.Fatal(`An invalid boolean should be rejected`)
+	}
+}
+
+func TestPatchExternalID(t *testing.T) {
+	changes := parsePatchRequest(t, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "add", "value": {"externalId": "00u1abcd"}}
+		]
+	}`)
+
+	if changes.ExternalID == nil || *changes.ExternalID != "00u1abcd" {
+		t.Errorf(`Unexpected external ID, got %v`, changes.ExternalID)
+	}
+}
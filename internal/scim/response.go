@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scim // import "miniflux.app/v2/internal/scim"
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+)
+
+const (
+	contentTypeHeader = "application/scim+json"
+
+	userSchema             = "urn:ietf:params:scim:schemas:core:2.0:User"
+	listResponseSchema     = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	errorSchema            = "urn:ietf:params:scim:api:messages:2.0:Error"
+	serviceProviderSchema  = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	resourceTypeSchema     = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	schemaDefinitionSchema = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+
+	defaultPageSize = 100
+	maxPageSize     = 200
+
+	errorTypeInvalidFilter = "invalidFilter"
+	errorTypeInvalidSyntax = "invalidSyntax"
+	errorTypeInvalidValue  = "invalidValue"
+	errorTypeUniqueness    = "uniqueness"
+)
+
+type userResource struct {
+	Schemas  []string      `json:"schemas"`
+	ID       string        `json:"id"`
+	UserName string        `json:"userName"`
+	Active   bool          `json:"active"`
+	Meta     *resourceMeta `json:"meta"`
+}
+
+type resourceMeta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+type listResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+func newUserResource(user *model.User, disabled bool) *userResource {
+	userID := strconv.FormatInt(user.ID, 10)
+	return &userResource{
+		Schemas:  []string{userSchema},
+		ID:       userID,
+		UserName: user.Username,
+		Active:   !disabled,
+		Meta: &resourceMeta{
+			ResourceType: "User",
+			Location:     config.Opts.BaseURL() + "/scim/v2/Users/" + userID,
+		},
+	}
+}
+
+func newListResponse(resources []interface{}, totalResults, startIndex int) *listResponse {
+	if resources == nil {
+		resources = []interface{}{}
+	}
+
+	return &listResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+func writeResource(w http.ResponseWriter, r *http.Request, statusCode int, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+
+	builder := response.New(w, r)
+	builder.WithStatus(statusCode)
+	builder.WithHeader("Content-Type", contentTypeHeader)
+	builder.WithBody(data)
+	builder.Write()
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, statusCode int, scimType, detail string) {
+	slog.Warn("[SCIM] Request failed",
+		slog.String("client_ip", request.ClientIP(r)),
+		slog.String("method", r.Method),
+		slog.String("uri", r.RequestURI),
+		slog.Int("status_code", statusCode),
+		slog.String("detail", detail),
+	)
+
+	data, _ := json.Marshal(&errorResponse{
+		Schemas:  []string{errorSchema},
+		Status:   strconv.Itoa(statusCode),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+
+	builder := response.New(w, r)
+	builder.WithStatus(statusCode)
+	builder.WithHeader("Content-Type", contentTypeHeader)
+	builder.WithBody(data)
+	builder.Write()
+}
+
+func writeServerError(w http.ResponseWriter, r *http.Request, err error) {
+	slog.Error("[SCIM] Internal server error",
+		slog.String("client_ip", request.ClientIP(r)),
+		slog.String("method", r.Method),
+		slog.String("uri", r.RequestURI),
+		slog.Any("error", err),
+	)
+
+	data, _ := json.Marshal(&errorResponse{
+		Schemas: []string{errorSchema},
+		Status:  strconv.Itoa(http.StatusInternalServerError),
+		Detail:  http.StatusText(http.StatusInternalServerError),
+	})
+
+	builder := response.New(w, r)
+	builder.WithStatus(http.StatusInternalServerError)
+	builder.WithHeader("Content-Type", contentTypeHeader)
+	builder.WithBody(data)
+	builder.Write()
+}
@@ -6,6 +6,7 @@ package storage // import "miniflux.app/v2/internal/storage"
 import (
 	"fmt"
 
+	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/model"
 )
 
@@ -32,7 +33,7 @@ func (s *Storage) SetAPIKeyUsedTimestamp(userID int64, token string) error {
 func (s *Storage) APIKeys(userID int64) (model.APIKeys, error) {
 	query := `
 		SELECT
-			id, user_id, token, description, last_used_at, created_at
+			id, user_id, token, description, last_used_at, expires_at, created_at
 		FROM
 			api_keys
 		WHERE
@@ -54,6 +55,45 @@ func (s *Storage) APIKeys(userID int64) (model.APIKeys, error) {
 			&apiKey.Token,
 			&apiKey.Description,
 			&apiKey.LastUsedAt,
+			&apiKey.ExpiresAt,
+			&apiKey.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch API Key row: %v`, err)
+		}
+
+		apiKeys = append(apiKeys, &apiKey)
+	}
+
+	return apiKeys, nil
+}
+
+// AllAPIKeys returns the API Keys of all users, tokens are not included.
+func (s *Storage) AllAPIKeys() (model.APIKeys, error) {
+	query := `
+		SELECT
+			k.id, k.user_id, u.username, k.description, k.last_used_at, k.expires_at, k.created_at
+		FROM
+			api_keys k
+		JOIN
+			users u ON u.id=k.user_id
+		ORDER BY u.username ASC, k.description ASC
+	`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch API Keys: %v`, err)
+	}
+	defer rows.Close()
+
+	apiKeys := make(model.APIKeys, 0)
+	for rows.Next() {
+		var apiKey model.APIKey
+		if err := rows.Scan(
+			&apiKey.ID,
+			&apiKey.UserID,
+			&apiKey.Username,
+			&apiKey.Description,
+			&apiKey.LastUsedAt,
+			&apiKey.ExpiresAt,
 			&apiKey.CreatedAt,
 		); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch API Key row: %v`, err)
@@ -69,9 +109,9 @@ func (s *Storage) APIKeys(userID int64) (model.APIKeys, error) {
 func (s *Storage) CreateAPIKey(apiKey *model.APIKey) error {
 	query := `
 		INSERT INTO api_keys
-			(user_id, token, description)
+			(user_id, token, description, expires_at)
 		VALUES
-			($1, $2, $3)
+			($1, $2, $3, $4)
 		RETURNING
 			id, created_at
 	`
@@ -80,6 +120,7 @@ func (s *Storage) CreateAPIKey(apiKey *model.APIKey) error {
 		apiKey.UserID,
 		apiKey.Token,
 		apiKey.Description,
+		apiKey.ExpiresAt,
 	).Scan(
 		&apiKey.ID,
 		&apiKey.CreatedAt,
@@ -91,6 +132,25 @@ func (s *Storage) CreateAPIKey(apiKey *model.APIKey) error {
 	return nil
 }
 
+// RotateAPIKey replaces the token of an API Key, the key keeps its original lifetime.
+func (s *Storage) RotateAPIKey(userID, keyID int64) error {
+	query := `
+		UPDATE api_keys
+		SET
+			token=$3,
+			last_used_at=NULL,
+			expires_at=now() + (expires_at - created_at),
+			created_at=now()
+		WHERE id=$1 AND user_id=$2
+	`
+	_, err := s.db.Exec(query, keyID, userID, crypto.GenerateRandomString(32))
+	if err != nil {
+		return fmt.Errorf(`store: unable to rotate this API Key: %v`, err)
+	}
+
+	return nil
+}
+
 // RemoveAPIKey deletes an API Key.
 func (s *Storage) RemoveAPIKey(userID, keyID int64) error {
 	query := `DELETE FROM api_keys WHERE id = $1 AND user_id = $2`
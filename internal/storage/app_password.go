@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AppPasswordExists checks if an app password with the same description exists.
+func (s *Storage) AppPasswordExists(userID int64, description string) bool {
+	var result bool
+	query := `SELECT true FROM app_passwords WHERE user_id=$1 AND lower(description)=lower($2) LIMIT 1`
+	s.db.QueryRow(query, userID, description).Scan(&result)
+	return result
+}
+
+// AppPasswords returns all app passwords that belongs to the given user.
+func (s *Storage) AppPasswords(userID int64) (model.AppPasswords, error) {
+	query := `
+		SELECT
+			id, user_id, description, scope, last_used_at, created_at
+		FROM
+			app_passwords
+		WHERE
+			user_id=$1
+		ORDER BY description ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch app passwords: %v`, err)
+	}
+	defer rows.Close()
+
+	appPasswords := make(model.AppPasswords, 0)
+	for rows.Next() {
+		var appPassword model.AppPassword
+		if err := rows.Scan(
+			&appPassword.ID,
+			&appPassword.UserID,
+			&appPassword.Description,
+			&appPassword.Scope,
+			&appPassword.LastUsedAt,
+			&appPassword.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch app password row: %v`, err)
+		}
+
+		appPasswords = append(appPasswords, &appPassword)
+	}
+
+	return appPasswords, nil
+}
+
+// CreateAppPassword inserts a new app password.
+// The credential is the Fever API key or the bcrypt hash of the Google Reader password.
+func (s *Storage) CreateAppPassword(appPassword *model.AppPassword, credential string) error {
+	query := `
+		INSERT INTO app_passwords
+			(user_id, description, scope, credential)
+		VALUES
+			($1, $2, $3, $4)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		appPassword.UserID,
+		appPassword.Description,
+		appPassword.Scope,
+		credential,
+	).Scan(
+		&appPassword.ID,
+		&appPassword.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create app password: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveAppPassword deletes an app password.
+func (s *Storage) RemoveAppPassword(userID, appPasswordID int64) error {
+	query := `DELETE FROM app_passwords WHERE id = $1 AND user_id = $2`
+	if _, err := s.db.Exec(query, appPasswordID, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove this app password: %v`, err)
+	}
+
+	return nil
+}
+
+// AppPasswordCredentials returns the credentials of the user app passwords for the given scope.
+func (s *Storage) AppPasswordCredentials(userID int64, scope string) ([]string, error) {
+	rows, err := s.db.Query(`SELECT credential FROM app_passwords WHERE user_id=$1 AND scope=$2`, userID, scope)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch app passwords: %v`, err)
+	}
+	defer rows.Close()
+
+	var credentials []string
+	for rows.Next() {
+		var credential string
+		if err := rows.Scan(&credential); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch app password row: %v`, err)
+		}
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, nil
+}
+
+// CheckAppPassword compares the password with the user app passwords hashed for the given scope
+// and returns the matching credential.
+func (s *Storage) CheckAppPassword(userID int64, scope, password string) (string, error) {
+	credentials, err := s.AppPasswordCredentials(userID, scope)
+	if err != nil {
+		return "", err
+	}
+
+	for _, credential := range credentials {
+		if bcrypt.CompareHashAndPassword([]byte(credential), []byte(password)) == nil {
+			return credential, nil
+		}
+	}
+
+	return "", fmt.Errorf(`store: invalid app password for user #%d`, userID)
+}
+
+// SetAppPasswordUsedTimestamp updates the last used date of an app password.
+func (s *Storage) SetAppPasswordUsedTimestamp(scope, credential string) error {
+	query := `UPDATE app_passwords SET last_used_at=now() WHERE scope=$1 AND credential=$2`
+	if _, err := s.db.Exec(query, scope, credential); err != nil {
+		return fmt.Errorf(`store: unable to update last used date for app password: %v`, err)
+	}
+
+	return nil
+}
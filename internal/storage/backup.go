@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// UserPasswordHash returns the hashed password of a user, the value is empty when the user has no password.
+func (s *Storage) UserPasswordHash(userID int64) (string, error) {
+	var hash string
+	if err := s.db.QueryRow(`SELECT password FROM users WHERE id=$1`, userID).Scan(&hash); err != nil {
+		return "", fmt.Errorf(`store: unable to fetch password hash: %v`, err)
+	}
+
+	return hash, nil
+}
+
+// SetUserPasswordHash replaces the hashed password of a user.
+func (s *Storage) SetUserPasswordHash(userID int64, hash string) error {
+	if _, err := s.db.Exec(`UPDATE users SET password=$1 WHERE id=$2`, hash, userID); err != nil {
+		return fmt.Errorf(`store: unable to update password hash: %v`, err)
+	}
+
+	return nil
+}
+
+// RestoreEntries inserts entries from a backup and keeps their status, bookmark and dates.
+func (s *Storage) RestoreEntries(entries model.Entries) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	for _, entry := range entries {
+		if err := s.createEntry(tx, entry); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		query := `UPDATE entries SET status=$1, starred=$2, created_at=$3, changed_at=$4 WHERE id=$5`
+		if _, err := tx.Exec(query, entry.Status, entry.Starred, entry.CreatedAt, entry.ChangedAt, entry.ID); err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to restore entry %q: %v`, entry.URL, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
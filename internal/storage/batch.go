@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"miniflux.app/v2/internal/model"
 )
@@ -35,6 +36,12 @@ func (b *BatchBuilder) WithUserID(userID int64) *BatchBuilder {
 	return b
 }
 
+func (b *BatchBuilder) WithFeedID(feedID int64) *BatchBuilder {
+	b.conditions = append(b.conditions, fmt.Sprintf("id = $%d", len(b.args)+1))
+	b.args = append(b.args, feedID)
+	return b
+}
+
 func (b *BatchBuilder) WithCategoryID(categoryID int64) *BatchBuilder {
 	b.conditions = append(b.conditions, fmt.Sprintf("category_id = $%d", len(b.args)+1))
 	b.args = append(b.args, categoryID)
@@ -59,8 +66,28 @@ func (b *BatchBuilder) WithoutDisabledFeeds() *BatchBuilder {
 	return b
 }
 
+// WithinPollingWindow excludes the feeds that must not be refreshed at this time of the week.
+// The polling windows are evaluated in the timezone of the user, a window can span midnight.
+func (b *BatchBuilder) WithinPollingWindow() *BatchBuilder {
+	b.conditions = append(b.conditions, `
+		EXISTS (
+			SELECT 1 FROM users u, to_char(now() AT TIME ZONE u.timezone, 'HH24:MI') AS clock
+			WHERE
+				u.id = feeds.user_id AND
+				(
+					cardinality(feeds.polling_weekdays) = 0 OR
+					EXTRACT(isodow FROM now() AT TIME ZONE u.timezone)::int = ANY(feeds.polling_weekdays)
+				) AND (
+					feeds.polling_window_start = feeds.polling_window_end OR
+					(feeds.polling_window_start < feeds.polling_window_end AND clock >= feeds.polling_window_start AND clock < feeds.polling_window_end) OR
+					(feeds.polling_window_start > feeds.polling_window_end AND (clock >= feeds.polling_window_start OR clock < feeds.polling_window_end))
+				)
+		)`)
+	return b
+}
+
 func (b *BatchBuilder) FetchJobs() (jobs model.JobList, err error) {
-	query := `SELECT id, user_id FROM feeds`
+	query := `SELECT id, user_id, feed_url FROM feeds`
 
 	if len(b.conditions) > 0 {
 		query += fmt.Sprintf(" WHERE %s", strings.Join(b.conditions, " AND "))
@@ -78,7 +105,48 @@ func (b *BatchBuilder) FetchJobs() (jobs model.JobList, err error) {
 
 	for rows.Next() {
 		var job model.Job
-		if err := rows.Scan(&job.FeedID, &job.UserID); err != nil {
+		if err := rows.Scan(&job.FeedID, &job.UserID, &job.FeedURL); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch job: %v`, err)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// ClaimJobs fetches a batch of jobs and postpones the next check of the selected feeds by the lease duration.
+// Rows locked by another instance are skipped, so several instances sharing the database never fetch the same feeds.
+// The next check date is computed again when the feed is refreshed, the lease only matters if the refresh never happens.
+func (b *BatchBuilder) ClaimJobs(lease time.Duration) (jobs model.JobList, err error) {
+	subquery := `SELECT id FROM feeds`
+
+	if len(b.conditions) > 0 {
+		subquery += fmt.Sprintf(" WHERE %s", strings.Join(b.conditions, " AND "))
+	}
+
+	subquery += " ORDER BY next_check_at ASC"
+
+	if b.limit > 0 {
+		subquery += fmt.Sprintf(" LIMIT %d", b.limit)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE feeds
+		SET next_check_at = now() + make_interval(secs => $%d)
+		WHERE id IN (%s FOR UPDATE SKIP LOCKED)
+		RETURNING id, user_id, feed_url
+	`, len(b.args)+1, subquery)
+
+	rows, err := b.db.Query(query, append(b.args, lease.Seconds())...)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to claim batch of jobs: %v`, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var job model.Job
+		if err := rows.Scan(&job.FeedID, &job.UserID, &job.FeedURL); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch job: %v`, err)
 		}
 
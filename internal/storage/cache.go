@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"miniflux.app/v2/internal/cache"
+)
+
+// UseCache stores user sessions, user settings and unread counters in the given cache.
+// Cached values are invalidated when modified through the storage, the cache TTL bounds the staleness for other changes.
+func (s *Storage) UseCache(c *cache.Cache) {
+	s.cache = c
+}
+
+func unreadEntriesCacheKey(userID int64) string {
+	return "unread_entries:" + strconv.FormatInt(userID, 10)
+}
+
+func feedsWithErrorsCacheKey(userID int64) string {
+	return "feeds_with_errors:" + strconv.FormatInt(userID, 10)
+}
+
+func userCacheKey(userID int64) string {
+	return "user:" + strconv.FormatInt(userID, 10)
+}
+
+// userSessionCacheKey hashes the token to avoid storing valid session tokens in Redis.
+func userSessionCacheKey(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return "user_session:" + hex.EncodeToString(hash[:])
+}
+
+func (s *Storage) cachedCounter(key string) (int, bool) {
+	value, found := s.cache.Get(key)
+	if !found {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(string(value))
+	return n, err == nil
+}
+
+func (s *Storage) cacheCounter(key string, n int) {
+	s.cache.Set(key, []byte(strconv.Itoa(n)))
+}
+
+func (s *Storage) invalidateCounters(userID int64) {
+	s.cache.Delete(unreadEntriesCacheKey(userID), feedsWithErrorsCacheKey(userID))
+}
+
+func (s *Storage) invalidateUser(userID int64) {
+	s.cache.Delete(userCacheKey(userID))
+}
+
+// invalidateUserSessions leaves a tombstone for the removed sessions, so a concurrent UserSessionByToken
+// that read the session before its removal cannot cache it again.
+func (s *Storage) invalidateUserSessions(tokens ...string) {
+	keys := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		keys = append(keys, userSessionCacheKey(token))
+	}
+	s.cache.Tombstone(keys...)
+}
@@ -40,8 +40,8 @@ func (s *Storage) CategoryIDExists(userID, categoryID int64) bool {
 func (s *Storage) Category(userID, categoryID int64) (*model.Category, error) {
 	var category model.Category
 
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 AND id=$2`
-	err := s.db.QueryRow(query, userID, categoryID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	query := `SELECT id, user_id, title, hide_globally, auto_archive_after_days, auto_archive_status FROM categories WHERE user_id=$1 AND id=$2`
+	err := s.db.QueryRow(query, userID, categoryID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.AutoArchiveAfterDays, &category.AutoArchiveStatus)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -55,10 +55,10 @@ func (s *Storage) Category(userID, categoryID int64) (*model.Category, error) {
 
 // FirstCategory returns the first category for the given user.
 func (s *Storage) FirstCategory(userID int64) (*model.Category, error) {
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 ORDER BY title ASC LIMIT 1`
+	query := `SELECT id, user_id, title, hide_globally, auto_archive_after_days, auto_archive_status FROM categories WHERE user_id=$1 ORDER BY title ASC LIMIT 1`
 
 	var category model.Category
-	err := s.db.QueryRow(query, userID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	err := s.db.QueryRow(query, userID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.AutoArchiveAfterDays, &category.AutoArchiveStatus)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -74,8 +74,8 @@ func (s *Storage) FirstCategory(userID int64) (*model.Category, error) {
 func (s *Storage) CategoryByTitle(userID int64, title string) (*model.Category, error) {
 	var category model.Category
 
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 AND title=$2`
-	err := s.db.QueryRow(query, userID, title).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	query := `SELECT id, user_id, title, hide_globally, auto_archive_after_days, auto_archive_status FROM categories WHERE user_id=$1 AND title=$2`
+	err := s.db.QueryRow(query, userID, title).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.AutoArchiveAfterDays, &category.AutoArchiveStatus)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -89,7 +89,7 @@ func (s *Storage) CategoryByTitle(userID int64, title string) (*model.Category,
 
 // Categories returns all categories that belongs to the given user.
 func (s *Storage) Categories(userID int64) (model.Categories, error) {
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 ORDER BY title ASC`
+	query := `SELECT id, user_id, title, hide_globally, auto_archive_after_days, auto_archive_status FROM categories WHERE user_id=$1 ORDER BY title ASC`
 	rows, err := s.db.Query(query, userID)
 	if err != nil {
 		return nil, fmt.Errorf(`store: unable to fetch categories: %v`, err)
@@ -99,7 +99,7 @@ func (s *Storage) Categories(userID int64) (model.Categories, error) {
 	categories := make(model.Categories, 0)
 	for rows.Next() {
 		var category model.Category
-		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally); err != nil {
+		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.AutoArchiveAfterDays, &category.AutoArchiveStatus); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch category row: %v`, err)
 		}
 
@@ -122,6 +122,8 @@ func (s *Storage) CategoriesWithFeedCount(userID int64) (model.Categories, error
 			c.user_id,
 			c.title,
 			c.hide_globally,
+			c.auto_archive_after_days,
+			c.auto_archive_status,
 			(SELECT count(*) FROM feeds WHERE feeds.category_id=c.id) AS count,
 			(SELECT count(*)
 			   FROM feeds
@@ -154,7 +156,7 @@ func (s *Storage) CategoriesWithFeedCount(userID int64) (model.Categories, error
 	categories := make(model.Categories, 0)
 	for rows.Next() {
 		var category model.Category
-		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.FeedCount, &category.TotalUnread); err != nil {
+		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.AutoArchiveAfterDays, &category.AutoArchiveStatus, &category.FeedCount, &category.TotalUnread); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch category row: %v`, err)
 		}
 
@@ -176,7 +178,9 @@ func (s *Storage) CreateCategory(userID int64, request *model.CategoryRequest) (
 		RETURNING
 			id,
 			user_id,
-			title
+			title,
+			auto_archive_after_days,
+			auto_archive_status
 	`
 	err := s.db.QueryRow(
 		query,
@@ -186,6 +190,8 @@ func (s *Storage) CreateCategory(userID int64, request *model.CategoryRequest) (
 		&category.ID,
 		&category.UserID,
 		&category.Title,
+		&category.AutoArchiveAfterDays,
+		&category.AutoArchiveStatus,
 	)
 
 	if err != nil {
@@ -197,11 +203,17 @@ func (s *Storage) CreateCategory(userID int64, request *model.CategoryRequest) (
 
 // UpdateCategory updates an existing category.
 func (s *Storage) UpdateCategory(category *model.Category) error {
-	query := `UPDATE categories SET title=$1, hide_globally = $2 WHERE id=$3 AND user_id=$4`
+	query := `
+		UPDATE categories
+		SET title=$1, hide_globally=$2, auto_archive_after_days=$3, auto_archive_status=$4
+		WHERE id=$5 AND user_id=$6
+	`
 	_, err := s.db.Exec(
 		query,
 		category.Title,
 		category.HideGlobally,
+		category.AutoArchiveAfterDays,
+		category.AutoArchiveStatus,
 		category.ID,
 		category.UserID,
 	)
@@ -209,6 +221,7 @@ func (s *Storage) UpdateCategory(category *model.Category) error {
 	if err != nil {
 		return fmt.Errorf(`store: unable to update category: %v`, err)
 	}
+	s.invalidateCounters(category.UserID)
 
 	return nil
 }
@@ -229,6 +242,7 @@ func (s *Storage) RemoveCategory(userID, categoryID int64) error {
 	if count == 0 {
 		return errors.New(`store: no category has been removed`)
 	}
+	s.invalidateCounters(userID)
 
 	return nil
 }
@@ -23,7 +23,8 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 			url,
 			size,
 			mime_type,
-		    media_progression
+		    media_progression,
+			duration
 		FROM
 			enclosures
 		WHERE
@@ -48,6 +49,7 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 			&enclosure.Size,
 			&enclosure.MimeType,
 			&enclosure.MediaProgression,
+			&enclosure.Duration,
 		)
 
 		if err != nil {
@@ -69,7 +71,8 @@ func (s *Storage) GetEnclosure(enclosureID int64) (*model.Enclosure, error) {
 			url,
 			size,
 			mime_type,
-		    media_progression
+		    media_progression,
+			duration
 		FROM
 			enclosures
 		WHERE
@@ -88,6 +91,7 @@ func (s *Storage) GetEnclosure(enclosureID int64) (*model.Enclosure, error) {
 		&enclosure.Size,
 		&enclosure.MimeType,
 		&enclosure.MediaProgression,
+		&enclosure.Duration,
 	)
 
 	if err != nil {
@@ -105,10 +109,10 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 
 	query := `
 		INSERT INTO enclosures
-			(url, size, mime_type, entry_id, user_id, media_progression)
+			(url, size, mime_type, entry_id, user_id, media_progression, duration)
 		VALUES
-			($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (user_id, entry_id, md5(url)) DO NOTHING
+			($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, entry_id, md5(url)) DO UPDATE SET duration=EXCLUDED.duration WHERE enclosures.duration <> EXCLUDED.duration
 		RETURNING
 			id
 	`
@@ -120,6 +124,7 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 		enclosure.EntryID,
 		enclosure.UserID,
 		enclosure.MediaProgression,
+		enclosure.Duration,
 	).Scan(&enclosure.ID); err != nil && err != sql.ErrNoRows {
 		return fmt.Errorf(`store: unable to create enclosure: %w`, err)
 	}
@@ -166,9 +171,10 @@ func (s *Storage) UpdateEnclosure(enclosure *model.Enclosure) error {
 			mime_type=$3,
 			entry_id=$4, 
 			user_id=$5, 
-			media_progression=$6
+			media_progression=$6,
+			duration=$7
 		WHERE
-			id=$7
+			id=$8
 	`
 	_, err := s.db.Exec(query,
 		enclosure.URL,
@@ -177,6 +183,7 @@ func (s *Storage) UpdateEnclosure(enclosure *model.Enclosure) error {
 		enclosure.EntryID,
 		enclosure.UserID,
 		enclosure.MediaProgression,
+		enclosure.Duration,
 		enclosure.ID,
 	)
 
@@ -48,6 +48,10 @@ func (s *Storage) CountAllEntries() map[string]int64 {
 
 // CountUnreadEntries returns the number of unread entries.
 func (s *Storage) CountUnreadEntries(userID int64) int {
+	if n, found := s.cachedCounter(unreadEntriesCacheKey(userID)); found {
+		return n
+	}
+
 	builder := s.NewEntryQueryBuilder(userID)
 	builder.WithStatus(model.EntryStatusUnread)
 	builder.WithGloballyVisible()
@@ -61,6 +65,7 @@ func (s *Storage) CountUnreadEntries(userID int64) int {
 		return 0
 	}
 
+	s.cacheCounter(unreadEntriesCacheKey(userID), n)
 	return n
 }
 
@@ -78,18 +83,42 @@ func (s *Storage) UpdateEntryTitleAndContent(entry *model.Entry) error {
 			title=$1,
 			content=$2,
 			reading_time=$3,
-			document_vectors = setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($2, ''), 500000)), 'B')
+			document_vectors = setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($2, ''), 500000)), 'B'),
+			feed_content=$6,
+			translated_title=$7,
+			translated_content=$8,
+			translation_language=$9
 		WHERE
 			id=$4 AND user_id=$5
 	`
 
-	if _, err := s.db.Exec(query, entry.Title, entry.Content, entry.ReadingTime, entry.ID, entry.UserID); err != nil {
+	if _, err := s.db.Exec(query, entry.Title, entry.Content, entry.ReadingTime, entry.ID, entry.UserID, entry.FeedContent, entry.TranslatedTitle, entry.TranslatedContent, entry.TranslationLanguage); err != nil {
 		return fmt.Errorf(`store: unable to update entry #%d: %v`, entry.ID, err)
 	}
 
 	return nil
 }
 
+// UpdateEntryTranslation stores the translation of the entry title and content.
+func (s *Storage) UpdateEntryTranslation(entry *model.Entry) error {
+	query := `
+		UPDATE
+			entries
+		SET
+			translated_title=$1,
+			translated_content=$2,
+			translation_language=$3
+		WHERE
+			id=$4 AND user_id=$5
+	`
+
+	if _, err := s.db.Exec(query, entry.TranslatedTitle, entry.TranslatedContent, entry.TranslationLanguage, entry.ID, entry.UserID); err != nil {
+		return fmt.Errorf(`store: unable to update the translation of entry #%d: %v`, entry.ID, err)
+	}
+
+	return nil
+}
+
 // createEntry add a new entry.
 func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 	query := `
@@ -107,7 +136,19 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				reading_time,
 				changed_at,
 				document_vectors,
-				tags
+				tags,
+				thumbnail_url,
+				latitude,
+				longitude,
+				language,
+				comments_feed_url,
+				podcast_episode,
+				podcast_season,
+				podcast_episode_type,
+				feed_content,
+				translated_title,
+				translated_content,
+				translation_language
 			)
 		VALUES
 			(
@@ -123,7 +164,19 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				$10,
 				now(),
 				setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($6, ''), 500000)), 'B'),
-				$11
+				$11,
+				$12,
+				$13,
+				$14,
+				$15,
+				$16,
+				$17,
+				$18,
+				$19,
+				$20,
+				$21,
+				$22,
+				$23
 			)
 		RETURNING
 			id, status, created_at, changed_at
@@ -141,6 +194,18 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.FeedID,
 		entry.ReadingTime,
 		pq.Array(removeEmpty(removeDuplicates(entry.Tags))),
+		entry.ThumbnailURL,
+		entry.Latitude,
+		entry.Longitude,
+		entry.Language,
+		entry.CommentsFeedURL,
+		entry.PodcastEpisode,
+		entry.PodcastSeason,
+		entry.PodcastEpisodeType,
+		entry.FeedContent,
+		entry.TranslatedTitle,
+		entry.TranslatedContent,
+		entry.TranslationLanguage,
 	).Scan(
 		&entry.ID,
 		&entry.Status,
@@ -179,7 +244,19 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 			author=$5,
 			reading_time=$6,
 			document_vectors = setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($4, ''), 500000)), 'B'),
-			tags=$10
+			tags=$10,
+			thumbnail_url=$11,
+			latitude=$12,
+			longitude=$13,
+			language=$14,
+			comments_feed_url=$15,
+			podcast_episode=$16,
+			podcast_season=$17,
+			podcast_episode_type=$18,
+			feed_content=$19,
+			translated_title=CASE WHEN title=$1 AND content=$4 THEN translated_title ELSE '' END,
+			translated_content=CASE WHEN title=$1 AND content=$4 THEN translated_content ELSE '' END,
+			translation_language=CASE WHEN title=$1 AND content=$4 THEN translation_language ELSE '' END
 		WHERE
 			user_id=$7 AND feed_id=$8 AND hash=$9
 		RETURNING
@@ -197,6 +274,15 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.FeedID,
 		entry.Hash,
 		pq.Array(removeEmpty(removeDuplicates(entry.Tags))),
+		entry.ThumbnailURL,
+		entry.Latitude,
+		entry.Longitude,
+		entry.Language,
+		entry.CommentsFeedURL,
+		entry.PodcastEpisode,
+		entry.PodcastSeason,
+		entry.PodcastEpisodeType,
+		entry.FeedContent,
 	).Scan(&entry.ID)
 
 	if err != nil {
@@ -269,23 +355,47 @@ func (s *Storage) cleanupEntries(feedID int64, entryHashes []string) error {
 
 // RefreshFeedEntries updates feed entries while refreshing a feed.
 func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, err error) {
-	var entryHashes []string
+	newEntries, entryHashes, err := s.saveFeedEntries(userID, feedID, entries, updateExistingEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		if err := s.cleanupEntries(feedID, entryHashes); err != nil {
+			slog.Error("Unable to cleanup entries",
+				slog.Int64("user_id", userID),
+				slog.Int64("feed_id", feedID),
+				slog.Any("error", err),
+			)
+		}
+	}()
 
+	return newEntries, nil
+}
+
+// AddFeedEntries saves the entries of a partial feed document, for example the new entries sent by a WebSub hub.
+// Unlike RefreshFeedEntries, the removed entries missing from the document are kept.
+func (s *Storage) AddFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, err error) {
+	newEntries, _, err = s.saveFeedEntries(userID, feedID, entries, updateExistingEntries)
+	return newEntries, err
+}
+
+func (s *Storage) saveFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, entryHashes []string, err error) {
 	for _, entry := range entries {
 		entry.UserID = userID
 		entry.FeedID = feedID
 
 		tx, err := s.db.Begin()
 		if err != nil {
-			return nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
+			return nil, nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
 		}
 
 		entryExists, err := s.entryExists(tx, entry)
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				return nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
+				return nil, nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
 			}
-			return nil, err
+			return nil, nil, err
 		}
 
 		if entryExists {
@@ -301,29 +411,23 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				return nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
+				return nil, nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
 			}
-			return nil, err
+			return nil, nil, err
 		}
 
 		if err := tx.Commit(); err != nil {
-			return nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
+			return nil, nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
 		}
 
 		entryHashes = append(entryHashes, entry.Hash)
 	}
 
-	go func() {
-		if err := s.cleanupEntries(feedID, entryHashes); err != nil {
-			slog.Error("Unable to cleanup entries",
-				slog.Int64("user_id", userID),
-				slog.Int64("feed_id", feedID),
-				slog.Any("error", err),
-			)
-		}
-	}()
+	if len(newEntries) > 0 {
+		s.invalidateCounters(userID)
+	}
 
-	return newEntries, nil
+	return newEntries, entryHashes, nil
 }
 
 // ArchiveEntries changes the status of entries to "removed" after the given number of days.
@@ -366,6 +470,79 @@ func (s *Storage) ArchiveEntries(status string, days, limit int) (int64, error)
 	return count, nil
 }
 
+// ApplyAutoArchiveRules changes the status of the entries older than the archive rule of their feed.
+// The rule of the feed takes precedence, the rule of the category is used when the feed has none.
+func (s *Storage) ApplyAutoArchiveRules(limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	query := `
+		UPDATE
+			entries
+		SET
+			status=r.status,
+			changed_at=now()
+		FROM (
+			SELECT
+				e.id,
+				rule.status
+			FROM
+				entries e
+			JOIN
+				feeds f ON f.id=e.feed_id
+			JOIN
+				categories c ON c.id=f.category_id
+			CROSS JOIN LATERAL (
+				SELECT
+					CASE WHEN f.auto_archive_after_days > 0 THEN f.auto_archive_after_days ELSE c.auto_archive_after_days END AS days,
+					CASE WHEN f.auto_archive_after_days > 0 THEN f.auto_archive_status ELSE c.auto_archive_status END AS status
+			) rule
+			WHERE
+				rule.days > 0 AND
+				e.status <> $1 AND
+				e.status <> rule.status AND
+				e.starred is false AND
+				e.share_code='' AND
+				e.published_at < now() - rule.days * interval '1 day'
+			ORDER BY
+				e.published_at ASC
+			LIMIT $2
+		) r
+		WHERE
+			entries.id=r.id
+		RETURNING
+			entries.user_id
+	`
+
+	rows, err := s.db.Query(query, model.EntryStatusRemoved, limit)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to apply archive rules: %v`, err)
+	}
+	defer rows.Close()
+
+	var count int64
+	userIDs := make(map[int64]struct{})
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return 0, fmt.Errorf(`store: unable to fetch archived entry row: %v`, err)
+		}
+		userIDs[userID] = struct{}{}
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf(`store: unable to apply archive rules: %v`, err)
+	}
+
+	for userID := range userIDs {
+		s.invalidateCounters(userID)
+	}
+
+	return count, nil
+}
+
 // SetEntriesStatus update the status of the given list of entries.
 func (s *Storage) SetEntriesStatus(userID int64, entryIDs []int64, status string) error {
 	query := `UPDATE entries SET status=$1, changed_at=now() WHERE user_id=$2 AND id=ANY($3)`
@@ -373,6 +550,7 @@ func (s *Storage) SetEntriesStatus(userID int64, entryIDs []int64, status string
 	if err != nil {
 		return fmt.Errorf(`store: unable to update entries statuses %v: %v`, entryIDs, err)
 	}
+	s.invalidateCounters(userID)
 
 	count, err := result.RowsAffected()
 	if err != nil {
@@ -476,6 +654,7 @@ func (s *Storage) MarkAllAsRead(userID int64) error {
 	if err != nil {
 		return fmt.Errorf(`store: unable to mark all entries as read: %v`, err)
 	}
+	s.invalidateCounters(userID)
 
 	count, _ := result.RowsAffected()
 	slog.Debug("Marked all entries as read",
@@ -506,6 +685,7 @@ func (s *Storage) MarkGloballyVisibleFeedsAsRead(userID int64) error {
 	if err != nil {
 		return fmt.Errorf(`store: unable to mark globally visible feeds as read: %v`, err)
 	}
+	s.invalidateCounters(userID)
 
 	count, _ := result.RowsAffected()
 	slog.Debug("Marked globally visible feed entries as read",
@@ -531,6 +711,7 @@ func (s *Storage) MarkFeedAsRead(userID, feedID int64, before time.Time) error {
 	if err != nil {
 		return fmt.Errorf(`store: unable to mark feed entries as read: %v`, err)
 	}
+	s.invalidateCounters(userID)
 
 	count, _ := result.RowsAffected()
 	slog.Debug("Marked feed entries as read",
@@ -567,6 +748,7 @@ func (s *Storage) MarkCategoryAsRead(userID, categoryID int64, before time.Time)
 	if err != nil {
 		return fmt.Errorf(`store: unable to mark category entries as read: %v`, err)
 	}
+	s.invalidateCounters(userID)
 
 	count, _ := result.RowsAffected()
 	slog.Debug("Marked category entries as read",
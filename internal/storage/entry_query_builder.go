@@ -72,6 +72,20 @@ func (e *EntryQueryBuilder) AfterChangedDate(date time.Time) *EntryQueryBuilder
 	return e
 }
 
+// BeforeCreatedDate adds a condition < created_at
+func (e *EntryQueryBuilder) BeforeCreatedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("e.created_at < $%d", len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// AfterCreatedDate adds a condition > created_at
+func (e *EntryQueryBuilder) AfterCreatedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("e.created_at > $%d", len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
 // BeforePublishedDate adds a condition < published_at
 func (e *EntryQueryBuilder) BeforePublishedDate(date time.Time) *EntryQueryBuilder {
 	e.conditions = append(e.conditions, fmt.Sprintf("e.published_at < $%d", len(e.args)+1))
@@ -268,12 +282,24 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			e.title,
 			e.url,
 			e.comments_url,
+			e.comments_feed_url,
+			e.thumbnail_url,
+			e.latitude,
+			e.longitude,
 			e.author,
+			e.language,
 			e.share_code,
 			e.content,
+			e.feed_content,
+			e.translated_title,
+			e.translated_content,
+			e.translation_language,
 			e.status,
 			e.starred,
 			e.reading_time,
+			e.podcast_episode,
+			e.podcast_season,
+			e.podcast_episode_type,
 			e.created_at,
 			e.changed_at,
 			e.tags,
@@ -294,6 +320,7 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			f.hide_globally,
 			f.no_media_player,
 			fi.icon_id,
+			fi.dark_icon_id,
 			u.timezone
 		FROM
 			entries e
@@ -320,7 +347,7 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 
 	entries := make(model.Entries, 0)
 	for rows.Next() {
-		var iconID sql.NullInt64
+		var iconID, darkIconID sql.NullInt64
 		var tz string
 		var hasEnclosure sql.NullBool
 
@@ -335,12 +362,24 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			&entry.Title,
 			&entry.URL,
 			&entry.CommentsURL,
+			&entry.CommentsFeedURL,
+			&entry.ThumbnailURL,
+			&entry.Latitude,
+			&entry.Longitude,
 			&entry.Author,
+			&entry.Language,
 			&entry.ShareCode,
 			&entry.Content,
+			&entry.FeedContent,
+			&entry.TranslatedTitle,
+			&entry.TranslatedContent,
+			&entry.TranslationLanguage,
 			&entry.Status,
 			&entry.Starred,
 			&entry.ReadingTime,
+			&entry.PodcastEpisode,
+			&entry.PodcastSeason,
+			&entry.PodcastEpisodeType,
 			&entry.CreatedAt,
 			&entry.ChangedAt,
 			pq.Array(&entry.Tags),
@@ -361,6 +400,7 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			&entry.Feed.HideGlobally,
 			&entry.Feed.NoMediaPlayer,
 			&iconID,
+			&darkIconID,
 			&tz,
 		)
 
@@ -377,6 +417,7 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 
 		if iconID.Valid {
 			entry.Feed.Icon.IconID = iconID.Int64
+			entry.Feed.Icon.DarkIconID = darkIconID.Int64
 		} else {
 			entry.Feed.Icon.IconID = 0
 		}
@@ -12,6 +12,8 @@ import (
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
 )
 
 type byStateAndName struct{ f model.Feeds }
@@ -91,6 +93,10 @@ func (s *Storage) CountAllFeeds() map[string]int64 {
 
 // CountUserFeedsWithErrors returns the number of feeds with parsing errors that belong to the given user.
 func (s *Storage) CountUserFeedsWithErrors(userID int64) int {
+	if n, found := s.cachedCounter(feedsWithErrorsCacheKey(userID)); found {
+		return n
+	}
+
 	pollingParsingErrorLimit := config.Opts.PollingParsingErrorLimit()
 	if pollingParsingErrorLimit <= 0 {
 		pollingParsingErrorLimit = 1
@@ -102,6 +108,7 @@ func (s *Storage) CountUserFeedsWithErrors(userID int64) int {
 		return 0
 	}
 
+	s.cacheCounter(feedsWithErrorsCacheKey(userID), result)
 	return result
 }
 
@@ -162,23 +169,26 @@ func (s *Storage) FeedsByCategoryWithCounters(userID, categoryID int64) (model.F
 	return getFeedsSorted(builder)
 }
 
-// WeeklyFeedEntryCount returns the weekly entry count for a feed.
+// WeeklyFeedEntryCount returns the estimated number of entries published by the feed per week.
+//
+// Entries of the last four weeks are weighted with a half-life of one week, so the estimate follows
+// the recent posting cadence. Entries published during the last six hours are extrapolated to a week,
+// so a quiet feed that suddenly becomes active is checked more often right away.
 func (s *Storage) WeeklyFeedEntryCount(userID, feedID int64) (int, error) {
-	// Calculate a virtual weekly count based on the average updating frequency.
-	// This helps after just adding a high volume feed.
-	// Return 0 when the 'count(*)' is zero(0) or one(1).
+	// The decayed sum is divided by the integral of the weights over four weeks: (1 - 0.5^4) / ln(2).
 	query := `
 		SELECT
-			COALESCE(CAST(CEIL(
-				(EXTRACT(epoch from interval '1 week'))	/
-				NULLIF((EXTRACT(epoch from (max(published_at)-min(published_at))/NULLIF((count(*)-1), 0) )), 0)
-			) AS BIGINT), 0)
+			COALESCE(CAST(CEIL(GREATEST(
+				SUM(POWER(0.5, EXTRACT(epoch FROM now() - published_at) / EXTRACT(epoch FROM interval '1 week'))) / 1.3525,
+				COUNT(*) FILTER (WHERE published_at >= now() - interval '6 hours') * 28
+			)) AS BIGINT), 0)
 		FROM
 			entries
 		WHERE
 			entries.user_id=$1 AND
 			entries.feed_id=$2 AND
-			entries.published_at >= now() - interval '1 week';
+			entries.published_at >= now() - interval '4 weeks' AND
+			entries.published_at <= now();
 	`
 
 	var weeklyCount int
@@ -239,10 +249,16 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 			no_media_player,
 			apprise_service_urls,
 			disable_http2,
-			description
+			description,
+			page_monitor_selector,
+			newsletter_token,
+			relaxed_parsing,
+			fetch_via_headless_browser,
+			content_extractor,
+			rewrite_script
 		)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32)
 		RETURNING
 			id
 	`
@@ -274,6 +290,12 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		feed.AppriseServiceURLs,
 		feed.DisableHTTP2,
 		feed.Description,
+		feed.PageMonitorSelector,
+		feed.NewsletterToken,
+		feed.RelaxedParsing,
+		feed.FetchViaHeadlessBrowser,
+		feed.ContentExtractor,
+		feed.RewriteScript,
 	).Scan(&feed.ID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to create feed %q: %v`, feed.FeedURL, err)
@@ -349,9 +371,24 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 			disable_http2=$28,
 			description=$29,
 			ntfy_enabled=$30,
-			ntfy_priority=$31
+			ntfy_priority=$31,
+			matrix_bot_enabled=$32,
+			polling_min_interval=$33,
+			polling_max_interval=$34,
+			refresh_interval=$35,
+			polling_weekdays=COALESCE($36::int[], '{}'),
+			polling_window_start=$37,
+			polling_window_end=$38,
+			auto_archive_after_days=$39,
+			auto_archive_status=$40,
+			page_monitor_selector=$41,
+			relaxed_parsing=$42,
+			fetch_via_headless_browser=$43,
+			content_extractor=$44,
+			rewrite_script=$45,
+			auto_translate=$46
 		WHERE
-			id=$32 AND user_id=$33
+			id=$47 AND user_id=$48
 	`
 	_, err = s.db.Exec(query,
 		feed.FeedURL,
@@ -385,6 +422,21 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.Description,
 		feed.NtfyEnabled,
 		feed.NtfyPriority,
+		feed.MatrixBotEnabled,
+		feed.PollingMinInterval,
+		feed.PollingMaxInterval,
+		feed.RefreshInterval,
+		pq.Array(feed.PollingWeekdays),
+		feed.PollingWindowStart,
+		feed.PollingWindowEnd,
+		feed.AutoArchiveAfterDays,
+		feed.AutoArchiveStatus,
+		feed.PageMonitorSelector,
+		feed.RelaxedParsing,
+		feed.FetchViaHeadlessBrowser,
+		feed.ContentExtractor,
+		feed.RewriteScript,
+		feed.AutoTranslate,
 		feed.ID,
 		feed.UserID,
 	)
@@ -392,6 +444,7 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 	if err != nil {
 		return fmt.Errorf(`store: unable to update feed #%d (%s): %v`, feed.ID, feed.FeedURL, err)
 	}
+	s.invalidateCounters(feed.UserID)
 
 	return nil
 }
@@ -421,6 +474,7 @@ func (s *Storage) UpdateFeedError(feed *model.Feed) (err error) {
 	if err != nil {
 		return fmt.Errorf(`store: unable to update feed error #%d (%s): %v`, feed.ID, feed.FeedURL, err)
 	}
+	s.invalidateCounters(feed.UserID)
 
 	return nil
 }
@@ -454,6 +508,7 @@ func (s *Storage) RemoveFeed(userID, feedID int64) error {
 	if _, err := s.db.Exec(`DELETE FROM feeds WHERE id=$1 AND user_id=$2`, feedID, userID); err != nil {
 		return fmt.Errorf(`store: unable to delete feed #%d: %v`, feedID, err)
 	}
+	s.invalidateCounters(userID)
 
 	return nil
 }
@@ -463,3 +518,13 @@ func (s *Storage) ResetFeedErrors() error {
 	_, err := s.db.Exec(`UPDATE feeds SET parsing_error_count=0, parsing_error_msg=''`)
 	return err
 }
+
+// ScheduleFeedsRefresh asks the scheduler to refresh the given feeds during its next run.
+func (s *Storage) ScheduleFeedsRefresh(feedIDs []int64) error {
+	_, err := s.db.Exec(`UPDATE feeds SET next_check_at=now() WHERE id=ANY($1)`, pq.Int64Array(feedIDs))
+	if err != nil {
+		return fmt.Errorf(`store: unable to schedule feeds refresh: %v`, err)
+	}
+
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/lib/pq"
+
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/timezone"
 )
@@ -163,11 +165,30 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			c.title as category_title,
 			c.hide_globally as category_hidden,
 			fi.icon_id,
+			fi.dark_icon_id,
 			u.timezone,
 			f.apprise_service_urls,
 			f.disable_http2,
 			f.ntfy_enabled,
-			f.ntfy_priority
+			f.ntfy_priority,
+			f.matrix_bot_enabled,
+			f.polling_min_interval,
+			f.polling_max_interval,
+			f.refresh_interval,
+			f.polling_weekdays,
+			f.polling_window_start,
+			f.polling_window_end,
+			f.auto_archive_after_days,
+			f.auto_archive_status,
+			f.custom_icon,
+			f.websub_lease_expires_at,
+			f.page_monitor_selector,
+			f.newsletter_token,
+			f.relaxed_parsing,
+			f.fetch_via_headless_browser,
+			f.content_extractor,
+			f.rewrite_script,
+			f.auto_translate
 		FROM
 			feeds f
 		LEFT JOIN
@@ -196,7 +217,7 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 	feeds := make(model.Feeds, 0)
 	for rows.Next() {
 		var feed model.Feed
-		var iconID sql.NullInt64
+		var iconID, darkIconID sql.NullInt64
 		var tz string
 		feed.Category = &model.Category{}
 
@@ -233,11 +254,30 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&feed.Category.Title,
 			&feed.Category.HideGlobally,
 			&iconID,
+			&darkIconID,
 			&tz,
 			&feed.AppriseServiceURLs,
 			&feed.DisableHTTP2,
 			&feed.NtfyEnabled,
 			&feed.NtfyPriority,
+			&feed.MatrixBotEnabled,
+			&feed.PollingMinInterval,
+			&feed.PollingMaxInterval,
+			&feed.RefreshInterval,
+			pq.Array(&feed.PollingWeekdays),
+			&feed.PollingWindowStart,
+			&feed.PollingWindowEnd,
+			&feed.AutoArchiveAfterDays,
+			&feed.AutoArchiveStatus,
+			&feed.CustomIcon,
+			&feed.WebSubLeaseExpiresAt,
+			&feed.PageMonitorSelector,
+			&feed.NewsletterToken,
+			&feed.RelaxedParsing,
+			&feed.FetchViaHeadlessBrowser,
+			&feed.ContentExtractor,
+			&feed.RewriteScript,
+			&feed.AutoTranslate,
 		)
 
 		if err != nil {
@@ -245,7 +285,7 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 		}
 
 		if iconID.Valid {
-			feed.Icon = &model.FeedIcon{FeedID: feed.ID, IconID: iconID.Int64}
+			feed.Icon = &model.FeedIcon{FeedID: feed.ID, IconID: iconID.Int64, DarkIconID: darkIconID.Int64}
 		} else {
 			feed.Icon = &model.FeedIcon{FeedID: feed.ID, IconID: 0}
 		}
@@ -5,12 +5,42 @@ package storage // import "miniflux.app/v2/internal/storage"
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"miniflux.app/v2/internal/iconstore"
 	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
 )
 
+// UseIconStore saves the content of the new icons in the given store instead of the database.
+// The icons already stored in the database are moved with MoveIconsToIconStore.
+func (s *Storage) UseIconStore(store iconstore.Store) {
+	s.iconStore = store
+}
+
+// loadIconContent reads the content of the icon from the icon store when it's not in the database.
+func (s *Storage) loadIconContent(icon *model.Icon) error {
+	if len(icon.Content) > 0 {
+		return nil
+	}
+
+	if s.iconStore == nil {
+		return fmt.Errorf(`store: the content of icon #%d is not in the database and no icon store is configured`, icon.ID)
+	}
+
+	content, err := s.iconStore.Get(icon.Hash)
+	if err != nil {
+		return fmt.Errorf(`store: unable to load icon #%d: %w`, icon.ID, err)
+	}
+
+	icon.Content = content
+	return nil
+}
+
 // HasIcon checks if the given feed has an icon.
 func (s *Storage) HasIcon(feedID int64) bool {
 	var result bool
@@ -19,6 +49,14 @@ func (s *Storage) HasIcon(feedID int64) bool {
 	return result
 }
 
+// HasDiscoveredIcon checks if the given feed has an icon that is not a generated placeholder.
+func (s *Storage) HasDiscoveredIcon(feedID int64) bool {
+	var result bool
+	query := `SELECT true FROM feed_icons WHERE feed_id=$1 AND placeholder is false`
+	s.db.QueryRow(query, feedID).Scan(&result)
+	return result
+}
+
 // IconByID returns an icon by the ID.
 func (s *Storage) IconByID(iconID int64) (*model.Icon, error) {
 	var icon model.Icon
@@ -30,6 +68,13 @@ func (s *Storage) IconByID(iconID int64) (*model.Icon, error) {
 		return nil, fmt.Errorf("store: unable to fetch icon #%d: %w", iconID, err)
 	}
 
+	// The icon may have been removed from the icon store by a concurrent cleanup.
+	if err := s.loadIconContent(&icon); errors.Is(err, iconstore.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
 	return &icon, nil
 }
 
@@ -54,6 +99,10 @@ func (s *Storage) IconByFeedID(userID, feedID int64) (*model.Icon, error) {
 		return nil, fmt.Errorf(`store: unable to fetch icon: %v`, err)
 	}
 
+	if err := s.loadIconContent(&icon); err != nil {
+		return nil, err
+	}
+
 	return &icon, nil
 }
 
@@ -70,12 +119,24 @@ func (s *Storage) IconByHash(icon *model.Icon) error {
 }
 
 // CreateIcon creates a new icon.
+// Icons are identified by the hash of their content: the existing icon is returned if the same content is already stored.
+// The content is saved in the icon store when configured, and not in the database.
 func (s *Storage) CreateIcon(icon *model.Icon) error {
+	content := icon.Content
+	if s.iconStore != nil {
+		if err := s.iconStore.Put(icon.Hash, icon.Content); err != nil {
+			return fmt.Errorf(`store: unable to save icon content: %w`, err)
+		}
+		content = []byte{}
+	}
+
 	query := `
 		INSERT INTO icons
 			(hash, mime_type, content)
 		VALUES
 			($1, $2, $3)
+		ON CONFLICT (hash) DO UPDATE SET
+			hash=EXCLUDED.hash
 		RETURNING
 			id
 	`
@@ -83,7 +144,7 @@ func (s *Storage) CreateIcon(icon *model.Icon) error {
 		query,
 		icon.Hash,
 		normalizeMimeType(icon.MimeType),
-		icon.Content,
+		content,
 	).Scan(&icon.ID)
 
 	if err != nil {
@@ -94,27 +155,351 @@ func (s *Storage) CreateIcon(icon *model.Icon) error {
 }
 
 // CreateFeedIcon creates an icon and associate the icon to the given feed.
+// The dark variant of the icon is stored as well when the website declares one.
 func (s *Storage) CreateFeedIcon(feedID int64, icon *model.Icon) error {
-	err := s.IconByHash(icon)
+	if err := s.storeIcon(icon); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO feed_icons
+			(feed_id, icon_id, dark_icon_id)
+		VALUES
+			($1, $2, NULLIF($3, 0))
+		ON CONFLICT (feed_id) DO UPDATE SET
+			icon_id=EXCLUDED.icon_id,
+			dark_icon_id=EXCLUDED.dark_icon_id,
+			placeholder=false
+	`
+	_, err := s.db.Exec(query, feedID, icon.ID, darkIconID(icon))
 	if err != nil {
+		return fmt.Errorf(`store: unable to create feed icon: %v`, err)
+	}
+
+	if err := s.resetIconFailures(feedID); err != nil {
 		return err
 	}
 
-	if icon.ID == 0 {
-		err := s.CreateIcon(icon)
-		if err != nil {
+	return s.TouchFeedIcon(feedID)
+}
+
+// CreatePlaceholderFeedIcon associates a generated icon to the feed unless the feed already has an icon.
+// The placeholder is replaced as soon as an icon is found for the website.
+func (s *Storage) CreatePlaceholderFeedIcon(feedID int64, icon *model.Icon) error {
+	if err := s.storeIcon(icon); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO feed_icons
+			(feed_id, icon_id, placeholder)
+		VALUES
+			($1, $2, true)
+		ON CONFLICT (feed_id) DO NOTHING
+	`
+	if _, err := s.db.Exec(query, feedID, icon.ID); err != nil {
+		return fmt.Errorf(`store: unable to create placeholder icon of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// ReplaceFeedIcon associates the icon to the given feed instead of the previous one.
+// The previous icons are removed if they are not used by another feed.
+func (s *Storage) ReplaceFeedIcon(feedID int64, icon *model.Icon) error {
+	return s.replaceFeedIcon(feedID, icon, false)
+}
+
+// SetCustomFeedIcon replaces the icon of the feed by an icon chosen by the user.
+// Custom icons are not refreshed automatically.
+func (s *Storage) SetCustomFeedIcon(feedID int64, icon *model.Icon) error {
+	return s.replaceFeedIcon(feedID, icon, true)
+}
+
+// ResetCustomFeedIcon allows the automatic discovery of the feed icon again.
+// The icon is kept until a new one is found.
+func (s *Storage) ResetCustomFeedIcon(feedID int64) error {
+	if _, err := s.db.Exec(`UPDATE feeds SET custom_icon=false, icon_checked_at=NULL WHERE id=$1`, feedID); err != nil {
+		return fmt.Errorf(`store: unable to reset custom icon of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) replaceFeedIcon(feedID int64, icon *model.Icon, customIcon bool) error {
+	if err := s.storeIcon(icon); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	rows, err := tx.Query(`DELETE FROM feed_icons WHERE feed_id=$1 RETURNING icon_id, dark_icon_id`, feedID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove previous feed icon: %v`, err)
+	}
+
+	var previousIconIDs []int64
+	for rows.Next() {
+		var iconID int64
+		var previousDarkIconID sql.NullInt64
+		if err := rows.Scan(&iconID, &previousDarkIconID); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to fetch previous feed icon: %v`, err)
+		}
+		previousIconIDs = append(previousIconIDs, iconID)
+		if previousDarkIconID.Valid {
+			previousIconIDs = append(previousIconIDs, previousDarkIconID.Int64)
+		}
+	}
+	rows.Close()
+
+	queries := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO feed_icons (feed_id, icon_id, dark_icon_id) VALUES ($1, $2, NULLIF($3, 0))`, []any{feedID, icon.ID, darkIconID(icon)}},
+		{`UPDATE feeds SET icon_checked_at=now(), custom_icon=$2, icon_failure_count=0, icon_retry_at=NULL WHERE id=$1`, []any{feedID, customIcon}},
+	}
+
+	for _, q := range queries {
+		if _, err := tx.Exec(q.query, q.args...); err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to replace feed icon: %v`, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	_, err = s.removeIcons(
+		`DELETE FROM icons WHERE id=ANY($1) AND NOT EXISTS (SELECT 1 FROM feed_icons WHERE icon_id=icons.id OR dark_icon_id=icons.id) RETURNING hash, length(content)=0`,
+		pq.Array(previousIconIDs),
+	)
+	return err
+}
+
+// storeIcon saves the icon and its dark variant unless they are already stored.
+func (s *Storage) storeIcon(icon *model.Icon) error {
+	for _, i := range []*model.Icon{icon, icon.DarkVariant} {
+		if i == nil {
+			continue
+		}
+
+		if err := s.IconByHash(i); err != nil {
 			return err
 		}
+
+		if i.ID == 0 {
+			if err := s.CreateIcon(i); err != nil {
+				return err
+			}
+		}
 	}
 
-	_, err = s.db.Exec(`INSERT INTO feed_icons (feed_id, icon_id) VALUES ($1, $2)`, feedID, icon.ID)
+	return nil
+}
+
+func darkIconID(icon *model.Icon) int64 {
+	if icon.DarkVariant == nil {
+		return 0
+	}
+	return icon.DarkVariant.ID
+}
+
+// RemoveOrphanIcons removes the icons that are not used by any feed.
+func (s *Storage) RemoveOrphanIcons() (int64, error) {
+	return s.removeIcons(`
+		DELETE FROM icons
+		WHERE NOT EXISTS (SELECT 1 FROM feed_icons WHERE feed_icons.icon_id=icons.id OR feed_icons.dark_icon_id=icons.id)
+		RETURNING hash, length(content)=0
+	`)
+}
+
+// removeIcons runs the query deleting icons, then removes from the icon store the content of the deleted icons
+// that are not referenced by another icon row.
+// The query must return the hash of each icon and whether its content is outside the database.
+func (s *Storage) removeIcons(query string, args ...any) (int64, error) {
+	rows, err := s.db.Query(query, args...)
 	if err != nil {
-		return fmt.Errorf(`store: unable to create feed icon: %v`, err)
+		return 0, fmt.Errorf(`store: unable to remove icons: %v`, err)
+	}
+	defer rows.Close()
+
+	var count int64
+	var externalHashes []string
+	for rows.Next() {
+		var hash string
+		var external bool
+		if err := rows.Scan(&hash, &external); err != nil {
+			return 0, fmt.Errorf(`store: unable to fetch removed icon: %v`, err)
+		}
+		if external {
+			externalHashes = append(externalHashes, hash)
+		}
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf(`store: unable to remove icons: %v`, err)
+	}
+
+	if s.iconStore != nil && len(externalHashes) > 0 {
+		// The same content can be stored again by a concurrent refresh, the blobs still referenced are kept.
+		referencedHashes := make(map[string]bool)
+		rows, err := s.db.Query(`SELECT hash FROM icons WHERE hash=ANY($1)`, pq.Array(externalHashes))
+		if err != nil {
+			return count, fmt.Errorf(`store: unable to fetch referenced icons: %v`, err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var hash string
+			if err := rows.Scan(&hash); err != nil {
+				return count, fmt.Errorf(`store: unable to fetch referenced icons: %v`, err)
+			}
+			referencedHashes[hash] = true
+		}
+
+		if err := rows.Err(); err != nil {
+			return count, fmt.Errorf(`store: unable to fetch referenced icons: %v`, err)
+		}
+
+		for _, hash := range externalHashes {
+			if referencedHashes[hash] {
+				continue
+			}
+
+			if err := s.iconStore.Delete(hash); err != nil {
+				return count, fmt.Errorf(`store: unable to remove icon content: %w`, err)
+			}
+		}
+	}
+
+	return count, nil
+}
+
+// MoveIconsToIconStore moves the content of at most limit icons from the database to the icon store.
+func (s *Storage) MoveIconsToIconStore(limit int) (int, error) {
+	if s.iconStore == nil {
+		return 0, nil
+	}
+
+	rows, err := s.db.Query(`SELECT id, hash, content FROM icons WHERE length(content) > 0 LIMIT $1`, limit)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to fetch icons to move: %v`, err)
+	}
+
+	var icons model.Icons
+	for rows.Next() {
+		var icon model.Icon
+		if err := rows.Scan(&icon.ID, &icon.Hash, &icon.Content); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf(`store: unable to fetch icon to move: %v`, err)
+		}
+		icons = append(icons, &icon)
+	}
+	rows.Close()
+
+	for i, icon := range icons {
+		if err := s.iconStore.Put(icon.Hash, icon.Content); err != nil {
+			return i, fmt.Errorf(`store: unable to save icon content: %w`, err)
+		}
+
+		if _, err := s.db.Exec(`UPDATE icons SET content='' WHERE id=$1`, icon.ID); err != nil {
+			return i, fmt.Errorf(`store: unable to remove icon content from the database: %v`, err)
+		}
+	}
+
+	return len(icons), nil
+}
+
+// IsIconLookupDeferred returns true if the icon of the feed was not found recently and the lookup must not be retried yet.
+func (s *Storage) IsIconLookupDeferred(feedID int64) bool {
+	var result bool
+	query := `SELECT true FROM feeds WHERE id=$1 AND icon_retry_at > now()`
+	s.db.QueryRow(query, feedID).Scan(&result)
+	return result
+}
+
+// RecordIconFailure defers the next icon lookup of the feed.
+// The cooldown is doubled after each consecutive failure, up to the maximum delay.
+func (s *Storage) RecordIconFailure(feedID int64, cooldown, maxDelay time.Duration) error {
+	query := `
+		UPDATE
+			feeds
+		SET
+			icon_failure_count=icon_failure_count + 1,
+			icon_retry_at=now() + make_interval(secs => least($2 * power(2, least(icon_failure_count, 30)), $3))
+		WHERE
+			id=$1
+	`
+	if _, err := s.db.Exec(query, feedID, cooldown.Seconds(), maxDelay.Seconds()); err != nil {
+		return fmt.Errorf(`store: unable to record icon failure of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) resetIconFailures(feedID int64) error {
+	if _, err := s.db.Exec(`UPDATE feeds SET icon_failure_count=0, icon_retry_at=NULL WHERE id=$1`, feedID); err != nil {
+		return fmt.Errorf(`store: unable to reset icon failures of feed #%d: %v`, feedID, err)
 	}
 
 	return nil
 }
 
+// TouchFeedIcon records that the icon of the feed has been checked.
+func (s *Storage) TouchFeedIcon(feedID int64) error {
+	if _, err := s.db.Exec(`UPDATE feeds SET icon_checked_at=now() WHERE id=$1`, feedID); err != nil {
+		return fmt.Errorf(`store: unable to update icon check date of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// FeedsWithStaleIcon returns the enabled feeds whose icon has not been checked since the given number of days.
+// Feeds that were never checked come first, feeds having a custom icon are ignored.
+func (s *Storage) FeedsWithStaleIcon(days, limit int) (model.JobList, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			feed_url
+		FROM
+			feeds
+		WHERE
+			disabled is false AND
+			custom_icon is false AND
+			(icon_checked_at IS NULL OR icon_checked_at < now() - $1::interval)
+		ORDER BY
+			icon_checked_at ASC NULLS FIRST
+		LIMIT $2
+	`
+
+	rows, err := s.db.Query(query, fmt.Sprintf("%d days", days), limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch feeds with stale icon: %v`, err)
+	}
+	defer rows.Close()
+
+	var jobs model.JobList
+	for rows.Next() {
+		var job model.Job
+		if err := rows.Scan(&job.FeedID, &job.UserID, &job.FeedURL); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed with stale icon: %v`, err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
 // Icons returns all icons that belongs to a user.
 func (s *Storage) Icons(userID int64) (model.Icons, error) {
 	query := `
@@ -142,6 +527,9 @@ func (s *Storage) Icons(userID int64) (model.Icons, error) {
 		if err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch icons row: %v`, err)
 		}
+		if err := s.loadIconContent(&icon); err != nil {
+			return nil, err
+		}
 		icons = append(icons, &icon)
 	}
 
@@ -151,7 +539,7 @@ func (s *Storage) Icons(userID int64) (model.Icons, error) {
 func normalizeMimeType(mimeType string) string {
 	mimeType = strings.ToLower(mimeType)
 	switch mimeType {
-	case "image/png", "image/jpeg", "image/jpg", "image/webp", "image/svg+xml", "image/x-icon", "image/gif":
+	case "image/png", "image/jpeg", "image/jpg", "image/webp", "image/svg+xml", "image/x-icon", "image/gif", "image/bmp", "image/avif":
 		return mimeType
 	default:
 		return "image/x-icon"
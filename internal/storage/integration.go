@@ -6,6 +6,7 @@ package storage // import "miniflux.app/v2/internal/storage"
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"miniflux.app/v2/internal/model"
@@ -37,7 +38,10 @@ func (s *Storage) UserByFeverToken(token string) (*model.User, error) {
 		LEFT JOIN
 			integrations ON integrations.user_id=users.id
 		WHERE
-			integrations.fever_enabled='t' AND lower(integrations.fever_token)=lower($1)
+			integrations.fever_enabled='t' AND users.is_disabled='f' AND (
+				lower(integrations.fever_token)=lower($1) OR
+				integrations.user_id IN (SELECT user_id FROM app_passwords WHERE scope='fever' AND credential=lower($1))
+			)
 	`
 
 	var user model.User
@@ -62,7 +66,8 @@ func (s *Storage) GoogleReaderUserCheckPassword(username, password string) error
 		FROM
 			integrations
 		WHERE
-			integrations.googlereader_enabled='t' AND integrations.googlereader_username=$1
+			integrations.googlereader_enabled='t' AND integrations.googlereader_username=$1 AND
+			integrations.user_id IN (SELECT id FROM users WHERE is_disabled='f')
 	`
 
 	err := s.db.QueryRow(query, username).Scan(&hash)
@@ -92,7 +97,8 @@ func (s *Storage) GoogleReaderUserGetIntegration(username string) (*model.Integr
 		FROM
 			integrations
 		WHERE
-			integrations.googlereader_enabled='t' AND integrations.googlereader_username=$1
+			integrations.googlereader_enabled='t' AND integrations.googlereader_username=$1 AND
+			integrations.user_id IN (SELECT id FROM users WHERE is_disabled='f')
 	`
 
 	err := s.db.QueryRow(query, username).Scan(&integration.UserID, &integration.GoogleReaderEnabled, &integration.GoogleReaderUsername, &integration.GoogleReaderPassword)
@@ -207,7 +213,44 @@ func (s *Storage) Integration(userID int64) (*model.Integration, error) {
 			ntfy_api_token,
 			ntfy_username,
 			ntfy_password,
-			ntfy_icon_url
+			ntfy_icon_url,
+			telegram_bot_category_chat_ids,
+			telegram_bot_digest_mode,
+			telegram_bot_digest_size,
+			telegram_bot_quiet_hours_start,
+			telegram_bot_quiet_hours_end,
+			matrix_bot_access_token,
+			email_digest_enabled,
+			email_digest_address,
+			email_digest_frequency,
+			email_digest_hour,
+			email_digest_weekday,
+			email_digest_content,
+			kindle_enabled,
+			kindle_email,
+			mastodon_enabled,
+			mastodon_url,
+			mastodon_access_token,
+			mastodon_visibility,
+			bluesky_enabled,
+			bluesky_pds_url,
+			bluesky_handle,
+			bluesky_app_password,
+			pinboard_entry_tags,
+			pinboard_category_tag,
+			zotero_enabled,
+			zotero_user_id,
+			zotero_api_key,
+			zotero_collection_key,
+			hypothesis_enabled,
+			hypothesis_api_token,
+			hypothesis_group,
+			hypothesis_show_annotations,
+			homeassistant_enabled,
+			homeassistant_webhook_url,
+			homeassistant_rules,
+			webhook_payload_template,
+			homeassistant_payload_template
 		FROM
 			integrations
 		WHERE
@@ -314,6 +357,43 @@ func (s *Storage) Integration(userID int64) (*model.Integration, error) {
 		&integration.NtfyUsername,
 		&integration.NtfyPassword,
 		&integration.NtfyIconURL,
+		&integration.TelegramBotCategoryChatIDs,
+		&integration.TelegramBotDigestMode,
+		&integration.TelegramBotDigestSize,
+		&integration.TelegramBotQuietHoursStart,
+		&integration.TelegramBotQuietHoursEnd,
+		&integration.MatrixBotAccessToken,
+		&integration.EmailDigestEnabled,
+		&integration.EmailDigestAddress,
+		&integration.EmailDigestFrequency,
+		&integration.EmailDigestHour,
+		&integration.EmailDigestWeekday,
+		&integration.EmailDigestContent,
+		&integration.KindleEnabled,
+		&integration.KindleEmail,
+		&integration.MastodonEnabled,
+		&integration.MastodonURL,
+		&integration.MastodonAccessToken,
+		&integration.MastodonVisibility,
+		&integration.BlueskyEnabled,
+		&integration.BlueskyPDSURL,
+		&integration.BlueskyHandle,
+		&integration.BlueskyAppPassword,
+		&integration.PinboardEntryTags,
+		&integration.PinboardCategoryTag,
+		&integration.ZoteroEnabled,
+		&integration.ZoteroUserID,
+		&integration.ZoteroAPIKey,
+		&integration.ZoteroCollectionKey,
+		&integration.HypothesisEnabled,
+		&integration.HypothesisAPIToken,
+		&integration.HypothesisGroup,
+		&integration.HypothesisShowAnnotations,
+		&integration.HomeAssistantEnabled,
+		&integration.HomeAssistantWebhookURL,
+		&integration.HomeAssistantRules,
+		&integration.WebhookPayloadTemplate,
+		&integration.HomeAssistantPayloadTemplate,
 	)
 	switch {
 	case err == sql.ErrNoRows:
@@ -428,9 +508,46 @@ func (s *Storage) UpdateIntegration(integration *model.Integration) error {
 			ntfy_api_token=$95,
 			ntfy_username=$96,
 			ntfy_password=$97,
-			ntfy_icon_url=$98
+			ntfy_icon_url=$98,
+			telegram_bot_category_chat_ids=$99,
+			telegram_bot_digest_mode=$100,
+			telegram_bot_digest_size=$101,
+			telegram_bot_quiet_hours_start=$102,
+			telegram_bot_quiet_hours_end=$103,
+			matrix_bot_access_token=$104,
+			email_digest_enabled=$105,
+			email_digest_address=$106,
+			email_digest_frequency=$107,
+			email_digest_hour=$108,
+			email_digest_weekday=$109,
+			email_digest_content=$110,
+			kindle_enabled=$111,
+			kindle_email=$112,
+			mastodon_enabled=$113,
+			mastodon_url=$114,
+			mastodon_access_token=$115,
+			mastodon_visibility=$116,
+			bluesky_enabled=$117,
+			bluesky_pds_url=$118,
+			bluesky_handle=$119,
+			bluesky_app_password=$120,
+			pinboard_entry_tags=$121,
+			pinboard_category_tag=$122,
+			zotero_enabled=$123,
+			zotero_user_id=$127,
+			zotero_api_key=$125,
+			zotero_collection_key=$126,
+			hypothesis_enabled=$123,
+			hypothesis_api_token=$124,
+			hypothesis_group=$125,
+			hypothesis_show_annotations=$126,
+			homeassistant_enabled=$127,
+			homeassistant_webhook_url=$128,
+			homeassistant_rules=$129,
+			webhook_payload_template=$130,
+			homeassistant_payload_template=$131
 		WHERE
-			user_id=$99
+			user_id=$132
 	`
 	_, err := s.db.Exec(
 		query,
@@ -532,6 +649,43 @@ func (s *Storage) UpdateIntegration(integration *model.Integration) error {
 		integration.NtfyUsername,
 		integration.NtfyPassword,
 		integration.NtfyIconURL,
+		integration.TelegramBotCategoryChatIDs,
+		integration.TelegramBotDigestMode,
+		integration.TelegramBotDigestSize,
+		integration.TelegramBotQuietHoursStart,
+		integration.TelegramBotQuietHoursEnd,
+		integration.MatrixBotAccessToken,
+		integration.EmailDigestEnabled,
+		integration.EmailDigestAddress,
+		integration.EmailDigestFrequency,
+		integration.EmailDigestHour,
+		integration.EmailDigestWeekday,
+		integration.EmailDigestContent,
+		integration.KindleEnabled,
+		integration.KindleEmail,
+		integration.MastodonEnabled,
+		integration.MastodonURL,
+		integration.MastodonAccessToken,
+		integration.MastodonVisibility,
+		integration.BlueskyEnabled,
+		integration.BlueskyPDSURL,
+		integration.BlueskyHandle,
+		integration.BlueskyAppPassword,
+		integration.PinboardEntryTags,
+		integration.PinboardCategoryTag,
+		integration.ZoteroEnabled,
+		integration.ZoteroUserID,
+		integration.ZoteroAPIKey,
+		integration.ZoteroCollectionKey,
+		integration.HypothesisEnabled,
+		integration.HypothesisAPIToken,
+		integration.HypothesisGroup,
+		integration.HypothesisShowAnnotations,
+		integration.HomeAssistantEnabled,
+		integration.HomeAssistantWebhookURL,
+		integration.HomeAssistantRules,
+		integration.WebhookPayloadTemplate,
+		integration.HomeAssistantPayloadTemplate,
 		integration.UserID,
 	)
 
@@ -542,6 +696,128 @@ func (s *Storage) UpdateIntegration(integration *model.Integration) error {
 	return nil
 }
 
+// TelegramBotHourlyDigestUserIDs returns the users who receive an hourly Telegram digest.
+func (s *Storage) TelegramBotHourlyDigestUserIDs() ([]int64, error) {
+	query := `
+		SELECT
+			user_id
+		FROM
+			integrations
+		WHERE
+			telegram_bot_enabled='t' AND telegram_bot_digest_mode=$1
+	`
+	rows, err := s.db.Query(query, model.TelegramBotDigestModeHourly)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch Telegram digest users: %v`, err)
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch Telegram digest user row: %v`, err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// TelegramBotDigestSentAt returns the last time an hourly Telegram digest was sent to the given user.
+func (s *Storage) TelegramBotDigestSentAt(userID int64) (time.Time, error) {
+	var sentAt sql.NullTime
+	query := `SELECT telegram_bot_digest_sent_at FROM integrations WHERE user_id=$1`
+	if err := s.db.QueryRow(query, userID).Scan(&sentAt); err != nil {
+		return time.Time{}, fmt.Errorf(`store: unable to fetch Telegram digest date: %v`, err)
+	}
+
+	return sentAt.Time, nil
+}
+
+// SetTelegramBotDigestSentAt records the last time an hourly Telegram digest was sent to the given user.
+func (s *Storage) SetTelegramBotDigestSentAt(userID int64, sentAt time.Time) error {
+	query := `UPDATE integrations SET telegram_bot_digest_sent_at=$1 WHERE user_id=$2`
+	if _, err := s.db.Exec(query, sentAt, userID); err != nil {
+		return fmt.Errorf(`store: unable to update Telegram digest date: %v`, err)
+	}
+
+	return nil
+}
+
+// EmailDigestUserIDs returns the list of users who subscribed to email digests.
+func (s *Storage) EmailDigestUserIDs() ([]int64, error) {
+	query := `
+		SELECT
+			user_id
+		FROM
+			integrations
+		WHERE
+			email_digest_enabled='t' AND email_digest_address <> ''
+	`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch email digest users: %v`, err)
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch email digest user row: %v`, err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// EmailDigestSentAt returns the last time an email digest was sent to the given user.
+func (s *Storage) EmailDigestSentAt(userID int64) (time.Time, error) {
+	var sentAt sql.NullTime
+	query := `SELECT email_digest_sent_at FROM integrations WHERE user_id=$1`
+	if err := s.db.QueryRow(query, userID).Scan(&sentAt); err != nil {
+		return time.Time{}, fmt.Errorf(`store: unable to fetch email digest date: %v`, err)
+	}
+
+	return sentAt.Time, nil
+}
+
+// SetEmailDigestSentAt records the last time an email digest was sent to the given user.
+func (s *Storage) SetEmailDigestSentAt(userID int64, sentAt time.Time) error {
+	query := `UPDATE integrations SET email_digest_sent_at=$1 WHERE user_id=$2`
+	if _, err := s.db.Exec(query, sentAt, userID); err != nil {
+		return fmt.Errorf(`store: unable to update email digest date: %v`, err)
+	}
+
+	return nil
+}
+
+// HasMastodon returns true if the given user can share entries to Mastodon.
+func (s *Storage) HasMastodon(userID int64) bool {
+	query := `SELECT true FROM integrations WHERE user_id=$1 AND mastodon_enabled='t'`
+	var result bool
+	s.db.QueryRow(query, userID).Scan(&result)
+	return result
+}
+
+// HasBluesky returns true if the given user can share entries to Bluesky.
+func (s *Storage) HasBluesky(userID int64) bool {
+	query := `SELECT true FROM integrations WHERE user_id=$1 AND bluesky_enabled='t'`
+	var result bool
+	s.db.QueryRow(query, userID).Scan(&result)
+	return result
+}
+
+// HasHypothesis returns true if the given user can annotate entries with Hypothesis.
+func (s *Storage) HasHypothesis(userID int64) bool {
+	query := `SELECT true FROM integrations WHERE user_id=$1 AND hypothesis_enabled='t'`
+	var result bool
+	s.db.QueryRow(query, userID).Scan(&result)
+	return result
+}
+
 // HasSaveEntry returns true if the given user can save articles to third-parties.
 func (s *Storage) HasSaveEntry(userID int64) (result bool) {
 	query := `
@@ -571,7 +847,9 @@ func (s *Storage) HasSaveEntry(userID int64) (result bool) {
 				webhook_enabled='t' OR
 				omnivore_enabled='t' OR
 				raindrop_enabled='t' OR
-				betula_enabled='t'
+				betula_enabled='t' OR
+				kindle_enabled='t' OR
+				zotero_enabled='t'
 			)
 	`
 	if err := s.db.QueryRow(query, userID).Scan(&result); err != nil {
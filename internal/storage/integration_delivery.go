@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
+)
+
+const integrationDeliveryColumns = `
+	id,
+	user_id,
+	destination,
+	event_type,
+	feed_id,
+	entry_ids,
+	status,
+	attempts,
+	last_error,
+	next_attempt_at,
+	created_at
+`
+
+// CreateIntegrationDelivery queues a delivery to be retried.
+func (s *Storage) CreateIntegrationDelivery(delivery *model.IntegrationDelivery) error {
+	query := `
+		INSERT INTO integration_deliveries
+			(user_id, destination, event_type, feed_id, entry_ids, status, attempts, last_error, next_attempt_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		delivery.UserID,
+		delivery.Destination,
+		delivery.EventType,
+		sql.NullInt64{Int64: delivery.FeedID, Valid: delivery.FeedID > 0},
+		pq.Array(delivery.EntryIDs),
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+	).Scan(&delivery.ID, &delivery.CreatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create integration delivery: %v`, err)
+	}
+
+	return nil
+}
+
+// UpdateIntegrationDelivery records the outcome of a delivery attempt.
+func (s *Storage) UpdateIntegrationDelivery(delivery *model.IntegrationDelivery) error {
+	query := `
+		UPDATE integration_deliveries
+		SET status=$1, attempts=$2, last_error=$3, next_attempt_at=$4
+		WHERE id=$5
+	`
+	if _, err := s.db.Exec(query, delivery.Status, delivery.Attempts, delivery.LastError, delivery.NextAttemptAt, delivery.ID); err != nil {
+		return fmt.Errorf(`store: unable to update integration delivery #%d: %v`, delivery.ID, err)
+	}
+
+	return nil
+}
+
+// RetryIntegrationDelivery schedules a failed delivery to be attempted again as soon as possible.
+func (s *Storage) RetryIntegrationDelivery(userID, deliveryID int64) error {
+	query := `
+		UPDATE integration_deliveries
+		SET status=$1, attempts=0, next_attempt_at=now()
+		WHERE id=$2 AND user_id=$3
+	`
+	if _, err := s.db.Exec(query, model.IntegrationDeliveryStatusPending, deliveryID, userID); err != nil {
+		return fmt.Errorf(`store: unable to retry integration delivery #%d: %v`, deliveryID, err)
+	}
+
+	return nil
+}
+
+// RemoveIntegrationDelivery deletes a delivery.
+func (s *Storage) RemoveIntegrationDelivery(userID, deliveryID int64) error {
+	query := `DELETE FROM integration_deliveries WHERE id=$1 AND user_id=$2`
+	if _, err := s.db.Exec(query, deliveryID, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove integration delivery #%d: %v`, deliveryID, err)
+	}
+
+	return nil
+}
+
+// DueIntegrationDeliveries returns the pending deliveries that must be attempted now.
+func (s *Storage) DueIntegrationDeliveries(limit int) (model.IntegrationDeliveries, error) {
+	query := `
+		SELECT ` + integrationDeliveryColumns + `
+		FROM
+			integration_deliveries
+		WHERE
+			status=$1 AND next_attempt_at <= now()
+		ORDER BY next_attempt_at ASC
+		LIMIT $2
+	`
+	return s.fetchIntegrationDeliveries(query, model.IntegrationDeliveryStatusPending, limit)
+}
+
+// IntegrationDeliveries returns the queued and failed deliveries of the given user.
+func (s *Storage) IntegrationDeliveries(userID int64) (model.IntegrationDeliveries, error) {
+	query := `
+		SELECT ` + integrationDeliveryColumns + `
+		FROM
+			integration_deliveries
+		WHERE
+			user_id=$1
+		ORDER BY created_at DESC
+	`
+	return s.fetchIntegrationDeliveries(query, userID)
+}
+
+// CountFailedIntegrationDeliveries returns the number of deliveries the user must review.
+func (s *Storage) CountFailedIntegrationDeliveries(userID int64) int {
+	query := `SELECT count(*) FROM integration_deliveries WHERE user_id=$1 AND status=$2`
+	var result int
+	s.db.QueryRow(query, userID, model.IntegrationDeliveryStatusFailed).Scan(&result)
+	return result
+}
+
+func (s *Storage) fetchIntegrationDeliveries(query string, args ...any) (model.IntegrationDeliveries, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch integration deliveries: %v`, err)
+	}
+	defer rows.Close()
+
+	deliveries := make(model.IntegrationDeliveries, 0)
+	for rows.Next() {
+		var delivery model.IntegrationDelivery
+		var feedID sql.NullInt64
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.UserID,
+			&delivery.Destination,
+			&delivery.EventType,
+			&feedID,
+			pq.Array(&delivery.EntryIDs),
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.LastError,
+			&delivery.NextAttemptAt,
+			&delivery.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch integration delivery row: %v`, err)
+		}
+
+		delivery.FeedID = feedID.Int64
+		deliveries = append(deliveries, &delivery)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// IntegrationRoutingRuleExists checks if the same rule is already defined.
+func (s *Storage) IntegrationRoutingRuleExists(rule *model.IntegrationRoutingRule) bool {
+	query := `
+		SELECT
+			true
+		FROM
+			integration_routing_rules
+		WHERE
+			user_id=$1 AND destination=$2 AND coalesce(category_id, 0)=$3 AND coalesce(feed_id, 0)=$4
+		LIMIT 1
+	`
+	var result bool
+	s.db.QueryRow(query, rule.UserID, rule.Destination, rule.CategoryID, rule.FeedID).Scan(&result)
+	return result
+}
+
+// CreateIntegrationRoutingRule adds a routing rule.
+func (s *Storage) CreateIntegrationRoutingRule(rule *model.IntegrationRoutingRule) error {
+	query := `
+		INSERT INTO integration_routing_rules
+			(user_id, destination, category_id, feed_id)
+		VALUES
+			($1, $2, $3, $4)
+		RETURNING
+			id
+	`
+	err := s.db.QueryRow(
+		query,
+		rule.UserID,
+		rule.Destination,
+		sql.NullInt64{Int64: rule.CategoryID, Valid: rule.CategoryID > 0},
+		sql.NullInt64{Int64: rule.FeedID, Valid: rule.FeedID > 0},
+	).Scan(&rule.ID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create integration routing rule: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveIntegrationRoutingRule deletes a routing rule.
+func (s *Storage) RemoveIntegrationRoutingRule(userID, ruleID int64) error {
+	query := `DELETE FROM integration_routing_rules WHERE id=$1 AND user_id=$2`
+	if _, err := s.db.Exec(query, ruleID, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove integration routing rule #%d: %v`, ruleID, err)
+	}
+
+	return nil
+}
+
+// IntegrationRoutingRules returns the routing rules of the given user.
+func (s *Storage) IntegrationRoutingRules(userID int64) (model.IntegrationRoutingRules, error) {
+	query := `
+		SELECT
+			r.id,
+			r.user_id,
+			r.destination,
+			coalesce(r.category_id, 0),
+			coalesce(c.title, ''),
+			coalesce(r.feed_id, 0),
+			coalesce(f.title, '')
+		FROM
+			integration_routing_rules r
+		LEFT JOIN
+			categories c ON c.id=r.category_id
+		LEFT JOIN
+			feeds f ON f.id=r.feed_id
+		WHERE
+			r.user_id=$1
+		ORDER BY r.destination ASC, c.title ASC, f.title ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch integration routing rules: %v`, err)
+	}
+	defer rows.Close()
+
+	rules := make(model.IntegrationRoutingRules, 0)
+	for rows.Next() {
+		var rule model.IntegrationRoutingRule
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.UserID,
+			&rule.Destination,
+			&rule.CategoryID,
+			&rule.CategoryTitle,
+			&rule.FeedID,
+			&rule.FeedTitle,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch integration routing rule row: %v`, err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Advisory lock keys used to coordinate several instances sharing the same database.
+const (
+	// SchedulerLeaderLockKey is held by the instance running the cleanup, digest and delivery tasks.
+	SchedulerLeaderLockKey int64 = 0x6d696e69666c7578
+)
+
+// AdvisoryLock is a PostgreSQL session-level advisory lock.
+// The lock is bound to a dedicated database connection and is released when the connection is closed,
+// including when the process dies.
+type AdvisoryLock struct {
+	key  int64
+	conn *sql.Conn
+}
+
+// TryAdvisoryLock acquires the lock without waiting, nil is returned if another session holds it.
+func (s *Storage) TryAdvisoryLock(key int64) (*AdvisoryLock, error) {
+	ctx := context.Background()
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to get a database connection: %v`, err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf(`store: unable to acquire advisory lock: %v`, err)
+	}
+
+	if !acquired {
+		conn.Close()
+		return nil, nil
+	}
+
+	return &AdvisoryLock{key: key, conn: conn}, nil
+}
+
+// Ping returns an error when the connection holding the lock is lost, the lock is not held anymore in that case.
+func (l *AdvisoryLock) Ping() error {
+	if err := l.conn.PingContext(context.Background()); err != nil {
+		return fmt.Errorf(`store: advisory lock connection lost: %v`, err)
+	}
+	return nil
+}
+
+// Release unlocks and closes the dedicated connection.
+func (l *AdvisoryLock) Release() error {
+	defer l.conn.Close()
+
+	if _, err := l.conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, l.key); err != nil {
+		return fmt.Errorf(`store: unable to release advisory lock: %v`, err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+	"time"
+)
+
+// CreateLoginFailure records a failed login attempt.
+func (s *Storage) CreateLoginFailure(username, clientIP string) error {
+	query := `INSERT INTO login_failures (username, client_ip) VALUES (LOWER($1), $2)`
+	if _, err := s.db.Exec(query, username, clientIP); err != nil {
+		return fmt.Errorf(`store: unable to create login failure: %v`, err)
+	}
+
+	return nil
+}
+
+// IsLoginLocked returns true when the username has at least maxAttempts failed logins within the window,
+// or the client IP at least maxAttemptsPerIP. A limit lower or equal to zero is disabled.
+func (s *Storage) IsLoginLocked(username, clientIP string, maxAttempts, maxAttemptsPerIP int, window time.Duration) (bool, error) {
+	if maxAttempts <= 0 && maxAttemptsPerIP <= 0 {
+		return false, nil
+	}
+
+	query := `
+		SELECT
+			count(*) FILTER (WHERE username=LOWER($1)),
+			count(*) FILTER (WHERE client_ip=$2)
+		FROM
+			login_failures
+		WHERE
+			created_at > $3 AND (username=LOWER($1) OR client_ip=$2)
+	`
+	var byUsername, byClientIP int
+	if err := s.db.QueryRow(query, username, clientIP, time.Now().Add(-window)).Scan(&byUsername, &byClientIP); err != nil {
+		return false, fmt.Errorf(`store: unable to count login failures: %v`, err)
+	}
+
+	return (maxAttempts > 0 && byUsername >= maxAttempts) || (maxAttemptsPerIP > 0 && byClientIP >= maxAttemptsPerIP), nil
+}
+
+// LockedUsernames returns the usernames having at least maxAttempts failed logins since the given date,
+// along with their number of failed logins. The failures without username, like the ones of the Fever API, are ignored.
+func (s *Storage) LockedUsernames(since time.Time, maxAttempts int) (map[string]int, error) {
+	query := `
+		SELECT
+			username,
+			count(*)
+		FROM
+			login_failures
+		WHERE
+			created_at > $1 AND username <> ''
+		GROUP BY
+			username
+		HAVING
+			count(*) >= $2
+	`
+	rows, err := s.db.Query(query, since, maxAttempts)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch locked usernames: %v`, err)
+	}
+	defer rows.Close()
+
+	usernames := make(map[string]int)
+	for rows.Next() {
+		var username string
+		var failures int
+		if err := rows.Scan(&username, &failures); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch locked username row: %v`, err)
+		}
+
+		usernames[username] = failures
+	}
+
+	return usernames, nil
+}
+
+// RemoveLoginFailures removes all failed logins recorded for the username.
+func (s *Storage) RemoveLoginFailures(username string) error {
+	query := `DELETE FROM login_failures WHERE username=LOWER($1)`
+	if _, err := s.db.Exec(query, username); err != nil {
+		return fmt.Errorf(`store: unable to remove login failures: %v`, err)
+	}
+
+	return nil
+}
+
+// CleanOldLoginFailures removes failed logins older than the given date.
+func (s *Storage) CleanOldLoginFailures(before time.Time) int64 {
+	result, err := s.db.Exec(`DELETE FROM login_failures WHERE created_at < $1`, before)
+	if err != nil {
+		return 0
+	}
+
+	n, _ := result.RowsAffected()
+	return n
+}
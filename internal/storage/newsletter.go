@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// NewsletterFeed returns the user and the feed receiving the newsletters sent to the given token.
+// The identifiers are zero when no feed uses the token.
+func (s *Storage) NewsletterFeed(token string) (userID, feedID int64, err error) {
+	query := `SELECT user_id, id FROM feeds WHERE newsletter_token=$1 AND newsletter_token <> ''`
+	err = s.db.QueryRow(query, token).Scan(&userID, &feedID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, 0, nil
+	case err != nil:
+		return 0, 0, fmt.Errorf(`store: unable to fetch newsletter feed: %v`, err)
+	}
+
+	return userID, feedID, nil
+}
+
+// UpdateNewsletterFeedURLs replaces the domain of the addresses stored in the URL of the newsletter feeds.
+func (s *Storage) UpdateNewsletterFeedURLs(domain string) (int64, error) {
+	query := `
+		UPDATE feeds
+		SET feed_url = 'mailto:' || newsletter_token || '@' || $1
+		WHERE newsletter_token <> '' AND feed_url LIKE 'mailto:%' AND feed_url <> 'mailto:' || newsletter_token || '@' || $1
+	`
+	result, err := s.db.Exec(query, domain)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to update newsletter feed URLs: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	return count, nil
+}
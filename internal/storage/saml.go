@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+)
+
+// UserBySAMLID returns the user linked to the SAML NameID.
+func (s *Storage) UserBySAMLID(samlID string) (*model.User, error) {
+	return s.UserByField("saml_id", samlID)
+}
+
+// HasSAMLID returns true if a SAML identity is linked to the user.
+func (s *Storage) HasSAMLID(userID int64) bool {
+	var result bool
+	query := `SELECT saml_id <> '' FROM users WHERE id=$1`
+	s.db.QueryRow(query, userID).Scan(&result)
+	return result
+}
+
+// SetSAMLID links the SAML NameID to the user, an empty ID removes the link.
+func (s *Storage) SetSAMLID(userID int64, samlID string) error {
+	query := `UPDATE users SET saml_id=$2 WHERE id=$1`
+	if _, err := s.db.Exec(query, userID, samlID); err != nil {
+		return fmt.Errorf(`store: unable to update SAML ID: %v`, err)
+	}
+
+	return nil
+}
+
+// SetUserAdmin grants or revokes administrator privileges.
+func (s *Storage) SetUserAdmin(userID int64, isAdmin bool) error {
+	defer s.invalidateUser(userID)
+
+	query := `UPDATE users SET is_admin=$2 WHERE id=$1`
+	if _, err := s.db.Exec(query, userID, isAdmin); err != nil {
+		return fmt.Errorf(`store: unable to update user privileges: %v`, err)
+	}
+
+	return nil
+}
+
+// CreateSAMLLinkRequest records an identity link started by the user session, the token is stored hashed.
+func (s *Storage) CreateSAMLLinkRequest(requestID string, userID int64, userSessionToken string) error {
+	query := `INSERT INTO saml_link_requests (request_id, user_id, user_session_hash) VALUES ($1, $2, $3)`
+	if _, err := s.db.Exec(query, requestID, userID, crypto.Hash(userSessionToken)); err != nil {
+		return fmt.Errorf(`store: unable to create SAML link request: %v`, err)
+	}
+
+	return nil
+}
+
+// SetSAMLLinkRequestNameID stores the identity returned by the identity provider for a pending link request.
+// It returns false when the request does not exist, has expired or has already been answered.
+func (s *Storage) SetSAMLLinkRequestNameID(requestID, nameID string, maxAge time.Duration) (bool, error) {
+	query := `
+		UPDATE saml_link_requests
+		SET name_id=$2
+		WHERE request_id=$1 AND name_id='' AND created_at > $3
+	`
+	result, err := s.db.Exec(query, requestID, nameID, time.Now().Add(-maxAge))
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update SAML link request: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update SAML link request: %v`, err)
+	}
+
+	return count > 0, nil
+}
+
+// RemoveSAMLLinkRequest deletes an answered link request of the user session and returns its identity.
+// An empty identity is returned when there is no such request.
+func (s *Storage) RemoveSAMLLinkRequest(requestID string, userID int64, userSessionToken string, maxAge time.Duration) (string, error) {
+	query := `
+		DELETE FROM saml_link_requests
+		WHERE request_id=$1 AND user_id=$2 AND user_session_hash=$3 AND name_id <> '' AND created_at > $4
+		RETURNING name_id
+	`
+	var nameID string
+	err := s.db.QueryRow(query, requestID, userID, crypto.Hash(userSessionToken), time.Now().Add(-maxAge)).Scan(&nameID)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf(`store: unable to remove SAML link request: %v`, err)
+	}
+
+	return nameID, nil
+}
+
+// CleanOldSAMLLinkRequests removes the link requests older than maxAge.
+func (s *Storage) CleanOldSAMLLinkRequests(maxAge time.Duration) int64 {
+	result, err := s.db.Exec(`DELETE FROM saml_link_requests WHERE created_at < $1`, time.Now().Add(-maxAge))
+	if err != nil {
+		return 0
+	}
+
+	n, _ := result.RowsAffected()
+	return n
+}
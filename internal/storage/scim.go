@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// SetSCIMProvisioned marks the user as managed by a SCIM identity provider.
+func (s *Storage) SetSCIMProvisioned(userID int64) error {
+	if _, err := s.db.Exec(`UPDATE users SET scim_provisioned='t' WHERE id=$1`, userID); err != nil {
+		return fmt.Errorf(`store: unable to update user #%d: %v`, userID, err)
+	}
+
+	return nil
+}
+
+// SetSCIMExternalID stores the identifier of the user in the identity provider.
+func (s *Storage) SetSCIMExternalID(userID int64, externalID string) error {
+	if _, err := s.db.Exec(`UPDATE users SET scim_external_id=$2 WHERE id=$1`, userID, externalID); err != nil {
+		return fmt.Errorf(`store: unable to update user #%d: %v`, userID, err)
+	}
+
+	return nil
+}
+
+// SCIMProvisionedUserByExternalID returns the user provisioned by SCIM with the identifier of the identity provider.
+func (s *Storage) SCIMProvisionedUserByExternalID(externalID string) (*model.User, error) {
+	if externalID == "" {
+		return nil, nil
+	}
+
+	var userID int64
+	err := s.db.QueryRow(`SELECT id FROM users WHERE scim_provisioned='t' AND scim_external_id=$1`, externalID).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf(`store: unable to execute query: %v`, err)
+	}
+
+	return s.UserByID(userID)
+}
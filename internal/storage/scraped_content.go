@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ScrapedContent returns the content scraped from a web page less than maxAge ago.
+func (s *Storage) ScrapedContent(urlHash string, maxAge time.Duration) (pageBaseURL, content string, found bool, err error) {
+	query := `
+		SELECT
+			page_base_url, content
+		FROM
+			scraped_contents
+		WHERE
+			url_hash=$1 AND created_at > $2
+	`
+	err = s.db.QueryRow(query, urlHash, time.Now().Add(-maxAge)).Scan(&pageBaseURL, &content)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", "", false, nil
+	case err != nil:
+		return "", "", false, fmt.Errorf(`store: unable to fetch scraped content: %v`, err)
+	}
+
+	return pageBaseURL, content, true, nil
+}
+
+// StoreScrapedContent saves the content scraped from a web page to share it between the feeds.
+func (s *Storage) StoreScrapedContent(urlHash, url, pageBaseURL, content string) error {
+	query := `
+		INSERT INTO scraped_contents
+			(url_hash, url, page_base_url, content, created_at)
+		VALUES
+			($1, $2, $3, $4, now())
+		ON CONFLICT (url_hash) DO UPDATE SET
+			url=EXCLUDED.url,
+			page_base_url=EXCLUDED.page_base_url,
+			content=EXCLUDED.content,
+			created_at=EXCLUDED.created_at
+	`
+	if _, err := s.db.Exec(query, urlHash, url, pageBaseURL, content); err != nil {
+		return fmt.Errorf(`store: unable to save scraped content: %v`, err)
+	}
+
+	return nil
+}
+
+// CleanOldScrapedContents removes the scraped contents older than maxAge.
+func (s *Storage) CleanOldScrapedContents(maxAge time.Duration) (int64, error) {
+	result, err := s.db.Exec(`DELETE FROM scraped_contents WHERE created_at < $1`, time.Now().Add(-maxAge))
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to clean scraped contents: %v`, err)
+	}
+
+	n, _ := result.RowsAffected()
+	return n, nil
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/database"
+)
+
+// newTestStorage connects to the database given by TEST_MINIFLUX_DATABASE_URL, the test is skipped otherwise.
+func newTestStorage(t *testing.T) *Storage {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_MINIFLUX_DATABASE_URL")
+	if dsn == "" {
+		t.Skip(`Set TEST_MINIFLUX_DATABASE_URL to run the storage tests`)
+	}
+
+	db, err := database.NewConnectionPool(dsn, 1, 2, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewStorage(db)
+}
+
+func TestStoreScrapedContentReplacesPreviousContent(t *testing.T) {
+	store := newTestStorage(t)
+
+	urlHash := crypto.Hash(t.Name() + time.Now().String())
+	t.Cleanup(func() { store.db.Exec(`DELETE FROM scraped_contents WHERE url_hash=$1`, urlHash) })
+
+	if err := store.StoreScrapedContent(urlHash, "https://example.org/article", "https://example.org/", "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.StoreScrapedContent(urlHash, "https://example.org/article", "https://example.org/base/", "second"); err != nil {
+		t.Fatal(err)
+	}
+
+	pageBaseURL, content, found, err := store.ScrapedContent(urlHash, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !found || content != "second" || pageBaseURL != "https://example.org/base/" {
+		t.Errorf(`The last scraped content should be returned, got %v, %q, %q`, found, pageBaseURL, content)
+	}
+}
+
+func TestScrapedContentExpiration(t *testing.T) {
+	store := newTestStorage(t)
+
+	urlHash := crypto.Hash(t.Name() + time.Now().String())
+	t.Cleanup(func() { store.db.Exec(`DELETE FROM scraped_contents WHERE url_hash=$1`, urlHash) })
+
+	if err := store.StoreScrapedContent(urlHash, "https://example.org/article", "https://example.org/", "content"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, found, err := store.ScrapedContent(urlHash, time.Hour); err != nil || !found {
+		t.Fatalf(`The recent scraped content should be found, got %v, %v`, found, err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	if _, _, found, err := store.ScrapedContent(urlHash, time.Millisecond); err != nil || found {
+		t.Errorf(`The scraped content older than the TTL should not be returned, got %v, %v`, found, err)
+	}
+
+	if _, err := store.CleanOldScrapedContents(time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, found, err := store.ScrapedContent(urlHash, time.Hour); err != nil || found {
+		t.Errorf(`The scraped content older than the TTL should be removed, got %v, %v`, found, err)
+	}
+}
@@ -109,11 +109,17 @@ func (s *Storage) AppSession(id string) (*model.Session, error) {
 
 // FlushAllSessions removes all sessions from the database.
 func (s *Storage) FlushAllSessions() (err error) {
-	_, err = s.db.Exec(`DELETE FROM user_sessions`)
+	rows, err := s.db.Query(`DELETE FROM user_sessions RETURNING token`)
 	if err != nil {
 		return err
 	}
 
+	tokens, err := scanTokens(rows)
+	if err != nil {
+		return err
+	}
+	s.invalidateUserSessions(tokens...)
+
 	_, err = s.db.Exec(`DELETE FROM sessions`)
 	if err != nil {
 		return err
@@ -7,16 +7,22 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"miniflux.app/v2/internal/cache"
+	"miniflux.app/v2/internal/database"
+	"miniflux.app/v2/internal/iconstore"
 )
 
 // Storage handles all operations related to the database.
 type Storage struct {
-	db *sql.DB
+	db        *sql.DB
+	cache     *cache.Cache
+	iconStore iconstore.Store
 }
 
 // NewStorage returns a new Storage.
 func NewStorage(db *sql.DB) *Storage {
-	return &Storage{db}
+	return &Storage{db: db}
 }
 
 // DatabaseVersion returns the version of the database which is in use.
@@ -38,6 +44,11 @@ func (s *Storage) Ping() error {
 	return s.db.PingContext(ctx)
 }
 
+// IsSchemaUpToDate returns an error if the database migrations are not applied.
+func (s *Storage) IsSchemaUpToDate() error {
+	return database.IsSchemaUpToDate(s.db)
+}
+
 // DBStats returns database statistics.
 func (s *Storage) DBStats() sql.DBStats {
 	return s.db.Stats()
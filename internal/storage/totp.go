@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/v2/internal/crypto"
+
+	"github.com/lib/pq"
+)
+
+// HasTOTP returns true if the user has enabled two-factor authentication.
+func (s *Storage) HasTOTP(userID int64) bool {
+	var result bool
+	query := `SELECT totp_enabled FROM users WHERE id=$1`
+	s.db.QueryRow(query, userID).Scan(&result)
+	return result
+}
+
+// TOTPSecret returns the two-factor authentication secret of the user and whether it has been enabled.
+func (s *Storage) TOTPSecret(userID int64) (string, bool, error) {
+	var secret string
+	var enabled bool
+	query := `SELECT totp_secret, totp_enabled FROM users WHERE id=$1`
+	err := s.db.QueryRow(query, userID).Scan(&secret, &enabled)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", false, nil
+	case err != nil:
+		return "", false, fmt.Errorf(`store: unable to fetch TOTP secret: %v`, err)
+	}
+
+	return secret, enabled, nil
+}
+
+// SetPendingTOTPSecret stores a secret that is not used for authentication until it has been confirmed.
+func (s *Storage) SetPendingTOTPSecret(userID int64, secret string) error {
+	query := `
+		UPDATE users
+		SET totp_secret=$2, totp_enabled='f', totp_recovery_codes='{}', totp_last_counter=0
+		WHERE id=$1 AND totp_enabled='f'
+	`
+	if _, err := s.db.Exec(query, userID, secret); err != nil {
+		return fmt.Errorf(`store: unable to update TOTP secret: %v`, err)
+	}
+
+	return nil
+}
+
+// EnableTOTP turns on two-factor authentication with the pending secret and replaces the recovery codes.
+func (s *Storage) EnableTOTP(userID int64, recoveryCodes []string) error {
+	query := `UPDATE users SET totp_enabled='t', totp_recovery_codes=$2 WHERE id=$1 AND totp_secret <> ''`
+	if _, err := s.db.Exec(query, userID, pq.Array(hashRecoveryCodes(recoveryCodes))); err != nil {
+		return fmt.Errorf(`store: unable to enable TOTP: %v`, err)
+	}
+
+	return nil
+}
+
+// DisableTOTP turns off two-factor authentication and removes the secret.
+func (s *Storage) DisableTOTP(userID int64) error {
+	query := `UPDATE users SET totp_secret='', totp_enabled='f', totp_recovery_codes='{}', totp_last_counter=0 WHERE id=$1`
+	if _, err := s.db.Exec(query, userID); err != nil {
+		return fmt.Errorf(`store: unable to disable TOTP: %v`, err)
+	}
+
+	return nil
+}
+
+// TOTPLastCounter returns the time step of the last one-time password accepted for the user.
+func (s *Storage) TOTPLastCounter(userID int64) (int64, error) {
+	var counter int64
+	query := `SELECT totp_last_counter FROM users WHERE id=$1`
+	err := s.db.QueryRow(query, userID).Scan(&counter)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf(`store: unable to fetch TOTP counter: %v`, err)
+	}
+
+	return counter, nil
+}
+
+// SetTOTPLastCounter records the time step of an accepted one-time password.
+// It returns false when a code of the same or a later time step has already been accepted.
+func (s *Storage) SetTOTPLastCounter(userID, counter int64) (bool, error) {
+	query := `UPDATE users SET totp_last_counter=$2 WHERE id=$1 AND totp_last_counter < $2`
+	result, err := s.db.Exec(query, userID, counter)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update TOTP counter: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update TOTP counter: %v`, err)
+	}
+
+	return count > 0, nil
+}
+
+// UseTOTPRecoveryCode removes the recovery code and returns true if it was valid.
+func (s *Storage) UseTOTPRecoveryCode(userID int64, recoveryCode string) (bool, error) {
+	hash := crypto.Hash(recoveryCode)
+	query := `
+		UPDATE users
+		SET totp_recovery_codes=array_remove(totp_recovery_codes, $2)
+		WHERE id=$1 AND totp_enabled='t' AND $2 = ANY(totp_recovery_codes)
+	`
+	result, err := s.db.Exec(query, userID, hash)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to use TOTP recovery code: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to use TOTP recovery code: %v`, err)
+	}
+
+	return count > 0, nil
+}
+
+// CountTOTPRecoveryCodes returns the number of unused recovery codes.
+func (s *Storage) CountTOTPRecoveryCodes(userID int64) int {
+	var result int
+	query := `SELECT cardinality(totp_recovery_codes) FROM users WHERE id=$1`
+	s.db.QueryRow(query, userID).Scan(&result)
+	return result
+}
+
+func hashRecoveryCodes(recoveryCodes []string) []string {
+	hashes := make([]string, len(recoveryCodes))
+	for i, recoveryCode := range recoveryCodes {
+		hashes[i] = crypto.Hash(recoveryCode)
+	}
+	return hashes
+}
@@ -30,6 +30,8 @@ func (s *Storage) CountUsers() int {
 
 // SetLastLogin updates the last login date of a user.
 func (s *Storage) SetLastLogin(userID int64) error {
+	defer s.invalidateUser(userID)
+
 	query := `UPDATE users SET last_login_at=now() WHERE id=$1`
 	_, err := s.db.Exec(query, userID)
 	if err != nil {
@@ -66,9 +68,9 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 
 	query := `
 		INSERT INTO users
-			(username, password, is_admin, google_id, openid_connect_id)
+			(username, password, is_admin, google_id, openid_connect_id, auth_source)
 		VALUES
-			(LOWER($1), $2, $3, $4, $5)
+			(LOWER($1), $2, $3, $4, $5, $6)
 		RETURNING
 			id,
 			username,
@@ -94,7 +96,9 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 			mark_read_on_view,
 			media_playback_rate,
 			block_filter_entry_rules,
-			keep_filter_entry_rules
+			keep_filter_entry_rules,
+			iframe_allowed_domains,
+			render_math
 	`
 
 	tx, err := s.db.Begin()
@@ -110,6 +114,7 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		userCreationRequest.IsAdmin,
 		userCreationRequest.GoogleID,
 		userCreationRequest.OpenIDConnectID,
+		userCreationRequest.AuthSource,
 	).Scan(
 		&user.ID,
 		&user.Username,
@@ -136,6 +141,8 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		&user.MediaPlaybackRate,
 		&user.BlockFilterEntryRules,
 		&user.KeepFilterEntryRules,
+		pq.Array(&user.IframeAllowedDomains),
+		&user.RenderMath,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -163,6 +170,8 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 
 // UpdateUser updates a user.
 func (s *Storage) UpdateUser(user *model.User) error {
+	defer s.invalidateUser(user.ID)
+
 	if user.Password != "" {
 		hashedPassword, err := crypto.HashPassword(user.Password)
 		if err != nil {
@@ -196,9 +205,11 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				mark_read_on_media_player_completion=$23,
 				media_playback_rate=$24,
 				block_filter_entry_rules=$25,
-				keep_filter_entry_rules=$26
+				keep_filter_entry_rules=$26,
+				iframe_allowed_domains=$27,
+				render_math=$28
 			WHERE
-				id=$27
+				id=$29
 		`
 
 		_, err = s.db.Exec(
@@ -229,6 +240,8 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.MediaPlaybackRate,
 			user.BlockFilterEntryRules,
 			user.KeepFilterEntryRules,
+			pq.Array(user.IframeAllowedDomains),
+			user.RenderMath,
 			user.ID,
 		)
 		if err != nil {
@@ -261,9 +274,11 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				mark_read_on_media_player_completion=$22,
 				media_playback_rate=$23,
 				block_filter_entry_rules=$24,
-				keep_filter_entry_rules=$25
+				keep_filter_entry_rules=$25,
+				iframe_allowed_domains=$26,
+				render_math=$27
 			WHERE
-				id=$26
+				id=$28
 		`
 
 		_, err := s.db.Exec(
@@ -293,6 +308,8 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.MediaPlaybackRate,
 			user.BlockFilterEntryRules,
 			user.KeepFilterEntryRules,
+			pq.Array(user.IframeAllowedDomains),
+			user.RenderMath,
 			user.ID,
 		)
 
@@ -316,6 +333,11 @@ func (s *Storage) UserLanguage(userID int64) (language string) {
 
 // UserByID finds a user by the ID.
 func (s *Storage) UserByID(userID int64) (*model.User, error) {
+	var cachedUser model.User
+	if s.cache.GetJSON(userCacheKey(userID), &cachedUser) {
+		return &cachedUser, nil
+	}
+
 	query := `
 		SELECT
 			id,
@@ -344,13 +366,23 @@ func (s *Storage) UserByID(userID int64) (*model.User, error) {
 			mark_read_on_media_player_completion,
 			media_playback_rate,
 			block_filter_entry_rules,
-			keep_filter_entry_rules
+			keep_filter_entry_rules,
+			full_name,
+			email,
+			iframe_allowed_domains,
+			render_math
 		FROM
 			users
 		WHERE
 			id = $1
 	`
-	return s.fetchUser(query, userID)
+
+	user, err := s.fetchUser(query, userID)
+	if err == nil && user != nil {
+		s.cache.SetJSON(userCacheKey(userID), user)
+	}
+
+	return user, err
 }
 
 // UserByUsername finds a user by the username.
@@ -383,7 +415,11 @@ func (s *Storage) UserByUsername(username string) (*model.User, error) {
 			mark_read_on_media_player_completion,
 			media_playback_rate,
 			block_filter_entry_rules,
-			keep_filter_entry_rules
+			keep_filter_entry_rules,
+			full_name,
+			email,
+			iframe_allowed_domains,
+			render_math
 		FROM
 			users
 		WHERE
@@ -422,7 +458,11 @@ func (s *Storage) UserByField(field, value string) (*model.User, error) {
 			mark_read_on_media_player_completion,
 			media_playback_rate,
 			block_filter_entry_rules,
-			keep_filter_entry_rules
+			keep_filter_entry_rules,
+			full_name,
+			email,
+			iframe_allowed_domains,
+			render_math
 		FROM
 			users
 		WHERE
@@ -468,13 +508,17 @@ func (s *Storage) UserByAPIKey(token string) (*model.User, error) {
 			u.mark_read_on_media_player_completion,
 			media_playback_rate,
 			u.block_filter_entry_rules,
-			u.keep_filter_entry_rules
+			u.keep_filter_entry_rules,
+			u.full_name,
+			u.email,
+			u.iframe_allowed_domains,
+			u.render_math
 		FROM
 			users u
 		LEFT JOIN
 			api_keys ON api_keys.user_id=u.id
 		WHERE
-			api_keys.token = $1
+			api_keys.token = $1 AND (api_keys.expires_at IS NULL OR api_keys.expires_at > now()) AND u.is_disabled='f'
 	`
 	return s.fetchUser(query, token)
 }
@@ -509,6 +553,10 @@ func (s *Storage) fetchUser(query string, args ...interface{}) (*model.User, err
 		&user.MediaPlaybackRate,
 		&user.BlockFilterEntryRules,
 		&user.KeepFilterEntryRules,
+		&user.FullName,
+		&user.Email,
+		pq.Array(&user.IframeAllowedDomains),
+		&user.RenderMath,
 	)
 
 	if err == sql.ErrNoRows {
@@ -522,11 +570,25 @@ func (s *Storage) fetchUser(query string, args ...interface{}) (*model.User, err
 
 // RemoveUser deletes a user.
 func (s *Storage) RemoveUser(userID int64) error {
+	defer s.invalidateUser(userID)
+
 	tx, err := s.db.Begin()
 	if err != nil {
 		return fmt.Errorf(`store: unable to start transaction: %v`, err)
 	}
 
+	rows, err := tx.Query(`DELETE FROM user_sessions WHERE user_id=$1 RETURNING token`, userID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove sessions of user #%d: %v`, userID, err)
+	}
+
+	tokens, err := scanTokens(rows)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove sessions of user #%d: %v`, userID, err)
+	}
+
 	if _, err := tx.Exec(`DELETE FROM users WHERE id=$1`, userID); err != nil {
 		tx.Rollback()
 		return fmt.Errorf(`store: unable to remove user #%d: %v`, userID, err)
@@ -541,11 +603,20 @@ func (s *Storage) RemoveUser(userID int64) error {
 		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
 	}
 
+	s.invalidateUserSessions(tokens...)
 	return nil
 }
 
 // RemoveUserAsync deletes user data without locking the database.
+// The sessions are removed right away, so the user is logged out before the feeds are deleted.
 func (s *Storage) RemoveUserAsync(userID int64) {
+	if err := s.removeAllUserSessions(userID); err != nil {
+		slog.Error("Unable to remove user sessions",
+			slog.Int64("user_id", userID),
+			slog.Any("error", err),
+		)
+	}
+
 	go func() {
 		if err := s.deleteUserFeeds(userID); err != nil {
 			slog.Error("Unable to delete user feeds",
@@ -557,6 +628,7 @@ func (s *Storage) RemoveUserAsync(userID int64) {
 
 		s.db.Exec(`DELETE FROM users WHERE id=$1`, userID)
 		s.db.Exec(`DELETE FROM integrations WHERE user_id=$1`, userID)
+		s.invalidateUser(userID)
 
 		slog.Debug("User deleted",
 			slog.Int64("user_id", userID),
@@ -620,7 +692,11 @@ func (s *Storage) Users() (model.Users, error) {
 			mark_read_on_media_player_completion,
 			media_playback_rate,
 			block_filter_entry_rules,
-			keep_filter_entry_rules
+			keep_filter_entry_rules,
+			full_name,
+			email,
+			iframe_allowed_domains,
+			render_math
 		FROM
 			users
 		ORDER BY username ASC
@@ -662,6 +738,10 @@ func (s *Storage) Users() (model.Users, error) {
 			&user.MediaPlaybackRate,
 			&user.BlockFilterEntryRules,
 			&user.KeepFilterEntryRules,
+			&user.FullName,
+			&user.Email,
+			pq.Array(&user.IframeAllowedDomains),
+			&user.RenderMath,
 		)
 
 		if err != nil {
@@ -679,7 +759,7 @@ func (s *Storage) CheckPassword(username, password string) error {
 	var hash string
 	username = strings.ToLower(username)
 
-	err := s.db.QueryRow("SELECT password FROM users WHERE username=$1", username).Scan(&hash)
+	err := s.db.QueryRow("SELECT password FROM users WHERE username=$1 AND is_disabled='f'", username).Scan(&hash)
 	if err == sql.ErrNoRows {
 		return fmt.Errorf(`store: unable to find this user: %s`, username)
 	} else if err != nil {
@@ -693,6 +773,21 @@ func (s *Storage) CheckPassword(username, password string) error {
 	return nil
 }
 
+// UserAuthSource returns the authentication backend that created the user, empty for the local users.
+func (s *Storage) UserAuthSource(userID int64) (string, error) {
+	var authSource string
+	query := `SELECT auth_source FROM users WHERE id=$1`
+
+	err := s.db.QueryRow(query, userID).Scan(&authSource)
+	if err == sql.ErrNoRows {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf(`store: unable to fetch authentication source: %v`, err)
+	}
+
+	return authSource, nil
+}
+
 // HasPassword returns true if the given user has a password defined.
 func (s *Storage) HasPassword(userID int64) (bool, error) {
 	var result bool
@@ -710,3 +805,84 @@ func (s *Storage) HasPassword(userID int64) (bool, error) {
 	}
 	return false, nil
 }
+
+// IsUserDisabled returns true if the given user account has been deactivated.
+func (s *Storage) IsUserDisabled(userID int64) (bool, error) {
+	var disabled bool
+	query := `SELECT is_disabled FROM users WHERE id=$1`
+
+	err := s.db.QueryRow(query, userID).Scan(&disabled)
+	if err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf(`store: unable to execute query: %v`, err)
+	}
+
+	return disabled, nil
+}
+
+// DisabledUserIDs returns the IDs of all deactivated user accounts.
+func (s *Storage) DisabledUserIDs() (map[int64]bool, error) {
+	rows, err := s.db.Query(`SELECT id FROM users WHERE is_disabled='t'`)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch disabled users: %v`, err)
+	}
+	defer rows.Close()
+
+	userIDs := make(map[int64]bool)
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch disabled user row: %v`, err)
+		}
+		userIDs[userID] = true
+	}
+
+	return userIDs, nil
+}
+
+// SetUserDisabled activates or deactivates a user account.
+// Deactivating an account also removes all its sessions.
+func (s *Storage) SetUserDisabled(userID int64, disabled bool) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET is_disabled=$1 WHERE id=$2`, disabled, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to update user #%d: %v`, userID, err)
+	}
+
+	var tokens []string
+	if disabled {
+		rows, err := tx.Query(`DELETE FROM user_sessions WHERE user_id=$1 RETURNING token`, userID)
+		if err == nil {
+			tokens, err = scanTokens(rows)
+		}
+
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to remove sessions of user #%d: %v`, userID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	s.invalidateUserSessions(tokens...)
+	return nil
+}
+
+// SetUserProfile updates the full name and email address of the user.
+func (s *Storage) SetUserProfile(userID int64, fullName, email string) error {
+	defer s.invalidateUser(userID)
+
+	query := `UPDATE users SET full_name=$1, email=$2 WHERE id=$3`
+	if _, err := s.db.Exec(query, fullName, email, userID); err != nil {
+		return fmt.Errorf(`store: unable to update user #%d: %v`, userID, err)
+	}
+
+	return nil
+}
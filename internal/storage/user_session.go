@@ -19,12 +19,13 @@ func (s *Storage) UserSessions(userID int64) (model.UserSessions, error) {
 			user_id,
 			token,
 			created_at,
+			last_seen_at,
 			user_agent,
 			ip
 		FROM
 			user_sessions
 		WHERE
-			user_id=$1 ORDER BY id DESC
+			user_id=$1 ORDER BY last_seen_at DESC
 	`
 	rows, err := s.db.Query(query, userID)
 	if err != nil {
@@ -40,6 +41,7 @@ func (s *Storage) UserSessions(userID int64) (model.UserSessions, error) {
 			&session.UserID,
 			&session.Token,
 			&session.CreatedAt,
+			&session.LastSeenAt,
 			&session.UserAgent,
 			&session.IP,
 		)
@@ -63,8 +65,11 @@ func (s *Storage) CreateUserSessionFromUsername(username, userAgent, ip string)
 		return "", 0, fmt.Errorf(`store: unable to start transaction: %v`, err)
 	}
 
-	err = tx.QueryRow(`SELECT id FROM users WHERE username = LOWER($1)`, username).Scan(&userID)
-	if err != nil {
+	err = tx.QueryRow(`SELECT id FROM users WHERE username = LOWER($1) AND is_disabled='f'`, username).Scan(&userID)
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		return "", 0, fmt.Errorf(`store: the user %q does not exist or is disabled`, username)
+	} else if err != nil {
 		tx.Rollback()
 		return "", 0, fmt.Errorf(`store: unable to fetch user ID: %v`, err)
 	}
@@ -91,6 +96,10 @@ func (s *Storage) CreateUserSessionFromUsername(username, userAgent, ip string)
 // UserSessionByToken finds a session by the token.
 func (s *Storage) UserSessionByToken(token string) (*model.UserSession, error) {
 	var session model.UserSession
+	if s.cache.GetJSON(userSessionCacheKey(token), &session) {
+		session.Token = token
+		return &session, nil
+	}
 
 	query := `
 		SELECT
@@ -98,8 +107,9 @@ func (s *Storage) UserSessionByToken(token string) (*model.UserSession, error) {
 			user_id,
 			token,
 			created_at,
+			last_seen_at,
 			user_agent,
-			ip 
+			ip
 		FROM
 			user_sessions
 		WHERE
@@ -110,6 +120,7 @@ func (s *Storage) UserSessionByToken(token string) (*model.UserSession, error) {
 		&session.UserID,
 		&session.Token,
 		&session.CreatedAt,
+		&session.LastSeenAt,
 		&session.UserAgent,
 		&session.IP,
 	)
@@ -120,6 +131,7 @@ func (s *Storage) UserSessionByToken(token string) (*model.UserSession, error) {
 	case err != nil:
 		return nil, fmt.Errorf(`store: unable to fetch user session: %v`, err)
 	default:
+		s.cache.SetJSONIfAbsent(userSessionCacheKey(token), &session)
 		return &session, nil
 	}
 }
@@ -131,6 +143,7 @@ func (s *Storage) RemoveUserSessionByToken(userID int64, token string) error {
 	if err != nil {
 		return fmt.Errorf(`store: unable to remove this user session: %v`, err)
 	}
+	s.invalidateUserSessions(token)
 
 	count, err := result.RowsAffected()
 	if err != nil {
@@ -144,21 +157,67 @@ func (s *Storage) RemoveUserSessionByToken(userID int64, token string) error {
 	return nil
 }
 
+// UserSessionExists checks if the user session exists.
+func (s *Storage) UserSessionExists(userID, sessionID int64) bool {
+	var result bool
+	query := `SELECT true FROM user_sessions WHERE user_id=$1 AND id=$2`
+	s.db.QueryRow(query, userID, sessionID).Scan(&result)
+	return result
+}
+
 // RemoveUserSessionByID remove a session by using the ID.
 func (s *Storage) RemoveUserSessionByID(userID, sessionID int64) error {
-	query := `DELETE FROM user_sessions WHERE user_id=$1 AND id=$2`
-	result, err := s.db.Exec(query, userID, sessionID)
-	if err != nil {
+	var token string
+	query := `DELETE FROM user_sessions WHERE user_id=$1 AND id=$2 RETURNING token`
+	err := s.db.QueryRow(query, userID, sessionID).Scan(&token)
+	switch {
+	case err == sql.ErrNoRows:
+		return fmt.Errorf(`store: nothing has been removed`)
+	case err != nil:
 		return fmt.Errorf(`store: unable to remove this user session: %v`, err)
 	}
 
-	count, err := result.RowsAffected()
+	s.invalidateUserSessions(token)
+	return nil
+}
+
+// RemoveOtherUserSessions removes all sessions of the user except the one with the given token.
+func (s *Storage) RemoveOtherUserSessions(userID int64, token string) (int64, error) {
+	query := `DELETE FROM user_sessions WHERE user_id=$1 AND token<>$2 RETURNING token`
+	rows, err := s.db.Query(query, userID, token)
 	if err != nil {
-		return fmt.Errorf(`store: unable to remove this user session: %v`, err)
+		return 0, fmt.Errorf(`store: unable to remove user sessions: %v`, err)
 	}
 
-	if count != 1 {
-		return fmt.Errorf(`store: nothing has been removed`)
+	tokens, err := scanTokens(rows)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to remove user sessions: %v`, err)
+	}
+
+	s.invalidateUserSessions(tokens...)
+	return int64(len(tokens)), nil
+}
+
+func (s *Storage) removeAllUserSessions(userID int64) error {
+	rows, err := s.db.Query(`DELETE FROM user_sessions WHERE user_id=$1 RETURNING token`, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove user sessions: %v`, err)
+	}
+
+	tokens, err := scanTokens(rows)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove user sessions: %v`, err)
+	}
+
+	s.invalidateUserSessions(tokens...)
+	return nil
+}
+
+// TouchUserSession updates the last seen date of the session, at most once per minute.
+func (s *Storage) TouchUserSession(token string) error {
+	query := `UPDATE user_sessions SET last_seen_at=now() WHERE token=$1 AND last_seen_at < now() - interval '1 minute'`
+	if _, err := s.db.Exec(query, token); err != nil {
+		return fmt.Errorf(`store: unable to update user session: %v`, err)
 	}
 
 	return nil
@@ -171,12 +230,35 @@ func (s *Storage) CleanOldUserSessions(days int) int64 {
 			user_sessions
 		WHERE
 			created_at < now() - $1::interval
+		RETURNING
+			token
 	`
-	result, err := s.db.Exec(query, fmt.Sprintf("%d days", days))
+	rows, err := s.db.Query(query, fmt.Sprintf("%d days", days))
+	if err != nil {
+		return 0
+	}
+
+	tokens, err := scanTokens(rows)
 	if err != nil {
 		return 0
 	}
 
-	n, _ := result.RowsAffected()
-	return n
+	s.invalidateUserSessions(tokens...)
+	return int64(len(tokens))
+}
+
+// scanTokens reads the tokens returned by a DELETE ... RETURNING token query and closes the rows.
+func scanTokens(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	var tokens []string
+	for rows.Next() {
+		var token string
+		if err := rows.Scan(&token); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
 }
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// WebSubSubscription returns the WebSub subscription of the feed, or nil if the feed is not subscribed to any hub.
+func (s *Storage) WebSubSubscription(feedID int64) (*model.WebSubSubscription, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			websub_hub_url,
+			websub_topic_url,
+			websub_secret,
+			websub_requested_at,
+			websub_lease_expires_at
+		FROM
+			feeds
+		WHERE
+			id=$1 AND websub_hub_url <> ''
+	`
+
+	var subscription model.WebSubSubscription
+	err := s.db.QueryRow(query, feedID).Scan(
+		&subscription.FeedID,
+		&subscription.UserID,
+		&subscription.HubURL,
+		&subscription.TopicURL,
+		&subscription.Secret,
+		&subscription.RequestedAt,
+		&subscription.LeaseExpiresAt,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+
+	return &subscription, nil
+}
+
+// SaveWebSubSubscription records a subscription request sent to a hub.
+// The current lease is kept until the hub verifies the request, unless the hub or the topic changed.
+func (s *Storage) SaveWebSubSubscription(feedID int64, hubURL, topicURL, secret string) error {
+	query := `
+		UPDATE
+			feeds
+		SET
+			websub_lease_expires_at=CASE WHEN websub_hub_url=$2 AND websub_topic_url=$3 THEN websub_lease_expires_at ELSE NULL END,
+			websub_hub_url=$2,
+			websub_topic_url=$3,
+			websub_secret=$4,
+			websub_requested_at=now()
+		WHERE
+			id=$1
+	`
+	if _, err := s.db.Exec(query, feedID, hubURL, topicURL, secret); err != nil {
+		return fmt.Errorf(`store: unable to save WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// SetWebSubLease saves the lease granted by the hub when it verifies the subscription.
+func (s *Storage) SetWebSubLease(feedID int64, leaseExpiresAt time.Time) error {
+	query := `UPDATE feeds SET websub_lease_expires_at=$2 WHERE id=$1 AND websub_hub_url <> ''`
+	if _, err := s.db.Exec(query, feedID, leaseExpiresAt); err != nil {
+		return fmt.Errorf(`store: unable to update WebSub lease of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// RemoveWebSubSubscription forgets the subscription of the feed, its entries are polled again.
+func (s *Storage) RemoveWebSubSubscription(feedID int64) error {
+	query := `
+		UPDATE
+			feeds
+		SET
+			websub_hub_url='',
+			websub_topic_url='',
+			websub_secret='',
+			websub_requested_at=NULL,
+			websub_lease_expires_at=NULL
+		WHERE
+			id=$1
+	`
+	if _, err := s.db.Exec(query, feedID); err != nil {
+		return fmt.Errorf(`store: unable to remove WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
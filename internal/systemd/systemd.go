@@ -20,6 +20,10 @@ const (
 	// SdNotifyWatchdog the service manager to update the watchdog timestamp.
 	// https://www.freedesktop.org/software/systemd/man/sd_notify.html#WATCHDOG=1
 	SdNotifyWatchdog = "WATCHDOG=1"
+
+	// SdNotifyStopping tells the service manager that the service is beginning its shutdown.
+	// https://www.freedesktop.org/software/systemd/man/sd_notify.html#STOPPING=1
+	SdNotifyStopping = "STOPPING=1"
 )
 
 // HasNotifySocket checks if the process is supervised by Systemd and has the notify socket.
@@ -33,15 +33,20 @@ type funcMap struct {
 func (f *funcMap) Map() template.FuncMap {
 	return template.FuncMap{
 		"formatFileSize": formatFileSize,
+		"formatDuration": formatDuration,
 		"dict":           dict,
 		"hasKey":         hasKey,
 		"truncate":       truncate,
 		"isEmail":        isEmail,
+		"mapURL":         mapURL,
 		"baseURL":        config.Opts.BaseURL,
 		"rootURL":        config.Opts.RootURL,
 		"hasOAuth2Provider": func(provider string) bool {
 			return config.Opts.OAuth2Provider() == provider
 		},
+		"hasSAML":        config.Opts.HasSAML,
+		"hasTranslation": config.Opts.HasTranslation,
+		"oidcProviders":  config.Opts.OIDCProviders,
 		"hasAuthProxy": func() bool {
 			return config.Opts.AuthProxyHeader() != ""
 		},
@@ -148,6 +153,14 @@ func isEmail(str string) bool {
 }
 
 // Returns the duration in human readable format (hours and minutes).
+// mapURL returns the OpenStreetMap page centered on the location.
+func mapURL(latitude, longitude *float64) string {
+	if latitude == nil || longitude == nil {
+		return ""
+	}
+	return fmt.Sprintf("https://www.openstreetmap.org/?mlat=%[1]f&mlon=%[2]f#map=14/%[1]f/%[2]f", *latitude, *longitude)
+}
+
 func duration(t time.Time) string {
 	return durationImpl(t, time.Now())
 }
@@ -215,3 +228,11 @@ func formatFileSize(b int64) string {
 	number := math.Pow(unit, base-math.Floor(base))
 	return fmt.Sprintf("%.1f %ciB", number, "KMGTPE"[int64(base)-1])
 }
+
+// formatDuration formats a duration in seconds like a media player, for example "1:02:03" or "2:03".
+func formatDuration(seconds int64) string {
+	if seconds < 3600 {
+		return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+}
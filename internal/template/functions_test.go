@@ -95,6 +95,17 @@ func TestIsEmail(t *testing.T) {
 	}
 }
 
+func TestMapURL(t *testing.T) {
+	latitude, longitude := 45.256, -71.92
+	if result := mapURL(&latitude, &longitude); result != "https://www.openstreetmap.org/?mlat=45.256000&mlon=-71.920000#map=14/45.256000/-71.920000" {
+		t.Errorf(`Unexpected map URL, got %q`, result)
+	}
+
+	if result := mapURL(nil, &longitude); result != "" {
+		t.Errorf(`The map URL should be empty without latitude, got %q`, result)
+	}
+}
+
 func TestDuration(t *testing.T) {
 	now := time.Now()
 	var dt = []struct {
@@ -171,3 +182,23 @@ func TestFormatFileSize(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatDuration(t *testing.T) {
+	scenarios := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0:00"},
+		{59, "0:59"},
+		{125, "2:05"},
+		{3600, "1:00:00"},
+		{3725, "1:02:05"},
+	}
+
+	for _, scenario := range scenarios {
+		result := formatDuration(scenario.input)
+		if result != scenario.expected {
+			t.Errorf(`Unexpected result, got %q instead of %q for %d`, result, scenario.expected, scenario.input)
+		}
+	}
+}
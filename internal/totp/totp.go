@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package totp implements time-based one-time passwords as described in RFC 6238.
+package totp // import "miniflux.app/v2/internal/totp"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+)
+
+const (
+	secretSize = 20
+	digits     = 6
+	period     = 30
+
+	// Accept the codes of the previous and next periods to tolerate clock drift.
+	skew = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret encoded in base32.
+func GenerateSecret() string {
+	return encoding.EncodeToString(crypto.GenerateRandomBytes(secretSize))
+}
+
+// Code returns the one-time password of the given secret at the given time.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return "", fmt.Errorf("totp: invalid secret: %v", err)
+	}
+
+	return generate(key, uint64(t.Unix()/period)), nil
+}
+
+// Validate returns the time step of the code and true if the code is valid for the secret at the given time.
+// The codes of the time steps at or before lastCounter are rejected to prevent the replay of a code already used.
+func Validate(secret, code string, t time.Time, lastCounter int64) (int64, bool) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return 0, false
+	}
+
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != digits {
+		return 0, false
+	}
+
+	counter := t.Unix() / period
+	for i := int64(-skew); i <= skew; i++ {
+		if counter+i <= lastCounter {
+			continue
+		}
+
+		if hmac.Equal([]byte(generate(key, uint64(counter+i))), []byte(code)) {
+			return counter + i, true
+		}
+	}
+
+	return 0, false
+}
+
+// URI returns the provisioning URI understood by authenticator applications.
+func URI(issuer, account, secret string) string {
+	values := url.Values{}
+	values.Set("secret", secret)
+	values.Set("issuer", issuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprint(digits))
+	values.Set("period", fmt.Sprint(period))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + values.Encode()
+}
+
+func generate(key []byte, counter uint64) string {
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", digits, value%1000000)
+}
+
+// GenerateRecoveryCodes returns single-use codes that can replace a one-time password, e.g. when the device is lost.
+func GenerateRecoveryCodes(count int) []string {
+	codes := make([]string, count)
+	for i := range codes {
+		code := strings.ToLower(encoding.EncodeToString(crypto.GenerateRandomBytes(5)))
+		codes[i] = code[:4] + "-" + code[4:]
+	}
+	return codes
+}
+
+// NormalizeRecoveryCode returns the recovery code in the format produced by GenerateRecoveryCodes.
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+	if len(code) != 8 {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
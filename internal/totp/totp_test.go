@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package totp // import "miniflux.app/v2/internal/totp"
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The SHA1 test vectors of RFC 6238, truncated to six digits.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	scenarios := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for timestamp, expected := range scenarios {
+		code, err := Code(rfcSecret, time.Unix(timestamp, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if code != expected {
+			t.Errorf(`Unexpected code at %d, got %q instead of %q`, timestamp, code, expected)
+		}
+	}
+}
+
+func TestCodeWithInvalidSecret(t *testing.T) {
+	if _, err := Code("not base32!", time.Now()); err == nil {
+		t.Error(`An invalid secret should return an error`)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	counter, valid := Validate(rfcSecret, "081804", now, 0)
+	if !valid {
+		t.Error(`The current code should be valid`)
+	}
+
+	if counter != 1111111109/30 {
+		t.Errorf(`Unexpected time step, got %d`, counter)
+	}
+
+	if _, valid := Validate(rfcSecret, "081 804", now, 0); !valid {
+		t.Error(`Spaces should be ignored`)
+	}
+
+	if _, valid := Validate(rfcSecret, "081804", now.Add(30*time.Second), 0); !valid {
+		t.Error(`The code of the previous period should be valid`)
+	}
+
+	if _, valid := Validate(rfcSecret, "081804", now.Add(90*time.Second), 0); valid {
+		t.Error(`An expired code should not be valid`)
+	}
+
+	if _, valid := Validate(rfcSecret, "000000", now, 0); valid {
+		t.Error(`A wrong code should not be valid`)
+	}
+
+	if _, valid := Validate(rfcSecret, "", now, 0); valid {
+		t.Error(`An empty code should not be valid`)
+	}
+}
+
+func TestValidateRejectsReplayedCode(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	counter, valid := Validate(rfcSecret, "081804", now, 0)
+	if !valid {
+		t.Fatal(`The current code should be valid`)
+	}
+
+	if _, valid := Validate(rfcSecret, "081804", now, counter); valid {
+		t.Error(`A code already used should not be valid`)
+	}
+
+	if _, valid := Validate(rfcSecret, "081804", now.Add(30*time.Second), counter); valid {
+		t.Error(`A code already used should not be valid during the next period`)
+	}
+
+	nextCode, err := Code(rfcSecret, now.Add(30*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, valid := Validate(rfcSecret, nextCode, now.Add(30*time.Second), counter); !valid {
+		t.Error(`The code of a later period should be valid`)
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret := GenerateSecret()
+	if len(secret) != 32 || strings.Contains(secret, "=") {
+		t.Errorf(`Unexpected secret %q`, secret)
+	}
+
+	if _, err := Code(secret, time.Now()); err != nil {
+		t.Errorf(`The generated secret should be usable: %v`, err)
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Miniflux", "john doe", "JBSWY3DPEHPK3PXP")
+	expected := "otpauth://totp/Miniflux:john%20doe?algorithm=SHA1&digits=6&issuer=Miniflux&period=30&secret=JBSWY3DPEHPK3PXP"
+	if uri != expected {
+		t.Errorf(`Unexpected URI, got %q instead of %q`, uri, expected)
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes := GenerateRecoveryCodes(10)
+	if len(codes) != 10 {
+		t.Fatalf(`Unexpected number of recovery codes, got %d`, len(codes))
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if len(code) != 9 || code[4] != '-' || seen[code] {
+			t.Errorf(`Unexpected recovery code %q`, code)
+		}
+		seen[code] = true
+
+		if NormalizeRecoveryCode(code) != code {
+			t.Errorf(`The recovery code %q should already be normalized`, code)
+		}
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	scenarios := map[string]string{
+		"abcd-efgh":   "abcd-efgh",
+		" ABCD-EFGH ": "abcd-efgh",
+		"abcdefgh":    "abcd-efgh",
+		"abcd efgh":   "abcd-efgh",
+		"abc":         "abc",
+	}
+
+	for input, expected := range scenarios {
+		if result := NormalizeRecoveryCode(input); result != expected {
+			t.Errorf(`Unexpected result for %q, got %q instead of %q`, input, result, expected)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing // import "miniflux.app/v2/internal/tracing"
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/version"
+)
+
+const (
+	queueSize     = 2048
+	maxBatchSize  = 512
+	flushInterval = 5 * time.Second
+	exportTimeout = 10 * time.Second
+)
+
+var defaultExporter *exporter
+
+type exporter struct {
+	endpoint    string
+	serviceName string
+	headers     map[string]string
+	client      *http.Client
+	queue       chan *Span
+	flush       chan chan struct{}
+}
+
+// Initialize starts the exporter sending spans to the OTLP/HTTP endpoint, for example "http://localhost:4318".
+// Headers are "key=value" pairs added to each export request, typically for authentication.
+func Initialize(endpoint, serviceName string, headers []string) {
+	e := &exporter{
+		endpoint:    strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		serviceName: serviceName,
+		headers:     make(map[string]string),
+		client:      &http.Client{Timeout: exportTimeout},
+		queue:       make(chan *Span, queueSize),
+		flush:       make(chan chan struct{}),
+	}
+
+	for _, header := range headers {
+		if key, value, found := strings.Cut(header, "="); found {
+			e.headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	go e.run()
+	defaultExporter = e
+
+	slog.Info("OpenTelemetry tracing enabled",
+		slog.String("endpoint", e.endpoint),
+		slog.String("service_name", serviceName),
+	)
+}
+
+// Shutdown exports the pending spans.
+func Shutdown(ctx context.Context) {
+	if defaultExporter == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	select {
+	case defaultExporter.flush <- done:
+	case <-ctx.Done():
+		return
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+func (e *exporter) enqueue(span *Span) {
+	select {
+	case e.queue <- span:
+	default:
+		// Dropping spans is better than blocking the application when the collector is unavailable.
+		slog.Debug("Tracing queue is full, dropping span", slog.String("span_name", span.name))
+	}
+}
+
+func (e *exporter) run() {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var batch []*Span
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= maxBatchSize {
+				e.export(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				e.export(batch)
+				batch = nil
+			}
+		case done := <-e.flush:
+			for len(e.queue) > 0 {
+				batch = append(batch, <-e.queue)
+			}
+			if len(batch) > 0 {
+				e.export(batch)
+				batch = nil
+			}
+			close(done)
+		}
+	}
+}
+
+func (e *exporter) export(spans []*Span) {
+	body, err := json.Marshal(e.newPayload(spans))
+	if err != nil {
+		slog.Error("Unable to encode spans", slog.Any("error", err))
+		return
+	}
+
+	request, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		slog.Error("Unable to create tracing export request", slog.Any("error", err))
+		return
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := e.client.Do(request)
+	if err != nil {
+		slog.Warn("Unable to export spans", slog.String("endpoint", e.endpoint), slog.Any("error", err))
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		slog.Warn("Unable to export spans",
+			slog.String("endpoint", e.endpoint),
+			slog.Int("status_code", response.StatusCode),
+		)
+	}
+}
+
+// The types below follow the JSON mapping of the OTLP protobuf messages.
+type otlpPayload struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+func (e *exporter) newPayload(spans []*Span) *otlpPayload {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		span.mu.Lock()
+		otlpSpan := otlpSpan{
+			TraceID:           hex.EncodeToString(span.traceID[:]),
+			SpanID:            hex.EncodeToString(span.spanID[:]),
+			Name:              span.name,
+			Kind:              span.kind,
+			StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+			Attributes:        toOTLPAttributes(span.attributes),
+		}
+
+		if span.parentSpanID != [8]byte{} {
+			otlpSpan.ParentSpanID = hex.EncodeToString(span.parentSpanID[:])
+		}
+
+		if span.errorMessage != "" {
+			otlpSpan.Status = &otlpStatus{Code: 2, Message: span.errorMessage}
+		}
+		span.mu.Unlock()
+
+		otlpSpans = append(otlpSpans, otlpSpan)
+	}
+
+	return &otlpPayload{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: toOTLPAttributes([]Attribute{
+						String("service.name", e.serviceName),
+						String("service.version", version.Version),
+					}),
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: "miniflux.app/v2", Version: version.Version},
+						Spans: otlpSpans,
+					},
+				},
+			},
+		},
+	}
+}
+
+func toOTLPAttributes(attributes []Attribute) []otlpAttribute {
+	otlpAttributes := make([]otlpAttribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		var value map[string]any
+		switch v := attribute.Value.(type) {
+		case string:
+			value = map[string]any{"stringValue": v}
+		case int64:
+			value = map[string]any{"intValue": strconv.FormatInt(v, 10)}
+		case bool:
+			value = map[string]any{"boolValue": v}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(v)}
+		}
+		otlpAttributes = append(otlpAttributes, otlpAttribute{Key: attribute.Key, Value: value})
+	}
+	return otlpAttributes
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing records OpenTelemetry compatible spans and exports them with the OTLP/HTTP JSON protocol.
+package tracing // import "miniflux.app/v2/internal/tracing"
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Span kinds as defined by the OpenTelemetry protocol.
+const (
+	KindInternal = 1
+	KindServer   = 2
+	KindClient   = 3
+)
+
+type contextKey struct{}
+
+// Attribute is a key/value pair attached to a span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute { return Attribute{key, value} }
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attribute { return Attribute{key, int64(value)} }
+
+// Int64 returns an integer attribute.
+func Int64(key string, value int64) Attribute { return Attribute{key, value} }
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute { return Attribute{key, value} }
+
+// Span represents a unit of work. A nil span is valid and records nothing, which is the case when tracing is disabled.
+type Span struct {
+	mu           sync.Mutex
+	traceID      [16]byte
+	spanID       [8]byte
+	parentSpanID [8]byte
+	name         string
+	kind         int
+	start        time.Time
+	end          time.Time
+	attributes   []Attribute
+	errorMessage string
+	ended        bool
+}
+
+// Start creates a span, the parent is the span stored in the context if any.
+func Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	return StartWithKind(ctx, name, KindInternal, attributes...)
+}
+
+// StartWithKind creates a span of the given kind.
+func StartWithKind(ctx context.Context, name string, kind int, attributes ...Attribute) (context.Context, *Span) {
+	if defaultExporter == nil {
+		return ctx, nil
+	}
+
+	span := &Span{
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: attributes,
+	}
+
+	if parent := FromContext(ctx); parent != nil {
+		span.traceID = parent.traceID
+		span.parentSpanID = parent.spanID
+	} else if remote, ok := ctx.Value(remoteContextKey{}).(*Span); ok {
+		span.traceID = remote.traceID
+		span.parentSpanID = remote.spanID
+	} else {
+		rand.Read(span.traceID[:])
+	}
+	rand.Read(span.spanID[:])
+
+	return context.WithValue(ctx, contextKey{}, span), span
+}
+
+// FromContext returns the span stored in the context.
+func FromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(contextKey{}).(*Span)
+	return span
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes = append(s.attributes, attributes...)
+}
+
+// RecordError marks the span as failed.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errorMessage = err.Error()
+}
+
+// End finishes the span and queues it for export.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	if defaultExporter != nil {
+		defaultExporter.enqueue(s)
+	}
+}
+
+// TraceID returns the hexadecimal trace identifier.
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return hex.EncodeToString(s.traceID[:])
+}
+
+type remoteContextKey struct{}
+
+// ContextWithTraceParent returns a context carrying the remote parent described by a W3C traceparent header.
+// The context is returned unchanged when the header is missing or invalid.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	remote, ok := parseTraceParent(traceParent)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteContextKey{}, remote)
+}
+
+// TraceParent returns the W3C traceparent header value of the span.
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return ""
+	}
+	return "00-" + hex.EncodeToString(s.traceID[:]) + "-" + hex.EncodeToString(s.spanID[:]) + "-01"
+}
+
+func parseTraceParent(value string) (*Span, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return nil, false
+	}
+
+	if parts[0] == "00" && len(parts) != 4 {
+		return nil, false
+	}
+
+	var span Span
+	if _, err := hex.Decode(span.traceID[:], []byte(parts[1])); err != nil {
+		return nil, false
+	}
+
+	if _, err := hex.Decode(span.spanID[:], []byte(parts[2])); err != nil {
+		return nil, false
+	}
+
+	if span.traceID == [16]byte{} || span.spanID == [8]byte{} {
+		return nil, false
+	}
+
+	return &span, true
+}
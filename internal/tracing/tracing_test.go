@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing // import "miniflux.app/v2/internal/tracing"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSpanIsNilWhenTracingIsDisabled(t *testing.T) {
+	ctx, span := Start(context.Background(), "test")
+	if span != nil {
+		t.Fatal(`The span should be nil when no exporter is configured`)
+	}
+
+	if FromContext(ctx) != nil {
+		t.Error(`The context should not contain a span`)
+	}
+
+	// Calling methods on a nil span must not panic.
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("error"))
+	span.End()
+
+	if span.TraceID() != "" || span.TraceParent() != "" {
+		t.Error(`A nil span should not have identifiers`)
+	}
+}
+
+func TestParseTraceParent(t *testing.T) {
+	scenarios := map[string]bool{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01":       true,
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra": true,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra": false,
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01":       false,
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01":       false,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01":       false,
+		"00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01":       false,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7":          false,
+		"": false,
+	}
+
+	for input, expected := range scenarios {
+		_, ok := parseTraceParent(input)
+		if ok != expected {
+			t.Errorf(`Unexpected result for %q: got %v instead of %v`, input, ok, expected)
+		}
+	}
+}
+
+func TestExportSpans(t *testing.T) {
+	payloads := make(chan *otlpPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf(`Unexpected path: %q`, r.URL.Path)
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf(`Unexpected Authorization header: %q`, r.Header.Get("Authorization"))
+		}
+
+		var payload otlpPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf(`Unable to decode payload: %v`, err)
+		}
+		payloads <- &payload
+	}))
+	defer server.Close()
+
+	Initialize(server.URL+"/", "miniflux-test", []string{"Authorization=Bearer secret"})
+	defer func() { defaultExporter = nil }()
+
+	ctx := ContextWithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx, parent := StartWithKind(ctx, "parent", KindServer, String("http.request.method", "GET"))
+	_, child := Start(ctx, "child", Int64("feed_id", 42))
+	child.RecordError(errors.New("unable to fetch feed"))
+	child.End()
+	parent.End()
+
+	if parent.TraceID() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf(`The trace ID should be propagated from the traceparent header, got %q`, parent.TraceID())
+	}
+
+	Shutdown(context.Background())
+
+	payload := <-payloads
+	if len(payload.ResourceSpans) != 1 || len(payload.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf(`Unexpected payload structure: %+v`, payload)
+	}
+
+	resourceAttributes := payload.ResourceSpans[0].Resource.Attributes
+	if len(resourceAttributes) == 0 || resourceAttributes[0].Key != "service.name" || resourceAttributes[0].Value["stringValue"] != "miniflux-test" {
+		t.Errorf(`Unexpected resource attributes: %+v`, resourceAttributes)
+	}
+
+	spans := payload.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf(`Expected 2 spans, got %d`, len(spans))
+	}
+
+	childSpan, parentSpan := spans[0], spans[1]
+	if parentSpan.ParentSpanID != "00f067aa0ba902b7" || parentSpan.Kind != KindServer {
+		t.Errorf(`Unexpected parent span: %+v`, parentSpan)
+	}
+
+	if childSpan.ParentSpanID != parentSpan.SpanID || childSpan.TraceID != parentSpan.TraceID {
+		t.Errorf(`The child span is not linked to its parent: %+v`, childSpan)
+	}
+
+	if childSpan.Status == nil || childSpan.Status.Code != 2 || childSpan.Status.Message != "unable to fetch feed" {
+		t.Errorf(`Unexpected child span status: %+v`, childSpan.Status)
+	}
+
+	if len(childSpan.Attributes) != 1 || childSpan.Attributes[0].Value["intValue"] != "42" {
+		t.Errorf(`Unexpected child span attributes: %+v`, childSpan.Attributes)
+	}
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "miniflux.app/v2/internal/translation"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"miniflux.app/v2/internal/urllib"
+	"miniflux.app/v2/internal/version"
+)
+
+const defaultDeepLAPIURL = "https://api-free.deepl.com"
+
+// DeepLClient translates the documents with the API of DeepL.
+type DeepLClient struct {
+	baseURL string
+	apiKey  string
+}
+
+// NewDeepLClient returns a DeepL client, the API of DeepL Free is used when the base URL is empty.
+func NewDeepLClient(baseURL, apiKey string) *DeepLClient {
+	if baseURL == "" {
+		baseURL = defaultDeepLAPIURL
+	}
+	return &DeepLClient{baseURL: baseURL, apiKey: apiKey}
+}
+
+// TranslateHTML implements the Translator interface, all the documents are sent in a single request.
+func (c *DeepLClient) TranslateHTML(documents []string, language string) ([]string, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("deepl: missing API key")
+	}
+
+	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.baseURL, "/v2/translate")
+	if err != nil {
+		return nil, fmt.Errorf("deepl: invalid API endpoint: %v", err)
+	}
+
+	requestBody, err := json.Marshal(&deepLRequest{
+		Text:           documents,
+		TargetLanguage: deepLLanguage(language),
+		TagHandling:    "html",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("deepl: unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, apiEndpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("deepl: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("Authorization", "DeepL-Auth-Key "+c.apiKey)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("deepl: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("deepl: unable to translate documents: url=%s status=%d", apiEndpoint, response.StatusCode)
+	}
+
+	var translationResponse deepLResponse
+	if err := json.NewDecoder(response.Body).Decode(&translationResponse); err != nil {
+		return nil, fmt.Errorf("deepl: unable to decode response: %v", err)
+	}
+
+	translatedDocuments := make([]string, 0, len(translationResponse.Translations))
+	for _, translation := range translationResponse.Translations {
+		translatedDocuments = append(translatedDocuments, translation.Text)
+	}
+
+	return translatedDocuments, nil
+}
+
+// deepLLanguage returns the DeepL code of the language, like "FR" for "fr_FR".
+func deepLLanguage(language string) string {
+	switch language {
+	case "en_US", "pt_BR":
+		return strings.ToUpper(strings.ReplaceAll(language, "_", "-"))
+	case "zh_CN":
+		return "ZH-HANS"
+	case "zh_TW":
+		return "ZH-HANT"
+	}
+
+	code, _, _ := strings.Cut(language, "_")
+	return strings.ToUpper(code)
+}
+
+type deepLRequest struct {
+	Text           []string `json:"text"`
+	TargetLanguage string   `json:"target_lang"`
+	TagHandling    string   `json:"tag_handling"`
+}
+
+type deepLResponse struct {
+	Translations []struct {
+		DetectedSourceLanguage string `json:"detected_source_language"`
+		Text                   string `json:"text"`
+	} `json:"translations"`
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "miniflux.app/v2/internal/translation"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"miniflux.app/v2/internal/urllib"
+	"miniflux.app/v2/internal/version"
+)
+
+// LibreTranslateClient translates the documents with the API of a LibreTranslate server.
+type LibreTranslateClient struct {
+	baseURL string
+	apiKey  string
+}
+
+// NewLibreTranslateClient returns a LibreTranslate client, the API key is only required by some servers.
+func NewLibreTranslateClient(baseURL, apiKey string) *LibreTranslateClient {
+	return &LibreTranslateClient{baseURL: baseURL, apiKey: apiKey}
+}
+
+// TranslateHTML implements the Translator interface, a request is sent for each document.
+func (c *LibreTranslateClient) TranslateHTML(documents []string, language string) ([]string, error) {
+	if c.baseURL == "" {
+		return nil, fmt.Errorf("libretranslate: missing base URL")
+	}
+
+	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.baseURL, "/translate")
+	if err != nil {
+		return nil, fmt.Errorf("libretranslate: invalid API endpoint: %v", err)
+	}
+
+	translatedDocuments := make([]string, 0, len(documents))
+	for _, document := range documents {
+		if strings.TrimSpace(document) == "" {
+			translatedDocuments = append(translatedDocuments, document)
+			continue
+		}
+
+		translatedDocument, err := c.translate(apiEndpoint, document, libreTranslateLanguage(language))
+		if err != nil {
+			return nil, err
+		}
+
+		translatedDocuments = append(translatedDocuments, translatedDocument)
+	}
+
+	return translatedDocuments, nil
+}
+
+func (c *LibreTranslateClient) translate(apiEndpoint, document, targetLanguage string) (string, error) {
+	requestBody, err := json.Marshal(&libreTranslateRequest{
+		Query:          document,
+		SourceLanguage: "auto",
+		TargetLanguage: targetLanguage,
+		Format:         "html",
+		APIKey:         c.apiKey,
+	})
+	if err != nil {
+		return "", fmt.Errorf("libretranslate: unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, apiEndpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return "", fmt.Errorf("libretranslate: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("libretranslate: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	var translationResponse libreTranslateResponse
+	if err := json.NewDecoder(response.Body).Decode(&translationResponse); err != nil && response.StatusCode < 400 {
+		return "", fmt.Errorf("libretranslate: unable to decode response: %v", err)
+	}
+
+	if response.StatusCode >= 400 {
+		return "", fmt.Errorf("libretranslate: unable to translate document: url=%s status=%d error=%q", apiEndpoint, response.StatusCode, translationResponse.Error)
+	}
+
+	return translationResponse.TranslatedText, nil
+}
+
+// libreTranslateLanguage returns the LibreTranslate code of the language, like "fr" for "fr_FR".
+func libreTranslateLanguage(language string) string {
+	switch language {
+	case "zh_TW":
+		return "zt"
+	case "pt_BR":
+		return "pt"
+	}
+
+	code, _, _ := strings.Cut(language, "_")
+	return code
+}
+
+type libreTranslateRequest struct {
+	Query          string `json:"q"`
+	SourceLanguage string `json:"source"`
+	TargetLanguage string `json:"target"`
+	Format         string `json:"format"`
+	APIKey         string `json:"api_key,omitempty"`
+}
+
+type libreTranslateResponse struct {
+	TranslatedText string `json:"translatedText"`
+	Error          string `json:"error"`
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "miniflux.app/v2/internal/translation"
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/sanitizer"
+)
+
+const defaultClientTimeout = 30 * time.Second
+
+// Translator translates HTML documents to the language of a user, like "fr_FR".
+type Translator interface {
+	TranslateHTML(documents []string, language string) ([]string, error)
+}
+
+// NewTranslator returns the translator of the service configured on the instance, nil is returned when the
+// translation is disabled.
+func NewTranslator() Translator {
+	switch config.Opts.TranslationProvider() {
+	case "libretranslate":
+		return NewLibreTranslateClient(config.Opts.TranslationAPIURL(), config.Opts.TranslationAPIKey())
+	case "deepl":
+		return NewDeepLClient(config.Opts.TranslationAPIURL(), config.Opts.TranslationAPIKey())
+	default:
+		return nil
+	}
+}
+
+// TranslateEntry stores the translation of the entry title and content in the language of the user.
+// The translated content is sanitized, the services return HTML documents.
+func TranslateEntry(translator Translator, entry *model.Entry, language string) error {
+	documents, err := translator.TranslateHTML([]string{html.EscapeString(entry.Title), entry.Content}, language)
+	if err != nil {
+		return err
+	}
+
+	if len(documents) != 2 {
+		return fmt.Errorf("translation: unexpected number of translated documents: %d", len(documents))
+	}
+
+	entry.TranslatedTitle = strings.TrimSpace(sanitizer.StripTags(documents[0]))
+	entry.TranslatedContent = sanitizer.Sanitize(entry.URL, documents[1])
+	entry.TranslationLanguage = language
+
+	return nil
+}
+
+// ShouldTranslateEntry returns true if the entry is not written in the language of the user and has not been
+// translated to it yet. The entries without language are translated.
+func ShouldTranslateEntry(entry *model.Entry, language string) bool {
+	if entry.TranslationLanguage == language && entry.TranslatedContent != "" {
+		return false
+	}
+
+	entryLanguage, _, _ := strings.Cut(strings.ReplaceAll(strings.ToLower(entry.Language), "_", "-"), "-")
+	userLanguage, _, _ := strings.Cut(strings.ToLower(language), "_")
+
+	return entryLanguage == "" || entryLanguage != userLanguage
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "miniflux.app/v2/internal/translation"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestLibreTranslateClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/translate" {
+			t.Errorf(`Unexpected path: %q`, r.URL.Path)
+		}
+
+		var request libreTranslateRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf(`Unable to decode the request: %v`, err)
+		}
+
+		if request.TargetLanguage != "fr" || request.SourceLanguage != "auto" || request.Format != "html" || request.APIKey != "secret" {
+			t.Errorf(`Unexpected request: %+v`, request)
+		}
+
+		json.NewEncoder(w).Encode(&libreTranslateResponse{TranslatedText: strings.ToUpper(request.Query)})
+	}))
+	defer server.Close()
+
+	documents, err := NewLibreTranslateClient(server.URL, "secret").TranslateHTML([]string{"title", "", "<p>content</p>"}, "fr_FR")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if len(documents) != 3 || documents[0] != "TITLE" || documents[1] != "" || documents[2] != "<P>CONTENT</P>" {
+		t.Errorf(`Unexpected translated documents: %q`, documents)
+	}
+}
+
+func TestLibreTranslateClientWithError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(&libreTranslateResponse{Error: "unsupported language"})
+	}))
+	defer server.Close()
+
+	_, err := NewLibreTranslateClient(server.URL, "").TranslateHTML([]string{"title"}, "hi_IN")
+	if err == nil || !strings.Contains(err.Error(), "unsupported language") {
+		t.Errorf(`The error of the server should be returned, got %v`, err)
+	}
+}
+
+func TestDeepLClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/translate" {
+			t.Errorf(`Unexpected path: %q`, r.URL.Path)
+		}
+
+		if authorization := r.Header.Get("Authorization"); authorization != "DeepL-Auth-Key secret" {
+			t.Errorf(`Unexpected authorization header: %q`, authorization)
+		}
+
+		var request deepLRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf(`Unable to decode the request: %v`, err)
+		}
+
+		if request.TargetLanguage != "PT-BR" || request.TagHandling != "html" {
+			t.Errorf(`Unexpected request: %+v`, request)
+		}
+
+		var response deepLResponse
+		for _, text := range request.Text {
+			response.Translations = append(response.Translations, struct {
+				DetectedSourceLanguage string `json:"detected_source_language"`
+				Text                   string `json:"text"`
+			}{"EN", strings.ToUpper(text)})
+		}
+		json.NewEncoder(w).Encode(&response)
+	}))
+	defer server.Close()
+
+	documents, err := NewDeepLClient(server.URL, "secret").TranslateHTML([]string{"title", "<p>content</p>"}, "pt_BR")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if len(documents) != 2 || documents[0] != "TITLE" || documents[1] != "<P>CONTENT</P>" {
+		t.Errorf(`Unexpected translated documents: %q`, documents)
+	}
+}
+
+func TestDeepLClientWithoutAPIKey(t *testing.T) {
+	if _, err := NewDeepLClient("", "").TranslateHTML([]string{"title"}, "fr_FR"); err == nil {
+		t.Error(`An error should be returned without API key`)
+	}
+}
+
+func TestLanguageCodes(t *testing.T) {
+	scenarios := []struct {
+		language       string
+		libreTranslate string
+		deepL          string
+	}{
+		{"fr_FR", "fr", "FR"},
+		{"en_US", "en", "EN-US"},
+		{"pt_BR", "pt", "PT-BR"},
+		{"zh_CN", "zh", "ZH-HANS"},
+		{"zh_TW", "zt", "ZH-HANT"},
+	}
+
+	for _, scenario := range scenarios {
+		if code := libreTranslateLanguage(scenario.language); code != scenario.libreTranslate {
+			t.Errorf(`Unexpected LibreTranslate code for %q: got %q instead of %q`, scenario.language, code, scenario.libreTranslate)
+		}
+
+		if code := deepLLanguage(scenario.language); code != scenario.deepL {
+			t.Errorf(`Unexpected DeepL code for %q: got %q instead of %q`, scenario.language, code, scenario.deepL)
+		}
+	}
+}
+
+type fakeTranslator struct{}
+
+func (fakeTranslator) TranslateHTML(documents []string, language string) ([]string, error) {
+	return []string{"Le <b>titre</b> &amp; plus", `<p onclick="alert(1)">Le contenu</p><script>alert(1)</script>`}, nil
+}
+
+func TestTranslateEntry(t *testing.T) {
+	entry := &model.Entry{URL: "https://example.org/article", Title: "The title & more", Content: "<p>The content</p>"}
+
+	if err := TranslateEntry(fakeTranslator{}, entry, "fr_FR"); err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if entry.TranslatedTitle != "Le titre & plus" {
+		t.Errorf(`Unexpected translated title: %q`, entry.TranslatedTitle)
+	}
+
+	if entry.TranslatedContent != "<p>Le contenu</p>" {
+		t.Errorf(`The translated content should be sanitized, got %q`, entry.TranslatedContent)
+	}
+
+	if entry.TranslationLanguage != "fr_FR" || entry.Content != "<p>The content</p>" {
+		t.Errorf(`Unexpected entry: %+v`, entry)
+	}
+}
+
+func TestShouldTranslateEntry(t *testing.T) {
+	scenarios := []struct {
+		entry    *model.Entry
+		language string
+		expected bool
+	}{
+		{&model.Entry{Language: "en-US"}, "fr_FR", true},
+		{&model.Entry{Language: ""}, "fr_FR", true},
+		{&model.Entry{Language: "fr"}, "fr_FR", false},
+		{&model.Entry{Language: "FR-ca"}, "fr_FR", false},
+		{&model.Entry{Language: "en", TranslationLanguage: "fr_FR", TranslatedContent: "<p>Contenu</p>"}, "fr_FR", false},
+		{&model.Entry{Language: "en", TranslationLanguage: "de_DE", TranslatedContent: "<p>Inhalt</p>"}, "fr_FR", true},
+	}
+
+	for _, scenario := range scenarios {
+		if result := ShouldTranslateEntry(scenario.entry, scenario.language); result != scenario.expected {
+			t.Errorf(`Unexpected result for %+v and %q: got %v instead of %v`, scenario.entry, scenario.language, result, scenario.expected)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/ui/session"
+)
+
+func (h *handler) rotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID := request.RouteInt64Param(r, "keyID")
+	if err := h.store.RotateAPIKey(request.UserID(r), keyID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	sess.NewFlashMessage(locale.NewPrinter(request.UserLanguage(r)).Printf("alert.api_key_rotated"))
+	html.Redirect(w, r, route.Path(h.router, "apiKeys"))
+}
@@ -5,6 +5,7 @@ package ui // import "miniflux.app/v2/internal/ui"
 
 import (
 	"net/http"
+	"time"
 
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
@@ -28,6 +29,7 @@ func (h *handler) saveAPIKey(w http.ResponseWriter, r *http.Request) {
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("form", apiKeyForm)
+	view.Set("expirationDays", form.APIKeyExpirationDays)
 	view.Set("menu", "settings")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
@@ -46,6 +48,10 @@ func (h *handler) saveAPIKey(w http.ResponseWriter, r *http.Request) {
 	}
 
 	apiKey := model.NewAPIKey(user.ID, apiKeyForm.Description)
+	if apiKeyForm.ExpirationDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, apiKeyForm.ExpirationDays)
+		apiKey.ExpiresAt = &expiresAt
+	}
 	if err = h.store.CreateAPIKey(apiKey); err != nil {
 		html.ServerError(w, r, err)
 		return
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"crypto/md5"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) saveAppPassword(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	integration, err := h.store.Integration(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	appPasswordForm := form.NewAppPasswordForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", appPasswordForm)
+	view.Set("integration", integration)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	renderPage := func() {
+		appPasswords, err := h.store.AppPasswords(user.ID)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		view.Set("appPasswords", appPasswords)
+		html.OK(w, r, view.Render("app_passwords"))
+	}
+
+	if validationErr := appPasswordForm.Validate(); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(user.Language))
+		renderPage()
+		return
+	}
+
+	if h.store.AppPasswordExists(user.ID, appPasswordForm.Description) {
+		view.Set("errorMessage", locale.NewLocalizedError("error.app_password_already_exists").Translate(user.Language))
+		renderPage()
+		return
+	}
+
+	// The Fever API key and the Google Reader token are derived from the integration username,
+	// so the username must be defined before an app password can be generated.
+	secret := crypto.GenerateRandomStringHex(16)
+	var credential string
+	switch appPasswordForm.Scope {
+	case model.AppPasswordScopeFever:
+		if integration.FeverUsername == "" {
+			view.Set("errorMessage", locale.NewLocalizedError("error.app_password_missing_username").Translate(user.Language))
+			renderPage()
+			return
+		}
+		credential = fmt.Sprintf("%x", md5.Sum([]byte(integration.FeverUsername+":"+secret)))
+	case model.AppPasswordScopeGoogleReader:
+		if integration.GoogleReaderUsername == "" {
+			view.Set("errorMessage", locale.NewLocalizedError("error.app_password_missing_username").Translate(user.Language))
+			renderPage()
+			return
+		}
+		credential, err = crypto.HashPassword(secret)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	appPassword := &model.AppPassword{
+		UserID:      user.ID,
+		Description: appPasswordForm.Description,
+		Scope:       appPasswordForm.Scope,
+	}
+	if err := h.store.CreateAppPassword(appPassword, credential); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	slog.Info("App password created",
+		slog.Int64("user_id", user.ID),
+		slog.String("scope", appPassword.Scope),
+		slog.String("description", appPassword.Description),
+	)
+
+	// The secret is never stored in clear text, this is the only time it is displayed.
+	view.Set("form", &form.AppPasswordForm{})
+	view.Set("newAppPassword", appPassword)
+	view.Set("newAppPasswordSecret", secret)
+	renderPage()
+}
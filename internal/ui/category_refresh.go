@@ -57,7 +57,7 @@ func (h *handler) refreshCategory(w http.ResponseWriter, r *http.Request) int64
 			slog.Int("nb_jobs", len(jobs)),
 		)
 
-		go h.pool.Push(jobs)
+		go h.pool.PushPriority(jobs)
 
 		sess.SetLastForceRefresh()
 		sess.NewFlashMessage(printer.Print("alert.background_feed_refresh"))
@@ -47,8 +47,10 @@ func (h *handler) updateCategory(w http.ResponseWriter, r *http.Request) {
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
 
 	categoryRequest := &model.CategoryRequest{
-		Title:        categoryForm.Title,
-		HideGlobally: categoryForm.HideGlobally,
+		Title:                categoryForm.Title,
+		HideGlobally:         categoryForm.HideGlobally,
+		AutoArchiveAfterDays: &categoryForm.AutoArchiveAfterDays,
+		AutoArchiveStatus:    &categoryForm.AutoArchiveStatus,
 	}
 
 	if validationErr := validator.ValidateCategoryModification(h.store, loggedUser.ID, category.ID, categoryRequest); validationErr != nil {
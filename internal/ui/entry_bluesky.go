@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"log/slog"
+	"net/http"
+	"unicode/utf8"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration/bluesky"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showBlueskySharePage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	entry, err := h.findEntryToShare(user.ID, request.RouteInt64Param(r, "entryID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil || !h.store.HasBluesky(user.ID) {
+		html.NotFound(w, r)
+		return
+	}
+
+	postForm := &form.BlueskyPostForm{Text: entry.Title}
+	if utf8.RuneCountInString(postForm.Text) > bluesky.MaxPostLength {
+		postForm.Text = string([]rune(postForm.Text)[:bluesky.MaxPostLength])
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", postForm)
+	view.Set("entry", entry)
+	view.Set("maxPostLength", bluesky.MaxPostLength)
+	view.Set("menu", "unread")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("bluesky_share"))
+}
+
+func (h *handler) shareToBluesky(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	entry, err := h.findEntryToShare(user.ID, request.RouteInt64Param(r, "entryID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	userIntegrations, err := h.store.Integration(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !userIntegrations.BlueskyEnabled {
+		html.NotFound(w, r)
+		return
+	}
+
+	postForm := form.NewBlueskyPostForm(r)
+	printer := locale.NewPrinter(user.Language)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", postForm)
+	view.Set("entry", entry)
+	view.Set("maxPostLength", bluesky.MaxPostLength)
+	view.Set("menu", "unread")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	if utf8.RuneCountInString(postForm.Text) > bluesky.MaxPostLength {
+		view.Set("errorMessage", printer.Printf("error.bluesky_post_too_long", bluesky.MaxPostLength))
+		html.OK(w, r, view.Render("bluesky_share"))
+		return
+	}
+
+	client := bluesky.NewClient(userIntegrations.BlueskyPDSURL, userIntegrations.BlueskyHandle, userIntegrations.BlueskyAppPassword)
+	if err := client.PostEntry(entry, postForm.Text); err != nil {
+		slog.Error("Unable to share entry to Bluesky",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("entry_id", entry.ID),
+			slog.String("entry_url", entry.URL),
+			slog.Any("error", err),
+		)
+		view.Set("errorMessage", printer.Print("error.bluesky_post_failed"))
+		html.OK(w, r, view.Render("bluesky_share"))
+		return
+	}
+
+	sess.NewFlashMessage(printer.Print("alert.bluesky_post_published"))
+	html.Redirect(w, r, route.Path(h.router, "feedEntry", "feedID", entry.FeedID, "entryID", entry.ID))
+}
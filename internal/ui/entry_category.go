@@ -81,6 +81,9 @@ func (h *handler) showCategoryEntryPage(w http.ResponseWriter, r *http.Request)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("hasMastodon", h.store.HasMastodon(user.ID))
+	view.Set("hasBluesky", h.store.HasBluesky(user.ID))
+	view.Set("hasHypothesis", h.store.HasHypothesis(user.ID))
 
 	html.OK(w, r, view.Render("entry"))
 }
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration/hypothesis"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showHypothesisPage(w http.ResponseWriter, r *http.Request) {
+	user, entry, userIntegrations, ok := h.findEntryToAnnotate(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderHypothesisPage(w, r, user, entry, userIntegrations, &form.HypothesisAnnotationForm{}, "")
+}
+
+func (h *handler) annotateWithHypothesis(w http.ResponseWriter, r *http.Request) {
+	user, entry, userIntegrations, ok := h.findEntryToAnnotate(w, r)
+	if !ok {
+		return
+	}
+
+	annotationForm := form.NewHypothesisAnnotationForm(r)
+	printer := locale.NewPrinter(user.Language)
+
+	if annotationForm.Text == "" && annotationForm.Quote == "" {
+		h.renderHypothesisPage(w, r, user, entry, userIntegrations, annotationForm, printer.Print("error.hypothesis_empty_annotation"))
+		return
+	}
+
+	client := hypothesis.NewClient(userIntegrations.HypothesisAPIToken, userIntegrations.HypothesisGroup)
+	if err := client.CreateAnnotation(entry.URL, annotationForm.Quote, annotationForm.Text); err != nil {
+		slog.Error("Unable to publish annotation to Hypothesis",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("entry_id", entry.ID),
+			slog.String("entry_url", entry.URL),
+			slog.Any("error", err),
+		)
+		h.renderHypothesisPage(w, r, user, entry, userIntegrations, annotationForm, printer.Print("error.hypothesis_annotation_failed"))
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	sess.NewFlashMessage(printer.Print("alert.hypothesis_annotation_published"))
+	html.Redirect(w, r, route.Path(h.router, "hypothesisEntry", "entryID", entry.ID))
+}
+
+// findEntryToAnnotate writes the error response itself and returns false when the page cannot be displayed.
+func (h *handler) findEntryToAnnotate(w http.ResponseWriter, r *http.Request) (*model.User, *model.Entry, *model.Integration, bool) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return nil, nil, nil, false
+	}
+
+	entry, err := h.findEntryToShare(user.ID, request.RouteInt64Param(r, "entryID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return nil, nil, nil, false
+	}
+
+	if entry == nil {
+		html.NotFound(w, r)
+		return nil, nil, nil, false
+	}
+
+	userIntegrations, err := h.store.Integration(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return nil, nil, nil, false
+	}
+
+	if !userIntegrations.HypothesisEnabled {
+		html.NotFound(w, r)
+		return nil, nil, nil, false
+	}
+
+	return user, entry, userIntegrations, true
+}
+
+func (h *handler) renderHypothesisPage(w http.ResponseWriter, r *http.Request, user *model.User, entry *model.Entry, userIntegrations *model.Integration, annotationForm *form.HypothesisAnnotationForm, errorMessage string) {
+	var annotations []*hypothesis.Annotation
+	annotationsUnavailable := false
+	if userIntegrations.HypothesisShowAnnotations {
+		var err error
+		client := hypothesis.NewClient(userIntegrations.HypothesisAPIToken, userIntegrations.HypothesisGroup)
+		if annotations, err = client.Annotations(entry.URL); err != nil {
+			// The page remains usable to publish annotations.
+			slog.Warn("Unable to fetch Hypothesis annotations",
+				slog.Int64("user_id", user.ID),
+				slog.Int64("entry_id", entry.ID),
+				slog.String("entry_url", entry.URL),
+				slog.Any("error", err),
+			)
+			annotationsUnavailable = true
+		}
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", annotationForm)
+	view.Set("entry", entry)
+	view.Set("showAnnotations", userIntegrations.HypothesisShowAnnotations)
+	view.Set("annotations", annotations)
+	view.Set("annotationsUnavailable", annotationsUnavailable)
+	view.Set("errorMessage", errorMessage)
+	view.Set("menu", "unread")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("hypothesis_annotations"))
+}
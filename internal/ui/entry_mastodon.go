@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration/mastodon"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showMastodonSharePage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	entry, err := h.findEntryToShare(user.ID, request.RouteInt64Param(r, "entryID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	userIntegrations, err := h.store.Integration(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !userIntegrations.MastodonEnabled {
+		html.NotFound(w, r)
+		return
+	}
+
+	postForm := &form.MastodonPostForm{
+		Status:     entry.Title + "\n\n" + entry.URL,
+		Visibility: userIntegrations.MastodonVisibility,
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", postForm)
+	view.Set("entry", entry)
+	view.Set("menu", "unread")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("mastodon_share"))
+}
+
+func (h *handler) shareToMastodon(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	entry, err := h.findEntryToShare(user.ID, request.RouteInt64Param(r, "entryID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	userIntegrations, err := h.store.Integration(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !userIntegrations.MastodonEnabled {
+		html.NotFound(w, r)
+		return
+	}
+
+	postForm := form.NewMastodonPostForm(r)
+	printer := locale.NewPrinter(user.Language)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", postForm)
+	view.Set("entry", entry)
+	view.Set("menu", "unread")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	if postForm.Status == "" {
+		view.Set("errorMessage", printer.Print("error.mastodon_empty_post"))
+		html.OK(w, r, view.Render("mastodon_share"))
+		return
+	}
+
+	if !mastodon.IsValidVisibility(postForm.Visibility) {
+		postForm.Visibility = userIntegrations.MastodonVisibility
+	}
+
+	client := mastodon.NewClient(userIntegrations.MastodonURL, userIntegrations.MastodonAccessToken)
+	if err := client.PostStatus(postForm.Status, postForm.Visibility); err != nil {
+		slog.Error("Unable to share entry to Mastodon",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("entry_id", entry.ID),
+			slog.String("entry_url", entry.URL),
+			slog.Any("error", err),
+		)
+		view.Set("errorMessage", printer.Print("error.mastodon_post_failed"))
+		html.OK(w, r, view.Render("mastodon_share"))
+		return
+	}
+
+	sess.NewFlashMessage(printer.Print("alert.mastodon_post_published"))
+	html.Redirect(w, r, route.Path(h.router, "feedEntry", "feedID", entry.FeedID, "entryID", entry.ID))
+}
+
+func (h *handler) findEntryToShare(userID, entryID int64) (*model.Entry, error) {
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(entryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithEnclosures()
+	return builder.GetEntry()
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/mediaproxy"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/translation"
+)
+
+func (h *handler) translateEntry(w http.ResponseWriter, r *http.Request) {
+	loggedUserID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	translator := translation.NewTranslator()
+	if translator == nil {
+		json.BadRequest(w, r, errors.New("the translation is not configured on this instance"))
+		return
+	}
+
+	entryBuilder := h.store.NewEntryQueryBuilder(loggedUserID)
+	entryBuilder.WithEntryID(entryID)
+	entryBuilder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := entryBuilder.GetEntry()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	user, err := h.store.UserByID(loggedUserID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	// The stored translation is reused, the service is only called for a new language.
+	if entry.TranslationLanguage != user.Language || entry.TranslatedContent == "" {
+		if err := translation.TranslateEntry(translator, entry, user.Language); err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+
+		if err := h.store.UpdateEntryTranslation(entry); err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+	}
+
+	json.OK(w, r, map[string]string{"title": entry.TranslatedTitle, "content": mediaproxy.RewriteDocumentWithRelativeProxyURL(h.router, entry.TranslatedContent)})
+}
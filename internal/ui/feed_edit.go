@@ -49,6 +49,7 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		BlocklistRules:              feed.BlocklistRules,
 		KeeplistRules:               feed.KeeplistRules,
 		UrlRewriteRules:             feed.UrlRewriteRules,
+		PageMonitorSelector:         feed.PageMonitorSelector,
 		Crawler:                     feed.Crawler,
 		UserAgent:                   feed.UserAgent,
 		Cookie:                      feed.Cookie,
@@ -64,8 +65,22 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		CategoryHidden:              feed.Category.HideGlobally,
 		AppriseServiceURLs:          feed.AppriseServiceURLs,
 		DisableHTTP2:                feed.DisableHTTP2,
+		RelaxedParsing:              feed.RelaxedParsing,
+		FetchViaHeadlessBrowser:     feed.FetchViaHeadlessBrowser,
+		ContentExtractor:            feed.ContentExtractor,
+		RewriteScript:               feed.RewriteScript,
+		AutoTranslate:               feed.AutoTranslate,
 		NtfyEnabled:                 feed.NtfyEnabled,
 		NtfyPriority:                feed.NtfyPriority,
+		MatrixBotEnabled:            feed.MatrixBotEnabled,
+		PollingMinInterval:          feed.PollingMinInterval,
+		PollingMaxInterval:          feed.PollingMaxInterval,
+		RefreshInterval:             feed.RefreshInterval,
+		PollingWeekdays:             feed.PollingWeekdays,
+		PollingWindowStart:          feed.PollingWindowStart,
+		PollingWindowEnd:            feed.PollingWindowEnd,
+		AutoArchiveAfterDays:        feed.AutoArchiveAfterDays,
+		AutoArchiveStatus:           feed.AutoArchiveStatus,
 	}
 
 	sess := session.New(h.store, request.SessionID(r))
@@ -79,6 +94,10 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
 	view.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyConfigured())
+	view.Set("hasHeadlessBrowser", config.Opts.HasHeadlessBrowser())
+	view.Set("hasRewriteScripts", config.Opts.HasRewriteScripts())
+	view.Set("hasTranslation", config.Opts.HasTranslation())
+	view.Set("refreshIntervals", form.RefreshIntervalOptions(config.Opts.SchedulerFeedRefreshMinInterval(), feed.RefreshInterval))
 
 	html.OK(w, r, view.Render("edit_feed"))
 }
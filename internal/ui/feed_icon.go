@@ -25,11 +25,16 @@ func (h *handler) showIcon(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.New(w, r).WithCaching(icon.Hash, 72*time.Hour, func(b *response.Builder) {
-		b.WithHeader("Content-Security-Policy", `default-src 'self'`)
+	// The content of an icon never changes, a new icon is created when the website icon is modified.
+	response.New(w, r).WithCaching(icon.Hash, 365*24*time.Hour, func(b *response.Builder) {
+		b.WithHeader("Cache-Control", "public, max-age=31536000, immutable")
 		b.WithHeader("Content-Type", icon.MimeType)
 		b.WithBody(icon.Content)
-		if icon.MimeType != "image/svg+xml" {
+		if icon.MimeType == "image/svg+xml" {
+			// SVG icons are sanitized when downloaded, the policy prevents any script execution when opened directly.
+			b.WithHeader("Content-Security-Policy", `default-src 'none'; img-src data:; style-src 'unsafe-inline'; sandbox`)
+		} else {
+			b.WithHeader("Content-Security-Policy", `default-src 'self'`)
 			b.WithoutCompression()
 		}
 		b.Write()
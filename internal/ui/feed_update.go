@@ -4,13 +4,19 @@
 package ui // import "miniflux.app/v2/internal/ui"
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/ui/form"
 	"miniflux.app/v2/internal/ui/session"
 	"miniflux.app/v2/internal/ui/view"
@@ -44,6 +50,11 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 
 	feedForm := form.NewFeedForm(r)
 
+	// The rewrite script is not part of the form when the scripts are disabled, the stored one is kept.
+	if !config.Opts.HasRewriteScripts() {
+		feedForm.RewriteScript = feed.RewriteScript
+	}
+
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("form", feedForm)
@@ -54,16 +65,36 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
 	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
+	view.Set("refreshIntervals", form.RefreshIntervalOptions(config.Opts.SchedulerFeedRefreshMinInterval(), feed.RefreshInterval))
+	view.Set("hasHeadlessBrowser", config.Opts.HasHeadlessBrowser())
+	view.Set("hasRewriteScripts", config.Opts.HasRewriteScripts())
+	view.Set("hasTranslation", config.Opts.HasTranslation())
 
 	feedModificationRequest := &model.FeedModificationRequest{
-		FeedURL:         model.OptionalString(feedForm.FeedURL),
-		SiteURL:         model.OptionalString(feedForm.SiteURL),
-		Title:           model.OptionalString(feedForm.Title),
-		Description:     model.OptionalString(feedForm.Description),
-		CategoryID:      model.OptionalNumber(feedForm.CategoryID),
-		BlocklistRules:  model.OptionalString(feedForm.BlocklistRules),
-		KeeplistRules:   model.OptionalString(feedForm.KeeplistRules),
-		UrlRewriteRules: model.OptionalString(feedForm.UrlRewriteRules),
+		FeedURL:              model.OptionalString(feedForm.FeedURL),
+		SiteURL:              model.OptionalString(feedForm.SiteURL),
+		Title:                model.OptionalString(feedForm.Title),
+		Description:          model.OptionalString(feedForm.Description),
+		CategoryID:           model.OptionalNumber(feedForm.CategoryID),
+		BlocklistRules:       model.OptionalString(feedForm.BlocklistRules),
+		KeeplistRules:        model.OptionalString(feedForm.KeeplistRules),
+		RewriteRules:         &feedForm.RewriteRules,
+		UrlRewriteRules:      model.OptionalString(feedForm.UrlRewriteRules),
+		PageMonitorSelector:  model.OptionalString(feedForm.PageMonitorSelector),
+		PollingMinInterval:   model.OptionalNumber(feedForm.PollingMinInterval),
+		PollingMaxInterval:   model.OptionalNumber(feedForm.PollingMaxInterval),
+		RefreshInterval:      &feedForm.RefreshInterval,
+		PollingWeekdays:      feedForm.PollingWeekdays,
+		PollingWindowStart:   &feedForm.PollingWindowStart,
+		PollingWindowEnd:     &feedForm.PollingWindowEnd,
+		AutoArchiveAfterDays: &feedForm.AutoArchiveAfterDays,
+		AutoArchiveStatus:    &feedForm.AutoArchiveStatus,
+		ContentExtractor:     &feedForm.ContentExtractor,
+		IconURL:              model.OptionalString(feedForm.IconURL),
+	}
+
+	if config.Opts.HasRewriteScripts() {
+		feedModificationRequest.RewriteScript = &feedForm.RewriteScript
 	}
 
 	if validationErr := validator.ValidateFeedModification(h.store, loggedUser.ID, feed.ID, feedModificationRequest); validationErr != nil {
@@ -72,6 +103,17 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := h.updateFeedIcon(r, feedForm, feed); err != nil {
+		slog.Warn("Unable to update feed icon",
+			slog.Int64("user_id", loggedUser.ID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+		view.Set("errorMessage", locale.NewLocalizedError("error.unable_to_set_custom_icon").Translate(loggedUser.Language))
+		html.OK(w, r, view.Render("edit_feed"))
+		return
+	}
+
 	err = h.store.UpdateFeed(feedForm.Merge(feed))
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -80,3 +122,34 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 
 	html.Redirect(w, r, route.Path(h.router, "feedEntries", "feedID", feed.ID))
 }
+
+// updateFeedIcon replaces the feed icon by the uploaded image or the image available at the given URL.
+func (h *handler) updateFeedIcon(r *http.Request, feedForm *form.FeedForm, feed *model.Feed) error {
+	if feedForm.ResetIcon {
+		return feedHandler.SetCustomFeedIcon(h.store, feed.UserID, feed.ID, "")
+	}
+
+	file, fileHeader, err := r.FormFile("icon_file")
+	if errors.Is(err, http.ErrMissingFile) {
+		if feedForm.IconURL == "" {
+			return nil
+		}
+		return feedHandler.SetCustomFeedIcon(h.store, feed.UserID, feed.ID, feedForm.IconURL)
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	maxSize := config.Opts.IconMaxSize()
+	content, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return err
+	}
+
+	if int64(len(content)) > maxSize {
+		return fmt.Errorf("the icon file %q is too large", fileHeader.Filename)
+	}
+
+	return feedHandler.UploadCustomFeedIcon(h.store, feed.ID, content, fileHeader.Header.Get("Content-Type"))
+}
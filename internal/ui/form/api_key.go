@@ -5,13 +5,18 @@ package form // import "miniflux.app/v2/internal/ui/form"
 
 import (
 	"net/http"
+	"strconv"
 
 	"miniflux.app/v2/internal/locale"
 )
 
+// APIKeyExpirationDays lists the lifetimes proposed for new API keys, 0 means the key never expires.
+var APIKeyExpirationDays = []int{0, 30, 90, 180, 365}
+
 // APIKeyForm represents the API Key form.
 type APIKeyForm struct {
-	Description string
+	Description    string
+	ExpirationDays int
 }
 
 // Validate makes sure the form values are valid.
@@ -20,12 +25,22 @@ func (a APIKeyForm) Validate() *locale.LocalizedError {
 		return locale.NewLocalizedError("error.fields_mandatory")
 	}
 
+	if a.ExpirationDays < 0 {
+		return locale.NewLocalizedError("error.api_key_invalid_expiration")
+	}
+
 	return nil
 }
 
 // NewAPIKeyForm returns a new APIKeyForm.
 func NewAPIKeyForm(r *http.Request) *APIKeyForm {
+	expirationDays, err := strconv.Atoi(r.FormValue("expiration_days"))
+	if err != nil {
+		expirationDays = 0
+	}
+
 	return &APIKeyForm{
-		Description: r.FormValue("description"),
+		Description:    r.FormValue("description"),
+		ExpirationDays: expirationDays,
 	}
 }
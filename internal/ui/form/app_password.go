@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+// AppPasswordForm represents the app password form.
+type AppPasswordForm struct {
+	Description string
+	Scope       string
+}
+
+// Validate makes sure the form values are valid.
+func (a AppPasswordForm) Validate() *locale.LocalizedError {
+	if a.Description == "" || a.Scope == "" {
+		return locale.NewLocalizedError("error.fields_mandatory")
+	}
+
+	switch a.Scope {
+	case model.AppPasswordScopeFever, model.AppPasswordScopeGoogleReader:
+	default:
+		return locale.NewLocalizedError("error.app_password_invalid_scope")
+	}
+
+	return nil
+}
+
+// NewAppPasswordForm returns a new AppPasswordForm.
+func NewAppPasswordForm(r *http.Request) *AppPasswordForm {
+	return &AppPasswordForm{
+		Description: r.FormValue("description"),
+		Scope:       r.FormValue("scope"),
+	}
+}
@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strings"
+)
+
+// BlueskyPostForm represents a post shared to Bluesky.
+type BlueskyPostForm struct {
+	Text string
+}
+
+// NewBlueskyPostForm returns a new BlueskyPostForm.
+func NewBlueskyPostForm(r *http.Request) *BlueskyPostForm {
+	return &BlueskyPostForm{
+		Text: strings.TrimSpace(r.FormValue("text")),
+	}
+}
@@ -5,18 +5,28 @@ package form // import "miniflux.app/v2/internal/ui/form"
 
 import (
 	"net/http"
+	"strconv"
 )
 
 // CategoryForm represents a feed form in the UI
 type CategoryForm struct {
-	Title        string
-	HideGlobally string
+	Title                string
+	HideGlobally         string
+	AutoArchiveAfterDays int
+	AutoArchiveStatus    string
 }
 
 // NewCategoryForm returns a new CategoryForm.
 func NewCategoryForm(r *http.Request) *CategoryForm {
+	autoArchiveAfterDays, err := strconv.Atoi(r.FormValue("auto_archive_after_days"))
+	if err != nil {
+		autoArchiveAfterDays = 0
+	}
+
 	return &CategoryForm{
-		Title:        r.FormValue("title"),
-		HideGlobally: r.FormValue("hide_globally"),
+		Title:                r.FormValue("title"),
+		HideGlobally:         r.FormValue("hide_globally"),
+		AutoArchiveAfterDays: autoArchiveAfterDays,
+		AutoArchiveStatus:    r.FormValue("auto_archive_status"),
 	}
 }
@@ -5,7 +5,9 @@ package form // import "miniflux.app/v2/internal/ui/form"
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
 	"miniflux.app/v2/internal/model"
 )
@@ -36,11 +38,74 @@ type FeedForm struct {
 	CategoryHidden              bool // Category has "hide_globally"
 	AppriseServiceURLs          string
 	DisableHTTP2                bool
+	RelaxedParsing              bool
+	FetchViaHeadlessBrowser     bool
+	ContentExtractor            string
+	RewriteScript               string
+	AutoTranslate               bool
 	NtfyEnabled                 bool
 	NtfyPriority                int
+	MatrixBotEnabled            bool
+	PollingMinInterval          int
+	PollingMaxInterval          int
+	RefreshInterval             int
+	PollingWeekdays             []int64
+	PollingWindowStart          string
+	PollingWindowEnd            string
+	AutoArchiveAfterDays        int
+	AutoArchiveStatus           string
+	IconURL                     string
+	ResetIcon                   bool
+	PageMonitorSelector         string
+}
+
+// HasPollingWeekday returns true if the feed can be refreshed on the given ISO day of the week.
+func (f FeedForm) HasPollingWeekday(weekday int64) bool {
+	return slices.Contains(f.PollingWeekdays, weekday)
+}
+
+// RefreshIntervalOption is a refresh interval proposed in the feed form.
+type RefreshIntervalOption struct {
+	Minutes  int
+	LabelKey string
+}
+
+var refreshIntervalPresets = []RefreshIntervalOption{
+	{Minutes: 15, LabelKey: "form.feed.refresh_interval.every_15_minutes"},
+	{Minutes: 30, LabelKey: "form.feed.refresh_interval.every_30_minutes"},
+	{Minutes: 60, LabelKey: "form.feed.refresh_interval.hourly"},
+	{Minutes: 6 * 60, LabelKey: "form.feed.refresh_interval.every_6_hours"},
+	{Minutes: 12 * 60, LabelKey: "form.feed.refresh_interval.every_12_hours"},
+	{Minutes: 24 * 60, LabelKey: "form.feed.refresh_interval.daily"},
+	{Minutes: 7 * 24 * 60, LabelKey: "form.feed.refresh_interval.weekly"},
+}
+
+// RefreshIntervalOptions returns the presets allowed by the minimum interval.
+// The current interval is always included, even if it was set to a custom value with the API.
+func RefreshIntervalOptions(minInterval, currentInterval int) []RefreshIntervalOption {
+	options := []RefreshIntervalOption{{Minutes: 0, LabelKey: "form.feed.refresh_interval.scheduler"}}
+	for _, option := range refreshIntervalPresets {
+		if option.Minutes >= minInterval || option.Minutes == currentInterval {
+			options = append(options, option)
+		}
+	}
+
+	hasCurrentInterval := slices.ContainsFunc(options, func(option RefreshIntervalOption) bool {
+		return option.Minutes == currentInterval
+	})
+
+	if !hasCurrentInterval {
+		options = append(options, RefreshIntervalOption{Minutes: currentInterval, LabelKey: "form.feed.refresh_interval.custom"})
+		slices.SortFunc(options, func(a, b RefreshIntervalOption) int {
+			return a.Minutes - b.Minutes
+		})
+	}
+
+	return options
 }
 
 // Merge updates the fields of the given feed.
+// The rewrite script is always copied, the caller must keep the stored script when the field is not shown.
 func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.Category.ID = f.CategoryID
 	feed.Title = f.Title
@@ -67,8 +132,23 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.HideGlobally = f.HideGlobally
 	feed.AppriseServiceURLs = f.AppriseServiceURLs
 	feed.DisableHTTP2 = f.DisableHTTP2
+	feed.RelaxedParsing = f.RelaxedParsing
+	feed.FetchViaHeadlessBrowser = f.FetchViaHeadlessBrowser
+	feed.ContentExtractor = f.ContentExtractor
+	feed.RewriteScript = f.RewriteScript
+	feed.AutoTranslate = f.AutoTranslate
 	feed.NtfyEnabled = f.NtfyEnabled
 	feed.NtfyPriority = f.NtfyPriority
+	feed.MatrixBotEnabled = f.MatrixBotEnabled
+	feed.PollingMinInterval = f.PollingMinInterval
+	feed.PollingMaxInterval = f.PollingMaxInterval
+	feed.RefreshInterval = f.RefreshInterval
+	feed.PollingWeekdays = f.PollingWeekdays
+	feed.PollingWindowStart = f.PollingWindowStart
+	feed.PollingWindowEnd = f.PollingWindowEnd
+	feed.AutoArchiveAfterDays = f.AutoArchiveAfterDays
+	feed.AutoArchiveStatus = f.AutoArchiveStatus
+	feed.PageMonitorSelector = f.PageMonitorSelector
 	return feed
 }
 
@@ -82,6 +162,28 @@ func NewFeedForm(r *http.Request) *FeedForm {
 	if err != nil {
 		ntfyPriority = 0
 	}
+	pollingMinInterval, err := strconv.Atoi(r.FormValue("polling_min_interval"))
+	if err != nil || pollingMinInterval < 0 {
+		pollingMinInterval = 0
+	}
+	pollingMaxInterval, err := strconv.Atoi(r.FormValue("polling_max_interval"))
+	if err != nil || pollingMaxInterval < 0 {
+		pollingMaxInterval = 0
+	}
+	refreshInterval, err := strconv.Atoi(r.FormValue("refresh_interval"))
+	if err != nil {
+		refreshInterval = 0
+	}
+	autoArchiveAfterDays, err := strconv.Atoi(r.FormValue("auto_archive_after_days"))
+	if err != nil {
+		autoArchiveAfterDays = 0
+	}
+	pollingWeekdays := make([]int64, 0, 7)
+	for _, value := range r.Form["polling_weekdays"] {
+		if weekday, err := strconv.ParseInt(value, 10, 64); err == nil {
+			pollingWeekdays = append(pollingWeekdays, weekday)
+		}
+	}
 	return &FeedForm{
 		FeedURL:                     r.FormValue("feed_url"),
 		SiteURL:                     r.FormValue("site_url"),
@@ -94,6 +196,7 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		BlocklistRules:              r.FormValue("blocklist_rules"),
 		KeeplistRules:               r.FormValue("keeplist_rules"),
 		UrlRewriteRules:             r.FormValue("urlrewrite_rules"),
+		PageMonitorSelector:         strings.TrimSpace(r.FormValue("page_monitor_selector")),
 		Crawler:                     r.FormValue("crawler") == "1",
 		CategoryID:                  int64(categoryID),
 		Username:                    r.FormValue("feed_username"),
@@ -106,7 +209,23 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		HideGlobally:                r.FormValue("hide_globally") == "1",
 		AppriseServiceURLs:          r.FormValue("apprise_service_urls"),
 		DisableHTTP2:                r.FormValue("disable_http2") == "1",
+		RelaxedParsing:              r.FormValue("relaxed_parsing") == "1",
+		FetchViaHeadlessBrowser:     r.FormValue("fetch_via_headless_browser") == "1",
+		ContentExtractor:            r.FormValue("content_extractor"),
+		RewriteScript:               r.FormValue("rewrite_script"),
+		AutoTranslate:               r.FormValue("auto_translate") == "1",
 		NtfyEnabled:                 r.FormValue("ntfy_enabled") == "1",
 		NtfyPriority:                ntfyPriority,
+		MatrixBotEnabled:            r.FormValue("matrix_bot_enabled") == "1",
+		PollingMinInterval:          pollingMinInterval,
+		PollingMaxInterval:          pollingMaxInterval,
+		RefreshInterval:             refreshInterval,
+		PollingWeekdays:             pollingWeekdays,
+		PollingWindowStart:          r.FormValue("polling_window_start"),
+		PollingWindowEnd:            r.FormValue("polling_window_end"),
+		AutoArchiveAfterDays:        autoArchiveAfterDays,
+		AutoArchiveStatus:           r.FormValue("auto_archive_status"),
+		IconURL:                     r.FormValue("icon_url"),
+		ResetIcon:                   r.FormValue("reset_icon") == "1",
 	}
 }
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"testing"
+)
+
+func TestRefreshIntervalOptionsWithMinimum(t *testing.T) {
+	options := RefreshIntervalOptions(60, 0)
+
+	if options[0].Minutes != 0 {
+		t.Errorf(`The first option should let the scheduler decide, got %d`, options[0].Minutes)
+	}
+
+	for _, option := range options[1:] {
+		if option.Minutes < 60 {
+			t.Errorf(`The interval %d is below the minimum`, option.Minutes)
+		}
+	}
+}
+
+func TestRefreshIntervalOptionsWithCustomInterval(t *testing.T) {
+	options := RefreshIntervalOptions(5, 45)
+
+	var found bool
+	for i, option := range options {
+		if i > 0 && options[i-1].Minutes > option.Minutes {
+			t.Errorf(`The options are not sorted: %v`, options)
+		}
+
+		if option.Minutes == 45 {
+			found = true
+			if option.LabelKey != "form.feed.refresh_interval.custom" {
+				t.Errorf(`Unexpected label for a custom interval: %q`, option.LabelKey)
+			}
+		}
+	}
+
+	if !found {
+		t.Error(`The current interval should be included`)
+	}
+}
+
+func TestRefreshIntervalOptionsKeepCurrentIntervalBelowMinimum(t *testing.T) {
+	options := RefreshIntervalOptions(60, 15)
+
+	if len(options) < 2 || options[1].Minutes != 15 || options[1].LabelKey != "form.feed.refresh_interval.every_15_minutes" {
+		t.Errorf(`The current preset should be kept even if it is below the minimum: %v`, options)
+	}
+}
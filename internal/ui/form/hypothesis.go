@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strings"
+)
+
+// HypothesisAnnotationForm represents an annotation published to Hypothesis.
+type HypothesisAnnotationForm struct {
+	Quote string
+	Text  string
+}
+
+// NewHypothesisAnnotationForm returns a new HypothesisAnnotationForm.
+func NewHypothesisAnnotationForm(r *http.Request) *HypothesisAnnotationForm {
+	return &HypothesisAnnotationForm{
+		Quote: strings.TrimSpace(r.FormValue("quote")),
+		Text:  strings.TrimSpace(r.FormValue("text")),
+	}
+}
@@ -16,6 +16,8 @@ type IntegrationForm struct {
 	PinboardToken                    string
 	PinboardTags                     string
 	PinboardMarkAsUnread             bool
+	PinboardEntryTags                bool
+	PinboardCategoryTag              bool
 	InstapaperEnabled                bool
 	InstapaperUsername               string
 	InstapaperPassword               string
@@ -54,6 +56,11 @@ type IntegrationForm struct {
 	TelegramBotDisableWebPagePreview bool
 	TelegramBotDisableNotification   bool
 	TelegramBotDisableButtons        bool
+	TelegramBotCategoryChatIDs       string
+	TelegramBotDigestMode            string
+	TelegramBotDigestSize            int
+	TelegramBotQuietHoursStart       *int64
+	TelegramBotQuietHoursEnd         *int64
 	LinkAceEnabled                   bool
 	LinkAceURL                       string
 	LinkAceAPIKey                    string
@@ -73,6 +80,7 @@ type IntegrationForm struct {
 	MatrixBotPassword                string
 	MatrixBotURL                     string
 	MatrixBotChatID                  string
+	MatrixBotAccessToken             string
 	AppriseEnabled                   bool
 	AppriseURL                       string
 	AppriseServicesURL               string
@@ -90,6 +98,7 @@ type IntegrationForm struct {
 	ShaarliAPISecret                 string
 	WebhookEnabled                   bool
 	WebhookURL                       string
+	WebhookPayloadTemplate           string
 	WebhookSecret                    string
 	RSSBridgeEnabled                 bool
 	RSSBridgeURL                     string
@@ -110,6 +119,34 @@ type IntegrationForm struct {
 	NtfyUsername                     string
 	NtfyPassword                     string
 	NtfyIconURL                      string
+	EmailDigestEnabled               bool
+	EmailDigestAddress               string
+	EmailDigestFrequency             string
+	EmailDigestHour                  int
+	EmailDigestWeekday               int
+	EmailDigestContent               string
+	MastodonEnabled                  bool
+	MastodonURL                      string
+	MastodonAccessToken              string
+	MastodonVisibility               string
+	BlueskyEnabled                   bool
+	BlueskyPDSURL                    string
+	BlueskyHandle                    string
+	BlueskyAppPassword               string
+	ZoteroEnabled                    bool
+	ZoteroUserID                     string
+	ZoteroAPIKey                     string
+	ZoteroCollectionKey              string
+	HypothesisEnabled                bool
+	HypothesisAPIToken               string
+	HypothesisGroup                  string
+	HypothesisShowAnnotations        bool
+	HomeAssistantEnabled             bool
+	HomeAssistantWebhookURL          string
+	HomeAssistantRules               string
+	HomeAssistantPayloadTemplate     string
+	KindleEnabled                    bool
+	KindleEmail                      string
 }
 
 // Merge copy form values to the model.
@@ -118,6 +155,8 @@ func (i IntegrationForm) Merge(integration *model.Integration) {
 	integration.PinboardToken = i.PinboardToken
 	integration.PinboardTags = i.PinboardTags
 	integration.PinboardMarkAsUnread = i.PinboardMarkAsUnread
+	integration.PinboardEntryTags = i.PinboardEntryTags
+	integration.PinboardCategoryTag = i.PinboardCategoryTag
 	integration.InstapaperEnabled = i.InstapaperEnabled
 	integration.InstapaperUsername = i.InstapaperUsername
 	integration.InstapaperPassword = i.InstapaperPassword
@@ -154,6 +193,11 @@ func (i IntegrationForm) Merge(integration *model.Integration) {
 	integration.TelegramBotDisableWebPagePreview = i.TelegramBotDisableWebPagePreview
 	integration.TelegramBotDisableNotification = i.TelegramBotDisableNotification
 	integration.TelegramBotDisableButtons = i.TelegramBotDisableButtons
+	integration.TelegramBotCategoryChatIDs = i.TelegramBotCategoryChatIDs
+	integration.TelegramBotDigestMode = i.TelegramBotDigestMode
+	integration.TelegramBotDigestSize = i.TelegramBotDigestSize
+	integration.TelegramBotQuietHoursStart = i.TelegramBotQuietHoursStart
+	integration.TelegramBotQuietHoursEnd = i.TelegramBotQuietHoursEnd
 	integration.LinkAceEnabled = i.LinkAceEnabled
 	integration.LinkAceURL = i.LinkAceURL
 	integration.LinkAceAPIKey = i.LinkAceAPIKey
@@ -173,6 +217,7 @@ func (i IntegrationForm) Merge(integration *model.Integration) {
 	integration.MatrixBotPassword = i.MatrixBotPassword
 	integration.MatrixBotURL = i.MatrixBotURL
 	integration.MatrixBotChatID = i.MatrixBotChatID
+	integration.MatrixBotAccessToken = i.MatrixBotAccessToken
 	integration.AppriseEnabled = i.AppriseEnabled
 	integration.AppriseServicesURL = i.AppriseServicesURL
 	integration.AppriseURL = i.AppriseURL
@@ -190,6 +235,7 @@ func (i IntegrationForm) Merge(integration *model.Integration) {
 	integration.ShaarliAPISecret = i.ShaarliAPISecret
 	integration.WebhookEnabled = i.WebhookEnabled
 	integration.WebhookURL = i.WebhookURL
+	integration.WebhookPayloadTemplate = i.WebhookPayloadTemplate
 	integration.RSSBridgeEnabled = i.RSSBridgeEnabled
 	integration.RSSBridgeURL = i.RSSBridgeURL
 	integration.OmnivoreEnabled = i.OmnivoreEnabled
@@ -209,6 +255,34 @@ func (i IntegrationForm) Merge(integration *model.Integration) {
 	integration.NtfyUsername = i.NtfyUsername
 	integration.NtfyPassword = i.NtfyPassword
 	integration.NtfyIconURL = i.NtfyIconURL
+	integration.EmailDigestEnabled = i.EmailDigestEnabled
+	integration.EmailDigestAddress = i.EmailDigestAddress
+	integration.EmailDigestFrequency = i.EmailDigestFrequency
+	integration.EmailDigestHour = i.EmailDigestHour
+	integration.EmailDigestWeekday = i.EmailDigestWeekday
+	integration.EmailDigestContent = i.EmailDigestContent
+	integration.MastodonEnabled = i.MastodonEnabled
+	integration.MastodonURL = i.MastodonURL
+	integration.MastodonAccessToken = i.MastodonAccessToken
+	integration.MastodonVisibility = i.MastodonVisibility
+	integration.BlueskyEnabled = i.BlueskyEnabled
+	integration.BlueskyPDSURL = i.BlueskyPDSURL
+	integration.BlueskyHandle = i.BlueskyHandle
+	integration.BlueskyAppPassword = i.BlueskyAppPassword
+	integration.ZoteroEnabled = i.ZoteroEnabled
+	integration.ZoteroUserID = i.ZoteroUserID
+	integration.ZoteroAPIKey = i.ZoteroAPIKey
+	integration.ZoteroCollectionKey = i.ZoteroCollectionKey
+	integration.HypothesisEnabled = i.HypothesisEnabled
+	integration.HypothesisAPIToken = i.HypothesisAPIToken
+	integration.HypothesisGroup = i.HypothesisGroup
+	integration.HypothesisShowAnnotations = i.HypothesisShowAnnotations
+	integration.HomeAssistantEnabled = i.HomeAssistantEnabled
+	integration.HomeAssistantWebhookURL = i.HomeAssistantWebhookURL
+	integration.HomeAssistantRules = i.HomeAssistantRules
+	integration.HomeAssistantPayloadTemplate = i.HomeAssistantPayloadTemplate
+	integration.KindleEnabled = i.KindleEnabled
+	integration.KindleEmail = i.KindleEmail
 }
 
 // NewIntegrationForm returns a new IntegrationForm.
@@ -218,6 +292,8 @@ func NewIntegrationForm(r *http.Request) *IntegrationForm {
 		PinboardToken:                    r.FormValue("pinboard_token"),
 		PinboardTags:                     r.FormValue("pinboard_tags"),
 		PinboardMarkAsUnread:             r.FormValue("pinboard_mark_as_unread") == "1",
+		PinboardEntryTags:                r.FormValue("pinboard_entry_tags") == "1",
+		PinboardCategoryTag:              r.FormValue("pinboard_category_tag") == "1",
 		InstapaperEnabled:                r.FormValue("instapaper_enabled") == "1",
 		InstapaperUsername:               r.FormValue("instapaper_username"),
 		InstapaperPassword:               r.FormValue("instapaper_password"),
@@ -256,6 +332,11 @@ func NewIntegrationForm(r *http.Request) *IntegrationForm {
 		TelegramBotDisableWebPagePreview: r.FormValue("telegram_bot_disable_web_page_preview") == "1",
 		TelegramBotDisableNotification:   r.FormValue("telegram_bot_disable_notification") == "1",
 		TelegramBotDisableButtons:        r.FormValue("telegram_bot_disable_buttons") == "1",
+		TelegramBotCategoryChatIDs:       r.FormValue("telegram_bot_category_chat_ids"),
+		TelegramBotDigestMode:            r.FormValue("telegram_bot_digest_mode"),
+		TelegramBotDigestSize:            intField(r.FormValue("telegram_bot_digest_size")),
+		TelegramBotQuietHoursStart:       optionalInt64Field(r.FormValue("telegram_bot_quiet_hours_start")),
+		TelegramBotQuietHoursEnd:         optionalInt64Field(r.FormValue("telegram_bot_quiet_hours_end")),
 		LinkAceEnabled:                   r.FormValue("linkace_enabled") == "1",
 		LinkAceURL:                       r.FormValue("linkace_url"),
 		LinkAceAPIKey:                    r.FormValue("linkace_api_key"),
@@ -275,6 +356,7 @@ func NewIntegrationForm(r *http.Request) *IntegrationForm {
 		MatrixBotPassword:                r.FormValue("matrix_bot_password"),
 		MatrixBotURL:                     r.FormValue("matrix_bot_url"),
 		MatrixBotChatID:                  r.FormValue("matrix_bot_chat_id"),
+		MatrixBotAccessToken:             r.FormValue("matrix_bot_access_token"),
 		AppriseEnabled:                   r.FormValue("apprise_enabled") == "1",
 		AppriseURL:                       r.FormValue("apprise_url"),
 		AppriseServicesURL:               r.FormValue("apprise_services_url"),
@@ -292,6 +374,7 @@ func NewIntegrationForm(r *http.Request) *IntegrationForm {
 		ShaarliAPISecret:                 r.FormValue("shaarli_api_secret"),
 		WebhookEnabled:                   r.FormValue("webhook_enabled") == "1",
 		WebhookURL:                       r.FormValue("webhook_url"),
+		WebhookPayloadTemplate:           r.FormValue("webhook_payload_template"),
 		RSSBridgeEnabled:                 r.FormValue("rssbridge_enabled") == "1",
 		RSSBridgeURL:                     r.FormValue("rssbridge_url"),
 		OmnivoreEnabled:                  r.FormValue("omnivore_enabled") == "1",
@@ -311,6 +394,34 @@ func NewIntegrationForm(r *http.Request) *IntegrationForm {
 		NtfyUsername:                     r.FormValue("ntfy_username"),
 		NtfyPassword:                     r.FormValue("ntfy_password"),
 		NtfyIconURL:                      r.FormValue("ntfy_icon_url"),
+		EmailDigestEnabled:               r.FormValue("email_digest_enabled") == "1",
+		EmailDigestAddress:               r.FormValue("email_digest_address"),
+		EmailDigestFrequency:             r.FormValue("email_digest_frequency"),
+		EmailDigestHour:                  intField(r.FormValue("email_digest_hour")),
+		EmailDigestWeekday:               intField(r.FormValue("email_digest_weekday")),
+		EmailDigestContent:               r.FormValue("email_digest_content"),
+		MastodonEnabled:                  r.FormValue("mastodon_enabled") == "1",
+		MastodonURL:                      r.FormValue("mastodon_url"),
+		MastodonAccessToken:              r.FormValue("mastodon_access_token"),
+		MastodonVisibility:               r.FormValue("mastodon_visibility"),
+		BlueskyEnabled:                   r.FormValue("bluesky_enabled") == "1",
+		BlueskyPDSURL:                    r.FormValue("bluesky_pds_url"),
+		BlueskyHandle:                    r.FormValue("bluesky_handle"),
+		BlueskyAppPassword:               r.FormValue("bluesky_app_password"),
+		ZoteroEnabled:                    r.FormValue("zotero_enabled") == "1",
+		ZoteroUserID:                     r.FormValue("zotero_user_id"),
+		ZoteroAPIKey:                     r.FormValue("zotero_api_key"),
+		ZoteroCollectionKey:              r.FormValue("zotero_collection_key"),
+		HypothesisEnabled:                r.FormValue("hypothesis_enabled") == "1",
+		HypothesisAPIToken:               r.FormValue("hypothesis_api_token"),
+		HypothesisGroup:                  r.FormValue("hypothesis_group"),
+		HypothesisShowAnnotations:        r.FormValue("hypothesis_show_annotations") == "1",
+		HomeAssistantEnabled:             r.FormValue("homeassistant_enabled") == "1",
+		HomeAssistantWebhookURL:          r.FormValue("homeassistant_webhook_url"),
+		HomeAssistantRules:               r.FormValue("homeassistant_rules"),
+		HomeAssistantPayloadTemplate:     r.FormValue("homeassistant_payload_template"),
+		KindleEnabled:                    r.FormValue("kindle_enabled") == "1",
+		KindleEmail:                      r.FormValue("kindle_email"),
 	}
 }
 
@@ -321,3 +432,8 @@ func optionalInt64Field(formValue string) *int64 {
 	value, _ := strconv.ParseInt(formValue, 10, 64)
 	return &value
 }
+
+func intField(formValue string) int {
+	value, _ := strconv.Atoi(formValue)
+	return value
+}
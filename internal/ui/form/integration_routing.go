@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"miniflux.app/v2/internal/locale"
+)
+
+// IntegrationRoutingRuleForm represents a routing rule restricting an integration to a category or a feed.
+type IntegrationRoutingRuleForm struct {
+	Destination string
+	Scope       string
+	CategoryID  int64
+	FeedID      int64
+}
+
+// Validate makes sure the form values are valid.
+func (f IntegrationRoutingRuleForm) Validate() *locale.LocalizedError {
+	if f.Destination == "" || (f.CategoryID == 0 && f.FeedID == 0) {
+		return locale.NewLocalizedError("error.fields_mandatory")
+	}
+
+	return nil
+}
+
+// NewIntegrationRoutingRuleForm returns a new IntegrationRoutingRuleForm.
+//
+// The scope is either "category-ID" or "feed-ID".
+func NewIntegrationRoutingRuleForm(r *http.Request) *IntegrationRoutingRuleForm {
+	routingForm := &IntegrationRoutingRuleForm{
+		Destination: r.FormValue("destination"),
+		Scope:       r.FormValue("scope"),
+	}
+
+	kind, value, _ := strings.Cut(routingForm.Scope, "-")
+	id, _ := strconv.ParseInt(value, 10, 64)
+	switch kind {
+	case "category":
+		routingForm.CategoryID = id
+	case "feed":
+		routingForm.FeedID = id
+	}
+
+	return routingForm
+}
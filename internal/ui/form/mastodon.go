@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strings"
+)
+
+// MastodonPostForm represents a post shared to Mastodon.
+type MastodonPostForm struct {
+	Status     string
+	Visibility string
+}
+
+// NewMastodonPostForm returns a new MastodonPostForm.
+func NewMastodonPostForm(r *http.Request) *MastodonPostForm {
+	return &MastodonPostForm{
+		Status:     strings.TrimSpace(r.FormValue("status")),
+		Visibility: r.FormValue("visibility"),
+	}
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"miniflux.app/v2/internal/locale"
+)
+
+// NewsletterForm represents the form used to create a newsletter feed.
+type NewsletterForm struct {
+	Title      string
+	CategoryID int64
+}
+
+// Validate makes sure the form values are valid.
+func (n *NewsletterForm) Validate() *locale.LocalizedError {
+	if n.Title == "" || n.CategoryID == 0 {
+		return locale.NewLocalizedError("error.feed_mandatory_fields")
+	}
+	return nil
+}
+
+// NewNewsletterForm returns a new NewsletterForm.
+func NewNewsletterForm(r *http.Request) *NewsletterForm {
+	categoryID, err := strconv.Atoi(r.FormValue("category_id"))
+	if err != nil {
+		categoryID = 0
+	}
+
+	return &NewsletterForm{
+		Title:      strings.TrimSpace(r.FormValue("title")),
+		CategoryID: int64(categoryID),
+	}
+}
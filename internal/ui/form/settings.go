@@ -6,6 +6,7 @@ package form // import "miniflux.app/v2/internal/ui/form"
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
@@ -48,6 +49,8 @@ type SettingsForm struct {
 	MediaPlaybackRate     float64
 	BlockFilterEntryRules string
 	KeepFilterEntryRules  string
+	IframeAllowedDomains  string
+	RenderMath            bool
 }
 
 // MarkAsReadBehavior returns the MarkReadBehavior from the given MarkReadOnView and MarkReadOnMediaPlayerCompletion values.
@@ -106,6 +109,8 @@ func (s *SettingsForm) Merge(user *model.User) *model.User {
 	user.MediaPlaybackRate = s.MediaPlaybackRate
 	user.BlockFilterEntryRules = s.BlockFilterEntryRules
 	user.KeepFilterEntryRules = s.KeepFilterEntryRules
+	user.IframeAllowedDomains = s.IframeAllowedDomainList()
+	user.RenderMath = s.RenderMath
 
 	MarkReadOnView, MarkReadOnMediaPlayerCompletion := ExtractMarkAsReadBehavior(s.MarkReadBehavior)
 	user.MarkReadOnView = MarkReadOnView
@@ -118,6 +123,17 @@ func (s *SettingsForm) Merge(user *model.User) *model.User {
 	return user
 }
 
+// IframeAllowedDomainList returns the lowercase domains of the comma or space separated field.
+func (s *SettingsForm) IframeAllowedDomainList() []string {
+	domains := []string{}
+	for _, domain := range strings.FieldsFunc(s.IframeAllowedDomains, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\r' || r == '\t'
+	}) {
+		domains = append(domains, strings.ToLower(domain))
+	}
+	return domains
+}
+
 // Validate makes sure the form values are valid.
 func (s *SettingsForm) Validate() *locale.LocalizedError {
 	if s.Username == "" || s.Theme == "" || s.Language == "" || s.Timezone == "" || s.EntryDirection == "" || s.DisplayMode == "" || s.DefaultHomePage == "" {
@@ -189,5 +205,7 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 		MediaPlaybackRate:      mediaPlaybackRate,
 		BlockFilterEntryRules:  r.FormValue("block_filter_entry_rules"),
 		KeepFilterEntryRules:   r.FormValue("keep_filter_entry_rules"),
+		IframeAllowedDomains:   r.FormValue("iframe_allowed_domains"),
+		RenderMath:             r.FormValue("render_math") == "1",
 	}
 }
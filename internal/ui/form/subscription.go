@@ -6,6 +6,7 @@ package form // import "miniflux.app/v2/internal/ui/form"
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/validator"
@@ -14,6 +15,7 @@ import (
 // SubscriptionForm represents the subscription form.
 type SubscriptionForm struct {
 	URL                         string
+	URLs                        []string
 	CategoryID                  int64
 	Crawler                     bool
 	FetchViaProxy               bool
@@ -28,6 +30,12 @@ type SubscriptionForm struct {
 	KeeplistRules               string
 	UrlRewriteRules             string
 	DisableHTTP2                bool
+	RelaxedParsing              bool
+	FetchViaHeadlessBrowser     bool
+	ContentExtractor            string
+	RewriteScript               string
+	UseFeedCategory             bool
+	PageMonitorSelector         string
 }
 
 // Validate makes sure the form values locale.are valid.
@@ -40,6 +48,28 @@ func (s *SubscriptionForm) Validate() *locale.LocalizedError {
 		return locale.NewLocalizedError("error.invalid_feed_url")
 	}
 
+	for _, feedURL := range s.URLs {
+		if !validator.IsValidURL(feedURL) {
+			return locale.NewLocalizedError("error.invalid_feed_url")
+		}
+	}
+
+	if !validator.IsValidScraperRules(s.ScraperRules) {
+		return locale.NewLocalizedError("error.feed_invalid_scraper_rule")
+	}
+
+	if !validator.IsValidRewriteRules(s.RewriteRules) {
+		return locale.NewLocalizedError("error.feed_invalid_rewrite_rule")
+	}
+
+	if !validator.IsValidRewriteScript(s.RewriteScript) {
+		return locale.NewLocalizedError("error.feed_invalid_rewrite_script")
+	}
+
+	if !validator.IsValidContentExtractor(s.ContentExtractor) {
+		return locale.NewLocalizedError("error.feed_invalid_content_extractor")
+	}
+
 	if !validator.IsValidRegex(s.BlocklistRules) {
 		return locale.NewLocalizedError("error.feed_invalid_blocklist_rule")
 	}
@@ -52,6 +82,10 @@ func (s *SubscriptionForm) Validate() *locale.LocalizedError {
 		return locale.NewLocalizedError("error.feed_invalid_urlrewrite_rule")
 	}
 
+	if s.PageMonitorSelector != "" && !validator.IsValidCSSSelector(s.PageMonitorSelector) {
+		return locale.NewLocalizedError("error.feed_invalid_page_monitor_selector")
+	}
+
 	return nil
 }
 
@@ -64,6 +98,7 @@ func NewSubscriptionForm(r *http.Request) *SubscriptionForm {
 
 	return &SubscriptionForm{
 		URL:                         r.FormValue("url"),
+		URLs:                        r.Form["url"],
 		CategoryID:                  int64(categoryID),
 		Crawler:                     r.FormValue("crawler") == "1",
 		AllowSelfSignedCertificates: r.FormValue("allow_self_signed_certificates") == "1",
@@ -78,5 +113,11 @@ func NewSubscriptionForm(r *http.Request) *SubscriptionForm {
 		KeeplistRules:               r.FormValue("keeplist_rules"),
 		UrlRewriteRules:             r.FormValue("urlrewrite_rules"),
 		DisableHTTP2:                r.FormValue("disable_http2") == "1",
+		RelaxedParsing:              r.FormValue("relaxed_parsing") == "1",
+		FetchViaHeadlessBrowser:     r.FormValue("fetch_via_headless_browser") == "1",
+		ContentExtractor:            r.FormValue("content_extractor"),
+		RewriteScript:               r.FormValue("rewrite_script"),
+		UseFeedCategory:             r.FormValue("use_feed_category") == "1",
+		PageMonitorSelector:         strings.TrimSpace(r.FormValue("page_monitor_selector")),
 	}
 }
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strings"
+
+	"miniflux.app/v2/internal/locale"
+)
+
+// TOTPForm represents the two-factor authentication code form.
+type TOTPForm struct {
+	Code string
+}
+
+// Validate makes sure the form values are valid.
+func (t TOTPForm) Validate() *locale.LocalizedError {
+	if t.Code == "" {
+		return locale.NewLocalizedError("error.fields_mandatory")
+	}
+
+	return nil
+}
+
+// NewTOTPForm returns a new TOTPForm.
+func NewTOTPForm(r *http.Request) *TOTPForm {
+	return &TOTPForm{
+		Code: strings.TrimSpace(r.FormValue("code")),
+	}
+}
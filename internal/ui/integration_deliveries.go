@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/timezone"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showIntegrationDeliveriesPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	deliveries, err := h.store.IntegrationDeliveries(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		delivery.NextAttemptAt = timezone.Convert(user.Timezone, delivery.NextAttemptAt)
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("deliveries", deliveries)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("integration_deliveries"))
+}
+
+func (h *handler) retryIntegrationDelivery(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.RetryIntegrationDelivery(request.UserID(r), request.RouteInt64Param(r, "deliveryID")); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "integrationDeliveries"))
+}
+
+func (h *handler) removeIntegrationDelivery(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.RemoveIntegrationDelivery(request.UserID(r), request.RouteInt64Param(r, "deliveryID")); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "integrationDeliveries"))
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showIntegrationRoutingPage(w http.ResponseWriter, r *http.Request) {
+	h.renderIntegrationRoutingPage(w, r, &form.IntegrationRoutingRuleForm{}, "")
+}
+
+func (h *handler) saveIntegrationRoutingRule(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	routingForm := form.NewIntegrationRoutingRuleForm(r)
+
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if validationErr := routingForm.Validate(); validationErr != nil {
+		h.renderIntegrationRoutingPage(w, r, routingForm, validationErr.Translate(user.Language))
+		return
+	}
+
+	if !integration.IsValidDestination(routingForm.Destination) ||
+		(routingForm.CategoryID > 0 && !h.store.CategoryIDExists(userID, routingForm.CategoryID)) ||
+		(routingForm.FeedID > 0 && !h.store.FeedExists(userID, routingForm.FeedID)) {
+		h.renderIntegrationRoutingPage(w, r, routingForm, locale.NewLocalizedError("error.invalid_routing_rule").Translate(user.Language))
+		return
+	}
+
+	rule := &model.IntegrationRoutingRule{
+		UserID:      userID,
+		Destination: routingForm.Destination,
+		CategoryID:  routingForm.CategoryID,
+		FeedID:      routingForm.FeedID,
+	}
+
+	if h.store.IntegrationRoutingRuleExists(rule) {
+		h.renderIntegrationRoutingPage(w, r, routingForm, locale.NewLocalizedError("error.routing_rule_already_exists").Translate(user.Language))
+		return
+	}
+
+	if err := h.store.CreateIntegrationRoutingRule(rule); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "integrationRouting"))
+}
+
+func (h *handler) removeIntegrationRoutingRule(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.RemoveIntegrationRoutingRule(request.UserID(r), request.RouteInt64Param(r, "ruleID")); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "integrationRouting"))
+}
+
+func (h *handler) renderIntegrationRoutingPage(w http.ResponseWriter, r *http.Request, routingForm *form.IntegrationRoutingRuleForm, errorMessage string) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	rules, err := h.store.IntegrationRoutingRules(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feeds, err := h.store.Feeds(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	destinationTitles := make(map[string]string, len(integration.Destinations))
+	for _, destination := range integration.Destinations {
+		destinationTitles[destination.Name] = destination.Title
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", routingForm)
+	view.Set("errorMessage", errorMessage)
+	view.Set("rules", rules)
+	view.Set("destinations", integration.Destinations)
+	view.Set("destinationTitles", destinationTitles)
+	view.Set("categories", categories)
+	view.Set("feeds", feeds)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("integration_routing"))
+}
@@ -32,6 +32,8 @@ func (h *handler) showIntegrationPage(w http.ResponseWriter, r *http.Request) {
 		PinboardToken:                    integration.PinboardToken,
 		PinboardTags:                     integration.PinboardTags,
 		PinboardMarkAsUnread:             integration.PinboardMarkAsUnread,
+		PinboardEntryTags:                integration.PinboardEntryTags,
+		PinboardCategoryTag:              integration.PinboardCategoryTag,
 		InstapaperEnabled:                integration.InstapaperEnabled,
 		InstapaperUsername:               integration.InstapaperUsername,
 		InstapaperPassword:               integration.InstapaperPassword,
@@ -68,6 +70,11 @@ func (h *handler) showIntegrationPage(w http.ResponseWriter, r *http.Request) {
 		TelegramBotDisableWebPagePreview: integration.TelegramBotDisableWebPagePreview,
 		TelegramBotDisableNotification:   integration.TelegramBotDisableNotification,
 		TelegramBotDisableButtons:        integration.TelegramBotDisableButtons,
+		TelegramBotCategoryChatIDs:       integration.TelegramBotCategoryChatIDs,
+		TelegramBotDigestMode:            integration.TelegramBotDigestMode,
+		TelegramBotDigestSize:            integration.TelegramBotDigestSize,
+		TelegramBotQuietHoursStart:       integration.TelegramBotQuietHoursStart,
+		TelegramBotQuietHoursEnd:         integration.TelegramBotQuietHoursEnd,
 		LinkAceEnabled:                   integration.LinkAceEnabled,
 		LinkAceURL:                       integration.LinkAceURL,
 		LinkAceAPIKey:                    integration.LinkAceAPIKey,
@@ -87,6 +94,7 @@ func (h *handler) showIntegrationPage(w http.ResponseWriter, r *http.Request) {
 		MatrixBotPassword:                integration.MatrixBotPassword,
 		MatrixBotURL:                     integration.MatrixBotURL,
 		MatrixBotChatID:                  integration.MatrixBotChatID,
+		MatrixBotAccessToken:             integration.MatrixBotAccessToken,
 		AppriseEnabled:                   integration.AppriseEnabled,
 		AppriseURL:                       integration.AppriseURL,
 		AppriseServicesURL:               integration.AppriseServicesURL,
@@ -104,6 +112,7 @@ func (h *handler) showIntegrationPage(w http.ResponseWriter, r *http.Request) {
 		ShaarliAPISecret:                 integration.ShaarliAPISecret,
 		WebhookEnabled:                   integration.WebhookEnabled,
 		WebhookURL:                       integration.WebhookURL,
+		WebhookPayloadTemplate:           integration.WebhookPayloadTemplate,
 		WebhookSecret:                    integration.WebhookSecret,
 		RSSBridgeEnabled:                 integration.RSSBridgeEnabled,
 		RSSBridgeURL:                     integration.RSSBridgeURL,
@@ -124,6 +133,34 @@ func (h *handler) showIntegrationPage(w http.ResponseWriter, r *http.Request) {
 		NtfyUsername:                     integration.NtfyUsername,
 		NtfyPassword:                     integration.NtfyPassword,
 		NtfyIconURL:                      integration.NtfyIconURL,
+		EmailDigestEnabled:               integration.EmailDigestEnabled,
+		EmailDigestAddress:               integration.EmailDigestAddress,
+		EmailDigestFrequency:             integration.EmailDigestFrequency,
+		EmailDigestHour:                  integration.EmailDigestHour,
+		EmailDigestWeekday:               integration.EmailDigestWeekday,
+		EmailDigestContent:               integration.EmailDigestContent,
+		MastodonEnabled:                  integration.MastodonEnabled,
+		MastodonURL:                      integration.MastodonURL,
+		MastodonAccessToken:              integration.MastodonAccessToken,
+		MastodonVisibility:               integration.MastodonVisibility,
+		BlueskyEnabled:                   integration.BlueskyEnabled,
+		BlueskyPDSURL:                    integration.BlueskyPDSURL,
+		BlueskyHandle:                    integration.BlueskyHandle,
+		BlueskyAppPassword:               integration.BlueskyAppPassword,
+		ZoteroEnabled:                    integration.ZoteroEnabled,
+		ZoteroUserID:                     integration.ZoteroUserID,
+		ZoteroAPIKey:                     integration.ZoteroAPIKey,
+		ZoteroCollectionKey:              integration.ZoteroCollectionKey,
+		HypothesisEnabled:                integration.HypothesisEnabled,
+		HypothesisAPIToken:               integration.HypothesisAPIToken,
+		HypothesisGroup:                  integration.HypothesisGroup,
+		HypothesisShowAnnotations:        integration.HypothesisShowAnnotations,
+		HomeAssistantEnabled:             integration.HomeAssistantEnabled,
+		HomeAssistantWebhookURL:          integration.HomeAssistantWebhookURL,
+		HomeAssistantRules:               integration.HomeAssistantRules,
+		HomeAssistantPayloadTemplate:     integration.HomeAssistantPayloadTemplate,
+		KindleEnabled:                    integration.KindleEnabled,
+		KindleEmail:                      integration.KindleEmail,
 	}
 
 	sess := session.New(h.store, request.SessionID(r))
@@ -134,6 +171,8 @@ func (h *handler) showIntegrationPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	view.Set("hasPocketConsumerKeyConfigured", config.Opts.PocketConsumerKey("") != "")
+	view.Set("hasSMTPConfigured", config.Opts.HasSMTP())
+	view.Set("countFailedDeliveries", h.store.CountFailedIntegrationDeliveries(user.ID))
 
 	html.OK(w, r, view.Render("integrations"))
 }
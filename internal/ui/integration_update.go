@@ -7,12 +7,20 @@ import (
 	"crypto/md5"
 	"fmt"
 	"net/http"
+	"net/mail"
+	"strings"
 
 	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration/homeassistant"
+	"miniflux.app/v2/internal/integration/hypothesis"
+	"miniflux.app/v2/internal/integration/mastodon"
+	"miniflux.app/v2/internal/integration/payloadtemplate"
+	"miniflux.app/v2/internal/integration/telegrambot"
 	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/ui/form"
 	"miniflux.app/v2/internal/ui/session"
 )
@@ -79,6 +87,72 @@ func (h *handler) updateIntegration(w http.ResponseWriter, r *http.Request) {
 		integration.WebhookSecret = ""
 	}
 
+	if !isValidHour(integration.TelegramBotQuietHoursStart) || !isValidHour(integration.TelegramBotQuietHoursEnd) {
+		sess.NewFlashErrorMessage(printer.Print("error.telegram_bot_quiet_hours_range"))
+		html.Redirect(w, r, route.Path(h.router, "integrations"))
+		return
+	}
+
+	if integration.TelegramBotDigestSize <= 0 {
+		integration.TelegramBotDigestSize = telegrambot.DefaultDigestSize
+	}
+
+	if integration.EmailDigestEnabled {
+		if _, err := mail.ParseAddress(integration.EmailDigestAddress); err != nil {
+			sess.NewFlashErrorMessage(printer.Print("error.email_digest_invalid_address"))
+			html.Redirect(w, r, route.Path(h.router, "integrations"))
+			return
+		}
+	}
+
+	if integration.KindleEnabled {
+		if _, err := mail.ParseAddress(integration.KindleEmail); err != nil {
+			sess.NewFlashErrorMessage(printer.Print("error.kindle_invalid_address"))
+			html.Redirect(w, r, route.Path(h.router, "integrations"))
+			return
+		}
+	}
+
+	if integration.HomeAssistantEnabled {
+		if integration.HomeAssistantRules == "" || homeassistant.ValidateRules(integration.HomeAssistantRules) != nil {
+			sess.NewFlashErrorMessage(printer.Print("error.homeassistant_invalid_rules"))
+			html.Redirect(w, r, route.Path(h.router, "integrations"))
+			return
+		}
+	}
+
+	if payloadtemplate.Validate(integration.WebhookPayloadTemplate) != nil || payloadtemplate.Validate(integration.HomeAssistantPayloadTemplate) != nil {
+		sess.NewFlashErrorMessage(printer.Print("error.invalid_payload_template"))
+		html.Redirect(w, r, route.Path(h.router, "integrations"))
+		return
+	}
+
+	if !mastodon.IsValidVisibility(integration.MastodonVisibility) {
+		integration.MastodonVisibility = mastodon.VisibilityPublic
+	}
+
+	if integration.HypothesisGroup = strings.TrimSpace(integration.HypothesisGroup); integration.HypothesisGroup == "" {
+		integration.HypothesisGroup = hypothesis.PublicGroup
+	}
+
+	if integration.EmailDigestHour < 0 || integration.EmailDigestHour > 23 {
+		sess.NewFlashErrorMessage(printer.Print("error.email_digest_hour_range"))
+		html.Redirect(w, r, route.Path(h.router, "integrations"))
+		return
+	}
+
+	if integration.EmailDigestFrequency != model.EmailDigestFrequencyWeekly {
+		integration.EmailDigestFrequency = model.EmailDigestFrequencyDaily
+	}
+
+	if integration.EmailDigestWeekday < 0 || integration.EmailDigestWeekday > 6 {
+		integration.EmailDigestWeekday = 1
+	}
+
+	if integration.EmailDigestContent != model.EmailDigestContentStarred {
+		integration.EmailDigestContent = model.EmailDigestContentUnread
+	}
+
 	err = h.store.UpdateIntegration(integration)
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -88,3 +162,7 @@ func (h *handler) updateIntegration(w http.ResponseWriter, r *http.Request) {
 	sess.NewFlashMessage(printer.Print("alert.prefs_saved"))
 	html.Redirect(w, r, route.Path(h.router, "integrations"))
 }
+
+func isValidHour(hour *int64) bool {
+	return hour == nil || (*hour >= 0 && *hour <= 23)
+}
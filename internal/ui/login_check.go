@@ -4,6 +4,7 @@
 package ui // import "miniflux.app/v2/internal/ui"
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -12,7 +13,9 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/ldap"
 	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/ui/form"
 	"miniflux.app/v2/internal/ui/session"
 	"miniflux.app/v2/internal/ui/view"
@@ -40,7 +43,14 @@ func (h *handler) checkLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.store.CheckPassword(authForm.Username, authForm.Password); err != nil {
+	if h.isLoginLocked(r, authForm.Username) {
+		view.Set("errorMessage", locale.NewLocalizedError("error.too_many_login_attempts").Translate(request.UserLanguage(r)))
+		html.OK(w, r, view.Render("login"))
+		return
+	}
+
+	if err := h.authenticate(authForm.Username, authForm.Password); err != nil {
+		h.recordLoginFailure(r, authForm.Username)
 		slog.Warn("Incorrect username or password",
 			slog.Bool("authentication_failed", true),
 			slog.String("client_ip", clientIP),
@@ -52,22 +62,111 @@ func (h *handler) checkLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sessionToken, userID, err := h.store.CreateUserSessionFromUsername(authForm.Username, r.UserAgent(), clientIP)
+	user, err := h.store.UserByUsername(authForm.Username)
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
-	slog.Info("User authenticated successfully with username/password",
+	if user == nil {
+		html.OK(w, r, view.Render("login"))
+		return
+	}
+
+	if h.store.HasTOTP(user.ID) {
+		slog.Info("Password accepted, waiting for the two-factor authentication code",
+			slog.String("client_ip", clientIP),
+			slog.String("user_agent", r.UserAgent()),
+			slog.Int64("user_id", user.ID),
+			slog.String("username", user.Username),
+		)
+
+		sess.SetTOTPPendingUserID(user.ID)
+		html.Redirect(w, r, route.Path(h.router, "totpLogin"))
+		return
+	}
+
+	h.startUserSession(w, r, sess, user.Username, "User authenticated successfully with username/password")
+}
+
+// authenticate checks the local password, then the LDAP directory for the users created by an LDAP authentication.
+func (h *handler) authenticate(username, password string) error {
+	localErr := h.store.CheckPassword(username, password)
+	if localErr == nil || !config.Opts.HasLDAP() {
+		return localErr
+	}
+
+	user, err := h.store.UserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	if user != nil {
+		authSource, err := h.store.UserAuthSource(user.ID)
+		if err != nil {
+			return err
+		}
+
+		// The accounts created locally, with OAuth2 or SAML cannot be taken over by a directory entry with the same username.
+		if authSource != model.UserAuthSourceLDAP {
+			return localErr
+		}
+	}
+
+	authenticator := ldap.NewAuthenticator(&ldap.Settings{
+		URL:           config.Opts.LDAPURL(),
+		BindDN:        config.Opts.LDAPBindDN(),
+		BindPassword:  config.Opts.LDAPBindPassword(),
+		BaseDN:        config.Opts.LDAPBaseDN(),
+		UserFilter:    config.Opts.LDAPUserFilter(),
+		GroupFilter:   config.Opts.LDAPGroupFilter(),
+		StartTLS:      config.Opts.LDAPStartTLS(),
+		TLSSkipVerify: config.Opts.LDAPTLSSkipVerify(),
+	})
+
+	if err := authenticator.Authenticate(username, password); err != nil {
+		return err
+	}
+
+	if user == nil {
+		if !config.Opts.IsLDAPUserCreationAllowed() {
+			return fmt.Errorf("the user %q does not exist and the creation of LDAP users is not allowed", username)
+		}
+
+		if _, err := h.store.CreateUser(&model.UserCreationRequest{Username: username, AuthSource: model.UserAuthSourceLDAP}); err != nil {
+			return err
+		}
+
+		slog.Info("User created after LDAP authentication",
+			slog.String("username", username),
+		)
+	}
+
+	return nil
+}
+
+func (h *handler) startUserSession(w http.ResponseWriter, r *http.Request, sess *session.Session, username, message string) {
+	clientIP := request.ClientIP(r)
+	sessionToken, userID, err := h.store.CreateUserSessionFromUsername(username, r.UserAgent(), clientIP)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	slog.Info(message,
 		slog.Bool("authentication_successful", true),
 		slog.String("client_ip", clientIP),
 		slog.String("user_agent", r.UserAgent()),
 		slog.Int64("user_id", userID),
-		slog.String("username", authForm.Username),
+		slog.String("username", username),
 	)
 
 	h.store.SetLastLogin(userID)
 
+	if err := h.store.RemoveLoginFailures(username); err != nil {
+		slog.Error("Unable to remove failed logins", slog.Any("error", err))
+	}
+
 	user, err := h.store.UserByID(userID)
 	if err != nil {
 		html.ServerError(w, r, err)
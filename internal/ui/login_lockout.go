@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+)
+
+// isLoginLocked returns true when too many logins failed recently for the username or the client IP.
+func (h *handler) isLoginLocked(r *http.Request, username string) bool {
+	clientIP := request.ClientIP(r)
+	locked, err := h.store.IsLoginLocked(
+		username,
+		clientIP,
+		config.Opts.LoginMaxFailedAttempts(),
+		config.Opts.LoginMaxFailedAttemptsPerIP(),
+		config.Opts.LoginLockoutDuration(),
+	)
+	if err != nil {
+		slog.Error("Unable to check failed logins", slog.Any("error", err))
+		return false
+	}
+
+	if locked {
+		slog.Warn("Login refused because of too many failed attempts",
+			slog.Bool("authentication_failed", true),
+			slog.Bool("login_locked", true),
+			slog.String("client_ip", clientIP),
+			slog.String("user_agent", r.UserAgent()),
+			slog.String("username", username),
+			slog.Duration("lockout_duration", config.Opts.LoginLockoutDuration()),
+		)
+	}
+
+	return locked
+}
+
+func (h *handler) recordLoginFailure(r *http.Request, username string) {
+	if config.Opts.LoginMaxFailedAttempts() <= 0 && config.Opts.LoginMaxFailedAttemptsPerIP() <= 0 {
+		return
+	}
+
+	if err := h.store.CreateLoginFailure(username, request.ClientIP(r)); err != nil {
+		slog.Error("Unable to record failed login", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/totp"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showTOTPLoginPage(w http.ResponseWriter, r *http.Request) {
+	if request.TOTPPendingUserID(r) == 0 {
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", &form.TOTPForm{})
+	html.OK(w, r, view.Render("login_totp"))
+}
+
+func (h *handler) checkTOTPLogin(w http.ResponseWriter, r *http.Request) {
+	userID := request.TOTPPendingUserID(r)
+	if userID == 0 {
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	clientIP := request.ClientIP(r)
+	sess := session.New(h.store, request.SessionID(r))
+	totpForm := form.NewTOTPForm(r)
+
+	// A wrong code sends the user back to the password form to slow down brute force attempts.
+	sess.SetTOTPPendingUserID(0)
+
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	secret, enabled, err := h.store.TOTPSecret(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	valid := false
+	if enabled {
+		valid, err = h.validateTOTPCode(user.ID, secret, totpForm.Code)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	if enabled && !valid {
+		valid, err = h.store.UseTOTPRecoveryCode(user.ID, totp.NormalizeRecoveryCode(totpForm.Code))
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if valid {
+			slog.Warn("Two-factor authentication recovery code used",
+				slog.String("client_ip", clientIP),
+				slog.Int64("user_id", user.ID),
+				slog.String("username", user.Username),
+			)
+		}
+	}
+
+	if !valid {
+		h.recordLoginFailure(r, user.Username)
+		slog.Warn("Invalid two-factor authentication code",
+			slog.Bool("authentication_failed", true),
+			slog.String("client_ip", clientIP),
+			slog.String("user_agent", r.UserAgent()),
+			slog.Int64("user_id", user.ID),
+			slog.String("username", user.Username),
+		)
+
+		view := view.New(h.tpl, r, sess)
+		view.Set("errorMessage", locale.NewLocalizedError("error.invalid_totp_code").Translate(request.UserLanguage(r)))
+		view.Set("form", &form.AuthForm{Username: user.Username})
+		html.OK(w, r, view.Render("login"))
+		return
+	}
+
+	h.startUserSession(w, r, sess, user.Username, "User authenticated successfully with username/password and two-factor authentication")
+}
@@ -8,6 +8,9 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"os"
+	"slices"
+	"strings"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/crypto"
@@ -16,6 +19,7 @@ import (
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/opml"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/ui/session"
 
@@ -51,6 +55,13 @@ func (m *middleware) handleUserSession(next http.Handler) http.Handler {
 				slog.Int64("user_session_id", session.ID),
 			)
 
+			if err := m.store.TouchUserSession(session.Token); err != nil {
+				slog.Warn("Unable to update the last seen date of the user session",
+					slog.Int64("user_session_id", session.ID),
+					slog.Any("error", err),
+				)
+			}
+
 			ctx := r.Context()
 			ctx = context.WithValue(ctx, request.UserIDContextKey, session.UserID)
 			ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
@@ -89,7 +100,8 @@ func (m *middleware) handleAppSession(next http.Handler) http.Handler {
 			http.SetCookie(w, cookie.New(cookie.CookieAppSessionID, session.ID, config.Opts.HTTPS, config.Opts.BasePath()))
 		}
 
-		if r.Method == http.MethodPost {
+		// The identity provider cannot know the CSRF token, SAML responses are protected by their signature.
+		if r.Method == http.MethodPost && mux.CurrentRoute(r).GetName() != "samlACS" {
 			formValue := r.FormValue("csrf")
 			headerValue := r.Header.Get("X-Csrf-Token")
 
@@ -100,7 +112,7 @@ func (m *middleware) handleAppSession(next http.Handler) http.Handler {
 					slog.String("header_csrf", headerValue),
 				)
 
-				if mux.CurrentRoute(r).GetName() == "checkLogin" {
+				if routeName := mux.CurrentRoute(r).GetName(); routeName == "checkLogin" || routeName == "checkTOTPLogin" {
 					html.Redirect(w, r, route.Path(m.router, "login"))
 					return
 				}
@@ -122,10 +134,39 @@ func (m *middleware) handleAppSession(next http.Handler) http.Handler {
 		ctx = context.WithValue(ctx, request.PocketRequestTokenContextKey, session.Data.PocketRequestToken)
 		ctx = context.WithValue(ctx, request.LastForceRefreshContextKey, session.Data.LastForceRefresh)
 		ctx = context.WithValue(ctx, request.WebAuthnDataContextKey, session.Data.WebAuthnSessionData)
+		ctx = context.WithValue(ctx, request.TOTPPendingUserIDContextKey, session.Data.TOTPPendingUserID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// handleTOTPEnrollment forces users with a password to enroll two-factor authentication when it is required.
+func (m *middleware) handleTOTPEnrollment(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !config.Opts.TOTPRequired() || !request.IsAuthenticated(r) || m.isPublicRoute(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch mux.CurrentRoute(r).GetName() {
+		case "totp", "enableTOTP", "logout":
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID := request.UserID(r)
+		if hasPassword, err := m.store.HasPassword(userID); err != nil || !hasPassword || m.store.HasTOTP(userID) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		slog.Debug("Redirecting to the two-factor authentication page because enrollment is required",
+			slog.Any("url", r.RequestURI),
+			slog.Int64("user_id", userID),
+		)
+		html.Redirect(w, r, route.Path(m.router, "totp"))
+	})
+}
+
 func (m *middleware) getAppSessionValueFromCookie(r *http.Request) *model.Session {
 	cookieValue := request.CookieValue(r, cookie.CookieAppSessionID)
 	if cookieValue == "" {
@@ -144,6 +185,52 @@ func (m *middleware) getAppSessionValueFromCookie(r *http.Request) *model.Sessio
 	return session
 }
 
+// handleAdminNetworks denies access to the administration pages from networks not listed in ADMIN_ALLOWED_NETWORKS.
+func (m *middleware) handleAdminNetworks(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP := request.ClientIP(r)
+
+		if !m.isAdminRoute(r) || isAdminNetwork(clientIP) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		slog.Warn("Administration page accessed from a network that is not allowed",
+			slog.String("client_ip", clientIP),
+			slog.String("user_agent", r.UserAgent()),
+			slog.Int64("user_id", request.UserID(r)),
+			slog.Any("url", r.RequestURI),
+		)
+		html.Forbidden(w, r)
+	})
+}
+
+// isAdminNetwork returns true when administrator privileges can be used from the client IP address.
+func isAdminNetwork(clientIP string) bool {
+	networks := config.Opts.AdminAllowedNetworks()
+	return len(networks) == 0 || request.IsIPInNetworks(clientIP, networks)
+}
+
+func (m *middleware) isAdminRoute(r *http.Request) bool {
+	switch mux.CurrentRoute(r).GetName() {
+	case "users",
+		"createUser",
+		"saveUser",
+		"editUser",
+		"updateUser",
+		"removeUser",
+		"unlockUser",
+		"allAPIKeys",
+		"jobQueue",
+		"flushJobQueue",
+		"pauseScheduler",
+		"resumeScheduler":
+		return true
+	default:
+		return false
+	}
+}
+
 func (m *middleware) isPublicRoute(r *http.Request) bool {
 	route := mux.CurrentRoute(r)
 	switch route.GetName() {
@@ -159,10 +246,16 @@ func (m *middleware) isPublicRoute(r *http.Request) bool {
 		"robots",
 		"sharedEntry",
 		"healthcheck",
+		"readiness",
 		"offline",
 		"proxy",
 		"webauthnLoginBegin",
-		"webauthnLoginFinish":
+		"webauthnLoginFinish",
+		"totpLogin",
+		"checkTOTPLogin",
+		"samlMetadata",
+		"samlLogin",
+		"samlACS":
 		return true
 	default:
 		return false
@@ -225,10 +318,31 @@ func (m *middleware) handleAuthProxy(next http.Handler) http.Handler {
 				return
 			}
 
-			if user, err = m.store.CreateUser(&model.UserCreationRequest{Username: username}); err != nil {
+			userCreationRequest := &model.UserCreationRequest{
+				Username: username,
+				IsAdmin:  isAuthProxyRoleSynchronized() && isAuthProxyAdmin(r),
+			}
+			if user, err = m.store.CreateUser(userCreationRequest); err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+
+			m.importAuthProxyOPML(user)
+		} else if isAdmin := isAuthProxyAdmin(r); isAuthProxyRoleSynchronized() && user.IsAdmin != isAdmin {
+			if err := m.store.SetUserAdmin(user.ID, isAdmin); err != nil {
 				html.ServerError(w, r, err)
 				return
 			}
+
+			slog.Info("[AuthProxy] User role synchronized with the proxy groups",
+				slog.Int64("user_id", user.ID),
+				slog.Bool("is_admin", isAdmin),
+			)
+		}
+
+		if err := m.syncAuthProxyProfile(r, user); err != nil {
+			html.ServerError(w, r, err)
+			return
 		}
 
 		sessionToken, _, err := m.store.CreateUserSessionFromUsername(user.Username, r.UserAgent(), clientIP)
@@ -261,3 +375,60 @@ func (m *middleware) handleAuthProxy(next http.Handler) http.Handler {
 		html.Redirect(w, r, route.Path(m.router, user.DefaultHomePage))
 	})
 }
+
+// syncAuthProxyProfile updates the full name and email address of the user from the proxy headers.
+func (m *middleware) syncAuthProxyProfile(r *http.Request, user *model.User) error {
+	fullName, email := user.FullName, user.Email
+	if header := config.Opts.AuthProxyNameHeader(); header != "" {
+		fullName = strings.TrimSpace(r.Header.Get(header))
+	}
+
+	if header := config.Opts.AuthProxyEmailHeader(); header != "" {
+		email = strings.TrimSpace(r.Header.Get(header))
+	}
+
+	if fullName == user.FullName && email == user.Email {
+		return nil
+	}
+
+	return m.store.SetUserProfile(user.ID, fullName, email)
+}
+
+// importAuthProxyOPML subscribes a new user to the feeds of AUTH_PROXY_USER_OPML_FILE.
+func (m *middleware) importAuthProxyOPML(user *model.User) {
+	filename := config.Opts.AuthProxyUserOPMLFile()
+	if filename == "" {
+		return
+	}
+
+	fp, err := os.Open(filename)
+	if err != nil {
+		slog.Error("[AuthProxy] Unable to open the default OPML file",
+			slog.String("filename", filename),
+			slog.Any("error", err),
+		)
+		return
+	}
+	defer fp.Close()
+
+	if err := opml.NewHandler(m.store).Import(user.ID, fp); err != nil {
+		slog.Error("[AuthProxy] Unable to import the default OPML file",
+			slog.Int64("user_id", user.ID),
+			slog.String("filename", filename),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func isAuthProxyRoleSynchronized() bool {
+	return config.Opts.AuthProxyGroupsHeader() != "" && len(config.Opts.AuthProxyAdminGroups()) > 0
+}
+
+func isAuthProxyAdmin(r *http.Request) bool {
+	for _, group := range strings.Split(r.Header.Get(config.Opts.AuthProxyGroupsHeader()), ",") {
+		if slices.Contains(config.Opts.AuthProxyAdminGroups(), strings.TrimSpace(group)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+)
+
+func TestIsAuthProxyAdmin(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("AUTH_PROXY_GROUPS_HEADER", "X-Forwarded-Groups")
+	os.Setenv("AUTH_PROXY_ADMIN_GROUPS", "admins,miniflux-admins")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	scenarios := []struct {
+		name     string
+		groups   *string
+		expected bool
+	}{
+		{"header absent", nil, false},
+		{"header empty", stringPtr(""), false},
+		{"single admin group", stringPtr("admins"), true},
+		{"admin group among others", stringPtr("users, miniflux-admins ,editors"), true},
+		{"group mismatch", stringPtr("users,editors"), false},
+		{"partial group name", stringPtr("admin,miniflux"), false},
+		{"case mismatch", stringPtr("Admins"), false},
+	}
+
+	for _, scenario := range scenarios {
+		r := httptest.NewRequest("GET", "/", nil)
+		if scenario.groups != nil {
+			r.Header.Set("X-Forwarded-Groups", *scenario.groups)
+		}
+
+		if result := isAuthProxyAdmin(r); result != scenario.expected {
+			t.Errorf(`%s: expected %v, got %v`, scenario.name, scenario.expected, result)
+		}
+	}
+
+	if !isAuthProxyRoleSynchronized() {
+		t.Error(`The role should be synchronized when the groups header and the admin groups are defined`)
+	}
+}
+
+func TestIsAuthProxyRoleSynchronizedWithoutAdminGroups(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("AUTH_PROXY_GROUPS_HEADER", "X-Forwarded-Groups")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if isAuthProxyRoleSynchronized() {
+		t.Error(`The role should not be synchronized without admin groups`)
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Forwarded-Groups", "admins")
+	if isAuthProxyAdmin(r) {
+		t.Error(`No group should grant administrator privileges without admin groups`)
+	}
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showCreateNewsletterPage(w http.ResponseWriter, r *http.Request) {
+	if !config.Opts.HasNewsletterService() {
+		html.NotFound(w, r)
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("categories", categories)
+	view.Set("form", &form.NewsletterForm{})
+	view.Set("menu", "feeds")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("create_newsletter"))
+}
+
+func (h *handler) saveNewsletter(w http.ResponseWriter, r *http.Request) {
+	if !config.Opts.HasNewsletterService() {
+		html.NotFound(w, r)
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	newsletterForm := form.NewNewsletterForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("categories", categories)
+	view.Set("form", newsletterForm)
+	view.Set("menu", "feeds")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	if validationErr := newsletterForm.Validate(); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(user.Language))
+		html.OK(w, r, view.Render("create_newsletter"))
+		return
+	}
+
+	feed, localizedError := feedHandler.CreateNewsletterFeed(h.store, user.ID, newsletterForm.CategoryID, newsletterForm.Title)
+	if localizedError != nil {
+		view.Set("errorMessage", localizedError.Translate(user.Language))
+		html.OK(w, r, view.Render("create_newsletter"))
+		return
+	}
+
+	// The address is displayed on the settings page of the feed.
+	html.Redirect(w, r, route.Path(h.router, "editFeed", "feedID", feed.ID))
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/oauth2"
 )
 
@@ -17,5 +18,40 @@ func getOAuth2Manager(ctx context.Context) *oauth2.Manager {
 		config.Opts.OAuth2ClientSecret(),
 		config.Opts.OAuth2RedirectURL(),
 		config.Opts.OIDCDiscoveryEndpoint(),
+		config.Opts.OIDCGroupsClaim(),
 	)
 }
+
+// getOAuth2Provider returns the named provider, only the discovery document of this provider is fetched.
+func getOAuth2Provider(ctx context.Context, name string) (oauth2.Provider, error) {
+	for _, provider := range config.Opts.OIDCProviders() {
+		if provider.ID != name {
+			continue
+		}
+
+		oidcProvider, err := oauth2.NewOidcProvider(
+			ctx,
+			provider.ID,
+			provider.ClientID,
+			provider.ClientSecret,
+			provider.RedirectURL,
+			provider.DiscoveryEndpoint,
+			config.Opts.OIDCGroupsClaim(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return oidcProvider, nil
+	}
+
+	return getOAuth2Manager(ctx).FindProvider(name)
+}
+
+// linkedOIDCProvider returns the name of the OpenID Connect provider the user is bound to, if any.
+func linkedOIDCProvider(user *model.User) string {
+	var providers []string
+	for _, provider := range config.Opts.OIDCProviders() {
+		providers = append(providers, provider.ID)
+	}
+	return oauth2.LinkedOIDCProvider(user.OpenIDConnectID, providers)
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/cookie"
@@ -16,6 +17,7 @@ import (
 	"miniflux.app/v2/internal/http/route"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/oauth2"
 	"miniflux.app/v2/internal/ui/session"
 )
 
@@ -48,7 +50,7 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authProvider, err := getOAuth2Manager(r.Context()).FindProvider(provider)
+	authProvider, err := getOAuth2Provider(r.Context(), provider)
 	if err != nil {
 		slog.Error("Unable to initialize OAuth2 provider",
 			slog.String("provider", provider),
@@ -75,6 +77,13 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// Users are bound to a single OpenID Connect provider.
+		if linkedProvider := linkedOIDCProvider(loggedUser); profile.Key == "openid_connect_id" && linkedProvider != "" && linkedProvider != provider {
+			sess.NewFlashErrorMessage(printer.Print("error.oidc_account_already_linked"))
+			html.Redirect(w, r, route.Path(h.router, "settings"))
+			return
+		}
+
 		if h.store.AnotherUserWithFieldExists(loggedUser.ID, profile.Key, profile.ID) {
 			slog.Error("Oauth2 user cannot be associated because it is already associated with another user",
 				slog.Int64("user_id", loggedUser.ID),
@@ -103,6 +112,26 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if user == nil && profile.Key == "openid_connect_id" && provider == oauth2.DefaultOIDCProvider {
+		user, err = h.userByLegacyOIDCProfileID(profile)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	// Only the groups of the default provider are trusted, the additional providers can't grant roles or categories.
+	hasTrustedGroups := profile.Key == "openid_connect_id" && provider == oauth2.DefaultOIDCProvider
+
+	// Users provisioned by SCIM are bound to their OpenID Connect account on their first login, the external ID must be the subject.
+	if user == nil && profile.Key == "openid_connect_id" && provider == oauth2.DefaultOIDCProvider {
+		user, err = h.linkSCIMProvisionedUser(authProvider, profile)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
 	if user == nil {
 		if !config.Opts.IsOAuth2UserCreationAllowed() {
 			html.Forbidden(w, r)
@@ -114,7 +143,10 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		userCreationRequest := &model.UserCreationRequest{Username: profile.Username}
+		userCreationRequest := &model.UserCreationRequest{
+			Username: profile.Username,
+			IsAdmin:  hasTrustedGroups && profile.IsMemberOf(config.Opts.OIDCAdminGroups()),
+		}
 		authProvider.PopulateUserCreationWithProfileID(userCreationRequest, profile)
 
 		user, err = h.store.CreateUser(userCreationRequest)
@@ -122,6 +154,26 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 			html.ServerError(w, r, err)
 			return
 		}
+
+		if hasTrustedGroups {
+			if err := h.createGroupCategories(user.ID, profile); err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+		}
+	} else if hasTrustedGroups && len(config.Opts.OIDCAdminGroups()) > 0 {
+		isAdmin := profile.IsMemberOf(config.Opts.OIDCAdminGroups())
+		if user.IsAdmin != isAdmin {
+			if err := h.store.SetUserAdmin(user.ID, isAdmin); err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+
+			slog.Info("User role synchronized with the OpenID Connect groups",
+				slog.Int64("user_id", user.ID),
+				slog.Bool("is_admin", isAdmin),
+			)
+		}
 	}
 
 	sessionToken, _, err := h.store.CreateUserSessionFromUsername(user.Username, r.UserAgent(), clientIP)
@@ -151,3 +203,64 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 
 	html.Redirect(w, r, route.Path(h.router, user.DefaultHomePage))
 }
+
+// createGroupCategories creates the categories mapped to the groups of a new user.
+func (h *handler) createGroupCategories(userID int64, profile *oauth2.Profile) error {
+	categories := config.Opts.OIDCGroupCategories()
+	for _, group := range profile.Groups {
+		title, found := categories[group]
+		if !found || h.store.CategoryTitleExists(userID, title) {
+			continue
+		}
+
+		if _, err := h.store.CreateCategory(userID, &model.CategoryRequest{Title: title}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// userByLegacyOIDCProfileID returns the user linked to the default provider before its subjects were prefixed, the stored identifier is prefixed.
+func (h *handler) userByLegacyOIDCProfileID(profile *oauth2.Profile) (*model.User, error) {
+	legacyID, found := oauth2.LegacyOIDCProfileID(profile.ID)
+	if !found {
+		return nil, nil
+	}
+
+	user, err := h.store.UserByField(profile.Key, legacyID)
+	if err != nil || user == nil || linkedOIDCProvider(user) != oauth2.DefaultOIDCProvider {
+		return nil, err
+	}
+
+	user.OpenIDConnectID = profile.ID
+	if err := h.store.UpdateUser(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// linkSCIMProvisionedUser links the user provisioned by SCIM whose external ID is the subject of the default provider.
+func (h *handler) linkSCIMProvisionedUser(authProvider oauth2.Provider, profile *oauth2.Profile) (*model.User, error) {
+	subject, found := strings.CutPrefix(profile.ID, oauth2.DefaultOIDCProvider+":")
+	if !found {
+		return nil, nil
+	}
+
+	user, err := h.store.SCIMProvisionedUserByExternalID(subject)
+	if err != nil || user == nil || user.OpenIDConnectID != "" {
+		return nil, err
+	}
+
+	authProvider.PopulateUserWithProfileID(user, profile)
+	if err := h.store.UpdateUser(user); err != nil {
+		return nil, err
+	}
+
+	slog.Info("User provisioned by SCIM linked to the OpenID Connect account",
+		slog.Int64("user_id", user.ID),
+		slog.String("username", user.Username),
+	)
+
+	return user, nil
+}
@@ -23,7 +23,7 @@ func (h *handler) oauth2Unlink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authProvider, err := getOAuth2Manager(r.Context()).FindProvider(provider)
+	authProvider, err := getOAuth2Provider(r.Context(), provider)
 	if err != nil {
 		slog.Error("Unable to initialize OAuth2 provider",
 			slog.String("provider", provider),
@@ -52,6 +52,11 @@ func (h *handler) oauth2Unlink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if authProvider.GetUserExtraKey() == "openid_connect_id" && linkedOIDCProvider(user) != provider {
+		html.Redirect(w, r, route.Path(h.router, "settings"))
+		return
+	}
+
 	authProvider.UnsetUserProfileID(user)
 	if err := h.store.UpdateUser(user); err != nil {
 		html.ServerError(w, r, err)
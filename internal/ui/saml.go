@@ -0,0 +1,310 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/response/xml"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/saml"
+	"miniflux.app/v2/internal/ui/session"
+)
+
+const maxSAMLMetadataSize = 1 << 20
+
+// The identity provider metadata is loaded on first use and kept for the lifetime of the process.
+var samlProvider struct {
+	sync.Mutex
+	serviceProvider *saml.ServiceProvider
+}
+
+func (h *handler) samlServiceProvider() (*saml.ServiceProvider, error) {
+	samlProvider.Lock()
+	defer samlProvider.Unlock()
+
+	if samlProvider.serviceProvider != nil {
+		return samlProvider.serviceProvider, nil
+	}
+
+	metadata, err := loadSAMLMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	identityProvider, err := saml.ParseIdentityProviderMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	samlProvider.serviceProvider = saml.NewServiceProvider(
+		config.Opts.SAMLSPEntityID(),
+		config.Opts.RootURL()+route.Path(h.router, "samlACS"),
+		identityProvider,
+	)
+
+	return samlProvider.serviceProvider, nil
+}
+
+func loadSAMLMetadata() ([]byte, error) {
+	if config.Opts.SAMLIdPMetadataFile() != "" {
+		return os.ReadFile(config.Opts.SAMLIdPMetadataFile())
+	}
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithUserAgent(config.Opts.HTTPClientUserAgent(), config.Opts.HTTPClientUserAgent())
+
+	response, err := requestBuilder.ExecuteRequest(config.Opts.SAMLIdPMetadataURL())
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the identity provider metadata: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch the identity provider metadata: status=%d", response.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(response.Body, maxSAMLMetadataSize))
+}
+
+func (h *handler) samlMetadata(w http.ResponseWriter, r *http.Request) {
+	if !config.Opts.HasSAML() {
+		html.NotFound(w, r)
+		return
+	}
+
+	serviceProvider, err := h.samlServiceProvider()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	xml.OK(w, r, serviceProvider.Metadata())
+}
+
+func (h *handler) samlLogin(w http.ResponseWriter, r *http.Request) {
+	if !config.Opts.HasSAML() {
+		html.NotFound(w, r)
+		return
+	}
+
+	serviceProvider, err := h.samlServiceProvider()
+	if err != nil {
+		slog.Error("Unable to initialize the SAML service provider", slog.Any("error", err))
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	// Authenticated users start the flow to link their SAML identity.
+	var userID int64
+	if request.IsAuthenticated(r) {
+		userID = request.UserID(r)
+	}
+
+	requestID, relayState := serviceProvider.NewRequest(userID, time.Now())
+
+	// The link request is tied to the user session to prevent another browser from completing it.
+	if userID != 0 {
+		if err := h.store.CreateSAMLLinkRequest(requestID, userID, request.UserSessionToken(r)); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+	redirectURL, err := serviceProvider.AuthnRequestURL(requestID, relayState)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, redirectURL)
+}
+
+func (h *handler) samlACS(w http.ResponseWriter, r *http.Request) {
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	sess := session.New(h.store, request.SessionID(r))
+
+	if !config.Opts.HasSAML() {
+		html.NotFound(w, r)
+		return
+	}
+
+	serviceProvider, err := h.samlServiceProvider()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	now := time.Now()
+	requestID, linkedUserID, err := serviceProvider.ParseRelayState(r.FormValue("RelayState"), now)
+	if err != nil {
+		slog.Warn("Invalid SAML relay state", slog.Any("error", err))
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	assertion, err := serviceProvider.ParseResponse(r.FormValue("SAMLResponse"), requestID, now)
+	if err != nil {
+		slog.Warn("Invalid SAML response",
+			slog.String("client_ip", request.ClientIP(r)),
+			slog.Any("error", err),
+		)
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	// The identity provider posts the response without the session cookies of Miniflux,
+	// the link is completed by the session that started it.
+	if linkedUserID != 0 {
+		found, err := h.store.SetSAMLLinkRequestNameID(requestID, assertion.NameID, saml.RequestLifetime)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if !found {
+			slog.Warn("Unknown SAML link request",
+				slog.String("client_ip", request.ClientIP(r)),
+				slog.Int64("user_id", linkedUserID),
+			)
+			html.Redirect(w, r, route.Path(h.router, "login"))
+			return
+		}
+
+		html.Redirect(w, r, route.Path(h.router, "samlLink", "requestID", requestID))
+		return
+	}
+
+	user, err := h.store.UserBySAMLID(assertion.NameID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		if !config.Opts.IsSAMLUserCreationAllowed() {
+			html.Forbidden(w, r)
+			return
+		}
+
+		username := samlUsername(assertion)
+		if h.store.UserExists(username) {
+			html.BadRequest(w, r, errors.New(printer.Print("error.user_already_exists")))
+			return
+		}
+
+		user, err = h.store.CreateUser(&model.UserCreationRequest{Username: username})
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if err := h.store.SetSAMLID(user.ID, assertion.NameID); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	if attribute := config.Opts.SAMLAdminAttribute(); attribute != "" {
+		if err := h.store.SetUserAdmin(user.ID, assertion.HasAttributeValue(attribute, config.Opts.SAMLAdminValue())); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	h.startUserSession(w, r, sess, user.Username, "User authenticated successfully using SAML")
+}
+
+func (h *handler) samlLink(w http.ResponseWriter, r *http.Request) {
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	sess := session.New(h.store, request.SessionID(r))
+	userID := request.UserID(r)
+
+	nameID, err := h.store.RemoveSAMLLinkRequest(request.RouteStringParam(r, "requestID"), userID, request.UserSessionToken(r), saml.RequestLifetime)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if nameID == "" {
+		slog.Warn("SAML identity cannot be associated because the link was not started by this session",
+			slog.String("client_ip", request.ClientIP(r)),
+			slog.Int64("user_id", userID),
+		)
+		sess.NewFlashErrorMessage(printer.Print("error.invalid_account_link"))
+		html.Redirect(w, r, route.Path(h.router, "settings"))
+		return
+	}
+
+	if h.store.AnotherUserWithFieldExists(userID, "saml_id", nameID) {
+		slog.Error("SAML identity cannot be associated because it is already associated with another user",
+			slog.Int64("user_id", userID),
+			slog.String("saml_name_id", nameID),
+		)
+		sess.NewFlashErrorMessage(printer.Print("error.duplicate_linked_account"))
+		html.Redirect(w, r, route.Path(h.router, "settings"))
+		return
+	}
+
+	if err := h.store.SetSAMLID(userID, nameID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess.NewFlashMessage(printer.Print("alert.account_linked"))
+	html.Redirect(w, r, route.Path(h.router, "settings"))
+}
+
+func (h *handler) samlUnlink(w http.ResponseWriter, r *http.Request) {
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	sess := session.New(h.store, request.SessionID(r))
+
+	hasPassword, err := h.store.HasPassword(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !hasPassword {
+		sess.NewFlashErrorMessage(printer.Print("error.unlink_account_without_password"))
+		html.Redirect(w, r, route.Path(h.router, "settings"))
+		return
+	}
+
+	if err := h.store.SetSAMLID(request.UserID(r), ""); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess.NewFlashMessage(printer.Print("alert.account_unlinked"))
+	html.Redirect(w, r, route.Path(h.router, "settings"))
+}
+
+// samlUsername returns the username of a new user from the configured attributes, or the NameID.
+func samlUsername(assertion *saml.Assertion) string {
+	for _, attribute := range []string{config.Opts.SAMLUsernameAttribute(), config.Opts.SAMLEmailAttribute()} {
+		if attribute == "" {
+			continue
+		}
+
+		if username := assertion.Attribute(attribute); username != "" {
+			return username
+		}
+	}
+
+	return assertion.NameID
+}
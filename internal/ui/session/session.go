@@ -77,3 +77,8 @@ func (s *Session) SetPocketRequestToken(requestToken string) {
 func (s *Session) SetWebAuthnSessionData(sessionData *model.WebAuthnSession) {
 	s.store.UpdateAppSessionObjectField(s.sessionID, "webauthn_session_data", sessionData)
 }
+
+// SetTOTPPendingUserID remembers the user who must still provide a two-factor authentication code.
+func (s *Session) SetTOTPPendingUserID(userID int64) {
+	s.store.UpdateAppSessionObjectField(s.sessionID, "totp_pending_user_id", userID)
+}
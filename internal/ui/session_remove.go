@@ -9,6 +9,8 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/ui/session"
 )
 
 func (h *handler) removeSession(w http.ResponseWriter, r *http.Request) {
@@ -21,3 +23,15 @@ func (h *handler) removeSession(w http.ResponseWriter, r *http.Request) {
 
 	html.Redirect(w, r, route.Path(h.router, "sessions"))
 }
+
+func (h *handler) removeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	count, err := h.store.RemoveOtherUserSessions(request.UserID(r), request.UserSessionToken(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	sess.NewFlashMessage(locale.NewPrinter(request.UserLanguage(r)).Printf("alert.sessions_removed", count))
+	html.Redirect(w, r, route.Path(h.router, "sessions"))
+}
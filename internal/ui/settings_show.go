@@ -5,6 +5,7 @@ package ui // import "miniflux.app/v2/internal/ui"
 
 import (
 	"net/http"
+	"strings"
 
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
@@ -44,6 +45,8 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		MediaPlaybackRate:      user.MediaPlaybackRate,
 		BlockFilterEntryRules:  user.BlockFilterEntryRules,
 		KeepFilterEntryRules:   user.KeepFilterEntryRules,
+		IframeAllowedDomains:   strings.Join(user.IframeAllowedDomains, ", "),
+		RenderMath:             user.RenderMath,
 	}
 
 	timezones, err := h.store.Timezones()
@@ -58,6 +61,12 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	hasPassword, err := h.store.HasPassword(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("form", settingsForm)
@@ -79,6 +88,10 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("categories_sorting_options", model.CategoriesSortingOptions())
 	view.Set("countWebAuthnCerts", h.store.CountWebAuthnCredentialsByUserID(user.ID))
 	view.Set("webAuthnCerts", creds)
+	view.Set("hasPassword", hasPassword)
+	view.Set("totpEnabled", h.store.HasTOTP(user.ID))
+	view.Set("samlLinked", h.store.HasSAMLID(user.ID))
+	view.Set("oidcLinkedProvider", linkedOIDCProvider(user))
 
 	html.OK(w, r, view.Render("settings"))
 }
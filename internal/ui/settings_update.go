@@ -38,6 +38,12 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	hasPassword, err := h.store.HasPassword(loggedUser.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	settingsForm := form.NewSettingsForm(r)
 
 	sess := session.New(h.store, request.SessionID(r))
@@ -54,6 +60,10 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 	view.Set("categories_sorting_options", model.CategoriesSortingOptions())
 	view.Set("countWebAuthnCerts", h.store.CountWebAuthnCredentialsByUserID(loggedUser.ID))
 	view.Set("webAuthnCerts", creds)
+	view.Set("hasPassword", hasPassword)
+	view.Set("totpEnabled", h.store.HasTOTP(loggedUser.ID))
+	view.Set("samlLinked", h.store.HasSAMLID(loggedUser.ID))
+	view.Set("oidcLinkedProvider", linkedOIDCProvider(loggedUser))
 
 	// Sanitize the end of the block & Keep rules
 	cleanEnd := regexp.MustCompile(`(?m)\r\n\s*$`)
@@ -85,6 +95,7 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		MediaPlaybackRate:     model.OptionalNumber(settingsForm.MediaPlaybackRate),
 		BlockFilterEntryRules: model.OptionalString(settingsForm.BlockFilterEntryRules),
 		KeepFilterEntryRules:  model.OptionalString(settingsForm.KeepFilterEntryRules),
+		IframeAllowedDomains:  settingsForm.IframeAllowedDomainList(),
 	}
 
 	if validationErr := validator.ValidateUserModification(h.store, loggedUser.ID, userModificationRequest); validationErr != nil {
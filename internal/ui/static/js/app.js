@@ -364,6 +364,90 @@ function handleFetchOriginalContent() {
     request.execute();
 }
 
+// Switch between the content provided by the feed and the content downloaded from the website.
+function handleToggleEntryContent() {
+    const buttonElement = document.querySelector(":is(a, button)[data-toggle-content]");
+    const contentElement = document.querySelector(".entry-content");
+    const alternativeContentElement = document.querySelector("template#entry-alternative-content");
+    if (!buttonElement || !contentElement || !alternativeContentElement) {
+        return;
+    }
+
+    const currentContent = contentElement.innerHTML;
+    contentElement.innerHTML = ttpolicy.createHTML(alternativeContentElement.innerHTML);
+    alternativeContentElement.innerHTML = ttpolicy.createHTML(currentContent);
+
+    const showingScrapedContent = buttonElement.dataset.value === "scraped";
+    buttonElement.dataset.value = showingScrapedContent ? "feed" : "scraped";
+    buttonElement.querySelector(".icon-label").textContent = showingScrapedContent ? buttonElement.dataset.labelScrapedContent : buttonElement.dataset.labelFeedContent;
+}
+
+// Switch between the original entry and its translation, the translation is requested on the first use.
+function handleTranslateEntry() {
+    const buttonElement = document.querySelector(":is(a, button)[data-translate-entry]");
+    const contentElement = document.querySelector(".entry-content");
+    if (!buttonElement || !contentElement) {
+        return;
+    }
+
+    const translatedContentElement = document.querySelector("template#entry-translated-content");
+    if (translatedContentElement) {
+        toggleEntryTranslation(buttonElement, contentElement, translatedContentElement);
+        return;
+    }
+
+    const labelElement = buttonElement.querySelector(".icon-label");
+    labelElement.textContent = buttonElement.dataset.labelLoading;
+
+    const request = new RequestBuilder(buttonElement.dataset.translateUrl);
+    request.withCallback((response) => {
+        labelElement.textContent = buttonElement.dataset.labelTranslate;
+
+        response.json().then((data) => {
+            if (data.hasOwnProperty("title") && data.hasOwnProperty("content")) {
+                const templateElement = document.createElement("template");
+                templateElement.id = "entry-translated-content";
+                templateElement.dataset.title = data.title;
+                templateElement.innerHTML = ttpolicy.createHTML(data.content);
+                document.body.appendChild(templateElement);
+
+                toggleEntryTranslation(buttonElement, contentElement, templateElement);
+            }
+        });
+    });
+    request.execute();
+}
+
+function toggleEntryTranslation(buttonElement, contentElement, templateElement) {
+    const currentContent = contentElement.innerHTML;
+    contentElement.innerHTML = ttpolicy.createHTML(templateElement.innerHTML);
+    templateElement.innerHTML = ttpolicy.createHTML(currentContent);
+
+    const titleElement = document.querySelector("#page-header-title a");
+    if (titleElement && templateElement.dataset.title) {
+        const currentTitle = titleElement.textContent;
+        titleElement.textContent = templateElement.dataset.title;
+        templateElement.dataset.title = currentTitle;
+    }
+
+    const showingOriginal = buttonElement.dataset.value === "original";
+    buttonElement.dataset.value = showingOriginal ? "translated" : "original";
+    buttonElement.querySelector(".icon-label").textContent = showingOriginal ? buttonElement.dataset.labelOriginalLanguage : buttonElement.dataset.labelTranslate;
+}
+
+// Show or hide the inline footnote of the clicked reference.
+function handleFootnoteReference(event) {
+    const linkElement = event.target.closest(".entry-content .footnote > a, .entry-content .footnote > sup > a");
+    if (!linkElement) {
+        return;
+    }
+
+    event.preventDefault();
+
+    const isExpanded = linkElement.closest(".footnote").classList.toggle("footnote-expanded");
+    linkElement.setAttribute("aria-expanded", isExpanded ? "true" : "false");
+}
+
 function openOriginalLink(openLinkInCurrentTab) {
     const entryLink = document.querySelector(".entry h1 a");
     if (entryLink !== null) {
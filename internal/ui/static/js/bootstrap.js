@@ -85,6 +85,8 @@ document.addEventListener("DOMContentLoaded", () => {
     onClick(":is(a, button)[data-save-entry]", (event) => handleSaveEntry(event.target));
     onClick(":is(a, button)[data-toggle-bookmark]", (event) => handleBookmark(event.target));
     onClick(":is(a, button)[data-fetch-content-entry]", handleFetchOriginalContent);
+    onClick(":is(a, button)[data-toggle-content]", handleToggleEntryContent);
+    onClick(":is(a, button)[data-translate-entry]", handleTranslateEntry);
     onClick(":is(a, button)[data-share-status]", handleShare);
     onClick(":is(a, button)[data-action=markPageAsRead]", (event) => handleConfirmationMessage(event.target, markPageAsRead));
     onClick(":is(a, button)[data-toggle-status]", (event) => handleEntryStatus("next", event.target));
@@ -113,6 +115,9 @@ document.addEventListener("DOMContentLoaded", () => {
         }
     }, true);
 
+    // The entry content is replaced when fetching the original content, the clicks are handled by the document.
+    document.addEventListener("click", handleFootnoteReference);
+
     checkMenuToggleModeByLayout();
     window.addEventListener("resize", checkMenuToggleModeByLayout, { passive: true });
 
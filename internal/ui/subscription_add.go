@@ -37,6 +37,9 @@ func (h *handler) showAddSubscriptionPage(w http.ResponseWriter, r *http.Request
 	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
 	view.Set("form", &form.SubscriptionForm{CategoryID: 0})
 	view.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyConfigured())
+	view.Set("hasHeadlessBrowser", config.Opts.HasHeadlessBrowser())
+	view.Set("hasRewriteScripts", config.Opts.HasRewriteScripts())
+	view.Set("hasNewsletterService", config.Opts.HasNewsletterService())
 
 	html.OK(w, r, view.Render("add_subscription"))
 }
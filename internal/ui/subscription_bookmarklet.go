@@ -52,6 +52,9 @@ func (h *handler) bookmarklet(w http.ResponseWriter, r *http.Request) {
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
 	view.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyConfigured())
+	view.Set("hasHeadlessBrowser", config.Opts.HasHeadlessBrowser())
+	view.Set("hasRewriteScripts", config.Opts.HasRewriteScripts())
+	view.Set("hasNewsletterService", config.Opts.HasNewsletterService())
 
 	html.OK(w, r, view.Render("add_subscription"))
 }
@@ -5,11 +5,13 @@ package ui // import "miniflux.app/v2/internal/ui"
 
 import (
 	"net/http"
+	"strings"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
 	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/ui/form"
@@ -47,27 +49,49 @@ func (h *handler) showChooseSubscriptionPage(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	feed, localizedError := feedHandler.CreateFeed(h.store, user.ID, &model.FeedCreationRequest{
-		CategoryID:                  subscriptionForm.CategoryID,
-		FeedURL:                     subscriptionForm.URL,
-		Crawler:                     subscriptionForm.Crawler,
-		AllowSelfSignedCertificates: subscriptionForm.AllowSelfSignedCertificates,
-		UserAgent:                   subscriptionForm.UserAgent,
-		Cookie:                      subscriptionForm.Cookie,
-		Username:                    subscriptionForm.Username,
-		Password:                    subscriptionForm.Password,
-		ScraperRules:                subscriptionForm.ScraperRules,
-		RewriteRules:                subscriptionForm.RewriteRules,
-		BlocklistRules:              subscriptionForm.BlocklistRules,
-		KeeplistRules:               subscriptionForm.KeeplistRules,
-		UrlRewriteRules:             subscriptionForm.UrlRewriteRules,
-		FetchViaProxy:               subscriptionForm.FetchViaProxy,
-		DisableHTTP2:                subscriptionForm.DisableHTTP2,
-	})
-	if localizedError != nil {
-		view.Set("form", subscriptionForm)
-		view.Set("errorMessage", localizedError.Translate(user.Language))
-		html.OK(w, r, view.Render("add_subscription"))
+	// Several feeds can be chosen at once, they are created in the order of the page.
+	var feed *model.Feed
+	var createdFeedTitles []string
+	for _, feedURL := range subscriptionForm.URLs {
+		var localizedError *locale.LocalizedErrorWrapper
+		feed, localizedError = feedHandler.CreateFeed(h.store, user.ID, &model.FeedCreationRequest{
+			CategoryID:                  subscriptionForm.CategoryID,
+			FeedURL:                     feedURL,
+			Crawler:                     subscriptionForm.Crawler,
+			AllowSelfSignedCertificates: subscriptionForm.AllowSelfSignedCertificates,
+			UserAgent:                   subscriptionForm.UserAgent,
+			Cookie:                      subscriptionForm.Cookie,
+			Username:                    subscriptionForm.Username,
+			Password:                    subscriptionForm.Password,
+			ScraperRules:                subscriptionForm.ScraperRules,
+			RewriteRules:                subscriptionForm.RewriteRules,
+			BlocklistRules:              subscriptionForm.BlocklistRules,
+			KeeplistRules:               subscriptionForm.KeeplistRules,
+			UrlRewriteRules:             subscriptionForm.UrlRewriteRules,
+			FetchViaProxy:               subscriptionForm.FetchViaProxy,
+			DisableHTTP2:                subscriptionForm.DisableHTTP2,
+			RelaxedParsing:              subscriptionForm.RelaxedParsing,
+			FetchViaHeadlessBrowser:     subscriptionForm.FetchViaHeadlessBrowser,
+			ContentExtractor:            subscriptionForm.ContentExtractor,
+			RewriteScript:               subscriptionForm.RewriteScript,
+			UseFeedCategory:             subscriptionForm.UseFeedCategory,
+		})
+		if localizedError != nil {
+			errorMessage := localizedError.Translate(user.Language)
+			if len(createdFeedTitles) > 0 {
+				errorMessage = locale.NewPrinter(user.Language).Printf("error.subscriptions_partially_created", errorMessage, strings.Join(createdFeedTitles, ", "))
+			}
+
+			view.Set("form", subscriptionForm)
+			view.Set("errorMessage", errorMessage)
+			html.OK(w, r, view.Render("add_subscription"))
+			return
+		}
+		createdFeedTitles = append(createdFeedTitles, feed.Title)
+	}
+
+	if len(subscriptionForm.URLs) > 1 {
+		html.Redirect(w, r, route.Path(h.router, "feeds"))
 		return
 	}
 
@@ -12,6 +12,7 @@ import (
 	"miniflux.app/v2/internal/http/route"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/activitypub"
 	"miniflux.app/v2/internal/reader/fetcher"
 	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/reader/subscription"
@@ -42,8 +43,12 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 	v.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	v.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
 	v.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyConfigured())
+	v.Set("hasHeadlessBrowser", config.Opts.HasHeadlessBrowser())
+	v.Set("hasRewriteScripts", config.Opts.HasRewriteScripts())
+	v.Set("hasNewsletterService", config.Opts.HasNewsletterService())
 
 	subscriptionForm := form.NewSubscriptionForm(r)
+	subscriptionForm.URL = activitypub.ProfileURL(subscriptionForm.URL)
 	if validationErr := subscriptionForm.Validate(); validationErr != nil {
 		v.Set("form", subscriptionForm)
 		v.Set("errorMessage", validationErr.Translate(user.Language))
@@ -56,21 +61,19 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 		rssBridgeURL = intg.RSSBridgeURL
 	}
 
-	requestBuilder := fetcher.NewRequestBuilder()
-	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
-	requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
-	requestBuilder.WithUserAgent(subscriptionForm.UserAgent, config.Opts.HTTPClientUserAgent())
-	requestBuilder.WithCookie(subscriptionForm.Cookie)
-	requestBuilder.WithUsernameAndPassword(subscriptionForm.Username, subscriptionForm.Password)
-	requestBuilder.UseProxy(subscriptionForm.FetchViaProxy)
-	requestBuilder.IgnoreTLSErrors(subscriptionForm.AllowSelfSignedCertificates)
-	requestBuilder.DisableHTTP2(subscriptionForm.DisableHTTP2)
+	subscriptionFinder := subscription.NewSubscriptionFinder(newSubscriptionRequestBuilder(subscriptionForm))
 
-	subscriptionFinder := subscription.NewSubscriptionFinder(requestBuilder)
-	subscriptions, localizedError := subscriptionFinder.FindSubscriptions(
-		subscriptionForm.URL,
-		rssBridgeURL,
-	)
+	// A monitored web page doesn't have to be a feed, the discovery is skipped.
+	var subscriptions subscription.Subscriptions
+	var localizedError *locale.LocalizedErrorWrapper
+	if subscriptionForm.PageMonitorSelector != "" {
+		subscriptions = subscription.Subscriptions{subscription.NewSubscription(subscriptionForm.URL, subscriptionForm.URL, "")}
+	} else {
+		subscriptions, localizedError = subscriptionFinder.FindSubscriptions(
+			subscriptionForm.URL,
+			rssBridgeURL,
+		)
+	}
 	if localizedError != nil {
 		v.Set("form", subscriptionForm)
 		v.Set("errorMessage", localizedError.Translate(user.Language))
@@ -105,6 +108,11 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 				UrlRewriteRules:             subscriptionForm.UrlRewriteRules,
 				FetchViaProxy:               subscriptionForm.FetchViaProxy,
 				DisableHTTP2:                subscriptionForm.DisableHTTP2,
+				RelaxedParsing:              subscriptionForm.RelaxedParsing,
+				FetchViaHeadlessBrowser:     subscriptionForm.FetchViaHeadlessBrowser,
+				ContentExtractor:            subscriptionForm.ContentExtractor,
+				RewriteScript:               subscriptionForm.RewriteScript,
+				UseFeedCategory:             subscriptionForm.UseFeedCategory,
 			},
 		})
 		if localizedError != nil {
@@ -132,6 +140,12 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 			UrlRewriteRules:             subscriptionForm.UrlRewriteRules,
 			FetchViaProxy:               subscriptionForm.FetchViaProxy,
 			DisableHTTP2:                subscriptionForm.DisableHTTP2,
+			RelaxedParsing:              subscriptionForm.RelaxedParsing,
+			FetchViaHeadlessBrowser:     subscriptionForm.FetchViaHeadlessBrowser,
+			ContentExtractor:            subscriptionForm.ContentExtractor,
+			RewriteScript:               subscriptionForm.RewriteScript,
+			UseFeedCategory:             subscriptionForm.UseFeedCategory,
+			PageMonitorSelector:         subscriptionForm.PageMonitorSelector,
 		})
 		if localizedError != nil {
 			v.Set("form", subscriptionForm)
@@ -142,6 +156,18 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 
 		html.Redirect(w, r, route.Path(h.router, "feedEntries", "feedID", feed.ID))
 	case n > 1:
+		subscription.PreviewSubscriptions(subscriptions, subscriptionForm.URL, func(withCredentials bool) *fetcher.RequestBuilder {
+			if withCredentials {
+				return newSubscriptionRequestBuilder(subscriptionForm)
+			}
+			return newSubscriptionRequestBuilder(&form.SubscriptionForm{
+				UserAgent:                   subscriptionForm.UserAgent,
+				FetchViaProxy:               subscriptionForm.FetchViaProxy,
+				AllowSelfSignedCertificates: subscriptionForm.AllowSelfSignedCertificates,
+				DisableHTTP2:                subscriptionForm.DisableHTTP2,
+			})
+		})
+
 		view := view.New(h.tpl, r, sess)
 		view.Set("subscriptions", subscriptions)
 		view.Set("form", subscriptionForm)
@@ -154,3 +180,16 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 		html.OK(w, r, view.Render("choose_subscription"))
 	}
 }
+
+func newSubscriptionRequestBuilder(subscriptionForm *form.SubscriptionForm) *fetcher.RequestBuilder {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
+	requestBuilder.WithUserAgent(subscriptionForm.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(subscriptionForm.Cookie)
+	requestBuilder.WithUsernameAndPassword(subscriptionForm.Username, subscriptionForm.Password)
+	requestBuilder.UseProxy(subscriptionForm.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(subscriptionForm.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(subscriptionForm.DisableHTTP2)
+	return requestBuilder
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/qrcode"
+	"miniflux.app/v2/internal/totp"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+const (
+	totpIssuer             = "Miniflux"
+	totpRecoveryCodesCount = 10
+)
+
+func (h *handler) showTOTPPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	h.renderTOTPPage(w, r, user, nil, "")
+}
+
+func (h *handler) enableTOTP(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	secret, enabled, err := h.store.TOTPSecret(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if enabled {
+		html.Redirect(w, r, route.Path(h.router, "totp"))
+		return
+	}
+
+	totpForm := form.NewTOTPForm(r)
+	if secret == "" {
+		h.renderTOTPPage(w, r, user, nil, "error.invalid_totp_code")
+		return
+	}
+
+	valid, err := h.validateTOTPCode(user.ID, secret, totpForm.Code)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !valid {
+		h.renderTOTPPage(w, r, user, nil, "error.invalid_totp_code")
+		return
+	}
+
+	recoveryCodes := totp.GenerateRecoveryCodes(totpRecoveryCodesCount)
+	if err := h.store.EnableTOTP(user.ID, recoveryCodes); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	h.renderTOTPPage(w, r, user, recoveryCodes, "")
+}
+
+func (h *handler) regenerateTOTPRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !h.checkTOTPCode(user.ID, form.NewTOTPForm(r).Code) {
+		h.renderTOTPPage(w, r, user, nil, "error.invalid_totp_code")
+		return
+	}
+
+	recoveryCodes := totp.GenerateRecoveryCodes(totpRecoveryCodesCount)
+	if err := h.store.EnableTOTP(user.ID, recoveryCodes); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	h.renderTOTPPage(w, r, user, recoveryCodes, "")
+}
+
+func (h *handler) disableTOTP(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	totpForm := form.NewTOTPForm(r)
+	if !h.checkTOTPCode(user.ID, totpForm.Code) {
+		valid, err := h.store.UseTOTPRecoveryCode(user.ID, totp.NormalizeRecoveryCode(totpForm.Code))
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if !valid {
+			h.renderTOTPPage(w, r, user, nil, "error.invalid_totp_code")
+			return
+		}
+	}
+
+	if err := h.store.DisableTOTP(user.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	sess.NewFlashMessage(printer.Print("alert.totp_disabled"))
+	html.Redirect(w, r, route.Path(h.router, "settings"))
+}
+
+func (h *handler) checkTOTPCode(userID int64, code string) bool {
+	secret, enabled, err := h.store.TOTPSecret(userID)
+	if err != nil || !enabled {
+		return false
+	}
+
+	valid, err := h.validateTOTPCode(userID, secret, code)
+	return err == nil && valid
+}
+
+// validateTOTPCode returns true if the code is valid and has not been used before.
+// The time step of the accepted code is recorded to prevent its replay.
+func (h *handler) validateTOTPCode(userID int64, secret, code string) (bool, error) {
+	lastCounter, err := h.store.TOTPLastCounter(userID)
+	if err != nil {
+		return false, err
+	}
+
+	counter, valid := totp.Validate(secret, code, time.Now(), lastCounter)
+	if !valid {
+		return false, nil
+	}
+
+	return h.store.SetTOTPLastCounter(userID, counter)
+}
+
+func (h *handler) renderTOTPPage(w http.ResponseWriter, r *http.Request, user *model.User, recoveryCodes []string, errorMessage string) {
+	secret, enabled, err := h.store.TOTPSecret(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+
+	if !enabled {
+		if secret == "" {
+			secret = totp.GenerateSecret()
+			if err := h.store.SetPendingTOTPSecret(user.ID, secret); err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+		}
+
+		code, err := qrcode.Encode([]byte(totp.URI(totpIssuer, user.Username, secret)))
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		view.Set("totpSecret", secret)
+		view.Set("totpQRCode", code.DataURL())
+	}
+
+	if errorMessage != "" {
+		view.Set("errorMessage", locale.NewLocalizedError(errorMessage).Translate(request.UserLanguage(r)))
+	}
+
+	view.Set("form", &form.TOTPForm{})
+	view.Set("totpEnabled", enabled)
+	view.Set("totpRequired", config.Opts.TOTPRequired())
+	view.Set("recoveryCodes", recoveryCodes)
+	view.Set("countRecoveryCodes", h.store.CountTOTPRecoveryCodes(user.ID))
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("totp"))
+}
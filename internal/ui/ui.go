@@ -27,6 +27,8 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter := router.NewRoute().Subrouter()
 	uiRouter.Use(middleware.handleUserSession)
 	uiRouter.Use(middleware.handleAppSession)
+	uiRouter.Use(middleware.handleTOTPEnrollment)
+	uiRouter.Use(middleware.handleAdminNetworks)
 	uiRouter.StrictSlash(true)
 
 	// Static assets.
@@ -41,6 +43,8 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/subscribe", handler.submitSubscription).Name("submitSubscription").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/subscriptions", handler.showChooseSubscriptionPage).Name("chooseSubscription").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/bookmarklet", handler.bookmarklet).Name("bookmarklet").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/newsletter/create", handler.showCreateNewsletterPage).Name("createNewsletter").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/newsletter/save", handler.saveNewsletter).Name("saveNewsletter").Methods(http.MethodPost)
 
 	// Unread page.
 	uiRouter.HandleFunc("/mark-all-as-read", handler.markAllAsRead).Name("markAllAsRead").Methods(http.MethodPost)
@@ -102,12 +106,19 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/entry/save/{entryID}", handler.saveEntry).Name("saveEntry").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/enclosure/{enclosureID}/save-progression", handler.saveEnclosureProgression).Name("saveEnclosureProgression").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/download/{entryID}", handler.fetchContent).Name("fetchContent").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/translate/{entryID}", handler.translateEntry).Name("translateEntry").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", handler.mediaProxy).Name("proxy").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/entry/bookmark/{entryID}", handler.toggleBookmark).Name("toggleBookmark").Methods(http.MethodPost)
 
 	// Share pages.
 	uiRouter.HandleFunc("/entry/share/{entryID}", handler.createSharedEntry).Name("shareEntry").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/entry/unshare/{entryID}", handler.unshareEntry).Name("unshareEntry").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/mastodon/{entryID}", handler.showMastodonSharePage).Name("mastodonShareEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entry/mastodon/{entryID}", handler.shareToMastodon).Name("mastodonPostEntry").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/bluesky/{entryID}", handler.showBlueskySharePage).Name("blueskyShareEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entry/bluesky/{entryID}", handler.shareToBluesky).Name("blueskyPostEntry").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/hypothesis/{entryID}", handler.showHypothesisPage).Name("hypothesisEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entry/hypothesis/{entryID}", handler.annotateWithHypothesis).Name("hypothesisPostEntry").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/share/{shareCode}", handler.sharedEntry).Name("sharedEntry").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/shares", handler.sharedEntries).Name("sharedEntries").Methods(http.MethodGet)
 
@@ -118,12 +129,31 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/users/{userID}/edit", handler.showEditUserPage).Name("editUser").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/users/{userID}/update", handler.updateUser).Name("updateUser").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/users/{userID}/remove", handler.removeUser).Name("removeUser").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/users/{userID}/unlock", handler.unlockUser).Name("unlockUser").Methods(http.MethodPost)
+
+	// Job queue pages.
+	uiRouter.HandleFunc("/jobs", handler.showJobQueuePage).Name("jobQueue").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/jobs/flush", handler.flushJobQueue).Name("flushJobQueue").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/scheduler/pause", handler.pauseScheduler).Name("pauseScheduler").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/scheduler/resume", handler.resumeScheduler).Name("resumeScheduler").Methods(http.MethodPost)
 
 	// Settings pages.
 	uiRouter.HandleFunc("/settings", handler.showSettingsPage).Name("settings").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/settings", handler.updateSettings).Name("updateSettings").Methods(http.MethodPost)
+
+	// Two-factor authentication pages.
+	uiRouter.HandleFunc("/totp", handler.showTOTPPage).Name("totp").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/totp/enable", handler.enableTOTP).Name("enableTOTP").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/totp/disable", handler.disableTOTP).Name("disableTOTP").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/totp/recovery-codes", handler.regenerateTOTPRecoveryCodes).Name("regenerateTOTPRecoveryCodes").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/integrations", handler.showIntegrationPage).Name("integrations").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/integration", handler.updateIntegration).Name("updateIntegration").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/integrations/deliveries", handler.showIntegrationDeliveriesPage).Name("integrationDeliveries").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/integrations/deliveries/{deliveryID}/retry", handler.retryIntegrationDelivery).Name("retryIntegrationDelivery").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/integrations/deliveries/{deliveryID}/remove", handler.removeIntegrationDelivery).Name("removeIntegrationDelivery").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/integrations/routing", handler.showIntegrationRoutingPage).Name("integrationRouting").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/integrations/routing", handler.saveIntegrationRoutingRule).Name("saveIntegrationRoutingRule").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/integrations/routing/{ruleID}/remove", handler.removeIntegrationRoutingRule).Name("removeIntegrationRoutingRule").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/integration/pocket/authorize", handler.pocketAuthorize).Name("pocketAuthorize").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/integration/pocket/callback", handler.pocketCallback).Name("pocketCallback").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/about", handler.showAboutPage).Name("about").Methods(http.MethodGet)
@@ -131,13 +161,21 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	// Session pages.
 	uiRouter.HandleFunc("/sessions", handler.showSessionsPage).Name("sessions").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/sessions/{sessionID}/remove", handler.removeSession).Name("removeSession").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/sessions/remove-others", handler.removeOtherSessions).Name("removeOtherSessions").Methods(http.MethodPost)
 
 	// API Keys pages.
 	uiRouter.HandleFunc("/keys", handler.showAPIKeysPage).Name("apiKeys").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/keys/{keyID}/remove", handler.removeAPIKey).Name("removeAPIKey").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/keys/{keyID}/rotate", handler.rotateAPIKey).Name("rotateAPIKey").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/keys/all", handler.showAllAPIKeysPage).Name("allAPIKeys").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/keys/create", handler.showCreateAPIKeyPage).Name("createAPIKey").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/keys/save", handler.saveAPIKey).Name("saveAPIKey").Methods(http.MethodPost)
 
+	// App passwords pages.
+	uiRouter.HandleFunc("/app-passwords", handler.showAppPasswordsPage).Name("appPasswords").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/app-passwords/save", handler.saveAppPassword).Name("saveAppPassword").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/app-passwords/{appPasswordID}/remove", handler.removeAppPassword).Name("removeAppPassword").Methods(http.MethodPost)
+
 	// OPML pages.
 	uiRouter.HandleFunc("/export", handler.exportFeeds).Name("export").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/import", handler.showImportPage).Name("import").Methods(http.MethodGet)
@@ -149,11 +187,20 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/oauth2/{provider}/redirect", handler.oauth2Redirect).Name("oauth2Redirect").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/oauth2/{provider}/callback", handler.oauth2Callback).Name("oauth2Callback").Methods(http.MethodGet)
 
+	// SAML flow.
+	uiRouter.HandleFunc("/saml/metadata", handler.samlMetadata).Name("samlMetadata").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saml/login", handler.samlLogin).Name("samlLogin").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saml/acs", handler.samlACS).Name("samlACS").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/saml/link/{requestID}", handler.samlLink).Name("samlLink").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saml/unlink", handler.samlUnlink).Name("samlUnlink").Methods(http.MethodGet)
+
 	// Offline page
 	uiRouter.HandleFunc("/offline", handler.showOfflinePage).Name("offline").Methods(http.MethodGet)
 
 	// Authentication pages.
 	uiRouter.HandleFunc("/login", handler.checkLogin).Name("checkLogin").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/login/totp", handler.showTOTPLoginPage).Name("totpLogin").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/login/totp", handler.checkTOTPLogin).Name("checkTOTPLogin").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/logout", handler.logout).Name("logout").Methods(http.MethodGet)
 	uiRouter.Handle("/", middleware.handleAuthProxy(http.HandlerFunc(handler.showLoginPage))).Name("login").Methods(http.MethodGet)
 
@@ -5,7 +5,9 @@ package ui // import "miniflux.app/v2/internal/ui"
 
 import (
 	"net/http"
+	"time"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/ui/session"
@@ -32,9 +34,26 @@ func (h *handler) showUsersPage(w http.ResponseWriter, r *http.Request) {
 
 	users.UseTimezone(user.Timezone)
 
+	lockedUsernames := make(map[string]int)
+	if maxAttempts := config.Opts.LoginMaxFailedAttempts(); maxAttempts > 0 {
+		lockedUsernames, err = h.store.LockedUsernames(time.Now().Add(-config.Opts.LoginLockoutDuration()), maxAttempts)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	disabledUserIDs, err := h.store.DisabledUserIDs()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("users", users)
+	view.Set("lockedUsernames", lockedUsernames)
+	view.Set("disabledUserIDs", disabledUserIDs)
 	view.Set("menu", "settings")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
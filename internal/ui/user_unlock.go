@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+)
+
+func (h *handler) unlockUser(w http.ResponseWriter, r *http.Request) {
+	loggedUser, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !loggedUser.IsAdmin {
+		html.Forbidden(w, r)
+		return
+	}
+
+	selectedUser, err := h.store.UserByID(request.RouteInt64Param(r, "userID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if selectedUser == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveLoginFailures(selectedUser.Username); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	slog.Info("User account unlocked by an administrator",
+		slog.Int64("admin_user_id", loggedUser.ID),
+		slog.Int64("user_id", selectedUser.ID),
+		slog.String("username", selectedUser.Username),
+	)
+
+	html.Redirect(w, r, route.Path(h.router, "users"))
+}
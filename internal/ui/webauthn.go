@@ -6,6 +6,7 @@ package ui // import "miniflux.app/v2/internal/ui"
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -105,6 +106,8 @@ func (h *handler) beginRegistration(w http.ResponseWriter, r *http.Request) {
 			nil,
 		},
 		webauthn.WithExclusions(credsDescriptors),
+		// Discoverable credentials allow logging in without typing the username.
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
 	)
 
 	if err != nil {
@@ -133,6 +136,10 @@ func (h *handler) finishRegistration(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	sessionData := request.WebAuthnSessionData(r)
+	if sessionData == nil || sessionData.SessionData == nil {
+		json.BadRequest(w, r, errors.New("missing webauthn session data"))
+		return
+	}
 	webAuthnUser := WebAuthnUser{user, sessionData.UserID, nil}
 	cred, err := web.FinishRegistration(webAuthnUser, *sessionData.SessionData, r)
 	if err != nil {
@@ -207,6 +214,10 @@ func (h *handler) finishLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	sessionData := request.WebAuthnSessionData(r)
+	if sessionData == nil || sessionData.SessionData == nil {
+		json.BadRequest(w, r, errors.New("missing webauthn session data"))
+		return
+	}
 
 	var user *model.User
 	username := request.QueryStringParam(r, "username", "")
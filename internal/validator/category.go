@@ -32,5 +32,22 @@ func ValidateCategoryModification(store *storage.Storage, userID, categoryID int
 		return locale.NewLocalizedError("error.category_already_exists")
 	}
 
+	if err := validateAutoArchiveRule(request.AutoArchiveAfterDays, request.AutoArchiveStatus); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateAutoArchiveRule checks the rule shared by feeds and categories, the entries can only be marked as read or removed.
+func validateAutoArchiveRule(afterDays *int, status *string) *locale.LocalizedError {
+	if afterDays != nil && *afterDays < 0 {
+		return locale.NewLocalizedError("error.invalid_auto_archive_rule")
+	}
+
+	if status != nil && *status != model.EntryStatusRead && *status != model.EntryStatusRemoved {
+		return locale.NewLocalizedError("error.invalid_auto_archive_rule")
+	}
+
 	return nil
 }
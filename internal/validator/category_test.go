@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidateAutoArchiveRule(t *testing.T) {
+	days := func(value int) *int { return &value }
+	status := func(value string) *string { return &value }
+
+	if err := validateAutoArchiveRule(nil, nil); err != nil {
+		t.Error(`An empty rule should be valid`)
+	}
+
+	if err := validateAutoArchiveRule(days(30), status(model.EntryStatusRead)); err != nil {
+		t.Error(`Marking entries as read should be valid`)
+	}
+
+	if err := validateAutoArchiveRule(days(0), status(model.EntryStatusRemoved)); err != nil {
+		t.Error(`Removing entries should be valid`)
+	}
+
+	if err := validateAutoArchiveRule(days(-1), nil); err == nil {
+		t.Error(`A negative number of days should be invalid`)
+	}
+
+	if err := validateAutoArchiveRule(nil, status(model.EntryStatusUnread)); err == nil {
+		t.Error(`Marking entries as unread should be invalid`)
+	}
+
+	if err := validateAutoArchiveRule(nil, status("")); err == nil {
+		t.Error(`An empty status should be invalid`)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"miniflux.app/v2/internal/model"
 )
 
+const maxKindleEntries = 50
+
 // ValidateEntriesStatusUpdateRequest validates a status update for a list of entries.
 func ValidateEntriesStatusUpdateRequest(request *model.EntriesStatusUpdateRequest) error {
 	if len(request.EntryIDs) == 0 {
@@ -18,6 +20,19 @@ func ValidateEntriesStatusUpdateRequest(request *model.EntriesStatusUpdateReques
 	return ValidateEntryStatus(request.Status)
 }
 
+// ValidateEntriesKindleRequest makes sure the list of entries to send to a Kindle device is valid.
+func ValidateEntriesKindleRequest(request *model.EntriesKindleRequest) error {
+	if len(request.EntryIDs) == 0 {
+		return fmt.Errorf(`the list of entries cannot be empty`)
+	}
+
+	if len(request.EntryIDs) > maxKindleEntries {
+		return fmt.Errorf(`the list of entries cannot contain more than %d entries`, maxKindleEntries)
+	}
+
+	return nil
+}
+
 // ValidateEntryStatus makes sure the entry status is valid.
 func ValidateEntryStatus(status string) error {
 	switch status {
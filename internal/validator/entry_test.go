@@ -34,6 +34,20 @@ func TestValidateEntriesStatusUpdateRequest(t *testing.T) {
 	}
 }
 
+func TestValidateEntriesKindleRequest(t *testing.T) {
+	if err := ValidateEntriesKindleRequest(&model.EntriesKindleRequest{EntryIDs: []int64{123}}); err != nil {
+		t.Error(`A valid request should not be rejected`)
+	}
+
+	if err := ValidateEntriesKindleRequest(&model.EntriesKindleRequest{}); err == nil {
+		t.Error(`An empty list of entries is not valid`)
+	}
+
+	if err := ValidateEntriesKindleRequest(&model.EntriesKindleRequest{EntryIDs: make([]int64, maxKindleEntries+1)}); err == nil {
+		t.Error(`Too many entries should be rejected`)
+	}
+}
+
 func TestValidateEntryStatus(t *testing.T) {
 	for _, status := range []string{model.EntryStatusRead, model.EntryStatusUnread, model.EntryStatusRemoved} {
 		if err := ValidateEntryStatus(status); err != nil {
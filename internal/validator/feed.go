@@ -4,6 +4,10 @@
 package validator // import "miniflux.app/v2/internal/validator"
 
 import (
+	"net/url"
+	"strings"
+
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/storage"
@@ -35,6 +39,26 @@ func ValidateFeedCreation(store *storage.Storage, userID int64, request *model.F
 		return locale.NewLocalizedError("error.feed_invalid_keeplist_rule")
 	}
 
+	if request.PageMonitorSelector != "" && !IsValidCSSSelector(request.PageMonitorSelector) {
+		return locale.NewLocalizedError("error.feed_invalid_page_monitor_selector")
+	}
+
+	if !IsValidScraperRules(request.ScraperRules) {
+		return locale.NewLocalizedError("error.feed_invalid_scraper_rule")
+	}
+
+	if !IsValidRewriteRules(request.RewriteRules) {
+		return locale.NewLocalizedError("error.feed_invalid_rewrite_rule")
+	}
+
+	if !IsValidRewriteScript(request.RewriteScript) {
+		return locale.NewLocalizedError("error.feed_invalid_rewrite_script")
+	}
+
+	if !IsValidContentExtractor(request.ContentExtractor) {
+		return locale.NewLocalizedError("error.feed_invalid_content_extractor")
+	}
+
 	return nil
 }
 
@@ -88,5 +112,101 @@ func ValidateFeedModification(store *storage.Storage, userID, feedID int64, requ
 		}
 	}
 
+	if request.PageMonitorSelector != nil && *request.PageMonitorSelector != "" {
+		if !IsValidCSSSelector(*request.PageMonitorSelector) {
+			return locale.NewLocalizedError("error.feed_invalid_page_monitor_selector")
+		}
+	}
+
+	if request.ScraperRules != nil {
+		if !IsValidScraperRules(*request.ScraperRules) {
+			return locale.NewLocalizedError("error.feed_invalid_scraper_rule")
+		}
+	}
+
+	if request.RewriteRules != nil && !IsValidRewriteRules(*request.RewriteRules) {
+		return locale.NewLocalizedError("error.feed_invalid_rewrite_rule")
+	}
+
+	if request.RewriteScript != nil && !IsValidRewriteScript(*request.RewriteScript) {
+		return locale.NewLocalizedError("error.feed_invalid_rewrite_script")
+	}
+
+	if request.ContentExtractor != nil && !IsValidContentExtractor(*request.ContentExtractor) {
+		return locale.NewLocalizedError("error.feed_invalid_content_extractor")
+	}
+
+	if request.PollingMinInterval != nil && *request.PollingMinInterval < 0 {
+		return locale.NewLocalizedError("error.feed_invalid_polling_interval")
+	}
+
+	if request.PollingMaxInterval != nil && *request.PollingMaxInterval < 0 {
+		return locale.NewLocalizedError("error.feed_invalid_polling_interval")
+	}
+
+	if request.PollingMinInterval != nil && request.PollingMaxInterval != nil && *request.PollingMaxInterval > 0 && *request.PollingMinInterval > *request.PollingMaxInterval {
+		return locale.NewLocalizedError("error.feed_invalid_polling_interval")
+	}
+
+	for _, weekday := range request.PollingWeekdays {
+		if weekday < 1 || weekday > 7 {
+			return locale.NewLocalizedError("error.feed_invalid_polling_window")
+		}
+	}
+
+	if request.PollingWindowStart != nil || request.PollingWindowEnd != nil {
+		start, end := storedPollingWindow(store, userID, feedID, request)
+		if request.PollingWindowStart != nil {
+			start = *request.PollingWindowStart
+		}
+		if request.PollingWindowEnd != nil {
+			end = *request.PollingWindowEnd
+		}
+
+		if (start == "") != (end == "") || !IsValidClock(start) || !IsValidClock(end) {
+			return locale.NewLocalizedError("error.feed_invalid_polling_window")
+		}
+	}
+
+	if request.RefreshInterval != nil && *request.RefreshInterval != 0 {
+		if minInterval := config.Opts.SchedulerFeedRefreshMinInterval(); *request.RefreshInterval < max(minInterval, 1) {
+			return locale.NewLocalizedError("error.feed_refresh_interval_too_short", minInterval)
+		}
+	}
+
+	if err := validateAutoArchiveRule(request.AutoArchiveAfterDays, request.AutoArchiveStatus); err != nil {
+		return err
+	}
+
+	if request.IconURL != nil && *request.IconURL != "" {
+		if !isValidIconURL(*request.IconURL) {
+			return locale.NewLocalizedError("error.invalid_icon_url")
+		}
+	}
+
 	return nil
 }
+
+// storedPollingWindow returns the current polling window of the feed when the request only changes one of its bounds.
+func storedPollingWindow(store *storage.Storage, userID, feedID int64, request *model.FeedModificationRequest) (start, end string) {
+	if request.PollingWindowStart != nil && request.PollingWindowEnd != nil {
+		return "", ""
+	}
+
+	feed, err := store.FeedByID(userID, feedID)
+	if err != nil || feed == nil {
+		return "", ""
+	}
+
+	return feed.PollingWindowStart, feed.PollingWindowEnd
+}
+
+// isValidIconURL accepts absolute HTTP URLs and data URLs of images.
+func isValidIconURL(iconURL string) bool {
+	if strings.HasPrefix(iconURL, "data:") {
+		return strings.HasPrefix(iconURL, "data:image/")
+	}
+
+	parsedURL, err := url.Parse(iconURL)
+	return err == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") && parsedURL.Host != ""
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidateFeedModificationIconURL(t *testing.T) {
+	scenarios := map[string]bool{
+		"":                                   true,
+		"https://example.org/favicon.png":    true,
+		"data:image/png;base64,iVBORw0KGgo=": true,
+		"/favicon.png":                       false,
+		"javascript:alert(1)":                false,
+		"data:text/html,<p>":                 false,
+	}
+
+	for iconURL, expected := range scenarios {
+		request := &model.FeedModificationRequest{IconURL: model.OptionalString(iconURL)}
+		if err := ValidateFeedModification(nil, 1, 1, request); (err == nil) != expected {
+			t.Errorf(`Unexpected validation result for the icon URL %q: %v`, iconURL, err)
+		}
+	}
+}
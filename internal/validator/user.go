@@ -4,6 +4,7 @@
 package validator // import "miniflux.app/v2/internal/validator"
 
 import (
+	"net/url"
 	"slices"
 	"strings"
 
@@ -123,6 +124,12 @@ func ValidateUserModification(store *storage.Storage, userID int64, changes *mod
 		}
 	}
 
+	if changes.IframeAllowedDomains != nil {
+		if err := validateIframeAllowedDomains(changes.IframeAllowedDomains); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -133,6 +140,17 @@ func validateReadingSpeed(readingSpeed int) *locale.LocalizedError {
 	return nil
 }
 
+// validateIframeAllowedDomains makes sure each value is a bare hostname, without scheme, port or path.
+func validateIframeAllowedDomains(domains []string) *locale.LocalizedError {
+	for _, domain := range domains {
+		parsedURL, err := url.Parse("https://" + domain)
+		if err != nil || domain == "" || parsedURL.Hostname() != domain || strings.ContainsAny(domain, " @") || !strings.Contains(domain, ".") {
+			return locale.NewLocalizedError("error.settings_invalid_iframe_domain", domain)
+		}
+	}
+	return nil
+}
+
 func validatePassword(password string) *locale.LocalizedError {
 	if len(password) < 6 {
 		return locale.NewLocalizedError("error.password_min_length")
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidateUserModificationIframeAllowedDomains(t *testing.T) {
+	scenarios := map[string]bool{
+		"peertube.example.org":         true,
+		"bandcamp.com":                 true,
+		"":                             false,
+		"localhost":                    false,
+		"https://peertube.example.org": false,
+		"peertube.example.org/videos":  false,
+		"peertube.example.org:8080":    false,
+		"user@peertube.example.org":    false,
+		"peertube.example.org?embed=1": false,
+		"peertube example.org":         false,
+	}
+
+	for domain, expected := range scenarios {
+		request := &model.UserModificationRequest{IframeAllowedDomains: []string{domain}}
+		if err := ValidateUserModification(nil, 1, request); (err == nil) != expected {
+			t.Errorf(`Unexpected validation result for the domain %q: %v`, domain, err)
+		}
+	}
+}
@@ -7,6 +7,14 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/readability"
+	"miniflux.app/v2/internal/reader/rewrite"
+	"miniflux.app/v2/internal/reader/scraper"
+
+	"github.com/andybalholm/cascadia"
 )
 
 // ValidateRange makes sure the offset/limit values are valid.
@@ -38,8 +46,44 @@ func IsValidRegex(expr string) bool {
 	return err == nil
 }
 
+// IsValidCSSSelector verifies if the CSS selector can be compiled.
+func IsValidCSSSelector(selector string) bool {
+	_, err := cascadia.Compile(selector)
+	return err == nil
+}
+
+// IsValidScraperRules verifies if the scraper rules can be parsed, an empty value uses the default extraction.
+func IsValidScraperRules(rules string) bool {
+	return rules == "" || scraper.ValidateRules(rules) == nil
+}
+
+// IsValidRewriteRules verifies if the patterns of the rewrite rules can be compiled.
+func IsValidRewriteRules(rules string) bool {
+	return rules == "" || rewrite.ValidateRules(rules) == nil
+}
+
+// IsValidRewriteScript verifies if the rewrite scripts are enabled and if the script can be compiled, an empty value disables the script.
+func IsValidRewriteScript(script string) bool {
+	return script == "" || (config.Opts.HasRewriteScripts() && rewrite.ValidateScript(script) == nil)
+}
+
+// IsValidContentExtractor verifies if the content extractor exists, an empty value uses the default extractor.
+func IsValidContentExtractor(name string) bool {
+	return readability.IsValidExtractor(name)
+}
+
 // IsValidURL verifies if the provided value is a valid absolute URL.
 func IsValidURL(absoluteURL string) bool {
 	_, err := url.ParseRequestURI(absoluteURL)
 	return err == nil
 }
+
+// IsValidClock returns true if the value is empty or a time of the day formatted as "HH:MM".
+func IsValidClock(value string) bool {
+	if value == "" {
+		return true
+	}
+
+	_, err := time.Parse("15:04", value)
+	return err == nil && len(value) == 5
+}
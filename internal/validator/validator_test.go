@@ -3,7 +3,12 @@
 
 package validator // import "miniflux.app/v2/internal/validator"
 
-import "testing"
+import (
+	"os"
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+)
 
 func TestIsValidURL(t *testing.T) {
 	scenarios := map[string]bool{
@@ -46,6 +51,118 @@ func TestValidateDirection(t *testing.T) {
 	}
 }
 
+func TestIsValidCSSSelector(t *testing.T) {
+	scenarios := map[string]bool{
+		"div.price":          true,
+		"#main > p, article": true,
+		"div[":               false,
+		"":                   false,
+	}
+
+	for selector, expected := range scenarios {
+		result := IsValidCSSSelector(selector)
+		if result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, selector, result, expected)
+		}
+	}
+}
+
+func TestIsValidScraperRules(t *testing.T) {
+	scenarios := map[string]bool{
+		"":                                  true,
+		"article .content":                  true,
+		`remove(".ads"); select("article")`: true,
+		`select("article") || main`:         true,
+		"div[":                              false,
+		`remove(".ads")`:                    false,
+	}
+
+	for rules, expected := range scenarios {
+		result := IsValidScraperRules(rules)
+		if result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, rules, result, expected)
+		}
+	}
+}
+
+func TestIsValidRewriteRules(t *testing.T) {
+	scenarios := map[string]bool{
+		"":                          true,
+		"add_image_title":           true,
+		`replace("a(.*)b"|"$1")`:    true,
+		`replace("a(.*b"|"$1")`:     false,
+		`replace_title("only-one")`: false,
+	}
+
+	for rules, expected := range scenarios {
+		result := IsValidRewriteRules(rules)
+		if result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, rules, result, expected)
+		}
+	}
+}
+
+func TestIsValidRewriteScript(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("REWRITE_SCRIPTS", "1")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	scenarios := map[string]bool{
+		"":                                  true,
+		"def rewrite(entry):\n    pass\n":   true,
+		"def transform(entry):\n    pass\n": false,
+		"def rewrite(entry)":                false,
+	}
+
+	for script, expected := range scenarios {
+		result := IsValidRewriteScript(script)
+		if result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, script, result, expected)
+		}
+	}
+}
+
+func TestIsValidRewriteScriptWhenDisabled(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !IsValidRewriteScript("") {
+		t.Error(`An empty script should be valid when the rewrite scripts are disabled`)
+	}
+
+	if IsValidRewriteScript("def rewrite(entry):\n    pass\n") {
+		t.Error(`The scripts should be rejected when the rewrite scripts are disabled`)
+	}
+}
+
+func TestIsValidContentExtractor(t *testing.T) {
+	scenarios := map[string]bool{
+		"":            true,
+		"readability": true,
+		"paragraphs":  true,
+		"unknown":     false,
+	}
+
+	for name, expected := range scenarios {
+		result := IsValidContentExtractor(name)
+		if result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, name, result, expected)
+		}
+	}
+}
+
 func TestIsValidRegex(t *testing.T) {
 	scenarios := map[string]bool{
 		"(?i)miniflux": true,
@@ -59,3 +176,21 @@ func TestIsValidRegex(t *testing.T) {
 		}
 	}
 }
+
+func TestIsValidClock(t *testing.T) {
+	scenarios := map[string]bool{
+		"":      true,
+		"00:00": true,
+		"23:59": true,
+		"8:00":  false,
+		"24:00": false,
+		"12:60": false,
+		"noon":  false,
+	}
+
+	for value, expected := range scenarios {
+		if result := IsValidClock(value); result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, value, result, expected)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package websub receives the verification requests and the content sent by the WebSub hubs.
+// See https://www.w3.org/TR/websub/#subscriber.
+package websub // import "miniflux.app/v2/internal/websub"
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/model"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// Serve declares the callback endpoint given to the hubs.
+func Serve(router *mux.Router, store *storage.Storage) {
+	handler := &handler{store}
+
+	router.HandleFunc("/websub/{feedID}", handler.verifyIntent).Methods(http.MethodGet).Name("webSubVerifyIntent")
+	router.HandleFunc("/websub/{feedID}", handler.receiveContent).Methods(http.MethodPost).Name("webSubReceiveContent")
+}
+
+type handler struct {
+	store *storage.Storage
+}
+
+// verifyIntent confirms the subscriptions requested by Miniflux, the hub expects the challenge in the response body.
+func (h *handler) verifyIntent(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+	mode := request.QueryStringParam(r, "hub.mode", "")
+	topicURL := request.QueryStringParam(r, "hub.topic", "")
+
+	subscription, err := h.store.WebSubSubscription(feedID)
+	if err != nil {
+		slog.Error("Unable to fetch WebSub subscription", slog.Int64("feed_id", feedID), slog.Any("error", err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Miniflux never unsubscribes explicitly: the subscriptions of the removed feeds expire with their lease.
+	if subscription == nil || subscription.TopicURL != topicURL {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch mode {
+	case "subscribe":
+		challenge := request.QueryStringParam(r, "hub.challenge", "")
+		if challenge == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		lease := model.WebSubLeaseDuration
+		if leaseSeconds, err := strconv.Atoi(request.QueryStringParam(r, "hub.lease_seconds", "")); err == nil && leaseSeconds > 0 {
+			lease = time.Duration(leaseSeconds) * time.Second
+		}
+
+		if err := h.store.SetWebSubLease(feedID, time.Now().Add(lease)); err != nil {
+			slog.Error("Unable to save WebSub lease", slog.Int64("feed_id", feedID), slog.Any("error", err))
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		slog.Debug("WebSub subscription verified",
+			slog.Int64("feed_id", feedID),
+			slog.String("hub_url", subscription.HubURL),
+			slog.Duration("lease", lease),
+		)
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, challenge)
+	case "denied":
+		// The feed is polled again, the subscription is requested again later.
+		slog.Warn("WebSub subscription denied by the hub",
+			slog.Int64("feed_id", feedID),
+			slog.String("hub_url", subscription.HubURL),
+			slog.String("reason", request.QueryStringParam(r, "hub.reason", "")),
+		)
+
+		if err := h.store.SetWebSubLease(feedID, time.Now()); err != nil {
+			slog.Error("Unable to save WebSub lease", slog.Int64("feed_id", feedID), slog.Any("error", err))
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// receiveContent stores the entries sent by the hub, the content must be signed with the secret of the subscription.
+func (h *handler) receiveContent(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	subscription, err := h.store.WebSubSubscription(feedID)
+	if err != nil {
+		slog.Error("Unable to fetch WebSub subscription", slog.Int64("feed_id", feedID), slog.Any("error", err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// The hub removes the subscriptions of the callbacks returning "410 Gone".
+	if subscription == nil {
+		w.WriteHeader(http.StatusGone)
+		return
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r.Body, config.Opts.HTTPClientMaxBodySize()))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	// The content with an invalid signature must be acknowledged, but ignored.
+	if !isValidSignature(r.Header.Get("X-Hub-Signature"), subscription.Secret, content) {
+		slog.Warn("Ignoring WebSub content with an invalid signature",
+			slog.Int64("feed_id", feedID),
+			slog.String("client_ip", request.ClientIP(r)),
+		)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	go func() {
+		if localizedError := feedHandler.PushFeedContent(h.store, subscription.UserID, feedID, content); localizedError != nil {
+			slog.Warn("Unable to store WebSub content",
+				slog.Int64("user_id", subscription.UserID),
+				slog.Int64("feed_id", feedID),
+				slog.Any("error", localizedError.Error()),
+			)
+		}
+	}()
+
+	w.WriteHeader(http.StatusAccepted)
+}
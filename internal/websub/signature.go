@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package websub // import "miniflux.app/v2/internal/websub"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"strings"
+)
+
+// isValidSignature checks the "X-Hub-Signature" header, made of the hash function and the HMAC of the content.
+// See https://www.w3.org/TR/websub/#signing-content.
+func isValidSignature(header, secret string, content []byte) bool {
+	method, signature, found := strings.Cut(strings.TrimSpace(header), "=")
+	if !found || secret == "" {
+		return false
+	}
+
+	var hashFunc func() hash.Hash
+	switch strings.ToLower(method) {
+	case "sha1":
+		hashFunc = sha1.New
+	case "sha256":
+		hashFunc = sha256.New
+	case "sha384":
+		hashFunc = sha512.New384
+	case "sha512":
+		hashFunc = sha512.New
+	default:
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(hashFunc, []byte(secret))
+	mac.Write(content)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package websub // import "miniflux.app/v2/internal/websub"
+
+import "testing"
+
+func TestIsValidSignature(t *testing.T) {
+	content := []byte("The quick brown fox jumps over the lazy dog")
+
+	scenarios := map[string]bool{
+		"sha1=de7c9b85b8b78aa6bc8a7a36f70a90701c9db4d9":                           true,
+		"sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8": true,
+		"SHA256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8": true,
+		"sha256=0000000000000000000000000000000000000000000000000000000000000000": false,
+		"md5=80070713463e7749b90c2dc24911e275":                                    false,
+		"sha256=not-hexadecimal":                                                  false,
+		"f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8":        false,
+		"": false,
+	}
+
+	for header, expected := range scenarios {
+		if result := isValidSignature(header, "key", content); result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, header, result, expected)
+		}
+	}
+
+	if isValidSignature("sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", "", content) {
+		t.Error(`A subscription without secret should never accept content`)
+	}
+}
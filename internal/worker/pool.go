@@ -4,32 +4,431 @@
 package worker // import "miniflux.app/v2/internal/worker"
 
 import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/urllib"
 )
 
+// maxFailedJobs is the number of failed jobs kept in memory for the status page.
+const maxFailedJobs = 50
+
+// priorityWaitTimeout is the time to wait for an idle worker before refreshing the feed in the caller goroutine.
+const priorityWaitTimeout = 2 * time.Second
+
 // Pool handles a pool of workers.
 type Pool struct {
-	queue chan model.Job
+	store     *storage.Storage
+	nbWorkers int
+	queue     chan task
+	priority  chan task
+	done      chan struct{}
+	stopOnce  sync.Once
+	workers   sync.WaitGroup
+	pending   atomic.Int64
+	paused    atomic.Bool
+	alive     atomic.Int64
+	createdAt time.Time
+
+	// Unix timestamps in nanoseconds of the last refreshes.
+	lastRefreshAt           atomic.Int64
+	lastSuccessfulRefreshAt atomic.Int64
+
+	mu         sync.Mutex
+	flushed    chan struct{}
+	running    map[int]RunningJob
+	failedJobs []FailedJob
+
+	// The number of feeds being refreshed and the jobs waiting for a free slot, by host.
+	hostConcurrency int
+	hostRunning     map[string]int
+	hostWaiting     map[string][]task
+}
+
+// task is a job waiting for a worker.
+// The result channel is only set when the caller waits for the end of the refresh.
+type task struct {
+	ctx          context.Context
+	job          model.Job
+	forceRefresh bool
+	result       chan *locale.LocalizedErrorWrapper
+
+	// host is set when the task holds a slot of the host concurrency limit.
+	host string
+}
+
+// RunningJob is a job being processed by a worker.
+type RunningJob struct {
+	WorkerID  int       `json:"worker_id"`
+	UserID    int64     `json:"user_id"`
+	FeedID    int64     `json:"feed_id"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Duration returns the time elapsed since the worker started the job.
+func (j RunningJob) Duration() time.Duration {
+	return time.Since(j.StartedAt).Truncate(time.Second)
+}
+
+// FailedJob is a job that returned an error.
+type FailedJob struct {
+	UserID   int64         `json:"user_id"`
+	FeedID   int64         `json:"feed_id"`
+	Error    string        `json:"error"`
+	FailedAt time.Time     `json:"failed_at"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Status describes the state of the job queue and of the workers.
+// The queue and the pause are kept in memory, each instance has its own.
+type Status struct {
+	QueueSize               int64        `json:"queue_size"`
+	NbWorkers               int          `json:"nb_workers"`
+	AliveWorkers            int64        `json:"alive_workers"`
+	SchedulerPaused         bool         `json:"scheduler_paused"`
+	StartedAt               time.Time    `json:"started_at"`
+	LastRefreshAt           *time.Time   `json:"last_refresh_at"`
+	LastSuccessfulRefreshAt *time.Time   `json:"last_successful_refresh_at"`
+	RunningJobs             []RunningJob `json:"running_jobs"`
+	RecentFailedJobs        []FailedJob  `json:"recent_failed_jobs"`
 }
 
 // Push send a list of jobs to the queue.
+// Once the pool is shutting down, the remaining jobs are scheduled again for the next run.
 func (p *Pool) Push(jobs model.JobList) {
+	p.push(p.queue, jobs)
+}
+
+// PushPriority send a list of jobs requested by a user, they are processed before the background jobs.
+func (p *Pool) PushPriority(jobs model.JobList) {
+	p.push(p.priority, jobs)
+}
+
+// RefreshFeed refreshes a feed before the background jobs and waits for the result.
+// The feed is refreshed in the caller goroutine when all workers are busy or when the pool is shutting down.
+func (p *Pool) RefreshFeed(ctx context.Context, userID, feedID int64, forceRefresh bool) *locale.LocalizedErrorWrapper {
+	t := task{
+		ctx:          context.WithoutCancel(ctx),
+		job:          model.Job{UserID: userID, FeedID: feedID},
+		forceRefresh: forceRefresh,
+		result:       make(chan *locale.LocalizedErrorWrapper, 1),
+	}
+
+	timer := time.NewTimer(priorityWaitTimeout)
+	defer timer.Stop()
+
+	select {
+	case p.priority <- t:
+	case <-timer.C:
+		return feedHandler.RefreshFeed(ctx, p.store, userID, feedID, forceRefresh)
+	case <-p.done:
+		return feedHandler.RefreshFeed(ctx, p.store, userID, feedID, forceRefresh)
+	case <-ctx.Done():
+		return locale.NewLocalizedErrorWrapper(ctx.Err(), "error.feed_refresh_in_background")
+	}
+
+	select {
+	case localizedError := <-t.result:
+		return localizedError
+	case <-ctx.Done():
+		return locale.NewLocalizedErrorWrapper(ctx.Err(), "error.feed_refresh_in_background")
+	}
+}
+
+func (p *Pool) push(queue chan task, jobs model.JobList) {
+	p.mu.Lock()
+	flushed := p.flushed
+	p.mu.Unlock()
+
+	p.pending.Add(int64(len(jobs)))
+	for i, job := range jobs {
+		select {
+		case queue <- task{ctx: context.Background(), job: job}:
+			p.pending.Add(-1)
+		case <-flushed:
+			p.pending.Add(-int64(len(jobs) - i))
+			return
+		case <-p.done:
+			p.pending.Add(-int64(len(jobs) - i))
+			p.requeue(jobs[i:])
+			return
+		}
+	}
+}
+
+// Flush drops the jobs waiting in the queue, the running jobs are not interrupted.
+// Dropped feeds are refreshed again by the scheduler when their next check date is reached.
+func (p *Pool) Flush() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	close(p.flushed)
+	p.flushed = make(chan struct{})
+	clear(p.hostWaiting)
+}
+
+// PauseScheduler stops the scheduler of this instance from pushing new batches of jobs.
+// The state is not persisted, the scheduler runs again after a restart.
+func (p *Pool) PauseScheduler() {
+	p.paused.Store(true)
+}
+
+// ResumeScheduler allows the scheduler to push new batches of jobs.
+func (p *Pool) ResumeScheduler() {
+	p.paused.Store(false)
+}
+
+// IsSchedulerPaused returns true if the scheduler must skip its next batches.
+func (p *Pool) IsSchedulerPaused() bool {
+	return p.paused.Load()
+}
+
+// Status returns a snapshot of the queue and of the workers.
+func (p *Pool) Status() *Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status := &Status{
+		QueueSize:               p.pending.Load() + int64(p.nbWaitingJobs()),
+		NbWorkers:               p.nbWorkers,
+		AliveWorkers:            p.alive.Load(),
+		SchedulerPaused:         p.paused.Load(),
+		StartedAt:               p.createdAt,
+		LastRefreshAt:           unixNanoToTime(p.lastRefreshAt.Load()),
+		LastSuccessfulRefreshAt: unixNanoToTime(p.lastSuccessfulRefreshAt.Load()),
+		RunningJobs:             make([]RunningJob, 0, len(p.running)),
+		RecentFailedJobs:        make([]FailedJob, 0, len(p.failedJobs)),
+	}
+
+	for _, job := range p.running {
+		status.RunningJobs = append(status.RunningJobs, job)
+	}
+
+	slices.SortFunc(status.RunningJobs, func(a, b RunningJob) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+
+	// Most recent failures first.
+	for i := len(p.failedJobs) - 1; i >= 0; i-- {
+		status.RecentFailedJobs = append(status.RecentFailedJobs, p.failedJobs[i])
+	}
+
+	return status
+}
+
+// Shutdown stops the workers from accepting new jobs and waits for the in-flight jobs until the context is done.
+// The jobs still running after the deadline are scheduled again for the next run.
+func (p *Pool) Shutdown(ctx context.Context) {
+	p.stopOnce.Do(func() { close(p.done) })
+
+	stopped := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		slog.Debug("All workers stopped")
+	case <-ctx.Done():
+		p.mu.Lock()
+		jobs := make(model.JobList, 0, len(p.running))
+		for _, job := range p.running {
+			jobs = append(jobs, model.Job{UserID: job.UserID, FeedID: job.FeedID})
+		}
+		p.mu.Unlock()
+
+		slog.Warn("Workers did not finish before the shutdown deadline",
+			slog.Int("nb_jobs", len(jobs)),
+		)
+		p.requeue(jobs)
+	}
+
+	p.mu.Lock()
+	waitingJobs := make(model.JobList, 0, p.nbWaitingJobs())
+	for _, tasks := range p.hostWaiting {
+		for _, t := range tasks {
+			waitingJobs = append(waitingJobs, t.job)
+		}
+	}
+	clear(p.hostWaiting)
+	p.mu.Unlock()
+
+	p.requeue(waitingJobs)
+}
+
+// acquireHost takes a slot of the host concurrency limit.
+// When the limit is reached, the task is kept aside and false is returned: the worker that releases a slot
+// of this host refreshes it, while the other workers keep refreshing feeds of other hosts.
+// Interactive refreshes are never postponed.
+func (p *Pool) acquireHost(t task) (task, bool) {
+	if p.hostConcurrency <= 0 || t.result != nil {
+		return t, true
+	}
+
+	host := strings.ToLower(urllib.Domain(t.job.FeedURL))
+	if host == "" {
+		return t, true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t.host = host
+	if p.hostRunning[host] >= p.hostConcurrency {
+		p.hostWaiting[host] = append(p.hostWaiting[host], t)
+		return t, false
+	}
+
+	p.hostRunning[host]++
+	return t, true
+}
+
+// releaseHost frees the slot held by the task, or hands it over to the next task waiting for the same host.
+func (p *Pool) releaseHost(t task) (task, bool) {
+	if t.host == "" {
+		return task{}, false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case <-p.done:
+	default:
+		if waiting := p.hostWaiting[t.host]; len(waiting) > 0 {
+			next := waiting[0]
+			if len(waiting) == 1 {
+				delete(p.hostWaiting, t.host)
+			} else {
+				p.hostWaiting[t.host] = waiting[1:]
+			}
+			return next, true
+		}
+	}
+
+	if p.hostRunning[t.host]--; p.hostRunning[t.host] <= 0 {
+		delete(p.hostRunning, t.host)
+	}
+	return task{}, false
+}
+
+func (p *Pool) nbWaitingJobs() int {
+	count := 0
+	for _, tasks := range p.hostWaiting {
+		count += len(tasks)
+	}
+	return count
+}
+
+func (p *Pool) requeue(jobs model.JobList) {
+	if len(jobs) == 0 {
+		return
+	}
+
+	feedIDs := make([]int64, 0, len(jobs))
 	for _, job := range jobs {
-		p.queue <- job
+		feedIDs = append(feedIDs, job.FeedID)
+	}
+
+	if err := p.store.ScheduleFeedsRefresh(feedIDs); err != nil {
+		slog.Error("Unable to requeue jobs",
+			slog.Int("nb_jobs", len(jobs)),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	slog.Info("Jobs requeued for the next run",
+		slog.Int("nb_jobs", len(jobs)),
+	)
+}
+
+func (p *Pool) jobStarted(workerID int, job model.Job) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.running[workerID] = RunningJob{
+		WorkerID:  workerID,
+		UserID:    job.UserID,
+		FeedID:    job.FeedID,
+		StartedAt: time.Now(),
+	}
+}
+
+func (p *Pool) jobFinished(workerID int, errorMessage string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	job, found := p.running[workerID]
+	if !found {
+		return
+	}
+	delete(p.running, workerID)
+
+	now := time.Now().UnixNano()
+	p.lastRefreshAt.Store(now)
+	if errorMessage == "" {
+		p.lastSuccessfulRefreshAt.Store(now)
+	}
+
+	if errorMessage != "" {
+		if len(p.failedJobs) == maxFailedJobs {
+			p.failedJobs = p.failedJobs[1:]
+		}
+		p.failedJobs = append(p.failedJobs, FailedJob{
+			UserID:   job.UserID,
+			FeedID:   job.FeedID,
+			Error:    errorMessage,
+			FailedAt: time.Now(),
+			Duration: time.Since(job.StartedAt).Truncate(time.Millisecond),
+		})
 	}
 }
 
 // NewPool creates a pool of background workers.
-func NewPool(store *storage.Storage, nbWorkers int) *Pool {
+// The host concurrency limits the number of feeds of the same host refreshed at the same time, 0 means unlimited.
+func NewPool(store *storage.Storage, nbWorkers, hostConcurrency int) *Pool {
 	workerPool := &Pool{
-		queue: make(chan model.Job),
+		store:           store,
+		nbWorkers:       nbWorkers,
+		hostConcurrency: hostConcurrency,
+		hostRunning:     make(map[string]int),
+		hostWaiting:     make(map[string][]task),
+		queue:           make(chan task),
+		priority:        make(chan task),
+		done:            make(chan struct{}),
+		flushed:         make(chan struct{}),
+		running:         make(map[int]RunningJob, nbWorkers),
+		createdAt:       time.Now(),
 	}
 
 	for i := range nbWorkers {
 		worker := &Worker{id: i, store: store}
-		go worker.Run(workerPool.queue)
+		workerPool.workers.Add(1)
+		workerPool.alive.Add(1)
+		go func() {
+			defer workerPool.alive.Add(-1)
+			defer workerPool.workers.Done()
+			worker.Run(workerPool)
+		}()
 	}
 
 	return workerPool
 }
+
+func unixNanoToTime(timestamp int64) *time.Time {
+	if timestamp == 0 {
+		return nil
+	}
+
+	t := time.Unix(0, timestamp)
+	return &t
+}
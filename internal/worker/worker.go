@@ -9,7 +9,6 @@ import (
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/metric"
-	"miniflux.app/v2/internal/model"
 	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/storage"
 )
@@ -20,37 +19,91 @@ type Worker struct {
 	store *storage.Storage
 }
 
-// Run wait for a job and refresh the given feed.
-func (w *Worker) Run(c <-chan model.Job) {
-	slog.Debug("Worker started",
+// Run wait for a job and refresh the given feed until the pool is shut down.
+func (w *Worker) Run(pool *Pool) {
+	logger := slog.With(
+		slog.String("component", "worker"),
 		slog.Int("worker_id", w.id),
 	)
 
+	logger.Debug("Worker started")
+
 	for {
-		job := <-c
-		slog.Debug("Job received by worker",
-			slog.Int("worker_id", w.id),
-			slog.Int64("user_id", job.UserID),
-			slog.Int64("feed_id", job.FeedID),
-		)
+		t, ok := w.nextTask(pool)
+		if !ok {
+			logger.Debug("Worker stopped")
+			return
+		}
 
-		startTime := time.Now()
-		localizedError := feedHandler.RefreshFeed(w.store, job.UserID, job.FeedID, false)
+		// The job waits until another worker finishes a feed of the same host.
+		if t, ok = pool.acquireHost(t); !ok {
+			logger.Debug("Job postponed because of the host concurrency limit",
+				slog.Int64("user_id", t.job.UserID),
+				slog.Int64("feed_id", t.job.FeedID),
+				slog.String("host", t.host),
+			)
+			continue
+		}
 
-		if config.Opts.HasMetricsCollector() {
-			status := "success"
-			if localizedError != nil {
-				status = "error"
-			}
-			metric.BackgroundFeedRefreshDuration.WithLabelValues(status).Observe(time.Since(startTime).Seconds())
+		for ok {
+			w.process(logger, pool, t)
+			t, ok = pool.releaseHost(t)
 		}
+	}
+}
+
+func (w *Worker) process(logger *slog.Logger, pool *Pool, t task) {
+	job := t.job
+
+	logger.Debug("Job received by worker",
+		slog.Int64("user_id", job.UserID),
+		slog.Int64("feed_id", job.FeedID),
+	)
+
+	startTime := time.Now()
+	pool.jobStarted(w.id, job)
+	localizedError := feedHandler.RefreshFeed(t.ctx, w.store, job.UserID, job.FeedID, t.forceRefresh)
+	if t.result != nil {
+		t.result <- localizedError
+	}
 
+	if localizedError != nil {
+		pool.jobFinished(w.id, localizedError.Error().Error())
+	} else {
+		pool.jobFinished(w.id, "")
+	}
+
+	if config.Opts.HasMetricsCollector() {
+		status := "success"
 		if localizedError != nil {
-			slog.Warn("Unable to refresh a feed",
-				slog.Int64("user_id", job.UserID),
-				slog.Int64("feed_id", job.FeedID),
-				slog.Any("error", localizedError.Error()),
-			)
+			status = "error"
 		}
+		metric.BackgroundFeedRefreshDuration.WithLabelValues(status).Observe(time.Since(startTime).Seconds())
+	}
+
+	if localizedError != nil {
+		logger.Warn("Unable to refresh a feed",
+			slog.Int64("user_id", job.UserID),
+			slog.Int64("feed_id", job.FeedID),
+			slog.Any("error", localizedError.Error()),
+		)
+	}
+}
+
+// nextTask returns the next task, the interactive refreshes are always processed before the background jobs.
+func (w *Worker) nextTask(pool *Pool) (task, bool) {
+	select {
+	case t := <-pool.priority:
+		return t, true
+	default:
+	}
+
+	select {
+	case <-pool.done:
+		return task{}, false
+	case t := <-pool.priority:
+		return t, true
+	case t := <-pool.queue:
+		return t, true
 	}
 }
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker // import "miniflux.app/v2/internal/worker"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+func TestNextTaskPrefersPriorityQueue(t *testing.T) {
+	pool := &Pool{
+		queue:    make(chan task, 1),
+		priority: make(chan task, 1),
+		done:     make(chan struct{}),
+	}
+
+	pool.queue <- task{job: model.Job{FeedID: 1}}
+	pool.priority <- task{job: model.Job{FeedID: 2}}
+
+	w := &Worker{}
+	for _, expectedFeedID := range []int64{2, 1} {
+		next, ok := w.nextTask(pool)
+		if !ok {
+			t.Fatal(`A task should be returned`)
+		}
+
+		if next.job.FeedID != expectedFeedID {
+			t.Errorf(`Unexpected feed #%d instead of #%d`, next.job.FeedID, expectedFeedID)
+		}
+	}
+}
+
+func TestNextTaskWhenPoolIsStopped(t *testing.T) {
+	pool := &Pool{
+		queue:    make(chan task),
+		priority: make(chan task),
+		done:     make(chan struct{}),
+	}
+	close(pool.done)
+
+	if _, ok := (&Worker{}).nextTask(pool); ok {
+		t.Error(`No task should be returned once the pool is stopped`)
+	}
+}
+
+func TestHostConcurrencyLimit(t *testing.T) {
+	pool := &Pool{
+		done:            make(chan struct{}),
+		hostConcurrency: 1,
+		hostRunning:     make(map[string]int),
+		hostWaiting:     make(map[string][]task),
+	}
+
+	first, ok := pool.acquireHost(task{job: model.Job{FeedID: 1, FeedURL: "https://example.org/feed1.xml"}})
+	if !ok {
+		t.Fatal(`The first feed of the host should be refreshed`)
+	}
+
+	if _, ok := pool.acquireHost(task{job: model.Job{FeedID: 2, FeedURL: "https://EXAMPLE.org/feed2.xml"}}); ok {
+		t.Fatal(`The second feed of the host should wait`)
+	}
+
+	if _, ok := pool.acquireHost(task{job: model.Job{FeedID: 3, FeedURL: "https://example.com/feed.xml"}}); !ok {
+		t.Fatal(`A feed of another host should be refreshed`)
+	}
+
+	if _, ok := pool.acquireHost(task{job: model.Job{FeedID: 4, FeedURL: "https://example.org/feed4.xml"}, result: make(chan *locale.LocalizedErrorWrapper, 1)}); !ok {
+		t.Fatal(`An interactive refresh should never wait`)
+	}
+
+	next, ok := pool.releaseHost(first)
+	if !ok || next.job.FeedID != 2 {
+		t.Fatalf(`The waiting feed should be handed over, got %v`, next.job)
+	}
+
+	if _, ok := pool.releaseHost(next); ok {
+		t.Fatal(`No other feed should be waiting`)
+	}
+
+	if count := pool.hostRunning["example.org"]; count != 0 {
+		t.Errorf(`The slots of the host should be released, got %d`, count)
+	}
+}
+
+func TestHostConcurrencyUnlimited(t *testing.T) {
+	pool := &Pool{done: make(chan struct{})}
+
+	for range 3 {
+		if _, ok := pool.acquireHost(task{job: model.Job{FeedURL: "https://example.org/feed.xml"}}); !ok {
+			t.Fatal(`Feeds should never wait without limit`)
+		}
+	}
+}